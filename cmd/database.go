@@ -1,12 +1,15 @@
 package cmd
 
 import (
+	"time"
+
 	"github.com/urfave/cli/v3"
 
 	"github.com/mayswind/ezbookkeeping/pkg/core"
 	"github.com/mayswind/ezbookkeeping/pkg/datastore"
 	"github.com/mayswind/ezbookkeeping/pkg/log"
 	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/services"
 )
 
 // Database represents the database command
@@ -77,6 +80,14 @@ func updateAllDatabaseTablesStructure(c *core.CliContext) error {
 
 	log.BootInfof(c, "[database.updateAllDatabaseTablesStructure] token record table maintained successfully")
 
+	err = datastore.Container.UserStore.SyncStructs(new(models.HistoricalExchangeRate))
+
+	if err != nil {
+		return err
+	}
+
+	log.BootInfof(c, "[database.updateAllDatabaseTablesStructure] historical exchange rate table maintained successfully")
+
 	err = datastore.Container.UserDataStore.SyncStructs(new(models.Account))
 
 	if err != nil {
@@ -125,6 +136,14 @@ func updateAllDatabaseTablesStructure(c *core.CliContext) error {
 
 	log.BootInfof(c, "[database.updateAllDatabaseTablesStructure] transaction template table maintained successfully")
 
+	updatedCount, err := services.TransactionTemplates.InitializeScheduledTemplatesNextRunTime(c, time.Now().Unix())
+
+	if err != nil {
+		return err
+	}
+
+	log.BootInfof(c, "[database.updateAllDatabaseTablesStructure] next run time of %d scheduled transaction templates initialized successfully", updatedCount)
+
 	err = datastore.Container.UserDataStore.SyncStructs(new(models.TransactionPictureInfo))
 
 	if err != nil {
@@ -133,5 +152,77 @@ func updateAllDatabaseTablesStructure(c *core.CliContext) error {
 
 	log.BootInfof(c, "[database.updateAllDatabaseTablesStructure] transaction picture table maintained successfully")
 
+	err = datastore.Container.UserDataStore.SyncStructs(new(models.AccountBalanceAssertion))
+
+	if err != nil {
+		return err
+	}
+
+	log.BootInfof(c, "[database.updateAllDatabaseTablesStructure] account balance assertion table maintained successfully")
+
+	err = datastore.Container.UserDataStore.SyncStructs(new(models.TransactionRule))
+
+	if err != nil {
+		return err
+	}
+
+	log.BootInfof(c, "[database.updateAllDatabaseTablesStructure] transaction rule table maintained successfully")
+
+	err = datastore.Container.UserDataStore.SyncStructs(new(models.Webhook))
+
+	if err != nil {
+		return err
+	}
+
+	log.BootInfof(c, "[database.updateAllDatabaseTablesStructure] webhook table maintained successfully")
+
+	err = datastore.Container.UserDataStore.SyncStructs(new(models.WebhookDelivery))
+
+	if err != nil {
+		return err
+	}
+
+	log.BootInfof(c, "[database.updateAllDatabaseTablesStructure] webhook delivery table maintained successfully")
+
+	err = datastore.Container.UserDataStore.SyncStructs(new(models.TransactionAnomaly))
+
+	if err != nil {
+		return err
+	}
+
+	log.BootInfof(c, "[database.updateAllDatabaseTablesStructure] transaction anomaly table maintained successfully")
+
+	err = datastore.Container.UserDataStore.SyncStructs(new(models.TransactionAnomalyDetectionTask))
+
+	if err != nil {
+		return err
+	}
+
+	log.BootInfof(c, "[database.updateAllDatabaseTablesStructure] transaction anomaly detection task table maintained successfully")
+
+	err = datastore.Container.UserDataStore.SyncStructs(new(models.ScheduledReport))
+
+	if err != nil {
+		return err
+	}
+
+	log.BootInfof(c, "[database.updateAllDatabaseTablesStructure] scheduled report table maintained successfully")
+
+	err = datastore.Container.UserDataStore.SyncStructs(new(models.UserExchangeRate))
+
+	if err != nil {
+		return err
+	}
+
+	log.BootInfof(c, "[database.updateAllDatabaseTablesStructure] user exchange rate table maintained successfully")
+
+	err = datastore.Container.UserDataStore.SyncStructs(new(models.UserCustomCurrency))
+
+	if err != nil {
+		return err
+	}
+
+	log.BootInfof(c, "[database.updateAllDatabaseTablesStructure] user custom currency table maintained successfully")
+
 	return nil
 }
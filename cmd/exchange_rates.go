@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"github.com/urfave/cli/v3"
+
+	clis "github.com/mayswind/ezbookkeeping/pkg/cli"
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
+)
+
+// ExchangeRates represents the exchange rates command
+var ExchangeRates = &cli.Command{
+	Name:  "exchangerates",
+	Usage: "ezBookkeeping exchange rates maintenance",
+	Commands: []*cli.Command{
+		{
+			Name:   "backfill",
+			Usage:  "Request historical exchange rates from the current exchange rates data source and save them (only European Central Bank, Bank of Canada and Norges Bank are supported)",
+			Action: bindAction(backfillHistoricalExchangeRates),
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     "start-date",
+					Required: true,
+					Usage:    "Start date (YYYY-MM-DD)",
+				},
+				&cli.StringFlag{
+					Name:     "end-date",
+					Required: false,
+					Usage:    "End date (YYYY-MM-DD), default is today",
+				},
+			},
+		},
+	},
+}
+
+func backfillHistoricalExchangeRates(c *core.CliContext) error {
+	_, err := initializeSystem(c)
+
+	if err != nil {
+		return err
+	}
+
+	startDate := c.String("start-date")
+	endDate := c.String("end-date")
+	savedDays, err := clis.ExchangeRates.BackfillHistoricalExchangeRates(c, startDate, endDate)
+
+	if err != nil {
+		log.CliErrorf(c, "[exchange_rates.backfillHistoricalExchangeRates] error occurs when backfilling historical exchange rates")
+		return err
+	}
+
+	log.CliInfof(c, "[exchange_rates.backfillHistoricalExchangeRates] historical exchange rates of %d days have been saved", savedDays)
+
+	return nil
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/urfave/cli/v3"
 
@@ -301,6 +302,44 @@ var UserData = &cli.Command{
 				},
 			},
 		},
+		{
+			Name:   "account-balance-assertion-check",
+			Usage:  "Check whether all user account balance assertions are passed",
+			Action: bindAction(checkUserAccountBalanceAssertions),
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     "username",
+					Aliases:  []string{"n"},
+					Required: true,
+					Usage:    "Specific user name",
+				},
+			},
+		},
+		{
+			Name:   "transaction-scheduled-backfill",
+			Usage:  "Create the missed scheduled transactions of specified user between the start date and the end date",
+			Action: bindAction(backfillUserScheduledTransactions),
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     "username",
+					Aliases:  []string{"n"},
+					Required: true,
+					Usage:    "Specific user name",
+				},
+				&cli.StringFlag{
+					Name:     "start-date",
+					Aliases:  []string{"s"},
+					Required: true,
+					Usage:    "Start date in server timezone (e.g. 2024-09-01)",
+				},
+				&cli.StringFlag{
+					Name:     "end-date",
+					Aliases:  []string{"e"},
+					Required: true,
+					Usage:    "End date in server timezone (e.g. 2024-09-30)",
+				},
+			},
+		},
 		{
 			Name:   "transaction-tag-index-fix-transaction-time",
 			Usage:  "Fix the transaction tag index data which does not have transaction time",
@@ -758,6 +797,71 @@ func checkUserTransactionAndAccount(c *core.CliContext) error {
 	return nil
 }
 
+func checkUserAccountBalanceAssertions(c *core.CliContext) error {
+	_, err := initializeSystem(c)
+
+	if err != nil {
+		return err
+	}
+
+	username := c.String("username")
+
+	log.CliInfof(c, "[user_data.checkUserAccountBalanceAssertions] starting checking user \"%s\" account balance assertions", username)
+
+	results, err := clis.UserData.CheckAccountBalanceAssertions(c, username)
+
+	if err != nil {
+		log.CliErrorf(c, "[user_data.checkUserAccountBalanceAssertions] error occurs when checking user account balance assertions")
+		return err
+	}
+
+	failedCount := 0
+
+	for i := 0; i < len(results); i++ {
+		if results[i].Passed {
+			continue
+		}
+
+		failedCount++
+		fmt.Printf("---\n")
+		printAccountBalanceAssertionCheckResult(results[i])
+	}
+
+	if failedCount > 0 {
+		log.CliErrorf(c, "[user_data.checkUserAccountBalanceAssertions] %d of %d account balance assertions are failed", failedCount, len(results))
+		return errs.ErrOperationFailed
+	}
+
+	log.CliInfof(c, "[user_data.checkUserAccountBalanceAssertions] all %d account balance assertions have been checked successfully", len(results))
+
+	return nil
+}
+
+func backfillUserScheduledTransactions(c *core.CliContext) error {
+	_, err := initializeSystem(c)
+
+	if err != nil {
+		return err
+	}
+
+	username := c.String("username")
+	startDate := c.String("start-date")
+	endDate := c.String("end-date")
+
+	log.CliInfof(c, "[user_data.backfillUserScheduledTransactions] starting creating user \"%s\" scheduled transactions from %s to %s", username, startDate, endDate)
+
+	createdCount, existedCount, err := clis.UserData.CreateScheduledTransactions(c, username, startDate, endDate)
+
+	if err != nil {
+		log.CliErrorf(c, "[user_data.backfillUserScheduledTransactions] error occurs when creating scheduled transactions, %d transactions have been created before error occurs", createdCount)
+		return err
+	}
+
+	log.CliInfof(c, "[user_data.backfillUserScheduledTransactions] %d scheduled transactions have been created, %d scheduled transactions already exist", createdCount, existedCount)
+
+	return nil
+}
+
 func fixTransactionTagIndexNotHaveTransactionTime(c *core.CliContext) error {
 	_, err := initializeSystem(c)
 
@@ -929,3 +1033,20 @@ func printTokenInfo(token *models.TokenRecord) {
 	fmt.Printf("[LastSeen] %s (%d)\n", utils.FormatUnixTimeToLongDateTimeInServerTimezone(token.LastSeenUnixTime), token.LastSeenUnixTime)
 	fmt.Printf("[UserAgent] %s\n", token.UserAgent)
 }
+
+func printAccountBalanceAssertionCheckResult(result *models.AccountBalanceAssertionCheckResult) {
+	assertionTimezone := time.FixedZone("Assertion Timezone", int(result.Assertion.TimezoneUtcOffset)*60)
+
+	fmt.Printf("[AssertionId] %d\n", result.Assertion.AssertionId)
+	fmt.Printf("[AccountId] %d\n", result.Assertion.AccountId)
+	fmt.Printf("[AssertionTime] %s (%d)\n", utils.FormatUnixTimeToLongDateTime(result.Assertion.AssertionTime, assertionTimezone), result.Assertion.AssertionTime)
+	fmt.Printf("[ExpectedBalance] %s\n", utils.FormatAmount(result.Assertion.ExpectedBalance))
+	fmt.Printf("[ActualBalance] %s\n", utils.FormatAmount(result.ActualBalance))
+	fmt.Printf("[Difference] %s\n", utils.FormatAmount(result.ActualBalance-result.Assertion.ExpectedBalance))
+
+	for i := 0; i < len(result.TransactionsSinceLastPassed); i++ {
+		transaction := result.TransactionsSinceLastPassed[i]
+		transactionTimezone := time.FixedZone("Transaction Timezone", int(transaction.TimezoneUtcOffset)*60)
+		fmt.Printf("[Transaction] %d, %s, %s, %s, %s\n", transaction.TransactionId, utils.FormatUnixTimeToLongDateTime(utils.GetUnixTimeFromTransactionTime(transaction.TransactionTime), transactionTimezone), transaction.Type, utils.FormatAmount(transaction.Amount), transaction.Comment)
+	}
+}
@@ -96,6 +96,8 @@ func startWebServer(c *core.CliContext) error {
 		_ = v.RegisterValidation("validUsername", validators.ValidUsername)
 		_ = v.RegisterValidation("validEmail", validators.ValidEmail)
 		_ = v.RegisterValidation("validCurrency", validators.ValidCurrency)
+		_ = v.RegisterValidation("validCurrencyCode", validators.ValidCurrencyCode)
+		_ = v.RegisterValidation("validCustomCurrencyCode", validators.ValidCustomCurrencyCode)
 		_ = v.RegisterValidation("validHexRGBColor", validators.ValidHexRGBColor)
 		_ = v.RegisterValidation("validAmountFilter", validators.ValidAmountFilter)
 	}
@@ -291,6 +293,8 @@ func startWebServer(c *core.CliContext) error {
 			if config.EnableDataExport {
 				apiV1Route.GET("/data/export.csv", bindCsv(api.DataManagements.ExportDataToEzbookkeepingCSVHandler))
 				apiV1Route.GET("/data/export.tsv", bindTsv(api.DataManagements.ExportDataToEzbookkeepingTSVHandler))
+				apiV1Route.GET("/data/export_exchange_rates.csv", bindCsv(api.DataManagements.ExportUserExchangeRatesToCSVHandler))
+				apiV1Route.GET("/data/export_exchange_rates.tsv", bindTsv(api.DataManagements.ExportUserExchangeRatesToTSVHandler))
 			}
 
 			// Accounts
@@ -303,15 +307,27 @@ func startWebServer(c *core.CliContext) error {
 			apiV1Route.POST("/accounts/delete.json", bindApi(api.Accounts.AccountDeleteHandler))
 			apiV1Route.POST("/accounts/sub_account/delete.json", bindApi(api.Accounts.SubAccountDeleteHandler))
 
+			// Account Balance Assertions
+			apiV1Route.GET("/accounts/balance_assertions/list.json", bindApi(api.AccountBalanceAssertions.AssertionListHandler))
+			apiV1Route.GET("/accounts/balance_assertions/get.json", bindApi(api.AccountBalanceAssertions.AssertionGetHandler))
+			apiV1Route.GET("/accounts/balance_assertions/check.json", bindApi(api.AccountBalanceAssertions.AssertionCheckHandler))
+			apiV1Route.POST("/accounts/balance_assertions/add.json", bindApi(api.AccountBalanceAssertions.AssertionCreateHandler))
+			apiV1Route.POST("/accounts/balance_assertions/modify.json", bindApi(api.AccountBalanceAssertions.AssertionModifyHandler))
+			apiV1Route.POST("/accounts/balance_assertions/delete.json", bindApi(api.AccountBalanceAssertions.AssertionDeleteHandler))
+
 			// Transactions
 			apiV1Route.GET("/transactions/count.json", bindApi(api.Transactions.TransactionCountHandler))
 			apiV1Route.GET("/transactions/list.json", bindApi(api.Transactions.TransactionListHandler))
 			apiV1Route.GET("/transactions/list/by_month.json", bindApi(api.Transactions.TransactionMonthListHandler))
 			apiV1Route.GET("/transactions/statistics.json", bindApi(api.Transactions.TransactionStatisticsHandler))
 			apiV1Route.GET("/transactions/statistics/trends.json", bindApi(api.Transactions.TransactionStatisticsTrendsHandler))
+			apiV1Route.GET("/transactions/statistics/locations.json", bindApi(api.Transactions.TransactionStatisticsLocationsHandler))
 			apiV1Route.GET("/transactions/amounts.json", bindApi(api.Transactions.TransactionAmountsHandler))
 			apiV1Route.GET("/transactions/get.json", bindApi(api.Transactions.TransactionGetHandler))
+			apiV1Route.GET("/transactions/suggest.json", bindApi(api.Transactions.TransactionSuggestHandler))
+			apiV1Route.GET("/transactions/recurring.json", bindApi(api.Transactions.TransactionRecurringListHandler))
 			apiV1Route.POST("/transactions/add.json", bindApi(api.Transactions.TransactionCreateHandler))
+			apiV1Route.POST("/transactions/quick_add.json", bindApi(api.Transactions.TransactionQuickAddHandler))
 			apiV1Route.POST("/transactions/modify.json", bindApi(api.Transactions.TransactionModifyHandler))
 			apiV1Route.POST("/transactions/delete.json", bindApi(api.Transactions.TransactionDeleteHandler))
 
@@ -322,6 +338,13 @@ func startWebServer(c *core.CliContext) error {
 				apiV1Route.GET("/transactions/import/process.json", bindApi(api.Transactions.TransactionImportProcessHandler))
 			}
 
+			// Financial Reports
+			apiV1Route.GET("/reports/income_statement.json", bindApi(api.FinancialReports.IncomeStatementHandler))
+			apiV1Route.GET("/reports/income_statement.csv", bindCsv(api.FinancialReports.IncomeStatementCsvHandler))
+			apiV1Route.GET("/reports/balance_sheet.json", bindApi(api.FinancialReports.BalanceSheetHandler))
+			apiV1Route.GET("/reports/balance_sheet.csv", bindCsv(api.FinancialReports.BalanceSheetCsvHandler))
+			apiV1Route.GET("/reports/transactions.pdf", bindPdf(api.Transactions.TransactionReportPdfHandler))
+
 			// Transaction Pictures
 			if config.EnableTransactionPictures {
 				apiV1Route.POST("/transaction/pictures/upload.json", bindApi(api.TransactionPictures.TransactionPictureUploadHandler))
@@ -352,13 +375,59 @@ func startWebServer(c *core.CliContext) error {
 			apiV1Route.GET("/transaction/templates/list.json", bindApi(api.TransactionTemplates.TemplateListHandler))
 			apiV1Route.GET("/transaction/templates/get.json", bindApi(api.TransactionTemplates.TemplateGetHandler))
 			apiV1Route.POST("/transaction/templates/add.json", bindApi(api.TransactionTemplates.TemplateCreateHandler))
+			apiV1Route.POST("/transaction/templates/add_from_recurring.json", bindApi(api.TransactionTemplates.TemplateCreateFromRecurringHandler))
 			apiV1Route.POST("/transaction/templates/modify.json", bindApi(api.TransactionTemplates.TemplateModifyHandler))
 			apiV1Route.POST("/transaction/templates/hide.json", bindApi(api.TransactionTemplates.TemplateHideHandler))
 			apiV1Route.POST("/transaction/templates/move.json", bindApi(api.TransactionTemplates.TemplateMoveHandler))
 			apiV1Route.POST("/transaction/templates/delete.json", bindApi(api.TransactionTemplates.TemplateDeleteHandler))
+			apiV1Route.GET("/transaction/templates/scheduled_forecast.json", bindApi(api.TransactionTemplates.ScheduledTransactionForecastHandler))
+
+			// Transaction Rules
+			apiV1Route.GET("/transaction/rules/list.json", bindApi(api.TransactionRules.RuleListHandler))
+			apiV1Route.GET("/transaction/rules/get.json", bindApi(api.TransactionRules.RuleGetHandler))
+			apiV1Route.POST("/transaction/rules/add.json", bindApi(api.TransactionRules.RuleCreateHandler))
+			apiV1Route.POST("/transaction/rules/modify.json", bindApi(api.TransactionRules.RuleModifyHandler))
+			apiV1Route.POST("/transaction/rules/move.json", bindApi(api.TransactionRules.RuleMoveHandler))
+			apiV1Route.POST("/transaction/rules/delete.json", bindApi(api.TransactionRules.RuleDeleteHandler))
+			apiV1Route.POST("/transaction/rules/apply.json", bindApi(api.TransactionRules.RuleApplyHandler))
+
+			// Webhooks
+			if config.EnableWebhook {
+				apiV1Route.GET("/webhooks/list.json", bindApi(api.Webhooks.WebhookListHandler))
+				apiV1Route.GET("/webhooks/get.json", bindApi(api.Webhooks.WebhookGetHandler))
+				apiV1Route.POST("/webhooks/add.json", bindApi(api.Webhooks.WebhookCreateHandler))
+				apiV1Route.POST("/webhooks/modify.json", bindApi(api.Webhooks.WebhookModifyHandler))
+				apiV1Route.POST("/webhooks/delete.json", bindApi(api.Webhooks.WebhookDeleteHandler))
+				apiV1Route.GET("/webhooks/deliveries/list.json", bindApi(api.Webhooks.WebhookDeliveryListHandler))
+			}
+
+			// Scheduled Reports
+			if config.EnableSendScheduledReport && config.EnableSMTP {
+				apiV1Route.GET("/scheduled_reports/list.json", bindApi(api.ScheduledReports.ScheduledReportListHandler))
+				apiV1Route.GET("/scheduled_reports/get.json", bindApi(api.ScheduledReports.ScheduledReportGetHandler))
+				apiV1Route.POST("/scheduled_reports/add.json", bindApi(api.ScheduledReports.ScheduledReportCreateHandler))
+				apiV1Route.POST("/scheduled_reports/modify.json", bindApi(api.ScheduledReports.ScheduledReportModifyHandler))
+				apiV1Route.POST("/scheduled_reports/delete.json", bindApi(api.ScheduledReports.ScheduledReportDeleteHandler))
+			}
 
 			// Exchange Rates
 			apiV1Route.GET("/exchange_rates/latest.json", bindApi(api.ExchangeRates.LatestExchangeRateHandler))
+			apiV1Route.GET("/exchange_rates/history.json", bindApi(api.ExchangeRates.HistoricalExchangeRateHandler))
+			apiV1Route.GET("/exchange_rates/history/range.json", bindApi(api.ExchangeRates.HistoricalExchangeRatesInRangeHandler))
+
+			// User Defined Exchange Rates
+			apiV1Route.GET("/exchange_rates/user_custom/list.json", bindApi(api.UserExchangeRates.UserExchangeRateListHandler))
+			apiV1Route.GET("/exchange_rates/user_custom/get.json", bindApi(api.UserExchangeRates.UserExchangeRateGetHandler))
+			apiV1Route.POST("/exchange_rates/user_custom/add.json", bindApi(api.UserExchangeRates.UserExchangeRateCreateHandler))
+			apiV1Route.POST("/exchange_rates/user_custom/modify.json", bindApi(api.UserExchangeRates.UserExchangeRateModifyHandler))
+			apiV1Route.POST("/exchange_rates/user_custom/delete.json", bindApi(api.UserExchangeRates.UserExchangeRateDeleteHandler))
+
+			// User Defined Currencies
+			apiV1Route.GET("/currencies/user_custom/list.json", bindApi(api.UserCustomCurrencies.UserCustomCurrencyListHandler))
+			apiV1Route.GET("/currencies/user_custom/get.json", bindApi(api.UserCustomCurrencies.UserCustomCurrencyGetHandler))
+			apiV1Route.POST("/currencies/user_custom/add.json", bindApi(api.UserCustomCurrencies.UserCustomCurrencyCreateHandler))
+			apiV1Route.POST("/currencies/user_custom/modify.json", bindApi(api.UserCustomCurrencies.UserCustomCurrencyModifyHandler))
+			apiV1Route.POST("/currencies/user_custom/delete.json", bindApi(api.UserCustomCurrencies.UserCustomCurrencyDeleteHandler))
 		}
 	}
 
@@ -472,6 +541,19 @@ func bindTsv(fn core.DataHandlerFunc) gin.HandlerFunc {
 	}
 }
 
+func bindPdf(fn core.DataHandlerFunc) gin.HandlerFunc {
+	return func(ginCtx *gin.Context) {
+		c := core.WrapWebContext(ginCtx)
+		result, fileName, err := fn(c)
+
+		if err != nil {
+			utils.PrintDataErrorResult(c, "text/text", err)
+		} else {
+			utils.PrintDataSuccessResult(c, "application/pdf", fileName, result)
+		}
+	}
+}
+
 func bindImage(fn core.ImageHandlerFunc) gin.HandlerFunc {
 	return func(ginCtx *gin.Context) {
 		c := core.WrapWebContext(ginCtx)
@@ -38,6 +38,7 @@ func main() {
 			cmd.Database,
 			cmd.UserData,
 			cmd.CronJobs,
+			cmd.ExchangeRates,
 			cmd.SecurityUtils,
 			cmd.Utilities,
 		},
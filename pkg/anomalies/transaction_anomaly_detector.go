@@ -0,0 +1,284 @@
+package anomalies
+
+import (
+	"math"
+	"sort"
+
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+// TransactionAnomalyDetectorOptions represents the options of transaction anomaly detection
+type TransactionAnomalyDetectorOptions struct {
+	MinCategorySamples            int
+	CategoryOutlierStdDevs        float64
+	CategoryOutlierMinMedianRatio float64
+	NewPayeeMinSamples            int
+	NewPayeeLargeAmountPercentile float64
+	DuplicateTimeWindowInSeconds  int64
+}
+
+// DetectedTransactionAnomaly represents an anomaly found in the specified transaction
+type DetectedTransactionAnomaly struct {
+	TransactionId        int64
+	Type                 models.TransactionAnomalyType
+	RelatedTransactionId int64
+	ReferenceAmount      int64
+}
+
+// TransactionAnomalyDetector represents the detector which finds the unusual expense transactions by comparing them with the historical transactions
+type TransactionAnomalyDetector struct {
+	options            *TransactionAnomalyDetectorOptions
+	accountCurrencies  map[int64]string
+	categoryAmounts    map[transactionAnomalyCategoryKey][]int64
+	currencyAmounts    map[string][]int64
+	knownPayees        map[string]bool
+	recentTransactions []*models.Transaction // sorted by transaction time
+}
+
+type transactionAnomalyCategoryKey struct {
+	categoryId int64
+	currency   string
+}
+
+// DefaultTransactionAnomalyDetectorOptions returns the default options of transaction anomaly detection
+func DefaultTransactionAnomalyDetectorOptions(duplicateTimeWindowInSeconds int64) *TransactionAnomalyDetectorOptions {
+	return &TransactionAnomalyDetectorOptions{
+		MinCategorySamples:            10,
+		CategoryOutlierStdDevs:        3,
+		CategoryOutlierMinMedianRatio: 2,
+		NewPayeeMinSamples:            20,
+		NewPayeeLargeAmountPercentile: 0.9,
+		DuplicateTimeWindowInSeconds:  duplicateTimeWindowInSeconds,
+	}
+}
+
+// NewTransactionAnomalyDetector returns a new transaction anomaly detector which uses the specified historical transactions as baseline
+func NewTransactionAnomalyDetector(historicalTransactions []*models.Transaction, accountCurrencies map[int64]string, options *TransactionAnomalyDetectorOptions) *TransactionAnomalyDetector {
+	detector := &TransactionAnomalyDetector{
+		options:            options,
+		accountCurrencies:  accountCurrencies,
+		categoryAmounts:    make(map[transactionAnomalyCategoryKey][]int64),
+		currencyAmounts:    make(map[string][]int64),
+		knownPayees:        make(map[string]bool),
+		recentTransactions: make([]*models.Transaction, 0),
+	}
+
+	sortedTransactions := getExpenseTransactionsSortedByTime(historicalTransactions)
+
+	for i := 0; i < len(sortedTransactions); i++ {
+		detector.addToBaseline(sortedTransactions[i])
+	}
+
+	return detector
+}
+
+// DetectTransactionAnomalies returns the anomalies of the specified new expense transactions in chronological order,
+// each transaction is added to the baseline after being checked, so the later transactions in the same batch are compared with the earlier ones
+func (d *TransactionAnomalyDetector) DetectTransactionAnomalies(transactions []*models.Transaction) []*DetectedTransactionAnomaly {
+	anomalies := make([]*DetectedTransactionAnomaly, 0)
+	sortedTransactions := getExpenseTransactionsSortedByTime(transactions)
+
+	for i := 0; i < len(sortedTransactions); i++ {
+		transaction := sortedTransactions[i]
+		currency, exists := d.accountCurrencies[transaction.AccountId]
+
+		if exists {
+			if anomaly := d.checkCategoryOutlier(transaction, currency); anomaly != nil {
+				anomalies = append(anomalies, anomaly)
+			}
+
+			if anomaly := d.checkNewPayeeLargeAmount(transaction, currency); anomaly != nil {
+				anomalies = append(anomalies, anomaly)
+			}
+		}
+
+		if anomaly := d.checkPossibleDuplicate(transaction); anomaly != nil {
+			anomalies = append(anomalies, anomaly)
+		}
+
+		d.addToBaseline(transaction)
+	}
+
+	return anomalies
+}
+
+// IsKnownPayee returns whether the specified normalized payee name has been used by the baseline transactions
+func (d *TransactionAnomalyDetector) IsKnownPayee(payeeName string) bool {
+	return d.knownPayees[payeeName]
+}
+
+// AddKnownPayee marks the specified normalized payee name as known, which is used by the transactions earlier than the baseline
+func (d *TransactionAnomalyDetector) AddKnownPayee(payeeName string) {
+	if payeeName != "" {
+		d.knownPayees[payeeName] = true
+	}
+}
+
+func (d *TransactionAnomalyDetector) checkCategoryOutlier(transaction *models.Transaction, currency string) *DetectedTransactionAnomaly {
+	amounts := d.categoryAmounts[transactionAnomalyCategoryKey{categoryId: transaction.CategoryId, currency: currency}]
+
+	if d.options.MinCategorySamples < 1 || len(amounts) < d.options.MinCategorySamples {
+		return nil
+	}
+
+	mean, stdDev := getMeanAndStandardDeviation(amounts)
+	threshold := math.Max(mean+d.options.CategoryOutlierStdDevs*stdDev, float64(getPercentile(amounts, 0.5))*d.options.CategoryOutlierMinMedianRatio)
+
+	if float64(transaction.Amount) <= threshold {
+		return nil
+	}
+
+	return &DetectedTransactionAnomaly{
+		TransactionId:   transaction.TransactionId,
+		Type:            models.TRANSACTION_ANOMALY_TYPE_CATEGORY_OUTLIER,
+		ReferenceAmount: int64(math.Round(threshold)),
+	}
+}
+
+func (d *TransactionAnomalyDetector) checkNewPayeeLargeAmount(transaction *models.Transaction, currency string) *DetectedTransactionAnomaly {
+	payeeName := utils.GetNormalizedPayeeName(transaction.Comment)
+
+	if payeeName == "" || d.knownPayees[payeeName] {
+		return nil
+	}
+
+	amounts := d.currencyAmounts[currency]
+
+	if d.options.NewPayeeMinSamples < 1 || len(amounts) < d.options.NewPayeeMinSamples {
+		return nil
+	}
+
+	threshold := getPercentile(amounts, d.options.NewPayeeLargeAmountPercentile)
+
+	if transaction.Amount < threshold || transaction.Amount <= 0 {
+		return nil
+	}
+
+	return &DetectedTransactionAnomaly{
+		TransactionId:   transaction.TransactionId,
+		Type:            models.TRANSACTION_ANOMALY_TYPE_NEW_PAYEE_LARGE_AMOUNT,
+		ReferenceAmount: threshold,
+	}
+}
+
+func (d *TransactionAnomalyDetector) checkPossibleDuplicate(transaction *models.Transaction) *DetectedTransactionAnomaly {
+	if d.options.DuplicateTimeWindowInSeconds <= 0 {
+		return nil
+	}
+
+	unixTime := utils.GetUnixTimeFromTransactionTime(transaction.TransactionTime)
+	minTransactionTime := utils.GetMinTransactionTimeFromUnixTime(unixTime - d.options.DuplicateTimeWindowInSeconds)
+	maxTransactionTime := utils.GetMaxTransactionTimeFromUnixTime(unixTime + d.options.DuplicateTimeWindowInSeconds)
+	payeeName := utils.GetNormalizedPayeeName(transaction.Comment)
+
+	startIndex := sort.Search(len(d.recentTransactions), func(i int) bool {
+		return d.recentTransactions[i].TransactionTime >= minTransactionTime
+	})
+
+	for i := startIndex; i < len(d.recentTransactions) && d.recentTransactions[i].TransactionTime <= maxTransactionTime; i++ {
+		existedTransaction := d.recentTransactions[i]
+
+		if existedTransaction.TransactionId == transaction.TransactionId {
+			continue
+		}
+
+		if existedTransaction.AccountId != transaction.AccountId || existedTransaction.CategoryId != transaction.CategoryId || existedTransaction.Amount != transaction.Amount {
+			continue
+		}
+
+		if utils.GetNormalizedPayeeName(existedTransaction.Comment) != payeeName {
+			continue
+		}
+
+		return &DetectedTransactionAnomaly{
+			TransactionId:        transaction.TransactionId,
+			Type:                 models.TRANSACTION_ANOMALY_TYPE_POSSIBLE_DUPLICATE,
+			RelatedTransactionId: existedTransaction.TransactionId,
+			ReferenceAmount:      existedTransaction.Amount,
+		}
+	}
+
+	return nil
+}
+
+func (d *TransactionAnomalyDetector) addToBaseline(transaction *models.Transaction) {
+	currency, exists := d.accountCurrencies[transaction.AccountId]
+
+	if exists {
+		categoryKey := transactionAnomalyCategoryKey{categoryId: transaction.CategoryId, currency: currency}
+		d.categoryAmounts[categoryKey] = insertSortedAmount(d.categoryAmounts[categoryKey], transaction.Amount)
+		d.currencyAmounts[currency] = insertSortedAmount(d.currencyAmounts[currency], transaction.Amount)
+	}
+
+	if payeeName := utils.GetNormalizedPayeeName(transaction.Comment); payeeName != "" {
+		d.knownPayees[payeeName] = true
+	}
+
+	index := sort.Search(len(d.recentTransactions), func(i int) bool {
+		return d.recentTransactions[i].TransactionTime > transaction.TransactionTime
+	})
+
+	d.recentTransactions = append(d.recentTransactions, nil)
+	copy(d.recentTransactions[index+1:], d.recentTransactions[index:])
+	d.recentTransactions[index] = transaction
+}
+
+func getExpenseTransactionsSortedByTime(transactions []*models.Transaction) []*models.Transaction {
+	expenseTransactions := make([]*models.Transaction, 0, len(transactions))
+
+	for i := 0; i < len(transactions); i++ {
+		if transactions[i].Type == models.TRANSACTION_DB_TYPE_EXPENSE {
+			expenseTransactions = append(expenseTransactions, transactions[i])
+		}
+	}
+
+	sort.SliceStable(expenseTransactions, func(i, j int) bool {
+		return expenseTransactions[i].TransactionTime < expenseTransactions[j].TransactionTime
+	})
+
+	return expenseTransactions
+}
+
+func insertSortedAmount(amounts []int64, amount int64) []int64 {
+	index := sort.Search(len(amounts), func(i int) bool {
+		return amounts[i] >= amount
+	})
+
+	amounts = append(amounts, 0)
+	copy(amounts[index+1:], amounts[index:])
+	amounts[index] = amount
+
+	return amounts
+}
+
+func getMeanAndStandardDeviation(amounts []int64) (float64, float64) {
+	sum := float64(0)
+
+	for i := 0; i < len(amounts); i++ {
+		sum += float64(amounts[i])
+	}
+
+	mean := sum / float64(len(amounts))
+	squaredDifferenceSum := float64(0)
+
+	for i := 0; i < len(amounts); i++ {
+		difference := float64(amounts[i]) - mean
+		squaredDifferenceSum += difference * difference
+	}
+
+	return mean, math.Sqrt(squaredDifferenceSum / float64(len(amounts)))
+}
+
+// getPercentile returns the nearest-rank percentile of the sorted amounts
+func getPercentile(sortedAmounts []int64, percentile float64) int64 {
+	index := int(math.Ceil(percentile*float64(len(sortedAmounts)))) - 1
+
+	if index < 0 {
+		index = 0
+	} else if index >= len(sortedAmounts) {
+		index = len(sortedAmounts) - 1
+	}
+
+	return sortedAmounts[index]
+}
@@ -0,0 +1,165 @@
+package anomalies
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+const testBaseUnixTime = int64(1700000000)
+
+var testAccountCurrencies = map[int64]string{
+	1: "USD",
+	2: "EUR",
+}
+
+func newTestExpenseTransaction(transactionId int64, unixTime int64, accountId int64, categoryId int64, amount int64, comment string) *models.Transaction {
+	return &models.Transaction{
+		TransactionId:   transactionId,
+		Type:            models.TRANSACTION_DB_TYPE_EXPENSE,
+		TransactionTime: utils.GetMinTransactionTimeFromUnixTime(unixTime),
+		AccountId:       accountId,
+		CategoryId:      categoryId,
+		Amount:          amount,
+		Comment:         comment,
+	}
+}
+
+func newTestHistoricalTransactions(count int, categoryId int64, amount int64, comment string) []*models.Transaction {
+	transactions := make([]*models.Transaction, 0, count)
+
+	for i := 0; i < count; i++ {
+		transactions = append(transactions, newTestExpenseTransaction(int64(1000+i), testBaseUnixTime+int64(i)*86400, 1, categoryId, amount+int64(i%3)*100, comment))
+	}
+
+	return transactions
+}
+
+func TestDetectTransactionAnomalies_CategoryOutlier(t *testing.T) {
+	history := newTestHistoricalTransactions(12, 10, 5000, "Grocery Store")
+	detector := NewTransactionAnomalyDetector(history, testAccountCurrencies, DefaultTransactionAnomalyDetectorOptions(0))
+
+	anomalies := detector.DetectTransactionAnomalies([]*models.Transaction{
+		newTestExpenseTransaction(1, testBaseUnixTime+30*86400, 1, 10, 5200, "Grocery Store"),
+		newTestExpenseTransaction(2, testBaseUnixTime+31*86400, 1, 10, 25000, "Grocery Store"),
+	})
+
+	assert.Equal(t, 1, len(anomalies))
+	assert.Equal(t, int64(2), anomalies[0].TransactionId)
+	assert.Equal(t, models.TRANSACTION_ANOMALY_TYPE_CATEGORY_OUTLIER, anomalies[0].Type)
+	assert.Equal(t, int64(10200), anomalies[0].ReferenceAmount)
+}
+
+func TestDetectTransactionAnomalies_CategoryOutlierNotEnoughSamples(t *testing.T) {
+	history := newTestHistoricalTransactions(5, 10, 5000, "Grocery Store")
+	detector := NewTransactionAnomalyDetector(history, testAccountCurrencies, DefaultTransactionAnomalyDetectorOptions(0))
+
+	anomalies := detector.DetectTransactionAnomalies([]*models.Transaction{
+		newTestExpenseTransaction(1, testBaseUnixTime+30*86400, 1, 10, 25000, "Grocery Store"),
+	})
+
+	assert.Equal(t, 0, len(anomalies))
+}
+
+func TestDetectTransactionAnomalies_CategoryOutlierInDifferentCurrency(t *testing.T) {
+	history := newTestHistoricalTransactions(12, 10, 5000, "Grocery Store")
+	detector := NewTransactionAnomalyDetector(history, testAccountCurrencies, DefaultTransactionAnomalyDetectorOptions(0))
+
+	anomalies := detector.DetectTransactionAnomalies([]*models.Transaction{
+		newTestExpenseTransaction(1, testBaseUnixTime+30*86400, 2, 10, 25000, "Grocery Store"),
+	})
+
+	assert.Equal(t, 0, len(anomalies))
+}
+
+func TestDetectTransactionAnomalies_NewPayeeLargeAmount(t *testing.T) {
+	history := newTestHistoricalTransactions(20, 10, 5000, "Grocery Store")
+	history = append(history, newTestExpenseTransaction(2000, testBaseUnixTime, 1, 20, 8000, "Electronics Shop"))
+	detector := NewTransactionAnomalyDetector(history, testAccountCurrencies, DefaultTransactionAnomalyDetectorOptions(0))
+
+	anomalies := detector.DetectTransactionAnomalies([]*models.Transaction{
+		newTestExpenseTransaction(1, testBaseUnixTime+30*86400, 1, 20, 9000, "Electronics shop #123"),
+		newTestExpenseTransaction(2, testBaseUnixTime+31*86400, 1, 20, 9000, "Jewelry Store"),
+		newTestExpenseTransaction(3, testBaseUnixTime+32*86400, 1, 20, 9500, "Jewelry store"),
+		newTestExpenseTransaction(4, testBaseUnixTime+33*86400, 1, 20, 1000, "Coffee House"),
+	})
+
+	assert.Equal(t, 1, len(anomalies))
+	assert.Equal(t, int64(2), anomalies[0].TransactionId)
+	assert.Equal(t, models.TRANSACTION_ANOMALY_TYPE_NEW_PAYEE_LARGE_AMOUNT, anomalies[0].Type)
+	assert.Equal(t, int64(5200), anomalies[0].ReferenceAmount)
+}
+
+func TestDetectTransactionAnomalies_NewPayeeLargeAmountKnownEarlierPayee(t *testing.T) {
+	history := newTestHistoricalTransactions(20, 10, 5000, "Grocery Store")
+	detector := NewTransactionAnomalyDetector(history, testAccountCurrencies, DefaultTransactionAnomalyDetectorOptions(0))
+
+	assert.Equal(t, true, detector.IsKnownPayee("grocery store"))
+	assert.Equal(t, false, detector.IsKnownPayee("jewelry store"))
+
+	detector.AddKnownPayee("jewelry store")
+	assert.Equal(t, true, detector.IsKnownPayee("jewelry store"))
+
+	anomalies := detector.DetectTransactionAnomalies([]*models.Transaction{
+		newTestExpenseTransaction(1, testBaseUnixTime+30*86400, 1, 20, 9000, "Jewelry Store"),
+	})
+
+	assert.Equal(t, 0, len(anomalies))
+}
+
+func TestDetectTransactionAnomalies_PossibleDuplicate(t *testing.T) {
+	history := []*models.Transaction{
+		newTestExpenseTransaction(1000, testBaseUnixTime, 1, 10, 3500, "Coffee House"),
+	}
+	detector := NewTransactionAnomalyDetector(history, testAccountCurrencies, DefaultTransactionAnomalyDetectorOptions(3600))
+
+	anomalies := detector.DetectTransactionAnomalies([]*models.Transaction{
+		newTestExpenseTransaction(1, testBaseUnixTime+600, 1, 10, 3500, "COFFEE HOUSE #2"),
+		newTestExpenseTransaction(2, testBaseUnixTime+700, 1, 10, 3600, "Coffee House"),
+		newTestExpenseTransaction(3, testBaseUnixTime+800, 2, 10, 3500, "Coffee House"),
+		newTestExpenseTransaction(4, testBaseUnixTime+4000, 1, 10, 3500, "Coffee House"),
+		newTestExpenseTransaction(5, testBaseUnixTime+4100, 1, 10, 3500, "Tea House"),
+	})
+
+	assert.Equal(t, 2, len(anomalies))
+	assert.Equal(t, int64(1), anomalies[0].TransactionId)
+	assert.Equal(t, models.TRANSACTION_ANOMALY_TYPE_POSSIBLE_DUPLICATE, anomalies[0].Type)
+	assert.Equal(t, int64(1000), anomalies[0].RelatedTransactionId)
+	assert.Equal(t, int64(4), anomalies[1].TransactionId)
+	assert.Equal(t, int64(1), anomalies[1].RelatedTransactionId)
+}
+
+func TestDetectTransactionAnomalies_SkipNonExpenseTransactions(t *testing.T) {
+	history := []*models.Transaction{
+		newTestExpenseTransaction(1000, testBaseUnixTime, 1, 10, 3500, "Salary"),
+	}
+	detector := NewTransactionAnomalyDetector(history, testAccountCurrencies, DefaultTransactionAnomalyDetectorOptions(3600))
+
+	transaction := newTestExpenseTransaction(1, testBaseUnixTime+60, 1, 10, 3500, "Salary")
+	transaction.Type = models.TRANSACTION_DB_TYPE_INCOME
+
+	anomalies := detector.DetectTransactionAnomalies([]*models.Transaction{transaction})
+	assert.Equal(t, 0, len(anomalies))
+}
+
+func TestGetPercentile(t *testing.T) {
+	amounts := []int64{100, 200, 300, 400, 500, 600, 700, 800, 900, 1000}
+
+	assert.Equal(t, int64(100), getPercentile(amounts, 0))
+	assert.Equal(t, int64(500), getPercentile(amounts, 0.5))
+	assert.Equal(t, int64(900), getPercentile(amounts, 0.9))
+	assert.Equal(t, int64(1000), getPercentile(amounts, 1))
+}
+
+func TestInsertSortedAmount(t *testing.T) {
+	amounts := make([]int64, 0)
+	amounts = insertSortedAmount(amounts, 300)
+	amounts = insertSortedAmount(amounts, 100)
+	amounts = insertSortedAmount(amounts, 200)
+	amounts = insertSortedAmount(amounts, 300)
+
+	assert.Equal(t, []int64{100, 200, 300, 300}, amounts)
+}
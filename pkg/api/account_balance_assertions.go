@@ -0,0 +1,236 @@
+package api
+
+import (
+	"sort"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/services"
+)
+
+// AccountBalanceAssertionsApi represents account balance assertion api
+type AccountBalanceAssertionsApi struct {
+	assertions      *services.AccountBalanceAssertionService
+	transactionTags *services.TransactionTagService
+}
+
+// Initialize an account balance assertion api singleton instance
+var (
+	AccountBalanceAssertions = &AccountBalanceAssertionsApi{
+		assertions:      services.AccountBalanceAssertions,
+		transactionTags: services.TransactionTags,
+	}
+)
+
+// AssertionListHandler returns account balance assertion list of current user
+func (a *AccountBalanceAssertionsApi) AssertionListHandler(c *core.WebContext) (any, *errs.Error) {
+	var assertionListReq models.AccountBalanceAssertionListRequest
+	err := c.ShouldBindQuery(&assertionListReq)
+
+	if err != nil {
+		log.Warnf(c, "[account_balance_assertions.AssertionListHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	var assertions []*models.AccountBalanceAssertion
+
+	if assertionListReq.AccountId > 0 {
+		assertions, err = a.assertions.GetAssertionsByAccountId(c, uid, assertionListReq.AccountId)
+	} else {
+		assertions, err = a.assertions.GetAllAssertionsByUid(c, uid)
+	}
+
+	if err != nil {
+		log.Errorf(c, "[account_balance_assertions.AssertionListHandler] failed to get balance assertions for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	assertionResps := make(models.AccountBalanceAssertionInfoResponseSlice, len(assertions))
+
+	for i := 0; i < len(assertions); i++ {
+		assertionResps[i] = assertions[i].ToAccountBalanceAssertionInfoResponse()
+	}
+
+	sort.Sort(assertionResps)
+
+	return assertionResps, nil
+}
+
+// AssertionGetHandler returns one specific account balance assertion of current user
+func (a *AccountBalanceAssertionsApi) AssertionGetHandler(c *core.WebContext) (any, *errs.Error) {
+	var assertionGetReq models.AccountBalanceAssertionGetRequest
+	err := c.ShouldBindQuery(&assertionGetReq)
+
+	if err != nil {
+		log.Warnf(c, "[account_balance_assertions.AssertionGetHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	assertion, err := a.assertions.GetAssertionByAssertionId(c, uid, assertionGetReq.Id)
+
+	if err != nil {
+		log.Errorf(c, "[account_balance_assertions.AssertionGetHandler] failed to get balance assertion \"id:%d\" for user \"uid:%d\", because %s", assertionGetReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	return assertion.ToAccountBalanceAssertionInfoResponse(), nil
+}
+
+// AssertionCreateHandler saves a new account balance assertion by request parameters for current user
+func (a *AccountBalanceAssertionsApi) AssertionCreateHandler(c *core.WebContext) (any, *errs.Error) {
+	var assertionCreateReq models.AccountBalanceAssertionCreateRequest
+	err := c.ShouldBindJSON(&assertionCreateReq)
+
+	if err != nil {
+		log.Warnf(c, "[account_balance_assertions.AssertionCreateHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	assertion := a.createNewAssertionModel(uid, &assertionCreateReq)
+
+	err = a.assertions.CreateAssertion(c, assertion)
+
+	if err != nil {
+		log.Errorf(c, "[account_balance_assertions.AssertionCreateHandler] failed to create balance assertion for account \"id:%d\" for user \"uid:%d\", because %s", assertion.AccountId, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[account_balance_assertions.AssertionCreateHandler] user \"uid:%d\" has created a new balance assertion \"id:%d\" successfully", uid, assertion.AssertionId)
+
+	return assertion.ToAccountBalanceAssertionInfoResponse(), nil
+}
+
+// AssertionModifyHandler saves an existed account balance assertion by request parameters for current user
+func (a *AccountBalanceAssertionsApi) AssertionModifyHandler(c *core.WebContext) (any, *errs.Error) {
+	var assertionModifyReq models.AccountBalanceAssertionModifyRequest
+	err := c.ShouldBindJSON(&assertionModifyReq)
+
+	if err != nil {
+		log.Warnf(c, "[account_balance_assertions.AssertionModifyHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	assertion, err := a.assertions.GetAssertionByAssertionId(c, uid, assertionModifyReq.Id)
+
+	if err != nil {
+		log.Errorf(c, "[account_balance_assertions.AssertionModifyHandler] failed to get balance assertion \"id:%d\" for user \"uid:%d\", because %s", assertionModifyReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	newAssertion := &models.AccountBalanceAssertion{
+		AssertionId:       assertion.AssertionId,
+		Uid:               uid,
+		AccountId:         assertion.AccountId,
+		AssertionTime:     assertionModifyReq.Time,
+		TimezoneUtcOffset: assertionModifyReq.UtcOffset,
+		ExpectedBalance:   assertionModifyReq.ExpectedBalance,
+		Comment:           assertionModifyReq.Comment,
+	}
+
+	if newAssertion.AssertionTime == assertion.AssertionTime &&
+		newAssertion.TimezoneUtcOffset == assertion.TimezoneUtcOffset &&
+		newAssertion.ExpectedBalance == assertion.ExpectedBalance &&
+		newAssertion.Comment == assertion.Comment {
+		return nil, errs.ErrNothingWillBeUpdated
+	}
+
+	err = a.assertions.ModifyAssertion(c, newAssertion)
+
+	if err != nil {
+		log.Errorf(c, "[account_balance_assertions.AssertionModifyHandler] failed to update balance assertion \"id:%d\" for user \"uid:%d\", because %s", assertionModifyReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[account_balance_assertions.AssertionModifyHandler] user \"uid:%d\" has updated balance assertion \"id:%d\" successfully", uid, assertionModifyReq.Id)
+
+	return newAssertion.ToAccountBalanceAssertionInfoResponse(), nil
+}
+
+// AssertionDeleteHandler deletes an existed account balance assertion by request parameters for current user
+func (a *AccountBalanceAssertionsApi) AssertionDeleteHandler(c *core.WebContext) (any, *errs.Error) {
+	var assertionDeleteReq models.AccountBalanceAssertionDeleteRequest
+	err := c.ShouldBindJSON(&assertionDeleteReq)
+
+	if err != nil {
+		log.Warnf(c, "[account_balance_assertions.AssertionDeleteHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	err = a.assertions.DeleteAssertion(c, uid, assertionDeleteReq.Id)
+
+	if err != nil {
+		log.Errorf(c, "[account_balance_assertions.AssertionDeleteHandler] failed to delete balance assertion \"id:%d\" for user \"uid:%d\", because %s", assertionDeleteReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[account_balance_assertions.AssertionDeleteHandler] user \"uid:%d\" has deleted balance assertion \"id:%d\"", uid, assertionDeleteReq.Id)
+	return true, nil
+}
+
+// AssertionCheckHandler returns the check results of account balance assertions of current user
+func (a *AccountBalanceAssertionsApi) AssertionCheckHandler(c *core.WebContext) (any, *errs.Error) {
+	var assertionCheckReq models.AccountBalanceAssertionCheckRequest
+	err := c.ShouldBindQuery(&assertionCheckReq)
+
+	if err != nil {
+		log.Warnf(c, "[account_balance_assertions.AssertionCheckHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	results, err := a.assertions.CheckAssertions(c, uid, assertionCheckReq.AccountId)
+
+	if err != nil {
+		log.Errorf(c, "[account_balance_assertions.AssertionCheckHandler] failed to check balance assertions for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	transactionIds := make([]int64, 0)
+
+	for i := 0; i < len(results); i++ {
+		for j := 0; j < len(results[i].TransactionsSinceLastPassed); j++ {
+			transactionIds = append(transactionIds, results[i].TransactionsSinceLastPassed[j].TransactionId)
+		}
+	}
+
+	var allTransactionTagIds map[int64][]int64
+
+	if len(transactionIds) > 0 {
+		allTransactionTagIds, err = a.transactionTags.GetAllTagIdsOfTransactions(c, uid, transactionIds)
+
+		if err != nil {
+			log.Errorf(c, "[account_balance_assertions.AssertionCheckHandler] failed to get transactions tag ids for user \"uid:%d\", because %s", uid, err.Error())
+			return nil, errs.Or(err, errs.ErrOperationFailed)
+		}
+	}
+
+	resultResps := make([]*models.AccountBalanceAssertionCheckResultResponse, 0, len(results))
+
+	for i := 0; i < len(results); i++ {
+		if assertionCheckReq.FailedOnly && results[i].Passed {
+			continue
+		}
+
+		resultResps = append(resultResps, results[i].ToAccountBalanceAssertionCheckResultResponse(allTransactionTagIds))
+	}
+
+	return resultResps, nil
+}
+
+func (a *AccountBalanceAssertionsApi) createNewAssertionModel(uid int64, assertionCreateReq *models.AccountBalanceAssertionCreateRequest) *models.AccountBalanceAssertion {
+	return &models.AccountBalanceAssertion{
+		Uid:               uid,
+		AccountId:         assertionCreateReq.AccountId,
+		AssertionTime:     assertionCreateReq.Time,
+		TimezoneUtcOffset: assertionCreateReq.UtcOffset,
+		ExpectedBalance:   assertionCreateReq.ExpectedBalance,
+		Comment:           assertionCreateReq.Comment,
+	}
+}
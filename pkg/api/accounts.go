@@ -18,7 +18,8 @@ import (
 type AccountsApi struct {
 	ApiUsingConfig
 	ApiUsingDuplicateChecker
-	accounts *services.AccountService
+	accounts             *services.AccountService
+	userCustomCurrencies *services.UserCustomCurrencyService
 }
 
 // Initialize an account api singleton instance
@@ -33,7 +34,8 @@ var (
 			},
 			container: duplicatechecker.Container,
 		},
-		accounts: services.Accounts,
+		accounts:             services.Accounts,
+		userCustomCurrencies: services.UserCustomCurrencies,
 	}
 )
 
@@ -239,9 +241,28 @@ func (a *AccountsApi) AccountCreateHandler(c *core.WebContext) (any, *errs.Error
 		return nil, errs.Or(err, errs.ErrOperationFailed)
 	}
 
+	currencies := []string{accountCreateReq.Currency}
+
+	for i := 0; i < len(accountCreateReq.SubAccounts); i++ {
+		currencies = append(currencies, accountCreateReq.SubAccounts[i].Currency)
+	}
+
+	customCurrencyPrecisions, err := a.getCustomCurrencyPrecisions(c, uid, currencies)
+
+	if err != nil {
+		log.Warnf(c, "[accounts.AccountCreateHandler] failed to get user defined currencies for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
 	mainAccount := a.createNewAccountModel(uid, &accountCreateReq, false, maxOrderId+1)
 	childrenAccounts, childrenAccountBalanceTimes := a.createSubAccountModels(uid, &accountCreateReq)
 
+	a.setCustomCurrencyPrecision(mainAccount, customCurrencyPrecisions)
+
+	for i := 0; i < len(childrenAccounts); i++ {
+		a.setCustomCurrencyPrecision(childrenAccounts[i], customCurrencyPrecisions)
+	}
+
 	if a.CurrentConfig().EnableDuplicateSubmissionsCheck && accountCreateReq.ClientSessionId != "" {
 		found, remark := a.GetSubmissionRemark(duplicatechecker.DUPLICATE_CHECKER_TYPE_NEW_ACCOUNT, uid, accountCreateReq.ClientSessionId)
 
@@ -428,6 +449,21 @@ func (a *AccountsApi) AccountModifyHandler(c *core.WebContext) (any, *errs.Error
 		}
 	}
 
+	var newSubAccountCurrencies []string
+
+	for i := 0; i < len(accountModifyReq.SubAccounts); i++ {
+		if accountModifyReq.SubAccounts[i].Id == 0 && accountModifyReq.SubAccounts[i].Currency != nil {
+			newSubAccountCurrencies = append(newSubAccountCurrencies, *accountModifyReq.SubAccounts[i].Currency)
+		}
+	}
+
+	customCurrencyPrecisions, err := a.getCustomCurrencyPrecisions(c, uid, newSubAccountCurrencies)
+
+	if err != nil {
+		log.Warnf(c, "[accounts.AccountModifyHandler] failed to get user defined currencies for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
 	anythingUpdate := false
 	var toUpdateAccounts []*models.Account
 	var toAddAccounts []*models.Account
@@ -464,6 +500,7 @@ func (a *AccountsApi) AccountModifyHandler(c *core.WebContext) (any, *errs.Error
 			anythingUpdate = true
 			maxOrderId = maxOrderId + 1
 			newSubAccount := a.createNewSubAccountModelForModify(uid, mainAccount.Type, subAccountReq, maxOrderId)
+			a.setCustomCurrencyPrecision(newSubAccount, customCurrencyPrecisions)
 			toAddAccounts = append(toAddAccounts, newSubAccount)
 
 			if subAccountReq.BalanceTime != nil {
@@ -703,6 +740,12 @@ func (a *AccountsApi) createNewAccountModel(uid int64, accountCreateReq *models.
 
 	if !isSubAccount && accountCreateReq.Category == models.ACCOUNT_CATEGORY_CREDIT_CARD {
 		accountExtend.CreditCardStatementDate = &accountCreateReq.CreditCardStatementDate
+
+		if accountCreateReq.CreditCardReminderDays > 0 {
+			accountExtend.CreditCardReminderDays = &accountCreateReq.CreditCardReminderDays
+			accountExtend.CreditCardReminderDigest = &accountCreateReq.CreditCardReminderDigest
+			accountExtend.CreditCardReminderUtcOffset = &accountCreateReq.CreditCardReminderUtcOffset
+		}
 	}
 
 	return &models.Account{
@@ -759,6 +802,16 @@ func (a *AccountsApi) getToUpdateAccount(uid int64, accountModifyReq *models.Acc
 
 	if !isSubAccount && accountModifyReq.Category == models.ACCOUNT_CATEGORY_CREDIT_CARD {
 		newAccountExtend.CreditCardStatementDate = &accountModifyReq.CreditCardStatementDate
+
+		if accountModifyReq.CreditCardReminderDays > 0 {
+			newAccountExtend.CreditCardReminderDays = &accountModifyReq.CreditCardReminderDays
+			newAccountExtend.CreditCardReminderDigest = &accountModifyReq.CreditCardReminderDigest
+			newAccountExtend.CreditCardReminderUtcOffset = &accountModifyReq.CreditCardReminderUtcOffset
+		}
+	}
+
+	if oldAccount.Extend != nil {
+		newAccountExtend.CurrencyPrecision = oldAccount.Extend.CurrencyPrecision
 	}
 
 	newAccount := &models.Account{
@@ -793,9 +846,66 @@ func (a *AccountsApi) getToUpdateAccount(uid int64, accountModifyReq *models.Acc
 		return newAccount
 	}
 
+	if newAccountExtend.GetCreditCardReminderDays() != oldAccountExtend.GetCreditCardReminderDays() ||
+		newAccountExtend.IsCreditCardReminderDigest() != oldAccountExtend.IsCreditCardReminderDigest() {
+		return newAccount
+	}
+
+	if (newAccountExtend.CreditCardReminderUtcOffset == nil) != (oldAccountExtend.CreditCardReminderUtcOffset == nil) ||
+		(newAccountExtend.CreditCardReminderUtcOffset != nil && *newAccountExtend.CreditCardReminderUtcOffset != *oldAccountExtend.CreditCardReminderUtcOffset) {
+		return newAccount
+	}
+
 	return nil
 }
 
+func (a *AccountsApi) getCustomCurrencyPrecisions(c *core.WebContext, uid int64, currencies []string) (map[string]int, error) {
+	var customCurrencies models.UserCustomCurrencies
+	customCurrencyPrecisions := make(map[string]int)
+
+	for i := 0; i < len(currencies); i++ {
+		currency := currencies[i]
+
+		if _, exists := validators.AllCurrencyNames[currency]; exists || currency == validators.ParentAccountCurrencyPlaceholder {
+			continue
+		}
+
+		if customCurrencies == nil {
+			allCustomCurrencies, err := a.userCustomCurrencies.GetAllCurrenciesByUid(c, uid)
+
+			if err != nil {
+				return nil, err
+			}
+
+			customCurrencies = allCustomCurrencies
+		}
+
+		customCurrency := customCurrencies.GetCurrencyByCode(currency)
+
+		if customCurrency == nil {
+			return nil, errs.ErrAccountCurrencyInvalid
+		}
+
+		customCurrencyPrecisions[currency] = int(customCurrency.Precision)
+	}
+
+	return customCurrencyPrecisions, nil
+}
+
+func (a *AccountsApi) setCustomCurrencyPrecision(account *models.Account, customCurrencyPrecisions map[string]int) {
+	precision, exists := customCurrencyPrecisions[account.Currency]
+
+	if !exists {
+		return
+	}
+
+	if account.Extend == nil {
+		account.Extend = &models.AccountExtend{}
+	}
+
+	account.Extend.CurrencyPrecision = &precision
+}
+
 func (a *AccountsApi) getToDeleteSubAccountIds(accountModifyReq *models.AccountModifyRequest, mainAccount *models.Account, accountAndSubAccounts []*models.Account) []int64 {
 	newSubAccountIds := make(map[int64]bool, len(accountModifyReq.SubAccounts))
 
@@ -1,6 +1,8 @@
 package api
 
 import (
+	"bytes"
+	"encoding/csv"
 	"fmt"
 	"strings"
 	"time"
@@ -20,14 +22,18 @@ const pageCountForDataExport = 1000
 // DataManagementsApi represents data management api
 type DataManagementsApi struct {
 	ApiUsingConfig
-	tokens       *services.TokenService
-	users        *services.UserService
-	accounts     *services.AccountService
-	transactions *services.TransactionService
-	categories   *services.TransactionCategoryService
-	tags         *services.TransactionTagService
-	pictures     *services.TransactionPictureService
-	templates    *services.TransactionTemplateService
+	tokens        *services.TokenService
+	users         *services.UserService
+	accounts      *services.AccountService
+	transactions  *services.TransactionService
+	categories    *services.TransactionCategoryService
+	tags          *services.TransactionTagService
+	pictures      *services.TransactionPictureService
+	templates     *services.TransactionTemplateService
+	rules         *services.TransactionRuleService
+	webhooks      *services.WebhookService
+	exchangeRates *services.UserExchangeRateService
+	currencies    *services.UserCustomCurrencyService
 }
 
 // Initialize a data management api singleton instance
@@ -36,14 +42,18 @@ var (
 		ApiUsingConfig: ApiUsingConfig{
 			container: settings.Container,
 		},
-		tokens:       services.Tokens,
-		users:        services.Users,
-		accounts:     services.Accounts,
-		transactions: services.Transactions,
-		categories:   services.TransactionCategories,
-		tags:         services.TransactionTags,
-		pictures:     services.TransactionPictures,
-		templates:    services.TransactionTemplates,
+		tokens:        services.Tokens,
+		users:         services.Users,
+		accounts:      services.Accounts,
+		transactions:  services.Transactions,
+		categories:    services.TransactionCategories,
+		tags:          services.TransactionTags,
+		pictures:      services.TransactionPictures,
+		templates:     services.TransactionTemplates,
+		rules:         services.TransactionRules,
+		webhooks:      services.Webhooks,
+		exchangeRates: services.UserExchangeRates,
+		currencies:    services.UserCustomCurrencies,
 	}
 )
 
@@ -57,6 +67,16 @@ func (a *DataManagementsApi) ExportDataToEzbookkeepingTSVHandler(c *core.WebCont
 	return a.getExportedFileContent(c, "tsv")
 }
 
+// ExportUserExchangeRatesToCSVHandler returns exported user defined exchange rates in csv format
+func (a *DataManagementsApi) ExportUserExchangeRatesToCSVHandler(c *core.WebContext) ([]byte, string, *errs.Error) {
+	return a.getExportedUserExchangeRatesFileContent(c, "csv", ',')
+}
+
+// ExportUserExchangeRatesToTSVHandler returns exported user defined exchange rates in tsv format
+func (a *DataManagementsApi) ExportUserExchangeRatesToTSVHandler(c *core.WebContext) ([]byte, string, *errs.Error) {
+	return a.getExportedUserExchangeRatesFileContent(c, "tsv", '\t')
+}
+
 // DataStatisticsHandler returns user data statistics
 func (a *DataManagementsApi) DataStatisticsHandler(c *core.WebContext) (any, *errs.Error) {
 	uid := c.GetCurrentUid()
@@ -151,6 +171,34 @@ func (a *DataManagementsApi) ClearDataHandler(c *core.WebContext) (any, *errs.Er
 		return nil, errs.ErrNotPermittedToPerformThisAction
 	}
 
+	err = a.exchangeRates.DeleteAllExchangeRates(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[data_managements.ClearDataHandler] failed to delete all user defined exchange rates, because %s", err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	err = a.currencies.DeleteAllCurrencies(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[data_managements.ClearDataHandler] failed to delete all user defined currencies, because %s", err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	err = a.webhooks.DeleteAllWebhooks(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[data_managements.ClearDataHandler] failed to delete all webhooks, because %s", err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	err = a.rules.DeleteAllRules(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[data_managements.ClearDataHandler] failed to delete all transaction rules, because %s", err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
 	err = a.templates.DeleteAllTemplates(c, uid)
 
 	if err != nil {
@@ -264,16 +312,78 @@ func (a *DataManagementsApi) getExportedFileContent(c *core.WebContext, fileType
 		return nil, "", errs.Or(err, errs.ErrOperationFailed)
 	}
 
-	fileName := a.getFileName(user, timezone, fileType)
+	fileName := a.getFileName(user, timezone, "", fileType)
 
 	return result, fileName, nil
 }
 
-func (a *DataManagementsApi) getFileName(user *models.User, timezone *time.Location, fileExtension string) string {
+func (a *DataManagementsApi) getExportedUserExchangeRatesFileContent(c *core.WebContext, fileType string, separator rune) ([]byte, string, *errs.Error) {
+	if !a.CurrentConfig().EnableDataExport {
+		return nil, "", errs.ErrDataExportNotAllowed
+	}
+
+	timezone := time.Local
+	utcOffset, err := c.GetClientTimezoneOffset()
+
+	if err != nil {
+		log.Warnf(c, "[data_managements.ExportUserExchangeRatesHandler] cannot get client timezone offset, because %s", err.Error())
+	} else {
+		timezone = time.FixedZone("Client Timezone", int(utcOffset)*60)
+	}
+
+	uid := c.GetCurrentUid()
+	user, err := a.users.GetUserById(c, uid)
+
+	if err != nil {
+		if !errs.IsCustomError(err) {
+			log.Warnf(c, "[data_managements.ExportUserExchangeRatesHandler] failed to get user for user \"uid:%d\", because %s", uid, err.Error())
+		}
+
+		return nil, "", errs.ErrUserNotFound
+	}
+
+	if user.FeatureRestriction.Contains(core.USER_FEATURE_RESTRICTION_TYPE_EXPORT_TRANSACTION) {
+		return nil, "", errs.ErrNotPermittedToPerformThisAction
+	}
+
+	exchangeRates, err := a.exchangeRates.GetAllExchangeRatesByUid(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[data_managements.ExportUserExchangeRatesHandler] failed to get user defined exchange rates for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, "", errs.ErrOperationFailed
+	}
+
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+	writer.Comma = separator
+
+	err = writer.Write([]string{"Base Currency", "Target Currency", "Rate", "Start Date", "End Date", "Comment"})
+
+	for i := 0; i < len(exchangeRates) && err == nil; i++ {
+		exchangeRate := exchangeRates[i].ToUserExchangeRateInfoResponse()
+		err = writer.Write([]string{exchangeRate.BaseCurrency, exchangeRate.TargetCurrency, exchangeRate.Rate, exchangeRate.StartDate, exchangeRate.EndDate, exchangeRate.Comment})
+	}
+
+	if err == nil {
+		writer.Flush()
+		err = writer.Error()
+	}
+
+	if err != nil {
+		log.Errorf(c, "[data_managements.ExportUserExchangeRatesHandler] failed to get %s format exported data for \"uid:%d\", because %s", fileType, uid, err.Error())
+		return nil, "", errs.ErrOperationFailed
+	}
+
+	fileName := a.getFileName(user, timezone, "_exchange_rates", fileType)
+
+	return buffer.Bytes(), fileName, nil
+}
+
+func (a *DataManagementsApi) getFileName(user *models.User, timezone *time.Location, fileNameSuffix string, fileExtension string) string {
 	currentTime := utils.FormatUnixTimeToLongDateTimeWithoutSecond(time.Now().Unix(), timezone)
 	currentTime = strings.Replace(currentTime, "-", "_", -1)
 	currentTime = strings.Replace(currentTime, " ", "_", -1)
 	currentTime = strings.Replace(currentTime, ":", "_", -1)
 
-	return fmt.Sprintf("%s_%s.%s", user.Username, currentTime, fileExtension)
+	return fmt.Sprintf("%s_%s%s.%s", user.Username, currentTime, fileNameSuffix, fileExtension)
 }
@@ -1,11 +1,6 @@
 package api
 
 import (
-	"crypto/tls"
-	"fmt"
-	"io"
-	"net/http"
-	"sort"
 	"time"
 
 	"github.com/mayswind/ezbookkeeping/pkg/core"
@@ -13,13 +8,18 @@ import (
 	"github.com/mayswind/ezbookkeeping/pkg/exchangerates"
 	"github.com/mayswind/ezbookkeeping/pkg/log"
 	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/services"
 	"github.com/mayswind/ezbookkeeping/pkg/settings"
 	"github.com/mayswind/ezbookkeeping/pkg/utils"
 )
 
+const maxHistoricalExchangeRatesDateRange = 366 * 24 * time.Hour
+
 // ExchangeRatesApi represents exchange rate api
 type ExchangeRatesApi struct {
 	ApiUsingConfig
+	exchangeRateHistories *services.ExchangeRateHistoryService
+	userExchangeRates     *services.UserExchangeRateService
 }
 
 // Initialize a exchange rate api singleton instance
@@ -28,109 +28,112 @@ var (
 		ApiUsingConfig: ApiUsingConfig{
 			container: settings.Container,
 		},
+		exchangeRateHistories: services.ExchangeRateHistories,
+		userExchangeRates:     services.UserExchangeRates,
 	}
 )
 
-// LatestExchangeRateHandler returns latest exchange rate data
+// LatestExchangeRateHandler returns latest exchange rate data, the user defined exchange rates which are effective today are merged over
 func (a *ExchangeRatesApi) LatestExchangeRateHandler(c *core.WebContext) (any, *errs.Error) {
-	dataSource := exchangerates.Container.Current
+	uid := c.GetCurrentUid()
+	exchangeRateResponse, err := exchangerates.Container.GetLatestExchangeRates(c, uid, a.CurrentConfig())
 
-	if dataSource == nil {
-		return nil, errs.ErrInvalidExchangeRatesDataSource
+	if err != nil {
+		return nil, errs.Or(err, errs.ErrFailedToRequestRemoteApi)
 	}
 
-	uid := c.GetCurrentUid()
+	if uid <= 0 {
+		return exchangeRateResponse, nil
+	}
 
-	transport := http.DefaultTransport.(*http.Transport).Clone()
-	utils.SetProxyUrl(transport, a.CurrentConfig().ExchangeRatesProxy)
+	userExchangeRates, err := a.userExchangeRates.GetAllExchangeRatesByUid(c, uid)
 
-	if a.CurrentConfig().ExchangeRatesSkipTLSVerify {
-		transport.TLSClientConfig = &tls.Config{
-			InsecureSkipVerify: true,
-		}
+	if err != nil {
+		log.Errorf(c, "[exchange_rates.LatestExchangeRateHandler] failed to get user defined exchange rates for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
 	}
 
-	client := &http.Client{
-		Transport: transport,
-		Timeout:   time.Duration(a.CurrentConfig().ExchangeRatesRequestTimeout) * time.Millisecond,
+	timezone := time.Local
+	utcOffset, err := c.GetClientTimezoneOffset()
+
+	if err != nil {
+		log.Warnf(c, "[exchange_rates.LatestExchangeRateHandler] cannot get client timezone offset, because %s", err.Error())
+	} else {
+		timezone = time.FixedZone("Client Timezone", int(utcOffset)*60)
 	}
 
-	requests, err := dataSource.BuildRequests()
+	today := utils.FormatTimeToNumericDate(time.Now().In(timezone))
+
+	return exchangeRateResponse.WithUserExchangeRates(userExchangeRates.GetEffectiveExchangeRates(today)), nil
+}
+
+// HistoricalExchangeRateHandler returns the stored exchange rate data which are effective on the specified date
+func (a *ExchangeRatesApi) HistoricalExchangeRateHandler(c *core.WebContext) (any, *errs.Error) {
+	var exchangeRateGetReq models.HistoricalExchangeRateGetRequest
+	err := c.ShouldBindQuery(&exchangeRateGetReq)
 
 	if err != nil {
-		log.Errorf(c, "[exchange_rates.LatestExchangeRateHandler] failed to build requests for user \"uid:%d\", because %s", uid, err.Error())
-		return nil, errs.ErrFailedToRequestRemoteApi
+		log.Warnf(c, "[exchange_rates.HistoricalExchangeRateHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
 	}
 
-	exchangeRateResps := make([]*models.LatestExchangeRateResponse, 0, len(requests))
+	date, err := utils.ParseFromLongDateFirstTime(exchangeRateGetReq.Date, 0)
 
-	for i := 0; i < len(requests); i++ {
-		req := requests[i]
+	if err != nil {
+		log.Warnf(c, "[exchange_rates.HistoricalExchangeRateHandler] cannot parse date \"%s\", because %s", exchangeRateGetReq.Date, err.Error())
+		return nil, errs.ErrExchangeRateDateInvalid
+	}
 
-		if len(req.Header.Values("User-Agent")) < 1 {
-			req.Header.Set("User-Agent", fmt.Sprintf("ezBookkeeping/%s", settings.Version))
-		} else if req.Header.Get("User-Agent") == "" {
-			req.Header.Del("User-Agent")
-		}
+	exchangeRates, err := a.exchangeRateHistories.GetExchangeRatesByDate(c, a.CurrentConfig().ExchangeRatesDataSource, utils.FormatTimeToNumericDate(date))
 
-		resp, err := client.Do(req)
+	if err != nil {
+		log.Errorf(c, "[exchange_rates.HistoricalExchangeRateHandler] failed to get historical exchange rates of \"%s\", because %s", exchangeRateGetReq.Date, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
 
-		if err != nil {
-			log.Errorf(c, "[exchange_rates.LatestExchangeRateHandler] failed to request latest exchange rate data for user \"uid:%d\", because %s", uid, err.Error())
-			return nil, errs.ErrFailedToRequestRemoteApi
-		}
+	exchangeRateResps := models.ToHistoricalExchangeRateResponses(exchangeRates)
 
-		if resp.StatusCode != 200 {
-			log.Errorf(c, "[exchange_rates.LatestExchangeRateHandler] failed to get latest exchange rate data response for user \"uid:%d\", because response code is not 200", uid)
-			return nil, errs.ErrFailedToRequestRemoteApi
-		}
+	if len(exchangeRateResps) < 1 {
+		return nil, errs.ErrHistoricalExchangeRatesNotFound
+	}
 
-		defer resp.Body.Close()
-		body, err := io.ReadAll(resp.Body)
+	return exchangeRateResps[0], nil
+}
 
-		log.Debugf(c, "[exchange_rates.LatestExchangeRateHandler] response#%d is %s", i, body)
+// HistoricalExchangeRatesInRangeHandler returns the stored exchange rate data of every day in the specified date range
+func (a *ExchangeRatesApi) HistoricalExchangeRatesInRangeHandler(c *core.WebContext) (any, *errs.Error) {
+	var exchangeRateRangeReq models.HistoricalExchangeRateRangeRequest
+	err := c.ShouldBindQuery(&exchangeRateRangeReq)
 
-		exchangeRateResp, err := dataSource.Parse(c, body)
+	if err != nil {
+		log.Warnf(c, "[exchange_rates.HistoricalExchangeRatesInRangeHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
 
-		if err != nil {
-			log.Errorf(c, "[exchange_rates.LatestExchangeRateHandler] failed to parse response for user \"uid:%d\", because %s", uid, err.Error())
-			return nil, errs.Or(err, errs.ErrFailedToRequestRemoteApi)
-		}
+	startDate, err := utils.ParseFromLongDateFirstTime(exchangeRateRangeReq.StartDate, 0)
 
-		exchangeRateResps = append(exchangeRateResps, exchangeRateResp)
+	if err != nil {
+		log.Warnf(c, "[exchange_rates.HistoricalExchangeRatesInRangeHandler] cannot parse start date \"%s\", because %s", exchangeRateRangeReq.StartDate, err.Error())
+		return nil, errs.ErrExchangeRateDateInvalid
 	}
 
-	lastExchangeRateResponse := exchangeRateResps[len(exchangeRateResps)-1]
-	allExchangeRatesMap := make(map[string]string)
-
-	for i := 0; i < len(exchangeRateResps); i++ {
-		exchangeRateResp := exchangeRateResps[i]
+	endDate, err := utils.ParseFromLongDateFirstTime(exchangeRateRangeReq.EndDate, 0)
 
-		for j := 0; j < len(exchangeRateResp.ExchangeRates); j++ {
-			exchangeRate := exchangeRateResp.ExchangeRates[j]
-			allExchangeRatesMap[exchangeRate.Currency] = exchangeRate.Rate
-		}
+	if err != nil {
+		log.Warnf(c, "[exchange_rates.HistoricalExchangeRatesInRangeHandler] cannot parse end date \"%s\", because %s", exchangeRateRangeReq.EndDate, err.Error())
+		return nil, errs.ErrExchangeRateDateInvalid
 	}
 
-	allExchangeRatesMap[lastExchangeRateResponse.BaseCurrency] = "1"
-	allExchangeRates := make(models.LatestExchangeRateSlice, 0, len(allExchangeRatesMap))
-
-	for currency, rate := range allExchangeRatesMap {
-		allExchangeRates = append(allExchangeRates, &models.LatestExchangeRate{
-			Currency: currency,
-			Rate:     rate,
-		})
+	if endDate.Before(startDate) || endDate.Sub(startDate) > maxHistoricalExchangeRatesDateRange {
+		return nil, errs.ErrExchangeRateDateRangeInvalid
 	}
 
-	sort.Sort(allExchangeRates)
+	exchangeRates, err := a.exchangeRateHistories.GetExchangeRatesInDateRange(c, a.CurrentConfig().ExchangeRatesDataSource, utils.FormatTimeToNumericDate(startDate), utils.FormatTimeToNumericDate(endDate))
 
-	finalExchangeRateResponse := &models.LatestExchangeRateResponse{
-		DataSource:    lastExchangeRateResponse.DataSource,
-		ReferenceUrl:  lastExchangeRateResponse.ReferenceUrl,
-		UpdateTime:    lastExchangeRateResponse.UpdateTime,
-		BaseCurrency:  lastExchangeRateResponse.BaseCurrency,
-		ExchangeRates: allExchangeRates,
+	if err != nil {
+		log.Errorf(c, "[exchange_rates.HistoricalExchangeRatesInRangeHandler] failed to get historical exchange rates from \"%s\" to \"%s\", because %s", exchangeRateRangeReq.StartDate, exchangeRateRangeReq.EndDate, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
 	}
 
-	return finalExchangeRateResponse, nil
+	return models.ToHistoricalExchangeRateResponses(exchangeRates), nil
 }
@@ -0,0 +1,518 @@
+package api
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/exchangerates"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/services"
+	"github.com/mayswind/ezbookkeeping/pkg/settings"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+// FinancialReportsApi represents financial report api
+type FinancialReportsApi struct {
+	ApiUsingConfig
+	transactions *services.TransactionService
+	categories   *services.TransactionCategoryService
+	accounts     *services.AccountService
+	users        *services.UserService
+}
+
+// Initialize a financial report api singleton instance
+var (
+	FinancialReports = &FinancialReportsApi{
+		ApiUsingConfig: ApiUsingConfig{
+			container: settings.Container,
+		},
+		transactions: services.Transactions,
+		categories:   services.TransactionCategories,
+		accounts:     services.Accounts,
+		users:        services.Users,
+	}
+)
+
+// financialReportAmountConverter converts the amounts of accounts to the default currency of user and records the currencies which cannot be converted
+type financialReportAmountConverter struct {
+	targetCurrency          string
+	exchangeRates           models.ExchangeRatesMap
+	unconvertibleCurrencies map[string]bool
+}
+
+// IncomeStatementHandler returns income statement of current user
+func (a *FinancialReportsApi) IncomeStatementHandler(c *core.WebContext) (any, *errs.Error) {
+	var reportReq models.FinancialReportRequest
+	err := c.ShouldBindQuery(&reportReq)
+
+	if err != nil {
+		log.Warnf(c, "[financial_reports.IncomeStatementHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	return a.getIncomeStatement(c, &reportReq)
+}
+
+// IncomeStatementCsvHandler returns income statement of current user in csv format
+func (a *FinancialReportsApi) IncomeStatementCsvHandler(c *core.WebContext) ([]byte, string, *errs.Error) {
+	var reportReq models.FinancialReportRequest
+	err := c.ShouldBindQuery(&reportReq)
+
+	if err != nil {
+		log.Warnf(c, "[financial_reports.IncomeStatementCsvHandler] parse request failed, because %s", err.Error())
+		return nil, "", errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	incomeStatement, errResp := a.getIncomeStatement(c, &reportReq)
+
+	if errResp != nil {
+		return nil, "", errResp
+	}
+
+	records := incomeStatement.ToCsvRecords(reportReq.ComparisonType != models.FINANCIAL_REPORT_COMPARISON_TYPE_NONE)
+
+	return a.getCsvFileContent(c, records, "income_statement", incomeStatement.StartTime, incomeStatement.EndTime)
+}
+
+// BalanceSheetHandler returns balance sheet of current user
+func (a *FinancialReportsApi) BalanceSheetHandler(c *core.WebContext) (any, *errs.Error) {
+	var reportReq models.FinancialReportRequest
+	err := c.ShouldBindQuery(&reportReq)
+
+	if err != nil {
+		log.Warnf(c, "[financial_reports.BalanceSheetHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	return a.getBalanceSheet(c, &reportReq)
+}
+
+// BalanceSheetCsvHandler returns balance sheet of current user in csv format
+func (a *FinancialReportsApi) BalanceSheetCsvHandler(c *core.WebContext) ([]byte, string, *errs.Error) {
+	var reportReq models.FinancialReportRequest
+	err := c.ShouldBindQuery(&reportReq)
+
+	if err != nil {
+		log.Warnf(c, "[financial_reports.BalanceSheetCsvHandler] parse request failed, because %s", err.Error())
+		return nil, "", errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	balanceSheet, errResp := a.getBalanceSheet(c, &reportReq)
+
+	if errResp != nil {
+		return nil, "", errResp
+	}
+
+	records := balanceSheet.ToCsvRecords(reportReq.ComparisonType != models.FINANCIAL_REPORT_COMPARISON_TYPE_NONE)
+
+	return a.getCsvFileContent(c, records, "balance_sheet", reportReq.StartTime, balanceSheet.Time)
+}
+
+func (a *FinancialReportsApi) getIncomeStatement(c *core.WebContext, reportReq *models.FinancialReportRequest) (*models.IncomeStatementResponse, *errs.Error) {
+	if reportReq.StartTime > reportReq.EndTime {
+		return nil, errs.ErrReportTimeRangeInvalid
+	}
+
+	utcOffset, err := c.GetClientTimezoneOffset()
+
+	if err != nil {
+		log.Warnf(c, "[financial_reports.getIncomeStatement] cannot get client timezone offset, because %s", err.Error())
+		return nil, errs.ErrClientTimezoneOffsetInvalid
+	}
+
+	uid := c.GetCurrentUid()
+	user, err := a.users.GetUserById(c, uid)
+
+	if err != nil {
+		if !errs.IsCustomError(err) {
+			log.Warnf(c, "[financial_reports.getIncomeStatement] failed to get user for user \"uid:%d\", because %s", uid, err.Error())
+		}
+
+		return nil, errs.ErrUserNotFound
+	}
+
+	accounts, err := a.accounts.GetAllAccountsByUid(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[financial_reports.getIncomeStatement] failed to get all accounts for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	categories, err := a.categories.GetAllCategoriesByUid(c, uid, 0, -1)
+
+	if err != nil {
+		log.Errorf(c, "[financial_reports.getIncomeStatement] failed to get categories for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	accountMap := a.accounts.GetAccountMapByList(accounts)
+	amountConverter := a.getAmountConverter(c, uid, user.DefaultCurrency, accounts)
+
+	totalAmounts, err := a.transactions.GetAccountsAndCategoriesTotalIncomeAndExpense(c, uid, reportReq.StartTime, reportReq.EndTime, nil, false, models.TRANSACTION_TAG_FILTER_HAS_ANY, utcOffset, reportReq.UseTransactionTimezone)
+
+	if err != nil {
+		log.Errorf(c, "[financial_reports.getIncomeStatement] failed to get accounts and categories total income and expense for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	categoryAmounts := amountConverter.getCategoryAmounts(totalAmounts, accountMap)
+	comparisonCategoryAmounts := make(map[int64]int64)
+
+	incomeStatementResp := &models.IncomeStatementResponse{
+		StartTime: reportReq.StartTime,
+		EndTime:   reportReq.EndTime,
+		Currency:  user.DefaultCurrency,
+	}
+
+	comparisonStartTime, comparisonEndTime, hasComparison := reportReq.ComparisonType.GetComparisonTimeRange(reportReq.StartTime, reportReq.EndTime, time.FixedZone("Client Timezone", int(utcOffset)*60))
+
+	if hasComparison {
+		comparisonTotalAmounts, err := a.transactions.GetAccountsAndCategoriesTotalIncomeAndExpense(c, uid, comparisonStartTime, comparisonEndTime, nil, false, models.TRANSACTION_TAG_FILTER_HAS_ANY, utcOffset, reportReq.UseTransactionTimezone)
+
+		if err != nil {
+			log.Errorf(c, "[financial_reports.getIncomeStatement] failed to get accounts and categories total income and expense of comparison period for user \"uid:%d\", because %s", uid, err.Error())
+			return nil, errs.Or(err, errs.ErrOperationFailed)
+		}
+
+		comparisonCategoryAmounts = amountConverter.getCategoryAmounts(comparisonTotalAmounts, accountMap)
+		incomeStatementResp.ComparisonStartTime = comparisonStartTime
+		incomeStatementResp.ComparisonEndTime = comparisonEndTime
+	}
+
+	incomeStatementResp.Income = a.getCategoryTree("Income", models.CATEGORY_TYPE_INCOME, categories, categoryAmounts, comparisonCategoryAmounts)
+	incomeStatementResp.Expense = a.getCategoryTree("Expense", models.CATEGORY_TYPE_EXPENSE, categories, categoryAmounts, comparisonCategoryAmounts)
+	incomeStatementResp.NetIncome = incomeStatementResp.Income.Amount - incomeStatementResp.Expense.Amount
+	incomeStatementResp.ComparisonNetIncome = incomeStatementResp.Income.ComparisonAmount - incomeStatementResp.Expense.ComparisonAmount
+	incomeStatementResp.UnconvertibleCurrencies = amountConverter.getUnconvertibleCurrencies()
+
+	return incomeStatementResp, nil
+}
+
+func (a *FinancialReportsApi) getBalanceSheet(c *core.WebContext, reportReq *models.FinancialReportRequest) (*models.BalanceSheetResponse, *errs.Error) {
+	if reportReq.StartTime > reportReq.EndTime {
+		return nil, errs.ErrReportTimeRangeInvalid
+	}
+
+	utcOffset, err := c.GetClientTimezoneOffset()
+
+	if err != nil {
+		log.Warnf(c, "[financial_reports.getBalanceSheet] cannot get client timezone offset, because %s", err.Error())
+		return nil, errs.ErrClientTimezoneOffsetInvalid
+	}
+
+	uid := c.GetCurrentUid()
+	user, err := a.users.GetUserById(c, uid)
+
+	if err != nil {
+		if !errs.IsCustomError(err) {
+			log.Warnf(c, "[financial_reports.getBalanceSheet] failed to get user for user \"uid:%d\", because %s", uid, err.Error())
+		}
+
+		return nil, errs.ErrUserNotFound
+	}
+
+	accounts, err := a.accounts.GetAllAccountsByUid(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[financial_reports.getBalanceSheet] failed to get all accounts for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	amountConverter := a.getAmountConverter(c, uid, user.DefaultCurrency, accounts)
+
+	balanceChanges, err := a.transactions.GetAccountsBalanceChangesAfterTime(c, uid, reportReq.EndTime)
+
+	if err != nil {
+		log.Errorf(c, "[financial_reports.getBalanceSheet] failed to get accounts balance changes for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	accountBalances := amountConverter.getAccountBalances(accounts, balanceChanges)
+	comparisonAccountBalances := make(map[int64]int64)
+
+	balanceSheetResp := &models.BalanceSheetResponse{
+		Time:     reportReq.EndTime,
+		Currency: user.DefaultCurrency,
+	}
+
+	_, comparisonTime, hasComparison := reportReq.ComparisonType.GetComparisonTimeRange(reportReq.StartTime, reportReq.EndTime, time.FixedZone("Client Timezone", int(utcOffset)*60))
+
+	if hasComparison {
+		comparisonBalanceChanges, err := a.transactions.GetAccountsBalanceChangesAfterTime(c, uid, comparisonTime)
+
+		if err != nil {
+			log.Errorf(c, "[financial_reports.getBalanceSheet] failed to get accounts balance changes of comparison time for user \"uid:%d\", because %s", uid, err.Error())
+			return nil, errs.Or(err, errs.ErrOperationFailed)
+		}
+
+		comparisonAccountBalances = amountConverter.getAccountBalances(accounts, comparisonBalanceChanges)
+		balanceSheetResp.ComparisonTime = comparisonTime
+	}
+
+	balanceSheetResp.Assets = a.getAccountTree("Assets", false, accounts, accountBalances, comparisonAccountBalances)
+	balanceSheetResp.Liabilities = a.getAccountTree("Liabilities", true, accounts, accountBalances, comparisonAccountBalances)
+	balanceSheetResp.NetAssets = balanceSheetResp.Assets.Amount - balanceSheetResp.Liabilities.Amount
+	balanceSheetResp.ComparisonNetAssets = balanceSheetResp.Assets.ComparisonAmount - balanceSheetResp.Liabilities.ComparisonAmount
+	balanceSheetResp.UnconvertibleCurrencies = amountConverter.getUnconvertibleCurrencies()
+
+	return balanceSheetResp, nil
+}
+
+func (a *FinancialReportsApi) getAmountConverter(c *core.WebContext, uid int64, targetCurrency string, accounts []*models.Account) *financialReportAmountConverter {
+	amountConverter := &financialReportAmountConverter{
+		targetCurrency:          targetCurrency,
+		exchangeRates:           make(models.ExchangeRatesMap),
+		unconvertibleCurrencies: make(map[string]bool),
+	}
+
+	needExchangeRates := false
+
+	for i := 0; i < len(accounts); i++ {
+		if accounts[i].Type == models.ACCOUNT_TYPE_SINGLE_ACCOUNT && accounts[i].Currency != targetCurrency {
+			needExchangeRates = true
+			break
+		}
+	}
+
+	if !needExchangeRates {
+		return amountConverter
+	}
+
+	exchangeRatesResp, err := exchangerates.Container.GetLatestExchangeRates(c, uid, a.CurrentConfig())
+
+	if err != nil {
+		log.Warnf(c, "[financial_reports.getAmountConverter] failed to get latest exchange rates for user \"uid:%d\", because %s", uid, err.Error())
+		return amountConverter
+	}
+
+	amountConverter.exchangeRates = exchangeRatesResp.ToExchangeRatesMap()
+
+	return amountConverter
+}
+
+func (a *FinancialReportsApi) getCategoryTree(name string, categoryType models.TransactionCategoryType, categories []*models.TransactionCategory, categoryAmounts map[int64]int64, comparisonCategoryAmounts map[int64]int64) *models.FinancialReportItem {
+	rootItem := &models.FinancialReportItem{
+		Name:     name,
+		Children: make([]*models.FinancialReportItem, 0),
+	}
+
+	primaryCategoryItems := make(map[int64]*models.FinancialReportItem)
+
+	// categories are ordered by parent category id, so all primary categories are before secondary categories
+	for i := 0; i < len(categories); i++ {
+		category := categories[i]
+
+		if category.Type != categoryType {
+			continue
+		}
+
+		if category.ParentCategoryId == models.LevelOneTransactionCategoryParentId {
+			primaryCategoryItem := &models.FinancialReportItem{
+				Id:       category.CategoryId,
+				Name:     category.Name,
+				Children: make([]*models.FinancialReportItem, 0),
+			}
+
+			primaryCategoryItems[category.CategoryId] = primaryCategoryItem
+			rootItem.Children = append(rootItem.Children, primaryCategoryItem)
+			continue
+		}
+
+		primaryCategoryItem, exists := primaryCategoryItems[category.ParentCategoryId]
+
+		if !exists {
+			continue
+		}
+
+		primaryCategoryItem.Children = append(primaryCategoryItem.Children, &models.FinancialReportItem{
+			Id:               category.CategoryId,
+			Name:             category.Name,
+			Amount:           categoryAmounts[category.CategoryId],
+			ComparisonAmount: comparisonCategoryAmounts[category.CategoryId],
+		})
+	}
+
+	rootItem.UpdateSubtotals()
+	rootItem.RemoveEmptyChildren()
+
+	return rootItem
+}
+
+func (a *FinancialReportsApi) getAccountTree(name string, liability bool, accounts []*models.Account, accountBalances map[int64]int64, comparisonAccountBalances map[int64]int64) *models.FinancialReportItem {
+	rootItem := &models.FinancialReportItem{
+		Name:     name,
+		Children: make([]*models.FinancialReportItem, 0),
+	}
+
+	accountCategoryItems := make(map[models.AccountCategory]*models.FinancialReportItem)
+	parentAccountItems := make(map[int64]*models.FinancialReportItem)
+	sign := int64(1)
+
+	if liability {
+		sign = -1
+	}
+
+	// accounts are ordered by parent account id, so all parent accounts are before sub-accounts
+	for i := 0; i < len(accounts); i++ {
+		account := accounts[i]
+
+		if account.ParentAccountId != models.LevelOneAccountParentId {
+			parentAccountItem, exists := parentAccountItems[account.ParentAccountId]
+
+			if !exists {
+				continue
+			}
+
+			parentAccountItem.Children = append(parentAccountItem.Children, &models.FinancialReportItem{
+				Id:               account.AccountId,
+				Name:             account.Name,
+				Amount:           sign * accountBalances[account.AccountId],
+				ComparisonAmount: sign * comparisonAccountBalances[account.AccountId],
+			})
+
+			continue
+		}
+
+		if (liability && !account.Category.IsLiability()) || (!liability && !account.Category.IsAsset()) {
+			continue
+		}
+
+		accountCategoryItem, exists := accountCategoryItems[account.Category]
+
+		if !exists {
+			accountCategoryItem = &models.FinancialReportItem{
+				Id:       int64(account.Category),
+				Name:     account.Category.String(),
+				Children: make([]*models.FinancialReportItem, 0),
+			}
+
+			accountCategoryItems[account.Category] = accountCategoryItem
+		}
+
+		accountItem := &models.FinancialReportItem{
+			Id:               account.AccountId,
+			Name:             account.Name,
+			Amount:           sign * accountBalances[account.AccountId],
+			ComparisonAmount: sign * comparisonAccountBalances[account.AccountId],
+		}
+
+		if account.Type == models.ACCOUNT_TYPE_MULTI_SUB_ACCOUNTS {
+			accountItem.Children = make([]*models.FinancialReportItem, 0)
+			parentAccountItems[account.AccountId] = accountItem
+		}
+
+		accountCategoryItem.Children = append(accountCategoryItem.Children, accountItem)
+	}
+
+	for _, accountCategoryItem := range accountCategoryItems {
+		rootItem.Children = append(rootItem.Children, accountCategoryItem)
+	}
+
+	sort.Slice(rootItem.Children, func(i, j int) bool {
+		return rootItem.Children[i].Id < rootItem.Children[j].Id
+	})
+
+	rootItem.UpdateSubtotals()
+	rootItem.RemoveEmptyChildren()
+
+	return rootItem
+}
+
+func (a *FinancialReportsApi) getCsvFileContent(c *core.WebContext, records [][]string, reportName string, startUnixTime int64, endUnixTime int64) ([]byte, string, *errs.Error) {
+	timezone := time.Local
+	utcOffset, err := c.GetClientTimezoneOffset()
+
+	if err == nil {
+		timezone = time.FixedZone("Client Timezone", int(utcOffset)*60)
+	}
+
+	var buffer bytes.Buffer
+	csvWriter := csv.NewWriter(&buffer)
+	err = csvWriter.WriteAll(records)
+
+	if err != nil {
+		log.Errorf(c, "[financial_reports.getCsvFileContent] failed to write csv content, because %s", err.Error())
+		return nil, "", errs.ErrOperationFailed
+	}
+
+	fileName := fmt.Sprintf("%s_%s_%s.csv", reportName, utils.FormatUnixTimeToLongDate(startUnixTime, timezone), utils.FormatUnixTimeToLongDate(endUnixTime, timezone))
+
+	return buffer.Bytes(), fileName, nil
+}
+
+func (c *financialReportAmountConverter) getCategoryAmounts(totalAmounts []*models.Transaction, accountMap map[int64]*models.Account) map[int64]int64 {
+	categoryAmounts := make(map[int64]int64)
+
+	for i := 0; i < len(totalAmounts); i++ {
+		totalAmountItem := totalAmounts[i]
+		account, exists := accountMap[totalAmountItem.AccountId]
+
+		if !exists {
+			continue
+		}
+
+		amount, success := c.convertAmount(totalAmountItem.Amount, account)
+
+		if success {
+			categoryAmounts[totalAmountItem.CategoryId] += amount
+		}
+	}
+
+	return categoryAmounts
+}
+
+func (c *financialReportAmountConverter) getAccountBalances(accounts []*models.Account, balanceChanges map[int64]int64) map[int64]int64 {
+	accountBalances := make(map[int64]int64, len(accounts))
+
+	for i := 0; i < len(accounts); i++ {
+		account := accounts[i]
+
+		if account.Type != models.ACCOUNT_TYPE_SINGLE_ACCOUNT {
+			continue
+		}
+
+		amount, success := c.convertAmount(account.Balance-balanceChanges[account.AccountId], account)
+
+		if success {
+			accountBalances[account.AccountId] = amount
+		}
+	}
+
+	return accountBalances
+}
+
+func (c *financialReportAmountConverter) convertAmount(amount int64, account *models.Account) (int64, bool) {
+	if amount == 0 {
+		return 0, true
+	}
+
+	convertedAmount, success := c.exchangeRates.ConvertAmountWithPrecision(amount, account.Currency, account.GetCurrencyPrecision(), c.targetCurrency, models.DEFAULT_CURRENCY_PRECISION)
+
+	if !success {
+		c.unconvertibleCurrencies[account.Currency] = true
+	}
+
+	return convertedAmount, success
+}
+
+func (c *financialReportAmountConverter) getUnconvertibleCurrencies() []string {
+	if len(c.unconvertibleCurrencies) < 1 {
+		return nil
+	}
+
+	currencies := make([]string, 0, len(c.unconvertibleCurrencies))
+
+	for currency := range c.unconvertibleCurrencies {
+		currencies = append(currencies, currency)
+	}
+
+	sort.Strings(currencies)
+
+	return currencies
+}
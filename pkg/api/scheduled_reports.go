@@ -0,0 +1,214 @@
+package api
+
+import (
+	"sort"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/services"
+	"github.com/mayswind/ezbookkeeping/pkg/settings"
+)
+
+// ScheduledReportsApi represents scheduled report api
+type ScheduledReportsApi struct {
+	ApiUsingConfig
+	scheduledReports *services.ScheduledReportService
+	users            *services.UserService
+}
+
+// Initialize a scheduled report api singleton instance
+var (
+	ScheduledReports = &ScheduledReportsApi{
+		ApiUsingConfig: ApiUsingConfig{
+			container: settings.Container,
+		},
+		scheduledReports: services.ScheduledReports,
+		users:            services.Users,
+	}
+)
+
+// ScheduledReportListHandler returns scheduled report list of current user
+func (a *ScheduledReportsApi) ScheduledReportListHandler(c *core.WebContext) (any, *errs.Error) {
+	uid := c.GetCurrentUid()
+	scheduledReports, err := a.scheduledReports.GetAllScheduledReportsByUid(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[scheduled_reports.ScheduledReportListHandler] failed to get scheduled reports for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	scheduledReportResps := make(models.ScheduledReportInfoResponseSlice, len(scheduledReports))
+
+	for i := 0; i < len(scheduledReports); i++ {
+		scheduledReportResps[i] = scheduledReports[i].ToScheduledReportInfoResponse()
+	}
+
+	sort.Sort(scheduledReportResps)
+
+	return scheduledReportResps, nil
+}
+
+// ScheduledReportGetHandler returns one specific scheduled report of current user
+func (a *ScheduledReportsApi) ScheduledReportGetHandler(c *core.WebContext) (any, *errs.Error) {
+	var scheduledReportGetReq models.ScheduledReportGetRequest
+	err := c.ShouldBindQuery(&scheduledReportGetReq)
+
+	if err != nil {
+		log.Warnf(c, "[scheduled_reports.ScheduledReportGetHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	scheduledReport, err := a.scheduledReports.GetScheduledReportByReportId(c, uid, scheduledReportGetReq.Id)
+
+	if err != nil {
+		log.Errorf(c, "[scheduled_reports.ScheduledReportGetHandler] failed to get scheduled report \"id:%d\" for user \"uid:%d\", because %s", scheduledReportGetReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	return scheduledReport.ToScheduledReportInfoResponse(), nil
+}
+
+// ScheduledReportCreateHandler saves a new scheduled report by request parameters for current user
+func (a *ScheduledReportsApi) ScheduledReportCreateHandler(c *core.WebContext) (any, *errs.Error) {
+	var scheduledReportCreateReq models.ScheduledReportCreateRequest
+	err := c.ShouldBindJSON(&scheduledReportCreateReq)
+
+	if err != nil {
+		log.Warnf(c, "[scheduled_reports.ScheduledReportCreateHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	utcOffset, err := c.GetClientTimezoneOffset()
+
+	if err != nil {
+		log.Warnf(c, "[scheduled_reports.ScheduledReportCreateHandler] cannot get client timezone offset, because %s", err.Error())
+		return nil, errs.ErrClientTimezoneOffsetInvalid
+	}
+
+	uid := c.GetCurrentUid()
+	user, err := a.getUserAndCheckAttachments(c, uid, scheduledReportCreateReq.AttachCsv)
+
+	if err != nil {
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	scheduledReport := &models.ScheduledReport{
+		Uid:               uid,
+		FrequencyType:     scheduledReportCreateReq.FrequencyType,
+		TimezoneUtcOffset: utcOffset,
+		AttachCsv:         scheduledReportCreateReq.AttachCsv,
+		AttachPdf:         scheduledReportCreateReq.AttachPdf,
+	}
+
+	err = a.scheduledReports.CreateScheduledReport(c, scheduledReport, user.FirstDayOfWeek)
+
+	if err != nil {
+		log.Errorf(c, "[scheduled_reports.ScheduledReportCreateHandler] failed to create scheduled report \"id:%d\" for user \"uid:%d\", because %s", scheduledReport.ReportId, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[scheduled_reports.ScheduledReportCreateHandler] user \"uid:%d\" has created a new scheduled report \"id:%d\" successfully", uid, scheduledReport.ReportId)
+
+	return scheduledReport.ToScheduledReportInfoResponse(), nil
+}
+
+// ScheduledReportModifyHandler saves an existed scheduled report by request parameters for current user
+func (a *ScheduledReportsApi) ScheduledReportModifyHandler(c *core.WebContext) (any, *errs.Error) {
+	var scheduledReportModifyReq models.ScheduledReportModifyRequest
+	err := c.ShouldBindJSON(&scheduledReportModifyReq)
+
+	if err != nil {
+		log.Warnf(c, "[scheduled_reports.ScheduledReportModifyHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	utcOffset, err := c.GetClientTimezoneOffset()
+
+	if err != nil {
+		log.Warnf(c, "[scheduled_reports.ScheduledReportModifyHandler] cannot get client timezone offset, because %s", err.Error())
+		return nil, errs.ErrClientTimezoneOffsetInvalid
+	}
+
+	uid := c.GetCurrentUid()
+	user, err := a.getUserAndCheckAttachments(c, uid, scheduledReportModifyReq.AttachCsv)
+
+	if err != nil {
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	scheduledReport, err := a.scheduledReports.GetScheduledReportByReportId(c, uid, scheduledReportModifyReq.Id)
+
+	if err != nil {
+		log.Errorf(c, "[scheduled_reports.ScheduledReportModifyHandler] failed to get scheduled report \"id:%d\" for user \"uid:%d\", because %s", scheduledReportModifyReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	newScheduledReport := &models.ScheduledReport{
+		ReportId:          scheduledReport.ReportId,
+		Uid:               uid,
+		FrequencyType:     scheduledReportModifyReq.FrequencyType,
+		TimezoneUtcOffset: utcOffset,
+		AttachCsv:         scheduledReportModifyReq.AttachCsv,
+		AttachPdf:         scheduledReportModifyReq.AttachPdf,
+		Disabled:          scheduledReportModifyReq.Disabled,
+		LastSentUnixTime:  scheduledReport.LastSentUnixTime,
+	}
+
+	err = a.scheduledReports.ModifyScheduledReport(c, newScheduledReport, user.FirstDayOfWeek)
+
+	if err != nil {
+		log.Errorf(c, "[scheduled_reports.ScheduledReportModifyHandler] failed to update scheduled report \"id:%d\" for user \"uid:%d\", because %s", scheduledReportModifyReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[scheduled_reports.ScheduledReportModifyHandler] user \"uid:%d\" has updated scheduled report \"id:%d\" successfully", uid, scheduledReportModifyReq.Id)
+
+	return newScheduledReport.ToScheduledReportInfoResponse(), nil
+}
+
+// ScheduledReportDeleteHandler deletes an existed scheduled report by request parameters for current user
+func (a *ScheduledReportsApi) ScheduledReportDeleteHandler(c *core.WebContext) (any, *errs.Error) {
+	var scheduledReportDeleteReq models.ScheduledReportDeleteRequest
+	err := c.ShouldBindJSON(&scheduledReportDeleteReq)
+
+	if err != nil {
+		log.Warnf(c, "[scheduled_reports.ScheduledReportDeleteHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	err = a.scheduledReports.DeleteScheduledReport(c, uid, scheduledReportDeleteReq.Id)
+
+	if err != nil {
+		log.Errorf(c, "[scheduled_reports.ScheduledReportDeleteHandler] failed to delete scheduled report \"id:%d\" for user \"uid:%d\", because %s", scheduledReportDeleteReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[scheduled_reports.ScheduledReportDeleteHandler] user \"uid:%d\" has deleted scheduled report \"id:%d\"", uid, scheduledReportDeleteReq.Id)
+	return true, nil
+}
+
+func (a *ScheduledReportsApi) getUserAndCheckAttachments(c *core.WebContext, uid int64, attachCsv bool) (*models.User, error) {
+	user, err := a.users.GetUserById(c, uid)
+
+	if err != nil {
+		if !errs.IsCustomError(err) {
+			log.Warnf(c, "[scheduled_reports.getUserAndCheckAttachments] failed to get user for user \"uid:%d\", because %s", uid, err.Error())
+		}
+
+		return nil, errs.ErrUserNotFound
+	}
+
+	if attachCsv && !a.CurrentConfig().EnableDataExport {
+		return nil, errs.ErrDataExportNotAllowed
+	}
+
+	if attachCsv && user.FeatureRestriction.Contains(core.USER_FEATURE_RESTRICTION_TYPE_EXPORT_TRANSACTION) {
+		return nil, errs.ErrNotPermittedToPerformThisAction
+	}
+
+	return user, nil
+}
@@ -0,0 +1,463 @@
+package api
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/services"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+const pageCountForApplyingTransactionRules = 1000
+
+// TransactionRulesApi represents transaction rule api
+type TransactionRulesApi struct {
+	rules           *services.TransactionRuleService
+	transactions    *services.TransactionService
+	categories      *services.TransactionCategoryService
+	transactionTags *services.TransactionTagService
+	accounts        *services.AccountService
+	users           *services.UserService
+}
+
+// Initialize a transaction rule api singleton instance
+var (
+	TransactionRules = &TransactionRulesApi{
+		rules:           services.TransactionRules,
+		transactions:    services.Transactions,
+		categories:      services.TransactionCategories,
+		transactionTags: services.TransactionTags,
+		accounts:        services.Accounts,
+		users:           services.Users,
+	}
+)
+
+// RuleListHandler returns transaction rule list of current user
+func (a *TransactionRulesApi) RuleListHandler(c *core.WebContext) (any, *errs.Error) {
+	uid := c.GetCurrentUid()
+	rules, err := a.rules.GetAllRulesByUid(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[transaction_rules.RuleListHandler] failed to get rules for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	ruleResps := make(models.TransactionRuleInfoResponseSlice, len(rules))
+
+	for i := 0; i < len(rules); i++ {
+		ruleResps[i] = rules[i].ToTransactionRuleInfoResponse()
+	}
+
+	sort.Sort(ruleResps)
+
+	return ruleResps, nil
+}
+
+// RuleGetHandler returns one specific transaction rule of current user
+func (a *TransactionRulesApi) RuleGetHandler(c *core.WebContext) (any, *errs.Error) {
+	var ruleGetReq models.TransactionRuleGetRequest
+	err := c.ShouldBindQuery(&ruleGetReq)
+
+	if err != nil {
+		log.Warnf(c, "[transaction_rules.RuleGetHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	rule, err := a.rules.GetRuleByRuleId(c, uid, ruleGetReq.Id)
+
+	if err != nil {
+		log.Errorf(c, "[transaction_rules.RuleGetHandler] failed to get rule \"id:%d\" for user \"uid:%d\", because %s", ruleGetReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	ruleResp := rule.ToTransactionRuleInfoResponse()
+
+	return ruleResp, nil
+}
+
+// RuleCreateHandler saves a new transaction rule by request parameters for current user
+func (a *TransactionRulesApi) RuleCreateHandler(c *core.WebContext) (any, *errs.Error) {
+	var ruleCreateReq models.TransactionRuleCreateRequest
+	err := c.ShouldBindJSON(&ruleCreateReq)
+
+	if err != nil {
+		log.Warnf(c, "[transaction_rules.RuleCreateHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	if len(ruleCreateReq.AddTagIds) > maximumTagsCountOfTransaction {
+		return nil, errs.ErrTransactionHasTooManyTags
+	}
+
+	uid := c.GetCurrentUid()
+
+	maxOrderId, err := a.rules.GetMaxDisplayOrder(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[transaction_rules.RuleCreateHandler] failed to get max display order for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	rule := &models.TransactionRule{
+		Uid:                  uid,
+		Name:                 ruleCreateReq.Name,
+		TransactionType:      ruleCreateReq.TransactionType,
+		MatchAllConditions:   ruleCreateReq.MatchAllConditions,
+		Conditions:           ruleCreateReq.Conditions,
+		CategoryId:           ruleCreateReq.CategoryId,
+		AddTagIds:            strings.Join(ruleCreateReq.AddTagIds, ","),
+		ReplaceComment:       ruleCreateReq.ReplaceComment,
+		Comment:              ruleCreateReq.Comment,
+		DestinationAccountId: ruleCreateReq.DestinationAccountId,
+		StopProcessing:       ruleCreateReq.StopProcessing,
+		DisplayOrder:         maxOrderId + 1,
+	}
+
+	err = a.checkRuleValid(c, uid, rule, ruleCreateReq.AddTagIds)
+
+	if err != nil {
+		log.Warnf(c, "[transaction_rules.RuleCreateHandler] rule is invalid for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	err = a.rules.CreateRule(c, rule)
+
+	if err != nil {
+		log.Errorf(c, "[transaction_rules.RuleCreateHandler] failed to create rule \"id:%d\" for user \"uid:%d\", because %s", rule.RuleId, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[transaction_rules.RuleCreateHandler] user \"uid:%d\" has created a new rule \"id:%d\" successfully", uid, rule.RuleId)
+
+	ruleResp := rule.ToTransactionRuleInfoResponse()
+
+	return ruleResp, nil
+}
+
+// RuleModifyHandler saves an existed transaction rule by request parameters for current user
+func (a *TransactionRulesApi) RuleModifyHandler(c *core.WebContext) (any, *errs.Error) {
+	var ruleModifyReq models.TransactionRuleModifyRequest
+	err := c.ShouldBindJSON(&ruleModifyReq)
+
+	if err != nil {
+		log.Warnf(c, "[transaction_rules.RuleModifyHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	if len(ruleModifyReq.AddTagIds) > maximumTagsCountOfTransaction {
+		return nil, errs.ErrTransactionHasTooManyTags
+	}
+
+	uid := c.GetCurrentUid()
+	rule, err := a.rules.GetRuleByRuleId(c, uid, ruleModifyReq.Id)
+
+	if err != nil {
+		log.Errorf(c, "[transaction_rules.RuleModifyHandler] failed to get rule \"id:%d\" for user \"uid:%d\", because %s", ruleModifyReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	newRule := &models.TransactionRule{
+		RuleId:               rule.RuleId,
+		Uid:                  uid,
+		Name:                 ruleModifyReq.Name,
+		TransactionType:      ruleModifyReq.TransactionType,
+		MatchAllConditions:   ruleModifyReq.MatchAllConditions,
+		Conditions:           ruleModifyReq.Conditions,
+		CategoryId:           ruleModifyReq.CategoryId,
+		AddTagIds:            strings.Join(ruleModifyReq.AddTagIds, ","),
+		ReplaceComment:       ruleModifyReq.ReplaceComment,
+		Comment:              ruleModifyReq.Comment,
+		DestinationAccountId: ruleModifyReq.DestinationAccountId,
+		StopProcessing:       ruleModifyReq.StopProcessing,
+		Disabled:             ruleModifyReq.Disabled,
+	}
+
+	err = a.checkRuleValid(c, uid, newRule, ruleModifyReq.AddTagIds)
+
+	if err != nil {
+		log.Warnf(c, "[transaction_rules.RuleModifyHandler] rule \"id:%d\" is invalid for user \"uid:%d\", because %s", ruleModifyReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	err = a.rules.ModifyRule(c, newRule)
+
+	if err != nil {
+		log.Errorf(c, "[transaction_rules.RuleModifyHandler] failed to update rule \"id:%d\" for user \"uid:%d\", because %s", ruleModifyReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[transaction_rules.RuleModifyHandler] user \"uid:%d\" has updated rule \"id:%d\" successfully", uid, ruleModifyReq.Id)
+
+	newRule.DisplayOrder = rule.DisplayOrder
+	ruleResp := newRule.ToTransactionRuleInfoResponse()
+
+	return ruleResp, nil
+}
+
+// RuleMoveHandler moves display order of existed transaction rules by request parameters for current user
+func (a *TransactionRulesApi) RuleMoveHandler(c *core.WebContext) (any, *errs.Error) {
+	var ruleMoveReq models.TransactionRuleMoveRequest
+	err := c.ShouldBindJSON(&ruleMoveReq)
+
+	if err != nil {
+		log.Warnf(c, "[transaction_rules.RuleMoveHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	rules := make([]*models.TransactionRule, len(ruleMoveReq.NewDisplayOrders))
+
+	for i := 0; i < len(ruleMoveReq.NewDisplayOrders); i++ {
+		newDisplayOrder := ruleMoveReq.NewDisplayOrders[i]
+		rule := &models.TransactionRule{
+			Uid:          uid,
+			RuleId:       newDisplayOrder.Id,
+			DisplayOrder: newDisplayOrder.DisplayOrder,
+		}
+
+		rules[i] = rule
+	}
+
+	err = a.rules.ModifyRuleDisplayOrders(c, uid, rules)
+
+	if err != nil {
+		log.Errorf(c, "[transaction_rules.RuleMoveHandler] failed to move rules for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[transaction_rules.RuleMoveHandler] user \"uid:%d\" has moved rules", uid)
+	return true, nil
+}
+
+// RuleDeleteHandler deletes an existed transaction rule by request parameters for current user
+func (a *TransactionRulesApi) RuleDeleteHandler(c *core.WebContext) (any, *errs.Error) {
+	var ruleDeleteReq models.TransactionRuleDeleteRequest
+	err := c.ShouldBindJSON(&ruleDeleteReq)
+
+	if err != nil {
+		log.Warnf(c, "[transaction_rules.RuleDeleteHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	err = a.rules.DeleteRule(c, uid, ruleDeleteReq.Id)
+
+	if err != nil {
+		log.Errorf(c, "[transaction_rules.RuleDeleteHandler] failed to delete rule \"id:%d\" for user \"uid:%d\", because %s", ruleDeleteReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[transaction_rules.RuleDeleteHandler] user \"uid:%d\" has deleted rule \"id:%d\"", uid, ruleDeleteReq.Id)
+	return true, nil
+}
+
+// RuleApplyHandler applies transaction rules to the existed transactions of current user
+func (a *TransactionRulesApi) RuleApplyHandler(c *core.WebContext) (any, *errs.Error) {
+	var ruleApplyReq models.TransactionRuleApplyRequest
+	err := c.ShouldBindJSON(&ruleApplyReq)
+
+	if err != nil {
+		log.Warnf(c, "[transaction_rules.RuleApplyHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	if ruleApplyReq.StartTime > 0 && ruleApplyReq.EndTime > 0 && ruleApplyReq.StartTime > ruleApplyReq.EndTime {
+		return nil, errs.ErrParameterInvalid
+	}
+
+	uid := c.GetCurrentUid()
+	user, err := a.users.GetUserById(c, uid)
+
+	if err != nil {
+		if !errs.IsCustomError(err) {
+			log.Errorf(c, "[transaction_rules.RuleApplyHandler] failed to get user, because %s", err.Error())
+		}
+
+		return nil, errs.ErrUserNotFound
+	}
+
+	var rules models.TransactionRuleSlice
+
+	if len(ruleApplyReq.RuleIds) > 0 {
+		ruleIds, err := utils.StringArrayToInt64Array(ruleApplyReq.RuleIds)
+
+		if err != nil {
+			log.Warnf(c, "[transaction_rules.RuleApplyHandler] parse rule ids failed, because %s", err.Error())
+			return nil, errs.ErrTransactionRuleIdInvalid
+		}
+
+		rules, err = a.rules.GetRulesByRuleIds(c, uid, ruleIds)
+	} else {
+		rules, err = a.rules.GetAllEnabledRulesByUid(c, uid)
+	}
+
+	if err != nil {
+		log.Errorf(c, "[transaction_rules.RuleApplyHandler] failed to get rules for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	result := &models.TransactionRuleApplyResponse{}
+
+	if len(rules) < 1 {
+		return result, nil
+	}
+
+	maxTransactionTime := int64(0)
+	minTransactionTime := int64(0)
+
+	if ruleApplyReq.EndTime > 0 {
+		maxTransactionTime = utils.GetMaxTransactionTimeFromUnixTime(ruleApplyReq.EndTime)
+	}
+
+	if ruleApplyReq.StartTime > 0 {
+		minTransactionTime = utils.GetMinTransactionTimeFromUnixTime(ruleApplyReq.StartTime)
+	}
+
+	for page := int32(1); ; page++ {
+		transactions, err := a.transactions.GetTransactionsByMaxTime(c, uid, maxTransactionTime, minTransactionTime, 0, nil, nil, nil, false, models.TRANSACTION_TAG_FILTER_HAS_ANY, "", "", nil, false, page, pageCountForApplyingTransactionRules, false, true)
+
+		if err != nil {
+			log.Errorf(c, "[transaction_rules.RuleApplyHandler] failed to get transactions for user \"uid:%d\", because %s", uid, err.Error())
+			return nil, errs.Or(err, errs.ErrOperationFailed)
+		}
+
+		if len(transactions) < 1 {
+			break
+		}
+
+		transactionIds := make([]int64, len(transactions))
+
+		for i := 0; i < len(transactions); i++ {
+			transactionIds[i] = transactions[i].TransactionId
+		}
+
+		allTransactionTagIds, err := a.transactionTags.GetAllTagIdsOfTransactions(c, uid, transactionIds)
+
+		if err != nil {
+			log.Errorf(c, "[transaction_rules.RuleApplyHandler] failed to get transactions tag ids for user \"uid:%d\", because %s", uid, err.Error())
+			return nil, errs.Or(err, errs.ErrOperationFailed)
+		}
+
+		for i := 0; i < len(transactions); i++ {
+			transaction := transactions[i]
+
+			if transaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_IN {
+				continue
+			}
+
+			oldTagIds := allTransactionTagIds[transaction.TransactionId]
+			newTransaction := *transaction
+			newTagIds, matched := rules.ApplyTo(&newTransaction, append([]int64{}, oldTagIds...), "", "")
+
+			if !matched {
+				continue
+			}
+
+			result.MatchedCount++
+
+			addTagIds := utils.Int64SliceMinus(newTagIds, oldTagIds)
+
+			if newTransaction.CategoryId == transaction.CategoryId &&
+				newTransaction.Comment == transaction.Comment &&
+				newTransaction.RelatedAccountId == transaction.RelatedAccountId &&
+				len(addTagIds) < 1 {
+				continue
+			}
+
+			if len(newTagIds) > maximumTagsCountOfTransaction ||
+				!user.CanEditTransactionByTransactionTime(transaction.TransactionTime, transaction.TimezoneUtcOffset) {
+				result.FailedCount++
+				continue
+			}
+
+			if ruleApplyReq.DryRun {
+				result.ModifiedCount++
+				continue
+			}
+
+			err = a.transactions.ModifyTransaction(c, &newTransaction, len(oldTagIds), addTagIds, nil, nil, nil)
+
+			if err != nil {
+				log.Warnf(c, "[transaction_rules.RuleApplyHandler] failed to apply rules to transaction \"id:%d\" for user \"uid:%d\", because %s", transaction.TransactionId, uid, err.Error())
+				result.FailedCount++
+				continue
+			}
+
+			result.ModifiedCount++
+		}
+
+		if len(transactions) < pageCountForApplyingTransactionRules {
+			break
+		}
+	}
+
+	log.Infof(c, "[transaction_rules.RuleApplyHandler] user \"uid:%d\" has applied rules, %d transactions matched, %d transactions modified, %d transactions failed", uid, result.MatchedCount, result.ModifiedCount, result.FailedCount)
+
+	return result, nil
+}
+
+func (a *TransactionRulesApi) checkRuleValid(c core.Context, uid int64, rule *models.TransactionRule, addTagIds []string) error {
+	if !rule.IsConditionsValid() {
+		return errs.ErrTransactionRuleConditionInvalid
+	}
+
+	if !rule.IsActionsValid() {
+		return errs.ErrTransactionRuleActionInvalid
+	}
+
+	if rule.CategoryId > 0 {
+		category, err := a.categories.GetCategoryByCategoryId(c, uid, rule.CategoryId)
+
+		if err != nil {
+			return err
+		}
+
+		if category.ParentCategoryId == models.LevelOneTransactionCategoryParentId {
+			return errs.ErrCannotUsePrimaryCategoryForTransaction
+		}
+
+		if (rule.TransactionType == models.TRANSACTION_TYPE_INCOME && category.Type != models.CATEGORY_TYPE_INCOME) ||
+			(rule.TransactionType == models.TRANSACTION_TYPE_EXPENSE && category.Type != models.CATEGORY_TYPE_EXPENSE) ||
+			(rule.TransactionType == models.TRANSACTION_TYPE_TRANSFER && category.Type != models.CATEGORY_TYPE_TRANSFER) {
+			return errs.ErrTransactionCategoryTypeInvalid
+		}
+	}
+
+	if len(addTagIds) > 0 {
+		tagIds, err := utils.StringArrayToInt64Array(addTagIds)
+
+		if err != nil {
+			return errs.ErrTransactionTagIdInvalid
+		}
+
+		tagIds = utils.ToUniqueInt64Slice(tagIds)
+		tags, err := a.transactionTags.GetTagsByTagIds(c, uid, tagIds)
+
+		if err != nil {
+			return err
+		}
+
+		if len(tags) != len(tagIds) {
+			return errs.ErrTransactionTagNotFound
+		}
+	}
+
+	if rule.DestinationAccountId > 0 {
+		accounts, err := a.accounts.GetAccountsByAccountIds(c, uid, []int64{rule.DestinationAccountId})
+
+		if err != nil {
+			return err
+		}
+
+		if _, exists := accounts[rule.DestinationAccountId]; !exists {
+			return errs.ErrDestinationAccountNotFound
+		}
+	}
+
+	return nil
+}
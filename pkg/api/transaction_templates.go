@@ -8,6 +8,7 @@ import (
 	"github.com/mayswind/ezbookkeeping/pkg/core"
 	"github.com/mayswind/ezbookkeeping/pkg/duplicatechecker"
 	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/expressions"
 	"github.com/mayswind/ezbookkeeping/pkg/log"
 	"github.com/mayswind/ezbookkeeping/pkg/models"
 	"github.com/mayswind/ezbookkeeping/pkg/services"
@@ -16,12 +17,16 @@ import (
 )
 
 const maximumTagsCountOfTemplate = 10
+const maximumScheduledTransactionForecastDays = 366 * 2
 
 // TransactionTemplatesApi represents transaction template api
 type TransactionTemplatesApi struct {
 	ApiUsingConfig
 	ApiUsingDuplicateChecker
-	templates *services.TransactionTemplateService
+	templates             *services.TransactionTemplateService
+	accounts              *services.AccountService
+	users                 *services.UserService
+	recurringTransactions *services.RecurringTransactionService
 }
 
 // Initialize a transaction template api singleton instance
@@ -36,7 +41,10 @@ var (
 			},
 			container: duplicatechecker.Container,
 		},
-		templates: services.TransactionTemplates,
+		templates:             services.TransactionTemplates,
+		accounts:              services.Accounts,
+		users:                 services.Users,
+		recurringTransactions: services.RecurringTransactions,
 	}
 )
 
@@ -137,12 +145,6 @@ func (a *TransactionTemplatesApi) TemplateCreateHandler(c *core.WebContext) (any
 			templateCreateReq.ScheduledTimezoneUtcOffset == nil {
 			return nil, errs.ErrScheduledTransactionFrequencyInvalid
 		}
-
-		if *templateCreateReq.ScheduledFrequencyType == models.TRANSACTION_SCHEDULE_FREQUENCY_TYPE_DISABLED && *templateCreateReq.ScheduledFrequency != "" {
-			return nil, errs.ErrScheduledTransactionFrequencyInvalid
-		} else if *templateCreateReq.ScheduledFrequencyType != models.TRANSACTION_SCHEDULE_FREQUENCY_TYPE_DISABLED && *templateCreateReq.ScheduledFrequency == "" {
-			return nil, errs.ErrScheduledTransactionFrequencyInvalid
-		}
 	}
 
 	if len(templateCreateReq.TagIds) > maximumTagsCountOfTemplate {
@@ -158,8 +160,18 @@ func (a *TransactionTemplatesApi) TemplateCreateHandler(c *core.WebContext) (any
 		return nil, errs.Or(err, errs.ErrOperationFailed)
 	}
 
+	user, err := a.users.GetUserById(c, uid)
+
+	if err != nil {
+		if !errs.IsCustomError(err) {
+			log.Warnf(c, "[transaction_templates.TemplateCreateHandler] failed to get user for user \"uid:%d\", because %s", uid, err.Error())
+		}
+
+		return nil, errs.ErrUserNotFound
+	}
+
 	serverUtcOffset := utils.GetServerTimezoneOffsetMinutes()
-	template, err := a.createNewTemplateModel(uid, &templateCreateReq, maxOrderId+1)
+	template, err := a.createNewTemplateModel(c, user, &templateCreateReq, maxOrderId+1)
 
 	if err != nil {
 		log.Errorf(c, "[transaction_templates.TemplateCreateHandler] failed to create new template for user \"uid:%d\", because %s", uid, err.Error())
@@ -203,6 +215,111 @@ func (a *TransactionTemplatesApi) TemplateCreateHandler(c *core.WebContext) (any
 	return templateResp, nil
 }
 
+// TemplateCreateFromRecurringHandler saves a new scheduled transaction template converted from the detected recurring transaction series for current user
+func (a *TransactionTemplatesApi) TemplateCreateFromRecurringHandler(c *core.WebContext) (any, *errs.Error) {
+	var recurringConvertReq models.RecurringTransactionConvertRequest
+	err := c.ShouldBindJSON(&recurringConvertReq)
+
+	if err != nil {
+		log.Warnf(c, "[transaction_templates.TemplateCreateFromRecurringHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	if !a.CurrentConfig().EnableScheduledTransaction {
+		return nil, errs.ErrScheduledTransactionNotEnabled
+	}
+
+	uid := c.GetCurrentUid()
+	serverUtcOffset := utils.GetServerTimezoneOffsetMinutes()
+
+	if a.CurrentConfig().EnableDuplicateSubmissionsCheck && recurringConvertReq.ClientSessionId != "" {
+		found, remark := a.GetSubmissionRemark(duplicatechecker.DUPLICATE_CHECKER_TYPE_NEW_TEMPLATE, uid, recurringConvertReq.ClientSessionId)
+
+		if found {
+			log.Infof(c, "[transaction_templates.TemplateCreateFromRecurringHandler] another template \"id:%s\" has been created for user \"uid:%d\"", remark, uid)
+			templateId, err := utils.StringToInt64(remark)
+
+			if err == nil {
+				template, err := a.templates.GetTemplateByTemplateId(c, uid, templateId)
+
+				if err != nil {
+					log.Errorf(c, "[transaction_templates.TemplateCreateFromRecurringHandler] failed to get existed template \"id:%d\" for user \"uid:%d\", because %s", templateId, uid, err.Error())
+					return nil, errs.Or(err, errs.ErrOperationFailed)
+				}
+
+				return template.ToTransactionTemplateInfoResponse(serverUtcOffset), nil
+			}
+		}
+	}
+
+	allSeries, err := a.recurringTransactions.GetRecurringTransactionSeries(c, uid, recurringConvertReq.Months, recurringConvertReq.MinOccurrences)
+
+	if err != nil {
+		log.Errorf(c, "[transaction_templates.TemplateCreateFromRecurringHandler] failed to get recurring transaction series for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	var template *models.TransactionTemplate
+
+	for i := 0; i < len(allSeries); i++ {
+		if allSeries[i].Key == recurringConvertReq.Key {
+			template = allSeries[i].ToScheduledTransactionTemplate(strings.TrimSpace(recurringConvertReq.Name))
+			break
+		}
+	}
+
+	if template == nil {
+		log.Warnf(c, "[transaction_templates.TemplateCreateFromRecurringHandler] recurring transaction series \"%s\" is not found for user \"uid:%d\"", recurringConvertReq.Key, uid)
+		return nil, errs.ErrRecurringTransactionSeriesNotFound
+	}
+
+	if template.Name == "" {
+		return nil, errs.ErrTransactionTemplateNameIsEmpty
+	}
+
+	if !template.IsScheduledFrequencyValid() {
+		return nil, errs.ErrScheduledTransactionFrequencyInvalid
+	}
+
+	maxOrderId, err := a.templates.GetMaxDisplayOrder(c, uid, models.TRANSACTION_TEMPLATE_TYPE_SCHEDULE)
+
+	if err != nil {
+		log.Errorf(c, "[transaction_templates.TemplateCreateFromRecurringHandler] failed to get max display order for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	user, err := a.users.GetUserById(c, uid)
+
+	if err != nil {
+		if !errs.IsCustomError(err) {
+			log.Warnf(c, "[transaction_templates.TemplateCreateFromRecurringHandler] failed to get user for user \"uid:%d\", because %s", uid, err.Error())
+		}
+
+		return nil, errs.ErrUserNotFound
+	}
+
+	template.Uid = uid
+	template.ScheduledFirstDayOfWeek = user.FirstDayOfWeek
+	template.TagIds = ""
+	template.DisplayOrder = maxOrderId + 1
+	template.ScheduledAt = a.getUTCScheduledAt(template.ScheduledTimezoneUtcOffset)
+	template.ScheduledNextRunTime = template.GetNextScheduledTransactionTime(time.Now().Unix())
+
+	err = a.templates.CreateTemplate(c, template)
+
+	if err != nil {
+		log.Errorf(c, "[transaction_templates.TemplateCreateFromRecurringHandler] failed to create template \"id:%d\" for user \"uid:%d\", because %s", template.TemplateId, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[transaction_templates.TemplateCreateFromRecurringHandler] user \"uid:%d\" has created a new template \"id:%d\" from recurring transaction series successfully", uid, template.TemplateId)
+
+	a.SetSubmissionRemarkIfEnable(duplicatechecker.DUPLICATE_CHECKER_TYPE_NEW_TEMPLATE, uid, recurringConvertReq.ClientSessionId, utils.Int64ToString(template.TemplateId))
+	templateResp := template.ToTransactionTemplateInfoResponse(serverUtcOffset)
+
+	return templateResp, nil
+}
+
 // TemplateModifyHandler saves an existed transaction template by request parameters for current user
 func (a *TransactionTemplatesApi) TemplateModifyHandler(c *core.WebContext) (any, *errs.Error) {
 	var templateModifyReq models.TransactionTemplateModifyRequest
@@ -236,21 +353,26 @@ func (a *TransactionTemplatesApi) TemplateModifyHandler(c *core.WebContext) (any
 			templateModifyReq.ScheduledTimezoneUtcOffset == nil {
 			return nil, errs.ErrScheduledTransactionFrequencyInvalid
 		}
-
-		if *templateModifyReq.ScheduledFrequencyType == models.TRANSACTION_SCHEDULE_FREQUENCY_TYPE_DISABLED && *templateModifyReq.ScheduledFrequency != "" {
-			return nil, errs.ErrScheduledTransactionFrequencyInvalid
-		} else if *templateModifyReq.ScheduledFrequencyType != models.TRANSACTION_SCHEDULE_FREQUENCY_TYPE_DISABLED && *templateModifyReq.ScheduledFrequency == "" {
-			return nil, errs.ErrScheduledTransactionFrequencyInvalid
-		}
 	}
 
 	if len(templateModifyReq.TagIds) > maximumTagsCountOfTemplate {
 		return nil, errs.ErrTransactionTemplateHasTooManyTags
 	}
 
+	user, err := a.users.GetUserById(c, uid)
+
+	if err != nil {
+		if !errs.IsCustomError(err) {
+			log.Warnf(c, "[transaction_templates.TemplateModifyHandler] failed to get user for user \"uid:%d\", because %s", uid, err.Error())
+		}
+
+		return nil, errs.ErrUserNotFound
+	}
+
 	newTemplate := &models.TransactionTemplate{
 		TemplateId:           template.TemplateId,
 		Uid:                  uid,
+		TemplateType:         template.TemplateType,
 		Name:                 templateModifyReq.Name,
 		Type:                 templateModifyReq.Type,
 		CategoryId:           templateModifyReq.CategoryId,
@@ -268,6 +390,12 @@ func (a *TransactionTemplatesApi) TemplateModifyHandler(c *core.WebContext) (any
 		newTemplate.ScheduledFrequency = a.getOrderedFrequencyValues(*templateModifyReq.ScheduledFrequency)
 		newTemplate.ScheduledAt = a.getUTCScheduledAt(*templateModifyReq.ScheduledTimezoneUtcOffset)
 		newTemplate.ScheduledTimezoneUtcOffset = *templateModifyReq.ScheduledTimezoneUtcOffset
+		newTemplate.ScheduledInterval = templateModifyReq.ScheduledInterval
+		newTemplate.ScheduledFirstDayOfWeek = user.FirstDayOfWeek
+		newTemplate.ScheduledNextBusinessDay = templateModifyReq.ScheduledNextBusinessDay
+		newTemplate.AmountExpression = strings.TrimSpace(templateModifyReq.AmountExpression)
+		newTemplate.ReminderDays = templateModifyReq.ReminderDays
+		newTemplate.ReminderDigest = templateModifyReq.ReminderDigest
 
 		if templateModifyReq.ScheduledStartDate != nil {
 			startTime, err := utils.ParseFromLongDateFirstTime(*templateModifyReq.ScheduledStartDate, *templateModifyReq.ScheduledTimezoneUtcOffset)
@@ -296,6 +424,17 @@ func (a *TransactionTemplatesApi) TemplateModifyHandler(c *core.WebContext) (any
 		if newTemplate.ScheduledStartTime != nil && newTemplate.ScheduledEndTime != nil && *newTemplate.ScheduledStartTime > *newTemplate.ScheduledEndTime {
 			return nil, errs.ErrScheduledTransactionTemplateStartDataLaterThanEndDate
 		}
+
+		if !newTemplate.IsScheduledFrequencyValid() {
+			return nil, errs.ErrScheduledTransactionFrequencyInvalid
+		}
+
+		if !a.isAmountExpressionValid(c, newTemplate) {
+			return nil, errs.ErrScheduledTransactionAmountExpressionInvalid
+		}
+
+		newTemplate.ScheduledLastRunTime = template.ScheduledLastRunTime
+		newTemplate.ScheduledNextRunTime = newTemplate.GetNextScheduledTransactionTime(max(time.Now().Unix(), template.ScheduledLastRunTime))
 	}
 
 	if newTemplate.Name == template.Name &&
@@ -316,7 +455,13 @@ func (a *TransactionTemplatesApi) TemplateModifyHandler(c *core.WebContext) (any
 				newTemplate.ScheduledStartTime == template.ScheduledStartTime &&
 				newTemplate.ScheduledEndTime == template.ScheduledEndTime &&
 				newTemplate.ScheduledAt == template.ScheduledAt &&
-				newTemplate.ScheduledTimezoneUtcOffset == template.ScheduledTimezoneUtcOffset {
+				newTemplate.ScheduledTimezoneUtcOffset == template.ScheduledTimezoneUtcOffset &&
+				newTemplate.ScheduledInterval == template.ScheduledInterval &&
+				newTemplate.ScheduledFirstDayOfWeek == template.ScheduledFirstDayOfWeek &&
+				newTemplate.ScheduledNextBusinessDay == template.ScheduledNextBusinessDay &&
+				newTemplate.AmountExpression == template.AmountExpression &&
+				newTemplate.ReminderDays == template.ReminderDays &&
+				newTemplate.ReminderDigest == template.ReminderDigest {
 				return nil, errs.ErrNothingWillBeUpdated
 			}
 		}
@@ -332,7 +477,6 @@ func (a *TransactionTemplatesApi) TemplateModifyHandler(c *core.WebContext) (any
 	log.Infof(c, "[transaction_templates.TemplateModifyHandler] user \"uid:%d\" has updated template \"id:%d\" successfully", uid, templateModifyReq.Id)
 
 	serverUtcOffset := utils.GetServerTimezoneOffsetMinutes()
-	newTemplate.TemplateType = template.TemplateType
 	newTemplate.DisplayOrder = template.DisplayOrder
 	newTemplate.Hidden = template.Hidden
 	templateResp := newTemplate.ToTransactionTemplateInfoResponse(serverUtcOffset)
@@ -457,9 +601,49 @@ func (a *TransactionTemplatesApi) TemplateDeleteHandler(c *core.WebContext) (any
 	return true, nil
 }
 
-func (a *TransactionTemplatesApi) createNewTemplateModel(uid int64, templateCreateReq *models.TransactionTemplateCreateRequest, order int32) (*models.TransactionTemplate, error) {
+// ScheduledTransactionForecastHandler returns the future transactions created by scheduled transaction templates and the balance forecast of current user
+func (a *TransactionTemplatesApi) ScheduledTransactionForecastHandler(c *core.WebContext) (any, *errs.Error) {
+	var forecastReq models.ScheduledTransactionForecastRequest
+	err := c.ShouldBindQuery(&forecastReq)
+
+	if err != nil {
+		log.Warnf(c, "[transaction_templates.ScheduledTransactionForecastHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	if !a.CurrentConfig().EnableScheduledTransaction {
+		return nil, errs.ErrScheduledTransactionNotEnabled
+	}
+
+	// the scheduled transactions before now have already been created
+	startTime := max(forecastReq.StartTime, time.Now().Unix())
+	endTime := forecastReq.EndTime
+
+	if endTime < startTime || endTime-startTime > maximumScheduledTransactionForecastDays*24*60*60 {
+		return nil, errs.ErrScheduledTransactionForecastTimeRangeInvalid
+	}
+
+	uid := c.GetCurrentUid()
+	occurrences, err := a.templates.GetScheduledTransactionOccurrences(c, uid, startTime, endTime)
+
+	if err != nil {
+		log.Errorf(c, "[transaction_templates.ScheduledTransactionForecastHandler] failed to get scheduled transaction occurrences for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	accounts, err := a.accounts.GetAllAccountsByUid(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[transaction_templates.ScheduledTransactionForecastHandler] failed to get all accounts for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	return occurrences.ToScheduledTransactionForecastResponse(accounts, startTime, endTime), nil
+}
+
+func (a *TransactionTemplatesApi) createNewTemplateModel(c core.Context, user *models.User, templateCreateReq *models.TransactionTemplateCreateRequest, order int32) (*models.TransactionTemplate, error) {
 	template := &models.TransactionTemplate{
-		Uid:                  uid,
+		Uid:                  user.Uid,
 		TemplateType:         templateCreateReq.TemplateType,
 		Name:                 templateCreateReq.Name,
 		Type:                 templateCreateReq.Type,
@@ -479,6 +663,12 @@ func (a *TransactionTemplatesApi) createNewTemplateModel(uid int64, templateCrea
 		template.ScheduledFrequency = a.getOrderedFrequencyValues(*templateCreateReq.ScheduledFrequency)
 		template.ScheduledAt = a.getUTCScheduledAt(*templateCreateReq.ScheduledTimezoneUtcOffset)
 		template.ScheduledTimezoneUtcOffset = *templateCreateReq.ScheduledTimezoneUtcOffset
+		template.ScheduledInterval = templateCreateReq.ScheduledInterval
+		template.ScheduledFirstDayOfWeek = user.FirstDayOfWeek
+		template.ScheduledNextBusinessDay = templateCreateReq.ScheduledNextBusinessDay
+		template.AmountExpression = strings.TrimSpace(templateCreateReq.AmountExpression)
+		template.ReminderDays = templateCreateReq.ReminderDays
+		template.ReminderDigest = templateCreateReq.ReminderDigest
 
 		if templateCreateReq.ScheduledStartDate != nil {
 			startTime, err := utils.ParseFromLongDateFirstTime(*templateCreateReq.ScheduledStartDate, *templateCreateReq.ScheduledTimezoneUtcOffset)
@@ -505,11 +695,42 @@ func (a *TransactionTemplatesApi) createNewTemplateModel(uid int64, templateCrea
 		if template.ScheduledStartTime != nil && template.ScheduledEndTime != nil && *template.ScheduledStartTime > *template.ScheduledEndTime {
 			return nil, errs.ErrScheduledTransactionTemplateStartDataLaterThanEndDate
 		}
+
+		if !template.IsScheduledFrequencyValid() {
+			return nil, errs.ErrScheduledTransactionFrequencyInvalid
+		}
+
+		if !a.isAmountExpressionValid(c, template) {
+			return nil, errs.ErrScheduledTransactionAmountExpressionInvalid
+		}
+
+		template.ScheduledNextRunTime = template.GetNextScheduledTransactionTime(time.Now().Unix())
 	}
 
 	return template, nil
 }
 
+func (a *TransactionTemplatesApi) isAmountExpressionValid(c core.Context, template *models.TransactionTemplate) bool {
+	if template.AmountExpression == "" {
+		return true
+	}
+
+	variableNames, err := expressions.GetAmountExpressionVariableNames(c, template.AmountExpression)
+
+	if err != nil {
+		return false
+	}
+
+	for i := 0; i < len(variableNames); i++ {
+		if !template.IsAmountExpressionVariableSupported(variableNames[i]) {
+			log.Warnf(c, "[transaction_templates.isAmountExpressionValid] variable \"%s\" is not supported in amount expression", variableNames[i])
+			return false
+		}
+	}
+
+	return true
+}
+
 func (a *TransactionTemplatesApi) getUTCScheduledAt(scheduledTimezoneUtcOffset int16) int16 {
 	templateTimeZone := time.FixedZone("Template Timezone", int(scheduledTimezoneUtcOffset)*60)
 	transactionTime := time.Date(2020, 1, 1, 0, 0, 0, 0, templateTimeZone)
@@ -6,6 +6,7 @@ import (
 	"io"
 	"sort"
 	"strings"
+	"time"
 
 	orderedmap "github.com/wk8/go-ordered-map/v2"
 
@@ -15,26 +16,41 @@ import (
 	"github.com/mayswind/ezbookkeeping/pkg/core"
 	"github.com/mayswind/ezbookkeeping/pkg/duplicatechecker"
 	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/exchangerates"
+	"github.com/mayswind/ezbookkeeping/pkg/locales"
 	"github.com/mayswind/ezbookkeeping/pkg/log"
 	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/quickadd"
+	"github.com/mayswind/ezbookkeeping/pkg/reports"
 	"github.com/mayswind/ezbookkeeping/pkg/services"
 	"github.com/mayswind/ezbookkeeping/pkg/settings"
+	"github.com/mayswind/ezbookkeeping/pkg/suggestions"
 	"github.com/mayswind/ezbookkeeping/pkg/utils"
 )
 
 const maximumTagsCountOfTransaction = 10
 const maximumPicturesCountOfTransaction = 10
+const maximumCategorySuggestionCount = 3
+const minimumTrainingTransactionCountForSuggestion = 10
+const minimumCategorySuggestionProbabilityForImport = 0.6
 
 // TransactionsApi represents transaction api
 type TransactionsApi struct {
 	ApiUsingConfig
 	ApiUsingDuplicateChecker
-	transactions          *services.TransactionService
-	transactionCategories *services.TransactionCategoryService
-	transactionTags       *services.TransactionTagService
-	transactionPictures   *services.TransactionPictureService
-	accounts              *services.AccountService
-	users                 *services.UserService
+	transactions           *services.TransactionService
+	transactionCategories  *services.TransactionCategoryService
+	transactionTags        *services.TransactionTagService
+	transactionPictures    *services.TransactionPictureService
+	accounts               *services.AccountService
+	balanceAssertions      *services.AccountBalanceAssertionService
+	transactionRules       *services.TransactionRuleService
+	transactionSuggestions *services.TransactionSuggestionService
+	recurringTransactions  *services.RecurringTransactionService
+	transactionAnomalies   *services.TransactionAnomalyService
+	exchangeRateHistories  *services.ExchangeRateHistoryService
+	userExchangeRates      *services.UserExchangeRateService
+	users                  *services.UserService
 }
 
 // Initialize a transaction api singleton instance
@@ -49,12 +65,19 @@ var (
 			},
 			container: duplicatechecker.Container,
 		},
-		transactions:          services.Transactions,
-		transactionCategories: services.TransactionCategories,
-		transactionTags:       services.TransactionTags,
-		transactionPictures:   services.TransactionPictures,
-		accounts:              services.Accounts,
-		users:                 services.Users,
+		transactions:           services.Transactions,
+		transactionCategories:  services.TransactionCategories,
+		transactionTags:        services.TransactionTags,
+		transactionPictures:    services.TransactionPictures,
+		accounts:               services.Accounts,
+		balanceAssertions:      services.AccountBalanceAssertions,
+		transactionRules:       services.TransactionRules,
+		transactionSuggestions: services.TransactionSuggestions,
+		recurringTransactions:  services.RecurringTransactions,
+		transactionAnomalies:   services.TransactionAnomalies,
+		exchangeRateHistories:  services.ExchangeRateHistories,
+		userExchangeRates:      services.UserExchangeRates,
+		users:                  services.Users,
 	}
 )
 
@@ -96,7 +119,7 @@ func (a *TransactionsApi) TransactionCountHandler(c *core.WebContext) (any, *err
 		}
 	}
 
-	totalCount, err := a.transactions.GetTransactionCount(c, uid, transactionCountReq.MaxTime, transactionCountReq.MinTime, transactionCountReq.Type, allCategoryIds, allAccountIds, allTagIds, noTags, transactionCountReq.TagFilterType, transactionCountReq.AmountFilter, transactionCountReq.Keyword)
+	totalCount, err := a.transactions.GetTransactionCount(c, uid, transactionCountReq.MaxTime, transactionCountReq.MinTime, transactionCountReq.Type, allCategoryIds, allAccountIds, allTagIds, noTags, transactionCountReq.TagFilterType, transactionCountReq.AmountFilter, transactionCountReq.Keyword, transactionCountReq.GetGeoRadiusFilter(), transactionCountReq.AnomalyOnly)
 
 	if err != nil {
 		log.Errorf(c, "[transactions.TransactionCountHandler] failed to get transaction count for user \"uid:%d\", because %s", uid, err.Error())
@@ -167,7 +190,7 @@ func (a *TransactionsApi) TransactionListHandler(c *core.WebContext) (any, *errs
 	var totalCount int64
 
 	if transactionListReq.WithCount {
-		totalCount, err = a.transactions.GetTransactionCount(c, uid, transactionListReq.MaxTime, transactionListReq.MinTime, transactionListReq.Type, allCategoryIds, allAccountIds, allTagIds, noTags, transactionListReq.TagFilterType, transactionListReq.AmountFilter, transactionListReq.Keyword)
+		totalCount, err = a.transactions.GetTransactionCount(c, uid, transactionListReq.MaxTime, transactionListReq.MinTime, transactionListReq.Type, allCategoryIds, allAccountIds, allTagIds, noTags, transactionListReq.TagFilterType, transactionListReq.AmountFilter, transactionListReq.Keyword, transactionListReq.GetGeoRadiusFilter(), transactionListReq.AnomalyOnly)
 
 		if err != nil {
 			log.Errorf(c, "[transactions.TransactionListHandler] failed to get transaction count for user \"uid:%d\", because %s", uid, err.Error())
@@ -175,7 +198,7 @@ func (a *TransactionsApi) TransactionListHandler(c *core.WebContext) (any, *errs
 		}
 	}
 
-	transactions, err := a.transactions.GetTransactionsByMaxTime(c, uid, transactionListReq.MaxTime, transactionListReq.MinTime, transactionListReq.Type, allCategoryIds, allAccountIds, allTagIds, noTags, transactionListReq.TagFilterType, transactionListReq.AmountFilter, transactionListReq.Keyword, transactionListReq.Page, transactionListReq.Count, true, true)
+	transactions, err := a.transactions.GetTransactionsByMaxTime(c, uid, transactionListReq.MaxTime, transactionListReq.MinTime, transactionListReq.Type, allCategoryIds, allAccountIds, allTagIds, noTags, transactionListReq.TagFilterType, transactionListReq.AmountFilter, transactionListReq.Keyword, transactionListReq.GetGeoRadiusFilter(), transactionListReq.AnomalyOnly, transactionListReq.Page, transactionListReq.Count, true, true)
 
 	if err != nil {
 		log.Errorf(c, "[transactions.TransactionListHandler] failed to get transactions earlier than \"%d\" for user \"uid:%d\", because %s", transactionListReq.MaxTime, uid, err.Error())
@@ -198,6 +221,29 @@ func (a *TransactionsApi) TransactionListHandler(c *core.WebContext) (any, *errs
 		return nil, errs.Or(err, errs.ErrOperationFailed)
 	}
 
+	if transactionListReq.WithAnomalies {
+		transactionIds := make([]int64, len(transactionResult))
+
+		for i := 0; i < len(transactionResult); i++ {
+			transactionIds[i] = transactionResult[i].Id
+		}
+
+		allTransactionAnomalies, err := a.transactionAnomalies.GetAnomaliesByTransactionIds(c, uid, transactionIds)
+
+		if err != nil {
+			log.Errorf(c, "[transactions.TransactionListHandler] failed to get transaction anomalies for user \"uid:%d\", because %s", uid, err.Error())
+			return nil, errs.Or(err, errs.ErrOperationFailed)
+		}
+
+		for i := 0; i < len(transactionResult); i++ {
+			transactionAnomalies := allTransactionAnomalies[transactionResult[i].Id]
+
+			for j := 0; j < len(transactionAnomalies); j++ {
+				transactionResult[i].Anomalies = append(transactionResult[i].Anomalies, transactionAnomalies[j].ToTransactionAnomalyResponse())
+			}
+		}
+	}
+
 	transactionResps := &models.TransactionInfoPageWrapperResponse{
 		Items: transactionResult,
 	}
@@ -267,7 +313,7 @@ func (a *TransactionsApi) TransactionMonthListHandler(c *core.WebContext) (any,
 		}
 	}
 
-	transactions, err := a.transactions.GetTransactionsInMonthByPage(c, uid, transactionListReq.Year, transactionListReq.Month, transactionListReq.Type, allCategoryIds, allAccountIds, allTagIds, noTags, transactionListReq.TagFilterType, transactionListReq.AmountFilter, transactionListReq.Keyword)
+	transactions, err := a.transactions.GetTransactionsInMonthByPage(c, uid, transactionListReq.Year, transactionListReq.Month, transactionListReq.Type, allCategoryIds, allAccountIds, allTagIds, noTags, transactionListReq.TagFilterType, transactionListReq.AmountFilter, transactionListReq.Keyword, transactionListReq.GetGeoRadiusFilter())
 
 	if err != nil {
 		log.Errorf(c, "[transactions.TransactionMonthListHandler] failed to get transactions in month \"%d-%d\" for user \"uid:%d\", because %s", transactionListReq.Year, transactionListReq.Month, uid, err.Error())
@@ -319,6 +365,15 @@ func (a *TransactionsApi) TransactionStatisticsHandler(c *core.WebContext) (any,
 	}
 
 	uid := c.GetCurrentUid()
+
+	if statisticReq.Breakdown != "" {
+		return a.getTransactionStatisticBreakdown(c, uid, &statisticReq, allTagIds, noTags, utcOffset)
+	}
+
+	if statisticReq.CurrencyConversion != models.STATISTIC_CURRENCY_CONVERSION_TYPE_NONE {
+		return a.getConvertedTransactionStatistic(c, uid, &statisticReq, allTagIds, noTags, utcOffset)
+	}
+
 	totalAmounts, err := a.transactions.GetAccountsAndCategoriesTotalIncomeAndExpense(c, uid, statisticReq.StartTime, statisticReq.EndTime, allTagIds, noTags, statisticReq.TagFilterType, utcOffset, statisticReq.UseTransactionTimezone)
 
 	if err != nil {
@@ -382,32 +437,81 @@ func (a *TransactionsApi) TransactionStatisticsTrendsHandler(c *core.WebContext)
 	}
 
 	uid := c.GetCurrentUid()
-	allMonthlyTotalAmounts, err := a.transactions.GetAccountsAndCategoriesMonthlyIncomeAndExpense(c, uid, startYear, startMonth, endYear, endMonth, allTagIds, noTags, statisticTrendsReq.TagFilterType, utcOffset, statisticTrendsReq.UseTransactionTimezone)
+	user, err := a.users.GetUserById(c, uid)
+
+	if err != nil {
+		if !errs.IsCustomError(err) {
+			log.Warnf(c, "[transactions.TransactionStatisticsTrendsHandler] failed to get user for user \"uid:%d\", because %s", uid, err.Error())
+		}
+
+		return nil, errs.ErrUserNotFound
+	}
+
+	clientTimezone := time.FixedZone("Client Timezone", int(utcOffset)*60)
+	periodOptions := statisticTrendsReq.GetStatisticPeriodOptions(user.FirstDayOfWeek)
+
+	if statisticTrendsReq.CurrencyConversion != models.STATISTIC_CURRENCY_CONVERSION_TYPE_NONE {
+		transactions, err := a.transactions.GetIncomeAndExpenseTransactionsForPeriodicStatistics(c, uid, startYear, startMonth, endYear, endMonth, periodOptions, allTagIds, noTags, statisticTrendsReq.TagFilterType, utcOffset, statisticTrendsReq.UseTransactionTimezone)
+
+		if err != nil {
+			log.Errorf(c, "[transactions.TransactionStatisticsTrendsHandler] failed to get income and expense transactions for user \"uid:%d\", because %s", uid, err.Error())
+			return nil, errs.Or(err, errs.ErrOperationFailed)
+		}
+
+		amountConverter, err := a.getStatisticAmountConverter(c, user, transactions, statisticTrendsReq.CurrencyConversion, clientTimezone, statisticTrendsReq.UseTransactionTimezone)
+
+		if err != nil {
+			return nil, errs.Or(err, errs.ErrOperationFailed)
+		}
+
+		allPeriodicConvertedAmounts := amountConverter.GetPeriodicTotalAmounts(transactions, periodOptions)
+		statisticTrendsResp := make(models.TransactionStatisticTrendsResponseItemSlice, 0, len(allPeriodicConvertedAmounts))
+
+		for periodStartDate, periodicConvertedAmounts := range allPeriodicConvertedAmounts {
+			periodicStatisticResp := a.getTransactionStatisticTrendsResponseItem(periodStartDate, periodOptions, clientTimezone)
+			periodicStatisticResp.Items = make([]*models.TransactionStatisticResponseItem, len(periodicConvertedAmounts))
+
+			for i := 0; i < len(periodicConvertedAmounts); i++ {
+				convertedAmountItem := periodicConvertedAmounts[i]
+				periodicStatisticResp.Items[i] = &models.TransactionStatisticResponseItem{
+					CategoryId:  convertedAmountItem.CategoryId,
+					AccountId:   convertedAmountItem.AccountId,
+					TotalAmount: convertedAmountItem.Amount,
+					Currency:    convertedAmountItem.Currency,
+				}
+			}
+
+			statisticTrendsResp = append(statisticTrendsResp, periodicStatisticResp)
+		}
+
+		sort.Sort(statisticTrendsResp)
+
+		return statisticTrendsResp, nil
+	}
+
+	allPeriodicTotalAmounts, err := a.transactions.GetAccountsAndCategoriesPeriodicIncomeAndExpense(c, uid, startYear, startMonth, endYear, endMonth, periodOptions, allTagIds, noTags, statisticTrendsReq.TagFilterType, utcOffset, statisticTrendsReq.UseTransactionTimezone)
 
 	if err != nil {
 		log.Errorf(c, "[transactions.TransactionStatisticsTrendsHandler] failed to get accounts and categories total income and expense for user \"uid:%d\", because %s", uid, err.Error())
 		return nil, errs.Or(err, errs.ErrOperationFailed)
 	}
 
-	statisticTrendsResp := make(models.TransactionStatisticTrendsResponseItemSlice, 0, len(allMonthlyTotalAmounts))
+	statisticTrendsResp := make(models.TransactionStatisticTrendsResponseItemSlice, 0, len(allPeriodicTotalAmounts))
 
-	for yearMonth, monthlyTotalAmounts := range allMonthlyTotalAmounts {
-		monthlyStatisticResp := &models.TransactionStatisticTrendsResponseItem{
-			Year:  yearMonth / 100,
-			Month: yearMonth % 100,
-			Items: make([]*models.TransactionStatisticResponseItem, len(monthlyTotalAmounts)),
-		}
+	for periodStartDate, periodicTotalAmounts := range allPeriodicTotalAmounts {
+		periodicStatisticResp := a.getTransactionStatisticTrendsResponseItem(periodStartDate, periodOptions, clientTimezone)
+		periodicStatisticResp.Items = make([]*models.TransactionStatisticResponseItem, len(periodicTotalAmounts))
 
-		for i := 0; i < len(monthlyTotalAmounts); i++ {
-			totalAmountItem := monthlyTotalAmounts[i]
-			monthlyStatisticResp.Items[i] = &models.TransactionStatisticResponseItem{
+		for i := 0; i < len(periodicTotalAmounts); i++ {
+			totalAmountItem := periodicTotalAmounts[i]
+			periodicStatisticResp.Items[i] = &models.TransactionStatisticResponseItem{
 				CategoryId:  totalAmountItem.CategoryId,
 				AccountId:   totalAmountItem.AccountId,
 				TotalAmount: totalAmountItem.Amount,
 			}
 		}
 
-		statisticTrendsResp = append(statisticTrendsResp, monthlyStatisticResp)
+		statisticTrendsResp = append(statisticTrendsResp, periodicStatisticResp)
 	}
 
 	sort.Sort(statisticTrendsResp)
@@ -415,6 +519,187 @@ func (a *TransactionsApi) TransactionStatisticsTrendsHandler(c *core.WebContext)
 	return statisticTrendsResp, nil
 }
 
+// TransactionStatisticsLocationsHandler returns transaction location clusters of current user
+func (a *TransactionsApi) TransactionStatisticsLocationsHandler(c *core.WebContext) (any, *errs.Error) {
+	var statisticLocationsReq models.TransactionLocationStatisticRequest
+	err := c.ShouldBindQuery(&statisticLocationsReq)
+
+	if err != nil {
+		log.Warnf(c, "[transactions.TransactionStatisticsLocationsHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	utcOffset, err := c.GetClientTimezoneOffset()
+
+	if err != nil {
+		log.Warnf(c, "[transactions.TransactionStatisticsLocationsHandler] cannot get client timezone offset, because %s", err.Error())
+		return nil, errs.ErrClientTimezoneOffsetInvalid
+	}
+
+	var allTagIds []int64
+	noTags := statisticLocationsReq.TagIds == "none"
+
+	if !noTags {
+		allTagIds, err = a.getTagIds(statisticLocationsReq.TagIds)
+
+		if err != nil {
+			log.Warnf(c, "[transactions.TransactionStatisticsLocationsHandler] get transaction tag ids error, because %s", err.Error())
+			return nil, errs.Or(err, errs.ErrOperationFailed)
+		}
+	}
+
+	uid := c.GetCurrentUid()
+	transactions, err := a.transactions.GetIncomeAndExpenseTransactionsForStatistics(c, uid, statisticLocationsReq.StartTime, statisticLocationsReq.EndTime, allTagIds, noTags, statisticLocationsReq.TagFilterType, utcOffset, statisticLocationsReq.UseTransactionTimezone)
+
+	if err != nil {
+		log.Errorf(c, "[transactions.TransactionStatisticsLocationsHandler] failed to get income and expense transactions for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	accounts, err := a.accounts.GetAllAccountsByUid(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[transactions.TransactionStatisticsLocationsHandler] failed to get all accounts for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	clusterRadius := statisticLocationsReq.ClusterRadius
+
+	if clusterRadius <= 0 {
+		clusterRadius = models.DefaultTransactionLocationClusterRadius
+	}
+
+	statisticResp := &models.TransactionLocationStatisticResponse{
+		StartTime:     statisticLocationsReq.StartTime,
+		EndTime:       statisticLocationsReq.EndTime,
+		ClusterRadius: clusterRadius,
+		Clusters:      models.GetTransactionLocationClusters(transactions, a.accounts.GetAccountMapByList(accounts), statisticLocationsReq.Type, clusterRadius),
+	}
+
+	return statisticResp, nil
+}
+
+// TransactionReportPdfHandler returns transaction report of current user in pdf format
+func (a *TransactionsApi) TransactionReportPdfHandler(c *core.WebContext) ([]byte, string, *errs.Error) {
+	var reportReq models.TransactionReportRequest
+	err := c.ShouldBindQuery(&reportReq)
+
+	if err != nil {
+		log.Warnf(c, "[transactions.TransactionReportPdfHandler] parse request failed, because %s", err.Error())
+		return nil, "", errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	if reportReq.StartTime > reportReq.EndTime {
+		return nil, "", errs.ErrReportTimeRangeInvalid
+	}
+
+	utcOffset, err := c.GetClientTimezoneOffset()
+
+	if err != nil {
+		log.Warnf(c, "[transactions.TransactionReportPdfHandler] cannot get client timezone offset, because %s", err.Error())
+		return nil, "", errs.ErrClientTimezoneOffsetInvalid
+	}
+
+	uid := c.GetCurrentUid()
+	user, err := a.users.GetUserById(c, uid)
+
+	if err != nil {
+		if !errs.IsCustomError(err) {
+			log.Errorf(c, "[transactions.TransactionReportPdfHandler] failed to get user, because %s", err.Error())
+		}
+
+		return nil, "", errs.ErrUserNotFound
+	}
+
+	allAccountIds, err := a.getAccountOrSubAccountIds(c, reportReq.AccountIds, uid)
+
+	if err != nil {
+		log.Warnf(c, "[transactions.TransactionReportPdfHandler] get account error, because %s", err.Error())
+		return nil, "", errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	allCategoryIds, err := a.getCategoryOrSubCategoryIds(c, reportReq.CategoryIds, uid)
+
+	if err != nil {
+		log.Warnf(c, "[transactions.TransactionReportPdfHandler] get transaction category error, because %s", err.Error())
+		return nil, "", errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	var allTagIds []int64
+	noTags := reportReq.TagIds == "none"
+
+	if !noTags {
+		allTagIds, err = a.getTagIds(reportReq.TagIds)
+
+		if err != nil {
+			log.Warnf(c, "[transactions.TransactionReportPdfHandler] get transaction tag ids error, because %s", err.Error())
+			return nil, "", errs.Or(err, errs.ErrOperationFailed)
+		}
+	}
+
+	transactions, err := a.transactions.GetTransactionsInTimeRangeByFilters(c, uid, reportReq.StartTime, reportReq.EndTime, reportReq.Type, allCategoryIds, allAccountIds, allTagIds, noTags, reportReq.TagFilterType, reportReq.AmountFilter, reportReq.Keyword, utcOffset, reportReq.UseTransactionTimezone)
+
+	if err != nil {
+		log.Errorf(c, "[transactions.TransactionReportPdfHandler] failed to get transactions for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, "", errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	accounts, err := a.accounts.GetAllAccountsByUid(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[transactions.TransactionReportPdfHandler] failed to get all accounts for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, "", errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	categories, err := a.transactionCategories.GetAllCategoriesByUid(c, uid, 0, -1)
+
+	if err != nil {
+		log.Errorf(c, "[transactions.TransactionReportPdfHandler] failed to get categories for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, "", errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	exchangeRates := make(models.ExchangeRatesMap)
+	needExchangeRates := false
+
+	for i := 0; i < len(accounts); i++ {
+		if accounts[i].Type == models.ACCOUNT_TYPE_SINGLE_ACCOUNT && accounts[i].Currency != user.DefaultCurrency {
+			needExchangeRates = true
+			break
+		}
+	}
+
+	if needExchangeRates {
+		exchangeRatesResp, err := exchangerates.Container.GetLatestExchangeRates(c, uid, a.CurrentConfig())
+
+		if err != nil {
+			log.Warnf(c, "[transactions.TransactionReportPdfHandler] failed to get latest exchange rates for user \"uid:%d\", because %s", uid, err.Error())
+		} else {
+			exchangeRates = exchangeRatesResp.ToExchangeRatesMap()
+		}
+	}
+
+	clientTimezone := time.FixedZone("Client Timezone", int(utcOffset)*60)
+	report := reports.NewTransactionReport(transactions, a.accounts.GetAccountMapByList(accounts), a.transactionCategories.GetCategoryMapByList(categories), &reports.TransactionReportOptions{
+		StartTime:              reportReq.StartTime,
+		EndTime:                reportReq.EndTime,
+		Currency:               user.DefaultCurrency,
+		ExchangeRates:          exchangeRates,
+		ClientTimezone:         clientTimezone,
+		UseTransactionTimezone: reportReq.UseTransactionTimezone,
+	})
+
+	result, err := reports.RenderTransactionReportPdf(report, reports.NewReportFormatter(user), locales.GetLocaleTextItems(user.Language).TransactionReportTextItems, time.Now())
+
+	if err != nil {
+		log.Errorf(c, "[transactions.TransactionReportPdfHandler] failed to render transaction report for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, "", errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	fileName := fmt.Sprintf("transaction_report_%s_%s.pdf", utils.FormatUnixTimeToLongDate(reportReq.StartTime, clientTimezone), utils.FormatUnixTimeToLongDate(reportReq.EndTime, clientTimezone))
+
+	return result, fileName, nil
+}
+
 // TransactionAmountsHandler returns transaction amounts of current user
 func (a *TransactionsApi) TransactionAmountsHandler(c *core.WebContext) (any, *errs.Error) {
 	var transactionAmountsReq models.TransactionAmountsRequest
@@ -665,6 +950,119 @@ func (a *TransactionsApi) TransactionGetHandler(c *core.WebContext) (any, *errs.
 	return transactionResp, nil
 }
 
+// TransactionSuggestHandler returns the suggested categories and tags of the specified transaction according to the transaction history of current user
+func (a *TransactionsApi) TransactionSuggestHandler(c *core.WebContext) (any, *errs.Error) {
+	var transactionSuggestReq models.TransactionSuggestionRequest
+	err := c.ShouldBindQuery(&transactionSuggestReq)
+
+	if err != nil {
+		log.Warnf(c, "[transactions.TransactionSuggestHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	var transactionDbType models.TransactionDbType
+
+	if transactionSuggestReq.Type == models.TRANSACTION_TYPE_INCOME {
+		transactionDbType = models.TRANSACTION_DB_TYPE_INCOME
+	} else if transactionSuggestReq.Type == models.TRANSACTION_TYPE_EXPENSE {
+		transactionDbType = models.TRANSACTION_DB_TYPE_EXPENSE
+	} else if transactionSuggestReq.Type == models.TRANSACTION_TYPE_TRANSFER {
+		transactionDbType = models.TRANSACTION_DB_TYPE_TRANSFER_OUT
+	} else {
+		log.Warnf(c, "[transactions.TransactionSuggestHandler] transaction type is invalid")
+		return nil, errs.ErrTransactionTypeInvalid
+	}
+
+	uid := c.GetCurrentUid()
+	suggestionModels, err := a.transactionSuggestions.GetTransactionSuggestionModels(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[transactions.TransactionSuggestHandler] failed to get transaction suggestion models for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	categories, err := a.transactionCategories.GetAllCategoriesByUid(c, uid, 0, -1)
+
+	if err != nil {
+		log.Errorf(c, "[transactions.TransactionSuggestHandler] failed to get categories for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	tags, err := a.transactionTags.GetAllTagsByUid(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[transactions.TransactionSuggestHandler] failed to get tags for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	categoryMap := a.transactionCategories.GetCategoryMapByList(categories)
+	tagMap := a.transactionTags.GetTagMapByList(tags)
+	suggestionModel := suggestionModels[transactionDbType]
+
+	suggestionResp := &models.TransactionSuggestionResponse{
+		Categories: make([]*models.TransactionCategorySuggestionResponse, 0),
+		TagIds:     make([]string, 0),
+	}
+
+	if suggestionModel == nil || suggestionModel.SampleCount() < minimumTrainingTransactionCountForSuggestion {
+		return suggestionResp, nil
+	}
+
+	categoryPredictions := suggestionModel.SuggestCategories(transactionSuggestReq.Comment, transactionSuggestReq.Amount, transactionSuggestReq.AccountId)
+
+	for i := 0; i < len(categoryPredictions) && len(suggestionResp.Categories) < maximumCategorySuggestionCount; i++ {
+		if !a.isSuggestedCategoryAvailable(categoryMap, categoryPredictions[i].Label, transactionDbType) {
+			continue
+		}
+
+		suggestionResp.Categories = append(suggestionResp.Categories, &models.TransactionCategorySuggestionResponse{
+			CategoryId:  categoryPredictions[i].Label,
+			Probability: categoryPredictions[i].Probability,
+		})
+	}
+
+	suggestedTagIds := suggestionModel.SuggestTags(transactionSuggestReq.Comment, transactionSuggestReq.Amount, transactionSuggestReq.AccountId)
+
+	for i := 0; i < len(suggestedTagIds) && len(suggestionResp.TagIds) < maximumTagsCountOfTransaction; i++ {
+		if tag, exists := tagMap[suggestedTagIds[i]]; exists && !tag.Hidden {
+			suggestionResp.TagIds = append(suggestionResp.TagIds, utils.Int64ToString(suggestedTagIds[i]))
+		}
+	}
+
+	return suggestionResp, nil
+}
+
+// TransactionRecurringListHandler returns the recurring transaction series detected from the transactions of current user
+func (a *TransactionsApi) TransactionRecurringListHandler(c *core.WebContext) (any, *errs.Error) {
+	var recurringListReq models.RecurringTransactionListRequest
+	err := c.ShouldBindQuery(&recurringListReq)
+
+	if err != nil {
+		log.Warnf(c, "[transactions.TransactionRecurringListHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	allSeries, err := a.recurringTransactions.GetRecurringTransactionSeries(c, uid, recurringListReq.Months, recurringListReq.MinOccurrences)
+
+	if err != nil {
+		log.Errorf(c, "[transactions.TransactionRecurringListHandler] failed to get recurring transaction series for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	seriesResps := make([]*models.RecurringTransactionSeriesResponse, 0, len(allSeries))
+
+	for i := 0; i < len(allSeries); i++ {
+		if recurringListReq.ActiveOnly && !allSeries[i].Active {
+			continue
+		}
+
+		seriesResps = append(seriesResps, allSeries[i].ToRecurringTransactionSeriesResponse())
+	}
+
+	return seriesResps, nil
+}
+
 // TransactionCreateHandler saves a new transaction by request parameters for current user
 func (a *TransactionsApi) TransactionCreateHandler(c *core.WebContext) (any, *errs.Error) {
 	var transactionCreateReq models.TransactionCreateRequest
@@ -675,10 +1073,21 @@ func (a *TransactionsApi) TransactionCreateHandler(c *core.WebContext) (any, *er
 		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
 	}
 
+	transactionResp, errr := a.createTransaction(c, &transactionCreateReq)
+
+	if errr != nil {
+		return nil, errr
+	}
+
+	return transactionResp, nil
+}
+
+// createTransaction validates the transaction creation request and saves the new transaction for current user
+func (a *TransactionsApi) createTransaction(c *core.WebContext, transactionCreateReq *models.TransactionCreateRequest) (*models.TransactionInfoResponse, *errs.Error) {
 	tagIds, err := utils.StringArrayToInt64Array(transactionCreateReq.TagIds)
 
 	if err != nil {
-		log.Warnf(c, "[transactions.TransactionCreateHandler] parse tag ids failed, because %s", err.Error())
+		log.Warnf(c, "[transactions.createTransaction] parse tag ids failed, because %s", err.Error())
 		return nil, errs.ErrTransactionTagIdInvalid
 	}
 
@@ -689,7 +1098,7 @@ func (a *TransactionsApi) TransactionCreateHandler(c *core.WebContext) (any, *er
 	pictureIds, err := utils.StringArrayToInt64Array(transactionCreateReq.PictureIds)
 
 	if err != nil {
-		log.Warnf(c, "[transactions.TransactionCreateHandler] parse picture ids failed, because %s", err.Error())
+		log.Warnf(c, "[transactions.createTransaction] parse picture ids failed, because %s", err.Error())
 		return nil, errs.ErrTransactionPictureIdInvalid
 	}
 
@@ -698,25 +1107,25 @@ func (a *TransactionsApi) TransactionCreateHandler(c *core.WebContext) (any, *er
 	}
 
 	if transactionCreateReq.Type < models.TRANSACTION_TYPE_MODIFY_BALANCE || transactionCreateReq.Type > models.TRANSACTION_TYPE_TRANSFER {
-		log.Warnf(c, "[transactions.TransactionCreateHandler] transaction type is invalid")
+		log.Warnf(c, "[transactions.createTransaction] transaction type is invalid")
 		return nil, errs.ErrTransactionTypeInvalid
 	}
 
 	if transactionCreateReq.Type == models.TRANSACTION_TYPE_MODIFY_BALANCE && transactionCreateReq.CategoryId > 0 {
-		log.Warnf(c, "[transactions.TransactionCreateHandler] balance modification transaction cannot set category id")
+		log.Warnf(c, "[transactions.createTransaction] balance modification transaction cannot set category id")
 		return nil, errs.ErrBalanceModificationTransactionCannotSetCategory
 	}
 
 	if transactionCreateReq.Type != models.TRANSACTION_TYPE_TRANSFER && transactionCreateReq.DestinationAccountId != 0 {
-		log.Warnf(c, "[transactions.TransactionCreateHandler] non-transfer transaction destination account cannot be set")
+		log.Warnf(c, "[transactions.createTransaction] non-transfer transaction destination account cannot be set")
 		return nil, errs.ErrTransactionDestinationAccountCannotBeSet
 	} else if transactionCreateReq.Type == models.TRANSACTION_TYPE_TRANSFER && transactionCreateReq.SourceAccountId == transactionCreateReq.DestinationAccountId {
-		log.Warnf(c, "[transactions.TransactionCreateHandler] transfer transaction source account must not be destination account")
+		log.Warnf(c, "[transactions.createTransaction] transfer transaction source account must not be destination account")
 		return nil, errs.ErrTransactionSourceAndDestinationIdCannotBeEqual
 	}
 
 	if transactionCreateReq.Type != models.TRANSACTION_TYPE_TRANSFER && transactionCreateReq.DestinationAmount != 0 {
-		log.Warnf(c, "[transactions.TransactionCreateHandler] non-transfer transaction destination amount cannot be set")
+		log.Warnf(c, "[transactions.createTransaction] non-transfer transaction destination amount cannot be set")
 		return nil, errs.ErrTransactionDestinationAmountCannotBeSet
 	}
 
@@ -725,13 +1134,26 @@ func (a *TransactionsApi) TransactionCreateHandler(c *core.WebContext) (any, *er
 
 	if err != nil {
 		if !errs.IsCustomError(err) {
-			log.Errorf(c, "[transactions.TransactionCreateHandler] failed to get user, because %s", err.Error())
+			log.Errorf(c, "[transactions.createTransaction] failed to get user, because %s", err.Error())
 		}
 
 		return nil, errs.ErrUserNotFound
 	}
 
-	transaction := a.createNewTransactionModel(uid, &transactionCreateReq, c.ClientIP())
+	transaction := a.createNewTransactionModel(uid, transactionCreateReq, c.ClientIP())
+	rules, err := a.transactionRules.GetAllEnabledRulesByUid(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[transactions.createTransaction] failed to get transaction rules for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	tagIds, _ = rules.ApplyTo(transaction, tagIds, "", "")
+
+	if len(tagIds) > maximumTagsCountOfTransaction {
+		return nil, errs.ErrTransactionHasTooManyTags
+	}
+
 	transactionEditable := user.CanEditTransactionByTransactionTime(transaction.TransactionTime, transactionCreateReq.UtcOffset)
 
 	if !transactionEditable {
@@ -744,14 +1166,14 @@ func (a *TransactionsApi) TransactionCreateHandler(c *core.WebContext) (any, *er
 		pictureInfos, err = a.transactionPictures.GetNewPictureInfosByPictureIds(c, uid, pictureIds)
 
 		if err != nil {
-			log.Errorf(c, "[transactions.TransactionCreateHandler] failed to get transactions pictures for user \"uid:%d\", because %s", uid, err.Error())
+			log.Errorf(c, "[transactions.createTransaction] failed to get transactions pictures for user \"uid:%d\", because %s", uid, err.Error())
 			return nil, errs.Or(err, errs.ErrOperationFailed)
 		}
 
 		notExistsPictureIds := utils.Int64SliceMinus(pictureIds, a.transactionPictures.GetTransactionPictureIds(pictureInfos))
 
 		if len(notExistsPictureIds) > 0 {
-			log.Errorf(c, "[transactions.TransactionCreateHandler] some pictures \"ids:%s\" does not exists for user \"uid:%d\"", strings.Join(utils.Int64ArrayToStringArray(notExistsPictureIds), ","), uid)
+			log.Errorf(c, "[transactions.createTransaction] some pictures \"ids:%s\" does not exists for user \"uid:%d\"", strings.Join(utils.Int64ArrayToStringArray(notExistsPictureIds), ","), uid)
 			return nil, errs.ErrTransactionPictureNotFound
 		}
 	}
@@ -760,14 +1182,14 @@ func (a *TransactionsApi) TransactionCreateHandler(c *core.WebContext) (any, *er
 		found, remark := a.GetSubmissionRemark(duplicatechecker.DUPLICATE_CHECKER_TYPE_NEW_TRANSACTION, uid, transactionCreateReq.ClientSessionId)
 
 		if found {
-			log.Infof(c, "[transactions.TransactionCreateHandler] another transaction \"id:%s\" has been created for user \"uid:%d\"", remark, uid)
+			log.Infof(c, "[transactions.createTransaction] another transaction \"id:%s\" has been created for user \"uid:%d\"", remark, uid)
 			transactionId, err := utils.StringToInt64(remark)
 
 			if err == nil {
 				transaction, err = a.transactions.GetTransactionByTransactionId(c, uid, transactionId)
 
 				if err != nil {
-					log.Errorf(c, "[transactions.TransactionCreateHandler] failed to get existed transaction \"id:%d\" for user \"uid:%d\", because %s", transactionId, uid, err.Error())
+					log.Errorf(c, "[transactions.createTransaction] failed to get existed transaction \"id:%d\" for user \"uid:%d\", because %s", transactionId, uid, err.Error())
 					return nil, errs.Or(err, errs.ErrOperationFailed)
 				}
 
@@ -782,11 +1204,11 @@ func (a *TransactionsApi) TransactionCreateHandler(c *core.WebContext) (any, *er
 	err = a.transactions.CreateTransaction(c, transaction, tagIds, pictureIds)
 
 	if err != nil {
-		log.Errorf(c, "[transactions.TransactionCreateHandler] failed to create transaction \"id:%d\" for user \"uid:%d\", because %s", transaction.TransactionId, uid, err.Error())
+		log.Errorf(c, "[transactions.createTransaction] failed to create transaction \"id:%d\" for user \"uid:%d\", because %s", transaction.TransactionId, uid, err.Error())
 		return nil, errs.Or(err, errs.ErrOperationFailed)
 	}
 
-	log.Infof(c, "[transactions.TransactionCreateHandler] user \"uid:%d\" has created a new transaction \"id:%d\" successfully", uid, transaction.TransactionId)
+	log.Infof(c, "[transactions.createTransaction] user \"uid:%d\" has created a new transaction \"id:%d\" successfully", uid, transaction.TransactionId)
 
 	a.SetSubmissionRemarkIfEnable(duplicatechecker.DUPLICATE_CHECKER_TYPE_NEW_TRANSACTION, uid, transactionCreateReq.ClientSessionId, utils.Int64ToString(transaction.TransactionId))
 	transactionResp := transaction.ToTransactionInfoResponse(tagIds, transactionEditable)
@@ -795,28 +1217,126 @@ func (a *TransactionsApi) TransactionCreateHandler(c *core.WebContext) (any, *er
 	return transactionResp, nil
 }
 
-// TransactionModifyHandler saves an existed transaction by request parameters for current user
-func (a *TransactionsApi) TransactionModifyHandler(c *core.WebContext) (any, *errs.Error) {
-	var transactionModifyReq models.TransactionModifyRequest
-	err := c.ShouldBindJSON(&transactionModifyReq)
+// TransactionQuickAddHandler parses the quick add text to a new transaction for current user, and saves the transaction if required
+func (a *TransactionsApi) TransactionQuickAddHandler(c *core.WebContext) (any, *errs.Error) {
+	var quickAddReq models.TransactionQuickAddRequest
+	err := c.ShouldBindJSON(&quickAddReq)
 
 	if err != nil {
-		log.Warnf(c, "[transactions.TransactionModifyHandler] parse request failed, because %s", err.Error())
+		log.Warnf(c, "[transactions.TransactionQuickAddHandler] parse request failed, because %s", err.Error())
 		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
 	}
 
-	tagIds, err := utils.StringArrayToInt64Array(transactionModifyReq.TagIds)
+	uid := c.GetCurrentUid()
+	user, err := a.users.GetUserById(c, uid)
 
 	if err != nil {
-		log.Warnf(c, "[transactions.TransactionModifyHandler] parse tag ids failed, because %s", err.Error())
-		return nil, errs.ErrTransactionTagIdInvalid
-	}
+		if !errs.IsCustomError(err) {
+			log.Errorf(c, "[transactions.TransactionQuickAddHandler] failed to get user, because %s", err.Error())
+		}
 
-	if len(tagIds) > maximumTagsCountOfTransaction {
-		return nil, errs.ErrTransactionHasTooManyTags
+		return nil, errs.ErrUserNotFound
 	}
 
-	pictureIds, err := utils.StringArrayToInt64Array(transactionModifyReq.PictureIds)
+	decimalSeparator := user.DecimalSeparator
+	digitGroupingSymbol := user.DigitGroupingSymbol
+	localeTextItems := locales.GetLocaleTextItems(user.Language)
+
+	if decimalSeparator == core.DECIMAL_SEPARATOR_DEFAULT {
+		decimalSeparator = localeTextItems.DefaultTypes.DecimalSeparator
+	}
+
+	if digitGroupingSymbol == core.DIGIT_GROUPING_SYMBOL_DEFAULT {
+		digitGroupingSymbol = localeTextItems.DefaultTypes.DigitGroupingSymbol
+	}
+
+	clientTimezone := time.FixedZone("Client Timezone", int(quickAddReq.UtcOffset)*60)
+	parseOptions := &quickadd.QuickAddParseOptions{
+		DecimalSeparator:    decimalSeparator.Rune(),
+		DigitGroupingSymbol: digitGroupingSymbol.Rune(),
+		CurrentTime:         time.Now().In(clientTimezone),
+	}
+	parseResult := quickadd.ParseQuickAddText(quickAddReq.Text, parseOptions)
+
+	accounts, err := a.accounts.GetAllAccountsByUid(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[transactions.TransactionQuickAddHandler] failed to get accounts for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	categories, err := a.transactionCategories.GetAllCategoriesByUid(c, uid, 0, -1)
+
+	if err != nil {
+		log.Errorf(c, "[transactions.TransactionQuickAddHandler] failed to get categories for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	tags, err := a.transactionTags.GetAllTagsByUid(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[transactions.TransactionQuickAddHandler] failed to get tags for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	quickAddResp := a.resolveQuickAddTransaction(user, parseResult, parseOptions, accounts, categories, tags)
+	quickAddResp.Preview.UtcOffset = quickAddReq.UtcOffset
+	quickAddResp.Preview.ClientSessionId = quickAddReq.ClientSessionId
+
+	if quickAddResp.Preview.Type == models.TRANSACTION_TYPE_TRANSFER {
+		quickAddResp.Preview.DestinationAmount, quickAddResp.DestinationAmountRecognized = a.getQuickAddTransferDestinationAmount(c, uid, quickAddResp.Preview, accounts, clientTimezone)
+	}
+
+	if !quickAddReq.Commit {
+		return quickAddResp, nil
+	}
+
+	if !quickAddResp.AmountRecognized {
+		return nil, errs.ErrQuickAddTextAmountNotRecognized
+	}
+
+	if !quickAddResp.AccountRecognized {
+		return nil, errs.ErrQuickAddTextAccountNotRecognized
+	}
+
+	if quickAddResp.Preview.Type == models.TRANSACTION_TYPE_TRANSFER && !quickAddResp.DestinationAmountRecognized {
+		return nil, errs.ErrQuickAddTextDestinationAmountNotRecognized
+	}
+
+	transactionResp, errr := a.createTransaction(c, quickAddResp.Preview)
+
+	if errr != nil {
+		return nil, errr
+	}
+
+	quickAddResp.Committed = true
+	quickAddResp.Transaction = transactionResp
+
+	return quickAddResp, nil
+}
+
+// TransactionModifyHandler saves an existed transaction by request parameters for current user
+func (a *TransactionsApi) TransactionModifyHandler(c *core.WebContext) (any, *errs.Error) {
+	var transactionModifyReq models.TransactionModifyRequest
+	err := c.ShouldBindJSON(&transactionModifyReq)
+
+	if err != nil {
+		log.Warnf(c, "[transactions.TransactionModifyHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	tagIds, err := utils.StringArrayToInt64Array(transactionModifyReq.TagIds)
+
+	if err != nil {
+		log.Warnf(c, "[transactions.TransactionModifyHandler] parse tag ids failed, because %s", err.Error())
+		return nil, errs.ErrTransactionTagIdInvalid
+	}
+
+	if len(tagIds) > maximumTagsCountOfTransaction {
+		return nil, errs.ErrTransactionHasTooManyTags
+	}
+
+	pictureIds, err := utils.StringArrayToInt64Array(transactionModifyReq.PictureIds)
 
 	if err != nil {
 		log.Warnf(c, "[transactions.TransactionModifyHandler] parse picture ids failed, because %s", err.Error())
@@ -1315,6 +1835,28 @@ func (a *TransactionsApi) TransactionParseImportFileHandler(c *core.WebContext)
 		return nil, errs.Or(err, errs.ErrOperationFailed)
 	}
 
+	rules, err := a.transactionRules.GetAllEnabledRulesByUid(c, user.Uid)
+
+	if err != nil {
+		log.Errorf(c, "[transactions.TransactionParseImportFileHandler] failed to get transaction rules for user \"uid:%d\", because %s", user.Uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	a.applyTransactionRulesToImportTransactions(rules, parsedTransactions)
+
+	suggestCategories := form.Value["suggestCategories"]
+
+	if len(suggestCategories) > 0 && suggestCategories[0] == "true" {
+		suggestionModels, err := a.transactionSuggestions.GetTransactionSuggestionModels(c, user.Uid)
+
+		if err != nil {
+			log.Errorf(c, "[transactions.TransactionParseImportFileHandler] failed to get transaction suggestion models for user \"uid:%d\", because %s", user.Uid, err.Error())
+			return nil, errs.Or(err, errs.ErrOperationFailed)
+		}
+
+		a.applySuggestionsToImportTransactions(suggestionModels, a.transactionCategories.GetCategoryMapByList(categories), a.transactionTags.GetTagMapByList(tags), parsedTransactions)
+	}
+
 	parsedTransactionRespsList := parsedTransactions.ToImportTransactionResponseList()
 
 	if len(parsedTransactionRespsList) < 1 {
@@ -1326,6 +1868,17 @@ func (a *TransactionsApi) TransactionParseImportFileHandler(c *core.WebContext)
 		TotalCount: int64(len(parsedTransactionRespsList)),
 	}
 
+	if balanceAssertionImporter, ok := dataImporter.(converter.AccountBalanceAssertionDataImporter); ok {
+		parsedBalanceAssertions, err := balanceAssertionImporter.ParseImportedAccountBalanceAssertions(c, user, fileData, utcOffset, accountMap)
+
+		if err != nil {
+			log.Errorf(c, "[transactions.TransactionParseImportFileHandler] failed to parse imported balance assertions for user \"uid:%d\", because %s", user.Uid, err.Error())
+			return nil, errs.Or(err, errs.ErrOperationFailed)
+		}
+
+		parsedTransactionResps.BalanceAssertions = parsedBalanceAssertions.ToImportAccountBalanceAssertionResponseList()
+	}
+
 	return parsedTransactionResps, nil
 }
 
@@ -1433,6 +1986,13 @@ func (a *TransactionsApi) TransactionImportHandler(c *core.WebContext) (any, *er
 		newTransactions[i] = transaction
 	}
 
+	newBalanceAssertions, err := a.createImportBalanceAssertionModels(c, uid, transactionImportReq.BalanceAssertions)
+
+	if err != nil {
+		log.Errorf(c, "[transactions.TransactionImportHandler] failed to resolve accounts of balance assertions for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
 	err = a.transactions.BatchCreateTransactions(c, user.Uid, newTransactions, newTransactionTagIdsMap, func(currentProcess float64) {
 		a.SetSubmissionRemarkIfEnable(duplicatechecker.DUPLICATE_CHECKER_TYPE_IMPORT_TRANSACTIONS, uid, transactionImportReq.ClientSessionId, fmt.Sprintf("processing:%.2f", currentProcess))
 	})
@@ -1446,6 +2006,16 @@ func (a *TransactionsApi) TransactionImportHandler(c *core.WebContext) (any, *er
 
 	log.Infof(c, "[transactions.TransactionImportHandler] user \"uid:%d\" has imported %d transactions successfully", uid, count)
 
+	if len(newBalanceAssertions) > 0 {
+		assertionCount, err := a.balanceAssertions.CreateAssertions(c, uid, newBalanceAssertions)
+
+		if err != nil {
+			log.Errorf(c, "[transactions.TransactionImportHandler] failed to import %d balance assertions for user \"uid:%d\", because %s", len(newBalanceAssertions), uid, err.Error())
+		} else {
+			log.Infof(c, "[transactions.TransactionImportHandler] user \"uid:%d\" has imported %d balance assertions successfully", uid, assertionCount)
+		}
+	}
+
 	a.SetSubmissionRemarkIfEnable(duplicatechecker.DUPLICATE_CHECKER_TYPE_IMPORT_TRANSACTIONS, uid, transactionImportReq.ClientSessionId, fmt.Sprintf("finished:%d", count))
 
 	return count, nil
@@ -1501,6 +2071,193 @@ func (a *TransactionsApi) TransactionImportProcessHandler(c *core.WebContext) (a
 	return process, nil
 }
 
+func (a *TransactionsApi) getTransactionStatisticBreakdown(c *core.WebContext, uid int64, statisticReq *models.TransactionStatisticRequest, allTagIds []int64, noTags bool, utcOffset int16) (any, *errs.Error) {
+	dimensions, err := models.ParseTransactionStatisticBreakdownDimensions(statisticReq.Breakdown)
+
+	if err != nil {
+		log.Warnf(c, "[transactions.getTransactionStatisticBreakdown] cannot parse breakdown \"%s\", because %s", statisticReq.Breakdown, err.Error())
+		return nil, errs.Or(err, errs.ErrTransactionStatisticBreakdownInvalid)
+	}
+
+	transactions, err := a.transactions.GetIncomeAndExpenseTransactionsForStatistics(c, uid, statisticReq.StartTime, statisticReq.EndTime, allTagIds, noTags, statisticReq.TagFilterType, utcOffset, statisticReq.UseTransactionTimezone)
+
+	if err != nil {
+		log.Errorf(c, "[transactions.getTransactionStatisticBreakdown] failed to get income and expense transactions for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	accounts, err := a.accounts.GetAllAccountsByUid(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[transactions.getTransactionStatisticBreakdown] failed to get all accounts for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	var allTransactionTagIds map[int64][]int64
+
+	for i := 0; i < len(dimensions); i++ {
+		if dimensions[i] == models.TRANSACTION_STATISTIC_BREAKDOWN_DIMENSION_TAG {
+			allTransactionTagIds, err = a.transactionTags.GetAllTagIdsMapOfAllTransactions(c, uid)
+
+			if err != nil {
+				log.Errorf(c, "[transactions.getTransactionStatisticBreakdown] failed to get tag index for user \"uid:%d\", because %s", uid, err.Error())
+				return nil, errs.Or(err, errs.ErrOperationFailed)
+			}
+		}
+	}
+
+	items := models.GetTransactionStatisticBreakdownItems(transactions, a.accounts.GetAccountMapByList(accounts), allTransactionTagIds, &models.TransactionStatisticBreakdownOptions{
+		Dimensions:             dimensions,
+		MultiTagRule:           statisticReq.MultiTagRule,
+		ClientTimezone:         time.FixedZone("Client Timezone", int(utcOffset)*60),
+		UseTransactionTimezone: statisticReq.UseTransactionTimezone,
+	})
+
+	statisticResp := &models.TransactionStatisticBreakdownResponse{
+		StartTime:  statisticReq.StartTime,
+		EndTime:    statisticReq.EndTime,
+		Dimensions: dimensions,
+		Items:      items,
+	}
+
+	return statisticResp, nil
+}
+
+func (a *TransactionsApi) getConvertedTransactionStatistic(c *core.WebContext, uid int64, statisticReq *models.TransactionStatisticRequest, allTagIds []int64, noTags bool, utcOffset int16) (any, *errs.Error) {
+	user, err := a.users.GetUserById(c, uid)
+
+	if err != nil {
+		if !errs.IsCustomError(err) {
+			log.Warnf(c, "[transactions.getConvertedTransactionStatistic] failed to get user for user \"uid:%d\", because %s", uid, err.Error())
+		}
+
+		return nil, errs.ErrUserNotFound
+	}
+
+	transactions, err := a.transactions.GetIncomeAndExpenseTransactionsForStatistics(c, uid, statisticReq.StartTime, statisticReq.EndTime, allTagIds, noTags, statisticReq.TagFilterType, utcOffset, statisticReq.UseTransactionTimezone)
+
+	if err != nil {
+		log.Errorf(c, "[transactions.getConvertedTransactionStatistic] failed to get income and expense transactions for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	amountConverter, err := a.getStatisticAmountConverter(c, user, transactions, statisticReq.CurrencyConversion, time.FixedZone("Client Timezone", int(utcOffset)*60), statisticReq.UseTransactionTimezone)
+
+	if err != nil {
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	convertedAmounts := amountConverter.GetTotalAmounts(transactions)
+	statisticResp := &models.TransactionStatisticResponse{
+		StartTime: statisticReq.StartTime,
+		EndTime:   statisticReq.EndTime,
+		Items:     make([]*models.TransactionStatisticResponseItem, len(convertedAmounts)),
+	}
+
+	for i := 0; i < len(convertedAmounts); i++ {
+		convertedAmountItem := convertedAmounts[i]
+		statisticResp.Items[i] = &models.TransactionStatisticResponseItem{
+			CategoryId:  convertedAmountItem.CategoryId,
+			AccountId:   convertedAmountItem.AccountId,
+			TotalAmount: convertedAmountItem.Amount,
+			Currency:    convertedAmountItem.Currency,
+		}
+	}
+
+	return statisticResp, nil
+}
+
+func (a *TransactionsApi) getStatisticAmountConverter(c *core.WebContext, user *models.User, transactions []*models.Transaction, conversionType models.StatisticCurrencyConversionType, clientTimezone *time.Location, useTransactionTimezone bool) (*models.TransactionStatisticAmountConverter, error) {
+	accounts, err := a.accounts.GetAllAccountsByUid(c, user.Uid)
+
+	if err != nil {
+		log.Errorf(c, "[transactions.getStatisticAmountConverter] failed to get all accounts for user \"uid:%d\", because %s", user.Uid, err.Error())
+		return nil, err
+	}
+
+	accountCurrencies := make(map[int64]string, len(accounts))
+	accountPrecisions := make(map[int64]int, len(accounts))
+
+	for i := 0; i < len(accounts); i++ {
+		accountCurrencies[accounts[i].AccountId] = accounts[i].Currency
+		accountPrecisions[accounts[i].AccountId] = accounts[i].GetCurrencyPrecision()
+	}
+
+	var minTransactionTime, maxTransactionTime int64
+
+	for i := 0; i < len(transactions); i++ {
+		transactionTime := transactions[i].TransactionTime
+
+		if minTransactionTime == 0 || transactionTime < minTransactionTime {
+			minTransactionTime = transactionTime
+		}
+
+		if transactionTime > maxTransactionTime {
+			maxTransactionTime = transactionTime
+		}
+	}
+
+	var exchangeRates []*models.HistoricalExchangeRate
+	var userExchangeRates models.UserExchangeRates
+	dataSource := a.CurrentConfig().ExchangeRatesDataSource
+
+	if len(transactions) > 0 {
+		// the rate date is in utc, so the date range is expanded by one day on each side to cover all the timezones
+		startDate := utils.FormatTimeToNumericDate(time.Unix(utils.GetUnixTimeFromTransactionTime(minTransactionTime), 0).In(time.UTC).AddDate(0, 0, -1))
+		endDate := utils.FormatTimeToNumericDate(time.Unix(utils.GetUnixTimeFromTransactionTime(maxTransactionTime), 0).In(time.UTC).AddDate(0, 0, 1))
+		exchangeRates, err = a.exchangeRateHistories.GetEffectiveExchangeRatesInDateRange(c, dataSource, startDate, endDate)
+
+		if err != nil {
+			log.Errorf(c, "[transactions.getStatisticAmountConverter] failed to get historical exchange rates for user \"uid:%d\", because %s", user.Uid, err.Error())
+			return nil, err
+		}
+
+		userExchangeRates, err = a.userExchangeRates.GetExchangeRatesInDateRange(c, user.Uid, startDate, endDate)
+
+		if err != nil {
+			log.Errorf(c, "[transactions.getStatisticAmountConverter] failed to get user defined exchange rates for user \"uid:%d\", because %s", user.Uid, err.Error())
+			return nil, err
+		}
+	}
+
+	var fallbackExchangeRates models.ExchangeRatesMap
+
+	if len(transactions) > 0 && len(exchangeRates) < 1 {
+		latestExchangeRates, err := exchangerates.Container.GetLatestExchangeRates(c, user.Uid, a.CurrentConfig())
+
+		if err != nil {
+			log.Warnf(c, "[transactions.getStatisticAmountConverter] failed to get latest exchange rates for user \"uid:%d\", because %s", user.Uid, err.Error())
+		} else {
+			fallbackExchangeRates = latestExchangeRates.ToExchangeRatesMap()
+		}
+	}
+
+	historicalExchangeRates := models.NewHistoricalExchangeRates(exchangeRates, fallbackExchangeRates)
+	historicalExchangeRates.SetUserExchangeRates(userExchangeRates)
+
+	amountConverter := models.NewTransactionStatisticAmountConverter(historicalExchangeRates, conversionType, accountCurrencies, user.DefaultCurrency, clientTimezone, useTransactionTimezone)
+	amountConverter.SetAccountCurrencyPrecisions(accountPrecisions)
+
+	return amountConverter, nil
+}
+
+func (a *TransactionsApi) getTransactionStatisticTrendsResponseItem(periodStartDate int32, periodOptions *models.StatisticPeriodOptions, clientTimezone *time.Location) *models.TransactionStatisticTrendsResponseItem {
+	periodStartTime := time.Date(int(periodStartDate/10000), time.Month(periodStartDate/100%100), int(periodStartDate%100), 0, 0, 0, 0, clientTimezone)
+	periodicStatisticResp := &models.TransactionStatisticTrendsResponseItem{
+		Year:      periodStartDate / 10000,
+		Month:     periodStartDate / 100 % 100,
+		Day:       periodStartDate % 100,
+		StartTime: periodStartTime.Unix(),
+		EndTime:   periodOptions.GetNextPeriodStartDate(periodStartTime).Unix() - 1,
+	}
+
+	if periodOptions.Granularity == models.STATISTIC_PERIOD_GRANULARITY_QUARTER {
+		periodicStatisticResp.Quarter = periodOptions.GetQuarter(periodStartTime)
+	}
+
+	return periodicStatisticResp
+}
+
 func (a *TransactionsApi) filterTransactions(c *core.WebContext, uid int64, transactions []*models.Transaction, accountMap map[int64]*models.Account) []*models.Transaction {
 	finalTransactions := make([]*models.Transaction, 0, len(transactions))
 
@@ -1786,6 +2543,319 @@ func (a *TransactionsApi) getTransactionResponseListResult(c *core.WebContext, u
 	return result, nil
 }
 
+func (a *TransactionsApi) applyTransactionRulesToImportTransactions(rules models.TransactionRuleSlice, importTransactions models.ImportedTransactionSlice) {
+	if len(rules) < 1 {
+		return
+	}
+
+	for i := 0; i < len(importTransactions); i++ {
+		importTransaction := importTransactions[i]
+		tagIds := make([]int64, 0, len(importTransaction.TagIds))
+
+		for j := 0; j < len(importTransaction.TagIds); j++ {
+			tagId, err := utils.StringToInt64(importTransaction.TagIds[j])
+
+			if err == nil && tagId > 0 {
+				tagIds = append(tagIds, tagId)
+			}
+		}
+
+		newTagIds, matched := rules.ApplyTo(importTransaction.Transaction, tagIds, importTransaction.OriginalCategoryName, importTransaction.OriginalPayee)
+
+		if !matched {
+			continue
+		}
+
+		addTagIds := utils.Int64SliceMinus(newTagIds, tagIds)
+
+		for j := 0; j < len(addTagIds); j++ {
+			importTransaction.TagIds = append(importTransaction.TagIds, utils.Int64ToString(addTagIds[j]))
+		}
+	}
+}
+
+func (a *TransactionsApi) applySuggestionsToImportTransactions(suggestionModels map[models.TransactionDbType]*suggestions.TransactionSuggestionModel, categoryMap map[int64]*models.TransactionCategory, tagMap map[int64]*models.TransactionTag, importTransactions models.ImportedTransactionSlice) {
+	for i := 0; i < len(importTransactions); i++ {
+		importTransaction := importTransactions[i]
+		suggestionModel := suggestionModels[importTransaction.Type]
+
+		if suggestionModel == nil || suggestionModel.SampleCount() < minimumTrainingTransactionCountForSuggestion {
+			continue
+		}
+
+		if importTransaction.CategoryId == 0 {
+			categoryPredictions := suggestionModel.SuggestCategories(importTransaction.Comment, importTransaction.Amount, importTransaction.AccountId)
+
+			for j := 0; j < len(categoryPredictions) && categoryPredictions[j].Probability >= minimumCategorySuggestionProbabilityForImport; j++ {
+				if a.isSuggestedCategoryAvailable(categoryMap, categoryPredictions[j].Label, importTransaction.Type) {
+					importTransaction.CategoryId = categoryPredictions[j].Label
+					importTransaction.CategorySuggested = true
+					break
+				}
+			}
+		}
+
+		if len(importTransaction.TagIds) == 0 {
+			suggestedTagIds := suggestionModel.SuggestTags(importTransaction.Comment, importTransaction.Amount, importTransaction.AccountId)
+
+			for j := 0; j < len(suggestedTagIds) && len(importTransaction.TagIds) < maximumTagsCountOfTransaction; j++ {
+				if tag, exists := tagMap[suggestedTagIds[j]]; exists && !tag.Hidden {
+					importTransaction.TagIds = append(importTransaction.TagIds, utils.Int64ToString(suggestedTagIds[j]))
+					importTransaction.TagsSuggested = true
+				}
+			}
+		}
+	}
+}
+
+func (a *TransactionsApi) isSuggestedCategoryAvailable(categoryMap map[int64]*models.TransactionCategory, categoryId int64, transactionDbType models.TransactionDbType) bool {
+	category, exists := categoryMap[categoryId]
+
+	if !exists || category.Hidden || category.ParentCategoryId == models.LevelOneTransactionCategoryParentId {
+		return false
+	}
+
+	if parentCategory, exists := categoryMap[category.ParentCategoryId]; !exists || parentCategory.Hidden {
+		return false
+	}
+
+	return (transactionDbType == models.TRANSACTION_DB_TYPE_INCOME && category.Type == models.CATEGORY_TYPE_INCOME) ||
+		(transactionDbType == models.TRANSACTION_DB_TYPE_EXPENSE && category.Type == models.CATEGORY_TYPE_EXPENSE) ||
+		(transactionDbType == models.TRANSACTION_DB_TYPE_TRANSFER_OUT && category.Type == models.CATEGORY_TYPE_TRANSFER)
+}
+
+// createImportBalanceAssertionModels returns the balance assertion models of transaction import request,
+// the accounts which are created after parsing the import file are resolved by name, and the assertions of unknown accounts are skipped
+func (a *TransactionsApi) createImportBalanceAssertionModels(c *core.WebContext, uid int64, assertionCreateReqs []*models.ImportAccountBalanceAssertionCreateRequest) ([]*models.AccountBalanceAssertion, error) {
+	newBalanceAssertions := make([]*models.AccountBalanceAssertion, 0, len(assertionCreateReqs))
+
+	if len(assertionCreateReqs) < 1 {
+		return newBalanceAssertions, nil
+	}
+
+	var accountMap map[string]*models.Account
+
+	for i := 0; i < len(assertionCreateReqs); i++ {
+		assertionCreateReq := assertionCreateReqs[i]
+		accountId := assertionCreateReq.AccountId
+
+		if accountId <= 0 && assertionCreateReq.OriginalAccountName != "" {
+			if accountMap == nil {
+				accounts, err := a.accounts.GetAllAccountsByUid(c, uid)
+
+				if err != nil {
+					return nil, err
+				}
+
+				accountMap = a.accounts.GetVisibleAccountNameMapByList(accounts)
+			}
+
+			if account, exists := accountMap[assertionCreateReq.OriginalAccountName]; exists {
+				accountId = account.AccountId
+			}
+		}
+
+		if accountId <= 0 {
+			log.Warnf(c, "[transactions.createImportBalanceAssertionModels] skip balance assertion \"index:%d\" for user \"uid:%d\", because account \"%s\" does not exist", i, uid, assertionCreateReq.OriginalAccountName)
+			continue
+		}
+
+		newBalanceAssertions = append(newBalanceAssertions, &models.AccountBalanceAssertion{
+			Uid:               uid,
+			AccountId:         accountId,
+			AssertionTime:     assertionCreateReq.Time,
+			TimezoneUtcOffset: assertionCreateReq.UtcOffset,
+			ExpectedBalance:   assertionCreateReq.ExpectedBalance,
+			Comment:           assertionCreateReq.Comment,
+		})
+	}
+
+	return newBalanceAssertions, nil
+}
+
+func (a *TransactionsApi) resolveQuickAddTransaction(user *models.User, parseResult *quickadd.QuickAddParseResult, parseOptions *quickadd.QuickAddParseOptions, accounts []*models.Account, categories []*models.TransactionCategory, tags []*models.TransactionTag) *models.TransactionQuickAddResponse {
+	words := parseResult.Words
+	transactionCreateReq := &models.TransactionCreateRequest{
+		Type:   models.TRANSACTION_TYPE_EXPENSE,
+		Time:   time.Now().Unix(),
+		TagIds: make([]string, 0),
+	}
+
+	if parseResult.HasTime {
+		transactionCreateReq.Time = parseResult.Time.Unix()
+	}
+
+	if parseResult.IsIncome {
+		transactionCreateReq.Type = models.TRANSACTION_TYPE_INCOME
+	}
+
+	accountCandidates := make([]*quickadd.NameCandidate, 0, len(accounts))
+
+	for i := 0; i < len(accounts); i++ {
+		if !accounts[i].Hidden && accounts[i].Type == models.ACCOUNT_TYPE_SINGLE_ACCOUNT {
+			accountCandidates = append(accountCandidates, &quickadd.NameCandidate{Id: accounts[i].AccountId, Name: accounts[i].Name})
+		}
+	}
+
+	sourceAccountMatch := quickadd.FindBestNameMatch(words, accountCandidates)
+
+	if sourceAccountMatch != nil {
+		words = quickadd.RemoveMatchedWords(words, sourceAccountMatch)
+		transactionCreateReq.SourceAccountId = sourceAccountMatch.Candidate.Id
+
+		remainingAccountCandidates := make([]*quickadd.NameCandidate, 0, len(accountCandidates))
+
+		for i := 0; i < len(accountCandidates); i++ {
+			if accountCandidates[i].Id != sourceAccountMatch.Candidate.Id {
+				remainingAccountCandidates = append(remainingAccountCandidates, accountCandidates[i])
+			}
+		}
+
+		destinationAccountMatch := quickadd.FindBestNameMatch(words, remainingAccountCandidates)
+
+		// two accounts in the text means transfer, the account which appears first is the source account
+		if destinationAccountMatch != nil && !parseResult.IsIncome {
+			words = quickadd.RemoveMatchedWords(words, destinationAccountMatch)
+			transactionCreateReq.Type = models.TRANSACTION_TYPE_TRANSFER
+			transactionCreateReq.DestinationAccountId = destinationAccountMatch.Candidate.Id
+
+			if destinationAccountMatch.WordStartIndex < sourceAccountMatch.WordStartIndex {
+				transactionCreateReq.SourceAccountId, transactionCreateReq.DestinationAccountId = transactionCreateReq.DestinationAccountId, transactionCreateReq.SourceAccountId
+			}
+		}
+	} else {
+		for i := 0; i < len(accountCandidates); i++ {
+			if accountCandidates[i].Id == user.DefaultAccountId {
+				transactionCreateReq.SourceAccountId = user.DefaultAccountId
+				break
+			}
+		}
+	}
+
+	amountRecognized := false
+
+	if parseResult.HasAmount {
+		amountPrecision := models.DEFAULT_CURRENCY_PRECISION
+
+		for i := 0; i < len(accounts); i++ {
+			if accounts[i].AccountId == transactionCreateReq.SourceAccountId {
+				amountPrecision = accounts[i].GetCurrencyPrecision()
+				break
+			}
+		}
+
+		amount, _, err := quickadd.ParseLocalizedAmount(parseResult.AmountText, parseOptions.DecimalSeparator, parseOptions.DigitGroupingSymbol, amountPrecision)
+
+		if err == nil {
+			transactionCreateReq.SourceAmount = amount
+			amountRecognized = true
+		}
+	}
+
+	categoryCandidates := make(map[models.TransactionCategoryType][]*quickadd.NameCandidate)
+
+	for i := 0; i < len(categories); i++ {
+		category := categories[i]
+
+		if !category.Hidden && category.ParentCategoryId != models.LevelOneTransactionCategoryParentId {
+			categoryCandidates[category.Type] = append(categoryCandidates[category.Type], &quickadd.NameCandidate{Id: category.CategoryId, Name: category.Name})
+		}
+	}
+
+	var categoryMatch *quickadd.NameMatchResult
+
+	if transactionCreateReq.Type == models.TRANSACTION_TYPE_TRANSFER {
+		categoryMatch = quickadd.FindBestNameMatch(words, categoryCandidates[models.CATEGORY_TYPE_TRANSFER])
+	} else if transactionCreateReq.Type == models.TRANSACTION_TYPE_INCOME {
+		categoryMatch = quickadd.FindBestNameMatch(words, categoryCandidates[models.CATEGORY_TYPE_INCOME])
+	} else {
+		categoryMatch = quickadd.FindBestNameMatch(words, categoryCandidates[models.CATEGORY_TYPE_EXPENSE])
+		incomeCategoryMatch := quickadd.FindBestNameMatch(words, categoryCandidates[models.CATEGORY_TYPE_INCOME])
+
+		if incomeCategoryMatch != nil && (categoryMatch == nil || incomeCategoryMatch.Score > categoryMatch.Score) {
+			categoryMatch = incomeCategoryMatch
+			transactionCreateReq.Type = models.TRANSACTION_TYPE_INCOME
+		}
+	}
+
+	if categoryMatch != nil {
+		words = quickadd.RemoveMatchedWords(words, categoryMatch)
+		transactionCreateReq.CategoryId = categoryMatch.Candidate.Id
+	}
+
+	tagCandidates := make([]*quickadd.NameCandidate, 0, len(tags))
+
+	for i := 0; i < len(tags); i++ {
+		if !tags[i].Hidden {
+			tagCandidates = append(tagCandidates, &quickadd.NameCandidate{Id: tags[i].TagId, Name: tags[i].Name})
+		}
+	}
+
+	unresolvedTags := make([]string, 0)
+	existedTagIds := make(map[int64]bool)
+
+	for i := 0; i < len(parseResult.TagNames); i++ {
+		tagMatch := quickadd.FindBestNameMatch([]string{parseResult.TagNames[i]}, tagCandidates)
+
+		if tagMatch == nil {
+			unresolvedTags = append(unresolvedTags, parseResult.TagNames[i])
+		} else if !existedTagIds[tagMatch.Candidate.Id] && len(transactionCreateReq.TagIds) < maximumTagsCountOfTransaction {
+			transactionCreateReq.TagIds = append(transactionCreateReq.TagIds, utils.Int64ToString(tagMatch.Candidate.Id))
+			existedTagIds[tagMatch.Candidate.Id] = true
+		}
+	}
+
+	transactionCreateReq.Comment = utils.SubString(strings.Join(words, " "), 0, 255)
+
+	return &models.TransactionQuickAddResponse{
+		Preview:           transactionCreateReq,
+		AmountRecognized:  amountRecognized,
+		AccountRecognized: transactionCreateReq.SourceAccountId > 0,
+		UnresolvedTags:    unresolvedTags,
+	}
+}
+
+// getQuickAddTransferDestinationAmount returns the destination amount of quick add transfer transaction,
+// the source amount is converted by the latest exchange rates if the two accounts have different currencies,
+// and is rescaled if the two accounts have different currency precisions
+func (a *TransactionsApi) getQuickAddTransferDestinationAmount(c *core.WebContext, uid int64, transactionCreateReq *models.TransactionCreateRequest, accounts []*models.Account, clientTimezone *time.Location) (int64, bool) {
+	accountMap := a.accounts.GetAccountMapByList(accounts)
+	sourceAccount, exists := accountMap[transactionCreateReq.SourceAccountId]
+
+	if !exists {
+		return 0, false
+	}
+
+	destinationAccount, exists := accountMap[transactionCreateReq.DestinationAccountId]
+
+	if !exists {
+		return 0, false
+	}
+
+	if sourceAccount.Currency == destinationAccount.Currency {
+		return models.ExchangeRatesMap{}.ConvertAmountWithPrecision(transactionCreateReq.SourceAmount, sourceAccount.Currency, sourceAccount.GetCurrencyPrecision(), destinationAccount.Currency, destinationAccount.GetCurrencyPrecision())
+	}
+
+	exchangeRatesResp, err := exchangerates.Container.GetLatestExchangeRates(c, uid, a.CurrentConfig())
+
+	if err != nil {
+		log.Warnf(c, "[transactions.getQuickAddTransferDestinationAmount] failed to get latest exchange rates for user \"uid:%d\", because %s", uid, err.Error())
+		return 0, false
+	}
+
+	exchangeRates := exchangeRatesResp.ToExchangeRatesMap()
+	userExchangeRates, err := a.userExchangeRates.GetAllExchangeRatesByUid(c, uid)
+
+	if err != nil {
+		log.Warnf(c, "[transactions.getQuickAddTransferDestinationAmount] failed to get user defined exchange rates for user \"uid:%d\", because %s", uid, err.Error())
+	} else {
+		today := utils.FormatTimeToNumericDate(time.Now().In(clientTimezone))
+		exchangeRates = exchangeRates.WithUserExchangeRates(userExchangeRates.GetEffectiveExchangeRates(today))
+	}
+
+	return exchangeRates.ConvertAmountWithPrecision(transactionCreateReq.SourceAmount, sourceAccount.Currency, sourceAccount.GetCurrencyPrecision(), destinationAccount.Currency, destinationAccount.GetCurrencyPrecision())
+}
+
 func (a *TransactionsApi) createNewTransactionModel(uid int64, transactionCreateReq *models.TransactionCreateRequest, clientIp string) *models.Transaction {
 	var transactionDbType models.TransactionDbType
 
@@ -0,0 +1,162 @@
+package api
+
+import (
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/services"
+	"github.com/mayswind/ezbookkeeping/pkg/validators"
+)
+
+// UserCustomCurrenciesApi represents user defined currency api
+type UserCustomCurrenciesApi struct {
+	currencies *services.UserCustomCurrencyService
+}
+
+// Initialize a user defined currency api singleton instance
+var (
+	UserCustomCurrencies = &UserCustomCurrenciesApi{
+		currencies: services.UserCustomCurrencies,
+	}
+)
+
+// UserCustomCurrencyListHandler returns user defined currency list of current user
+func (a *UserCustomCurrenciesApi) UserCustomCurrencyListHandler(c *core.WebContext) (any, *errs.Error) {
+	uid := c.GetCurrentUid()
+	currencies, err := a.currencies.GetAllCurrenciesByUid(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[user_custom_currencies.UserCustomCurrencyListHandler] failed to get user defined currencies for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	return currencies.ToUserCustomCurrencyInfoResponses(), nil
+}
+
+// UserCustomCurrencyGetHandler returns one specific user defined currency of current user
+func (a *UserCustomCurrenciesApi) UserCustomCurrencyGetHandler(c *core.WebContext) (any, *errs.Error) {
+	var currencyGetReq models.UserCustomCurrencyGetRequest
+	err := c.ShouldBindQuery(&currencyGetReq)
+
+	if err != nil {
+		log.Warnf(c, "[user_custom_currencies.UserCustomCurrencyGetHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	currency, err := a.currencies.GetCurrencyByCurrencyId(c, uid, currencyGetReq.Id)
+
+	if err != nil {
+		log.Errorf(c, "[user_custom_currencies.UserCustomCurrencyGetHandler] failed to get user defined currency \"id:%d\" for user \"uid:%d\", because %s", currencyGetReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	return currency.ToUserCustomCurrencyInfoResponse(), nil
+}
+
+// UserCustomCurrencyCreateHandler saves a new user defined currency by request parameters for current user
+func (a *UserCustomCurrenciesApi) UserCustomCurrencyCreateHandler(c *core.WebContext) (any, *errs.Error) {
+	var currencyCreateReq models.UserCustomCurrencyCreateRequest
+	err := c.ShouldBindJSON(&currencyCreateReq)
+
+	if err != nil {
+		log.Warnf(c, "[user_custom_currencies.UserCustomCurrencyCreateHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	if !validators.IsValidCustomCurrencyCode(currencyCreateReq.Code) {
+		log.Warnf(c, "[user_custom_currencies.UserCustomCurrencyCreateHandler] currency code \"%s\" is invalid", currencyCreateReq.Code)
+		return nil, errs.ErrUserCustomCurrencyCodeInvalid
+	}
+
+	if currencyCreateReq.Precision < 0 || currencyCreateReq.Precision > models.MAX_CURRENCY_PRECISION {
+		log.Warnf(c, "[user_custom_currencies.UserCustomCurrencyCreateHandler] currency precision \"%d\" is invalid", currencyCreateReq.Precision)
+		return nil, errs.ErrUserCustomCurrencyPrecisionInvalid
+	}
+
+	uid := c.GetCurrentUid()
+	currency := &models.UserCustomCurrency{
+		Uid:       uid,
+		Code:      currencyCreateReq.Code,
+		Name:      currencyCreateReq.Name,
+		Symbol:    currencyCreateReq.Symbol,
+		Precision: currencyCreateReq.Precision,
+	}
+
+	err = a.currencies.CreateCurrency(c, currency)
+
+	if err != nil {
+		log.Errorf(c, "[user_custom_currencies.UserCustomCurrencyCreateHandler] failed to create user defined currency \"%s\" for user \"uid:%d\", because %s", currencyCreateReq.Code, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[user_custom_currencies.UserCustomCurrencyCreateHandler] user \"uid:%d\" has created a new user defined currency \"id:%d\" successfully", uid, currency.CurrencyId)
+
+	return currency.ToUserCustomCurrencyInfoResponse(), nil
+}
+
+// UserCustomCurrencyModifyHandler saves an existed user defined currency by request parameters for current user
+func (a *UserCustomCurrenciesApi) UserCustomCurrencyModifyHandler(c *core.WebContext) (any, *errs.Error) {
+	var currencyModifyReq models.UserCustomCurrencyModifyRequest
+	err := c.ShouldBindJSON(&currencyModifyReq)
+
+	if err != nil {
+		log.Warnf(c, "[user_custom_currencies.UserCustomCurrencyModifyHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	currency, err := a.currencies.GetCurrencyByCurrencyId(c, uid, currencyModifyReq.Id)
+
+	if err != nil {
+		log.Errorf(c, "[user_custom_currencies.UserCustomCurrencyModifyHandler] failed to get user defined currency \"id:%d\" for user \"uid:%d\", because %s", currencyModifyReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	if currencyModifyReq.Name == currency.Name && currencyModifyReq.Symbol == currency.Symbol {
+		return nil, errs.ErrNothingWillBeUpdated
+	}
+
+	newCurrency := &models.UserCustomCurrency{
+		CurrencyId: currency.CurrencyId,
+		Uid:        uid,
+		Code:       currency.Code,
+		Name:       currencyModifyReq.Name,
+		Symbol:     currencyModifyReq.Symbol,
+		Precision:  currency.Precision,
+	}
+
+	err = a.currencies.ModifyCurrency(c, newCurrency)
+
+	if err != nil {
+		log.Errorf(c, "[user_custom_currencies.UserCustomCurrencyModifyHandler] failed to update user defined currency \"id:%d\" for user \"uid:%d\", because %s", currencyModifyReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[user_custom_currencies.UserCustomCurrencyModifyHandler] user \"uid:%d\" has updated user defined currency \"id:%d\" successfully", uid, currencyModifyReq.Id)
+
+	return newCurrency.ToUserCustomCurrencyInfoResponse(), nil
+}
+
+// UserCustomCurrencyDeleteHandler deletes an existed user defined currency by request parameters for current user
+func (a *UserCustomCurrenciesApi) UserCustomCurrencyDeleteHandler(c *core.WebContext) (any, *errs.Error) {
+	var currencyDeleteReq models.UserCustomCurrencyDeleteRequest
+	err := c.ShouldBindJSON(&currencyDeleteReq)
+
+	if err != nil {
+		log.Warnf(c, "[user_custom_currencies.UserCustomCurrencyDeleteHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	err = a.currencies.DeleteCurrency(c, uid, currencyDeleteReq.Id)
+
+	if err != nil {
+		log.Errorf(c, "[user_custom_currencies.UserCustomCurrencyDeleteHandler] failed to delete user defined currency \"id:%d\" for user \"uid:%d\", because %s", currencyDeleteReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[user_custom_currencies.UserCustomCurrencyDeleteHandler] user \"uid:%d\" has deleted user defined currency \"id:%d\"", uid, currencyDeleteReq.Id)
+	return true, nil
+}
@@ -0,0 +1,234 @@
+package api
+
+import (
+	"strconv"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/services"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+	"github.com/mayswind/ezbookkeeping/pkg/validators"
+)
+
+// UserExchangeRatesApi represents user defined exchange rate api
+type UserExchangeRatesApi struct {
+	userExchangeRates    *services.UserExchangeRateService
+	userCustomCurrencies *services.UserCustomCurrencyService
+}
+
+// Initialize a user defined exchange rate api singleton instance
+var (
+	UserExchangeRates = &UserExchangeRatesApi{
+		userExchangeRates:    services.UserExchangeRates,
+		userCustomCurrencies: services.UserCustomCurrencies,
+	}
+)
+
+// UserExchangeRateListHandler returns user defined exchange rate list of current user
+func (a *UserExchangeRatesApi) UserExchangeRateListHandler(c *core.WebContext) (any, *errs.Error) {
+	uid := c.GetCurrentUid()
+	exchangeRates, err := a.userExchangeRates.GetAllExchangeRatesByUid(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[user_exchange_rates.UserExchangeRateListHandler] failed to get user defined exchange rates for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	return exchangeRates.ToUserExchangeRateInfoResponses(), nil
+}
+
+// UserExchangeRateGetHandler returns one specific user defined exchange rate of current user
+func (a *UserExchangeRatesApi) UserExchangeRateGetHandler(c *core.WebContext) (any, *errs.Error) {
+	var exchangeRateGetReq models.UserExchangeRateGetRequest
+	err := c.ShouldBindQuery(&exchangeRateGetReq)
+
+	if err != nil {
+		log.Warnf(c, "[user_exchange_rates.UserExchangeRateGetHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	exchangeRate, err := a.userExchangeRates.GetExchangeRateByExchangeRateId(c, uid, exchangeRateGetReq.Id)
+
+	if err != nil {
+		log.Errorf(c, "[user_exchange_rates.UserExchangeRateGetHandler] failed to get user defined exchange rate \"id:%d\" for user \"uid:%d\", because %s", exchangeRateGetReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	return exchangeRate.ToUserExchangeRateInfoResponse(), nil
+}
+
+// UserExchangeRateCreateHandler saves a new user defined exchange rate by request parameters for current user
+func (a *UserExchangeRatesApi) UserExchangeRateCreateHandler(c *core.WebContext) (any, *errs.Error) {
+	var exchangeRateCreateReq models.UserExchangeRateCreateRequest
+	err := c.ShouldBindJSON(&exchangeRateCreateReq)
+
+	if err != nil {
+		log.Warnf(c, "[user_exchange_rates.UserExchangeRateCreateHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	exchangeRate, err := a.createNewExchangeRateModel(uid, exchangeRateCreateReq.BaseCurrency, exchangeRateCreateReq.TargetCurrency, exchangeRateCreateReq.Rate, exchangeRateCreateReq.StartDate, exchangeRateCreateReq.EndDate, exchangeRateCreateReq.Comment)
+
+	if err != nil {
+		log.Warnf(c, "[user_exchange_rates.UserExchangeRateCreateHandler] user defined exchange rate is invalid, because %s", err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	err = a.checkCustomCurrenciesExist(c, uid, exchangeRateCreateReq.BaseCurrency, exchangeRateCreateReq.TargetCurrency)
+
+	if err != nil {
+		log.Warnf(c, "[user_exchange_rates.UserExchangeRateCreateHandler] currency of user defined exchange rate is invalid, because %s", err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	err = a.userExchangeRates.CreateExchangeRate(c, exchangeRate)
+
+	if err != nil {
+		log.Errorf(c, "[user_exchange_rates.UserExchangeRateCreateHandler] failed to create user defined exchange rate for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[user_exchange_rates.UserExchangeRateCreateHandler] user \"uid:%d\" has created a new user defined exchange rate \"id:%d\" successfully", uid, exchangeRate.ExchangeRateId)
+
+	return exchangeRate.ToUserExchangeRateInfoResponse(), nil
+}
+
+// UserExchangeRateModifyHandler saves an existed user defined exchange rate by request parameters for current user
+func (a *UserExchangeRatesApi) UserExchangeRateModifyHandler(c *core.WebContext) (any, *errs.Error) {
+	var exchangeRateModifyReq models.UserExchangeRateModifyRequest
+	err := c.ShouldBindJSON(&exchangeRateModifyReq)
+
+	if err != nil {
+		log.Warnf(c, "[user_exchange_rates.UserExchangeRateModifyHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	exchangeRate, err := a.userExchangeRates.GetExchangeRateByExchangeRateId(c, uid, exchangeRateModifyReq.Id)
+
+	if err != nil {
+		log.Errorf(c, "[user_exchange_rates.UserExchangeRateModifyHandler] failed to get user defined exchange rate \"id:%d\" for user \"uid:%d\", because %s", exchangeRateModifyReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	newExchangeRate, err := a.createNewExchangeRateModel(uid, exchangeRateModifyReq.BaseCurrency, exchangeRateModifyReq.TargetCurrency, exchangeRateModifyReq.Rate, exchangeRateModifyReq.StartDate, exchangeRateModifyReq.EndDate, exchangeRateModifyReq.Comment)
+
+	if err != nil {
+		log.Warnf(c, "[user_exchange_rates.UserExchangeRateModifyHandler] user defined exchange rate is invalid, because %s", err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	err = a.checkCustomCurrenciesExist(c, uid, exchangeRateModifyReq.BaseCurrency, exchangeRateModifyReq.TargetCurrency)
+
+	if err != nil {
+		log.Warnf(c, "[user_exchange_rates.UserExchangeRateModifyHandler] currency of user defined exchange rate is invalid, because %s", err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	newExchangeRate.ExchangeRateId = exchangeRate.ExchangeRateId
+
+	if newExchangeRate.BaseCurrency == exchangeRate.BaseCurrency &&
+		newExchangeRate.TargetCurrency == exchangeRate.TargetCurrency &&
+		newExchangeRate.Rate == exchangeRate.Rate &&
+		newExchangeRate.StartDate == exchangeRate.StartDate &&
+		newExchangeRate.EndDate == exchangeRate.EndDate &&
+		newExchangeRate.Comment == exchangeRate.Comment {
+		return nil, errs.ErrNothingWillBeUpdated
+	}
+
+	err = a.userExchangeRates.ModifyExchangeRate(c, newExchangeRate)
+
+	if err != nil {
+		log.Errorf(c, "[user_exchange_rates.UserExchangeRateModifyHandler] failed to update user defined exchange rate \"id:%d\" for user \"uid:%d\", because %s", exchangeRateModifyReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[user_exchange_rates.UserExchangeRateModifyHandler] user \"uid:%d\" has updated user defined exchange rate \"id:%d\" successfully", uid, exchangeRateModifyReq.Id)
+
+	return newExchangeRate.ToUserExchangeRateInfoResponse(), nil
+}
+
+// UserExchangeRateDeleteHandler deletes an existed user defined exchange rate by request parameters for current user
+func (a *UserExchangeRatesApi) UserExchangeRateDeleteHandler(c *core.WebContext) (any, *errs.Error) {
+	var exchangeRateDeleteReq models.UserExchangeRateDeleteRequest
+	err := c.ShouldBindJSON(&exchangeRateDeleteReq)
+
+	if err != nil {
+		log.Warnf(c, "[user_exchange_rates.UserExchangeRateDeleteHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	err = a.userExchangeRates.DeleteExchangeRate(c, uid, exchangeRateDeleteReq.Id)
+
+	if err != nil {
+		log.Errorf(c, "[user_exchange_rates.UserExchangeRateDeleteHandler] failed to delete user defined exchange rate \"id:%d\" for user \"uid:%d\", because %s", exchangeRateDeleteReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[user_exchange_rates.UserExchangeRateDeleteHandler] user \"uid:%d\" has deleted user defined exchange rate \"id:%d\"", uid, exchangeRateDeleteReq.Id)
+	return true, nil
+}
+
+func (a *UserExchangeRatesApi) createNewExchangeRateModel(uid int64, baseCurrency string, targetCurrency string, rate string, startDate string, endDate string, comment string) (*models.UserExchangeRate, error) {
+	if baseCurrency == targetCurrency {
+		return nil, errs.ErrUserExchangeRateCurrencyPairInvalid
+	}
+
+	rateValue, err := strconv.ParseFloat(rate, 64)
+
+	if err != nil || rateValue <= 0 {
+		return nil, errs.ErrUserExchangeRateInvalid
+	}
+
+	startTime, err := utils.ParseFromLongDateFirstTime(startDate, 0)
+
+	if err != nil {
+		return nil, errs.ErrExchangeRateDateInvalid
+	}
+
+	exchangeRate := &models.UserExchangeRate{
+		Uid:            uid,
+		BaseCurrency:   baseCurrency,
+		TargetCurrency: targetCurrency,
+		Rate:           rate,
+		StartDate:      utils.FormatTimeToNumericDate(startTime),
+		Comment:        comment,
+	}
+
+	if endDate != "" {
+		endTime, err := utils.ParseFromLongDateFirstTime(endDate, 0)
+
+		if err != nil {
+			return nil, errs.ErrExchangeRateDateInvalid
+		}
+
+		exchangeRate.EndDate = utils.FormatTimeToNumericDate(endTime)
+
+		if exchangeRate.EndDate < exchangeRate.StartDate {
+			return nil, errs.ErrExchangeRateDateRangeInvalid
+		}
+	}
+
+	return exchangeRate, nil
+}
+
+func (a *UserExchangeRatesApi) checkCustomCurrenciesExist(c *core.WebContext, uid int64, currencies ...string) error {
+	for i := 0; i < len(currencies); i++ {
+		if _, exists := validators.AllCurrencyNames[currencies[i]]; exists {
+			continue
+		}
+
+		_, err := a.userCustomCurrencies.GetCurrencyByCode(c, uid, currencies[i])
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,264 @@
+package api
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/services"
+	"github.com/mayswind/ezbookkeeping/pkg/settings"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+const webhookSecretLength = 32
+
+// WebhooksApi represents webhook api
+type WebhooksApi struct {
+	ApiUsingConfig
+	webhooks *services.WebhookService
+}
+
+// Initialize a webhook api singleton instance
+var (
+	Webhooks = &WebhooksApi{
+		ApiUsingConfig: ApiUsingConfig{
+			container: settings.Container,
+		},
+		webhooks: services.Webhooks,
+	}
+)
+
+// WebhookListHandler returns webhook list of current user
+func (a *WebhooksApi) WebhookListHandler(c *core.WebContext) (any, *errs.Error) {
+	uid := c.GetCurrentUid()
+	webhooks, err := a.webhooks.GetAllWebhooksByUid(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[webhooks.WebhookListHandler] failed to get webhooks for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	webhookResps := make(models.WebhookInfoResponseSlice, len(webhooks))
+
+	for i := 0; i < len(webhooks); i++ {
+		webhookResps[i] = webhooks[i].ToWebhookInfoResponse()
+	}
+
+	sort.Sort(webhookResps)
+
+	return webhookResps, nil
+}
+
+// WebhookGetHandler returns one specific webhook of current user
+func (a *WebhooksApi) WebhookGetHandler(c *core.WebContext) (any, *errs.Error) {
+	var webhookGetReq models.WebhookGetRequest
+	err := c.ShouldBindQuery(&webhookGetReq)
+
+	if err != nil {
+		log.Warnf(c, "[webhooks.WebhookGetHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	webhook, err := a.webhooks.GetWebhookByWebhookId(c, uid, webhookGetReq.Id)
+
+	if err != nil {
+		log.Errorf(c, "[webhooks.WebhookGetHandler] failed to get webhook \"id:%d\" for user \"uid:%d\", because %s", webhookGetReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	return webhook.ToWebhookInfoResponse(), nil
+}
+
+// WebhookCreateHandler saves a new webhook by request parameters for current user
+func (a *WebhooksApi) WebhookCreateHandler(c *core.WebContext) (any, *errs.Error) {
+	var webhookCreateReq models.WebhookCreateRequest
+	err := c.ShouldBindJSON(&webhookCreateReq)
+
+	if err != nil {
+		log.Warnf(c, "[webhooks.WebhookCreateHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	eventTypes, err := a.getValidEventTypes(webhookCreateReq.Url, webhookCreateReq.EventTypes)
+
+	if err != nil {
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	uid := c.GetCurrentUid()
+	secret, err := utils.GetRandomString(webhookSecretLength)
+
+	if err != nil {
+		log.Errorf(c, "[webhooks.WebhookCreateHandler] failed to generate secret for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.ErrOperationFailed
+	}
+
+	webhook := &models.Webhook{
+		Uid:        uid,
+		Name:       webhookCreateReq.Name,
+		Url:        webhookCreateReq.Url,
+		EventTypes: eventTypes,
+	}
+
+	err = a.webhooks.CreateWebhook(c, webhook, secret)
+
+	if err != nil {
+		log.Errorf(c, "[webhooks.WebhookCreateHandler] failed to create webhook \"id:%d\" for user \"uid:%d\", because %s", webhook.WebhookId, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[webhooks.WebhookCreateHandler] user \"uid:%d\" has created a new webhook \"id:%d\" successfully", uid, webhook.WebhookId)
+
+	webhookResp := webhook.ToWebhookInfoResponse()
+	webhookResp.Secret = secret
+
+	return webhookResp, nil
+}
+
+// WebhookModifyHandler saves an existed webhook by request parameters for current user
+func (a *WebhooksApi) WebhookModifyHandler(c *core.WebContext) (any, *errs.Error) {
+	var webhookModifyReq models.WebhookModifyRequest
+	err := c.ShouldBindJSON(&webhookModifyReq)
+
+	if err != nil {
+		log.Warnf(c, "[webhooks.WebhookModifyHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	eventTypes, err := a.getValidEventTypes(webhookModifyReq.Url, webhookModifyReq.EventTypes)
+
+	if err != nil {
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	uid := c.GetCurrentUid()
+	webhook, err := a.webhooks.GetWebhookByWebhookId(c, uid, webhookModifyReq.Id)
+
+	if err != nil {
+		log.Errorf(c, "[webhooks.WebhookModifyHandler] failed to get webhook \"id:%d\" for user \"uid:%d\", because %s", webhookModifyReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	newSecret := ""
+
+	if webhookModifyReq.RegenerateSecret {
+		newSecret, err = utils.GetRandomString(webhookSecretLength)
+
+		if err != nil {
+			log.Errorf(c, "[webhooks.WebhookModifyHandler] failed to generate secret for user \"uid:%d\", because %s", uid, err.Error())
+			return nil, errs.ErrOperationFailed
+		}
+	}
+
+	newWebhook := &models.Webhook{
+		WebhookId:  webhook.WebhookId,
+		Uid:        uid,
+		Name:       webhookModifyReq.Name,
+		Url:        webhookModifyReq.Url,
+		EventTypes: eventTypes,
+		Disabled:   webhookModifyReq.Disabled,
+	}
+
+	err = a.webhooks.ModifyWebhook(c, newWebhook, newSecret)
+
+	if err != nil {
+		log.Errorf(c, "[webhooks.WebhookModifyHandler] failed to update webhook \"id:%d\" for user \"uid:%d\", because %s", webhookModifyReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[webhooks.WebhookModifyHandler] user \"uid:%d\" has updated webhook \"id:%d\" successfully", uid, webhookModifyReq.Id)
+
+	webhookResp := newWebhook.ToWebhookInfoResponse()
+	webhookResp.Secret = newSecret
+
+	return webhookResp, nil
+}
+
+// WebhookDeleteHandler deletes an existed webhook by request parameters for current user
+func (a *WebhooksApi) WebhookDeleteHandler(c *core.WebContext) (any, *errs.Error) {
+	var webhookDeleteReq models.WebhookDeleteRequest
+	err := c.ShouldBindJSON(&webhookDeleteReq)
+
+	if err != nil {
+		log.Warnf(c, "[webhooks.WebhookDeleteHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	err = a.webhooks.DeleteWebhook(c, uid, webhookDeleteReq.Id)
+
+	if err != nil {
+		log.Errorf(c, "[webhooks.WebhookDeleteHandler] failed to delete webhook \"id:%d\" for user \"uid:%d\", because %s", webhookDeleteReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[webhooks.WebhookDeleteHandler] user \"uid:%d\" has deleted webhook \"id:%d\"", uid, webhookDeleteReq.Id)
+	return true, nil
+}
+
+// WebhookDeliveryListHandler returns the delivery log of one specific webhook of current user
+func (a *WebhooksApi) WebhookDeliveryListHandler(c *core.WebContext) (any, *errs.Error) {
+	var deliveryListReq models.WebhookDeliveryListRequest
+	err := c.ShouldBindQuery(&deliveryListReq)
+
+	if err != nil {
+		log.Warnf(c, "[webhooks.WebhookDeliveryListHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	_, err = a.webhooks.GetWebhookByWebhookId(c, uid, deliveryListReq.WebhookId)
+
+	if err != nil {
+		log.Errorf(c, "[webhooks.WebhookDeliveryListHandler] failed to get webhook \"id:%d\" for user \"uid:%d\", because %s", deliveryListReq.WebhookId, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	deliveries, err := a.webhooks.GetDeliveriesByWebhookId(c, uid, deliveryListReq.WebhookId, deliveryListReq.Page, deliveryListReq.Count)
+
+	if err != nil {
+		log.Errorf(c, "[webhooks.WebhookDeliveryListHandler] failed to get deliveries of webhook \"id:%d\" for user \"uid:%d\", because %s", deliveryListReq.WebhookId, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	deliveryResps := make([]*models.WebhookDeliveryInfoResponse, len(deliveries))
+
+	for i := 0; i < len(deliveries); i++ {
+		deliveryResps[i] = deliveries[i].ToWebhookDeliveryInfoResponse()
+	}
+
+	return deliveryResps, nil
+}
+
+func (a *WebhooksApi) getValidEventTypes(webhookUrl string, eventTypeNames []string) (string, error) {
+	parsedUrl, err := url.Parse(webhookUrl)
+
+	if err != nil || (parsedUrl.Scheme != "http" && parsedUrl.Scheme != "https") || parsedUrl.Host == "" {
+		return "", errs.ErrWebhookUrlInvalid
+	}
+
+	if !a.CurrentConfig().WebhookAllowPrivateNetwork && utils.IsPrivateNetworkHost(parsedUrl.Hostname()) {
+		return "", errs.ErrWebhookUrlNotAllowed
+	}
+
+	uniqueEventTypeNames := make([]string, 0, len(eventTypeNames))
+	existedEventTypeNames := make(map[string]bool, len(eventTypeNames))
+
+	for i := 0; i < len(eventTypeNames); i++ {
+		if _, ok := models.ParseWebhookEventType(eventTypeNames[i]); !ok {
+			return "", errs.ErrWebhookEventTypesInvalid
+		}
+
+		if !existedEventTypeNames[eventTypeNames[i]] {
+			uniqueEventTypeNames = append(uniqueEventTypeNames, eventTypeNames[i])
+			existedEventTypeNames[eventTypeNames[i]] = true
+		}
+	}
+
+	return strings.Join(uniqueEventTypeNames, ","), nil
+}
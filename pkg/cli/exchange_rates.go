@@ -0,0 +1,70 @@
+package cli
+
+import (
+	"time"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/exchangerates"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
+	"github.com/mayswind/ezbookkeeping/pkg/services"
+	"github.com/mayswind/ezbookkeeping/pkg/settings"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+// ExchangeRatesCli represents exchange rates cli
+type ExchangeRatesCli struct {
+	CliUsingConfig
+	exchangeRateHistories *services.ExchangeRateHistoryService
+}
+
+// Initialize an exchange rates cli singleton instance
+var (
+	ExchangeRates = &ExchangeRatesCli{
+		CliUsingConfig: CliUsingConfig{
+			container: settings.Container,
+		},
+		exchangeRateHistories: services.ExchangeRateHistories,
+	}
+)
+
+// BackfillHistoricalExchangeRates requests the exchange rates of every day in the date range from the current exchange rates data source and saves them,
+// returns the count of days which exchange rates are saved
+func (l *ExchangeRatesCli) BackfillHistoricalExchangeRates(c *core.CliContext, startDate string, endDate string) (int, error) {
+	if !exchangerates.Container.IsHistoricalExchangeRatesSupported() {
+		log.CliErrorf(c, "[exchange_rates.BackfillHistoricalExchangeRates] exchange rates data source \"%s\" does not support historical exchange rates", l.CurrentConfig().ExchangeRatesDataSource)
+		return 0, errs.ErrExchangeRatesDataSourceNotSupportHistory
+	}
+
+	startTime, err := utils.ParseFromLongDateFirstTime(startDate, 0)
+
+	if err != nil {
+		log.CliErrorf(c, "[exchange_rates.BackfillHistoricalExchangeRates] cannot parse start date \"%s\", because %s", startDate, err.Error())
+		return 0, errs.ErrExchangeRateDateInvalid
+	}
+
+	endTime := time.Now().In(time.UTC)
+
+	if endDate != "" {
+		endTime, err = utils.ParseFromLongDateFirstTime(endDate, 0)
+
+		if err != nil {
+			log.CliErrorf(c, "[exchange_rates.BackfillHistoricalExchangeRates] cannot parse end date \"%s\", because %s", endDate, err.Error())
+			return 0, errs.ErrExchangeRateDateInvalid
+		}
+	}
+
+	if endTime.Before(startTime) {
+		log.CliErrorf(c, "[exchange_rates.BackfillHistoricalExchangeRates] end date is earlier than start date")
+		return 0, errs.ErrExchangeRateDateRangeInvalid
+	}
+
+	savedDays, err := l.exchangeRateHistories.SaveHistoricalExchangeRates(c, startTime, endTime)
+
+	if err != nil {
+		log.CliErrorf(c, "[exchange_rates.BackfillHistoricalExchangeRates] failed to save historical exchange rates, because %s", err.Error())
+		return savedDays, err
+	}
+
+	return savedDays, nil
+}
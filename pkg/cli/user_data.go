@@ -22,6 +22,7 @@ const pageCountForDataExport = 1000
 type UserDataCli struct {
 	CliUsingConfig
 	accounts                *services.AccountService
+	balanceAssertions       *services.AccountBalanceAssertionService
 	transactions            *services.TransactionService
 	categories              *services.TransactionCategoryService
 	tags                    *services.TransactionTagService
@@ -38,6 +39,7 @@ var (
 			container: settings.Container,
 		},
 		accounts:                services.Accounts,
+		balanceAssertions:       services.AccountBalanceAssertions,
 		transactions:            services.Transactions,
 		categories:              services.TransactionCategories,
 		tags:                    services.TransactionTags,
@@ -624,6 +626,77 @@ func (l *UserDataCli) CheckTransactionAndAccount(c *core.CliContext, username st
 	return true, nil
 }
 
+// CheckAccountBalanceAssertions returns the check results of all account balance assertions of user
+func (l *UserDataCli) CheckAccountBalanceAssertions(c *core.CliContext, username string) ([]*models.AccountBalanceAssertionCheckResult, error) {
+	if username == "" {
+		log.CliErrorf(c, "[user_data.CheckAccountBalanceAssertions] user name is empty")
+		return nil, errs.ErrUsernameIsEmpty
+	}
+
+	uid, err := l.getUserIdByUsername(c, username)
+
+	if err != nil {
+		log.CliErrorf(c, "[user_data.CheckAccountBalanceAssertions] error occurs when getting user id by user name")
+		return nil, err
+	}
+
+	results, err := l.balanceAssertions.CheckAssertions(c, uid, 0)
+
+	if err != nil {
+		log.CliErrorf(c, "[user_data.CheckAccountBalanceAssertions] failed to check balance assertions for user \"%s\", because %s", username, err.Error())
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// CreateScheduledTransactions creates all scheduled transactions of user between the start date and the end date (both inclusive)
+func (l *UserDataCli) CreateScheduledTransactions(c *core.CliContext, username string, startDate string, endDate string) (int, int, error) {
+	if username == "" {
+		log.CliErrorf(c, "[user_data.CreateScheduledTransactions] user name is empty")
+		return 0, 0, errs.ErrUsernameIsEmpty
+	}
+
+	uid, err := l.getUserIdByUsername(c, username)
+
+	if err != nil {
+		log.CliErrorf(c, "[user_data.CreateScheduledTransactions] error occurs when getting user id by user name")
+		return 0, 0, err
+	}
+
+	serverUtcOffset := utils.GetServerTimezoneOffsetMinutes()
+	startTime, err := utils.ParseFromLongDateFirstTime(startDate, serverUtcOffset)
+
+	if err != nil {
+		log.CliErrorf(c, "[user_data.CreateScheduledTransactions] failed to parse start date \"%s\", because %s", startDate, err.Error())
+		return 0, 0, errs.ErrParameterInvalid
+	}
+
+	endTime, err := utils.ParseFromLongDateLastTime(endDate, serverUtcOffset)
+
+	if err != nil {
+		log.CliErrorf(c, "[user_data.CreateScheduledTransactions] failed to parse end date \"%s\", because %s", endDate, err.Error())
+		return 0, 0, errs.ErrParameterInvalid
+	}
+
+	// never create scheduled transactions in the future
+	endUnixTime := min(endTime.Unix(), time.Now().Unix())
+
+	if startTime.Unix() > endUnixTime {
+		log.CliErrorf(c, "[user_data.CreateScheduledTransactions] start date \"%s\" is later than end date \"%s\" or now", startDate, endDate)
+		return 0, 0, errs.ErrParameterInvalid
+	}
+
+	createdCount, existedCount, err := l.transactions.CreateScheduledTransactionsByTimeRange(c, uid, startTime.Unix(), endUnixTime)
+
+	if err != nil {
+		log.CliErrorf(c, "[user_data.CreateScheduledTransactions] failed to create scheduled transactions for user \"%s\", because %s", username, err.Error())
+		return createdCount, existedCount, err
+	}
+
+	return createdCount, existedCount, nil
+}
+
 // FixTransactionTagIndexWithTransactionTime fixes user transaction tag index data with transaction time
 func (l *UserDataCli) FixTransactionTagIndexWithTransactionTime(c *core.CliContext, username string) (bool, error) {
 	if username == "" {
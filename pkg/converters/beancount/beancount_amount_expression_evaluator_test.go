@@ -181,6 +181,34 @@ func TestEvaluateBeancountAmountExpression_ValidExpression(t *testing.T) {
 	assert.Equal(t, "10.00", result)
 }
 
+func TestEvaluateBeancountAmountExpressionWithPrecision(t *testing.T) {
+	context := core.NewNullContext()
+
+	result, err := evaluateBeancountAmountExpressionWithPrecision(context, "0.12345678", 8, false)
+	assert.Nil(t, err)
+	assert.Equal(t, "0.12345678", result)
+
+	result, err = evaluateBeancountAmountExpressionWithPrecision(context, "1.5+2.5", 8, false)
+	assert.Nil(t, err)
+	assert.Equal(t, "4.00000000", result)
+
+	result, err = evaluateBeancountAmountExpressionWithPrecision(context, "1.5+2.5", 8, true)
+	assert.Nil(t, err)
+	assert.Equal(t, "4", result)
+
+	result, err = evaluateBeancountAmountExpressionWithPrecision(context, "0.1+0.2", 8, true)
+	assert.Nil(t, err)
+	assert.Equal(t, "0.3", result)
+
+	result, err = evaluateBeancountAmountExpressionWithPrecision(context, "-1.555", 8, true)
+	assert.Nil(t, err)
+	assert.Equal(t, "-1.555", result)
+
+	result, err = evaluateBeancountAmountExpressionWithPrecision(context, "100", 0, true)
+	assert.Nil(t, err)
+	assert.Equal(t, "100", result)
+}
+
 func TestEvaluateBeancountAmountExpression_InvalidExpression(t *testing.T) {
 	context := core.NewNullContext()
 
@@ -43,6 +43,7 @@ const (
 type beancountData struct {
 	accounts     map[string]*beancountAccount
 	transactions []*beancountTransactionEntry
+	balances     []*beancountBalanceEntry
 }
 
 // beancountAccount defines the structure of beancount account
@@ -65,6 +66,15 @@ type beancountTransactionEntry struct {
 	metadata  map[string]string
 }
 
+// beancountBalanceEntry defines the structure of beancount balance assertion entry
+type beancountBalanceEntry struct {
+	date           string
+	account        string
+	amount         string
+	originalAmount string
+	commodity      string
+}
+
 // beancountPosting defines the structure of beancount transaction posting
 type beancountPosting struct {
 	account            string
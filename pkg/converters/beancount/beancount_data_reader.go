@@ -12,6 +12,7 @@ import (
 	"github.com/mayswind/ezbookkeeping/pkg/core"
 	"github.com/mayswind/ezbookkeeping/pkg/errs"
 	"github.com/mayswind/ezbookkeeping/pkg/log"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
 	"github.com/mayswind/ezbookkeeping/pkg/utils"
 )
 
@@ -51,6 +52,7 @@ func (r *beancountDataReader) read(ctx core.Context) (*beancountData, error) {
 	data := &beancountData{
 		accounts:     make(map[string]*beancountAccount),
 		transactions: make([]*beancountTransactionEntry, 0),
+		balances:     make([]*beancountBalanceEntry, 0),
 	}
 
 	var err error
@@ -150,15 +152,24 @@ func (r *beancountDataReader) read(ctx core.Context) (*beancountData, error) {
 				directive == string(beancountDirectiveInCompleteTransaction) ||
 				directive == string(beancountDirectivePaddingTransaction) {
 				currentTransactionEntry = r.readTransactionLine(ctx, i, items, firstItem, beancountDirective(directive), currentTags)
+			} else if directive == string(beancountDirectiveBalance) {
+				balanceEntry, err := r.readBalanceLine(ctx, i, items, firstItem, data)
+
+				if err != nil {
+					return nil, err
+				}
+
+				if balanceEntry != nil {
+					data.balances = append(data.balances, balanceEntry)
+				}
 			} else if directive == string(beancountDirectiveCommodity) ||
 				directive == string(beancountDirectivePrice) ||
 				directive == string(beancountDirectiveNote) ||
 				directive == string(beancountDirectiveDocument) ||
 				directive == string(beancountDirectiveEvent) ||
-				directive == string(beancountDirectiveBalance) ||
 				directive == string(beancountDirectivePad) ||
 				directive == string(beancountDirectiveQuery) ||
-				directive == string(beancountDirectiveCustom) { // skip commodity / price / note / document / event / balance / pad / query / custom lines
+				directive == string(beancountDirectiveCustom) { // skip commodity / price / note / document / event / pad / query / custom lines
 				continue
 			} else {
 				log.Warnf(ctx, "[beancount_data_reader.read] cannot parse line#%d \"%s\", because directive is unknown", i, strings.Join(items, " "))
@@ -504,6 +515,78 @@ func (r *beancountDataReader) readTransactionPostingLine(ctx core.Context, lineI
 	return transactionPositing, nil
 }
 
+func (r *beancountDataReader) readBalanceLine(ctx core.Context, lineIndex int, items []string, date string, data *beancountData) (*beancountBalanceEntry, error) {
+	// YYYY-MM-DD balance Account Amount [~ Tolerance] Commodity
+	if r.getNotEmptyItemsCount(items) < 4 {
+		log.Warnf(ctx, "[beancount_data_reader.readBalanceLine] cannot parse balance line#%d \"%s\", because items count in line not correct", lineIndex, strings.Join(items, " "))
+		return nil, nil
+	}
+
+	accountName, accountNameActualIndex := r.getNotEmptyItemAndIndexByIndex(items, 2)
+
+	if accountName == "" || accountNameActualIndex < 0 {
+		log.Warnf(ctx, "[beancount_data_reader.readBalanceLine] cannot parse balance line#%d \"%s\", because missing account name", lineIndex, strings.Join(items, " "))
+		return nil, nil
+	}
+
+	balanceEntry := &beancountBalanceEntry{
+		date:    date,
+		account: accountName,
+	}
+
+	amountActualLastIndex := -1
+	balanceEntry.originalAmount, amountActualLastIndex = r.getOriginalAmountAndLastIndexFromIndex(items, accountNameActualIndex+1)
+
+	if balanceEntry.originalAmount == "" || amountActualLastIndex < 0 {
+		log.Warnf(ctx, "[beancount_data_reader.readBalanceLine] cannot parse balance line#%d \"%s\", because missing amount", lineIndex, strings.Join(items, " "))
+		return nil, nil
+	}
+
+	// the precision of balance amount depends on the account, so all the decimal places of supported currencies are kept
+	finalAmount, err := evaluateBeancountAmountExpressionWithPrecision(ctx, balanceEntry.originalAmount, models.MAX_CURRENCY_PRECISION, true)
+
+	if err != nil {
+		log.Warnf(ctx, "[beancount_data_reader.readBalanceLine] cannot evaluate amount expression in line#%d \"%s\", because %s", lineIndex, strings.Join(items, " "), err.Error())
+		return nil, errs.ErrAmountInvalid
+	} else {
+		balanceEntry.amount = finalAmount
+	}
+
+	commodity, commodityActualIndex := r.getNotEmptyItemAndIndexFromIndex(items, amountActualLastIndex+1)
+
+	if commodity == "~" && commodityActualIndex > 0 { // skip [~ Tolerance]
+		_, toleranceActualIndex := r.getNotEmptyItemAndIndexFromIndex(items, commodityActualIndex+1)
+
+		if toleranceActualIndex > 0 {
+			commodity, commodityActualIndex = r.getNotEmptyItemAndIndexFromIndex(items, toleranceActualIndex+1)
+		} else {
+			commodity, commodityActualIndex = "", -1
+		}
+	}
+
+	if commodity == "" || commodityActualIndex < 0 || commodity[0] == beancountCommentPrefix {
+		log.Warnf(ctx, "[beancount_data_reader.readBalanceLine] cannot parse balance line#%d \"%s\", because missing commodity", lineIndex, strings.Join(items, " "))
+		return nil, errs.ErrInvalidBeancountFile
+	}
+
+	if strings.ToUpper(commodity) != commodity { // The syntax for a currency is a word all in capital letters
+		log.Warnf(ctx, "[beancount_data_reader.readBalanceLine] cannot parse balance line#%d \"%s\", because commodity name is not capital letters", lineIndex, strings.Join(items, " "))
+		return nil, errs.ErrInvalidBeancountFile
+	}
+
+	balanceEntry.commodity = commodity
+
+	if _, exists := data.accounts[balanceEntry.account]; !exists {
+		_, err := r.createAccount(ctx, data, balanceEntry.account)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return balanceEntry, nil
+}
+
 func (r *beancountDataReader) readTransactionMetadataLine(ctx core.Context, lineIndex int, items []string) []string {
 	key := r.getNotEmptyItemByIndex(items, 0)
 	value := r.getNotEmptyItemByIndex(items, 1)
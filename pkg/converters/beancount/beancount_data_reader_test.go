@@ -518,3 +518,40 @@ func TestBeancountDataReaderReadTransactionMetadataLine(t *testing.T) {
 	assert.Equal(t, "value 7", actualData.transactions[1].postings[0].metadata["key7"])
 	assert.Equal(t, 0, len(actualData.transactions[1].postings[1].metadata))
 }
+
+func TestBeancountDataReaderReadBalanceLine(t *testing.T) {
+	context := core.NewNullContext()
+	reader, err := createNewBeancountDataReader(context, []byte(""+
+		"2024-01-01 open Assets:TestAccount\n"+
+		"2024-01-02 balance Assets:TestAccount 123.45 CNY\n"+
+		"2024-01-03 balance Liabilities:TestAccount2 -0.12 ~ 0.01 USD ; comment\n"+
+		"2024-01-04 balance Assets:TestAccount 100.00\n"))
+	assert.Nil(t, err)
+
+	_, err = reader.read(context)
+	assert.EqualError(t, err, errs.ErrInvalidBeancountFile.Message)
+
+	reader, err = createNewBeancountDataReader(context, []byte(""+
+		"2024-01-01 open Assets:TestAccount\n"+
+		"2024-01-02 balance Assets:TestAccount 123.45 CNY\n"+
+		"2024-01-03 balance Liabilities:TestAccount2 -0.12 ~ 0.01 USD ; comment\n"+
+		"2024-01-04 balance Assets:TestAccount\n"))
+	assert.Nil(t, err)
+
+	actualData, err := reader.read(context)
+	assert.Nil(t, err)
+
+	assert.Equal(t, 2, len(actualData.accounts))
+	assert.Equal(t, 2, len(actualData.balances))
+
+	assert.Equal(t, "2024-01-02", actualData.balances[0].date)
+	assert.Equal(t, "Assets:TestAccount", actualData.balances[0].account)
+	assert.Equal(t, "123.45", actualData.balances[0].amount)
+	assert.Equal(t, "CNY", actualData.balances[0].commodity)
+
+	assert.Equal(t, "2024-01-03", actualData.balances[1].date)
+	assert.Equal(t, "Liabilities:TestAccount2", actualData.balances[1].account)
+	assert.Equal(t, "-0.12", actualData.balances[1].amount)
+	assert.Equal(t, "USD", actualData.balances[1].commodity)
+	assert.Equal(t, beancountLiabilitiesAccountType, actualData.accounts["Liabilities:TestAccount2"].accountType)
+}
@@ -1,10 +1,15 @@
 package beancount
 
 import (
+	"strings"
+
 	"github.com/mayswind/ezbookkeeping/pkg/converters/converter"
 	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
 	"github.com/mayswind/ezbookkeeping/pkg/models"
 	"github.com/mayswind/ezbookkeeping/pkg/utils"
+	"github.com/mayswind/ezbookkeeping/pkg/validators"
 )
 
 var beancountTransactionTypeNameMapping = map[models.TransactionType]string{
@@ -47,3 +52,83 @@ func (c *beancountTransactionDataImporter) ParseImportedData(ctx core.Context, u
 
 	return dataTableImporter.ParseImportedData(ctx, user, transactionDataTable, defaultTimezoneOffset, accountMap, expenseCategoryMap, incomeCategoryMap, transferCategoryMap, tagMap)
 }
+
+// ParseImportedAccountBalanceAssertions returns the imported account balance assertions by parsing the balance directives in Beancount data
+func (c *beancountTransactionDataImporter) ParseImportedAccountBalanceAssertions(ctx core.Context, user *models.User, data []byte, defaultTimezoneOffset int16, accountMap map[string]*models.Account) (models.ImportAccountBalanceAssertionSlice, error) {
+	beancountDataReader, err := createNewBeancountDataReader(ctx, data)
+
+	if err != nil {
+		return nil, err
+	}
+
+	beancountData, err := beancountDataReader.read(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if accountMap == nil {
+		accountMap = make(map[string]*models.Account)
+	}
+
+	assertions := make(models.ImportAccountBalanceAssertionSlice, 0, len(beancountData.balances))
+
+	for i := 0; i < len(beancountData.balances); i++ {
+		balanceEntry := beancountData.balances[i]
+
+		// The balance assertion in Beancount applies at the beginning of the date
+		assertionTime, err := utils.ParseFromLongDateFirstTime(strings.ReplaceAll(balanceEntry.date, "/", "-"), defaultTimezoneOffset)
+
+		if err != nil {
+			log.Errorf(ctx, "[beancount_transaction_data_file_importer.ParseImportedAccountBalanceAssertions] cannot parse balance date \"%s\", because %s", balanceEntry.date, err.Error())
+			return nil, errs.ErrTransactionTimeInvalid
+		}
+
+		expectedBalance, err := utils.ParseAmountWithPrecision(balanceEntry.amount, c.getAccountCurrencyPrecision(balanceEntry.account, balanceEntry.commodity, accountMap))
+
+		if err != nil {
+			log.Errorf(ctx, "[beancount_transaction_data_file_importer.ParseImportedAccountBalanceAssertions] cannot parse balance amount \"%s\", because %s", balanceEntry.amount, err.Error())
+			return nil, errs.ErrAmountInvalid
+		}
+
+		accountId := int64(0)
+
+		if account, exists := accountMap[balanceEntry.account]; exists {
+			accountId = account.AccountId
+		}
+
+		assertions = append(assertions, &models.ImportAccountBalanceAssertion{
+			AccountBalanceAssertion: &models.AccountBalanceAssertion{
+				Uid:               user.Uid,
+				AccountId:         accountId,
+				AssertionTime:     assertionTime.Unix(),
+				TimezoneUtcOffset: defaultTimezoneOffset,
+				ExpectedBalance:   expectedBalance,
+			},
+			OriginalAccountName:     balanceEntry.account,
+			OriginalAccountCurrency: balanceEntry.commodity,
+		})
+	}
+
+	return assertions, nil
+}
+
+// getAccountCurrencyPrecision returns the currency precision of the existed account,
+// or the precision which the new account of the specified currency would use
+func (c *beancountTransactionDataImporter) getAccountCurrencyPrecision(accountName string, currency string, accountMap map[string]*models.Account) int {
+	if account, exists := accountMap[accountName]; exists {
+		return account.GetCurrencyPrecision()
+	}
+
+	if _, ok := validators.AllCurrencyNames[currency]; ok {
+		return models.DEFAULT_CURRENCY_PRECISION
+	}
+
+	for _, account := range accountMap {
+		if account.Currency == currency {
+			return account.GetCurrencyPrecision()
+		}
+	}
+
+	return models.DEFAULT_CURRENCY_PRECISION
+}
@@ -356,3 +356,82 @@ func TestBeancountTransactionDataFileParseImportedData_MissingTransactionRequire
 			"  Assets:TestAccount 123.45\n"), 0, nil, nil, nil, nil, nil)
 	assert.EqualError(t, err, errs.ErrInvalidBeancountFile.Message)
 }
+
+func TestBeancountTransactionDataFileParseImportedAccountBalanceAssertions(t *testing.T) {
+	converter := BeancountTransactionDataImporter
+	context := core.NewNullContext()
+
+	user := &models.User{
+		Uid:             1234567890,
+		DefaultCurrency: "CNY",
+	}
+
+	accountMap := map[string]*models.Account{
+		"Assets:TestAccount": {
+			AccountId: 123,
+			Name:      "Assets:TestAccount",
+		},
+	}
+
+	allNewAssertions, err := converter.ParseImportedAccountBalanceAssertions(context, user, []byte(
+		"2024-09-01 *\n"+
+			"  Equity:Opening-Balances -123.45 CNY\n"+
+			"  Assets:TestAccount 123.45 CNY\n"+
+			"2024-09-02 balance Assets:TestAccount 123.45 CNY\n"+
+			"2024-09-03 balance Assets:TestAccount2 -1.00 USD\n"), 480, accountMap)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(allNewAssertions))
+
+	assert.Equal(t, int64(1234567890), allNewAssertions[0].Uid)
+	assert.Equal(t, int64(123), allNewAssertions[0].AccountId)
+	assert.Equal(t, int64(1725206400), allNewAssertions[0].AssertionTime)
+	assert.Equal(t, int16(480), allNewAssertions[0].TimezoneUtcOffset)
+	assert.Equal(t, int64(12345), allNewAssertions[0].ExpectedBalance)
+	assert.Equal(t, "Assets:TestAccount", allNewAssertions[0].OriginalAccountName)
+	assert.Equal(t, "CNY", allNewAssertions[0].OriginalAccountCurrency)
+
+	assert.Equal(t, int64(1234567890), allNewAssertions[1].Uid)
+	assert.Equal(t, int64(0), allNewAssertions[1].AccountId)
+	assert.Equal(t, int64(1725292800), allNewAssertions[1].AssertionTime)
+	assert.Equal(t, int64(-100), allNewAssertions[1].ExpectedBalance)
+	assert.Equal(t, "Assets:TestAccount2", allNewAssertions[1].OriginalAccountName)
+	assert.Equal(t, "USD", allNewAssertions[1].OriginalAccountCurrency)
+}
+
+func TestBeancountTransactionDataFileParseImportedAccountBalanceAssertions_CurrencyPrecision(t *testing.T) {
+	converter := BeancountTransactionDataImporter
+	context := core.NewNullContext()
+
+	user := &models.User{
+		Uid:             1234567890,
+		DefaultCurrency: "CNY",
+	}
+
+	precision := 8
+	accountMap := map[string]*models.Account{
+		"Assets:Bitcoin": {
+			AccountId: 123,
+			Name:      "Assets:Bitcoin",
+			Currency:  "BTC",
+			Extend: &models.AccountExtend{
+				CurrencyPrecision: &precision,
+			},
+		},
+	}
+
+	allNewAssertions, err := converter.ParseImportedAccountBalanceAssertions(context, user, []byte(
+		"2024-09-02 balance Assets:Bitcoin 0.12345678 BTC\n"+
+			"2024-09-03 balance Assets:Bitcoin2 0.5 BTC\n"+
+			"2024-09-04 balance Assets:TestAccount 1.5 USD\n"), 480, accountMap)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(allNewAssertions))
+	assert.Equal(t, int64(12345678), allNewAssertions[0].ExpectedBalance)
+	assert.Equal(t, int64(50000000), allNewAssertions[1].ExpectedBalance)
+	assert.Equal(t, int64(150), allNewAssertions[2].ExpectedBalance)
+
+	_, err = converter.ParseImportedAccountBalanceAssertions(context, user, []byte(
+		"2024-09-04 balance Assets:TestAccount 1.555 USD\n"), 480, accountMap)
+	assert.EqualError(t, err, errs.ErrAmountInvalid.Message)
+}
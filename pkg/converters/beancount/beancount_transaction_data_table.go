@@ -22,6 +22,7 @@ var beancountTransactionSupportedColumns = map[datatable.TransactionDataTableCol
 	datatable.TRANSACTION_DATA_TABLE_RELATED_ACCOUNT_CURRENCY: true,
 	datatable.TRANSACTION_DATA_TABLE_RELATED_AMOUNT:           true,
 	datatable.TRANSACTION_DATA_TABLE_DESCRIPTION:              true,
+	datatable.TRANSACTION_DATA_TABLE_PAYEE:                    true,
 }
 
 var BEANCOUNT_TRANSACTION_TAG_SEPARATOR = "#"
@@ -232,6 +233,7 @@ func (t *beancountTransactionDataRowIterator) parseTransaction(ctx core.Context,
 
 	data[datatable.TRANSACTION_DATA_TABLE_TAGS] = strings.Join(beancountEntry.tags, BEANCOUNT_TRANSACTION_TAG_SEPARATOR)
 	data[datatable.TRANSACTION_DATA_TABLE_DESCRIPTION] = beancountEntry.narration
+	data[datatable.TRANSACTION_DATA_TABLE_PAYEE] = beancountEntry.payee
 
 	return data, nil
 }
@@ -37,12 +37,12 @@ func (c *DataTableTransactionDataExporter) BuildExportedContent(ctx core.Context
 		dataRowMap[datatable.TRANSACTION_DATA_TABLE_SUB_CATEGORY] = c.getExportedTransactionSubCategoryName(dataTableBuilder, transaction.CategoryId, categoryMap)
 		dataRowMap[datatable.TRANSACTION_DATA_TABLE_ACCOUNT_NAME] = c.getExportedAccountName(dataTableBuilder, transaction.AccountId, accountMap)
 		dataRowMap[datatable.TRANSACTION_DATA_TABLE_ACCOUNT_CURRENCY] = c.getAccountCurrency(dataTableBuilder, transaction.AccountId, accountMap)
-		dataRowMap[datatable.TRANSACTION_DATA_TABLE_AMOUNT] = utils.FormatAmount(transaction.Amount)
+		dataRowMap[datatable.TRANSACTION_DATA_TABLE_AMOUNT] = utils.FormatAmountWithPrecision(transaction.Amount, c.getAccountCurrencyPrecision(transaction.AccountId, accountMap))
 
 		if transaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_OUT {
 			dataRowMap[datatable.TRANSACTION_DATA_TABLE_RELATED_ACCOUNT_NAME] = c.getExportedAccountName(dataTableBuilder, transaction.RelatedAccountId, accountMap)
 			dataRowMap[datatable.TRANSACTION_DATA_TABLE_RELATED_ACCOUNT_CURRENCY] = c.getAccountCurrency(dataTableBuilder, transaction.RelatedAccountId, accountMap)
-			dataRowMap[datatable.TRANSACTION_DATA_TABLE_RELATED_AMOUNT] = utils.FormatAmount(transaction.RelatedAccountAmount)
+			dataRowMap[datatable.TRANSACTION_DATA_TABLE_RELATED_AMOUNT] = utils.FormatAmountWithPrecision(transaction.RelatedAccountAmount, c.getAccountCurrencyPrecision(transaction.RelatedAccountId, accountMap))
 		}
 
 		dataRowMap[datatable.TRANSACTION_DATA_TABLE_GEOGRAPHIC_LOCATION] = c.getExportedGeographicLocation(transaction)
@@ -121,6 +121,16 @@ func (c *DataTableTransactionDataExporter) getAccountCurrency(dataTableBuilder d
 	}
 }
 
+func (c *DataTableTransactionDataExporter) getAccountCurrencyPrecision(accountId int64, accountMap map[int64]*models.Account) int {
+	account, exists := accountMap[accountId]
+
+	if exists {
+		return account.GetCurrencyPrecision()
+	} else {
+		return models.DEFAULT_CURRENCY_PRECISION
+	}
+}
+
 func (c *DataTableTransactionDataExporter) getExportedGeographicLocation(transaction *models.Transaction) string {
 	if transaction.GeoLongitude != 0 || transaction.GeoLatitude != 0 {
 		return fmt.Sprintf("%f%s%f", transaction.GeoLongitude, c.geoLocationSeparator, transaction.GeoLatitude)
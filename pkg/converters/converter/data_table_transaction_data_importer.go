@@ -182,7 +182,7 @@ func (c *DataTableTransactionDataImporter) ParseImportedData(ctx core.Context, u
 		if dataTable.HasColumn(datatable.TRANSACTION_DATA_TABLE_ACCOUNT_CURRENCY) {
 			accountCurrency = dataRow.GetData(datatable.TRANSACTION_DATA_TABLE_ACCOUNT_CURRENCY)
 
-			if _, ok := validators.AllCurrencyNames[accountCurrency]; !ok {
+			if _, ok := c.getCurrencyPrecision(accountCurrency, accountMap); !ok {
 				log.Errorf(ctx, "[data_table_transaction_data_exporter.ParseImportedData] account currency \"%s\" is not supported in data row \"index:%d\" for user \"uid:%d\"", accountCurrency, dataRowIndex, user.Uid)
 				return nil, nil, nil, nil, nil, nil, errs.ErrAccountCurrencyInvalid
 			}
@@ -191,7 +191,7 @@ func (c *DataTableTransactionDataImporter) ParseImportedData(ctx core.Context, u
 		account, exists := accountMap[accountName]
 
 		if !exists {
-			account = c.createNewAccountModel(user.Uid, accountName, accountCurrency)
+			account = c.createNewAccountModel(user.Uid, accountName, accountCurrency, accountMap)
 			allNewAccounts = append(allNewAccounts, account)
 			accountMap[accountName] = account
 		}
@@ -205,7 +205,7 @@ func (c *DataTableTransactionDataImporter) ParseImportedData(ctx core.Context, u
 			accountCurrency = account.Currency
 		}
 
-		amount, err := utils.ParseAmount(dataRow.GetData(datatable.TRANSACTION_DATA_TABLE_AMOUNT))
+		amount, err := utils.ParseAmountWithPrecision(dataRow.GetData(datatable.TRANSACTION_DATA_TABLE_AMOUNT), account.GetCurrencyPrecision())
 
 		if err != nil {
 			log.Errorf(ctx, "[data_table_transaction_data_exporter.ParseImportedData] cannot parse acmount \"%s\" in data row \"index:%d\" for user \"uid:%d\", because %s", dataRow.GetData(datatable.TRANSACTION_DATA_TABLE_AMOUNT), dataRowIndex, user.Uid, err.Error())
@@ -224,7 +224,7 @@ func (c *DataTableTransactionDataImporter) ParseImportedData(ctx core.Context, u
 			if dataTable.HasColumn(datatable.TRANSACTION_DATA_TABLE_RELATED_ACCOUNT_CURRENCY) {
 				account2Currency = dataRow.GetData(datatable.TRANSACTION_DATA_TABLE_RELATED_ACCOUNT_CURRENCY)
 
-				if _, ok := validators.AllCurrencyNames[account2Currency]; !ok {
+				if _, ok := c.getCurrencyPrecision(account2Currency, accountMap); !ok {
 					log.Errorf(ctx, "[data_table_transaction_data_exporter.ParseImportedData] account2 currency \"%s\" is not supported in data row \"index:%d\" for user \"uid:%d\"", account2Currency, dataRowIndex, user.Uid)
 					return nil, nil, nil, nil, nil, nil, errs.ErrAccountCurrencyInvalid
 				}
@@ -233,7 +233,7 @@ func (c *DataTableTransactionDataImporter) ParseImportedData(ctx core.Context, u
 			account2, exists := accountMap[account2Name]
 
 			if !exists {
-				account2 = c.createNewAccountModel(user.Uid, account2Name, account2Currency)
+				account2 = c.createNewAccountModel(user.Uid, account2Name, account2Currency, accountMap)
 				allNewAccounts = append(allNewAccounts, account2)
 				accountMap[account2Name] = account2
 			}
@@ -250,7 +250,7 @@ func (c *DataTableTransactionDataImporter) ParseImportedData(ctx core.Context, u
 			relatedAccountId = account2.AccountId
 
 			if dataTable.HasColumn(datatable.TRANSACTION_DATA_TABLE_RELATED_AMOUNT) {
-				relatedAccountAmount, err = utils.ParseAmount(dataRow.GetData(datatable.TRANSACTION_DATA_TABLE_RELATED_AMOUNT))
+				relatedAccountAmount, err = utils.ParseAmountWithPrecision(dataRow.GetData(datatable.TRANSACTION_DATA_TABLE_RELATED_AMOUNT), account2.GetCurrencyPrecision())
 
 				if err != nil {
 					log.Errorf(ctx, "[data_table_transaction_data_exporter.ParseImportedData] cannot parse acmount2 \"%s\" in data row \"index:%d\" for user \"uid:%d\", because %s", dataRow.GetData(datatable.TRANSACTION_DATA_TABLE_RELATED_AMOUNT), dataRowIndex, user.Uid, err.Error())
@@ -325,6 +325,12 @@ func (c *DataTableTransactionDataImporter) ParseImportedData(ctx core.Context, u
 			description = dataRow.GetData(datatable.TRANSACTION_DATA_TABLE_DESCRIPTION)
 		}
 
+		payee := ""
+
+		if dataTable.HasColumn(datatable.TRANSACTION_DATA_TABLE_PAYEE) {
+			payee = dataRow.GetData(datatable.TRANSACTION_DATA_TABLE_PAYEE)
+		}
+
 		transaction := &models.ImportTransaction{
 			Transaction: &models.Transaction{
 				Uid:                  user.Uid,
@@ -349,6 +355,7 @@ func (c *DataTableTransactionDataImporter) ParseImportedData(ctx core.Context, u
 			OriginalDestinationAccountName:     account2Name,
 			OriginalDestinationAccountCurrency: account2Currency,
 			OriginalTagNames:                   tagNames,
+			OriginalPayee:                      payee,
 		}
 
 		allNewTransactions = append(allNewTransactions, transaction)
@@ -442,12 +449,35 @@ func (c *DataTableTransactionDataImporter) getTransactionCategory(categories map
 	return subCategory, exists
 }
 
-func (c *DataTableTransactionDataImporter) createNewAccountModel(uid int64, accountName string, currency string) *models.Account {
-	return &models.Account{
+func (c *DataTableTransactionDataImporter) getCurrencyPrecision(currency string, accountMap map[string]*models.Account) (int, bool) {
+	if _, ok := validators.AllCurrencyNames[currency]; ok {
+		return models.DEFAULT_CURRENCY_PRECISION, true
+	}
+
+	// user defined currency is only supported when there is any account using it
+	for _, account := range accountMap {
+		if account.Currency == currency {
+			return account.GetCurrencyPrecision(), true
+		}
+	}
+
+	return 0, false
+}
+
+func (c *DataTableTransactionDataImporter) createNewAccountModel(uid int64, accountName string, currency string, accountMap map[string]*models.Account) *models.Account {
+	account := &models.Account{
 		Uid:      uid,
 		Name:     accountName,
 		Currency: currency,
 	}
+
+	if precision, ok := c.getCurrencyPrecision(currency, accountMap); ok && precision != models.DEFAULT_CURRENCY_PRECISION {
+		account.Extend = &models.AccountExtend{
+			CurrencyPrecision: &precision,
+		}
+	}
+
+	return account
 }
 
 func (c *DataTableTransactionDataImporter) createNewTransactionCategoryModel(uid int64, categoryName string, transactionCategoryType models.TransactionCategoryType) *models.TransactionCategory {
@@ -17,6 +17,12 @@ type TransactionDataImporter interface {
 	ParseImportedData(ctx core.Context, user *models.User, data []byte, defaultTimezoneOffset int16, accountMap map[string]*models.Account, expenseCategoryMap map[string]map[string]*models.TransactionCategory, incomeCategoryMap map[string]map[string]*models.TransactionCategory, transferCategoryMap map[string]map[string]*models.TransactionCategory, tagMap map[string]*models.TransactionTag) (models.ImportedTransactionSlice, []*models.Account, []*models.TransactionCategory, []*models.TransactionCategory, []*models.TransactionCategory, []*models.TransactionTag, error)
 }
 
+// AccountBalanceAssertionDataImporter defines the structure of transaction data importer which also supports importing account balance assertions
+type AccountBalanceAssertionDataImporter interface {
+	// ParseImportedAccountBalanceAssertions returns the imported account balance assertions
+	ParseImportedAccountBalanceAssertions(ctx core.Context, user *models.User, data []byte, defaultTimezoneOffset int16, accountMap map[string]*models.Account) (models.ImportAccountBalanceAssertionSlice, error)
+}
+
 // TransactionDataConverter defines the structure of transaction data converter
 type TransactionDataConverter interface {
 	TransactionDataExporter
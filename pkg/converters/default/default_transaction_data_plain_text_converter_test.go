@@ -125,6 +125,43 @@ func TestDefaultTransactionDataCSVFileConverterToExportedContent(t *testing.T) {
 	assert.Equal(t, expectedContent, string(actualContent))
 }
 
+func TestDefaultTransactionDataCSVFileConverterToExportedContent_UserCustomCurrency(t *testing.T) {
+	converter := DefaultTransactionDataCSVFileConverter
+	context := core.NewNullContext()
+
+	transactions := []*models.Transaction{
+		{
+			TransactionId:        1,
+			TransactionTime:      1725165296000,
+			Type:                 models.TRANSACTION_DB_TYPE_TRANSFER_OUT,
+			TimezoneUtcOffset:    480,
+			CategoryId:           1,
+			AccountId:            1,
+			Amount:               12345678,
+			RelatedAccountId:     2,
+			RelatedAccountAmount: 5,
+		},
+	}
+
+	btcPrecision := 8
+	pointsPrecision := 0
+	accountMap := map[int64]*models.Account{
+		1: {AccountId: 1, Name: "Wallet", Currency: "BTC", Extend: &models.AccountExtend{CurrencyPrecision: &btcPrecision}},
+		2: {AccountId: 2, Name: "Points", Currency: "PTS", Extend: &models.AccountExtend{CurrencyPrecision: &pointsPrecision}},
+	}
+
+	categoryMap := map[int64]*models.TransactionCategory{
+		1: {CategoryId: 1, Type: models.CATEGORY_TYPE_TRANSFER, Name: "Test Category"},
+	}
+
+	expectedContent := "Time,Timezone,Type,Category,Sub Category,Account,Account Currency,Amount,Account2,Account2 Currency,Account2 Amount,Geographic Location,Tags,Description\n" +
+		"2024-09-01 12:34:56,+08:00,Transfer,Test Category,Test Category,Wallet,BTC,0.12345678,Points,PTS,5,,,\n"
+	actualContent, err := converter.ToExportedContent(context, 123, transactions, accountMap, categoryMap, nil, nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedContent, string(actualContent))
+}
+
 func TestDefaultTransactionDataCSVFileConverterParseImportedData_MinimumValidData(t *testing.T) {
 	converter := DefaultTransactionDataCSVFileConverter
 	context := core.NewNullContext()
@@ -335,6 +372,52 @@ func TestDefaultTransactionDataCSVFileConverterParseImportedData_ParseNotSupport
 	assert.EqualError(t, err, errs.ErrAccountCurrencyInvalid.Message)
 }
 
+func TestDefaultTransactionDataCSVFileConverterParseImportedData_ParseUserCustomCurrency(t *testing.T) {
+	converter := DefaultTransactionDataCSVFileConverter
+	context := core.NewNullContext()
+
+	user := &models.User{
+		Uid:             1234567890,
+		DefaultCurrency: "CNY",
+	}
+
+	precision := 8
+	accountMap := map[string]*models.Account{
+		"Wallet": {
+			AccountId: 1,
+			Name:      "Wallet",
+			Currency:  "BTC",
+			Extend: &models.AccountExtend{
+				CurrencyPrecision: &precision,
+			},
+		},
+	}
+
+	allNewTransactions, allNewAccounts, _, _, _, _, err := converter.ParseImportedData(context, user, []byte("Time,Type,Sub Category,Account,Account Currency,Amount,Account2,Account2 Currency,Account2 Amount\n"+
+		"2024-09-01 01:23:45,Income,Test Category,Wallet,BTC,0.00012345,,,\n"+
+		"2024-09-01 12:34:56,Transfer,Test Category2,Wallet,BTC,0.5,Cold Wallet,BTC,0.49990000"), 0, accountMap, nil, nil, nil, nil)
+
+	assert.Nil(t, err)
+
+	assert.Equal(t, 2, len(allNewTransactions))
+	assert.Equal(t, int64(12345), allNewTransactions[0].Amount)
+	assert.Equal(t, int64(50000000), allNewTransactions[1].Amount)
+	assert.Equal(t, int64(49990000), allNewTransactions[1].RelatedAccountAmount)
+
+	assert.Equal(t, 1, len(allNewAccounts))
+	assert.Equal(t, "Cold Wallet", allNewAccounts[0].Name)
+	assert.Equal(t, "BTC", allNewAccounts[0].Currency)
+	assert.Equal(t, 8, allNewAccounts[0].GetCurrencyPrecision())
+
+	_, _, _, _, _, _, err = converter.ParseImportedData(context, user, []byte("Time,Type,Sub Category,Account,Account Currency,Amount,Account2,Account2 Currency,Account2 Amount\n"+
+		"2024-09-01 01:23:45,Income,Test Category,Wallet,BTC,0.000000001,,,"), 0, accountMap, nil, nil, nil, nil)
+	assert.EqualError(t, err, errs.ErrAmountInvalid.Message)
+
+	_, _, _, _, _, _, err = converter.ParseImportedData(context, user, []byte("Time,Type,Sub Category,Account,Account Currency,Amount,Account2,Account2 Currency,Account2 Amount\n"+
+		"2024-09-01 01:23:45,Income,Test Category,Wallet2,ETH,1.5,,,"), 0, accountMap, nil, nil, nil, nil)
+	assert.EqualError(t, err, errs.ErrAccountCurrencyInvalid.Message)
+}
+
 func TestDefaultTransactionDataCSVFileConverterParseImportedData_ParseInvalidAmount(t *testing.T) {
 	converter := DefaultTransactionDataCSVFileConverter
 	context := core.NewNullContext()
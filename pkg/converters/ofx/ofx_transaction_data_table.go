@@ -24,6 +24,7 @@ var ofxTransactionSupportedColumns = map[datatable.TransactionDataTableColumn]bo
 	datatable.TRANSACTION_DATA_TABLE_RELATED_ACCOUNT_CURRENCY: true,
 	datatable.TRANSACTION_DATA_TABLE_RELATED_AMOUNT:           true,
 	datatable.TRANSACTION_DATA_TABLE_DESCRIPTION:              true,
+	datatable.TRANSACTION_DATA_TABLE_PAYEE:                    true,
 }
 
 // ofxTransactionData defines the structure of open financial exchange (ofx) transaction data
@@ -216,6 +217,12 @@ func (t *ofxTransactionDataRowIterator) parseTransaction(ctx core.Context, user
 		data[datatable.TRANSACTION_DATA_TABLE_DESCRIPTION] = ""
 	}
 
+	if ofxTransaction.Payee != nil {
+		data[datatable.TRANSACTION_DATA_TABLE_PAYEE] = ofxTransaction.Payee.Name
+	} else {
+		data[datatable.TRANSACTION_DATA_TABLE_PAYEE] = ofxTransaction.Name
+	}
+
 	return data, nil
 }
 
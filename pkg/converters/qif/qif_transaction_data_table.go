@@ -23,6 +23,7 @@ var qifTransactionSupportedColumns = map[datatable.TransactionDataTableColumn]bo
 	datatable.TRANSACTION_DATA_TABLE_AMOUNT:               true,
 	datatable.TRANSACTION_DATA_TABLE_RELATED_ACCOUNT_NAME: true,
 	datatable.TRANSACTION_DATA_TABLE_DESCRIPTION:          true,
+	datatable.TRANSACTION_DATA_TABLE_PAYEE:                true,
 }
 
 // qifDateFormatType represents the quicken interchange format (qif) date format type
@@ -189,6 +190,10 @@ func (t *qifTransactionDataRowIterator) parseTransaction(ctx core.Context, user
 		data[datatable.TRANSACTION_DATA_TABLE_DESCRIPTION] = qifTransaction.payee
 	}
 
+	if qifTransaction.payee != qifOpeningBalancePayeeText {
+		data[datatable.TRANSACTION_DATA_TABLE_PAYEE] = qifTransaction.payee
+	}
+
 	return data, nil
 }
 
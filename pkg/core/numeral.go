@@ -31,6 +31,18 @@ func (f DecimalSeparator) String() string {
 	}
 }
 
+// Rune returns the character of the decimal separator, or 0 if the decimal separator is default or invalid
+func (f DecimalSeparator) Rune() rune {
+	switch f {
+	case DECIMAL_SEPARATOR_DOT:
+		return '.'
+	case DECIMAL_SEPARATOR_COMMA:
+		return ','
+	default:
+		return 0
+	}
+}
+
 // DigitGroupingSymbol represents the digit grouping symbol
 type DigitGroupingSymbol byte
 
@@ -64,6 +76,22 @@ func (f DigitGroupingSymbol) String() string {
 	}
 }
 
+// Rune returns the character of the digit grouping symbol, or 0 if the digit grouping symbol is default or invalid
+func (f DigitGroupingSymbol) Rune() rune {
+	switch f {
+	case DIGIT_GROUPING_SYMBOL_DOT:
+		return '.'
+	case DIGIT_GROUPING_SYMBOL_COMMA:
+		return ','
+	case DIGIT_GROUPING_SYMBOL_SPACE:
+		return ' '
+	case DIGIT_GROUPING_SYMBOL_APOSTROPHE:
+		return '\''
+	default:
+		return 0
+	}
+}
+
 // DigitGroupingType represents digit grouping type
 type DigitGroupingType byte
 
@@ -84,6 +84,30 @@ func (c *CronJobSchedulerContainer) registerAllJobs(ctx core.Context, config *se
 	if config.EnableCreateScheduledTransaction {
 		Container.registerIntervalJob(ctx, CreateScheduledTransactionJob)
 	}
+
+	if config.EnableSendBillReminder && config.EnableSMTP {
+		Container.registerIntervalJob(ctx, SendBillReminderJob)
+	}
+
+	if config.EnableWebhook {
+		Container.registerIntervalJob(ctx, DeliverWebhookEventsJob)
+	}
+
+	if config.EnableAnomalyDetection {
+		Container.registerIntervalJob(ctx, DetectTransactionAnomaliesJob)
+	}
+
+	if config.EnableSendAnomalyAlert && config.EnableAnomalyDetection && config.EnableSMTP {
+		Container.registerIntervalJob(ctx, SendTransactionAnomalyAlertJob)
+	}
+
+	if config.EnableSendScheduledReport && config.EnableSMTP {
+		Container.registerIntervalJob(ctx, SendScheduledReportJob)
+	}
+
+	if config.EnableSaveExchangeRatesHistory {
+		Container.registerIntervalJob(ctx, SaveExchangeRatesHistoryJob)
+	}
 }
 
 func (c *CronJobSchedulerContainer) registerIntervalJob(ctx core.Context, job *CronJob) {
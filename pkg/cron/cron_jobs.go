@@ -30,3 +30,75 @@ var CreateScheduledTransactionJob = &CronJob{
 		return services.Transactions.CreateScheduledTransactions(c, time.Now().Unix(), c.GetInterval())
 	},
 }
+
+// SendBillReminderJob represents the cron job which periodically send reminder mails of upcoming scheduled transactions and credit card statement dates
+var SendBillReminderJob = &CronJob{
+	Name:        "SendBillReminder",
+	Description: "Periodically send reminder mails of upcoming scheduled transactions and credit card statement dates.",
+	Period: CronJobFixedHourPeriod{
+		Hour: 8,
+	},
+	Run: func(c *core.CronContext) error {
+		return services.BillReminders.SendBillReminders(c, time.Now().Unix())
+	},
+}
+
+// DeliverWebhookEventsJob represents the cron job which periodically send pending webhook events to the webhook urls
+var DeliverWebhookEventsJob = &CronJob{
+	Name:        "DeliverWebhookEvents",
+	Description: "Periodically send pending webhook events to the webhook urls.",
+	Period: CronJobIntervalPeriod{
+		Interval: time.Minute,
+	},
+	Run: func(c *core.CronContext) error {
+		return services.Webhooks.DeliverPendingWebhookEvents(c, time.Now().Unix())
+	},
+}
+
+// DetectTransactionAnomaliesJob represents the cron job which periodically detect the anomalies of the new and modified transactions in the detection queue
+var DetectTransactionAnomaliesJob = &CronJob{
+	Name:        "DetectTransactionAnomalies",
+	Description: "Periodically detect the anomalies of the new and modified transactions.",
+	Period: CronJobIntervalPeriod{
+		Interval: time.Minute,
+	},
+	Run: func(c *core.CronContext) error {
+		return services.TransactionAnomalies.DetectPendingTransactionAnomalies(c, time.Now().Unix())
+	},
+}
+
+// SendTransactionAnomalyAlertJob represents the cron job which periodically send alert mails of the unusual transactions found by anomaly detection
+var SendTransactionAnomalyAlertJob = &CronJob{
+	Name:        "SendTransactionAnomalyAlert",
+	Description: "Periodically send alert mails of the unusual transactions found by anomaly detection.",
+	Period: CronJobEvery15MinutesPeriod{
+		Second: 30,
+	},
+	Run: func(c *core.CronContext) error {
+		return services.TransactionAnomalies.SendTransactionAnomalyAlerts(c)
+	},
+}
+
+// SendScheduledReportJob represents the cron job which periodically send the weekly and monthly report mails after the report periods end
+var SendScheduledReportJob = &CronJob{
+	Name:        "SendScheduledReport",
+	Description: "Periodically send the weekly and monthly report mails after the report periods end.",
+	Period: CronJobEvery15MinutesPeriod{
+		Second: 45,
+	},
+	Run: func(c *core.CronContext) error {
+		return services.ScheduledReports.SendScheduledReports(c, time.Now().Unix())
+	},
+}
+
+// SaveExchangeRatesHistoryJob represents the cron job which periodically request the latest exchange rates and save them as historical exchange rates
+var SaveExchangeRatesHistoryJob = &CronJob{
+	Name:        "SaveExchangeRatesHistory",
+	Description: "Periodically request the latest exchange rates and save them as historical exchange rates.",
+	Period: CronJobFixedHourPeriod{
+		Hour: 23,
+	},
+	Run: func(c *core.CronContext) error {
+		return services.ExchangeRateHistories.SaveLatestExchangeRates(c)
+	},
+}
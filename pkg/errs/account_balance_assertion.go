@@ -0,0 +1,11 @@
+package errs
+
+import "net/http"
+
+// Error codes related to account balance assertions
+var (
+	ErrAccountBalanceAssertionIdInvalid                = NewNormalError(NormalSubcategoryAssertion, 0, http.StatusBadRequest, "balance assertion id is invalid")
+	ErrAccountBalanceAssertionNotFound                 = NewNormalError(NormalSubcategoryAssertion, 1, http.StatusBadRequest, "balance assertion not found")
+	ErrAccountBalanceAssertionAlreadyExists            = NewNormalError(NormalSubcategoryAssertion, 2, http.StatusBadRequest, "balance assertion of this account at the same time already exists")
+	ErrAccountBalanceAssertionCannotAddToParentAccount = NewNormalError(NormalSubcategoryAssertion, 3, http.StatusBadRequest, "balance assertion cannot be added to account which has sub accounts")
+)
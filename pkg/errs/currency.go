@@ -0,0 +1,13 @@
+package errs
+
+import "net/http"
+
+// Error codes related to user defined currencies
+var (
+	ErrUserCustomCurrencyIdInvalid            = NewNormalError(NormalSubcategoryCurrency, 0, http.StatusBadRequest, "currency id is invalid")
+	ErrUserCustomCurrencyNotFound             = NewNormalError(NormalSubcategoryCurrency, 1, http.StatusBadRequest, "currency not found")
+	ErrUserCustomCurrencyCodeInvalid          = NewNormalError(NormalSubcategoryCurrency, 2, http.StatusBadRequest, "currency code is invalid")
+	ErrUserCustomCurrencyCodeAlreadyExists    = NewNormalError(NormalSubcategoryCurrency, 3, http.StatusBadRequest, "currency code already exists")
+	ErrUserCustomCurrencyPrecisionInvalid     = NewNormalError(NormalSubcategoryCurrency, 4, http.StatusBadRequest, "currency precision is invalid")
+	ErrUserCustomCurrencyInUseCannotBeDeleted = NewNormalError(NormalSubcategoryCurrency, 5, http.StatusBadRequest, "currency is in use and cannot be deleted")
+)
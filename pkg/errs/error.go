@@ -38,6 +38,12 @@ const (
 	NormalSubcategoryTemplate       = 10
 	NormalSubcategoryPicture        = 11
 	NormalSubcategoryConverter      = 12
+	NormalSubcategoryAssertion      = 13
+	NormalSubcategoryRule           = 14
+	NormalSubcategoryWebhook        = 15
+	NormalSubcategoryReport         = 16
+	NormalSubcategoryExchangeRate   = 17
+	NormalSubcategoryCurrency       = 18
 )
 
 // Error represents the specific error returned to user
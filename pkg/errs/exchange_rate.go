@@ -0,0 +1,15 @@
+package errs
+
+import "net/http"
+
+// Error codes related to exchange rates
+var (
+	ErrExchangeRatesDataSourceNotSupportHistory = NewNormalError(NormalSubcategoryExchangeRate, 0, http.StatusBadRequest, "exchange rates data source does not support historical exchange rates")
+	ErrExchangeRateDateInvalid                  = NewNormalError(NormalSubcategoryExchangeRate, 1, http.StatusBadRequest, "exchange rate date is invalid")
+	ErrExchangeRateDateRangeInvalid             = NewNormalError(NormalSubcategoryExchangeRate, 2, http.StatusBadRequest, "exchange rate date range is invalid")
+	ErrHistoricalExchangeRatesNotFound          = NewNormalError(NormalSubcategoryExchangeRate, 3, http.StatusBadRequest, "historical exchange rates not found")
+	ErrUserExchangeRateIdInvalid                = NewNormalError(NormalSubcategoryExchangeRate, 4, http.StatusBadRequest, "exchange rate id is invalid")
+	ErrUserExchangeRateNotFound                 = NewNormalError(NormalSubcategoryExchangeRate, 5, http.StatusBadRequest, "exchange rate not found")
+	ErrUserExchangeRateInvalid                  = NewNormalError(NormalSubcategoryExchangeRate, 6, http.StatusBadRequest, "exchange rate is invalid")
+	ErrUserExchangeRateCurrencyPairInvalid      = NewNormalError(NormalSubcategoryExchangeRate, 7, http.StatusBadRequest, "base currency and target currency cannot be the same")
+)
@@ -0,0 +1,11 @@
+package errs
+
+import "net/http"
+
+// Error codes related to reports
+var (
+	ErrReportTimeRangeInvalid   = NewNormalError(NormalSubcategoryReport, 0, http.StatusBadRequest, "report time range is invalid")
+	ErrScheduledReportIdInvalid = NewNormalError(NormalSubcategoryReport, 1, http.StatusBadRequest, "scheduled report id is invalid")
+	ErrScheduledReportNotFound  = NewNormalError(NormalSubcategoryReport, 2, http.StatusBadRequest, "scheduled report not found")
+	ErrReportPdfTextUnsupported = NewNormalError(NormalSubcategoryReport, 3, http.StatusBadRequest, "report contains characters which cannot be displayed in pdf")
+)
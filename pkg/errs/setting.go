@@ -23,4 +23,6 @@ var (
 	ErrInvalidAmapSecurityVerificationMethod          = NewSystemError(SystemSubcategorySetting, 16, http.StatusInternalServerError, "invalid amap security verification method")
 	ErrInvalidPasswordResetTokenExpiredTime           = NewSystemError(SystemSubcategorySetting, 17, http.StatusInternalServerError, "invalid password reset token expired time")
 	ErrInvalidExchangeRatesDataSource                 = NewSystemError(SystemSubcategorySetting, 18, http.StatusInternalServerError, "invalid exchange rates data source")
+	ErrInvalidPdfFontPath                             = NewSystemError(SystemSubcategorySetting, 19, http.StatusInternalServerError, "invalid pdf font path")
+	ErrInvalidPdfFont                                 = NewSystemError(SystemSubcategorySetting, 20, http.StatusInternalServerError, "invalid pdf font")
 )
@@ -41,4 +41,8 @@ var (
 	ErrImportFileTransactionTypeMappingInvalid                  = NewSystemError(NormalSubcategoryTransaction, 34, http.StatusBadRequest, "transaction type mapping invalid")
 	ErrImportFileTransactionTimeFormatInvalid                   = NewSystemError(NormalSubcategoryTransaction, 35, http.StatusBadRequest, "transaction time format invalid")
 	ErrImportFileTransactionTimezoneFormatInvalid               = NewSystemError(NormalSubcategoryTransaction, 36, http.StatusBadRequest, "transaction time zone format invalid")
+	ErrQuickAddTextAmountNotRecognized                          = NewNormalError(NormalSubcategoryTransaction, 37, http.StatusBadRequest, "amount is not recognized from quick add text")
+	ErrQuickAddTextAccountNotRecognized                         = NewNormalError(NormalSubcategoryTransaction, 38, http.StatusBadRequest, "account is not recognized from quick add text")
+	ErrTransactionStatisticBreakdownInvalid                     = NewNormalError(NormalSubcategoryTransaction, 39, http.StatusBadRequest, "transaction statistic breakdown is invalid")
+	ErrQuickAddTextDestinationAmountNotRecognized               = NewNormalError(NormalSubcategoryTransaction, 40, http.StatusBadRequest, "destination amount is not recognized from quick add text")
 )
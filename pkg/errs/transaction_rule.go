@@ -0,0 +1,11 @@
+package errs
+
+import "net/http"
+
+// Error codes related to transaction rules
+var (
+	ErrTransactionRuleIdInvalid        = NewNormalError(NormalSubcategoryRule, 0, http.StatusBadRequest, "transaction rule id is invalid")
+	ErrTransactionRuleNotFound         = NewNormalError(NormalSubcategoryRule, 1, http.StatusBadRequest, "transaction rule not found")
+	ErrTransactionRuleConditionInvalid = NewNormalError(NormalSubcategoryRule, 2, http.StatusBadRequest, "transaction rule condition is invalid")
+	ErrTransactionRuleActionInvalid    = NewNormalError(NormalSubcategoryRule, 3, http.StatusBadRequest, "transaction rule action is invalid")
+)
@@ -11,4 +11,8 @@ var (
 	ErrScheduledTransactionFrequencyInvalid                  = NewNormalError(NormalSubcategoryTemplate, 4, http.StatusBadRequest, "scheduled transaction frequency is invalid")
 	ErrTransactionTemplateHasTooManyTags                     = NewNormalError(NormalSubcategoryTemplate, 5, http.StatusBadRequest, "transaction template has too many tags")
 	ErrScheduledTransactionTemplateStartDataLaterThanEndDate = NewNormalError(NormalSubcategoryTemplate, 6, http.StatusBadRequest, "scheduled transaction start date is later than end time")
+	ErrScheduledTransactionForecastTimeRangeInvalid          = NewNormalError(NormalSubcategoryTemplate, 7, http.StatusBadRequest, "scheduled transaction forecast time range is invalid")
+	ErrScheduledTransactionAmountExpressionInvalid           = NewNormalError(NormalSubcategoryTemplate, 8, http.StatusBadRequest, "scheduled transaction amount expression is invalid")
+	ErrRecurringTransactionSeriesNotFound                    = NewNormalError(NormalSubcategoryTemplate, 9, http.StatusBadRequest, "recurring transaction series not found")
+	ErrTransactionTemplateNameIsEmpty                        = NewNormalError(NormalSubcategoryTemplate, 10, http.StatusBadRequest, "transaction template name is empty")
 )
@@ -0,0 +1,13 @@
+package errs
+
+import "net/http"
+
+// Error codes related to webhooks
+var (
+	ErrWebhookIdInvalid         = NewNormalError(NormalSubcategoryWebhook, 0, http.StatusBadRequest, "webhook id is invalid")
+	ErrWebhookNotFound          = NewNormalError(NormalSubcategoryWebhook, 1, http.StatusBadRequest, "webhook not found")
+	ErrWebhookUrlInvalid        = NewNormalError(NormalSubcategoryWebhook, 2, http.StatusBadRequest, "webhook url is invalid")
+	ErrWebhookEventTypesInvalid = NewNormalError(NormalSubcategoryWebhook, 3, http.StatusBadRequest, "webhook event types are invalid")
+	ErrWebhookNotEnabled        = NewNormalError(NormalSubcategoryWebhook, 4, http.StatusBadRequest, "webhook is not enabled")
+	ErrWebhookUrlNotAllowed     = NewNormalError(NormalSubcategoryWebhook, 5, http.StatusBadRequest, "webhook url in private network is not allowed")
+)
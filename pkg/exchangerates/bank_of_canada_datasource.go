@@ -2,6 +2,7 @@ package exchangerates
 
 import (
 	"encoding/json"
+	"fmt"
 	"math"
 	"net/http"
 	"strings"
@@ -16,11 +17,13 @@ import (
 )
 
 const bankOfCanadaExchangeRateUrl = "https://www.bankofcanada.ca/valet/observations/group/FX_RATES_DAILY/json?recent=1"
+const bankOfCanadaHistoricalExchangeRateUrl = "https://www.bankofcanada.ca/valet/observations/group/FX_RATES_DAILY/json?start_date=%s&end_date=%s"
 const bankOfCanadaExchangeRateReferenceUrl = "https://www.bankofcanada.ca/rates/exchange/daily-exchange-rates/"
 const bankOfCanadaDataSource = "Bank of Canada"
 const bankOfCanadaBaseCurrency = "CAD"
 
 const bankOfCanadaDataUpdateDateFormat = "2006-01-02 15:04"
+const bankOfCanadaHistoricalRequestDateFormat = "2006-01-02"
 const bankOfCanadaDataUpdateDateTimezone = "America/Toronto"
 
 // BankOfCanadaDataSource defines the structure of exchange rates data source of bank of Canada
@@ -130,6 +133,27 @@ func (e *BankOfCanadaExchangeRateData) ToLatestExchangeRateResponse(c core.Conte
 	return latestExchangeRateResp
 }
 
+// ToHistoricalExchangeRateResponses returns the view-objects of every day according to original data from bank of Canada
+func (e *BankOfCanadaExchangeRateData) ToHistoricalExchangeRateResponses(c core.Context) []*models.LatestExchangeRateResponse {
+	exchangeRateResps := make([]*models.LatestExchangeRateResponse, 0, len(e.Observations))
+
+	for i := 0; i < len(e.Observations); i++ {
+		dailyData := &BankOfCanadaExchangeRateData{
+			Observations: []BankOfCanadaObservationData{e.Observations[i]},
+		}
+
+		exchangeRateResp := dailyData.ToLatestExchangeRateResponse(c)
+
+		if exchangeRateResp == nil || len(exchangeRateResp.ExchangeRates) < 1 {
+			continue
+		}
+
+		exchangeRateResps = append(exchangeRateResps, exchangeRateResp)
+	}
+
+	return exchangeRateResps
+}
+
 // BuildRequests returns the bank of Canada exchange rates http requests
 func (e *BankOfCanadaDataSource) BuildRequests() ([]*http.Request, error) {
 	req, err := http.NewRequest("GET", bankOfCanadaExchangeRateUrl, nil)
@@ -141,14 +165,24 @@ func (e *BankOfCanadaDataSource) BuildRequests() ([]*http.Request, error) {
 	return []*http.Request{req}, nil
 }
 
+// BuildHistoricalRequests returns the bank of Canada historical exchange rates http requests
+func (e *BankOfCanadaDataSource) BuildHistoricalRequests(startDate time.Time, endDate time.Time) ([]*http.Request, error) {
+	url := fmt.Sprintf(bankOfCanadaHistoricalExchangeRateUrl, startDate.Format(bankOfCanadaHistoricalRequestDateFormat), endDate.Format(bankOfCanadaHistoricalRequestDateFormat))
+	req, err := http.NewRequest("GET", url, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return []*http.Request{req}, nil
+}
+
 // Parse returns the common response entity according to the bank of Canada data source raw response
 func (e *BankOfCanadaDataSource) Parse(c core.Context, content []byte) (*models.LatestExchangeRateResponse, error) {
-	bankOfCanadaData := &BankOfCanadaExchangeRateData{}
-	err := json.Unmarshal(content, bankOfCanadaData)
+	bankOfCanadaData, err := e.parseData(c, content)
 
 	if err != nil {
-		log.Errorf(c, "[bank_of_canada_datasource.Parse] failed to parse json data, content is %s, because %s", string(content), err.Error())
-		return nil, errs.ErrFailedToRequestRemoteApi
+		return nil, err
 	}
 
 	latestExchangeRateResponse := bankOfCanadaData.ToLatestExchangeRateResponse(c)
@@ -160,3 +194,26 @@ func (e *BankOfCanadaDataSource) Parse(c core.Context, content []byte) (*models.
 
 	return latestExchangeRateResponse, nil
 }
+
+// ParseHistorical returns the common response entities of every day according to the bank of Canada data source raw response
+func (e *BankOfCanadaDataSource) ParseHistorical(c core.Context, content []byte) ([]*models.LatestExchangeRateResponse, error) {
+	bankOfCanadaData, err := e.parseData(c, content)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return bankOfCanadaData.ToHistoricalExchangeRateResponses(c), nil
+}
+
+func (e *BankOfCanadaDataSource) parseData(c core.Context, content []byte) (*BankOfCanadaExchangeRateData, error) {
+	bankOfCanadaData := &BankOfCanadaExchangeRateData{}
+	err := json.Unmarshal(content, bankOfCanadaData)
+
+	if err != nil {
+		log.Errorf(c, "[bank_of_canada_datasource.parseData] failed to parse json data, content is %s, because %s", string(content), err.Error())
+		return nil, errs.ErrFailedToRequestRemoteApi
+	}
+
+	return bankOfCanadaData, nil
+}
@@ -195,3 +195,40 @@ func TestBankOfCanadaDataSource_InvalidRate(t *testing.T) {
 	assert.Equal(t, nil, err)
 	assert.Len(t, actualLatestExchangeRateResponse.ExchangeRates, 0)
 }
+
+func TestBankOfCanadaDataSource_ParseHistorical(t *testing.T) {
+	dataSource := &BankOfCanadaDataSource{}
+	context := core.NewNullContext()
+
+	actualExchangeRateResponses, err := dataSource.ParseHistorical(context, []byte("{"+
+		"    \"observations\": [\n"+
+		"        {\n"+
+		"            \"d\": \"2021-03-31\",\n"+
+		"            \"FXUSDCAD\": {\n"+
+		"                \"v\": \"1.2575\"\n"+
+		"            }\n"+
+		"        },\n"+
+		"        {\n"+
+		"            \"d\": \"2021-04-01\",\n"+
+		"            \"FXUSDCAD\": {\n"+
+		"                \"v\": \"1.2565\"\n"+
+		"            }\n"+
+		"        }\n"+
+		"    ]\n"+
+		"}"))
+	assert.Equal(t, nil, err)
+	assert.Len(t, actualExchangeRateResponses, 2)
+
+	assert.Equal(t, int64(1617222600), actualExchangeRateResponses[0].UpdateTime)
+	assert.Equal(t, "CAD", actualExchangeRateResponses[0].BaseCurrency)
+	assert.Contains(t, actualExchangeRateResponses[0].ExchangeRates, &models.LatestExchangeRate{
+		Currency: "USD",
+		Rate:     "0.7952286282306162",
+	})
+
+	assert.Equal(t, int64(1617309000), actualExchangeRateResponses[1].UpdateTime)
+	assert.Contains(t, actualExchangeRateResponses[1].ExchangeRates, &models.LatestExchangeRate{
+		Currency: "USD",
+		Rate:     "0.7958615200955034",
+	})
+}
@@ -0,0 +1,203 @@
+package exchangerates
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+	"github.com/mayswind/ezbookkeeping/pkg/validators"
+)
+
+const bankOfEnglandExchangeRateUrl = "https://www.bankofengland.co.uk/boeapps/database/_iadb-fromshowcolumns.asp?csv.x=yes&Datefrom=%s&Dateto=now&SeriesCodes=%s&CSVF=TN&UsingCodes=Y&VPD=Y&VFD=N"
+const bankOfEnglandExchangeRateReferenceUrl = "https://www.bankofengland.co.uk/statistics/exchange-rates"
+const bankOfEnglandDataSource = "Bank of England"
+const bankOfEnglandBaseCurrency = "GBP"
+
+const bankOfEnglandRequestDateFormat = "02/Jan/2006"
+const bankOfEnglandDataDateFormat = "02 Jan 2006"
+const bankOfEnglandDataUpdateDateFormat = "02 Jan 2006 15:04"
+const bankOfEnglandDataUpdateDateTimezone = "Europe/London"
+
+const bankOfEnglandRecentExchangeRateDays = 14
+
+var bankOfEnglandSeriesCodeCurrencyCodeMap map[string]string
+var bankOfEnglandSeriesCodes string
+
+// BankOfEnglandDataSource defines the structure of exchange rates data source of bank of England
+type BankOfEnglandDataSource struct {
+	ExchangeRatesDataSource
+}
+
+func init() {
+	bankOfEnglandSeriesCodeCurrencyCodeMap = make(map[string]string, 25)
+	bankOfEnglandSeriesCodeCurrencyCodeMap["XUDLADS"] = "AUD"
+	bankOfEnglandSeriesCodeCurrencyCodeMap["XUDLCDS"] = "CAD"
+	bankOfEnglandSeriesCodeCurrencyCodeMap["XUDLBK89"] = "CNY"
+	bankOfEnglandSeriesCodeCurrencyCodeMap["XUDLBK25"] = "CZK"
+	bankOfEnglandSeriesCodeCurrencyCodeMap["XUDLDKS"] = "DKK"
+	bankOfEnglandSeriesCodeCurrencyCodeMap["XUDLERS"] = "EUR"
+	bankOfEnglandSeriesCodeCurrencyCodeMap["XUDLHDS"] = "HKD"
+	bankOfEnglandSeriesCodeCurrencyCodeMap["XUDLBK33"] = "HUF"
+	bankOfEnglandSeriesCodeCurrencyCodeMap["XUDLBK97"] = "INR"
+	bankOfEnglandSeriesCodeCurrencyCodeMap["XUDLBK78"] = "ILS"
+	bankOfEnglandSeriesCodeCurrencyCodeMap["XUDLJYS"] = "JPY"
+	bankOfEnglandSeriesCodeCurrencyCodeMap["XUDLBK83"] = "MYR"
+	bankOfEnglandSeriesCodeCurrencyCodeMap["XUDLNDS"] = "NZD"
+	bankOfEnglandSeriesCodeCurrencyCodeMap["XUDLNKS"] = "NOK"
+	bankOfEnglandSeriesCodeCurrencyCodeMap["XUDLBK47"] = "PLN"
+	bankOfEnglandSeriesCodeCurrencyCodeMap["XUDLSRS"] = "SAR"
+	bankOfEnglandSeriesCodeCurrencyCodeMap["XUDLSGS"] = "SGD"
+	bankOfEnglandSeriesCodeCurrencyCodeMap["XUDLZRS"] = "ZAR"
+	bankOfEnglandSeriesCodeCurrencyCodeMap["XUDLBK93"] = "KRW"
+	bankOfEnglandSeriesCodeCurrencyCodeMap["XUDLSKS"] = "SEK"
+	bankOfEnglandSeriesCodeCurrencyCodeMap["XUDLSFS"] = "CHF"
+	bankOfEnglandSeriesCodeCurrencyCodeMap["XUDLTWS"] = "TWD"
+	bankOfEnglandSeriesCodeCurrencyCodeMap["XUDLBK87"] = "THB"
+	bankOfEnglandSeriesCodeCurrencyCodeMap["XUDLBK95"] = "TRY"
+	bankOfEnglandSeriesCodeCurrencyCodeMap["XUDLUSS"] = "USD"
+
+	seriesCodes := make([]string, 0, len(bankOfEnglandSeriesCodeCurrencyCodeMap))
+
+	for seriesCode := range bankOfEnglandSeriesCodeCurrencyCodeMap {
+		seriesCodes = append(seriesCodes, seriesCode)
+	}
+
+	sort.Strings(seriesCodes)
+	bankOfEnglandSeriesCodes = strings.Join(seriesCodes, ",")
+}
+
+// BuildRequests returns the bank of England exchange rates http requests
+func (e *BankOfEnglandDataSource) BuildRequests() ([]*http.Request, error) {
+	startDate := time.Now().AddDate(0, 0, -bankOfEnglandRecentExchangeRateDays)
+	url := fmt.Sprintf(bankOfEnglandExchangeRateUrl, startDate.Format(bankOfEnglandRequestDateFormat), bankOfEnglandSeriesCodes)
+	req, err := http.NewRequest("GET", url, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return []*http.Request{req}, nil
+}
+
+// Parse returns the common response entity according to the bank of England data source raw response
+func (e *BankOfEnglandDataSource) Parse(c core.Context, content []byte) (*models.LatestExchangeRateResponse, error) {
+	lines := strings.Split(strings.ReplaceAll(string(content), "\r", ""), "\n")
+
+	if len(lines) < 2 {
+		log.Errorf(c, "[bank_of_england_datasource.Parse] content is invalid, content is %s", string(content))
+		return nil, errs.ErrFailedToRequestRemoteApi
+	}
+
+	titleLineItems := strings.Split(strings.TrimSpace(lines[0]), ",")
+
+	if len(titleLineItems) < 2 || titleLineItems[0] != "DATE" {
+		log.Errorf(c, "[bank_of_england_datasource.Parse] title line is invalid, title line is %s", lines[0])
+		return nil, errs.ErrFailedToRequestRemoteApi
+	}
+
+	var latestUpdateDate time.Time
+	var latestLineItems []string
+
+	for i := 1; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+
+		if line == "" {
+			continue
+		}
+
+		items := strings.Split(line, ",")
+		updateDate, err := time.Parse(bankOfEnglandDataDateFormat, items[0])
+
+		if err != nil {
+			log.Warnf(c, "[bank_of_england_datasource.Parse] failed to parse date, line is %s", line)
+			continue
+		}
+
+		if latestLineItems == nil || updateDate.After(latestUpdateDate) {
+			latestUpdateDate = updateDate
+			latestLineItems = items
+		}
+	}
+
+	if latestLineItems == nil {
+		log.Errorf(c, "[bank_of_england_datasource.Parse] there is no valid data line, content is %s", string(content))
+		return nil, errs.ErrFailedToRequestRemoteApi
+	}
+
+	exchangeRates := make(models.LatestExchangeRateSlice, 0, len(titleLineItems)-1)
+
+	for i := 1; i < len(titleLineItems) && i < len(latestLineItems); i++ {
+		exchangeRate := e.parseExchangeRate(c, titleLineItems[i], latestLineItems[i])
+
+		if exchangeRate != nil {
+			exchangeRates = append(exchangeRates, exchangeRate)
+		}
+	}
+
+	timezone, err := time.LoadLocation(bankOfEnglandDataUpdateDateTimezone)
+
+	if err != nil {
+		log.Errorf(c, "[bank_of_england_datasource.Parse] failed to get timezone, timezone name is %s", bankOfEnglandDataUpdateDateTimezone)
+		return nil, errs.ErrFailedToRequestRemoteApi
+	}
+
+	updateDateTime := latestLineItems[0] + " 16:00" // Daily spot exchange rates are recorded at about 4 p.m. London time
+	updateTime, err := time.ParseInLocation(bankOfEnglandDataUpdateDateFormat, updateDateTime, timezone)
+
+	if err != nil {
+		log.Errorf(c, "[bank_of_england_datasource.Parse] failed to parse update date, datetime is %s", updateDateTime)
+		return nil, errs.ErrFailedToRequestRemoteApi
+	}
+
+	latestExchangeRateResp := &models.LatestExchangeRateResponse{
+		DataSource:    bankOfEnglandDataSource,
+		ReferenceUrl:  bankOfEnglandExchangeRateReferenceUrl,
+		UpdateTime:    updateTime.Unix(),
+		BaseCurrency:  bankOfEnglandBaseCurrency,
+		ExchangeRates: exchangeRates,
+	}
+
+	return latestExchangeRateResp, nil
+}
+
+func (e *BankOfEnglandDataSource) parseExchangeRate(c core.Context, seriesCode string, value string) *models.LatestExchangeRate {
+	currencyCode, exists := bankOfEnglandSeriesCodeCurrencyCodeMap[strings.TrimSpace(seriesCode)]
+
+	if !exists {
+		return nil
+	}
+
+	if _, exists := validators.AllCurrencyNames[currencyCode]; !exists {
+		return nil
+	}
+
+	value = strings.TrimSpace(value)
+
+	if value == "" {
+		return nil
+	}
+
+	rate, err := utils.StringToFloat64(value)
+
+	if err != nil {
+		log.Warnf(c, "[bank_of_england_datasource.parseExchangeRate] failed to parse rate, currency is %s, rate is %s", currencyCode, value)
+		return nil
+	}
+
+	if rate <= 0 {
+		log.Warnf(c, "[bank_of_england_datasource.parseExchangeRate] rate is invalid, currency is %s, rate is %s", currencyCode, value)
+		return nil
+	}
+
+	return &models.LatestExchangeRate{
+		Currency: currencyCode,
+		Rate:     utils.Float64ToString(rate),
+	}
+}
@@ -0,0 +1,121 @@
+package exchangerates
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+)
+
+const bankOfEnglandMinimumRequiredContent = "DATE,XUDLCDS,XUDLERS,XUDLJYS,XUDLUSS\n" +
+	"01 Apr 2021,1.7320,1.1747,152.4500,1.3792\n" +
+	"31 Mar 2021,1.7287,1.1743,152.4100,1.3772\n"
+
+func TestBankOfEnglandDataSource_StandardDataExtractBaseCurrency(t *testing.T) {
+	dataSource := &BankOfEnglandDataSource{}
+	context := core.NewNullContext()
+
+	actualLatestExchangeRateResponse, err := dataSource.Parse(context, []byte(bankOfEnglandMinimumRequiredContent))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "GBP", actualLatestExchangeRateResponse.BaseCurrency)
+}
+
+func TestBankOfEnglandDataSource_StandardDataExtractUpdateTime(t *testing.T) {
+	dataSource := &BankOfEnglandDataSource{}
+	context := core.NewNullContext()
+
+	actualLatestExchangeRateResponse, err := dataSource.Parse(context, []byte(bankOfEnglandMinimumRequiredContent))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, int64(1617289200), actualLatestExchangeRateResponse.UpdateTime)
+}
+
+func TestBankOfEnglandDataSource_StandardDataExtractExchangeRates(t *testing.T) {
+	dataSource := &BankOfEnglandDataSource{}
+	context := core.NewNullContext()
+
+	actualLatestExchangeRateResponse, err := dataSource.Parse(context, []byte(bankOfEnglandMinimumRequiredContent))
+	assert.Equal(t, nil, err)
+	assert.Len(t, actualLatestExchangeRateResponse.ExchangeRates, 4)
+	assert.Contains(t, actualLatestExchangeRateResponse.ExchangeRates, &models.LatestExchangeRate{
+		Currency: "USD",
+		Rate:     "1.3792",
+	})
+	assert.Contains(t, actualLatestExchangeRateResponse.ExchangeRates, &models.LatestExchangeRate{
+		Currency: "EUR",
+		Rate:     "1.1747",
+	})
+	assert.Contains(t, actualLatestExchangeRateResponse.ExchangeRates, &models.LatestExchangeRate{
+		Currency: "JPY",
+		Rate:     "152.45",
+	})
+}
+
+func TestBankOfEnglandDataSource_BlankContent(t *testing.T) {
+	dataSource := &BankOfEnglandDataSource{}
+	context := core.NewNullContext()
+
+	_, err := dataSource.Parse(context, []byte(""))
+	assert.NotEqual(t, nil, err)
+}
+
+func TestBankOfEnglandDataSource_OnlyTitleLine(t *testing.T) {
+	dataSource := &BankOfEnglandDataSource{}
+	context := core.NewNullContext()
+
+	_, err := dataSource.Parse(context, []byte("DATE,XUDLERS,XUDLUSS\n"))
+	assert.NotEqual(t, nil, err)
+}
+
+func TestBankOfEnglandDataSource_MissingTitleLine(t *testing.T) {
+	dataSource := &BankOfEnglandDataSource{}
+	context := core.NewNullContext()
+
+	_, err := dataSource.Parse(context, []byte("01 Apr 2021,1.1747,1.3792\n"+
+		"31 Mar 2021,1.1743,1.3772\n"))
+	assert.NotEqual(t, nil, err)
+}
+
+func TestBankOfEnglandDataSource_InvalidDate(t *testing.T) {
+	dataSource := &BankOfEnglandDataSource{}
+	context := core.NewNullContext()
+
+	_, err := dataSource.Parse(context, []byte("DATE,XUDLERS,XUDLUSS\n"+
+		"2021-04-01,1.1747,1.3792\n"))
+	assert.NotEqual(t, nil, err)
+}
+
+func TestBankOfEnglandDataSource_InvalidSeriesCode(t *testing.T) {
+	dataSource := &BankOfEnglandDataSource{}
+	context := core.NewNullContext()
+
+	actualLatestExchangeRateResponse, err := dataSource.Parse(context, []byte("DATE,XUDLXXX\n"+
+		"01 Apr 2021,1.1747\n"))
+	assert.Equal(t, nil, err)
+	assert.Len(t, actualLatestExchangeRateResponse.ExchangeRates, 0)
+}
+
+func TestBankOfEnglandDataSource_EmptyRate(t *testing.T) {
+	dataSource := &BankOfEnglandDataSource{}
+	context := core.NewNullContext()
+
+	actualLatestExchangeRateResponse, err := dataSource.Parse(context, []byte("DATE,XUDLERS,XUDLUSS\n"+
+		"01 Apr 2021,,1.3792\n"))
+	assert.Equal(t, nil, err)
+	assert.Len(t, actualLatestExchangeRateResponse.ExchangeRates, 1)
+	assert.Contains(t, actualLatestExchangeRateResponse.ExchangeRates, &models.LatestExchangeRate{
+		Currency: "USD",
+		Rate:     "1.3792",
+	})
+}
+
+func TestBankOfEnglandDataSource_InvalidRate(t *testing.T) {
+	dataSource := &BankOfEnglandDataSource{}
+	context := core.NewNullContext()
+
+	actualLatestExchangeRateResponse, err := dataSource.Parse(context, []byte("DATE,XUDLERS,XUDLUSS\n"+
+		"01 Apr 2021,null,0\n"))
+	assert.Equal(t, nil, err)
+	assert.Len(t, actualLatestExchangeRateResponse.ExchangeRates, 0)
+}
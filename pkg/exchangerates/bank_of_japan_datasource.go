@@ -0,0 +1,177 @@
+package exchangerates
+
+import (
+	"math"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+	"github.com/mayswind/ezbookkeeping/pkg/validators"
+)
+
+const bankOfJapanExchangeRateUrl = "https://www.boj.or.jp/en/about/services/tame/tame_rate/kijun/index.htm"
+const bankOfJapanExchangeRateReferenceUrl = "https://www.boj.or.jp/en/about/services/tame/tame_rate/kijun/index.htm"
+const bankOfJapanDataSource = "Bank of Japan"
+const bankOfJapanBaseCurrency = "JPY"
+
+const bankOfJapanDataUpdateMonthFormat = "January 2006"
+const bankOfJapanDataUpdateDateTimezone = "Asia/Tokyo"
+
+var bankOfJapanDataUpdateMonthPattern = regexp.MustCompile(`(January|February|March|April|May|June|July|August|September|October|November|December) [0-9]{4}`)
+
+// BankOfJapanDataSource defines the structure of exchange rates data source of bank of Japan,
+// the basic and arbitrated exchange rates are designated by the Minister of Finance and published monthly by bank of Japan
+type BankOfJapanDataSource struct {
+	ExchangeRatesDataSource
+}
+
+// BuildRequests returns the bank of Japan exchange rates http requests
+func (e *BankOfJapanDataSource) BuildRequests() ([]*http.Request, error) {
+	req, err := http.NewRequest("GET", bankOfJapanExchangeRateUrl, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return []*http.Request{req}, nil
+}
+
+// Parse returns the common response entity according to the bank of Japan data source raw response
+func (e *BankOfJapanDataSource) Parse(c core.Context, content []byte) (*models.LatestExchangeRateResponse, error) {
+	tables, err := utils.ParseHtmlTables(content)
+
+	if err != nil {
+		log.Errorf(c, "[bank_of_japan_datasource.Parse] failed to parse html data, content is %s, because %s", string(content), err.Error())
+		return nil, errs.ErrFailedToRequestRemoteApi
+	}
+
+	for i := 0; i < len(tables); i++ {
+		table := tables[i]
+
+		if len(table.Rows) < 2 {
+			continue
+		}
+
+		titleItemMap := make(map[string]int)
+
+		for j := 0; j < len(table.Rows[0]); j++ {
+			titleItemMap[table.Rows[0][j]] = j
+		}
+
+		currencyCodeColumnIndex, exists := titleItemMap["Code"]
+
+		if !exists {
+			continue
+		}
+
+		unitColumnIndex, exists := titleItemMap["Unit"]
+
+		if !exists {
+			continue
+		}
+
+		rateColumnIndex, exists := titleItemMap["Rate"]
+
+		if !exists {
+			continue
+		}
+
+		updateMonth := bankOfJapanDataUpdateMonthPattern.FindString(table.Caption)
+
+		if updateMonth == "" {
+			log.Errorf(c, "[bank_of_japan_datasource.Parse] missing applicable month in table caption, caption is %s", table.Caption)
+			return nil, errs.ErrFailedToRequestRemoteApi
+		}
+
+		exchangeRates := make(models.LatestExchangeRateSlice, 0, len(table.Rows)-1)
+
+		for j := 1; j < len(table.Rows); j++ {
+			exchangeRate := e.parseExchangeRate(c, table.Rows[j], currencyCodeColumnIndex, unitColumnIndex, rateColumnIndex)
+
+			if exchangeRate != nil {
+				exchangeRates = append(exchangeRates, exchangeRate)
+			}
+		}
+
+		timezone, err := time.LoadLocation(bankOfJapanDataUpdateDateTimezone)
+
+		if err != nil {
+			log.Errorf(c, "[bank_of_japan_datasource.Parse] failed to get timezone, timezone name is %s", bankOfJapanDataUpdateDateTimezone)
+			return nil, errs.ErrFailedToRequestRemoteApi
+		}
+
+		updateTime, err := time.ParseInLocation(bankOfJapanDataUpdateMonthFormat, updateMonth, timezone) // the rates are applicable from the first day of the month
+
+		if err != nil {
+			log.Errorf(c, "[bank_of_japan_datasource.Parse] failed to parse update month, month is %s", updateMonth)
+			return nil, errs.ErrFailedToRequestRemoteApi
+		}
+
+		latestExchangeRateResp := &models.LatestExchangeRateResponse{
+			DataSource:    bankOfJapanDataSource,
+			ReferenceUrl:  bankOfJapanExchangeRateReferenceUrl,
+			UpdateTime:    updateTime.Unix(),
+			BaseCurrency:  bankOfJapanBaseCurrency,
+			ExchangeRates: exchangeRates,
+		}
+
+		return latestExchangeRateResp, nil
+	}
+
+	log.Errorf(c, "[bank_of_japan_datasource.Parse] there is no exchange rates table, content is %s", string(content))
+	return nil, errs.ErrFailedToRequestRemoteApi
+}
+
+func (e *BankOfJapanDataSource) parseExchangeRate(c core.Context, items []string, currencyCodeColumnIndex int, unitColumnIndex int, rateColumnIndex int) *models.LatestExchangeRate {
+	if currencyCodeColumnIndex >= len(items) || unitColumnIndex >= len(items) || rateColumnIndex >= len(items) {
+		log.Warnf(c, "[bank_of_japan_datasource.parseExchangeRate] missing column in data row, row is %s", strings.Join(items, ","))
+		return nil
+	}
+
+	currencyCode := items[currencyCodeColumnIndex]
+
+	if _, exists := validators.AllCurrencyNames[currencyCode]; !exists {
+		return nil
+	}
+
+	unit, err := utils.StringToInt64(items[unitColumnIndex])
+
+	if err != nil {
+		log.Warnf(c, "[bank_of_japan_datasource.parseExchangeRate] failed to parse unit, currency is %s, unit is %s", currencyCode, items[unitColumnIndex])
+		return nil
+	}
+
+	if unit <= 0 {
+		log.Warnf(c, "[bank_of_japan_datasource.parseExchangeRate] unit is invalid, currency is %s, unit is %s", currencyCode, items[unitColumnIndex])
+		return nil
+	}
+
+	rate, err := utils.StringToFloat64(strings.ReplaceAll(items[rateColumnIndex], ",", ""))
+
+	if err != nil {
+		log.Warnf(c, "[bank_of_japan_datasource.parseExchangeRate] failed to parse rate, currency is %s, rate is %s", currencyCode, items[rateColumnIndex])
+		return nil
+	}
+
+	if rate <= 0 {
+		log.Warnf(c, "[bank_of_japan_datasource.parseExchangeRate] rate is invalid, currency is %s, rate is %s", currencyCode, items[rateColumnIndex])
+		return nil
+	}
+
+	finalRate := float64(unit) / rate
+
+	if math.IsInf(finalRate, 0) {
+		return nil
+	}
+
+	return &models.LatestExchangeRate{
+		Currency: currencyCode,
+		Rate:     utils.Float64ToString(finalRate),
+	}
+}
@@ -0,0 +1,160 @@
+package exchangerates
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+)
+
+const bankOfJapanMinimumRequiredContent = "<!DOCTYPE html>\n" +
+	"<html lang=\"en\">\n" +
+	"<body>\n" +
+	"  <table>\n" +
+	"    <caption>Basic Exchange Rate and Arbitrated Exchange Rates (April 2021)</caption>\n" +
+	"    <thead>\n" +
+	"      <tr><th>Currency</th><th>Code</th><th>Unit</th><th>Rate</th></tr>\n" +
+	"    </thead>\n" +
+	"    <tbody>\n" +
+	"      <tr><td>U.S. Dollar</td><td>USD</td><td>1</td><td>110.00</td></tr>\n" +
+	"      <tr><td>Euro</td><td>EUR</td><td>1</td><td>129.13</td></tr>\n" +
+	"      <tr><td>Korean Won</td><td>KRW</td><td>100</td><td>9.75</td></tr>\n" +
+	"    </tbody>\n" +
+	"  </table>\n" +
+	"</body>\n" +
+	"</html>"
+
+func TestBankOfJapanDataSource_StandardDataExtractBaseCurrency(t *testing.T) {
+	dataSource := &BankOfJapanDataSource{}
+	context := core.NewNullContext()
+
+	actualLatestExchangeRateResponse, err := dataSource.Parse(context, []byte(bankOfJapanMinimumRequiredContent))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "JPY", actualLatestExchangeRateResponse.BaseCurrency)
+}
+
+func TestBankOfJapanDataSource_StandardDataExtractUpdateTime(t *testing.T) {
+	dataSource := &BankOfJapanDataSource{}
+	context := core.NewNullContext()
+
+	actualLatestExchangeRateResponse, err := dataSource.Parse(context, []byte(bankOfJapanMinimumRequiredContent))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, int64(1617202800), actualLatestExchangeRateResponse.UpdateTime)
+}
+
+func TestBankOfJapanDataSource_StandardDataExtractExchangeRates(t *testing.T) {
+	dataSource := &BankOfJapanDataSource{}
+	context := core.NewNullContext()
+
+	actualLatestExchangeRateResponse, err := dataSource.Parse(context, []byte(bankOfJapanMinimumRequiredContent))
+	assert.Equal(t, nil, err)
+	assert.Len(t, actualLatestExchangeRateResponse.ExchangeRates, 3)
+	assert.Contains(t, actualLatestExchangeRateResponse.ExchangeRates, &models.LatestExchangeRate{
+		Currency: "USD",
+		Rate:     "0.00909090909090909",
+	})
+	assert.Contains(t, actualLatestExchangeRateResponse.ExchangeRates, &models.LatestExchangeRate{
+		Currency: "EUR",
+		Rate:     "0.007744133818632386",
+	})
+	assert.Contains(t, actualLatestExchangeRateResponse.ExchangeRates, &models.LatestExchangeRate{
+		Currency: "KRW",
+		Rate:     "10.256410256410257",
+	})
+}
+
+func TestBankOfJapanDataSource_BlankContent(t *testing.T) {
+	dataSource := &BankOfJapanDataSource{}
+	context := core.NewNullContext()
+
+	_, err := dataSource.Parse(context, []byte(""))
+	assert.NotEqual(t, nil, err)
+}
+
+func TestBankOfJapanDataSource_NoTable(t *testing.T) {
+	dataSource := &BankOfJapanDataSource{}
+	context := core.NewNullContext()
+
+	_, err := dataSource.Parse(context, []byte("<html><body><p>Basic Exchange Rate and Arbitrated Exchange Rates (April 2021)</p></body></html>"))
+	assert.NotEqual(t, nil, err)
+}
+
+func TestBankOfJapanDataSource_MissingTitleColumn(t *testing.T) {
+	dataSource := &BankOfJapanDataSource{}
+	context := core.NewNullContext()
+
+	_, err := dataSource.Parse(context, []byte("<table>"+
+		"<caption>Basic Exchange Rate and Arbitrated Exchange Rates (April 2021)</caption>"+
+		"<tr><th>Currency</th><th>Code</th><th>Rate</th></tr>"+
+		"<tr><td>U.S. Dollar</td><td>USD</td><td>110.00</td></tr>"+
+		"</table>"))
+	assert.NotEqual(t, nil, err)
+}
+
+func TestBankOfJapanDataSource_MissingApplicableMonth(t *testing.T) {
+	dataSource := &BankOfJapanDataSource{}
+	context := core.NewNullContext()
+
+	_, err := dataSource.Parse(context, []byte("<table>"+
+		"<caption>Basic Exchange Rate and Arbitrated Exchange Rates</caption>"+
+		"<tr><th>Currency</th><th>Code</th><th>Unit</th><th>Rate</th></tr>"+
+		"<tr><td>U.S. Dollar</td><td>USD</td><td>1</td><td>110.00</td></tr>"+
+		"</table>"))
+	assert.NotEqual(t, nil, err)
+}
+
+func TestBankOfJapanDataSource_InvalidCurrency(t *testing.T) {
+	dataSource := &BankOfJapanDataSource{}
+	context := core.NewNullContext()
+
+	actualLatestExchangeRateResponse, err := dataSource.Parse(context, []byte("<table>"+
+		"<caption>Basic Exchange Rate and Arbitrated Exchange Rates (April 2021)</caption>"+
+		"<tr><th>Currency</th><th>Code</th><th>Unit</th><th>Rate</th></tr>"+
+		"<tr><td>Unknown</td><td>XXX</td><td>1</td><td>1.00</td></tr>"+
+		"</table>"))
+	assert.Equal(t, nil, err)
+	assert.Len(t, actualLatestExchangeRateResponse.ExchangeRates, 0)
+}
+
+func TestBankOfJapanDataSource_MissingColumnInDataRow(t *testing.T) {
+	dataSource := &BankOfJapanDataSource{}
+	context := core.NewNullContext()
+
+	actualLatestExchangeRateResponse, err := dataSource.Parse(context, []byte("<table>"+
+		"<caption>Basic Exchange Rate and Arbitrated Exchange Rates (April 2021)</caption>"+
+		"<tr><th>Currency</th><th>Code</th><th>Unit</th><th>Rate</th></tr>"+
+		"<tr><td>U.S. Dollar</td><td>USD</td><td>1</td></tr>"+
+		"</table>"))
+	assert.Equal(t, nil, err)
+	assert.Len(t, actualLatestExchangeRateResponse.ExchangeRates, 0)
+}
+
+func TestBankOfJapanDataSource_InvalidUnit(t *testing.T) {
+	dataSource := &BankOfJapanDataSource{}
+	context := core.NewNullContext()
+
+	actualLatestExchangeRateResponse, err := dataSource.Parse(context, []byte("<table>"+
+		"<caption>Basic Exchange Rate and Arbitrated Exchange Rates (April 2021)</caption>"+
+		"<tr><th>Currency</th><th>Code</th><th>Unit</th><th>Rate</th></tr>"+
+		"<tr><td>U.S. Dollar</td><td>USD</td><td>null</td><td>110.00</td></tr>"+
+		"<tr><td>Euro</td><td>EUR</td><td>0</td><td>129.13</td></tr>"+
+		"</table>"))
+	assert.Equal(t, nil, err)
+	assert.Len(t, actualLatestExchangeRateResponse.ExchangeRates, 0)
+}
+
+func TestBankOfJapanDataSource_InvalidRate(t *testing.T) {
+	dataSource := &BankOfJapanDataSource{}
+	context := core.NewNullContext()
+
+	actualLatestExchangeRateResponse, err := dataSource.Parse(context, []byte("<table>"+
+		"<caption>Basic Exchange Rate and Arbitrated Exchange Rates (April 2021)</caption>"+
+		"<tr><th>Currency</th><th>Code</th><th>Unit</th><th>Rate</th></tr>"+
+		"<tr><td>U.S. Dollar</td><td>USD</td><td>1</td><td>null</td></tr>"+
+		"<tr><td>Euro</td><td>EUR</td><td>1</td><td>0</td></tr>"+
+		"</table>"))
+	assert.Equal(t, nil, err)
+	assert.Len(t, actualLatestExchangeRateResponse.ExchangeRates, 0)
+}
@@ -0,0 +1,199 @@
+package exchangerates
+
+import (
+	"bytes"
+	"encoding/xml"
+	"math"
+	"net/http"
+	"time"
+
+	"golang.org/x/net/html/charset"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+	"github.com/mayswind/ezbookkeeping/pkg/validators"
+)
+
+const bankOfThailandExchangeRateUrl = "https://www.bot.or.th/App/RSS/fxrate-all.xml"
+const bankOfThailandExchangeRateReferenceUrl = "https://www.bot.or.th/en/statistics/exchange-rate.html"
+const bankOfThailandDataSource = "Bank of Thailand"
+const bankOfThailandBaseCurrency = "THB"
+
+const bankOfThailandDataUpdateDateFormat = "2006-01-02T15:04:05Z07:00"
+
+// BankOfThailandDataSource defines the structure of exchange rates data source of bank of Thailand
+type BankOfThailandDataSource struct {
+	ExchangeRatesDataSource
+}
+
+// BankOfThailandData represents the whole data from bank of Thailand
+type BankOfThailandData struct {
+	XMLName xml.Name                  `xml:"RDF"`
+	Channel *BankOfThailandRssChannel `xml:"channel"`
+	Items   []*BankOfThailandRssItem  `xml:"item"`
+}
+
+// BankOfThailandRssChannel represents the rss channel from bank of Thailand
+type BankOfThailandRssChannel struct {
+	Date string `xml:"date"`
+}
+
+// BankOfThailandRssItem represents the rss item from bank of Thailand
+type BankOfThailandRssItem struct {
+	Statistics *BankOfThailandItemStatistics `xml:"statistics"`
+}
+
+// BankOfThailandItemStatistics represents the item statistics from bank of Thailand
+type BankOfThailandItemStatistics struct {
+	ExchangeRate *BankOfThailandExchangeRate `xml:"exchangeRate"`
+}
+
+// BankOfThailandExchangeRate represents the exchange rate from bank of Thailand
+type BankOfThailandExchangeRate struct {
+	BaseCurrency   string                                 `xml:"baseCurrency"`
+	TargetCurrency string                                 `xml:"targetCurrency"`
+	Observation    *BankOfThailandExchangeRateObservation `xml:"observation"`
+}
+
+// BankOfThailandExchangeRateObservation represents the exchange rate data from bank of Thailand
+type BankOfThailandExchangeRateObservation struct {
+	Value        string `xml:"value"`
+	Unit         string `xml:"unit"`
+	UnitExponent string `xml:"unit_mult"`
+}
+
+// ToLatestExchangeRateResponse returns a view-object according to original data from bank of Thailand
+func (e *BankOfThailandData) ToLatestExchangeRateResponse(c core.Context) *models.LatestExchangeRateResponse {
+	if e.Channel == nil {
+		log.Errorf(c, "[bank_of_thailand_datasource.ToLatestExchangeRateResponse] rss channel does not exist")
+		return nil
+	}
+
+	if len(e.Items) < 1 {
+		log.Errorf(c, "[bank_of_thailand_datasource.ToLatestExchangeRateResponse] rss items is empty")
+		return nil
+	}
+
+	exchangeRates := make(models.LatestExchangeRateSlice, 0, len(e.Items))
+
+	for i := 0; i < len(e.Items); i++ {
+		item := e.Items[i]
+
+		if item.Statistics == nil || item.Statistics.ExchangeRate == nil || item.Statistics.ExchangeRate.Observation == nil {
+			continue
+		}
+
+		if item.Statistics.ExchangeRate.BaseCurrency != bankOfThailandBaseCurrency || item.Statistics.ExchangeRate.Observation.Unit != bankOfThailandBaseCurrency {
+			continue
+		}
+
+		if _, exists := validators.AllCurrencyNames[item.Statistics.ExchangeRate.TargetCurrency]; !exists {
+			continue
+		}
+
+		finalExchangeRate := item.Statistics.ExchangeRate.ToLatestExchangeRate(c)
+
+		if finalExchangeRate == nil {
+			continue
+		}
+
+		exchangeRates = append(exchangeRates, finalExchangeRate)
+	}
+
+	updateDateTime := e.Channel.Date
+	updateTime, err := time.Parse(bankOfThailandDataUpdateDateFormat, updateDateTime)
+
+	if err != nil {
+		log.Errorf(c, "[bank_of_thailand_datasource.ToLatestExchangeRateResponse] failed to parse update date, datetime is %s", updateDateTime)
+		return nil
+	}
+
+	latestExchangeRateResp := &models.LatestExchangeRateResponse{
+		DataSource:    bankOfThailandDataSource,
+		ReferenceUrl:  bankOfThailandExchangeRateReferenceUrl,
+		UpdateTime:    updateTime.Unix(),
+		BaseCurrency:  bankOfThailandBaseCurrency,
+		ExchangeRates: exchangeRates,
+	}
+
+	return latestExchangeRateResp
+}
+
+// ToLatestExchangeRate returns a data pair according to original data from bank of Thailand
+func (e *BankOfThailandExchangeRate) ToLatestExchangeRate(c core.Context) *models.LatestExchangeRate {
+	rate, err := utils.StringToFloat64(e.Observation.Value)
+
+	if err != nil {
+		log.Warnf(c, "[bank_of_thailand_datasource.ToLatestExchangeRate] failed to parse rate, currency is %s, rate is %s", e.TargetCurrency, e.Observation.Value)
+		return nil
+	}
+
+	if rate <= 0 {
+		log.Warnf(c, "[bank_of_thailand_datasource.ToLatestExchangeRate] rate is invalid, currency is %s, rate is %s", e.TargetCurrency, e.Observation.Value)
+		return nil
+	}
+
+	unitExponent := 0
+
+	if e.Observation.UnitExponent != "" {
+		unitExponent, err = utils.StringToInt(e.Observation.UnitExponent)
+
+		if err != nil {
+			log.Warnf(c, "[bank_of_thailand_datasource.ToLatestExchangeRate] failed to parse unit, currency is %s, unit exponent is %s", e.TargetCurrency, e.Observation.UnitExponent)
+			return nil
+		}
+	}
+
+	if unitExponent < 0 {
+		log.Warnf(c, "[bank_of_thailand_datasource.ToLatestExchangeRate] unit exponent is less than zero, currency is %s, unit exponent is %d", e.TargetCurrency, unitExponent)
+		return nil
+	}
+
+	finalRate := math.Pow10(unitExponent) / rate // the value is the amount of baht for 10^unit_mult units of target currency (e.g. 100 JPY)
+
+	if math.IsInf(finalRate, 0) {
+		return nil
+	}
+
+	return &models.LatestExchangeRate{
+		Currency: e.TargetCurrency,
+		Rate:     utils.Float64ToString(finalRate),
+	}
+}
+
+// BuildRequests returns the bank of Thailand exchange rates http requests
+func (e *BankOfThailandDataSource) BuildRequests() ([]*http.Request, error) {
+	req, err := http.NewRequest("GET", bankOfThailandExchangeRateUrl, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return []*http.Request{req}, nil
+}
+
+// Parse returns the common response entity according to the bank of Thailand data source raw response
+func (e *BankOfThailandDataSource) Parse(c core.Context, content []byte) (*models.LatestExchangeRateResponse, error) {
+	xmlDecoder := xml.NewDecoder(bytes.NewReader(content))
+	xmlDecoder.CharsetReader = charset.NewReaderLabel
+
+	bankOfThailandData := &BankOfThailandData{}
+	err := xmlDecoder.Decode(bankOfThailandData)
+
+	if err != nil {
+		log.Errorf(c, "[bank_of_thailand_datasource.Parse] failed to parse xml data, content is %s, because %s", string(content), err.Error())
+		return nil, errs.ErrFailedToRequestRemoteApi
+	}
+
+	latestExchangeRateResponse := bankOfThailandData.ToLatestExchangeRateResponse(c)
+
+	if latestExchangeRateResponse == nil {
+		log.Errorf(c, "[bank_of_thailand_datasource.Parse] failed to parse latest exchange rate data, content is %s", string(content))
+		return nil, errs.ErrFailedToRequestRemoteApi
+	}
+
+	return latestExchangeRateResponse, nil
+}
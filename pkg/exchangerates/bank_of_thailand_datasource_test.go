@@ -0,0 +1,290 @@
+package exchangerates
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+)
+
+const bankOfThailandMinimumRequiredContent = "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n" +
+	"<rdf:RDF xmlns:rdf=\"http://www.w3.org/1999/02/22-rdf-syntax-ns#\" xmlns:cb=\"http://www.cbwiki.net/wiki/index.php/Specification_1.2/\" xmlns:dc=\"http://purl.org/dc/elements/1.1/\" xmlns=\"http://purl.org/rss/1.0/\">\n" +
+	"  <channel rdf:about=\"https://www.bot.or.th/App/RSS/fxrate-all.xml\">\n" +
+	"    <dc:date>2021-04-01T18:00:00+07:00</dc:date>\n" +
+	"  </channel>\n" +
+	"  <item rdf:about=\"https://www.bot.or.th/App/RSS/fxrate-all.xml#USD\">\n" +
+	"    <cb:statistics rdf:parseType=\"Resource\">\n" +
+	"      <cb:exchangeRate rdf:parseType=\"Resource\">\n" +
+	"        <cb:observation rdf:parseType=\"Resource\">\n" +
+	"          <cb:value>31.2736</cb:value>\n" +
+	"          <cb:unit>THB</cb:unit>\n" +
+	"        </cb:observation>\n" +
+	"        <cb:baseCurrency>THB</cb:baseCurrency>\n" +
+	"        <cb:targetCurrency>USD</cb:targetCurrency>\n" +
+	"      </cb:exchangeRate>\n" +
+	"    </cb:statistics>\n" +
+	"  </item>\n" +
+	"  <item rdf:about=\"https://www.bot.or.th/App/RSS/fxrate-all.xml#JPY\">\n" +
+	"    <cb:statistics rdf:parseType=\"Resource\">\n" +
+	"      <cb:exchangeRate rdf:parseType=\"Resource\">\n" +
+	"        <cb:observation rdf:parseType=\"Resource\">\n" +
+	"          <cb:value>28.3015</cb:value>\n" +
+	"          <cb:unit>THB</cb:unit>\n" +
+	"          <cb:unit_mult>2</cb:unit_mult>\n" +
+	"        </cb:observation>\n" +
+	"        <cb:baseCurrency>THB</cb:baseCurrency>\n" +
+	"        <cb:targetCurrency>JPY</cb:targetCurrency>\n" +
+	"      </cb:exchangeRate>\n" +
+	"    </cb:statistics>\n" +
+	"  </item>\n" +
+	"</rdf:RDF>"
+
+func TestBankOfThailandDataSource_StandardDataExtractBaseCurrency(t *testing.T) {
+	dataSource := &BankOfThailandDataSource{}
+	context := core.NewNullContext()
+
+	actualLatestExchangeRateResponse, err := dataSource.Parse(context, []byte(bankOfThailandMinimumRequiredContent))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "THB", actualLatestExchangeRateResponse.BaseCurrency)
+}
+
+func TestBankOfThailandDataSource_StandardDataExtractUpdateTime(t *testing.T) {
+	dataSource := &BankOfThailandDataSource{}
+	context := core.NewNullContext()
+
+	actualLatestExchangeRateResponse, err := dataSource.Parse(context, []byte(bankOfThailandMinimumRequiredContent))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, int64(1617274800), actualLatestExchangeRateResponse.UpdateTime)
+}
+
+func TestBankOfThailandDataSource_StandardDataExtractExchangeRates(t *testing.T) {
+	dataSource := &BankOfThailandDataSource{}
+	context := core.NewNullContext()
+
+	actualLatestExchangeRateResponse, err := dataSource.Parse(context, []byte(bankOfThailandMinimumRequiredContent))
+	assert.Equal(t, nil, err)
+	assert.Contains(t, actualLatestExchangeRateResponse.ExchangeRates, &models.LatestExchangeRate{
+		Currency: "USD",
+		Rate:     "0.03197585183669293",
+	})
+	assert.Contains(t, actualLatestExchangeRateResponse.ExchangeRates, &models.LatestExchangeRate{
+		Currency: "JPY",
+		Rate:     "3.5333816228821795",
+	})
+}
+
+func TestBankOfThailandDataSource_BlankContent(t *testing.T) {
+	dataSource := &BankOfThailandDataSource{}
+	context := core.NewNullContext()
+
+	_, err := dataSource.Parse(context, []byte(""))
+	assert.NotEqual(t, nil, err)
+}
+
+func TestBankOfThailandDataSource_OnlyXMLHeader(t *testing.T) {
+	dataSource := &BankOfThailandDataSource{}
+	context := core.NewNullContext()
+
+	_, err := dataSource.Parse(context, []byte("<?xml version=\"1.0\" encoding=\"UTF-8\"?>"))
+	assert.NotEqual(t, nil, err)
+}
+
+func TestBankOfThailandDataSource_EmptyChannelContent(t *testing.T) {
+	dataSource := &BankOfThailandDataSource{}
+	context := core.NewNullContext()
+
+	_, err := dataSource.Parse(context, []byte(""+
+		"<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n"+
+		"<rdf:RDF xmlns:rdf=\"http://www.w3.org/1999/02/22-rdf-syntax-ns#\" xmlns:cb=\"http://www.cbwiki.net/wiki/index.php/Specification_1.2/\" xmlns:dc=\"http://purl.org/dc/elements/1.1/\" xmlns=\"http://purl.org/rss/1.0/\">\n"+
+		"  <item rdf:about=\"https://www.bot.or.th/App/RSS/fxrate-all.xml#USD\">\n"+
+		"    <cb:statistics rdf:parseType=\"Resource\">\n"+
+		"      <cb:exchangeRate rdf:parseType=\"Resource\">\n"+
+		"        <cb:observation rdf:parseType=\"Resource\">\n"+
+		"          <cb:value>31.2736</cb:value>\n"+
+		"          <cb:unit>THB</cb:unit>\n"+
+		"        </cb:observation>\n"+
+		"        <cb:baseCurrency>THB</cb:baseCurrency>\n"+
+		"        <cb:targetCurrency>USD</cb:targetCurrency>\n"+
+		"      </cb:exchangeRate>\n"+
+		"    </cb:statistics>\n"+
+		"  </item>\n"+
+		"</rdf:RDF>"))
+	assert.NotEqual(t, nil, err)
+}
+
+func TestBankOfThailandDataSource_NoItem(t *testing.T) {
+	dataSource := &BankOfThailandDataSource{}
+	context := core.NewNullContext()
+
+	_, err := dataSource.Parse(context, []byte(""+
+		"<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n"+
+		"<rdf:RDF xmlns:rdf=\"http://www.w3.org/1999/02/22-rdf-syntax-ns#\" xmlns:cb=\"http://www.cbwiki.net/wiki/index.php/Specification_1.2/\" xmlns:dc=\"http://purl.org/dc/elements/1.1/\" xmlns=\"http://purl.org/rss/1.0/\">\n"+
+		"  <channel rdf:about=\"https://www.bot.or.th/App/RSS/fxrate-all.xml\">\n"+
+		"    <dc:date>2021-04-01T18:00:00+07:00</dc:date>\n"+
+		"  </channel>\n"+
+		"</rdf:RDF>"))
+	assert.NotEqual(t, nil, err)
+}
+
+func TestBankOfThailandDataSource_BaseCurrencyNotEqualPreset(t *testing.T) {
+	dataSource := &BankOfThailandDataSource{}
+	context := core.NewNullContext()
+
+	actualLatestExchangeRateResponse, err := dataSource.Parse(context, []byte(""+
+		"<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n"+
+		"<rdf:RDF xmlns:rdf=\"http://www.w3.org/1999/02/22-rdf-syntax-ns#\" xmlns:cb=\"http://www.cbwiki.net/wiki/index.php/Specification_1.2/\" xmlns:dc=\"http://purl.org/dc/elements/1.1/\" xmlns=\"http://purl.org/rss/1.0/\">\n"+
+		"  <channel rdf:about=\"https://www.bot.or.th/App/RSS/fxrate-all.xml\">\n"+
+		"    <dc:date>2021-04-01T18:00:00+07:00</dc:date>\n"+
+		"  </channel>\n"+
+		"  <item rdf:about=\"https://www.bot.or.th/App/RSS/fxrate-all.xml#USD\">\n"+
+		"    <cb:statistics rdf:parseType=\"Resource\">\n"+
+		"      <cb:exchangeRate rdf:parseType=\"Resource\">\n"+
+		"        <cb:observation rdf:parseType=\"Resource\">\n"+
+		"          <cb:value>31.2736</cb:value>\n"+
+		"          <cb:unit>THB</cb:unit>\n"+
+		"        </cb:observation>\n"+
+		"        <cb:baseCurrency>USD</cb:baseCurrency>\n"+
+		"        <cb:targetCurrency>USD</cb:targetCurrency>\n"+
+		"      </cb:exchangeRate>\n"+
+		"    </cb:statistics>\n"+
+		"  </item>\n"+
+		"</rdf:RDF>"))
+	assert.Equal(t, nil, err)
+	assert.Len(t, actualLatestExchangeRateResponse.ExchangeRates, 0)
+}
+
+func TestBankOfThailandDataSource_UnitCurrencyNotEqualPreset(t *testing.T) {
+	dataSource := &BankOfThailandDataSource{}
+	context := core.NewNullContext()
+
+	actualLatestExchangeRateResponse, err := dataSource.Parse(context, []byte(""+
+		"<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n"+
+		"<rdf:RDF xmlns:rdf=\"http://www.w3.org/1999/02/22-rdf-syntax-ns#\" xmlns:cb=\"http://www.cbwiki.net/wiki/index.php/Specification_1.2/\" xmlns:dc=\"http://purl.org/dc/elements/1.1/\" xmlns=\"http://purl.org/rss/1.0/\">\n"+
+		"  <channel rdf:about=\"https://www.bot.or.th/App/RSS/fxrate-all.xml\">\n"+
+		"    <dc:date>2021-04-01T18:00:00+07:00</dc:date>\n"+
+		"  </channel>\n"+
+		"  <item rdf:about=\"https://www.bot.or.th/App/RSS/fxrate-all.xml#USD\">\n"+
+		"    <cb:statistics rdf:parseType=\"Resource\">\n"+
+		"      <cb:exchangeRate rdf:parseType=\"Resource\">\n"+
+		"        <cb:observation rdf:parseType=\"Resource\">\n"+
+		"          <cb:value>31.2736</cb:value>\n"+
+		"          <cb:unit>USD</cb:unit>\n"+
+		"        </cb:observation>\n"+
+		"        <cb:baseCurrency>THB</cb:baseCurrency>\n"+
+		"        <cb:targetCurrency>USD</cb:targetCurrency>\n"+
+		"      </cb:exchangeRate>\n"+
+		"    </cb:statistics>\n"+
+		"  </item>\n"+
+		"</rdf:RDF>"))
+	assert.Equal(t, nil, err)
+	assert.Len(t, actualLatestExchangeRateResponse.ExchangeRates, 0)
+}
+
+func TestBankOfThailandDataSource_InvalidCurrency(t *testing.T) {
+	dataSource := &BankOfThailandDataSource{}
+	context := core.NewNullContext()
+
+	actualLatestExchangeRateResponse, err := dataSource.Parse(context, []byte(""+
+		"<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n"+
+		"<rdf:RDF xmlns:rdf=\"http://www.w3.org/1999/02/22-rdf-syntax-ns#\" xmlns:cb=\"http://www.cbwiki.net/wiki/index.php/Specification_1.2/\" xmlns:dc=\"http://purl.org/dc/elements/1.1/\" xmlns=\"http://purl.org/rss/1.0/\">\n"+
+		"  <channel rdf:about=\"https://www.bot.or.th/App/RSS/fxrate-all.xml\">\n"+
+		"    <dc:date>2021-04-01T18:00:00+07:00</dc:date>\n"+
+		"  </channel>\n"+
+		"  <item rdf:about=\"https://www.bot.or.th/App/RSS/fxrate-all.xml#XXX\">\n"+
+		"    <cb:statistics rdf:parseType=\"Resource\">\n"+
+		"      <cb:exchangeRate rdf:parseType=\"Resource\">\n"+
+		"        <cb:observation rdf:parseType=\"Resource\">\n"+
+		"          <cb:value>1</cb:value>\n"+
+		"          <cb:unit>THB</cb:unit>\n"+
+		"        </cb:observation>\n"+
+		"        <cb:baseCurrency>THB</cb:baseCurrency>\n"+
+		"        <cb:targetCurrency>XXX</cb:targetCurrency>\n"+
+		"      </cb:exchangeRate>\n"+
+		"    </cb:statistics>\n"+
+		"  </item>\n"+
+		"</rdf:RDF>"))
+	assert.Equal(t, nil, err)
+	assert.Len(t, actualLatestExchangeRateResponse.ExchangeRates, 0)
+}
+
+func TestBankOfThailandDataSource_InvalidRate(t *testing.T) {
+	dataSource := &BankOfThailandDataSource{}
+	context := core.NewNullContext()
+
+	actualLatestExchangeRateResponse, err := dataSource.Parse(context, []byte(""+
+		"<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n"+
+		"<rdf:RDF xmlns:rdf=\"http://www.w3.org/1999/02/22-rdf-syntax-ns#\" xmlns:cb=\"http://www.cbwiki.net/wiki/index.php/Specification_1.2/\" xmlns:dc=\"http://purl.org/dc/elements/1.1/\" xmlns=\"http://purl.org/rss/1.0/\">\n"+
+		"  <channel rdf:about=\"https://www.bot.or.th/App/RSS/fxrate-all.xml\">\n"+
+		"    <dc:date>2021-04-01T18:00:00+07:00</dc:date>\n"+
+		"  </channel>\n"+
+		"  <item rdf:about=\"https://www.bot.or.th/App/RSS/fxrate-all.xml#USD\">\n"+
+		"    <cb:statistics rdf:parseType=\"Resource\">\n"+
+		"      <cb:exchangeRate rdf:parseType=\"Resource\">\n"+
+		"        <cb:observation rdf:parseType=\"Resource\">\n"+
+		"          <cb:value>null</cb:value>\n"+
+		"          <cb:unit>THB</cb:unit>\n"+
+		"        </cb:observation>\n"+
+		"        <cb:baseCurrency>THB</cb:baseCurrency>\n"+
+		"        <cb:targetCurrency>USD</cb:targetCurrency>\n"+
+		"      </cb:exchangeRate>\n"+
+		"    </cb:statistics>\n"+
+		"  </item>\n"+
+		"  <item rdf:about=\"https://www.bot.or.th/App/RSS/fxrate-all.xml#EUR\">\n"+
+		"    <cb:statistics rdf:parseType=\"Resource\">\n"+
+		"      <cb:exchangeRate rdf:parseType=\"Resource\">\n"+
+		"        <cb:observation rdf:parseType=\"Resource\">\n"+
+		"          <cb:value>0</cb:value>\n"+
+		"          <cb:unit>THB</cb:unit>\n"+
+		"        </cb:observation>\n"+
+		"        <cb:baseCurrency>THB</cb:baseCurrency>\n"+
+		"        <cb:targetCurrency>EUR</cb:targetCurrency>\n"+
+		"      </cb:exchangeRate>\n"+
+		"    </cb:statistics>\n"+
+		"  </item>\n"+
+		"</rdf:RDF>"))
+	assert.Equal(t, nil, err)
+	assert.Len(t, actualLatestExchangeRateResponse.ExchangeRates, 0)
+}
+
+func TestBankOfThailandDataSource_InvalidUnitExponent(t *testing.T) {
+	dataSource := &BankOfThailandDataSource{}
+	context := core.NewNullContext()
+
+	actualLatestExchangeRateResponse, err := dataSource.Parse(context, []byte(""+
+		"<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n"+
+		"<rdf:RDF xmlns:rdf=\"http://www.w3.org/1999/02/22-rdf-syntax-ns#\" xmlns:cb=\"http://www.cbwiki.net/wiki/index.php/Specification_1.2/\" xmlns:dc=\"http://purl.org/dc/elements/1.1/\" xmlns=\"http://purl.org/rss/1.0/\">\n"+
+		"  <channel rdf:about=\"https://www.bot.or.th/App/RSS/fxrate-all.xml\">\n"+
+		"    <dc:date>2021-04-01T18:00:00+07:00</dc:date>\n"+
+		"  </channel>\n"+
+		"  <item rdf:about=\"https://www.bot.or.th/App/RSS/fxrate-all.xml#USD\">\n"+
+		"    <cb:statistics rdf:parseType=\"Resource\">\n"+
+		"      <cb:exchangeRate rdf:parseType=\"Resource\">\n"+
+		"        <cb:observation rdf:parseType=\"Resource\">\n"+
+		"          <cb:value>31.2736</cb:value>\n"+
+		"          <cb:unit>THB</cb:unit>\n"+
+		"          <cb:unit_mult>null</cb:unit_mult>\n"+
+		"        </cb:observation>\n"+
+		"        <cb:baseCurrency>THB</cb:baseCurrency>\n"+
+		"        <cb:targetCurrency>USD</cb:targetCurrency>\n"+
+		"      </cb:exchangeRate>\n"+
+		"    </cb:statistics>\n"+
+		"  </item>\n"+
+		"  <item rdf:about=\"https://www.bot.or.th/App/RSS/fxrate-all.xml#JPY\">\n"+
+		"    <cb:statistics rdf:parseType=\"Resource\">\n"+
+		"      <cb:exchangeRate rdf:parseType=\"Resource\">\n"+
+		"        <cb:observation rdf:parseType=\"Resource\">\n"+
+		"          <cb:value>28.3015</cb:value>\n"+
+		"          <cb:unit>THB</cb:unit>\n"+
+		"          <cb:unit_mult>-2</cb:unit_mult>\n"+
+		"        </cb:observation>\n"+
+		"        <cb:baseCurrency>THB</cb:baseCurrency>\n"+
+		"        <cb:targetCurrency>JPY</cb:targetCurrency>\n"+
+		"      </cb:exchangeRate>\n"+
+		"    </cb:statistics>\n"+
+		"  </item>\n"+
+		"</rdf:RDF>"))
+	assert.Equal(t, nil, err)
+	assert.Len(t, actualLatestExchangeRateResponse.ExchangeRates, 0)
+}
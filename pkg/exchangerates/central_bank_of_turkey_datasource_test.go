@@ -0,0 +1,169 @@
+package exchangerates
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+)
+
+const centralBankOfTurkeyMinimumRequiredContent = "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n" +
+	"<?xml-stylesheet type=\"text/xsl\" href=\"isokur.xsl\"?>\n" +
+	"<Tarih_Date Tarih=\"01.04.2021\" Date=\"04/01/2021\" Bulten_No=\"2021/63\">\n" +
+	"  <Currency CrossOrder=\"0\" Kod=\"USD\" CurrencyCode=\"USD\">\n" +
+	"    <Unit>1</Unit>\n" +
+	"    <Isim>ABD DOLARI</Isim>\n" +
+	"    <CurrencyName>US DOLLAR</CurrencyName>\n" +
+	"    <ForexBuying>8.2992</ForexBuying>\n" +
+	"    <ForexSelling>8.3142</ForexSelling>\n" +
+	"  </Currency>\n" +
+	"  <Currency CrossOrder=\"9\" Kod=\"JPY\" CurrencyCode=\"JPY\">\n" +
+	"    <Unit>100</Unit>\n" +
+	"    <Isim>JAPON YENİ</Isim>\n" +
+	"    <CurrencyName>JAPENESE YEN</CurrencyName>\n" +
+	"    <ForexBuying>7.4948</ForexBuying>\n" +
+	"    <ForexSelling>7.5444</ForexSelling>\n" +
+	"  </Currency>\n" +
+	"</Tarih_Date>"
+
+func TestCentralBankOfTurkeyDataSource_StandardDataExtractBaseCurrency(t *testing.T) {
+	dataSource := &CentralBankOfTurkeyDataSource{}
+	context := core.NewNullContext()
+
+	actualLatestExchangeRateResponse, err := dataSource.Parse(context, []byte(centralBankOfTurkeyMinimumRequiredContent))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "TRY", actualLatestExchangeRateResponse.BaseCurrency)
+}
+
+func TestCentralBankOfTurkeyDataSource_StandardDataExtractUpdateTime(t *testing.T) {
+	dataSource := &CentralBankOfTurkeyDataSource{}
+	context := core.NewNullContext()
+
+	actualLatestExchangeRateResponse, err := dataSource.Parse(context, []byte(centralBankOfTurkeyMinimumRequiredContent))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, int64(1617280200), actualLatestExchangeRateResponse.UpdateTime)
+}
+
+func TestCentralBankOfTurkeyDataSource_StandardDataExtractExchangeRates(t *testing.T) {
+	dataSource := &CentralBankOfTurkeyDataSource{}
+	context := core.NewNullContext()
+
+	actualLatestExchangeRateResponse, err := dataSource.Parse(context, []byte(centralBankOfTurkeyMinimumRequiredContent))
+	assert.Equal(t, nil, err)
+	assert.Contains(t, actualLatestExchangeRateResponse.ExchangeRates, &models.LatestExchangeRate{
+		Currency: "USD",
+		Rate:     "0.12049354154617312",
+	})
+	assert.Contains(t, actualLatestExchangeRateResponse.ExchangeRates, &models.LatestExchangeRate{
+		Currency: "JPY",
+		Rate:     "13.34258419170625",
+	})
+}
+
+func TestCentralBankOfTurkeyDataSource_BlankContent(t *testing.T) {
+	dataSource := &CentralBankOfTurkeyDataSource{}
+	context := core.NewNullContext()
+
+	_, err := dataSource.Parse(context, []byte(""))
+	assert.NotEqual(t, nil, err)
+}
+
+func TestCentralBankOfTurkeyDataSource_OnlyXMLHeader(t *testing.T) {
+	dataSource := &CentralBankOfTurkeyDataSource{}
+	context := core.NewNullContext()
+
+	_, err := dataSource.Parse(context, []byte("<?xml version=\"1.0\" encoding=\"UTF-8\"?>"))
+	assert.NotEqual(t, nil, err)
+}
+
+func TestCentralBankOfTurkeyDataSource_EmptyExchangeRatesDataset(t *testing.T) {
+	dataSource := &CentralBankOfTurkeyDataSource{}
+	context := core.NewNullContext()
+
+	_, err := dataSource.Parse(context, []byte("<?xml version=\"1.0\" encoding=\"UTF-8\"?>"+
+		"<Tarih_Date Tarih=\"01.04.2021\" Date=\"04/01/2021\" Bulten_No=\"2021/63\">"+
+		"</Tarih_Date>"))
+	assert.NotEqual(t, nil, err)
+}
+
+func TestCentralBankOfTurkeyDataSource_InvalidCurrency(t *testing.T) {
+	dataSource := &CentralBankOfTurkeyDataSource{}
+	context := core.NewNullContext()
+
+	actualLatestExchangeRateResponse, err := dataSource.Parse(context, []byte("<?xml version=\"1.0\" encoding=\"UTF-8\"?>"+
+		"<Tarih_Date Tarih=\"01.04.2021\" Date=\"04/01/2021\" Bulten_No=\"2021/63\">"+
+		"  <Currency CrossOrder=\"0\" Kod=\"XXX\" CurrencyCode=\"XXX\">\n"+
+		"    <Unit>1</Unit>\n"+
+		"    <ForexBuying>1</ForexBuying>\n"+
+		"  </Currency>\n"+
+		"</Tarih_Date>"))
+	assert.Equal(t, nil, err)
+	assert.Len(t, actualLatestExchangeRateResponse.ExchangeRates, 0)
+}
+
+func TestCentralBankOfTurkeyDataSource_EmptyRate(t *testing.T) {
+	dataSource := &CentralBankOfTurkeyDataSource{}
+	context := core.NewNullContext()
+
+	actualLatestExchangeRateResponse, err := dataSource.Parse(context, []byte("<?xml version=\"1.0\" encoding=\"UTF-8\"?>"+
+		"<Tarih_Date Tarih=\"01.04.2021\" Date=\"04/01/2021\" Bulten_No=\"2021/63\">"+
+		"  <Currency CrossOrder=\"0\" Kod=\"USD\" CurrencyCode=\"USD\">\n"+
+		"    <Unit>1</Unit>\n"+
+		"    <ForexBuying></ForexBuying>\n"+
+		"  </Currency>\n"+
+		"</Tarih_Date>"))
+	assert.Equal(t, nil, err)
+	assert.Len(t, actualLatestExchangeRateResponse.ExchangeRates, 0)
+}
+
+func TestCentralBankOfTurkeyDataSource_InvalidRate(t *testing.T) {
+	dataSource := &CentralBankOfTurkeyDataSource{}
+	context := core.NewNullContext()
+
+	actualLatestExchangeRateResponse, err := dataSource.Parse(context, []byte("<?xml version=\"1.0\" encoding=\"UTF-8\"?>"+
+		"<Tarih_Date Tarih=\"01.04.2021\" Date=\"04/01/2021\" Bulten_No=\"2021/63\">"+
+		"  <Currency CrossOrder=\"0\" Kod=\"USD\" CurrencyCode=\"USD\">\n"+
+		"    <Unit>1</Unit>\n"+
+		"    <ForexBuying>null</ForexBuying>\n"+
+		"  </Currency>\n"+
+		"</Tarih_Date>"))
+	assert.Equal(t, nil, err)
+	assert.Len(t, actualLatestExchangeRateResponse.ExchangeRates, 0)
+
+	actualLatestExchangeRateResponse, err = dataSource.Parse(context, []byte("<?xml version=\"1.0\" encoding=\"UTF-8\"?>"+
+		"<Tarih_Date Tarih=\"01.04.2021\" Date=\"04/01/2021\" Bulten_No=\"2021/63\">"+
+		"  <Currency CrossOrder=\"0\" Kod=\"USD\" CurrencyCode=\"USD\">\n"+
+		"    <Unit>1</Unit>\n"+
+		"    <ForexBuying>0</ForexBuying>\n"+
+		"  </Currency>\n"+
+		"</Tarih_Date>"))
+	assert.Equal(t, nil, err)
+	assert.Len(t, actualLatestExchangeRateResponse.ExchangeRates, 0)
+}
+
+func TestCentralBankOfTurkeyDataSource_InvalidUnit(t *testing.T) {
+	dataSource := &CentralBankOfTurkeyDataSource{}
+	context := core.NewNullContext()
+
+	actualLatestExchangeRateResponse, err := dataSource.Parse(context, []byte("<?xml version=\"1.0\" encoding=\"UTF-8\"?>"+
+		"<Tarih_Date Tarih=\"01.04.2021\" Date=\"04/01/2021\" Bulten_No=\"2021/63\">"+
+		"  <Currency CrossOrder=\"0\" Kod=\"USD\" CurrencyCode=\"USD\">\n"+
+		"    <Unit>null</Unit>\n"+
+		"    <ForexBuying>8.2992</ForexBuying>\n"+
+		"  </Currency>\n"+
+		"</Tarih_Date>"))
+	assert.Equal(t, nil, err)
+	assert.Len(t, actualLatestExchangeRateResponse.ExchangeRates, 0)
+
+	actualLatestExchangeRateResponse, err = dataSource.Parse(context, []byte("<?xml version=\"1.0\" encoding=\"UTF-8\"?>"+
+		"<Tarih_Date Tarih=\"01.04.2021\" Date=\"04/01/2021\" Bulten_No=\"2021/63\">"+
+		"  <Currency CrossOrder=\"0\" Kod=\"USD\" CurrencyCode=\"USD\">\n"+
+		"    <Unit>0</Unit>\n"+
+		"    <ForexBuying>8.2992</ForexBuying>\n"+
+		"  </Currency>\n"+
+		"</Tarih_Date>"))
+	assert.Equal(t, nil, err)
+	assert.Len(t, actualLatestExchangeRateResponse.ExchangeRates, 0)
+}
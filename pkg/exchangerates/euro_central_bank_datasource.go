@@ -17,12 +17,15 @@ import (
 )
 
 const euroCentralBankExchangeRateUrl = "https://www.ecb.europa.eu/stats/eurofxref/eurofxref-daily.xml"
+const euroCentralBankRecentHistoricalExchangeRateUrl = "https://www.ecb.europa.eu/stats/eurofxref/eurofxref-hist-90d.xml"
+const euroCentralBankAllHistoricalExchangeRateUrl = "https://www.ecb.europa.eu/stats/eurofxref/eurofxref-hist.xml"
 const euroCentralBankExchangeRateReferenceUrl = "https://www.ecb.europa.eu/stats/policy_and_exchange_rates/euro_reference_exchange_rates/html/index.en.html"
 const euroCentralBankDataSource = "European Central Bank"
 const euroCentralBankBaseCurrency = "EUR"
 
 const euroCentralBankDataUpdateDateFormat = "2006-01-02 15"
 const euroCentralBankDataUpdateDateTimezone = "Europe/Berlin"
+const euroCentralBankRecentHistoricalExchangeRateDays = 90
 
 // EuroCentralBankDataSource defines the structure of exchange rates data source of euro central bank
 type EuroCentralBankDataSource struct {
@@ -103,6 +106,27 @@ func (e *EuroCentralBankExchangeRateData) ToLatestExchangeRateResponse(c core.Co
 	return latestExchangeRateResp
 }
 
+// ToHistoricalExchangeRateResponses returns the view-objects of every day according to original data from euro central bank
+func (e *EuroCentralBankExchangeRateData) ToHistoricalExchangeRateResponses(c core.Context) []*models.LatestExchangeRateResponse {
+	exchangeRateResps := make([]*models.LatestExchangeRateResponse, 0, len(e.AllExchangeRates))
+
+	for i := 0; i < len(e.AllExchangeRates); i++ {
+		dailyData := &EuroCentralBankExchangeRateData{
+			AllExchangeRates: []*EuroCentralBankExchangeRates{e.AllExchangeRates[i]},
+		}
+
+		exchangeRateResp := dailyData.ToLatestExchangeRateResponse(c)
+
+		if exchangeRateResp == nil {
+			continue
+		}
+
+		exchangeRateResps = append(exchangeRateResps, exchangeRateResp)
+	}
+
+	return exchangeRateResps
+}
+
 // ToLatestExchangeRate returns a data pair according to original data from euro central bank
 func (e *EuroCentralBankExchangeRate) ToLatestExchangeRate() *models.LatestExchangeRate {
 	return &models.LatestExchangeRate{
@@ -122,17 +146,30 @@ func (e *EuroCentralBankDataSource) BuildRequests() ([]*http.Request, error) {
 	return []*http.Request{req}, nil
 }
 
+// BuildHistoricalRequests returns the euro central bank historical exchange rates http requests,
+// the data of recent 90 days is requested if the start date is in recent 90 days, otherwise all the historical data is requested
+func (e *EuroCentralBankDataSource) BuildHistoricalRequests(startDate time.Time, endDate time.Time) ([]*http.Request, error) {
+	url := euroCentralBankAllHistoricalExchangeRateUrl
+
+	if startDate.After(time.Now().AddDate(0, 0, -euroCentralBankRecentHistoricalExchangeRateDays+1)) {
+		url = euroCentralBankRecentHistoricalExchangeRateUrl
+	}
+
+	req, err := http.NewRequest("GET", url, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return []*http.Request{req}, nil
+}
+
 // Parse returns the common response entity according to the euro central bank data source raw response
 func (e *EuroCentralBankDataSource) Parse(c core.Context, content []byte) (*models.LatestExchangeRateResponse, error) {
-	xmlDecoder := xml.NewDecoder(bytes.NewReader(content))
-	xmlDecoder.CharsetReader = charset.NewReaderLabel
-
-	euroCentralBankData := &EuroCentralBankExchangeRateData{}
-	err := xmlDecoder.Decode(euroCentralBankData)
+	euroCentralBankData, err := e.parseData(c, content)
 
 	if err != nil {
-		log.Errorf(c, "[euro_central_bank_datasource.Parse] failed to parse xml data, content is %s, because %s", string(content), err.Error())
-		return nil, errs.ErrFailedToRequestRemoteApi
+		return nil, err
 	}
 
 	latestExchangeRateResponse := euroCentralBankData.ToLatestExchangeRateResponse(c)
@@ -144,3 +181,29 @@ func (e *EuroCentralBankDataSource) Parse(c core.Context, content []byte) (*mode
 
 	return latestExchangeRateResponse, nil
 }
+
+// ParseHistorical returns the common response entities of every day according to the euro central bank data source raw response
+func (e *EuroCentralBankDataSource) ParseHistorical(c core.Context, content []byte) ([]*models.LatestExchangeRateResponse, error) {
+	euroCentralBankData, err := e.parseData(c, content)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return euroCentralBankData.ToHistoricalExchangeRateResponses(c), nil
+}
+
+func (e *EuroCentralBankDataSource) parseData(c core.Context, content []byte) (*EuroCentralBankExchangeRateData, error) {
+	xmlDecoder := xml.NewDecoder(bytes.NewReader(content))
+	xmlDecoder.CharsetReader = charset.NewReaderLabel
+
+	euroCentralBankData := &EuroCentralBankExchangeRateData{}
+	err := xmlDecoder.Decode(euroCentralBankData)
+
+	if err != nil {
+		log.Errorf(c, "[euro_central_bank_datasource.parseData] failed to parse xml data, content is %s, because %s", string(content), err.Error())
+		return nil, errs.ErrFailedToRequestRemoteApi
+	}
+
+	return euroCentralBankData, nil
+}
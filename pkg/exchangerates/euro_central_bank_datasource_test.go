@@ -138,3 +138,35 @@ func TestEuroCentralBankDataSource_InvalidRate(t *testing.T) {
 	assert.Equal(t, nil, err)
 	assert.Len(t, actualLatestExchangeRateResponse.ExchangeRates, 0)
 }
+
+func TestEuroCentralBankDataSource_ParseHistorical(t *testing.T) {
+	dataSource := &EuroCentralBankDataSource{}
+	context := core.NewNullContext()
+
+	actualExchangeRateResponses, err := dataSource.ParseHistorical(context, []byte("<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n"+
+		"<gesmes:Envelope xmlns:gesmes=\"http://www.gesmes.org/xml/2002-08-01\" xmlns=\"http://www.ecb.int/vocabulary/2002-08-01/eurofxref\">\n"+
+		"  <Cube>\n"+
+		"    <Cube time=\"2021-04-01\">\n"+
+		"      <Cube currency=\"USD\" rate=\"1.1746\" />\n"+
+		"    </Cube>\n"+
+		"    <Cube time=\"2021-03-31\">\n"+
+		"      <Cube currency=\"USD\" rate=\"1.1725\" />\n"+
+		"    </Cube>\n"+
+		"  </Cube>\n"+
+		"</gesmes:Envelope>"))
+	assert.Equal(t, nil, err)
+	assert.Len(t, actualExchangeRateResponses, 2)
+
+	assert.Equal(t, int64(1617285600), actualExchangeRateResponses[0].UpdateTime)
+	assert.Equal(t, "EUR", actualExchangeRateResponses[0].BaseCurrency)
+	assert.Contains(t, actualExchangeRateResponses[0].ExchangeRates, &models.LatestExchangeRate{
+		Currency: "USD",
+		Rate:     "1.1746",
+	})
+
+	assert.Equal(t, int64(1617199200), actualExchangeRateResponses[1].UpdateTime)
+	assert.Contains(t, actualExchangeRateResponses[1].ExchangeRates, &models.LatestExchangeRate{
+		Currency: "USD",
+		Rate:     "1.1725",
+	})
+}
@@ -2,6 +2,7 @@ package exchangerates
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/mayswind/ezbookkeeping/pkg/core"
 	"github.com/mayswind/ezbookkeeping/pkg/models"
@@ -15,3 +16,12 @@ type ExchangeRatesDataSource interface {
 	// Parse returns the common response entity according to the data source raw response
 	Parse(c core.Context, content []byte) (*models.LatestExchangeRateResponse, error)
 }
+
+// HistoricalExchangeRatesDataSource defines the structure of exchange rates data source which supports querying historical exchange rates
+type HistoricalExchangeRatesDataSource interface {
+	// BuildHistoricalRequests returns the http requests of historical exchange rates between the start date and the end date
+	BuildHistoricalRequests(startDate time.Time, endDate time.Time) ([]*http.Request, error)
+
+	// ParseHistorical returns the common response entities of every day according to the data source raw response
+	ParseHistorical(c core.Context, content []byte) ([]*models.LatestExchangeRateResponse, error)
+}
@@ -1,13 +1,33 @@
 package exchangerates
 
 import (
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
 	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
 	"github.com/mayswind/ezbookkeeping/pkg/settings"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
 )
 
+// LatestExchangeRatesRefreshedHandler represents the handler which is called with the latest exchange rates data requested from the current (primary) exchange rates data source
+type LatestExchangeRatesRefreshedHandler func(c core.Context, exchangeRateResp *models.LatestExchangeRateResponse)
+
 // ExchangeRatesDataSourceContainer contains the current exchange rates data source
 type ExchangeRatesDataSourceContainer struct {
-	Current ExchangeRatesDataSource
+	Current              ExchangeRatesDataSource
+	allDataSources       []ExchangeRatesDataSource
+	cacheLock            sync.RWMutex
+	cachedResponse       *models.LatestExchangeRateResponse
+	cacheExpiredUnixTime int64
+	refreshedHandler     LatestExchangeRatesRefreshedHandler
 }
 
 // Initialize a exchange rates data source container singleton instance
@@ -15,60 +35,416 @@ var (
 	Container = &ExchangeRatesDataSourceContainer{}
 )
 
-// InitializeExchangeRatesDataSource initializes the current exchange rates data source according to the config
+// InitializeExchangeRatesDataSource initializes the current exchange rates data source and the fallback data sources according to the config
 func InitializeExchangeRatesDataSource(config *settings.Config) error {
-	if config.ExchangeRatesDataSource == settings.ReserveBankOfAustraliaDataSource {
-		Container.Current = &ReserveBankOfAustraliaDataSource{}
-		return nil
-	} else if config.ExchangeRatesDataSource == settings.BankOfCanadaDataSource {
-		Container.Current = &BankOfCanadaDataSource{}
-		return nil
-	} else if config.ExchangeRatesDataSource == settings.CzechNationalBankDataSource {
-		Container.Current = &CzechNationalBankDataSource{}
-		return nil
-	} else if config.ExchangeRatesDataSource == settings.DanmarksNationalbankDataSource {
-		Container.Current = &DanmarksNationalbankDataSource{}
-		return nil
-	} else if config.ExchangeRatesDataSource == settings.EuroCentralBankDataSource {
-		Container.Current = &EuroCentralBankDataSource{}
-		return nil
-	} else if config.ExchangeRatesDataSource == settings.NationalBankOfGeorgiaDataSource {
-		Container.Current = &NationalBankOfGeorgiaDataSource{}
-		return nil
-	} else if config.ExchangeRatesDataSource == settings.CentralBankOfHungaryDataSource {
-		Container.Current = &CentralBankOfHungaryDataSource{}
-		return nil
-	} else if config.ExchangeRatesDataSource == settings.BankOfIsraelDataSource {
-		Container.Current = &BankOfIsraelDataSource{}
-		return nil
-	} else if config.ExchangeRatesDataSource == settings.CentralBankOfMyanmarDataSource {
-		Container.Current = &CentralBankOfMyanmarDataSource{}
-		return nil
-	} else if config.ExchangeRatesDataSource == settings.NorgesBankDataSource {
-		Container.Current = &NorgesBankDataSource{}
-		return nil
-	} else if config.ExchangeRatesDataSource == settings.NationalBankOfPolandDataSource {
-		Container.Current = &NationalBankOfPolandDataSource{}
-		return nil
-	} else if config.ExchangeRatesDataSource == settings.NationalBankOfRomaniaDataSource {
-		Container.Current = &NationalBankOfRomaniaDataSource{}
-		return nil
-	} else if config.ExchangeRatesDataSource == settings.BankOfRussiaDataSource {
-		Container.Current = &BankOfRussiaDataSource{}
-		return nil
-	} else if config.ExchangeRatesDataSource == settings.SwissNationalBankDataSource {
-		Container.Current = &SwissNationalBankDataSource{}
-		return nil
-	} else if config.ExchangeRatesDataSource == settings.NationalBankOfUkraineDataSource {
-		Container.Current = &NationalBankOfUkraineDataSource{}
-		return nil
-	} else if config.ExchangeRatesDataSource == settings.CentralBankOfUzbekistanDataSource {
-		Container.Current = &CentralBankOfUzbekistanDataSource{}
-		return nil
-	} else if config.ExchangeRatesDataSource == settings.InternationalMonetaryFundDataSource {
-		Container.Current = &InternationalMonetaryFundDataSource{}
-		return nil
-	}
-
-	return errs.ErrInvalidExchangeRatesDataSource
+	dataSourceNames := config.ExchangeRatesDataSources
+
+	if len(dataSourceNames) < 1 {
+		dataSourceNames = []string{config.ExchangeRatesDataSource}
+	}
+
+	allDataSources := make([]ExchangeRatesDataSource, 0, len(dataSourceNames))
+
+	for i := 0; i < len(dataSourceNames); i++ {
+		dataSource, err := newExchangeRatesDataSource(dataSourceNames[i])
+
+		if err != nil {
+			return err
+		}
+
+		allDataSources = append(allDataSources, dataSource)
+	}
+
+	Container.cacheLock.Lock()
+	defer Container.cacheLock.Unlock()
+
+	Container.Current = allDataSources[0]
+	Container.allDataSources = allDataSources
+	Container.cachedResponse = nil
+	Container.cacheExpiredUnixTime = 0
+
+	return nil
+}
+
+func newExchangeRatesDataSource(dataSource string) (ExchangeRatesDataSource, error) {
+	if dataSource == settings.ReserveBankOfAustraliaDataSource {
+		return &ReserveBankOfAustraliaDataSource{}, nil
+	} else if dataSource == settings.BankOfCanadaDataSource {
+		return &BankOfCanadaDataSource{}, nil
+	} else if dataSource == settings.CzechNationalBankDataSource {
+		return &CzechNationalBankDataSource{}, nil
+	} else if dataSource == settings.DanmarksNationalbankDataSource {
+		return &DanmarksNationalbankDataSource{}, nil
+	} else if dataSource == settings.EuroCentralBankDataSource {
+		return &EuroCentralBankDataSource{}, nil
+	} else if dataSource == settings.NationalBankOfGeorgiaDataSource {
+		return &NationalBankOfGeorgiaDataSource{}, nil
+	} else if dataSource == settings.CentralBankOfHungaryDataSource {
+		return &CentralBankOfHungaryDataSource{}, nil
+	} else if dataSource == settings.ReserveBankOfIndiaDataSource {
+		return &ReserveBankOfIndiaDataSource{}, nil
+	} else if dataSource == settings.BankOfIsraelDataSource {
+		return &BankOfIsraelDataSource{}, nil
+	} else if dataSource == settings.BankOfJapanDataSource {
+		return &BankOfJapanDataSource{}, nil
+	} else if dataSource == settings.CentralBankOfMyanmarDataSource {
+		return &CentralBankOfMyanmarDataSource{}, nil
+	} else if dataSource == settings.NorgesBankDataSource {
+		return &NorgesBankDataSource{}, nil
+	} else if dataSource == settings.NationalBankOfPolandDataSource {
+		return &NationalBankOfPolandDataSource{}, nil
+	} else if dataSource == settings.NationalBankOfRomaniaDataSource {
+		return &NationalBankOfRomaniaDataSource{}, nil
+	} else if dataSource == settings.BankOfRussiaDataSource {
+		return &BankOfRussiaDataSource{}, nil
+	} else if dataSource == settings.SwissNationalBankDataSource {
+		return &SwissNationalBankDataSource{}, nil
+	} else if dataSource == settings.BankOfThailandDataSource {
+		return &BankOfThailandDataSource{}, nil
+	} else if dataSource == settings.CentralBankOfTurkeyDataSource {
+		return &CentralBankOfTurkeyDataSource{}, nil
+	} else if dataSource == settings.NationalBankOfUkraineDataSource {
+		return &NationalBankOfUkraineDataSource{}, nil
+	} else if dataSource == settings.BankOfEnglandDataSource {
+		return &BankOfEnglandDataSource{}, nil
+	} else if dataSource == settings.FederalReserveDataSource {
+		return &FederalReserveDataSource{}, nil
+	} else if dataSource == settings.CentralBankOfUzbekistanDataSource {
+		return &CentralBankOfUzbekistanDataSource{}, nil
+	} else if dataSource == settings.InternationalMonetaryFundDataSource {
+		return &InternationalMonetaryFundDataSource{}, nil
+	}
+
+	return nil, errs.ErrInvalidExchangeRatesDataSource
+}
+
+// SetLatestExchangeRatesRefreshedHandler sets the handler which is called each time the latest exchange rates are requested from the current (primary) exchange rates data source successfully,
+// the exchange rates merged from the fallback data sources are not passed to the handler
+func (e *ExchangeRatesDataSourceContainer) SetLatestExchangeRatesRefreshedHandler(handler LatestExchangeRatesRefreshedHandler) {
+	e.cacheLock.Lock()
+	defer e.cacheLock.Unlock()
+
+	e.refreshedHandler = handler
+}
+
+// GetLatestExchangeRates returns the latest exchange rates data, the cached data is returned if it has not expired
+func (e *ExchangeRatesDataSourceContainer) GetLatestExchangeRates(c core.Context, uid int64, currentConfig *settings.Config) (*models.LatestExchangeRateResponse, error) {
+	if currentConfig.ExchangeRatesCacheTTL > 0 {
+		e.cacheLock.RLock()
+		cachedResponse := e.cachedResponse
+		cacheExpiredUnixTime := e.cacheExpiredUnixTime
+		e.cacheLock.RUnlock()
+
+		if cachedResponse != nil && time.Now().Unix() < cacheExpiredUnixTime {
+			return cachedResponse, nil
+		}
+	}
+
+	return e.RefreshLatestExchangeRates(c, uid, currentConfig)
+}
+
+// RefreshLatestExchangeRates requests the latest exchange rates data from all the exchange rates data sources in order and updates the cache,
+// the currencies missing from the former data source are merged from the subsequent data sources,
+// and the expired cached data is returned if all the data sources fail
+func (e *ExchangeRatesDataSourceContainer) RefreshLatestExchangeRates(c core.Context, uid int64, currentConfig *settings.Config) (*models.LatestExchangeRateResponse, error) {
+	allDataSources := e.allDataSources
+
+	if len(allDataSources) < 1 && e.Current != nil {
+		allDataSources = []ExchangeRatesDataSource{e.Current}
+	}
+
+	if len(allDataSources) < 1 {
+		return nil, errs.ErrInvalidExchangeRatesDataSource
+	}
+
+	var primaryExchangeRateResp *models.LatestExchangeRateResponse
+	var finalExchangeRateResp *models.LatestExchangeRateResponse
+	var lastErr error
+	client := e.buildHttpClient(currentConfig)
+
+	for i := 0; i < len(allDataSources); i++ {
+		exchangeRateResp, err := e.getLatestExchangeRatesFromDataSource(c, uid, client, allDataSources[i])
+
+		if err != nil {
+			if i < len(allDataSources)-1 {
+				log.Warnf(c, "[exchange_rates_datasource_container.RefreshLatestExchangeRates] failed to get latest exchange rates from data source#%d, try next data source", i)
+			}
+
+			lastErr = err
+			continue
+		}
+
+		if i == 0 {
+			primaryExchangeRateResp = exchangeRateResp
+		}
+
+		if finalExchangeRateResp == nil {
+			finalExchangeRateResp = exchangeRateResp
+		} else {
+			finalExchangeRateResp = e.mergeMissingExchangeRates(finalExchangeRateResp, exchangeRateResp)
+		}
+	}
+
+	if finalExchangeRateResp == nil {
+		e.cacheLock.RLock()
+		cachedResponse := e.cachedResponse
+		e.cacheLock.RUnlock()
+
+		if cachedResponse != nil {
+			log.Warnf(c, "[exchange_rates_datasource_container.RefreshLatestExchangeRates] all data sources failed, use expired cached exchange rates for user \"uid:%d\"", uid)
+			return cachedResponse, nil
+		}
+
+		return nil, lastErr
+	}
+
+	e.cacheLock.Lock()
+	e.cachedResponse = finalExchangeRateResp
+	e.cacheExpiredUnixTime = time.Now().Add(currentConfig.ExchangeRatesCacheTTLDuration).Unix()
+	refreshedHandler := e.refreshedHandler
+	e.cacheLock.Unlock()
+
+	if primaryExchangeRateResp != nil && refreshedHandler != nil {
+		refreshedHandler(c, primaryExchangeRateResp)
+	}
+
+	return finalExchangeRateResp, nil
+}
+
+func (e *ExchangeRatesDataSourceContainer) getLatestExchangeRatesFromDataSource(c core.Context, uid int64, client *http.Client, dataSource ExchangeRatesDataSource) (*models.LatestExchangeRateResponse, error) {
+	requests, err := dataSource.BuildRequests()
+
+	if err != nil {
+		log.Errorf(c, "[exchange_rates_datasource_container.getLatestExchangeRatesFromDataSource] failed to build requests for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.ErrFailedToRequestRemoteApi
+	}
+
+	exchangeRateResps := make([]*models.LatestExchangeRateResponse, 0, len(requests))
+
+	for i := 0; i < len(requests); i++ {
+		body, err := e.requestData(c, client, requests[i])
+
+		if err != nil {
+			log.Errorf(c, "[exchange_rates_datasource_container.getLatestExchangeRatesFromDataSource] failed to request latest exchange rate data for user \"uid:%d\", because %s", uid, err.Error())
+			return nil, errs.ErrFailedToRequestRemoteApi
+		}
+
+		log.Debugf(c, "[exchange_rates_datasource_container.getLatestExchangeRatesFromDataSource] response#%d is %s", i, body)
+
+		exchangeRateResp, err := dataSource.Parse(c, body)
+
+		if err != nil {
+			log.Errorf(c, "[exchange_rates_datasource_container.getLatestExchangeRatesFromDataSource] failed to parse response for user \"uid:%d\", because %s", uid, err.Error())
+			return nil, errs.Or(err, errs.ErrFailedToRequestRemoteApi)
+		}
+
+		exchangeRateResps = append(exchangeRateResps, exchangeRateResp)
+	}
+
+	if len(exchangeRateResps) < 1 {
+		return nil, errs.ErrFailedToRequestRemoteApi
+	}
+
+	exchangeRateResp := e.mergeExchangeRateResponses(exchangeRateResps)
+
+	for i := 0; i < len(exchangeRateResp.ExchangeRates); i++ {
+		exchangeRateResp.ExchangeRates[i].DataSource = exchangeRateResp.DataSource
+	}
+
+	return exchangeRateResp, nil
+}
+
+// IsHistoricalExchangeRatesSupported returns whether the current exchange rates data source supports querying historical exchange rates
+func (e *ExchangeRatesDataSourceContainer) IsHistoricalExchangeRatesSupported() bool {
+	_, ok := e.Current.(HistoricalExchangeRatesDataSource)
+	return ok
+}
+
+// GetHistoricalExchangeRates returns the exchange rates data of every day between the start date and the end date from the current exchange rates data source
+func (e *ExchangeRatesDataSourceContainer) GetHistoricalExchangeRates(c core.Context, currentConfig *settings.Config, startDate time.Time, endDate time.Time) ([]*models.LatestExchangeRateResponse, error) {
+	if e.Current == nil {
+		return nil, errs.ErrInvalidExchangeRatesDataSource
+	}
+
+	dataSource, ok := e.Current.(HistoricalExchangeRatesDataSource)
+
+	if !ok {
+		return nil, errs.ErrExchangeRatesDataSourceNotSupportHistory
+	}
+
+	if endDate.Before(startDate) {
+		return nil, errs.ErrExchangeRateDateRangeInvalid
+	}
+
+	requests, err := dataSource.BuildHistoricalRequests(startDate, endDate)
+
+	if err != nil {
+		log.Errorf(c, "[exchange_rates_datasource_container.GetHistoricalExchangeRates] failed to build requests, because %s", err.Error())
+		return nil, errs.ErrFailedToRequestRemoteApi
+	}
+
+	startNumericDate := utils.FormatTimeToNumericDate(startDate)
+	endNumericDate := utils.FormatTimeToNumericDate(endDate)
+	dailyExchangeRateResps := make(map[int32][]*models.LatestExchangeRateResponse)
+	client := e.buildHttpClient(currentConfig)
+
+	for i := 0; i < len(requests); i++ {
+		body, err := e.requestData(c, client, requests[i])
+
+		if err != nil {
+			log.Errorf(c, "[exchange_rates_datasource_container.GetHistoricalExchangeRates] failed to request historical exchange rate data, because %s", err.Error())
+			return nil, errs.ErrFailedToRequestRemoteApi
+		}
+
+		exchangeRateResps, err := dataSource.ParseHistorical(c, body)
+
+		if err != nil {
+			log.Errorf(c, "[exchange_rates_datasource_container.GetHistoricalExchangeRates] failed to parse response, because %s", err.Error())
+			return nil, errs.Or(err, errs.ErrFailedToRequestRemoteApi)
+		}
+
+		for j := 0; j < len(exchangeRateResps); j++ {
+			exchangeRateResp := exchangeRateResps[j]
+			rateDate := utils.FormatTimeToNumericDate(time.Unix(exchangeRateResp.UpdateTime, 0).In(time.UTC))
+
+			if rateDate < startNumericDate || rateDate > endNumericDate {
+				continue
+			}
+
+			dailyExchangeRateResps[rateDate] = append(dailyExchangeRateResps[rateDate], exchangeRateResp)
+		}
+	}
+
+	allExchangeRateResps := make([]*models.LatestExchangeRateResponse, 0, len(dailyExchangeRateResps))
+
+	for _, exchangeRateResps := range dailyExchangeRateResps {
+		allExchangeRateResps = append(allExchangeRateResps, e.mergeExchangeRateResponses(exchangeRateResps))
+	}
+
+	sort.Slice(allExchangeRateResps, func(i, j int) bool {
+		return allExchangeRateResps[i].UpdateTime < allExchangeRateResps[j].UpdateTime
+	})
+
+	return allExchangeRateResps, nil
+}
+
+func (e *ExchangeRatesDataSourceContainer) buildHttpClient(currentConfig *settings.Config) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	utils.SetProxyUrl(transport, currentConfig.ExchangeRatesProxy)
+
+	if currentConfig.ExchangeRatesSkipTLSVerify {
+		transport.TLSClientConfig = &tls.Config{
+			InsecureSkipVerify: true,
+		}
+	}
+
+	return &http.Client{
+		Transport: transport,
+		Timeout:   time.Duration(currentConfig.ExchangeRatesRequestTimeout) * time.Millisecond,
+	}
+}
+
+func (e *ExchangeRatesDataSourceContainer) requestData(c core.Context, client *http.Client, req *http.Request) ([]byte, error) {
+	if len(req.Header.Values("User-Agent")) < 1 {
+		req.Header.Set("User-Agent", fmt.Sprintf("ezBookkeeping/%s", settings.Version))
+	} else if req.Header.Get("User-Agent") == "" {
+		req.Header.Del("User-Agent")
+	}
+
+	resp, err := client.Do(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("response code is %d", resp.StatusCode)
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
+func (e *ExchangeRatesDataSourceContainer) mergeExchangeRateResponses(exchangeRateResps []*models.LatestExchangeRateResponse) *models.LatestExchangeRateResponse {
+	lastExchangeRateResponse := exchangeRateResps[len(exchangeRateResps)-1]
+	allExchangeRatesMap := make(map[string]string)
+
+	for i := 0; i < len(exchangeRateResps); i++ {
+		exchangeRateResp := exchangeRateResps[i]
+
+		for j := 0; j < len(exchangeRateResp.ExchangeRates); j++ {
+			exchangeRate := exchangeRateResp.ExchangeRates[j]
+			allExchangeRatesMap[exchangeRate.Currency] = exchangeRate.Rate
+		}
+	}
+
+	allExchangeRatesMap[lastExchangeRateResponse.BaseCurrency] = "1"
+	allExchangeRates := make(models.LatestExchangeRateSlice, 0, len(allExchangeRatesMap))
+
+	for currency, rate := range allExchangeRatesMap {
+		allExchangeRates = append(allExchangeRates, &models.LatestExchangeRate{
+			Currency: currency,
+			Rate:     rate,
+		})
+	}
+
+	sort.Sort(allExchangeRates)
+
+	return &models.LatestExchangeRateResponse{
+		DataSource:    lastExchangeRateResponse.DataSource,
+		ReferenceUrl:  lastExchangeRateResponse.ReferenceUrl,
+		UpdateTime:    lastExchangeRateResponse.UpdateTime,
+		BaseCurrency:  lastExchangeRateResponse.BaseCurrency,
+		ExchangeRates: allExchangeRates,
+	}
+}
+
+func (e *ExchangeRatesDataSourceContainer) mergeMissingExchangeRates(primaryExchangeRateResp *models.LatestExchangeRateResponse, secondaryExchangeRateResp *models.LatestExchangeRateResponse) *models.LatestExchangeRateResponse {
+	secondaryExchangeRates := secondaryExchangeRateResp.ToExchangeRatesMap()
+	primaryBaseCurrencyRate, exists := secondaryExchangeRates[primaryExchangeRateResp.BaseCurrency]
+
+	if !exists {
+		return primaryExchangeRateResp
+	}
+
+	existedCurrencies := make(map[string]bool, len(primaryExchangeRateResp.ExchangeRates))
+	allExchangeRates := make(models.LatestExchangeRateSlice, 0, len(primaryExchangeRateResp.ExchangeRates)+len(secondaryExchangeRateResp.ExchangeRates))
+
+	for i := 0; i < len(primaryExchangeRateResp.ExchangeRates); i++ {
+		exchangeRate := primaryExchangeRateResp.ExchangeRates[i]
+		existedCurrencies[exchangeRate.Currency] = true
+		allExchangeRates = append(allExchangeRates, exchangeRate)
+	}
+
+	for i := 0; i < len(secondaryExchangeRateResp.ExchangeRates); i++ {
+		exchangeRate := secondaryExchangeRateResp.ExchangeRates[i]
+
+		if existedCurrencies[exchangeRate.Currency] {
+			continue
+		}
+
+		rate, exists := secondaryExchangeRates[exchangeRate.Currency]
+
+		if !exists {
+			continue
+		}
+
+		existedCurrencies[exchangeRate.Currency] = true
+		allExchangeRates = append(allExchangeRates, &models.LatestExchangeRate{
+			Currency:   exchangeRate.Currency,
+			Rate:       utils.Float64ToString(rate / primaryBaseCurrencyRate),
+			DataSource: exchangeRate.DataSource,
+		})
+	}
+
+	sort.Sort(allExchangeRates)
+
+	return &models.LatestExchangeRateResponse{
+		DataSource:    primaryExchangeRateResp.DataSource,
+		ReferenceUrl:  primaryExchangeRateResp.ReferenceUrl,
+		UpdateTime:    primaryExchangeRateResp.UpdateTime,
+		BaseCurrency:  primaryExchangeRateResp.BaseCurrency,
+		ExchangeRates: allExchangeRates,
+	}
 }
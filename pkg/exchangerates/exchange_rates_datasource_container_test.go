@@ -0,0 +1,237 @@
+package exchangerates
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/settings"
+)
+
+type testExchangeRatesDataSource struct {
+	url          string
+	response     *models.LatestExchangeRateResponse
+	requestCount int
+}
+
+func (e *testExchangeRatesDataSource) BuildRequests() ([]*http.Request, error) {
+	e.requestCount++
+	req, err := http.NewRequest("GET", e.url, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return []*http.Request{req}, nil
+}
+
+func (e *testExchangeRatesDataSource) Parse(c core.Context, content []byte) (*models.LatestExchangeRateResponse, error) {
+	exchangeRates := make(models.LatestExchangeRateSlice, 0, len(e.response.ExchangeRates))
+
+	for i := 0; i < len(e.response.ExchangeRates); i++ {
+		exchangeRates = append(exchangeRates, &models.LatestExchangeRate{
+			Currency: e.response.ExchangeRates[i].Currency,
+			Rate:     e.response.ExchangeRates[i].Rate,
+		})
+	}
+
+	return &models.LatestExchangeRateResponse{
+		DataSource:    e.response.DataSource,
+		ReferenceUrl:  e.response.ReferenceUrl,
+		UpdateTime:    e.response.UpdateTime,
+		BaseCurrency:  e.response.BaseCurrency,
+		ExchangeRates: exchangeRates,
+	}, nil
+}
+
+func newTestExchangeRatesServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/failed" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("{}"))
+	}))
+}
+
+func newTestPrimaryExchangeRatesDataSource(url string) *testExchangeRatesDataSource {
+	return &testExchangeRatesDataSource{
+		url: url,
+		response: &models.LatestExchangeRateResponse{
+			DataSource:   "Primary",
+			UpdateTime:   1700000000,
+			BaseCurrency: "EUR",
+			ExchangeRates: models.LatestExchangeRateSlice{
+				{Currency: "USD", Rate: "2"},
+			},
+		},
+	}
+}
+
+func newTestSecondaryExchangeRatesDataSource(url string) *testExchangeRatesDataSource {
+	return &testExchangeRatesDataSource{
+		url: url,
+		response: &models.LatestExchangeRateResponse{
+			DataSource:   "Secondary",
+			UpdateTime:   1700000100,
+			BaseCurrency: "USD",
+			ExchangeRates: models.LatestExchangeRateSlice{
+				{Currency: "EUR", Rate: "0.5"},
+				{Currency: "JPY", Rate: "80"},
+				{Currency: "USD", Rate: "1"},
+			},
+		},
+	}
+}
+
+func TestExchangeRatesDataSourceContainerRefreshLatestExchangeRates_MergeMissingCurrencies(t *testing.T) {
+	server := newTestExchangeRatesServer()
+	defer server.Close()
+
+	container := &ExchangeRatesDataSourceContainer{
+		allDataSources: []ExchangeRatesDataSource{
+			newTestPrimaryExchangeRatesDataSource(server.URL + "/primary"),
+			newTestSecondaryExchangeRatesDataSource(server.URL + "/secondary"),
+		},
+	}
+
+	actualResponse, err := container.RefreshLatestExchangeRates(core.NewNullContext(), 0, &settings.Config{ExchangeRatesProxy: "none"})
+	assert.Nil(t, err)
+	assert.Equal(t, "Primary", actualResponse.DataSource)
+	assert.Equal(t, int64(1700000000), actualResponse.UpdateTime)
+	assert.Equal(t, "EUR", actualResponse.BaseCurrency)
+	assert.Equal(t, 3, len(actualResponse.ExchangeRates))
+
+	assert.Equal(t, "EUR", actualResponse.ExchangeRates[0].Currency)
+	assert.Equal(t, "1", actualResponse.ExchangeRates[0].Rate)
+	assert.Equal(t, "Primary", actualResponse.ExchangeRates[0].DataSource)
+
+	assert.Equal(t, "JPY", actualResponse.ExchangeRates[1].Currency)
+	assert.Equal(t, "160", actualResponse.ExchangeRates[1].Rate)
+	assert.Equal(t, "Secondary", actualResponse.ExchangeRates[1].DataSource)
+
+	assert.Equal(t, "USD", actualResponse.ExchangeRates[2].Currency)
+	assert.Equal(t, "2", actualResponse.ExchangeRates[2].Rate)
+	assert.Equal(t, "Primary", actualResponse.ExchangeRates[2].DataSource)
+}
+
+func TestExchangeRatesDataSourceContainerRefreshLatestExchangeRates_PrimaryFailed(t *testing.T) {
+	server := newTestExchangeRatesServer()
+	defer server.Close()
+
+	container := &ExchangeRatesDataSourceContainer{
+		allDataSources: []ExchangeRatesDataSource{
+			newTestPrimaryExchangeRatesDataSource(server.URL + "/failed"),
+			newTestSecondaryExchangeRatesDataSource(server.URL + "/secondary"),
+		},
+	}
+
+	actualResponse, err := container.RefreshLatestExchangeRates(core.NewNullContext(), 0, &settings.Config{ExchangeRatesProxy: "none"})
+	assert.Nil(t, err)
+	assert.Equal(t, "Secondary", actualResponse.DataSource)
+	assert.Equal(t, "USD", actualResponse.BaseCurrency)
+	assert.Equal(t, 3, len(actualResponse.ExchangeRates))
+	assert.Equal(t, "JPY", actualResponse.ExchangeRates[1].Currency)
+	assert.Equal(t, "80", actualResponse.ExchangeRates[1].Rate)
+	assert.Equal(t, "Secondary", actualResponse.ExchangeRates[1].DataSource)
+}
+
+func TestExchangeRatesDataSourceContainerRefreshLatestExchangeRates_AllFailed(t *testing.T) {
+	server := newTestExchangeRatesServer()
+	defer server.Close()
+
+	container := &ExchangeRatesDataSourceContainer{
+		allDataSources: []ExchangeRatesDataSource{
+			newTestPrimaryExchangeRatesDataSource(server.URL + "/failed"),
+			newTestSecondaryExchangeRatesDataSource(server.URL + "/failed"),
+		},
+	}
+
+	_, err := container.RefreshLatestExchangeRates(core.NewNullContext(), 0, &settings.Config{ExchangeRatesProxy: "none"})
+	assert.Equal(t, errs.ErrFailedToRequestRemoteApi, err)
+}
+
+func TestExchangeRatesDataSourceContainerGetLatestExchangeRates_UseCache(t *testing.T) {
+	server := newTestExchangeRatesServer()
+	defer server.Close()
+
+	dataSource := newTestPrimaryExchangeRatesDataSource(server.URL + "/primary")
+	container := &ExchangeRatesDataSourceContainer{
+		allDataSources: []ExchangeRatesDataSource{dataSource},
+	}
+	config := &settings.Config{
+		ExchangeRatesProxy:            "none",
+		ExchangeRatesCacheTTL:         60,
+		ExchangeRatesCacheTTLDuration: 60 * time.Second,
+	}
+
+	firstResponse, err := container.GetLatestExchangeRates(core.NewNullContext(), 0, config)
+	assert.Nil(t, err)
+
+	secondResponse, err := container.GetLatestExchangeRates(core.NewNullContext(), 0, config)
+	assert.Nil(t, err)
+	assert.Equal(t, firstResponse, secondResponse)
+	assert.Equal(t, 1, dataSource.requestCount)
+}
+
+func TestExchangeRatesDataSourceContainerGetLatestExchangeRates_UseExpiredCacheWhenAllFailed(t *testing.T) {
+	server := newTestExchangeRatesServer()
+	defer server.Close()
+
+	dataSource := newTestPrimaryExchangeRatesDataSource(server.URL + "/primary")
+	container := &ExchangeRatesDataSourceContainer{
+		allDataSources: []ExchangeRatesDataSource{dataSource},
+	}
+	config := &settings.Config{ExchangeRatesProxy: "none"}
+
+	firstResponse, err := container.GetLatestExchangeRates(core.NewNullContext(), 0, config)
+	assert.Nil(t, err)
+
+	dataSource.url = server.URL + "/failed"
+
+	secondResponse, err := container.GetLatestExchangeRates(core.NewNullContext(), 0, config)
+	assert.Nil(t, err)
+	assert.Equal(t, firstResponse, secondResponse)
+	assert.Equal(t, 2, dataSource.requestCount)
+}
+
+func TestExchangeRatesDataSourceContainerRefreshLatestExchangeRates_RefreshedHandler(t *testing.T) {
+	server := newTestExchangeRatesServer()
+	defer server.Close()
+
+	var refreshedResponses []*models.LatestExchangeRateResponse
+	container := &ExchangeRatesDataSourceContainer{
+		allDataSources: []ExchangeRatesDataSource{
+			newTestPrimaryExchangeRatesDataSource(server.URL + "/primary"),
+			newTestSecondaryExchangeRatesDataSource(server.URL + "/secondary"),
+		},
+	}
+	container.SetLatestExchangeRatesRefreshedHandler(func(c core.Context, exchangeRateResp *models.LatestExchangeRateResponse) {
+		refreshedResponses = append(refreshedResponses, exchangeRateResp)
+	})
+
+	_, err := container.RefreshLatestExchangeRates(core.NewNullContext(), 0, &settings.Config{ExchangeRatesProxy: "none"})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(refreshedResponses))
+	assert.Equal(t, "Primary", refreshedResponses[0].DataSource)
+	assert.Equal(t, "EUR", refreshedResponses[0].BaseCurrency)
+	assert.Equal(t, 2, len(refreshedResponses[0].ExchangeRates))
+	assert.Equal(t, "EUR", refreshedResponses[0].ExchangeRates[0].Currency)
+	assert.Equal(t, "USD", refreshedResponses[0].ExchangeRates[1].Currency)
+	assert.Equal(t, "2", refreshedResponses[0].ExchangeRates[1].Rate)
+
+	container.allDataSources[0] = newTestPrimaryExchangeRatesDataSource(server.URL + "/failed")
+
+	actualResponse, err := container.RefreshLatestExchangeRates(core.NewNullContext(), 0, &settings.Config{ExchangeRatesProxy: "none"})
+	assert.Nil(t, err)
+	assert.Equal(t, "Secondary", actualResponse.DataSource)
+	assert.Equal(t, 1, len(refreshedResponses))
+}
@@ -0,0 +1,221 @@
+package exchangerates
+
+import (
+	"bytes"
+	"encoding/csv"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+	"github.com/mayswind/ezbookkeeping/pkg/validators"
+)
+
+const federalReserveExchangeRateUrl = "https://www.federalreserve.gov/datadownload/Output.aspx?rel=H10&series=60f32914ab61dfab590e0e470153e3ae&lastobs=10&from=&to=&filetype=csv&label=include&layout=seriescolumn"
+const federalReserveExchangeRateReferenceUrl = "https://www.federalreserve.gov/releases/h10/current/"
+const federalReserveDataSource = "Federal Reserve"
+const federalReserveBaseCurrency = "USD"
+
+const federalReserveDataUpdateDateFormat = "2006-01-02 15:04"
+const federalReserveDataUpdateDateTimezone = "America/New_York"
+
+const federalReserveSeriesCodeLineTitle = "Time Period"
+const federalReserveNoDataValue = "ND"
+
+var federalReserveSeriesCodeCurrencyMap map[string]*federalReserveSeriesCurrency
+
+// FederalReserveDataSource defines the structure of exchange rates data source of the federal reserve
+type FederalReserveDataSource struct {
+	ExchangeRatesDataSource
+}
+
+// federalReserveSeriesCurrency represents the currency of a series in the H.10 release,
+// most series are quoted in currency units per U.S. dollar, the others are quoted in U.S. dollars per currency unit
+type federalReserveSeriesCurrency struct {
+	currencyCode        string
+	usDollarPerCurrency bool
+}
+
+func init() {
+	federalReserveSeriesCodeCurrencyMap = make(map[string]*federalReserveSeriesCurrency, 22)
+	federalReserveSeriesCodeCurrencyMap["RXI$US_N.B.AL"] = &federalReserveSeriesCurrency{currencyCode: "AUD", usDollarPerCurrency: true}
+	federalReserveSeriesCodeCurrencyMap["RXI_N.B.BZ"] = &federalReserveSeriesCurrency{currencyCode: "BRL"}
+	federalReserveSeriesCodeCurrencyMap["RXI_N.B.CA"] = &federalReserveSeriesCurrency{currencyCode: "CAD"}
+	federalReserveSeriesCodeCurrencyMap["RXI_N.B.CH"] = &federalReserveSeriesCurrency{currencyCode: "CNY"}
+	federalReserveSeriesCodeCurrencyMap["RXI_N.B.DN"] = &federalReserveSeriesCurrency{currencyCode: "DKK"}
+	federalReserveSeriesCodeCurrencyMap["RXI$US_N.B.EU"] = &federalReserveSeriesCurrency{currencyCode: "EUR", usDollarPerCurrency: true}
+	federalReserveSeriesCodeCurrencyMap["RXI_N.B.HK"] = &federalReserveSeriesCurrency{currencyCode: "HKD"}
+	federalReserveSeriesCodeCurrencyMap["RXI_N.B.IN"] = &federalReserveSeriesCurrency{currencyCode: "INR"}
+	federalReserveSeriesCodeCurrencyMap["RXI_N.B.JA"] = &federalReserveSeriesCurrency{currencyCode: "JPY"}
+	federalReserveSeriesCodeCurrencyMap["RXI_N.B.KO"] = &federalReserveSeriesCurrency{currencyCode: "KRW"}
+	federalReserveSeriesCodeCurrencyMap["RXI_N.B.MA"] = &federalReserveSeriesCurrency{currencyCode: "MYR"}
+	federalReserveSeriesCodeCurrencyMap["RXI_N.B.MX"] = &federalReserveSeriesCurrency{currencyCode: "MXN"}
+	federalReserveSeriesCodeCurrencyMap["RXI$US_N.B.NZ"] = &federalReserveSeriesCurrency{currencyCode: "NZD", usDollarPerCurrency: true}
+	federalReserveSeriesCodeCurrencyMap["RXI_N.B.NO"] = &federalReserveSeriesCurrency{currencyCode: "NOK"}
+	federalReserveSeriesCodeCurrencyMap["RXI_N.B.SI"] = &federalReserveSeriesCurrency{currencyCode: "SGD"}
+	federalReserveSeriesCodeCurrencyMap["RXI_N.B.SF"] = &federalReserveSeriesCurrency{currencyCode: "ZAR"}
+	federalReserveSeriesCodeCurrencyMap["RXI_N.B.SL"] = &federalReserveSeriesCurrency{currencyCode: "LKR"}
+	federalReserveSeriesCodeCurrencyMap["RXI_N.B.SD"] = &federalReserveSeriesCurrency{currencyCode: "SEK"}
+	federalReserveSeriesCodeCurrencyMap["RXI_N.B.SZ"] = &federalReserveSeriesCurrency{currencyCode: "CHF"}
+	federalReserveSeriesCodeCurrencyMap["RXI_N.B.TA"] = &federalReserveSeriesCurrency{currencyCode: "TWD"}
+	federalReserveSeriesCodeCurrencyMap["RXI_N.B.TH"] = &federalReserveSeriesCurrency{currencyCode: "THB"}
+	federalReserveSeriesCodeCurrencyMap["RXI$US_N.B.UK"] = &federalReserveSeriesCurrency{currencyCode: "GBP", usDollarPerCurrency: true}
+}
+
+// BuildRequests returns the federal reserve exchange rates http requests
+func (e *FederalReserveDataSource) BuildRequests() ([]*http.Request, error) {
+	req, err := http.NewRequest("GET", federalReserveExchangeRateUrl, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return []*http.Request{req}, nil
+}
+
+// Parse returns the common response entity according to the federal reserve data source raw response
+func (e *FederalReserveDataSource) Parse(c core.Context, content []byte) (*models.LatestExchangeRateResponse, error) {
+	reader := csv.NewReader(bytes.NewReader(content))
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	allLines, err := reader.ReadAll()
+
+	if err != nil {
+		log.Errorf(c, "[federal_reserve_datasource.Parse] failed to parse csv data, content is %s, because %s", string(content), err.Error())
+		return nil, errs.ErrFailedToRequestRemoteApi
+	}
+
+	var seriesCodeLineItems []string
+	latestUpdateDate := ""
+	exchangeRateMap := make(map[string]string)
+
+	for i := 0; i < len(allLines); i++ {
+		items := allLines[i]
+
+		if len(items) < 2 {
+			continue
+		}
+
+		if seriesCodeLineItems == nil {
+			if strings.TrimSpace(items[0]) == federalReserveSeriesCodeLineTitle {
+				seriesCodeLineItems = items
+			}
+
+			continue
+		}
+
+		updateDate := strings.TrimSpace(items[0])
+
+		if _, err := time.Parse(time.DateOnly, updateDate); err != nil {
+			log.Warnf(c, "[federal_reserve_datasource.Parse] failed to parse date, date is %s", updateDate)
+			continue
+		}
+
+		if latestUpdateDate != "" && strings.Compare(updateDate, latestUpdateDate) < 0 {
+			continue
+		}
+
+		latestUpdateDate = updateDate
+
+		// the latest value of each series is kept, because some series may have no data on the latest date
+		for j := 1; j < len(items) && j < len(seriesCodeLineItems); j++ {
+			value := strings.TrimSpace(items[j])
+
+			if value == "" || value == federalReserveNoDataValue {
+				continue
+			}
+
+			exchangeRateMap[strings.TrimSpace(seriesCodeLineItems[j])] = value
+		}
+	}
+
+	if seriesCodeLineItems == nil {
+		log.Errorf(c, "[federal_reserve_datasource.Parse] missing series code line, content is %s", string(content))
+		return nil, errs.ErrFailedToRequestRemoteApi
+	}
+
+	if latestUpdateDate == "" {
+		log.Errorf(c, "[federal_reserve_datasource.Parse] there is no valid data line, content is %s", string(content))
+		return nil, errs.ErrFailedToRequestRemoteApi
+	}
+
+	exchangeRates := make(models.LatestExchangeRateSlice, 0, len(exchangeRateMap))
+
+	for seriesCode, value := range exchangeRateMap {
+		exchangeRate := e.parseExchangeRate(c, seriesCode, value)
+
+		if exchangeRate != nil {
+			exchangeRates = append(exchangeRates, exchangeRate)
+		}
+	}
+
+	timezone, err := time.LoadLocation(federalReserveDataUpdateDateTimezone)
+
+	if err != nil {
+		log.Errorf(c, "[federal_reserve_datasource.Parse] failed to get timezone, timezone name is %s", federalReserveDataUpdateDateTimezone)
+		return nil, errs.ErrFailedToRequestRemoteApi
+	}
+
+	updateDateTime := latestUpdateDate + " 12:00" // Noon buying rates in New York for cable transfers payable in foreign currencies
+	updateTime, err := time.ParseInLocation(federalReserveDataUpdateDateFormat, updateDateTime, timezone)
+
+	if err != nil {
+		log.Errorf(c, "[federal_reserve_datasource.Parse] failed to parse update date, datetime is %s", updateDateTime)
+		return nil, errs.ErrFailedToRequestRemoteApi
+	}
+
+	latestExchangeRateResp := &models.LatestExchangeRateResponse{
+		DataSource:    federalReserveDataSource,
+		ReferenceUrl:  federalReserveExchangeRateReferenceUrl,
+		UpdateTime:    updateTime.Unix(),
+		BaseCurrency:  federalReserveBaseCurrency,
+		ExchangeRates: exchangeRates,
+	}
+
+	return latestExchangeRateResp, nil
+}
+
+func (e *FederalReserveDataSource) parseExchangeRate(c core.Context, seriesCode string, value string) *models.LatestExchangeRate {
+	seriesCurrency, exists := federalReserveSeriesCodeCurrencyMap[seriesCode]
+
+	if !exists {
+		return nil
+	}
+
+	if _, exists := validators.AllCurrencyNames[seriesCurrency.currencyCode]; !exists {
+		return nil
+	}
+
+	rate, err := utils.StringToFloat64(value)
+
+	if err != nil {
+		log.Warnf(c, "[federal_reserve_datasource.parseExchangeRate] failed to parse rate, currency is %s, rate is %s", seriesCurrency.currencyCode, value)
+		return nil
+	}
+
+	if rate <= 0 {
+		log.Warnf(c, "[federal_reserve_datasource.parseExchangeRate] rate is invalid, currency is %s, rate is %s", seriesCurrency.currencyCode, value)
+		return nil
+	}
+
+	finalRate := rate
+
+	if seriesCurrency.usDollarPerCurrency {
+		finalRate = 1 / rate
+	}
+
+	if math.IsInf(finalRate, 0) {
+		return nil
+	}
+
+	return &models.LatestExchangeRate{
+		Currency: seriesCurrency.currencyCode,
+		Rate:     utils.Float64ToString(finalRate),
+	}
+}
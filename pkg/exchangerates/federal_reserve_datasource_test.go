@@ -0,0 +1,116 @@
+package exchangerates
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+)
+
+const federalReserveMinimumRequiredContent = "\"Series Description\",\"Canada -- Spot Exchange Rate, Canadian $/US$\",\"Euro Area -- Spot Exchange Rate US$/Euro\",\"Japan -- Spot Exchange Rate, Yen/US$\",\"United Kingdom -- Spot Exchange Rate, US$/Pound (1/RXI_N.B.UK)\"\n" +
+	"\"Unit:\",\"Currency:_Per_USD\",\"USD:_Per_EUR\",\"Currency:_Per_USD\",\"USD:_Per_GBP\"\n" +
+	"\"Multiplier:\",\"1\",\"1\",\"1\",\"1\"\n" +
+	"\"Currency:\",\"CAD\",\"USD\",\"JPY\",\"USD\"\n" +
+	"\"Unique Identifier: \",\"H10/H10/RXI_N.B.CA\",\"H10/H10/RXI$US_N.B.EU\",\"H10/H10/RXI_N.B.JA\",\"H10/H10/RXI$US_N.B.UK\"\n" +
+	"\"Time Period\",\"RXI_N.B.CA\",\"RXI$US_N.B.EU\",\"RXI_N.B.JA\",\"RXI$US_N.B.UK\"\n" +
+	"2021-03-31,1.2575,1.1725,110.6700,1.3780\n" +
+	"2021-04-01,1.2565,1.1780,110.5400,ND\n"
+
+func TestFederalReserveDataSource_StandardDataExtractBaseCurrency(t *testing.T) {
+	dataSource := &FederalReserveDataSource{}
+	context := core.NewNullContext()
+
+	actualLatestExchangeRateResponse, err := dataSource.Parse(context, []byte(federalReserveMinimumRequiredContent))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "USD", actualLatestExchangeRateResponse.BaseCurrency)
+}
+
+func TestFederalReserveDataSource_StandardDataExtractUpdateTime(t *testing.T) {
+	dataSource := &FederalReserveDataSource{}
+	context := core.NewNullContext()
+
+	actualLatestExchangeRateResponse, err := dataSource.Parse(context, []byte(federalReserveMinimumRequiredContent))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, int64(1617292800), actualLatestExchangeRateResponse.UpdateTime)
+}
+
+func TestFederalReserveDataSource_StandardDataExtractExchangeRates(t *testing.T) {
+	dataSource := &FederalReserveDataSource{}
+	context := core.NewNullContext()
+
+	actualLatestExchangeRateResponse, err := dataSource.Parse(context, []byte(federalReserveMinimumRequiredContent))
+	assert.Equal(t, nil, err)
+	assert.Len(t, actualLatestExchangeRateResponse.ExchangeRates, 4)
+	assert.Contains(t, actualLatestExchangeRateResponse.ExchangeRates, &models.LatestExchangeRate{
+		Currency: "CAD",
+		Rate:     "1.2565",
+	})
+	assert.Contains(t, actualLatestExchangeRateResponse.ExchangeRates, &models.LatestExchangeRate{
+		Currency: "EUR",
+		Rate:     "0.8488964346349746",
+	})
+	assert.Contains(t, actualLatestExchangeRateResponse.ExchangeRates, &models.LatestExchangeRate{
+		Currency: "JPY",
+		Rate:     "110.54",
+	})
+	assert.Contains(t, actualLatestExchangeRateResponse.ExchangeRates, &models.LatestExchangeRate{
+		Currency: "GBP",
+		Rate:     "0.725689404934688",
+	})
+}
+
+func TestFederalReserveDataSource_BlankContent(t *testing.T) {
+	dataSource := &FederalReserveDataSource{}
+	context := core.NewNullContext()
+
+	_, err := dataSource.Parse(context, []byte(""))
+	assert.NotEqual(t, nil, err)
+}
+
+func TestFederalReserveDataSource_MissingSeriesCodeLine(t *testing.T) {
+	dataSource := &FederalReserveDataSource{}
+	context := core.NewNullContext()
+
+	_, err := dataSource.Parse(context, []byte("\"Series Description\",\"Canada -- Spot Exchange Rate, Canadian $/US$\"\n"+
+		"2021-04-01,1.2565\n"))
+	assert.NotEqual(t, nil, err)
+}
+
+func TestFederalReserveDataSource_OnlySeriesCodeLine(t *testing.T) {
+	dataSource := &FederalReserveDataSource{}
+	context := core.NewNullContext()
+
+	_, err := dataSource.Parse(context, []byte("\"Time Period\",\"RXI_N.B.CA\",\"RXI$US_N.B.EU\"\n"))
+	assert.NotEqual(t, nil, err)
+}
+
+func TestFederalReserveDataSource_InvalidDate(t *testing.T) {
+	dataSource := &FederalReserveDataSource{}
+	context := core.NewNullContext()
+
+	_, err := dataSource.Parse(context, []byte("\"Time Period\",\"RXI_N.B.CA\",\"RXI$US_N.B.EU\"\n"+
+		"04/01/2021,1.2565,1.1780\n"))
+	assert.NotEqual(t, nil, err)
+}
+
+func TestFederalReserveDataSource_InvalidSeriesCode(t *testing.T) {
+	dataSource := &FederalReserveDataSource{}
+	context := core.NewNullContext()
+
+	actualLatestExchangeRateResponse, err := dataSource.Parse(context, []byte("\"Time Period\",\"RXI_N.B.XX\",\"RXI_N.B.EU\"\n"+
+		"2021-04-01,1.2565,1.1780\n"))
+	assert.Equal(t, nil, err)
+	assert.Len(t, actualLatestExchangeRateResponse.ExchangeRates, 0)
+}
+
+func TestFederalReserveDataSource_InvalidRate(t *testing.T) {
+	dataSource := &FederalReserveDataSource{}
+	context := core.NewNullContext()
+
+	actualLatestExchangeRateResponse, err := dataSource.Parse(context, []byte("\"Time Period\",\"RXI_N.B.CA\",\"RXI$US_N.B.EU\",\"RXI_N.B.JA\"\n"+
+		"2021-04-01,null,0,ND\n"))
+	assert.Equal(t, nil, err)
+	assert.Len(t, actualLatestExchangeRateResponse.ExchangeRates, 0)
+}
@@ -3,8 +3,10 @@ package exchangerates
 import (
 	"bytes"
 	"encoding/xml"
+	"fmt"
 	"math"
 	"net/http"
+	"sort"
 	"time"
 
 	"golang.org/x/net/html/charset"
@@ -18,12 +20,14 @@ import (
 )
 
 const norgesBankExchangeRateUrl = "https://data.norges-bank.no/api/data/EXR/B..NOK.SP?format=sdmx-compact-2.1&lastNObservations=1"
+const norgesBankHistoricalExchangeRateUrl = "https://data.norges-bank.no/api/data/EXR/B..NOK.SP?format=sdmx-compact-2.1&startPeriod=%s&endPeriod=%s"
 const norgesBankExchangeRateReferenceUrl = "https://www.norges-bank.no/en/topics/Statistics/exchange_rates/"
 const norgesBankDataSource = "Norges Bank"
 const norgesBankBaseCurrency = "NOK"
 
 const norgesBankUpdateDateFormat = "2006-01-02 15"
 const norgesBankUpdateDateTimezone = "Europe/Oslo"
+const norgesBankHistoricalRequestDateFormat = "2006-01-02"
 
 // NorgesBankDataSource defines the structure of exchange rates data source of Norges Bank
 type NorgesBankDataSource struct {
@@ -119,6 +123,55 @@ func (e *NorgesBankExchangeRateData) ToLatestExchangeRateResponse(c core.Context
 	return latestExchangeRateResp
 }
 
+// ToHistoricalExchangeRateResponses returns the view-objects of every day according to original data from Norges Bank
+func (e *NorgesBankExchangeRateData) ToHistoricalExchangeRateResponses(c core.Context) []*models.LatestExchangeRateResponse {
+	if e.DataSet == nil || len(e.DataSet.ExchangeRates) < 1 {
+		log.Errorf(c, "[norges_bank_datasource.ToHistoricalExchangeRateResponses] all exchange rates is empty")
+		return nil
+	}
+
+	dailyExchangeRatesMap := make(map[string][]*NorgesBankExchangeRate)
+
+	for i := 0; i < len(e.DataSet.ExchangeRates); i++ {
+		exchangeRate := e.DataSet.ExchangeRates[i]
+
+		for j := 0; j < len(exchangeRate.Observations); j++ {
+			observation := exchangeRate.Observations[j]
+
+			dailyExchangeRatesMap[observation.Date] = append(dailyExchangeRatesMap[observation.Date], &NorgesBankExchangeRate{
+				BaseCurrency:   exchangeRate.BaseCurrency,
+				TargetCurrency: exchangeRate.TargetCurrency,
+				UnitExponent:   exchangeRate.UnitExponent,
+				Observations:   []*NorgesBankExchangeRateObservation{observation},
+			})
+		}
+	}
+
+	exchangeRateResps := make([]*models.LatestExchangeRateResponse, 0, len(dailyExchangeRatesMap))
+
+	for _, dailyExchangeRates := range dailyExchangeRatesMap {
+		dailyData := &NorgesBankExchangeRateData{
+			DataSet: &NorgesBankExchangeRateDataSet{
+				ExchangeRates: dailyExchangeRates,
+			},
+		}
+
+		exchangeRateResp := dailyData.ToLatestExchangeRateResponse(c)
+
+		if exchangeRateResp == nil || len(exchangeRateResp.ExchangeRates) < 1 {
+			continue
+		}
+
+		exchangeRateResps = append(exchangeRateResps, exchangeRateResp)
+	}
+
+	sort.Slice(exchangeRateResps, func(i, j int) bool {
+		return exchangeRateResps[i].UpdateTime < exchangeRateResps[j].UpdateTime
+	})
+
+	return exchangeRateResps
+}
+
 // ToLatestExchangeRate returns a data pair according to original data from Norges Bank
 func (e *NorgesBankExchangeRate) ToLatestExchangeRate(c core.Context, exchangeRate string) *models.LatestExchangeRate {
 	rate, err := utils.StringToFloat64(exchangeRate)
@@ -170,17 +223,24 @@ func (e *NorgesBankDataSource) BuildRequests() ([]*http.Request, error) {
 	return []*http.Request{req}, nil
 }
 
+// BuildHistoricalRequests returns the Norges Bank historical exchange rates http requests
+func (e *NorgesBankDataSource) BuildHistoricalRequests(startDate time.Time, endDate time.Time) ([]*http.Request, error) {
+	url := fmt.Sprintf(norgesBankHistoricalExchangeRateUrl, startDate.Format(norgesBankHistoricalRequestDateFormat), endDate.Format(norgesBankHistoricalRequestDateFormat))
+	req, err := http.NewRequest("GET", url, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return []*http.Request{req}, nil
+}
+
 // Parse returns the common response entity according to the Norges Bank data source raw response
 func (e *NorgesBankDataSource) Parse(c core.Context, content []byte) (*models.LatestExchangeRateResponse, error) {
-	xmlDecoder := xml.NewDecoder(bytes.NewReader(content))
-	xmlDecoder.CharsetReader = charset.NewReaderLabel
-
-	norgesBankData := &NorgesBankExchangeRateData{}
-	err := xmlDecoder.Decode(norgesBankData)
+	norgesBankData, err := e.parseData(c, content)
 
 	if err != nil {
-		log.Errorf(c, "[norges_bank_datasource.Parse] failed to parse xml data, content is %s, because %s", string(content), err.Error())
-		return nil, errs.ErrFailedToRequestRemoteApi
+		return nil, err
 	}
 
 	latestExchangeRateResponse := norgesBankData.ToLatestExchangeRateResponse(c)
@@ -192,3 +252,29 @@ func (e *NorgesBankDataSource) Parse(c core.Context, content []byte) (*models.La
 
 	return latestExchangeRateResponse, nil
 }
+
+// ParseHistorical returns the common response entities of every day according to the Norges Bank data source raw response
+func (e *NorgesBankDataSource) ParseHistorical(c core.Context, content []byte) ([]*models.LatestExchangeRateResponse, error) {
+	norgesBankData, err := e.parseData(c, content)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return norgesBankData.ToHistoricalExchangeRateResponses(c), nil
+}
+
+func (e *NorgesBankDataSource) parseData(c core.Context, content []byte) (*NorgesBankExchangeRateData, error) {
+	xmlDecoder := xml.NewDecoder(bytes.NewReader(content))
+	xmlDecoder.CharsetReader = charset.NewReaderLabel
+
+	norgesBankData := &NorgesBankExchangeRateData{}
+	err := xmlDecoder.Decode(norgesBankData)
+
+	if err != nil {
+		log.Errorf(c, "[norges_bank_datasource.parseData] failed to parse xml data, content is %s, because %s", string(content), err.Error())
+		return nil, errs.ErrFailedToRequestRemoteApi
+	}
+
+	return norgesBankData, nil
+}
@@ -0,0 +1,185 @@
+package exchangerates
+
+import (
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+	"github.com/mayswind/ezbookkeeping/pkg/validators"
+)
+
+const reserveBankOfIndiaExchangeRateUrl = "https://www.rbi.org.in/scripts/ReferenceRateArchive.aspx"
+const reserveBankOfIndiaExchangeRateReferenceUrl = "https://www.rbi.org.in/scripts/ReferenceRateArchive.aspx"
+const reserveBankOfIndiaDataSource = "Reserve Bank of India"
+const reserveBankOfIndiaBaseCurrency = "INR"
+
+const reserveBankOfIndiaDataDateFormat = "02/01/2006"
+const reserveBankOfIndiaDataUpdateDateFormat = "02/01/2006 15:04"
+const reserveBankOfIndiaDataUpdateDateTimezone = "Asia/Kolkata"
+
+const reserveBankOfIndiaDateColumnTitle = "Date"
+
+var reserveBankOfIndiaColumnTitleCurrencyCodeMap map[string]string
+var reserveBankOfIndiaCurrencyUnitMap map[string]float64
+
+// ReserveBankOfIndiaDataSource defines the structure of exchange rates data source of the reserve bank of India
+type ReserveBankOfIndiaDataSource struct {
+	ExchangeRatesDataSource
+}
+
+func init() {
+	reserveBankOfIndiaColumnTitleCurrencyCodeMap = make(map[string]string, 4)
+	reserveBankOfIndiaColumnTitleCurrencyCodeMap["US Dollar"] = "USD"
+	reserveBankOfIndiaColumnTitleCurrencyCodeMap["Pound Sterling"] = "GBP"
+	reserveBankOfIndiaColumnTitleCurrencyCodeMap["Euro"] = "EUR"
+	reserveBankOfIndiaColumnTitleCurrencyCodeMap["Japanese Yen"] = "JPY"
+
+	reserveBankOfIndiaCurrencyUnitMap = make(map[string]float64, 1)
+	reserveBankOfIndiaCurrencyUnitMap["JPY"] = 100 // Reference rate of Japanese Yen is quoted per 100 Yen
+}
+
+// BuildRequests returns the reserve bank of India exchange rates http requests
+func (e *ReserveBankOfIndiaDataSource) BuildRequests() ([]*http.Request, error) {
+	req, err := http.NewRequest("GET", reserveBankOfIndiaExchangeRateUrl, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return []*http.Request{req}, nil
+}
+
+// Parse returns the common response entity according to the reserve bank of India data source raw response
+func (e *ReserveBankOfIndiaDataSource) Parse(c core.Context, content []byte) (*models.LatestExchangeRateResponse, error) {
+	tables, err := utils.ParseHtmlTables(content)
+
+	if err != nil {
+		log.Errorf(c, "[reserve_bank_of_india_datasource.Parse] failed to parse html data, content is %s, because %s", string(content), err.Error())
+		return nil, errs.ErrFailedToRequestRemoteApi
+	}
+
+	for i := 0; i < len(tables); i++ {
+		table := tables[i]
+
+		if len(table.Rows) < 1 || len(table.Rows[0]) < 2 || table.Rows[0][0] != reserveBankOfIndiaDateColumnTitle {
+			continue
+		}
+
+		titleItems := table.Rows[0]
+		var latestUpdateDate time.Time
+		var latestRowItems []string
+
+		for j := 1; j < len(table.Rows); j++ {
+			items := table.Rows[j]
+
+			if len(items) < 1 {
+				continue
+			}
+
+			updateDate, err := time.Parse(reserveBankOfIndiaDataDateFormat, items[0])
+
+			if err != nil {
+				log.Warnf(c, "[reserve_bank_of_india_datasource.Parse] failed to parse date, date is %s", items[0])
+				continue
+			}
+
+			if latestRowItems == nil || updateDate.After(latestUpdateDate) {
+				latestUpdateDate = updateDate
+				latestRowItems = items
+			}
+		}
+
+		if latestRowItems == nil {
+			log.Errorf(c, "[reserve_bank_of_india_datasource.Parse] there is no valid data row, content is %s", string(content))
+			return nil, errs.ErrFailedToRequestRemoteApi
+		}
+
+		exchangeRates := make(models.LatestExchangeRateSlice, 0, len(titleItems)-1)
+
+		for j := 1; j < len(titleItems) && j < len(latestRowItems); j++ {
+			exchangeRate := e.parseExchangeRate(c, titleItems[j], latestRowItems[j])
+
+			if exchangeRate != nil {
+				exchangeRates = append(exchangeRates, exchangeRate)
+			}
+		}
+
+		timezone, err := time.LoadLocation(reserveBankOfIndiaDataUpdateDateTimezone)
+
+		if err != nil {
+			log.Errorf(c, "[reserve_bank_of_india_datasource.Parse] failed to get timezone, timezone name is %s", reserveBankOfIndiaDataUpdateDateTimezone)
+			return nil, errs.ErrFailedToRequestRemoteApi
+		}
+
+		updateDateTime := latestRowItems[0] + " 13:30" // Reference rates are published at 13:30 IST every working day
+		updateTime, err := time.ParseInLocation(reserveBankOfIndiaDataUpdateDateFormat, updateDateTime, timezone)
+
+		if err != nil {
+			log.Errorf(c, "[reserve_bank_of_india_datasource.Parse] failed to parse update date, datetime is %s", updateDateTime)
+			return nil, errs.ErrFailedToRequestRemoteApi
+		}
+
+		latestExchangeRateResp := &models.LatestExchangeRateResponse{
+			DataSource:    reserveBankOfIndiaDataSource,
+			ReferenceUrl:  reserveBankOfIndiaExchangeRateReferenceUrl,
+			UpdateTime:    updateTime.Unix(),
+			BaseCurrency:  reserveBankOfIndiaBaseCurrency,
+			ExchangeRates: exchangeRates,
+		}
+
+		return latestExchangeRateResp, nil
+	}
+
+	log.Errorf(c, "[reserve_bank_of_india_datasource.Parse] there is no reference rates table, content is %s", string(content))
+	return nil, errs.ErrFailedToRequestRemoteApi
+}
+
+func (e *ReserveBankOfIndiaDataSource) parseExchangeRate(c core.Context, columnTitle string, value string) *models.LatestExchangeRate {
+	currencyCode, exists := reserveBankOfIndiaColumnTitleCurrencyCodeMap[columnTitle]
+
+	if !exists {
+		return nil
+	}
+
+	if _, exists := validators.AllCurrencyNames[currencyCode]; !exists {
+		return nil
+	}
+
+	if value == "" {
+		return nil
+	}
+
+	rate, err := utils.StringToFloat64(value)
+
+	if err != nil {
+		log.Warnf(c, "[reserve_bank_of_india_datasource.parseExchangeRate] failed to parse rate, currency is %s, rate is %s", currencyCode, value)
+		return nil
+	}
+
+	if rate <= 0 {
+		log.Warnf(c, "[reserve_bank_of_india_datasource.parseExchangeRate] rate is invalid, currency is %s, rate is %s", currencyCode, value)
+		return nil
+	}
+
+	unit, exists := reserveBankOfIndiaCurrencyUnitMap[currencyCode]
+
+	if !exists {
+		unit = 1
+	}
+
+	finalRate := unit / rate
+
+	if math.IsInf(finalRate, 0) {
+		return nil
+	}
+
+	return &models.LatestExchangeRate{
+		Currency: currencyCode,
+		Rate:     utils.Float64ToString(finalRate),
+	}
+}
@@ -0,0 +1,129 @@
+package exchangerates
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+)
+
+const reserveBankOfIndiaMinimumRequiredContent = "<!DOCTYPE html>\n" +
+	"<html>\n" +
+	"<body>\n" +
+	"  <table>\n" +
+	"    <tr><th>Date</th><th>US Dollar</th><th>Pound Sterling</th><th>Euro</th><th>Japanese Yen</th></tr>\n" +
+	"    <tr><td>31/03/2021</td><td>73.5047</td><td>100.9555</td><td>86.0990</td><td>66.3600</td></tr>\n" +
+	"    <tr><td>01/04/2021</td><td>73.1000</td><td>100.7950</td><td>85.8340</td><td>66.0900</td></tr>\n" +
+	"  </table>\n" +
+	"</body>\n" +
+	"</html>"
+
+func TestReserveBankOfIndiaDataSource_StandardDataExtractBaseCurrency(t *testing.T) {
+	dataSource := &ReserveBankOfIndiaDataSource{}
+	context := core.NewNullContext()
+
+	actualLatestExchangeRateResponse, err := dataSource.Parse(context, []byte(reserveBankOfIndiaMinimumRequiredContent))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "INR", actualLatestExchangeRateResponse.BaseCurrency)
+}
+
+func TestReserveBankOfIndiaDataSource_StandardDataExtractUpdateTime(t *testing.T) {
+	dataSource := &ReserveBankOfIndiaDataSource{}
+	context := core.NewNullContext()
+
+	actualLatestExchangeRateResponse, err := dataSource.Parse(context, []byte(reserveBankOfIndiaMinimumRequiredContent))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, int64(1617264000), actualLatestExchangeRateResponse.UpdateTime)
+}
+
+func TestReserveBankOfIndiaDataSource_StandardDataExtractExchangeRates(t *testing.T) {
+	dataSource := &ReserveBankOfIndiaDataSource{}
+	context := core.NewNullContext()
+
+	actualLatestExchangeRateResponse, err := dataSource.Parse(context, []byte(reserveBankOfIndiaMinimumRequiredContent))
+	assert.Equal(t, nil, err)
+	assert.Len(t, actualLatestExchangeRateResponse.ExchangeRates, 4)
+	assert.Contains(t, actualLatestExchangeRateResponse.ExchangeRates, &models.LatestExchangeRate{
+		Currency: "USD",
+		Rate:     "0.013679890560875515",
+	})
+	assert.Contains(t, actualLatestExchangeRateResponse.ExchangeRates, &models.LatestExchangeRate{
+		Currency: "JPY",
+		Rate:     "1.5130882130428203",
+	})
+}
+
+func TestReserveBankOfIndiaDataSource_BlankContent(t *testing.T) {
+	dataSource := &ReserveBankOfIndiaDataSource{}
+	context := core.NewNullContext()
+
+	_, err := dataSource.Parse(context, []byte(""))
+	assert.NotEqual(t, nil, err)
+}
+
+func TestReserveBankOfIndiaDataSource_NoTable(t *testing.T) {
+	dataSource := &ReserveBankOfIndiaDataSource{}
+	context := core.NewNullContext()
+
+	_, err := dataSource.Parse(context, []byte("<html><body><p>Reference Rate Archive</p></body></html>"))
+	assert.NotEqual(t, nil, err)
+}
+
+func TestReserveBankOfIndiaDataSource_OnlyTitleRow(t *testing.T) {
+	dataSource := &ReserveBankOfIndiaDataSource{}
+	context := core.NewNullContext()
+
+	_, err := dataSource.Parse(context, []byte("<table>"+
+		"<tr><th>Date</th><th>US Dollar</th><th>Pound Sterling</th><th>Euro</th><th>Japanese Yen</th></tr>"+
+		"</table>"))
+	assert.NotEqual(t, nil, err)
+}
+
+func TestReserveBankOfIndiaDataSource_InvalidDate(t *testing.T) {
+	dataSource := &ReserveBankOfIndiaDataSource{}
+	context := core.NewNullContext()
+
+	_, err := dataSource.Parse(context, []byte("<table>"+
+		"<tr><th>Date</th><th>US Dollar</th></tr>"+
+		"<tr><td>2021-04-01</td><td>73.1000</td></tr>"+
+		"</table>"))
+	assert.NotEqual(t, nil, err)
+}
+
+func TestReserveBankOfIndiaDataSource_InvalidCurrency(t *testing.T) {
+	dataSource := &ReserveBankOfIndiaDataSource{}
+	context := core.NewNullContext()
+
+	actualLatestExchangeRateResponse, err := dataSource.Parse(context, []byte("<table>"+
+		"<tr><th>Date</th><th>Unknown</th></tr>"+
+		"<tr><td>01/04/2021</td><td>73.1000</td></tr>"+
+		"</table>"))
+	assert.Equal(t, nil, err)
+	assert.Len(t, actualLatestExchangeRateResponse.ExchangeRates, 0)
+}
+
+func TestReserveBankOfIndiaDataSource_EmptyRate(t *testing.T) {
+	dataSource := &ReserveBankOfIndiaDataSource{}
+	context := core.NewNullContext()
+
+	actualLatestExchangeRateResponse, err := dataSource.Parse(context, []byte("<table>"+
+		"<tr><th>Date</th><th>US Dollar</th><th>Euro</th></tr>"+
+		"<tr><td>01/04/2021</td><td></td><td>85.8340</td></tr>"+
+		"</table>"))
+	assert.Equal(t, nil, err)
+	assert.Len(t, actualLatestExchangeRateResponse.ExchangeRates, 1)
+}
+
+func TestReserveBankOfIndiaDataSource_InvalidRate(t *testing.T) {
+	dataSource := &ReserveBankOfIndiaDataSource{}
+	context := core.NewNullContext()
+
+	actualLatestExchangeRateResponse, err := dataSource.Parse(context, []byte("<table>"+
+		"<tr><th>Date</th><th>US Dollar</th><th>Euro</th></tr>"+
+		"<tr><td>01/04/2021</td><td>null</td><td>0</td></tr>"+
+		"</table>"))
+	assert.Equal(t, nil, err)
+	assert.Len(t, actualLatestExchangeRateResponse.ExchangeRates, 0)
+}
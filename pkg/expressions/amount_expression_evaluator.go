@@ -0,0 +1,261 @@
+package expressions
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
+)
+
+var operatorPriority = map[rune]int{
+	'+': 1,
+	'-': 1,
+	'*': 2,
+	'/': 2,
+}
+
+// EvaluateAmountExpression returns the result of the amount expression, the variables in the expression are replaced by the specified values
+func EvaluateAmountExpression(ctx core.Context, expr string, variables map[string]float64) (float64, error) {
+	postfixExprTokens, err := ToPostfixExprTokens(ctx, expr)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return EvaluatePostfixExpr(ctx, postfixExprTokens, variables)
+}
+
+// GetAmountExpressionVariableNames returns all the distinct variable names in the amount expression
+func GetAmountExpressionVariableNames(ctx core.Context, expr string) ([]string, error) {
+	postfixExprTokens, err := ToPostfixExprTokens(ctx, expr)
+
+	if err != nil {
+		return nil, err
+	}
+
+	variableNames := make([]string, 0)
+	variableNameExists := make(map[string]bool)
+
+	for i := 0; i < len(postfixExprTokens); i++ {
+		token := strings.TrimPrefix(postfixExprTokens[i], "-")
+
+		if token == "" || !isVariableNameRune(rune(token[0])) {
+			continue
+		}
+
+		if !isValidVariableName(token) {
+			log.Warnf(ctx, "[amount_expression_evaluator.GetAmountExpressionVariableNames] cannot parse expression \"%s\", because containing invalid variable name \"%s\"", expr, token)
+			return nil, errs.ErrInvalidAmountExpression
+		}
+
+		if !variableNameExists[token] {
+			variableNames = append(variableNames, token)
+			variableNameExists[token] = true
+		}
+	}
+
+	return variableNames, nil
+}
+
+// ToPostfixExprTokens returns the tokens of the expression in postfix notation
+func ToPostfixExprTokens(ctx core.Context, expr string) ([]string, error) {
+	finalTokens := make([]string, 0)
+	operatorStack := make([]rune, 0)
+	currentNumberBuilder := strings.Builder{}
+	isLastTokenOperator := true
+
+	expr = strings.ReplaceAll(expr, " ", "")
+
+	for i := 0; i < len(expr); i++ {
+		ch := rune(expr[i])
+
+		// number or variable
+		if '0' <= ch && ch <= '9' || ch == '.' || isVariableNameRune(ch) {
+			currentNumberBuilder.WriteRune(ch)
+			continue
+		} else if ch == '-' && i+1 < len(expr) && ('0' <= expr[i+1] && expr[i+1] <= '9' || isVariableNameRune(rune(expr[i+1]))) && currentNumberBuilder.Len() == 0 && isLastTokenOperator {
+			currentNumberBuilder.WriteRune(ch)
+			continue
+		}
+
+		// operator or parenthesis
+		if currentNumberBuilder.Len() > 0 {
+			finalTokens = append(finalTokens, currentNumberBuilder.String())
+			currentNumberBuilder.Reset()
+			isLastTokenOperator = false
+		}
+
+		switch ch {
+		case '+', '-', '*', '/':
+			if ch == '-' && isLastTokenOperator {
+				currentNumberBuilder.WriteRune(ch)
+				continue
+			}
+
+			for len(operatorStack) > 0 {
+				topOperator := operatorStack[len(operatorStack)-1]
+
+				if topOperator == '(' {
+					break
+				}
+
+				if operatorPriority[topOperator] >= operatorPriority[ch] {
+					finalTokens = append(finalTokens, string(topOperator))
+					operatorStack = operatorStack[:len(operatorStack)-1]
+				} else {
+					break
+				}
+			}
+
+			operatorStack = append(operatorStack, ch)
+			isLastTokenOperator = true
+		case '(':
+			operatorStack = append(operatorStack, ch)
+			isLastTokenOperator = true
+		case ')':
+			hasLeftParenthesis := false
+
+			for len(operatorStack) > 0 {
+				topOperator := operatorStack[len(operatorStack)-1]
+				operatorStack = operatorStack[:len(operatorStack)-1]
+
+				if topOperator == '(' {
+					hasLeftParenthesis = true
+					break
+				}
+
+				finalTokens = append(finalTokens, string(topOperator))
+			}
+
+			if !hasLeftParenthesis {
+				log.Warnf(ctx, "[amount_expression_evaluator.ToPostfixExprTokens] cannot parse expression \"%s\", because missing left parenthesis", expr)
+				return nil, errs.ErrInvalidAmountExpression
+			}
+
+			isLastTokenOperator = false
+		default:
+			log.Warnf(ctx, "[amount_expression_evaluator.ToPostfixExprTokens] cannot parse expression \"%s\", because containing unknown token \"%c\"", expr, ch)
+			return nil, errs.ErrInvalidAmountExpression
+		}
+	}
+
+	if currentNumberBuilder.Len() > 0 {
+		finalTokens = append(finalTokens, currentNumberBuilder.String())
+	}
+
+	for len(operatorStack) > 0 {
+		topOperator := operatorStack[len(operatorStack)-1]
+		operatorStack = operatorStack[:len(operatorStack)-1]
+
+		if topOperator == '(' {
+			log.Warnf(ctx, "[amount_expression_evaluator.ToPostfixExprTokens] cannot parse expression \"%s\", because missing right parenthesis", expr)
+			return nil, errs.ErrInvalidAmountExpression
+		}
+
+		finalTokens = append(finalTokens, string(topOperator))
+	}
+
+	return finalTokens, nil
+}
+
+// EvaluatePostfixExpr returns the result of the expression in postfix notation, the variables in the expression are replaced by the specified values
+func EvaluatePostfixExpr(ctx core.Context, tokens []string, variables map[string]float64) (float64, error) {
+	stack := make([]float64, 0)
+
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+
+		switch token {
+		case "+", "-", "*", "/": // operators
+			if len(stack) < 2 {
+				log.Warnf(ctx, "[amount_expression_evaluator.EvaluatePostfixExpr] cannot evaluate expression \"%s\", because not enough operands", strings.Join(tokens, " "))
+				return 0, errs.ErrInvalidAmountExpression
+			}
+
+			// pop the top two operands
+			b := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+
+			a := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+
+			// evaluate the operation
+			var result float64
+			switch token {
+			case "+":
+				result = a + b
+			case "-":
+				result = a - b
+			case "*":
+				result = a * b
+			case "/":
+				if b == 0 {
+					log.Warnf(ctx, "[amount_expression_evaluator.EvaluatePostfixExpr] cannot evaluate expression \"%s\", because division by zero", strings.Join(tokens, " "))
+					return 0, errs.ErrInvalidAmountExpression
+				}
+				result = a / b
+			}
+
+			// push the result back to the stack
+			stack = append(stack, result)
+		default: // operands
+			num, err := evaluateOperand(token, variables)
+
+			if err != nil {
+				log.Warnf(ctx, "[amount_expression_evaluator.EvaluatePostfixExpr] cannot evaluate expression \"%s\", because containing invalid number or unknown variable \"%s\"", strings.Join(tokens, " "), token)
+				return 0, errs.ErrInvalidAmountExpression
+			}
+
+			stack = append(stack, num)
+		}
+	}
+
+	if len(stack) != 1 {
+		log.Warnf(ctx, "[amount_expression_evaluator.EvaluatePostfixExpr] cannot evaluate expression \"%s\", because missing operator", strings.Join(tokens, " "))
+		return 0, errs.ErrInvalidAmountExpression
+	}
+
+	return stack[0], nil
+}
+
+func evaluateOperand(token string, variables map[string]float64) (float64, error) {
+	variableName := strings.TrimPrefix(token, "-")
+
+	if variableName == "" || !isVariableNameRune(rune(variableName[0])) {
+		return strconv.ParseFloat(token, 64)
+	}
+
+	value, exists := variables[variableName]
+
+	if !isValidVariableName(variableName) || !exists {
+		return 0, errs.ErrInvalidAmountExpression
+	}
+
+	if len(variableName) < len(token) {
+		return -value, nil
+	}
+
+	return value, nil
+}
+
+func isValidVariableName(name string) bool {
+	if name == "" || !isVariableNameRune(rune(name[0])) {
+		return false
+	}
+
+	for i := 1; i < len(name); i++ {
+		ch := rune(name[i])
+
+		if !isVariableNameRune(ch) && !('0' <= ch && ch <= '9') {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isVariableNameRune(ch rune) bool {
+	return 'a' <= ch && ch <= 'z' || 'A' <= ch && ch <= 'Z' || ch == '_'
+}
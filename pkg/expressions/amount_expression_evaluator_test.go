@@ -0,0 +1,95 @@
+package expressions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+)
+
+func TestToPostfixExprTokens_ExpressionWithVariables(t *testing.T) {
+	context := core.NewNullContext()
+
+	result, err := ToPostfixExprTokens(context, "balance*0.05/12")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"balance", "0.05", "*", "12", "/"}, result)
+
+	result, err = ToPostfixExprTokens(context, "-destination_balance")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"-destination_balance"}, result)
+
+	result, err = ToPostfixExprTokens(context, "(last_month_income - last_month_expense) * 0.1")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"last_month_income", "last_month_expense", "-", "0.1", "*"}, result)
+}
+
+func TestEvaluateAmountExpression_ValidExpression(t *testing.T) {
+	context := core.NewNullContext()
+	variables := map[string]float64{
+		"balance":             1200,
+		"destination_balance": -350.5,
+		"last_month_income":   5000,
+	}
+
+	result, err := EvaluateAmountExpression(context, "1+2*3", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, float64(7), result)
+
+	result, err = EvaluateAmountExpression(context, "balance*0.05/12", variables)
+	assert.Nil(t, err)
+	assert.Equal(t, float64(5), result)
+
+	result, err = EvaluateAmountExpression(context, "-destination_balance", variables)
+	assert.Nil(t, err)
+	assert.Equal(t, 350.5, result)
+
+	result, err = EvaluateAmountExpression(context, "last_month_income*10/100", variables)
+	assert.Nil(t, err)
+	assert.Equal(t, float64(500), result)
+
+	result, err = EvaluateAmountExpression(context, "2*-balance", variables)
+	assert.Nil(t, err)
+	assert.Equal(t, float64(-2400), result)
+}
+
+func TestEvaluateAmountExpression_InvalidExpression(t *testing.T) {
+	context := core.NewNullContext()
+	variables := map[string]float64{
+		"balance": 1200,
+	}
+
+	_, err := EvaluateAmountExpression(context, "unknown*2", variables)
+	assert.Equal(t, errs.ErrInvalidAmountExpression, err)
+
+	_, err = EvaluateAmountExpression(context, "balance*2", nil)
+	assert.Equal(t, errs.ErrInvalidAmountExpression, err)
+
+	_, err = EvaluateAmountExpression(context, "2balance", variables)
+	assert.Equal(t, errs.ErrInvalidAmountExpression, err)
+
+	_, err = EvaluateAmountExpression(context, "balance.1", variables)
+	assert.Equal(t, errs.ErrInvalidAmountExpression, err)
+
+	_, err = EvaluateAmountExpression(context, "balance/0", variables)
+	assert.Equal(t, errs.ErrInvalidAmountExpression, err)
+}
+
+func TestGetAmountExpressionVariableNames(t *testing.T) {
+	context := core.NewNullContext()
+
+	result, err := GetAmountExpressionVariableNames(context, "1+2")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{}, result)
+
+	result, err = GetAmountExpressionVariableNames(context, "(balance - -destination_balance) * 2 + balance_1 - balance")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"balance", "destination_balance", "balance_1"}, result)
+
+	_, err = GetAmountExpressionVariableNames(context, "balance.1")
+	assert.Equal(t, errs.ErrInvalidAmountExpression, err)
+
+	_, err = GetAmountExpressionVariableNames(context, "balance(")
+	assert.Equal(t, errs.ErrInvalidAmountExpression, err)
+}
@@ -6,16 +6,23 @@ import (
 
 // LocaleTextItems represents all text items need to be translated
 type LocaleTextItems struct {
-	DefaultTypes                *DefaultTypes
-	DataConverterTextItems      *DataConverterTextItems
-	VerifyEmailTextItems        *VerifyEmailTextItems
-	ForgetPasswordMailTextItems *ForgetPasswordMailTextItems
+	DefaultTypes                         *DefaultTypes
+	DataConverterTextItems               *DataConverterTextItems
+	VerifyEmailTextItems                 *VerifyEmailTextItems
+	ForgetPasswordMailTextItems          *ForgetPasswordMailTextItems
+	BillReminderMailTextItems            *BillReminderMailTextItems
+	TransactionAnomalyAlertMailTextItems *TransactionAnomalyAlertMailTextItems
+	TransactionReportTextItems           *TransactionReportTextItems
+	ScheduledReportMailTextItems         *ScheduledReportMailTextItems
 }
 
 // DefaultTypes represents default types for the language
 type DefaultTypes struct {
+	LongDateFormat      core.LongDateFormat
 	DecimalSeparator    core.DecimalSeparator
 	DigitGroupingSymbol core.DigitGroupingSymbol
+	DigitGrouping       core.DigitGroupingType
+	CurrencyDisplayType core.CurrencyDisplayType
 }
 
 // DataConverterTextItems represents text items need to be translated in data converter
@@ -41,3 +48,86 @@ type ForgetPasswordMailTextItems struct {
 	ResetPassword             string
 	DescriptionBelowBtnFormat string
 }
+
+// BillReminderMailTextItems represents text items need to be translated in bill reminder mail
+type BillReminderMailTextItems struct {
+	TitleFormat               string
+	DigestTitle               string
+	SalutationFormat          string
+	Description               string
+	DigestDescription         string
+	Name                      string
+	Date                      string
+	Amount                    string
+	CreditCardStatementFormat string
+	DescriptionBelowFormat    string
+}
+
+// TransactionAnomalyAlertMailTextItems represents text items need to be translated in transaction anomaly alert mail
+type TransactionAnomalyAlertMailTextItems struct {
+	Title                     string
+	SalutationFormat          string
+	Description               string
+	Date                      string
+	Comment                   string
+	Amount                    string
+	Reason                    string
+	CategoryOutlierFormat     string
+	NewPayeeLargeAmountFormat string
+	PossibleDuplicate         string
+	DescriptionBelowFormat    string
+}
+
+// TransactionReportTextItems represents text items need to be translated in transaction report
+type TransactionReportTextItems struct {
+	Title                         string
+	PeriodFormat                  string
+	GeneratedTimeFormat           string
+	Summary                       string
+	TotalIncome                   string
+	TotalExpense                  string
+	NetIncome                     string
+	TransactionCount              string
+	CurrencyDescriptionFormat     string
+	UnconvertibleCurrenciesFormat string
+	IncomeAndExpenseChart         string
+	TopExpenseCategoriesChart     string
+	IncomeByCategory              string
+	ExpenseByCategory             string
+	Category                      string
+	Amount                        string
+	Percentage                    string
+	Transactions                  string
+	Date                          string
+	Type                          string
+	Account                       string
+	Description                   string
+	Income                        string
+	Expense                       string
+	Transfer                      string
+	NoData                        string
+	PageFormat                    string
+}
+
+// ScheduledReportMailTextItems represents text items need to be translated in scheduled report mail
+type ScheduledReportMailTextItems struct {
+	WeeklyTitle                   string
+	MonthlyTitle                  string
+	SalutationFormat              string
+	DescriptionFormat             string
+	TotalIncome                   string
+	TotalExpense                  string
+	NetIncome                     string
+	TopExpenseCategories          string
+	Category                      string
+	Amount                        string
+	Percentage                    string
+	NoExpense                     string
+	AccountBalances               string
+	Account                       string
+	Balance                       string
+	NetAssets                     string
+	UnconvertibleCurrenciesFormat string
+	AttachmentDescription         string
+	DescriptionBelowFormat        string
+}
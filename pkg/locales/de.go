@@ -6,8 +6,11 @@ import (
 
 var de = &LocaleTextItems{
 	DefaultTypes: &DefaultTypes{
+		LongDateFormat:      core.LONG_DATE_FORMAT_D_M_YYYY,
 		DecimalSeparator:    core.DECIMAL_SEPARATOR_COMMA,
 		DigitGroupingSymbol: core.DIGIT_GROUPING_SYMBOL_DOT,
+		DigitGrouping:       core.DIGIT_GROUPING_TYPE_THOUSANDS_SEPARATOR,
+		CurrencyDisplayType: core.CURRENCY_DISPLAY_TYPE_SYMBOL_BEFORE_AMOUNT,
 	},
 	DataConverterTextItems: &DataConverterTextItems{
 		Alipay:       "Alipay",
@@ -27,4 +30,79 @@ var de = &LocaleTextItems{
 		ResetPassword:             "Passwort zurücksetzen",
 		DescriptionBelowBtnFormat: "Wenn Sie nicht angefordert haben, Ihr Passwort zurückzusetzen, ignorieren Sie bitte diese E-Mail. Wenn Sie den obigen Link nicht anklicken können, kopieren Sie bitte die obige URL und fügen Sie sie in Ihren Browser ein. Der Link zum Zurücksetzen des Passworts wird nach %v Minuten ablaufen.",
 	},
+	BillReminderMailTextItems: &BillReminderMailTextItems{
+		TitleFormat:               "Anstehende Zahlung: %s",
+		DigestTitle:               "Anstehende Zahlungen",
+		SalutationFormat:          "Hallo %s,",
+		Description:               "Die folgende Zahlung steht in Kürze an.",
+		DigestDescription:         "Die folgenden Zahlungen stehen in Kürze an.",
+		Name:                      "Name",
+		Date:                      "Datum",
+		Amount:                    "Betrag",
+		CreditCardStatementFormat: "%s Abrechnung",
+		DescriptionBelowFormat:    "Sie erhalten diese E-Mail, weil Sie Erinnerungen in %s aktiviert haben. Sie können die Erinnerungseinstellungen Ihrer geplanten Transaktionen und Kreditkartenkonten jederzeit ändern.",
+	},
+	TransactionAnomalyAlertMailTextItems: &TransactionAnomalyAlertMailTextItems{
+		Title:                     "Ungewöhnliche Transaktionen gefunden",
+		SalutationFormat:          "Hallo %s,",
+		Description:               "Die folgenden Transaktionen wirken im Vergleich zu Ihren bisherigen Ausgaben ungewöhnlich. Bitte prüfen Sie, ob sie korrekt sind.",
+		Date:                      "Datum",
+		Comment:                   "Beschreibung",
+		Amount:                    "Betrag",
+		Reason:                    "Grund",
+		CategoryOutlierFormat:     "Deutlich höher als üblich in dieser Kategorie (über %s)",
+		NewPayeeLargeAmountFormat: "Hoher Betrag bei einem neuen Zahlungsempfänger (über %s)",
+		PossibleDuplicate:         "Sieht wie ein Duplikat einer anderen Transaktion aus",
+		DescriptionBelowFormat:    "Sie erhalten diese E-Mail, weil %s Ihre neuen Transaktionen auf ungewöhnliche Ausgaben prüft. Falls eine Transaktion falsch ist, können Sie sie jederzeit bearbeiten oder löschen.",
+	},
+	TransactionReportTextItems: &TransactionReportTextItems{
+		Title:                         "Transaktionsbericht",
+		PeriodFormat:                  "Zeitraum: %s - %s",
+		GeneratedTimeFormat:           "Erstellt am %s",
+		Summary:                       "Zusammenfassung",
+		TotalIncome:                   "Gesamteinnahmen",
+		TotalExpense:                  "Gesamtausgaben",
+		NetIncome:                     "Nettoeinkommen",
+		TransactionCount:              "Anzahl der Transaktionen",
+		CurrencyDescriptionFormat:     "Alle Summen wurden mit den aktuellen Wechselkursen in %s umgerechnet.",
+		UnconvertibleCurrenciesFormat: "Beträge in %s sind nicht in den Summen enthalten, da keine Wechselkurse verfügbar sind.",
+		IncomeAndExpenseChart:         "Einnahmen und Ausgaben",
+		TopExpenseCategoriesChart:     "Größte Ausgabenkategorien",
+		IncomeByCategory:              "Einnahmen nach Kategorie",
+		ExpenseByCategory:             "Ausgaben nach Kategorie",
+		Category:                      "Kategorie",
+		Amount:                        "Betrag",
+		Percentage:                    "Anteil",
+		Transactions:                  "Transaktionen",
+		Date:                          "Datum",
+		Type:                          "Typ",
+		Account:                       "Konto",
+		Description:                   "Beschreibung",
+		Income:                        "Einnahme",
+		Expense:                       "Ausgabe",
+		Transfer:                      "Überweisung",
+		NoData:                        "Keine Daten",
+		PageFormat:                    "Seite %d von %d",
+	},
+	ScheduledReportMailTextItems: &ScheduledReportMailTextItems{
+		WeeklyTitle:                   "Wochenbericht",
+		MonthlyTitle:                  "Monatsbericht",
+		SalutationFormat:              "Hallo %s,",
+		DescriptionFormat:             "Hier ist die Zusammenfassung Ihrer Finanzen vom %s bis %s.",
+		TotalIncome:                   "Gesamteinnahmen",
+		TotalExpense:                  "Gesamtausgaben",
+		NetIncome:                     "Nettoeinkommen",
+		TopExpenseCategories:          "Top-Ausgabenkategorien",
+		Category:                      "Kategorie",
+		Amount:                        "Betrag",
+		Percentage:                    "Anteil",
+		NoExpense:                     "Keine Ausgaben in diesem Zeitraum",
+		AccountBalances:               "Kontostände",
+		Account:                       "Konto",
+		Balance:                       "Saldo",
+		NetAssets:                     "Nettovermögen",
+		UnconvertibleCurrenciesFormat: "Beträge in %s sind nicht enthalten, da ihre Wechselkurse nicht verfügbar sind.",
+		AttachmentDescription:         "Die detaillierte Transaktionsliste dieses Zeitraums ist dieser E-Mail beigefügt.",
+		DescriptionBelowFormat:        "Sie erhalten diese E-Mail, weil Sie geplante Berichte in %s abonniert haben. Sie können das Abonnement jederzeit ändern oder kündigen.",
+	},
 }
@@ -6,8 +6,11 @@ import (
 
 var en = &LocaleTextItems{
 	DefaultTypes: &DefaultTypes{
+		LongDateFormat:      core.LONG_DATE_FORMAT_M_D_YYYY,
 		DecimalSeparator:    core.DECIMAL_SEPARATOR_DOT,
 		DigitGroupingSymbol: core.DIGIT_GROUPING_SYMBOL_COMMA,
+		DigitGrouping:       core.DIGIT_GROUPING_TYPE_THOUSANDS_SEPARATOR,
+		CurrencyDisplayType: core.CURRENCY_DISPLAY_TYPE_SYMBOL_BEFORE_AMOUNT,
 	},
 	DataConverterTextItems: &DataConverterTextItems{
 		Alipay:       "Alipay",
@@ -27,4 +30,79 @@ var en = &LocaleTextItems{
 		ResetPassword:             "Reset Password",
 		DescriptionBelowBtnFormat: "If you did not request to reset your password, please simply disregard this email. If you cannot click the link above, please copy the above url and paste it into your browser. The password reset link will be expired after %v minutes.",
 	},
+	BillReminderMailTextItems: &BillReminderMailTextItems{
+		TitleFormat:               "Upcoming Payment: %s",
+		DigestTitle:               "Upcoming Payments",
+		SalutationFormat:          "Hi %s,",
+		Description:               "The following payment is coming up soon.",
+		DigestDescription:         "The following payments are coming up soon.",
+		Name:                      "Name",
+		Date:                      "Date",
+		Amount:                    "Amount",
+		CreditCardStatementFormat: "%s Statement",
+		DescriptionBelowFormat:    "You received this email because you have enabled reminders in %s. You can change the reminder settings of your scheduled transactions and credit card accounts at any time.",
+	},
+	TransactionAnomalyAlertMailTextItems: &TransactionAnomalyAlertMailTextItems{
+		Title:                     "Unusual Transactions Found",
+		SalutationFormat:          "Hi %s,",
+		Description:               "The following transactions look unusual compared with your spending history. Please check whether they are correct.",
+		Date:                      "Date",
+		Comment:                   "Description",
+		Amount:                    "Amount",
+		Reason:                    "Reason",
+		CategoryOutlierFormat:     "Much higher than usual in this category (above %s)",
+		NewPayeeLargeAmountFormat: "Large amount at a new payee (above %s)",
+		PossibleDuplicate:         "Looks like a duplicate of another transaction",
+		DescriptionBelowFormat:    "You received this email because %s checks your new transactions for unusual spending. If a transaction is incorrect, you can edit or delete it at any time.",
+	},
+	TransactionReportTextItems: &TransactionReportTextItems{
+		Title:                         "Transaction Report",
+		PeriodFormat:                  "Period: %s - %s",
+		GeneratedTimeFormat:           "Generated at %s",
+		Summary:                       "Summary",
+		TotalIncome:                   "Total Income",
+		TotalExpense:                  "Total Expense",
+		NetIncome:                     "Net Income",
+		TransactionCount:              "Transaction Count",
+		CurrencyDescriptionFormat:     "All totals are converted to %s using the latest exchange rates.",
+		UnconvertibleCurrenciesFormat: "Amounts in %s are excluded from the totals because the exchange rates are unavailable.",
+		IncomeAndExpenseChart:         "Income and Expense",
+		TopExpenseCategoriesChart:     "Top Expense Categories",
+		IncomeByCategory:              "Income by Category",
+		ExpenseByCategory:             "Expense by Category",
+		Category:                      "Category",
+		Amount:                        "Amount",
+		Percentage:                    "Percentage",
+		Transactions:                  "Transactions",
+		Date:                          "Date",
+		Type:                          "Type",
+		Account:                       "Account",
+		Description:                   "Description",
+		Income:                        "Income",
+		Expense:                       "Expense",
+		Transfer:                      "Transfer",
+		NoData:                        "No data",
+		PageFormat:                    "Page %d of %d",
+	},
+	ScheduledReportMailTextItems: &ScheduledReportMailTextItems{
+		WeeklyTitle:                   "Weekly Report",
+		MonthlyTitle:                  "Monthly Report",
+		SalutationFormat:              "Hi %s,",
+		DescriptionFormat:             "Here is the summary of your finances from %s to %s.",
+		TotalIncome:                   "Total Income",
+		TotalExpense:                  "Total Expense",
+		NetIncome:                     "Net Income",
+		TopExpenseCategories:          "Top Expense Categories",
+		Category:                      "Category",
+		Amount:                        "Amount",
+		Percentage:                    "Percentage",
+		NoExpense:                     "No expense in this period",
+		AccountBalances:               "Account Balances",
+		Account:                       "Account",
+		Balance:                       "Balance",
+		NetAssets:                     "Net Assets",
+		UnconvertibleCurrenciesFormat: "Amounts in %s are not included because their exchange rates are unavailable.",
+		AttachmentDescription:         "The detailed transaction list of this period is attached to this email.",
+		DescriptionBelowFormat:        "You received this email because you have subscribed to scheduled reports in %s. You can change or cancel the subscription at any time.",
+	},
 }
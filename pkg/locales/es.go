@@ -6,8 +6,11 @@ import (
 
 var es = &LocaleTextItems{
 	DefaultTypes: &DefaultTypes{
+		LongDateFormat:      core.LONG_DATE_FORMAT_D_M_YYYY,
 		DecimalSeparator:    core.DECIMAL_SEPARATOR_DOT,
 		DigitGroupingSymbol: core.DIGIT_GROUPING_SYMBOL_COMMA,
+		DigitGrouping:       core.DIGIT_GROUPING_TYPE_THOUSANDS_SEPARATOR,
+		CurrencyDisplayType: core.CURRENCY_DISPLAY_TYPE_SYMBOL_BEFORE_AMOUNT,
 	},
 	DataConverterTextItems: &DataConverterTextItems{
 		Alipay:       "Alipay",
@@ -27,4 +30,79 @@ var es = &LocaleTextItems{
 		ResetPassword:             "Restablecer Contraseña",
 		DescriptionBelowBtnFormat: "Si no solicitó un restablecimiento de contraseña, simplemente descarte este correo. Si no puede hacer click en el link anterior, copie la url arriba mostrada y péguela en su navegadror. El enlace de restablecimiento de contraseña expira pasados %v minutos.",
 	},
+	BillReminderMailTextItems: &BillReminderMailTextItems{
+		TitleFormat:               "Próximo pago: %s",
+		DigestTitle:               "Próximos pagos",
+		SalutationFormat:          "Hola %s,",
+		Description:               "El siguiente pago vence pronto.",
+		DigestDescription:         "Los siguientes pagos vencen pronto.",
+		Name:                      "Nombre",
+		Date:                      "Fecha",
+		Amount:                    "Importe",
+		CreditCardStatementFormat: "Extracto de %s",
+		DescriptionBelowFormat:    "Ha recibido este correo porque ha activado los recordatorios en %s. Puede cambiar la configuración de recordatorios de sus transacciones programadas y cuentas de tarjeta de crédito en cualquier momento.",
+	},
+	TransactionAnomalyAlertMailTextItems: &TransactionAnomalyAlertMailTextItems{
+		Title:                     "Transacciones inusuales encontradas",
+		SalutationFormat:          "Hola %s,",
+		Description:               "Las siguientes transacciones parecen inusuales en comparación con su historial de gastos. Por favor, compruebe si son correctas.",
+		Date:                      "Fecha",
+		Comment:                   "Descripción",
+		Amount:                    "Importe",
+		Reason:                    "Motivo",
+		CategoryOutlierFormat:     "Mucho más alto de lo habitual en esta categoría (más de %s)",
+		NewPayeeLargeAmountFormat: "Importe elevado con un beneficiario nuevo (más de %s)",
+		PossibleDuplicate:         "Parece un duplicado de otra transacción",
+		DescriptionBelowFormat:    "Recibe este correo porque %s revisa sus nuevas transacciones en busca de gastos inusuales. Si una transacción es incorrecta, puede editarla o eliminarla en cualquier momento.",
+	},
+	TransactionReportTextItems: &TransactionReportTextItems{
+		Title:                         "Informe de transacciones",
+		PeriodFormat:                  "Periodo: %s - %s",
+		GeneratedTimeFormat:           "Generado el %s",
+		Summary:                       "Resumen",
+		TotalIncome:                   "Ingresos totales",
+		TotalExpense:                  "Gastos totales",
+		NetIncome:                     "Ingreso neto",
+		TransactionCount:              "Número de transacciones",
+		CurrencyDescriptionFormat:     "Todos los totales se convierten a %s con los tipos de cambio más recientes.",
+		UnconvertibleCurrenciesFormat: "Los importes en %s no se incluyen en los totales porque no hay tipos de cambio disponibles.",
+		IncomeAndExpenseChart:         "Ingresos y gastos",
+		TopExpenseCategoriesChart:     "Principales categorías de gastos",
+		IncomeByCategory:              "Ingresos por categoría",
+		ExpenseByCategory:             "Gastos por categoría",
+		Category:                      "Categoría",
+		Amount:                        "Importe",
+		Percentage:                    "Porcentaje",
+		Transactions:                  "Transacciones",
+		Date:                          "Fecha",
+		Type:                          "Tipo",
+		Account:                       "Cuenta",
+		Description:                   "Descripción",
+		Income:                        "Ingreso",
+		Expense:                       "Gasto",
+		Transfer:                      "Transferencia",
+		NoData:                        "Sin datos",
+		PageFormat:                    "Página %d de %d",
+	},
+	ScheduledReportMailTextItems: &ScheduledReportMailTextItems{
+		WeeklyTitle:                   "Informe semanal",
+		MonthlyTitle:                  "Informe mensual",
+		SalutationFormat:              "Hola %s,",
+		DescriptionFormat:             "Este es el resumen de sus finanzas del %s al %s.",
+		TotalIncome:                   "Ingresos totales",
+		TotalExpense:                  "Gastos totales",
+		NetIncome:                     "Ingreso neto",
+		TopExpenseCategories:          "Principales categorías de gastos",
+		Category:                      "Categoría",
+		Amount:                        "Importe",
+		Percentage:                    "Porcentaje",
+		NoExpense:                     "No hay gastos en este período",
+		AccountBalances:               "Saldos de cuentas",
+		Account:                       "Cuenta",
+		Balance:                       "Saldo",
+		NetAssets:                     "Patrimonio neto",
+		UnconvertibleCurrenciesFormat: "Los importes en %s no están incluidos porque sus tipos de cambio no están disponibles.",
+		AttachmentDescription:         "La lista detallada de transacciones de este período se adjunta a este correo.",
+		DescriptionBelowFormat:        "Ha recibido este correo porque se ha suscrito a informes programados en %s. Puede cambiar o cancelar la suscripción en cualquier momento.",
+	},
 }
@@ -6,8 +6,11 @@ import (
 
 var it = &LocaleTextItems{
 	DefaultTypes: &DefaultTypes{
+		LongDateFormat:      core.LONG_DATE_FORMAT_D_M_YYYY,
 		DecimalSeparator:    core.DECIMAL_SEPARATOR_COMMA,
 		DigitGroupingSymbol: core.DIGIT_GROUPING_SYMBOL_DOT,
+		DigitGrouping:       core.DIGIT_GROUPING_TYPE_THOUSANDS_SEPARATOR,
+		CurrencyDisplayType: core.CURRENCY_DISPLAY_TYPE_SYMBOL_BEFORE_AMOUNT,
 	},
 	DataConverterTextItems: &DataConverterTextItems{
 		Alipay:       "Alipay",
@@ -27,4 +30,79 @@ var it = &LocaleTextItems{
 		ResetPassword:             "Reimposta password",
 		DescriptionBelowBtnFormat: "Se non hai chiesto alcun cambio della password, puoi ignorare questa mail. Se non riesci a cliccare il link, copia l'indirizzo URL qui sopra e incollalo nel tuo browser preferito. Il link di verifica scadrà tra %v minuti.",
 	},
+	BillReminderMailTextItems: &BillReminderMailTextItems{
+		TitleFormat:               "Pagamento in arrivo: %s",
+		DigestTitle:               "Pagamenti in arrivo",
+		SalutationFormat:          "Ciao %s,",
+		Description:               "Il seguente pagamento è in scadenza a breve.",
+		DigestDescription:         "I seguenti pagamenti sono in scadenza a breve.",
+		Name:                      "Nome",
+		Date:                      "Data",
+		Amount:                    "Importo",
+		CreditCardStatementFormat: "Estratto conto %s",
+		DescriptionBelowFormat:    "Hai ricevuto questa e-mail perché hai attivato i promemoria in %s. Puoi modificare le impostazioni dei promemoria delle tue transazioni pianificate e dei conti carta di credito in qualsiasi momento.",
+	},
+	TransactionAnomalyAlertMailTextItems: &TransactionAnomalyAlertMailTextItems{
+		Title:                     "Transazioni insolite trovate",
+		SalutationFormat:          "Ciao %s,",
+		Description:               "Le seguenti transazioni sembrano insolite rispetto alla cronologia delle tue spese. Verifica che siano corrette.",
+		Date:                      "Data",
+		Comment:                   "Descrizione",
+		Amount:                    "Importo",
+		Reason:                    "Motivo",
+		CategoryOutlierFormat:     "Molto più alto del solito in questa categoria (oltre %s)",
+		NewPayeeLargeAmountFormat: "Importo elevato presso un nuovo beneficiario (oltre %s)",
+		PossibleDuplicate:         "Sembra un duplicato di un'altra transazione",
+		DescriptionBelowFormat:    "Hai ricevuto questa email perché %s controlla le tue nuove transazioni alla ricerca di spese insolite. Se una transazione non è corretta, puoi modificarla o eliminarla in qualsiasi momento.",
+	},
+	TransactionReportTextItems: &TransactionReportTextItems{
+		Title:                         "Rapporto delle transazioni",
+		PeriodFormat:                  "Periodo: %s - %s",
+		GeneratedTimeFormat:           "Generato il %s",
+		Summary:                       "Riepilogo",
+		TotalIncome:                   "Entrate totali",
+		TotalExpense:                  "Spese totali",
+		NetIncome:                     "Reddito netto",
+		TransactionCount:              "Numero di transazioni",
+		CurrencyDescriptionFormat:     "Tutti i totali sono convertiti in %s con i tassi di cambio più recenti.",
+		UnconvertibleCurrenciesFormat: "Gli importi in %s sono esclusi dai totali perché i tassi di cambio non sono disponibili.",
+		IncomeAndExpenseChart:         "Entrate e spese",
+		TopExpenseCategoriesChart:     "Principali categorie di spesa",
+		IncomeByCategory:              "Entrate per categoria",
+		ExpenseByCategory:             "Spese per categoria",
+		Category:                      "Categoria",
+		Amount:                        "Importo",
+		Percentage:                    "Percentuale",
+		Transactions:                  "Transazioni",
+		Date:                          "Data",
+		Type:                          "Tipo",
+		Account:                       "Conto",
+		Description:                   "Descrizione",
+		Income:                        "Entrata",
+		Expense:                       "Spesa",
+		Transfer:                      "Trasferimento",
+		NoData:                        "Nessun dato",
+		PageFormat:                    "Pagina %d di %d",
+	},
+	ScheduledReportMailTextItems: &ScheduledReportMailTextItems{
+		WeeklyTitle:                   "Report settimanale",
+		MonthlyTitle:                  "Report mensile",
+		SalutationFormat:              "Ciao %s,",
+		DescriptionFormat:             "Ecco il riepilogo delle tue finanze dal %s al %s.",
+		TotalIncome:                   "Entrate totali",
+		TotalExpense:                  "Uscite totali",
+		NetIncome:                     "Reddito netto",
+		TopExpenseCategories:          "Principali categorie di spesa",
+		Category:                      "Categoria",
+		Amount:                        "Importo",
+		Percentage:                    "Percentuale",
+		NoExpense:                     "Nessuna spesa in questo periodo",
+		AccountBalances:               "Saldi dei conti",
+		Account:                       "Conto",
+		Balance:                       "Saldo",
+		NetAssets:                     "Patrimonio netto",
+		UnconvertibleCurrenciesFormat: "Gli importi in %s non sono inclusi perché i relativi tassi di cambio non sono disponibili.",
+		AttachmentDescription:         "L'elenco dettagliato delle transazioni di questo periodo è allegato a questa email.",
+		DescriptionBelowFormat:        "Hai ricevuto questa email perché ti sei iscritto ai report programmati in %s. Puoi modificare o annullare l'iscrizione in qualsiasi momento.",
+	},
 }
@@ -6,8 +6,11 @@ import (
 
 var ja = &LocaleTextItems{
 	DefaultTypes: &DefaultTypes{
+		LongDateFormat:      core.LONG_DATE_FORMAT_YYYY_M_D,
 		DecimalSeparator:    core.DECIMAL_SEPARATOR_DOT,
 		DigitGroupingSymbol: core.DIGIT_GROUPING_SYMBOL_COMMA,
+		DigitGrouping:       core.DIGIT_GROUPING_TYPE_THOUSANDS_SEPARATOR,
+		CurrencyDisplayType: core.CURRENCY_DISPLAY_TYPE_SYMBOL_BEFORE_AMOUNT,
 	},
 	DataConverterTextItems: &DataConverterTextItems{
 		Alipay:       "Alipay",
@@ -27,4 +30,79 @@ var ja = &LocaleTextItems{
 		ResetPassword:             "パスワードをリセット",
 		DescriptionBelowBtnFormat: "パスワードのリセットをリクエストしていない場合はこのメールを無視してください。上記のリンクをクリックできない場合は、上記のURLをコピーしてブラウザに貼り付けてください。パスワードリセットのリンクは%v分後に期限切れになります。",
 	},
+	BillReminderMailTextItems: &BillReminderMailTextItems{
+		TitleFormat:               "支払い予定: %s",
+		DigestTitle:               "支払い予定",
+		SalutationFormat:          "こんにちは%s,",
+		Description:               "次の支払いが近づいています。",
+		DigestDescription:         "次の支払いが近づいています。",
+		Name:                      "名前",
+		Date:                      "日付",
+		Amount:                    "金額",
+		CreditCardStatementFormat: "%s の締め日",
+		DescriptionBelowFormat:    "%s でリマインダーを有効にしているため、このメールが送信されました。定期取引とクレジットカード口座のリマインダー設定はいつでも変更できます。",
+	},
+	TransactionAnomalyAlertMailTextItems: &TransactionAnomalyAlertMailTextItems{
+		Title:                     "異常な取引が見つかりました",
+		SalutationFormat:          "%s 様",
+		Description:               "以下の取引は、これまでの支出履歴と比べて通常とは異なるようです。内容が正しいかご確認ください。",
+		Date:                      "日付",
+		Comment:                   "説明",
+		Amount:                    "金額",
+		Reason:                    "理由",
+		CategoryOutlierFormat:     "このカテゴリの通常の金額を大きく上回っています（%s 超）",
+		NewPayeeLargeAmountFormat: "初めての支払先で金額が大きいです（%s 超）",
+		PossibleDuplicate:         "別の取引と重複している可能性があります",
+		DescriptionBelowFormat:    "%s が新しい取引に異常な支出がないかを確認しているため、このメールが送信されました。取引が誤っている場合は、いつでも編集または削除できます。",
+	},
+	TransactionReportTextItems: &TransactionReportTextItems{
+		Title:                         "取引レポート",
+		PeriodFormat:                  "期間: %s - %s",
+		GeneratedTimeFormat:           "作成日時 %s",
+		Summary:                       "概要",
+		TotalIncome:                   "収入合計",
+		TotalExpense:                  "支出合計",
+		NetIncome:                     "純利益",
+		TransactionCount:              "取引件数",
+		CurrencyDescriptionFormat:     "すべての合計は最新の為替レートで %s に換算されています。",
+		UnconvertibleCurrenciesFormat: "為替レートが利用できないため、%s の金額は合計に含まれていません。",
+		IncomeAndExpenseChart:         "収入と支出",
+		TopExpenseCategoriesChart:     "支出の多いカテゴリ",
+		IncomeByCategory:              "カテゴリ別収入",
+		ExpenseByCategory:             "カテゴリ別支出",
+		Category:                      "カテゴリ",
+		Amount:                        "金額",
+		Percentage:                    "割合",
+		Transactions:                  "取引",
+		Date:                          "日付",
+		Type:                          "種類",
+		Account:                       "口座",
+		Description:                   "説明",
+		Income:                        "収入",
+		Expense:                       "支出",
+		Transfer:                      "振替",
+		NoData:                        "データなし",
+		PageFormat:                    "%d / %d ページ",
+	},
+	ScheduledReportMailTextItems: &ScheduledReportMailTextItems{
+		WeeklyTitle:                   "週次レポート",
+		MonthlyTitle:                  "月次レポート",
+		SalutationFormat:              "%s 様",
+		DescriptionFormat:             "%s から %s までの収支の概要をお送りします。",
+		TotalIncome:                   "収入合計",
+		TotalExpense:                  "支出合計",
+		NetIncome:                     "純収入",
+		TopExpenseCategories:          "支出の多いカテゴリ",
+		Category:                      "カテゴリ",
+		Amount:                        "金額",
+		Percentage:                    "割合",
+		NoExpense:                     "この期間の支出はありません",
+		AccountBalances:               "口座残高",
+		Account:                       "口座",
+		Balance:                       "残高",
+		NetAssets:                     "純資産",
+		UnconvertibleCurrenciesFormat: "%s の金額は為替レートを取得できないため含まれていません。",
+		AttachmentDescription:         "この期間の取引明細をこのメールに添付しています。",
+		DescriptionBelowFormat:        "%s で定期レポートを購読しているため、このメールをお送りしています。購読はいつでも変更または解除できます。",
+	},
 }
@@ -6,8 +6,11 @@ import (
 
 var ru = &LocaleTextItems{
 	DefaultTypes: &DefaultTypes{
+		LongDateFormat:      core.LONG_DATE_FORMAT_D_M_YYYY,
 		DecimalSeparator:    core.DECIMAL_SEPARATOR_DOT,
 		DigitGroupingSymbol: core.DIGIT_GROUPING_SYMBOL_COMMA,
+		DigitGrouping:       core.DIGIT_GROUPING_TYPE_THOUSANDS_SEPARATOR,
+		CurrencyDisplayType: core.CURRENCY_DISPLAY_TYPE_SYMBOL_AFTER_AMOUNT,
 	},
 	DataConverterTextItems: &DataConverterTextItems{
 		Alipay:       "Alipay",
@@ -27,4 +30,79 @@ var ru = &LocaleTextItems{
 		ResetPassword:             "Сбросить пароль",
 		DescriptionBelowBtnFormat: "Если вы не запрашивали сброс пароля, просто проигнорируйте это письмо. Если вы не можете нажать на ссылку выше, скопируйте указанный выше URL и вставьте его в браузер. Ссылка для сброса пароля истечет через %v минут.",
 	},
+	BillReminderMailTextItems: &BillReminderMailTextItems{
+		TitleFormat:               "Предстоящий платёж: %s",
+		DigestTitle:               "Предстоящие платежи",
+		SalutationFormat:          "Здравствуйте %s,",
+		Description:               "Скоро предстоит следующий платёж.",
+		DigestDescription:         "Скоро предстоят следующие платежи.",
+		Name:                      "Название",
+		Date:                      "Дата",
+		Amount:                    "Сумма",
+		CreditCardStatementFormat: "Выписка по %s",
+		DescriptionBelowFormat:    "Вы получили это письмо, потому что включили напоминания в %s. Вы можете изменить настройки напоминаний для запланированных транзакций и кредитных карт в любое время.",
+	},
+	TransactionAnomalyAlertMailTextItems: &TransactionAnomalyAlertMailTextItems{
+		Title:                     "Обнаружены необычные транзакции",
+		SalutationFormat:          "Здравствуйте, %s!",
+		Description:               "Следующие транзакции выглядят необычно по сравнению с историей ваших расходов. Пожалуйста, проверьте, верны ли они.",
+		Date:                      "Дата",
+		Comment:                   "Описание",
+		Amount:                    "Сумма",
+		Reason:                    "Причина",
+		CategoryOutlierFormat:     "Намного выше обычного для этой категории (более %s)",
+		NewPayeeLargeAmountFormat: "Крупная сумма у нового получателя (более %s)",
+		PossibleDuplicate:         "Похоже на дубликат другой транзакции",
+		DescriptionBelowFormat:    "Вы получили это письмо, потому что %s проверяет ваши новые транзакции на необычные расходы. Если транзакция неверна, вы можете изменить или удалить её в любое время.",
+	},
+	TransactionReportTextItems: &TransactionReportTextItems{
+		Title:                         "Отчет по транзакциям",
+		PeriodFormat:                  "Период: %s - %s",
+		GeneratedTimeFormat:           "Создан %s",
+		Summary:                       "Сводка",
+		TotalIncome:                   "Общий доход",
+		TotalExpense:                  "Общий расход",
+		NetIncome:                     "Чистый доход",
+		TransactionCount:              "Количество транзакций",
+		CurrencyDescriptionFormat:     "Все итоги пересчитаны в %s по последним курсам валют.",
+		UnconvertibleCurrenciesFormat: "Суммы в %s не включены в итоги, так как курсы валют недоступны.",
+		IncomeAndExpenseChart:         "Доходы и расходы",
+		TopExpenseCategoriesChart:     "Основные категории расходов",
+		IncomeByCategory:              "Доходы по категориям",
+		ExpenseByCategory:             "Расходы по категориям",
+		Category:                      "Категория",
+		Amount:                        "Сумма",
+		Percentage:                    "Доля",
+		Transactions:                  "Транзакции",
+		Date:                          "Дата",
+		Type:                          "Тип",
+		Account:                       "Счет",
+		Description:                   "Описание",
+		Income:                        "Доход",
+		Expense:                       "Расход",
+		Transfer:                      "Перевод",
+		NoData:                        "Нет данных",
+		PageFormat:                    "Страница %d из %d",
+	},
+	ScheduledReportMailTextItems: &ScheduledReportMailTextItems{
+		WeeklyTitle:                   "Еженедельный отчёт",
+		MonthlyTitle:                  "Ежемесячный отчёт",
+		SalutationFormat:              "Здравствуйте, %s!",
+		DescriptionFormat:             "Вот сводка ваших финансов с %s по %s.",
+		TotalIncome:                   "Общий доход",
+		TotalExpense:                  "Общий расход",
+		NetIncome:                     "Чистый доход",
+		TopExpenseCategories:          "Основные категории расходов",
+		Category:                      "Категория",
+		Amount:                        "Сумма",
+		Percentage:                    "Доля",
+		NoExpense:                     "Нет расходов за этот период",
+		AccountBalances:               "Остатки на счетах",
+		Account:                       "Счёт",
+		Balance:                       "Остаток",
+		NetAssets:                     "Чистые активы",
+		UnconvertibleCurrenciesFormat: "Суммы в %s не учтены, так как их обменные курсы недоступны.",
+		AttachmentDescription:         "Подробный список транзакций за этот период приложен к этому письму.",
+		DescriptionBelowFormat:        "Вы получили это письмо, потому что подписались на регулярные отчёты в %s. Вы можете изменить или отменить подписку в любое время.",
+	},
 }
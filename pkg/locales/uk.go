@@ -6,8 +6,11 @@ import (
 
 var uk = &LocaleTextItems{
 	DefaultTypes: &DefaultTypes{
+		LongDateFormat:      core.LONG_DATE_FORMAT_D_M_YYYY,
 		DecimalSeparator:    core.DECIMAL_SEPARATOR_DOT,
 		DigitGroupingSymbol: core.DIGIT_GROUPING_SYMBOL_COMMA,
+		DigitGrouping:       core.DIGIT_GROUPING_TYPE_THOUSANDS_SEPARATOR,
+		CurrencyDisplayType: core.CURRENCY_DISPLAY_TYPE_SYMBOL_AFTER_AMOUNT,
 	},
 	DataConverterTextItems: &DataConverterTextItems{
 		Alipay:       "Alipay",
@@ -27,4 +30,79 @@ var uk = &LocaleTextItems{
 		ResetPassword:             "Скинути пароль",
 		DescriptionBelowBtnFormat: "Якщо ви не надсилали запит на скидання пароля, просто проігноруйте цей лист. Якщо ви не можете натиснути на посилання вище, скопіюйте вказану URL-адресу та вставте її у свій браузер. Посилання для скидання пароля буде дійсне протягом %v хвилин.",
 	},
+	BillReminderMailTextItems: &BillReminderMailTextItems{
+		TitleFormat:               "Майбутній платіж: %s",
+		DigestTitle:               "Майбутні платежі",
+		SalutationFormat:          "Вітаємо, %s!",
+		Description:               "Незабаром настане наступний платіж.",
+		DigestDescription:         "Незабаром настануть наступні платежі.",
+		Name:                      "Назва",
+		Date:                      "Дата",
+		Amount:                    "Сума",
+		CreditCardStatementFormat: "Виписка за %s",
+		DescriptionBelowFormat:    "Ви отримали цей лист, тому що увімкнули нагадування в %s. Ви можете будь-коли змінити налаштування нагадувань для запланованих транзакцій і кредитних карток.",
+	},
+	TransactionAnomalyAlertMailTextItems: &TransactionAnomalyAlertMailTextItems{
+		Title:                     "Виявлено незвичайні транзакції",
+		SalutationFormat:          "Вітаємо, %s!",
+		Description:               "Наступні транзакції виглядають незвично порівняно з історією ваших витрат. Будь ласка, перевірте, чи вони правильні.",
+		Date:                      "Дата",
+		Comment:                   "Опис",
+		Amount:                    "Сума",
+		Reason:                    "Причина",
+		CategoryOutlierFormat:     "Набагато вище звичайного для цієї категорії (понад %s)",
+		NewPayeeLargeAmountFormat: "Велика сума у нового одержувача (понад %s)",
+		PossibleDuplicate:         "Схоже на дублікат іншої транзакції",
+		DescriptionBelowFormat:    "Ви отримали цей лист, тому що %s перевіряє ваші нові транзакції на незвичайні витрати. Якщо транзакція неправильна, ви можете будь-коли змінити або видалити її.",
+	},
+	TransactionReportTextItems: &TransactionReportTextItems{
+		Title:                         "Звіт про транзакції",
+		PeriodFormat:                  "Період: %s - %s",
+		GeneratedTimeFormat:           "Створено %s",
+		Summary:                       "Підсумок",
+		TotalIncome:                   "Загальний дохід",
+		TotalExpense:                  "Загальні витрати",
+		NetIncome:                     "Чистий дохід",
+		TransactionCount:              "Кількість транзакцій",
+		CurrencyDescriptionFormat:     "Усі підсумки перераховано в %s за останніми курсами валют.",
+		UnconvertibleCurrenciesFormat: "Суми в %s не враховано в підсумках, оскільки курси валют недоступні.",
+		IncomeAndExpenseChart:         "Доходи та витрати",
+		TopExpenseCategoriesChart:     "Основні категорії витрат",
+		IncomeByCategory:              "Доходи за категоріями",
+		ExpenseByCategory:             "Витрати за категоріями",
+		Category:                      "Категорія",
+		Amount:                        "Сума",
+		Percentage:                    "Частка",
+		Transactions:                  "Транзакції",
+		Date:                          "Дата",
+		Type:                          "Тип",
+		Account:                       "Рахунок",
+		Description:                   "Опис",
+		Income:                        "Дохід",
+		Expense:                       "Витрата",
+		Transfer:                      "Переказ",
+		NoData:                        "Немає даних",
+		PageFormat:                    "Сторінка %d з %d",
+	},
+	ScheduledReportMailTextItems: &ScheduledReportMailTextItems{
+		WeeklyTitle:                   "Щотижневий звіт",
+		MonthlyTitle:                  "Щомісячний звіт",
+		SalutationFormat:              "Вітаємо, %s!",
+		DescriptionFormat:             "Ось підсумок ваших фінансів з %s по %s.",
+		TotalIncome:                   "Загальний дохід",
+		TotalExpense:                  "Загальні витрати",
+		NetIncome:                     "Чистий дохід",
+		TopExpenseCategories:          "Основні категорії витрат",
+		Category:                      "Категорія",
+		Amount:                        "Сума",
+		Percentage:                    "Частка",
+		NoExpense:                     "Немає витрат за цей період",
+		AccountBalances:               "Залишки на рахунках",
+		Account:                       "Рахунок",
+		Balance:                       "Залишок",
+		NetAssets:                     "Чисті активи",
+		UnconvertibleCurrenciesFormat: "Суми в %s не враховано, оскільки їхні обмінні курси недоступні.",
+		AttachmentDescription:         "Детальний список транзакцій за цей період додано до цього листа.",
+		DescriptionBelowFormat:        "Ви отримали цей лист, оскільки підписалися на регулярні звіти в %s. Ви можете змінити або скасувати підписку будь-коли.",
+	},
 }
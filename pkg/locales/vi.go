@@ -6,8 +6,11 @@ import (
 
 var vi = &LocaleTextItems{
 	DefaultTypes: &DefaultTypes{
+		LongDateFormat:      core.LONG_DATE_FORMAT_D_M_YYYY,
 		DecimalSeparator:    core.DECIMAL_SEPARATOR_COMMA,
 		DigitGroupingSymbol: core.DIGIT_GROUPING_SYMBOL_DOT,
+		DigitGrouping:       core.DIGIT_GROUPING_TYPE_THOUSANDS_SEPARATOR,
+		CurrencyDisplayType: core.CURRENCY_DISPLAY_TYPE_SYMBOL_BEFORE_AMOUNT,
 	},
 	DataConverterTextItems: &DataConverterTextItems{
 		Alipay:       "Alipay",
@@ -27,4 +30,79 @@ var vi = &LocaleTextItems{
 		ResetPassword:             "Đặt lại Mật khẩu",
 		DescriptionBelowBtnFormat: "Nếu bạn không yêu cầu đặt lại mật khẩu, vui lòng bỏ qua email này. Nếu bạn không thể nhấp vào liên kết trên, hãy sao chép và dán liên kết vào trình duyệt của bạn. Liên kết đặt lại mật khẩu sẽ hết hạn sau %v phút.",
 	},
+	BillReminderMailTextItems: &BillReminderMailTextItems{
+		TitleFormat:               "Khoản thanh toán sắp tới: %s",
+		DigestTitle:               "Các khoản thanh toán sắp tới",
+		SalutationFormat:          "Chào %s,",
+		Description:               "Khoản thanh toán sau sắp đến hạn.",
+		DigestDescription:         "Các khoản thanh toán sau sắp đến hạn.",
+		Name:                      "Tên",
+		Date:                      "Ngày",
+		Amount:                    "Số tiền",
+		CreditCardStatementFormat: "Sao kê %s",
+		DescriptionBelowFormat:    "Bạn nhận được email này vì bạn đã bật lời nhắc trong %s. Bạn có thể thay đổi cài đặt lời nhắc của các giao dịch định kỳ và tài khoản thẻ tín dụng bất kỳ lúc nào.",
+	},
+	TransactionAnomalyAlertMailTextItems: &TransactionAnomalyAlertMailTextItems{
+		Title:                     "Phát hiện giao dịch bất thường",
+		SalutationFormat:          "Xin chào %s,",
+		Description:               "Các giao dịch sau có vẻ bất thường so với lịch sử chi tiêu của bạn. Vui lòng kiểm tra xem chúng có chính xác không.",
+		Date:                      "Ngày",
+		Comment:                   "Mô tả",
+		Amount:                    "Số tiền",
+		Reason:                    "Lý do",
+		CategoryOutlierFormat:     "Cao hơn nhiều so với thường lệ trong danh mục này (trên %s)",
+		NewPayeeLargeAmountFormat: "Số tiền lớn tại người nhận mới (trên %s)",
+		PossibleDuplicate:         "Có vẻ trùng lặp với một giao dịch khác",
+		DescriptionBelowFormat:    "Bạn nhận được email này vì %s kiểm tra các giao dịch mới của bạn để phát hiện chi tiêu bất thường. Nếu giao dịch không chính xác, bạn có thể chỉnh sửa hoặc xóa nó bất cứ lúc nào.",
+	},
+	TransactionReportTextItems: &TransactionReportTextItems{
+		Title:                         "Báo cáo giao dịch",
+		PeriodFormat:                  "Kỳ: %s - %s",
+		GeneratedTimeFormat:           "Tạo lúc %s",
+		Summary:                       "Tổng quan",
+		TotalIncome:                   "Tổng thu nhập",
+		TotalExpense:                  "Tổng chi tiêu",
+		NetIncome:                     "Thu nhập ròng",
+		TransactionCount:              "Số giao dịch",
+		CurrencyDescriptionFormat:     "Tất cả tổng số được quy đổi sang %s theo tỷ giá mới nhất.",
+		UnconvertibleCurrenciesFormat: "Số tiền bằng %s không được tính vào tổng số vì không có tỷ giá.",
+		IncomeAndExpenseChart:         "Thu nhập và chi tiêu",
+		TopExpenseCategoriesChart:     "Danh mục chi tiêu hàng đầu",
+		IncomeByCategory:              "Thu nhập theo danh mục",
+		ExpenseByCategory:             "Chi tiêu theo danh mục",
+		Category:                      "Danh mục",
+		Amount:                        "Số tiền",
+		Percentage:                    "Tỷ lệ",
+		Transactions:                  "Giao dịch",
+		Date:                          "Ngày",
+		Type:                          "Loại",
+		Account:                       "Tài khoản",
+		Description:                   "Mô tả",
+		Income:                        "Thu nhập",
+		Expense:                       "Chi tiêu",
+		Transfer:                      "Chuyển khoản",
+		NoData:                        "Không có dữ liệu",
+		PageFormat:                    "Trang %d / %d",
+	},
+	ScheduledReportMailTextItems: &ScheduledReportMailTextItems{
+		WeeklyTitle:                   "Báo cáo hằng tuần",
+		MonthlyTitle:                  "Báo cáo hằng tháng",
+		SalutationFormat:              "Xin chào %s,",
+		DescriptionFormat:             "Đây là tóm tắt tài chính của bạn từ %s đến %s.",
+		TotalIncome:                   "Tổng thu nhập",
+		TotalExpense:                  "Tổng chi tiêu",
+		NetIncome:                     "Thu nhập ròng",
+		TopExpenseCategories:          "Danh mục chi tiêu hàng đầu",
+		Category:                      "Danh mục",
+		Amount:                        "Số tiền",
+		Percentage:                    "Tỷ lệ",
+		NoExpense:                     "Không có chi tiêu trong kỳ này",
+		AccountBalances:               "Số dư tài khoản",
+		Account:                       "Tài khoản",
+		Balance:                       "Số dư",
+		NetAssets:                     "Tài sản ròng",
+		UnconvertibleCurrenciesFormat: "Số tiền bằng %s không được tính vì không có tỷ giá hối đoái.",
+		AttachmentDescription:         "Danh sách giao dịch chi tiết của kỳ này được đính kèm trong email này.",
+		DescriptionBelowFormat:        "Bạn nhận được email này vì bạn đã đăng ký báo cáo định kỳ trong %s. Bạn có thể thay đổi hoặc hủy đăng ký bất cứ lúc nào.",
+	},
 }
@@ -6,8 +6,11 @@ import (
 
 var zhHans = &LocaleTextItems{
 	DefaultTypes: &DefaultTypes{
+		LongDateFormat:      core.LONG_DATE_FORMAT_YYYY_M_D,
 		DecimalSeparator:    core.DECIMAL_SEPARATOR_DOT,
 		DigitGroupingSymbol: core.DIGIT_GROUPING_SYMBOL_COMMA,
+		DigitGrouping:       core.DIGIT_GROUPING_TYPE_THOUSANDS_SEPARATOR,
+		CurrencyDisplayType: core.CURRENCY_DISPLAY_TYPE_SYMBOL_BEFORE_AMOUNT,
 	},
 	DataConverterTextItems: &DataConverterTextItems{
 		Alipay:       "支付宝",
@@ -27,4 +30,79 @@ var zhHans = &LocaleTextItems{
 		ResetPassword:             "重置密码",
 		DescriptionBelowBtnFormat: "如果您没有请求重置密码，请直接忽略本邮件。如果您无法点击上述链接，请复制下方的地址然后在您的浏览器中粘贴。重置密码链接将在 %v 分钟后过期。",
 	},
+	BillReminderMailTextItems: &BillReminderMailTextItems{
+		TitleFormat:               "即将到期的付款：%s",
+		DigestTitle:               "即将到期的付款",
+		SalutationFormat:          "%s 您好，",
+		Description:               "以下付款即将到期。",
+		DigestDescription:         "以下付款即将到期。",
+		Name:                      "名称",
+		Date:                      "日期",
+		Amount:                    "金额",
+		CreditCardStatementFormat: "%s 账单日",
+		DescriptionBelowFormat:    "您收到本邮件是因为您在 %s 中启用了提醒。您可以随时修改定时交易和信用卡账户的提醒设置。",
+	},
+	TransactionAnomalyAlertMailTextItems: &TransactionAnomalyAlertMailTextItems{
+		Title:                     "发现异常交易",
+		SalutationFormat:          "%s 您好，",
+		Description:               "与您的历史支出相比，以下交易看起来不太寻常。请确认这些交易是否正确。",
+		Date:                      "日期",
+		Comment:                   "描述",
+		Amount:                    "金额",
+		Reason:                    "原因",
+		CategoryOutlierFormat:     "远高于该分类的通常金额（超过 %s）",
+		NewPayeeLargeAmountFormat: "首次出现的收款方且金额较大（超过 %s）",
+		PossibleDuplicate:         "疑似与另一笔交易重复",
+		DescriptionBelowFormat:    "您收到本邮件是因为 %s 会检查您新增的交易是否存在异常支出。如果交易有误，您可以随时编辑或删除它。",
+	},
+	TransactionReportTextItems: &TransactionReportTextItems{
+		Title:                         "交易报表",
+		PeriodFormat:                  "期间：%s - %s",
+		GeneratedTimeFormat:           "生成于 %s",
+		Summary:                       "概要",
+		TotalIncome:                   "总收入",
+		TotalExpense:                  "总支出",
+		NetIncome:                     "净收入",
+		TransactionCount:              "交易笔数",
+		CurrencyDescriptionFormat:     "所有合计均已按最新汇率换算为 %s。",
+		UnconvertibleCurrenciesFormat: "由于汇率不可用，%s 的金额未计入合计。",
+		IncomeAndExpenseChart:         "收入与支出",
+		TopExpenseCategoriesChart:     "支出最多的分类",
+		IncomeByCategory:              "按分类统计收入",
+		ExpenseByCategory:             "按分类统计支出",
+		Category:                      "分类",
+		Amount:                        "金额",
+		Percentage:                    "占比",
+		Transactions:                  "交易",
+		Date:                          "日期",
+		Type:                          "类型",
+		Account:                       "账户",
+		Description:                   "描述",
+		Income:                        "收入",
+		Expense:                       "支出",
+		Transfer:                      "转账",
+		NoData:                        "没有数据",
+		PageFormat:                    "第 %d 页，共 %d 页",
+	},
+	ScheduledReportMailTextItems: &ScheduledReportMailTextItems{
+		WeeklyTitle:                   "每周报告",
+		MonthlyTitle:                  "每月报告",
+		SalutationFormat:              "%s，您好：",
+		DescriptionFormat:             "以下是您从 %s 到 %s 的财务摘要。",
+		TotalIncome:                   "总收入",
+		TotalExpense:                  "总支出",
+		NetIncome:                     "净收入",
+		TopExpenseCategories:          "主要支出分类",
+		Category:                      "分类",
+		Amount:                        "金额",
+		Percentage:                    "占比",
+		NoExpense:                     "本期间没有支出",
+		AccountBalances:               "账户余额",
+		Account:                       "账户",
+		Balance:                       "余额",
+		NetAssets:                     "净资产",
+		UnconvertibleCurrenciesFormat: "由于无法获取汇率，以 %s 计价的金额未计入统计。",
+		AttachmentDescription:         "本期间的详细交易列表已附在此邮件中。",
+		DescriptionBelowFormat:        "您收到此邮件是因为您在 %s 中订阅了定期报告。您可以随时修改或取消订阅。",
+	},
 }
@@ -6,8 +6,11 @@ import (
 
 var zhHant = &LocaleTextItems{
 	DefaultTypes: &DefaultTypes{
+		LongDateFormat:      core.LONG_DATE_FORMAT_YYYY_M_D,
 		DecimalSeparator:    core.DECIMAL_SEPARATOR_DOT,
 		DigitGroupingSymbol: core.DIGIT_GROUPING_SYMBOL_COMMA,
+		DigitGrouping:       core.DIGIT_GROUPING_TYPE_THOUSANDS_SEPARATOR,
+		CurrencyDisplayType: core.CURRENCY_DISPLAY_TYPE_SYMBOL_BEFORE_AMOUNT,
 	},
 	DataConverterTextItems: &DataConverterTextItems{
 		Alipay:       "支付寶",
@@ -27,4 +30,79 @@ var zhHant = &LocaleTextItems{
 		ResetPassword:             "重設密碼",
 		DescriptionBelowBtnFormat: "如果您沒有請求重設密碼，請直接忽略本郵件。如果您無法點擊上述連結，請複製下方的地址然後在您的瀏覽器中貼上。重設密碼連結將在 %v 分鐘後過期。",
 	},
+	BillReminderMailTextItems: &BillReminderMailTextItems{
+		TitleFormat:               "即將到期的付款：%s",
+		DigestTitle:               "即將到期的付款",
+		SalutationFormat:          "%s 您好，",
+		Description:               "以下付款即將到期。",
+		DigestDescription:         "以下付款即將到期。",
+		Name:                      "名稱",
+		Date:                      "日期",
+		Amount:                    "金額",
+		CreditCardStatementFormat: "%s 帳單日",
+		DescriptionBelowFormat:    "您收到本郵件是因為您在 %s 中啟用了提醒。您可以隨時修改定時交易和信用卡帳戶的提醒設定。",
+	},
+	TransactionAnomalyAlertMailTextItems: &TransactionAnomalyAlertMailTextItems{
+		Title:                     "發現異常交易",
+		SalutationFormat:          "%s 您好，",
+		Description:               "與您的歷史支出相比，以下交易看起來不太尋常。請確認這些交易是否正確。",
+		Date:                      "日期",
+		Comment:                   "描述",
+		Amount:                    "金額",
+		Reason:                    "原因",
+		CategoryOutlierFormat:     "遠高於該分類的通常金額（超過 %s）",
+		NewPayeeLargeAmountFormat: "首次出現的收款方且金額較大（超過 %s）",
+		PossibleDuplicate:         "疑似與另一筆交易重複",
+		DescriptionBelowFormat:    "您收到本郵件是因為 %s 會檢查您新增的交易是否存在異常支出。如果交易有誤，您可以隨時編輯或刪除它。",
+	},
+	TransactionReportTextItems: &TransactionReportTextItems{
+		Title:                         "交易報表",
+		PeriodFormat:                  "期間：%s - %s",
+		GeneratedTimeFormat:           "產生於 %s",
+		Summary:                       "概要",
+		TotalIncome:                   "總收入",
+		TotalExpense:                  "總支出",
+		NetIncome:                     "淨收入",
+		TransactionCount:              "交易筆數",
+		CurrencyDescriptionFormat:     "所有合計均已按最新匯率換算為 %s。",
+		UnconvertibleCurrenciesFormat: "由於匯率不可用，%s 的金額未計入合計。",
+		IncomeAndExpenseChart:         "收入與支出",
+		TopExpenseCategoriesChart:     "支出最多的分類",
+		IncomeByCategory:              "按分類統計收入",
+		ExpenseByCategory:             "按分類統計支出",
+		Category:                      "分類",
+		Amount:                        "金額",
+		Percentage:                    "占比",
+		Transactions:                  "交易",
+		Date:                          "日期",
+		Type:                          "類型",
+		Account:                       "帳戶",
+		Description:                   "描述",
+		Income:                        "收入",
+		Expense:                       "支出",
+		Transfer:                      "轉帳",
+		NoData:                        "沒有資料",
+		PageFormat:                    "第 %d 頁，共 %d 頁",
+	},
+	ScheduledReportMailTextItems: &ScheduledReportMailTextItems{
+		WeeklyTitle:                   "每週報告",
+		MonthlyTitle:                  "每月報告",
+		SalutationFormat:              "%s，您好：",
+		DescriptionFormat:             "以下是您從 %s 到 %s 的財務摘要。",
+		TotalIncome:                   "總收入",
+		TotalExpense:                  "總支出",
+		NetIncome:                     "淨收入",
+		TopExpenseCategories:          "主要支出分類",
+		Category:                      "分類",
+		Amount:                        "金額",
+		Percentage:                    "占比",
+		NoExpense:                     "本期間沒有支出",
+		AccountBalances:               "帳戶餘額",
+		Account:                       "帳戶",
+		Balance:                       "餘額",
+		NetAssets:                     "淨資產",
+		UnconvertibleCurrenciesFormat: "由於無法取得匯率，以 %s 計價的金額未計入統計。",
+		AttachmentDescription:         "本期間的詳細交易清單已附在此郵件中。",
+		DescriptionBelowFormat:        "您收到此郵件是因為您在 %s 中訂閱了定期報告。您可以隨時修改或取消訂閱。",
+	},
 }
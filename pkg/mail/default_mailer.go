@@ -2,6 +2,7 @@ package mail
 
 import (
 	"crypto/tls"
+	"io"
 	"net"
 
 	"gopkg.in/mail.v2"
@@ -57,6 +58,24 @@ func (m *DefaultMailer) SendMail(message *MailMessage) error {
 	mailMessage.SetHeader("Subject", message.Subject)
 	mailMessage.SetBody("text/html", message.Body)
 
+	for i := 0; i < len(message.Attachments); i++ {
+		attachment := message.Attachments[i]
+		fileSettings := []mail.FileSetting{
+			mail.SetCopyFunc(func(w io.Writer) error {
+				_, err := w.Write(attachment.Content)
+				return err
+			}),
+		}
+
+		if attachment.ContentType != "" {
+			fileSettings = append(fileSettings, mail.SetHeader(map[string][]string{
+				"Content-Type": {attachment.ContentType},
+			}))
+		}
+
+		mailMessage.Attach(attachment.FileName, fileSettings...)
+	}
+
 	err := m.dialer.DialAndSend(mailMessage)
 
 	return err
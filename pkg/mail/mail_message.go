@@ -2,7 +2,15 @@ package mail
 
 // MailMessage represents an email entity
 type MailMessage struct {
-	To      string
-	Subject string
-	Body    string
+	To          string
+	Subject     string
+	Body        string
+	Attachments []*MailAttachment
+}
+
+// MailAttachment represents an attachment file of email
+type MailAttachment struct {
+	FileName    string
+	ContentType string
+	Content     []byte
 }
@@ -1,6 +1,10 @@
 package models
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
 
 // LevelOneAccountParentId represents the parent id of level-one account
 const LevelOneAccountParentId = 0
@@ -21,6 +25,42 @@ const (
 	ACCOUNT_CATEGORY_CERTIFICATE_OF_DEPOSIT AccountCategory = 9
 )
 
+// String returns a textual representation of the account category enum
+func (c AccountCategory) String() string {
+	switch c {
+	case ACCOUNT_CATEGORY_CASH:
+		return "Cash"
+	case ACCOUNT_CATEGORY_CHECKING_ACCOUNT:
+		return "Checking Account"
+	case ACCOUNT_CATEGORY_CREDIT_CARD:
+		return "Credit Card"
+	case ACCOUNT_CATEGORY_VIRTUAL:
+		return "Virtual Account"
+	case ACCOUNT_CATEGORY_DEBT:
+		return "Debt Account"
+	case ACCOUNT_CATEGORY_RECEIVABLES:
+		return "Receivables"
+	case ACCOUNT_CATEGORY_INVESTMENT:
+		return "Investment Account"
+	case ACCOUNT_CATEGORY_SAVINGS_ACCOUNT:
+		return "Savings Account"
+	case ACCOUNT_CATEGORY_CERTIFICATE_OF_DEPOSIT:
+		return "Certificate of Deposit"
+	default:
+		return fmt.Sprintf("Invalid(%d)", int(c))
+	}
+}
+
+// IsAsset returns whether the account category belongs to assets
+func (c AccountCategory) IsAsset() bool {
+	return assetAccountCategory[c]
+}
+
+// IsLiability returns whether the account category belongs to liabilities
+func (c AccountCategory) IsLiability() bool {
+	return liabilityAccountCategory[c]
+}
+
 var assetAccountCategory = map[AccountCategory]bool{
 	ACCOUNT_CATEGORY_CASH:                   true,
 	ACCOUNT_CATEGORY_CHECKING_ACCOUNT:       true,
@@ -80,40 +120,114 @@ type Account struct {
 
 // AccountExtend represents account extend data stored in database
 type AccountExtend struct {
-	CreditCardStatementDate *int `json:"creditCardStatementDate"`
+	CreditCardStatementDate     *int   `json:"creditCardStatementDate"`
+	CreditCardReminderDays      *int   `json:"creditCardReminderDays,omitempty"`
+	CreditCardReminderDigest    *bool  `json:"creditCardReminderDigest,omitempty"`
+	CreditCardReminderUtcOffset *int16 `json:"creditCardReminderUtcOffset,omitempty"`
+	CurrencyPrecision           *int   `json:"currencyPrecision,omitempty"`
+}
+
+// GetCreditCardReminderDays returns how many days before the credit card statement date to send reminder, 0 means disabled
+func (e *AccountExtend) GetCreditCardReminderDays() int {
+	if e == nil || e.CreditCardReminderDays == nil {
+		return 0
+	}
+
+	return *e.CreditCardReminderDays
+}
+
+// IsCreditCardReminderDigest returns whether the credit card statement date reminder should be sent in digest mail
+func (e *AccountExtend) IsCreditCardReminderDigest() bool {
+	if e == nil || e.CreditCardReminderDigest == nil {
+		return false
+	}
+
+	return *e.CreditCardReminderDigest
+}
+
+// GetCreditCardReminderTimezone returns the timezone which the credit card statement date reminder is set in,
+// the server timezone is returned if the utc offset is not stored
+func (e *AccountExtend) GetCreditCardReminderTimezone() *time.Location {
+	if e == nil || e.CreditCardReminderUtcOffset == nil {
+		return time.Local
+	}
+
+	return time.FixedZone("Reminder Timezone", int(*e.CreditCardReminderUtcOffset)*60)
+}
+
+// GetCurrencyPrecision returns the decimal precision of the amounts in account currency
+func (e *AccountExtend) GetCurrencyPrecision() int {
+	if e == nil || e.CurrencyPrecision == nil {
+		return DEFAULT_CURRENCY_PRECISION
+	}
+
+	return *e.CurrencyPrecision
+}
+
+// GetCurrencyPrecision returns the decimal precision of the amounts in account currency
+func (a *Account) GetCurrencyPrecision() int {
+	return a.Extend.GetCurrencyPrecision()
+}
+
+// GetReminderCreditCardStatementTime returns the first time of the credit card statement date if it is reminder days later (in specified timezone), returns 0 if not
+func (a *Account) GetReminderCreditCardStatementTime(currentUnixTime int64, timezone *time.Location) int64 {
+	if a.ParentAccountId != LevelOneAccountParentId || a.Category != ACCOUNT_CATEGORY_CREDIT_CARD || a.Extend == nil || a.Extend.CreditCardStatementDate == nil || *a.Extend.CreditCardStatementDate <= 0 {
+		return 0
+	}
+
+	reminderDays := a.Extend.GetCreditCardReminderDays()
+
+	if reminderDays <= 0 {
+		return 0
+	}
+
+	currentTime := time.Unix(currentUnixTime, 0).In(timezone)
+	reminderDate := time.Date(currentTime.Year(), currentTime.Month(), currentTime.Day()+reminderDays, 0, 0, 0, 0, timezone)
+
+	if reminderDate.Day() != *a.Extend.CreditCardStatementDate {
+		return 0
+	}
+
+	return reminderDate.Unix()
 }
 
 // AccountCreateRequest represents all parameters of account creation request
 type AccountCreateRequest struct {
-	Name                    string                  `json:"name" binding:"required,notBlank,max=64"`
-	Category                AccountCategory         `json:"category" binding:"required"`
-	Type                    AccountType             `json:"type" binding:"required"`
-	Icon                    int64                   `json:"icon,string" binding:"required,min=1"`
-	Color                   string                  `json:"color" binding:"required,len=6,validHexRGBColor"`
-	Currency                string                  `json:"currency" binding:"required,len=3,validCurrency"`
-	Balance                 int64                   `json:"balance"`
-	BalanceTime             int64                   `json:"balanceTime"`
-	Comment                 string                  `json:"comment" binding:"max=255"`
-	CreditCardStatementDate int                     `json:"creditCardStatementDate" binding:"min=0,max=28"`
-	SubAccounts             []*AccountCreateRequest `json:"subAccounts" binding:"omitempty"`
-	ClientSessionId         string                  `json:"clientSessionId"`
+	Name                        string                  `json:"name" binding:"required,notBlank,max=64"`
+	Category                    AccountCategory         `json:"category" binding:"required"`
+	Type                        AccountType             `json:"type" binding:"required"`
+	Icon                        int64                   `json:"icon,string" binding:"required,min=1"`
+	Color                       string                  `json:"color" binding:"required,len=6,validHexRGBColor"`
+	Currency                    string                  `json:"currency" binding:"required,len=3,validCurrencyCode"`
+	Balance                     int64                   `json:"balance"`
+	BalanceTime                 int64                   `json:"balanceTime"`
+	Comment                     string                  `json:"comment" binding:"max=255"`
+	CreditCardStatementDate     int                     `json:"creditCardStatementDate" binding:"min=0,max=28"`
+	CreditCardReminderDays      int                     `json:"creditCardReminderDays" binding:"min=0,max=30"`
+	CreditCardReminderDigest    bool                    `json:"creditCardReminderDigest"`
+	CreditCardReminderUtcOffset int16                   `json:"creditCardReminderUtcOffset" binding:"min=-720,max=840"`
+	SubAccounts                 []*AccountCreateRequest `json:"subAccounts" binding:"omitempty"`
+	ClientSessionId             string                  `json:"clientSessionId"`
 }
 
 // AccountModifyRequest represents all parameters of account modification request
 type AccountModifyRequest struct {
-	Id                      int64                   `json:"id,string" binding:"required,min=0"`
-	Name                    string                  `json:"name" binding:"required,notBlank,max=64"`
-	Category                AccountCategory         `json:"category" binding:"required"`
-	Icon                    int64                   `json:"icon,string" binding:"min=1"`
-	Color                   string                  `json:"color" binding:"required,len=6,validHexRGBColor"`
-	Currency                *string                 `json:"currency" binding:"omitempty,len=3,validCurrency"`
-	Balance                 *int64                  `json:"balance" binding:"omitempty"`
-	BalanceTime             *int64                  `json:"balanceTime" binding:"omitempty"`
-	Comment                 string                  `json:"comment" binding:"max=255"`
-	CreditCardStatementDate int                     `json:"creditCardStatementDate" binding:"min=0,max=28"`
-	Hidden                  bool                    `json:"hidden"`
-	SubAccounts             []*AccountModifyRequest `json:"subAccounts" binding:"omitempty"`
-	ClientSessionId         string                  `json:"clientSessionId"`
+	Id                          int64                   `json:"id,string" binding:"required,min=0"`
+	Name                        string                  `json:"name" binding:"required,notBlank,max=64"`
+	Category                    AccountCategory         `json:"category" binding:"required"`
+	Icon                        int64                   `json:"icon,string" binding:"min=1"`
+	Color                       string                  `json:"color" binding:"required,len=6,validHexRGBColor"`
+	Currency                    *string                 `json:"currency" binding:"omitempty,len=3,validCurrencyCode"`
+	Balance                     *int64                  `json:"balance" binding:"omitempty"`
+	BalanceTime                 *int64                  `json:"balanceTime" binding:"omitempty"`
+	Comment                     string                  `json:"comment" binding:"max=255"`
+	CreditCardStatementDate     int                     `json:"creditCardStatementDate" binding:"min=0,max=28"`
+	CreditCardReminderDays      int                     `json:"creditCardReminderDays" binding:"min=0,max=30"`
+	CreditCardReminderDigest    bool                    `json:"creditCardReminderDigest"`
+	CreditCardReminderUtcOffset int16                   `json:"creditCardReminderUtcOffset" binding:"min=-720,max=840"`
+	Hidden                      bool                    `json:"hidden"`
+	SubAccounts                 []*AccountModifyRequest `json:"subAccounts" binding:"omitempty"`
+	ClientSessionId             string                  `json:"clientSessionId"`
 }
 
 // AccountListRequest represents all parameters of account listing request
@@ -150,52 +264,71 @@ type AccountDeleteRequest struct {
 
 // AccountInfoResponse represents a view-object of account
 type AccountInfoResponse struct {
-	Id                      int64                    `json:"id,string"`
-	Name                    string                   `json:"name"`
-	ParentId                int64                    `json:"parentId,string"`
-	Category                AccountCategory          `json:"category"`
-	Type                    AccountType              `json:"type"`
-	Icon                    int64                    `json:"icon,string"`
-	Color                   string                   `json:"color"`
-	Currency                string                   `json:"currency"`
-	Balance                 int64                    `json:"balance"`
-	Comment                 string                   `json:"comment"`
-	CreditCardStatementDate *int                     `json:"creditCardStatementDate,omitempty"`
-	DisplayOrder            int32                    `json:"displayOrder"`
-	IsAsset                 bool                     `json:"isAsset,omitempty"`
-	IsLiability             bool                     `json:"isLiability,omitempty"`
-	Hidden                  bool                     `json:"hidden"`
-	SubAccounts             AccountInfoResponseSlice `json:"subAccounts,omitempty"`
+	Id                          int64                    `json:"id,string"`
+	Name                        string                   `json:"name"`
+	ParentId                    int64                    `json:"parentId,string"`
+	Category                    AccountCategory          `json:"category"`
+	Type                        AccountType              `json:"type"`
+	Icon                        int64                    `json:"icon,string"`
+	Color                       string                   `json:"color"`
+	Currency                    string                   `json:"currency"`
+	CurrencyPrecision           *int                     `json:"currencyPrecision,omitempty"`
+	Balance                     int64                    `json:"balance"`
+	Comment                     string                   `json:"comment"`
+	CreditCardStatementDate     *int                     `json:"creditCardStatementDate,omitempty"`
+	CreditCardReminderDays      *int                     `json:"creditCardReminderDays,omitempty"`
+	CreditCardReminderDigest    *bool                    `json:"creditCardReminderDigest,omitempty"`
+	CreditCardReminderUtcOffset *int16                   `json:"creditCardReminderUtcOffset,omitempty"`
+	DisplayOrder                int32                    `json:"displayOrder"`
+	IsAsset                     bool                     `json:"isAsset,omitempty"`
+	IsLiability                 bool                     `json:"isLiability,omitempty"`
+	Hidden                      bool                     `json:"hidden"`
+	SubAccounts                 AccountInfoResponseSlice `json:"subAccounts,omitempty"`
 }
 
 // ToAccountInfoResponse returns a view-object according to database model
 func (a *Account) ToAccountInfoResponse() *AccountInfoResponse {
 	var creditCardStatementDate *int
+	var creditCardReminderDays *int
+	var creditCardReminderDigest *bool
+	var creditCardReminderUtcOffset *int16
+	var currencyPrecision *int
+
+	if a.Extend != nil {
+		currencyPrecision = a.Extend.CurrencyPrecision
+	}
 
 	if a.ParentAccountId == LevelOneAccountParentId && a.Category == ACCOUNT_CATEGORY_CREDIT_CARD {
 		if a.Extend != nil {
 			creditCardStatementDate = a.Extend.CreditCardStatementDate
+			creditCardReminderDays = a.Extend.CreditCardReminderDays
+			creditCardReminderDigest = a.Extend.CreditCardReminderDigest
+			creditCardReminderUtcOffset = a.Extend.CreditCardReminderUtcOffset
 		} else {
 			creditCardStatementDate = &defaultCreditCardAccountStatementDate
 		}
 	}
 
 	return &AccountInfoResponse{
-		Id:                      a.AccountId,
-		Name:                    a.Name,
-		ParentId:                a.ParentAccountId,
-		Category:                a.Category,
-		Type:                    a.Type,
-		Icon:                    a.Icon,
-		Color:                   a.Color,
-		Currency:                a.Currency,
-		Balance:                 a.Balance,
-		Comment:                 a.Comment,
-		CreditCardStatementDate: creditCardStatementDate,
-		DisplayOrder:            a.DisplayOrder,
-		IsAsset:                 assetAccountCategory[a.Category],
-		IsLiability:             liabilityAccountCategory[a.Category],
-		Hidden:                  a.Hidden,
+		Id:                          a.AccountId,
+		Name:                        a.Name,
+		ParentId:                    a.ParentAccountId,
+		Category:                    a.Category,
+		Type:                        a.Type,
+		Icon:                        a.Icon,
+		Color:                       a.Color,
+		Currency:                    a.Currency,
+		CurrencyPrecision:           currencyPrecision,
+		Balance:                     a.Balance,
+		Comment:                     a.Comment,
+		CreditCardStatementDate:     creditCardStatementDate,
+		CreditCardReminderDays:      creditCardReminderDays,
+		CreditCardReminderDigest:    creditCardReminderDigest,
+		CreditCardReminderUtcOffset: creditCardReminderUtcOffset,
+		DisplayOrder:                a.DisplayOrder,
+		IsAsset:                     assetAccountCategory[a.Category],
+		IsLiability:                 liabilityAccountCategory[a.Category],
+		Hidden:                      a.Hidden,
 	}
 }
 
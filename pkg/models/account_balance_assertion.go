@@ -0,0 +1,196 @@
+package models
+
+// AccountBalanceAssertion represents account balance assertion data stored in database
+type AccountBalanceAssertion struct {
+	AssertionId       int64  `xorm:"PK"`
+	Uid               int64  `xorm:"INDEX(IDX_account_balance_assertion_uid_deleted_account_id_time) NOT NULL"`
+	Deleted           bool   `xorm:"INDEX(IDX_account_balance_assertion_uid_deleted_account_id_time) NOT NULL"`
+	AccountId         int64  `xorm:"INDEX(IDX_account_balance_assertion_uid_deleted_account_id_time) NOT NULL"`
+	AssertionTime     int64  `xorm:"INDEX(IDX_account_balance_assertion_uid_deleted_account_id_time) NOT NULL"`
+	TimezoneUtcOffset int16  `xorm:"NOT NULL"`
+	ExpectedBalance   int64  `xorm:"NOT NULL"`
+	Comment           string `xorm:"VARCHAR(255) NOT NULL"`
+	CreatedUnixTime   int64
+	UpdatedUnixTime   int64
+	DeletedUnixTime   int64
+}
+
+// AccountBalanceAssertionListRequest represents all parameters of account balance assertion listing request
+type AccountBalanceAssertionListRequest struct {
+	AccountId int64 `form:"account_id,string" binding:"min=0"`
+}
+
+// AccountBalanceAssertionGetRequest represents all parameters of account balance assertion getting request
+type AccountBalanceAssertionGetRequest struct {
+	Id int64 `form:"id,string" binding:"required,min=1"`
+}
+
+// AccountBalanceAssertionCreateRequest represents all parameters of account balance assertion creation request
+type AccountBalanceAssertionCreateRequest struct {
+	AccountId       int64  `json:"accountId,string" binding:"required,min=1"`
+	Time            int64  `json:"time" binding:"required,min=1"`
+	UtcOffset       int16  `json:"utcOffset" binding:"min=-720,max=840"`
+	ExpectedBalance int64  `json:"expectedBalance" binding:"min=-99999999999,max=99999999999"`
+	Comment         string `json:"comment" binding:"max=255"`
+}
+
+// ImportAccountBalanceAssertionCreateRequest represents all parameters of account balance assertion creation request in transaction import,
+// the account id could be empty if the account did not exist when parsing the import file, and then it is resolved by the original account name
+type ImportAccountBalanceAssertionCreateRequest struct {
+	AccountId           int64  `json:"accountId,string" binding:"min=0"`
+	OriginalAccountName string `json:"originalAccountName"`
+	Time                int64  `json:"time" binding:"required,min=1"`
+	UtcOffset           int16  `json:"utcOffset" binding:"min=-720,max=840"`
+	ExpectedBalance     int64  `json:"expectedBalance" binding:"min=-99999999999,max=99999999999"`
+	Comment             string `json:"comment" binding:"max=255"`
+}
+
+// AccountBalanceAssertionModifyRequest represents all parameters of account balance assertion modification request
+type AccountBalanceAssertionModifyRequest struct {
+	Id              int64  `json:"id,string" binding:"required,min=1"`
+	Time            int64  `json:"time" binding:"required,min=1"`
+	UtcOffset       int16  `json:"utcOffset" binding:"min=-720,max=840"`
+	ExpectedBalance int64  `json:"expectedBalance" binding:"min=-99999999999,max=99999999999"`
+	Comment         string `json:"comment" binding:"max=255"`
+}
+
+// AccountBalanceAssertionDeleteRequest represents all parameters of account balance assertion deleting request
+type AccountBalanceAssertionDeleteRequest struct {
+	Id int64 `json:"id,string" binding:"required,min=1"`
+}
+
+// AccountBalanceAssertionCheckRequest represents all parameters of account balance assertion checking request
+type AccountBalanceAssertionCheckRequest struct {
+	AccountId  int64 `form:"account_id,string" binding:"min=0"`
+	FailedOnly bool  `form:"failed_only"`
+}
+
+// AccountBalanceAssertionInfoResponse represents a view-object of account balance assertion
+type AccountBalanceAssertionInfoResponse struct {
+	Id              int64  `json:"id,string"`
+	AccountId       int64  `json:"accountId,string"`
+	Time            int64  `json:"time"`
+	UtcOffset       int16  `json:"utcOffset"`
+	ExpectedBalance int64  `json:"expectedBalance"`
+	Comment         string `json:"comment"`
+}
+
+// AccountBalanceAssertionCheckResult represents the check result of an account balance assertion
+type AccountBalanceAssertionCheckResult struct {
+	Assertion                   *AccountBalanceAssertion
+	ActualBalance               int64
+	Passed                      bool
+	TransactionsSinceLastPassed []*Transaction
+}
+
+// AccountBalanceAssertionCheckResultResponse represents a view-object of account balance assertion check result
+type AccountBalanceAssertionCheckResultResponse struct {
+	Assertion                   *AccountBalanceAssertionInfoResponse `json:"assertion"`
+	ActualBalance               int64                                `json:"actualBalance"`
+	Difference                  int64                                `json:"difference"`
+	Passed                      bool                                 `json:"passed"`
+	TransactionsSinceLastPassed TransactionInfoResponseSlice         `json:"transactionsSinceLastPassed,omitempty"`
+}
+
+// ToAccountBalanceAssertionInfoResponse returns a view-object according to database model
+func (a *AccountBalanceAssertion) ToAccountBalanceAssertionInfoResponse() *AccountBalanceAssertionInfoResponse {
+	return &AccountBalanceAssertionInfoResponse{
+		Id:              a.AssertionId,
+		AccountId:       a.AccountId,
+		Time:            a.AssertionTime,
+		UtcOffset:       a.TimezoneUtcOffset,
+		ExpectedBalance: a.ExpectedBalance,
+		Comment:         a.Comment,
+	}
+}
+
+// ToAccountBalanceAssertionCheckResultResponse returns a view-object according to the check result
+func (r *AccountBalanceAssertionCheckResult) ToAccountBalanceAssertionCheckResultResponse(allTransactionTagIds map[int64][]int64) *AccountBalanceAssertionCheckResultResponse {
+	var transactionResps TransactionInfoResponseSlice
+
+	if !r.Passed {
+		transactionResps = make(TransactionInfoResponseSlice, 0, len(r.TransactionsSinceLastPassed))
+
+		for i := 0; i < len(r.TransactionsSinceLastPassed); i++ {
+			transaction := r.TransactionsSinceLastPassed[i]
+			transactionResp := transaction.ToTransactionInfoResponse(allTransactionTagIds[transaction.TransactionId], false)
+
+			if transactionResp != nil {
+				transactionResps = append(transactionResps, transactionResp)
+			}
+		}
+	}
+
+	return &AccountBalanceAssertionCheckResultResponse{
+		Assertion:                   r.Assertion.ToAccountBalanceAssertionInfoResponse(),
+		ActualBalance:               r.ActualBalance,
+		Difference:                  r.ActualBalance - r.Assertion.ExpectedBalance,
+		Passed:                      r.Passed,
+		TransactionsSinceLastPassed: transactionResps,
+	}
+}
+
+// AccountBalanceAssertionInfoResponseSlice represents the slice data structure of AccountBalanceAssertionInfoResponse
+type AccountBalanceAssertionInfoResponseSlice []*AccountBalanceAssertionInfoResponse
+
+// Len returns the count of items
+func (s AccountBalanceAssertionInfoResponseSlice) Len() int {
+	return len(s)
+}
+
+// Swap swaps two items
+func (s AccountBalanceAssertionInfoResponseSlice) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+}
+
+// Less reports whether the first item is less than the second one
+func (s AccountBalanceAssertionInfoResponseSlice) Less(i, j int) bool {
+	if s[i].AccountId != s[j].AccountId {
+		return s[i].AccountId < s[j].AccountId
+	}
+
+	return s[i].Time < s[j].Time
+}
+
+// ImportAccountBalanceAssertion represents the imported account balance assertion data
+type ImportAccountBalanceAssertion struct {
+	*AccountBalanceAssertion
+	OriginalAccountName     string
+	OriginalAccountCurrency string
+}
+
+// ImportAccountBalanceAssertionResponse represents a view-object of the imported account balance assertion data
+type ImportAccountBalanceAssertionResponse struct {
+	AccountId               int64  `json:"accountId,string"`
+	OriginalAccountName     string `json:"originalAccountName"`
+	OriginalAccountCurrency string `json:"originalAccountCurrency"`
+	Time                    int64  `json:"time"`
+	UtcOffset               int16  `json:"utcOffset"`
+	ExpectedBalance         int64  `json:"expectedBalance"`
+}
+
+// ToImportAccountBalanceAssertionResponse returns the a view-object according to imported account balance assertion data
+func (a ImportAccountBalanceAssertion) ToImportAccountBalanceAssertionResponse() *ImportAccountBalanceAssertionResponse {
+	return &ImportAccountBalanceAssertionResponse{
+		AccountId:               a.AccountId,
+		OriginalAccountName:     a.OriginalAccountName,
+		OriginalAccountCurrency: a.OriginalAccountCurrency,
+		Time:                    a.AssertionTime,
+		UtcOffset:               a.TimezoneUtcOffset,
+		ExpectedBalance:         a.ExpectedBalance,
+	}
+}
+
+// ImportAccountBalanceAssertionSlice represents the slice data structure of import account balance assertion data
+type ImportAccountBalanceAssertionSlice []*ImportAccountBalanceAssertion
+
+// ToImportAccountBalanceAssertionResponseList returns a list of view-objects according to imported account balance assertion data
+func (s ImportAccountBalanceAssertionSlice) ToImportAccountBalanceAssertionResponseList() []*ImportAccountBalanceAssertionResponse {
+	result := make([]*ImportAccountBalanceAssertionResponse, len(s))
+
+	for i := 0; i < len(s); i++ {
+		result[i] = s[i].ToImportAccountBalanceAssertionResponse()
+	}
+
+	return result
+}
@@ -0,0 +1,74 @@
+package models
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccountBalanceAssertionInfoResponseSliceLess(t *testing.T) {
+	var assertionRespSlice AccountBalanceAssertionInfoResponseSlice
+	assertionRespSlice = append(assertionRespSlice, &AccountBalanceAssertionInfoResponse{
+		Id:        1,
+		AccountId: 2,
+		Time:      100,
+	})
+	assertionRespSlice = append(assertionRespSlice, &AccountBalanceAssertionInfoResponse{
+		Id:        2,
+		AccountId: 1,
+		Time:      200,
+	})
+	assertionRespSlice = append(assertionRespSlice, &AccountBalanceAssertionInfoResponse{
+		Id:        3,
+		AccountId: 1,
+		Time:      100,
+	})
+
+	sort.Sort(assertionRespSlice)
+
+	assert.Equal(t, int64(3), assertionRespSlice[0].Id)
+	assert.Equal(t, int64(2), assertionRespSlice[1].Id)
+	assert.Equal(t, int64(1), assertionRespSlice[2].Id)
+}
+
+func TestAccountBalanceAssertionCheckResultToResponse(t *testing.T) {
+	assertion := &AccountBalanceAssertion{
+		AssertionId:     1,
+		AccountId:       2,
+		AssertionTime:   1725148800,
+		ExpectedBalance: 1000,
+	}
+
+	passedResult := &AccountBalanceAssertionCheckResult{
+		Assertion:     assertion,
+		ActualBalance: 1000,
+		Passed:        true,
+	}
+
+	passedResultResp := passedResult.ToAccountBalanceAssertionCheckResultResponse(nil)
+	assert.Equal(t, true, passedResultResp.Passed)
+	assert.Equal(t, int64(0), passedResultResp.Difference)
+	assert.Nil(t, passedResultResp.TransactionsSinceLastPassed)
+
+	failedResult := &AccountBalanceAssertionCheckResult{
+		Assertion:     assertion,
+		ActualBalance: 900,
+		Passed:        false,
+		TransactionsSinceLastPassed: []*Transaction{
+			{
+				TransactionId: 3,
+				Type:          TRANSACTION_DB_TYPE_EXPENSE,
+				AccountId:     2,
+				Amount:        100,
+			},
+		},
+	}
+
+	failedResultResp := failedResult.ToAccountBalanceAssertionCheckResultResponse(map[int64][]int64{3: {4}})
+	assert.Equal(t, false, failedResultResp.Passed)
+	assert.Equal(t, int64(-100), failedResultResp.Difference)
+	assert.Equal(t, 1, len(failedResultResp.TransactionsSinceLastPassed))
+	assert.Equal(t, int64(3), failedResultResp.TransactionsSinceLastPassed[0].Id)
+	assert.Equal(t, []string{"4"}, failedResultResp.TransactionsSinceLastPassed[0].TagIds)
+}
@@ -3,6 +3,7 @@ package models
 import (
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -49,3 +50,40 @@ func TestAccountInfoResponseSliceLess(t *testing.T) {
 	assert.Equal(t, int64(5), accountRespSlice[4].Id)
 	assert.Equal(t, int64(3), accountRespSlice[5].Id)
 }
+
+func TestAccountGetReminderCreditCardStatementTime(t *testing.T) {
+	timezone := time.FixedZone("Server Timezone", 480*60)
+	statementDate := 5
+	reminderDays := 7
+	account := &Account{
+		Category:        ACCOUNT_CATEGORY_CREDIT_CARD,
+		ParentAccountId: LevelOneAccountParentId,
+		Extend: &AccountExtend{
+			CreditCardStatementDate: &statementDate,
+			CreditCardReminderDays:  &reminderDays,
+		},
+	}
+
+	assert.Equal(t, time.Date(2024, 10, 5, 0, 0, 0, 0, timezone).Unix(), account.GetReminderCreditCardStatementTime(time.Date(2024, 9, 28, 10, 0, 0, 0, timezone).Unix(), timezone))
+	assert.Equal(t, int64(0), account.GetReminderCreditCardStatementTime(time.Date(2024, 9, 27, 10, 0, 0, 0, timezone).Unix(), timezone))
+
+	account.Category = ACCOUNT_CATEGORY_CASH
+	assert.Equal(t, int64(0), account.GetReminderCreditCardStatementTime(time.Date(2024, 9, 28, 10, 0, 0, 0, timezone).Unix(), timezone))
+
+	account.Category = ACCOUNT_CATEGORY_CREDIT_CARD
+	account.Extend.CreditCardReminderDays = nil
+	assert.Equal(t, int64(0), account.GetReminderCreditCardStatementTime(time.Date(2024, 9, 28, 10, 0, 0, 0, timezone).Unix(), timezone))
+}
+
+func TestAccountExtendGetCreditCardReminderTimezone(t *testing.T) {
+	var extend *AccountExtend
+	assert.Equal(t, time.Local, extend.GetCreditCardReminderTimezone())
+
+	extend = &AccountExtend{}
+	assert.Equal(t, time.Local, extend.GetCreditCardReminderTimezone())
+
+	utcOffset := int16(-300)
+	extend.CreditCardReminderUtcOffset = &utcOffset
+	_, offset := time.Date(2024, 9, 28, 10, 0, 0, 0, extend.GetCreditCardReminderTimezone()).Zone()
+	assert.Equal(t, -300*60, offset)
+}
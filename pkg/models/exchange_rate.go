@@ -1,6 +1,10 @@
 package models
 
-import "strings"
+import (
+	"math"
+	"strconv"
+	"strings"
+)
 
 // LatestExchangeRateResponse returns a view-object which contains latest exchange rate
 type LatestExchangeRateResponse struct {
@@ -11,10 +15,63 @@ type LatestExchangeRateResponse struct {
 	ExchangeRates LatestExchangeRateSlice `json:"exchangeRates"`
 }
 
+// ExchangeRatesMap represents the exchange rates of all currencies which are relative to the same base currency
+type ExchangeRatesMap map[string]float64
+
+// ToExchangeRatesMap returns the map of currency to exchange rate, the invalid exchange rates are ignored
+func (r *LatestExchangeRateResponse) ToExchangeRatesMap() ExchangeRatesMap {
+	exchangeRatesMap := make(ExchangeRatesMap, len(r.ExchangeRates))
+
+	for i := 0; i < len(r.ExchangeRates); i++ {
+		exchangeRate := r.ExchangeRates[i]
+		rate, err := strconv.ParseFloat(exchangeRate.Rate, 64)
+
+		if err != nil || rate <= 0 {
+			continue
+		}
+
+		exchangeRatesMap[exchangeRate.Currency] = rate
+	}
+
+	return exchangeRatesMap
+}
+
+// ConvertAmount returns the amount converted from the source currency to the target currency, and returns false if any exchange rate is missing
+func (m ExchangeRatesMap) ConvertAmount(amount int64, fromCurrency string, toCurrency string) (int64, bool) {
+	return m.ConvertAmountWithPrecision(amount, fromCurrency, DEFAULT_CURRENCY_PRECISION, toCurrency, DEFAULT_CURRENCY_PRECISION)
+}
+
+// ConvertAmountWithPrecision returns the amount converted from the source currency to the target currency,
+// the amounts are scaled by the decimal precision of each currency, and returns false if any exchange rate is missing
+func (m ExchangeRatesMap) ConvertAmountWithPrecision(amount int64, fromCurrency string, fromPrecision int, toCurrency string, toPrecision int) (int64, bool) {
+	if fromCurrency == toCurrency {
+		if fromPrecision == toPrecision {
+			return amount, true
+		}
+
+		return int64(math.Round(float64(amount) * math.Pow10(toPrecision-fromPrecision))), true
+	}
+
+	fromRate, exists := m[fromCurrency]
+
+	if !exists {
+		return 0, false
+	}
+
+	toRate, exists := m[toCurrency]
+
+	if !exists {
+		return 0, false
+	}
+
+	return int64(math.Round(float64(amount) * toRate / fromRate * math.Pow10(toPrecision-fromPrecision))), true
+}
+
 // LatestExchangeRate represents a data pair of currency and exchange rate
 type LatestExchangeRate struct {
-	Currency string `json:"currency"`
-	Rate     string `json:"rate"`
+	Currency   string `json:"currency"`
+	Rate       string `json:"rate"`
+	DataSource string `json:"dataSource,omitempty"`
 }
 
 // LatestExchangeRateSlice represents the slice data structure of LatestExchangeRate
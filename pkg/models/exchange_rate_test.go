@@ -25,3 +25,81 @@ func TestLatestExchangeRateSliceLess(t *testing.T) {
 	assert.Equal(t, "EUR", latestExchangeRateSlice[1].Currency)
 	assert.Equal(t, "USD", latestExchangeRateSlice[2].Currency)
 }
+
+func TestLatestExchangeRateResponseToExchangeRatesMap(t *testing.T) {
+	resp := &LatestExchangeRateResponse{
+		BaseCurrency: "EUR",
+		ExchangeRates: LatestExchangeRateSlice{
+			{Currency: "EUR", Rate: "1"},
+			{Currency: "USD", Rate: "1.25"},
+			{Currency: "JPY", Rate: "invalid"},
+			{Currency: "CNY", Rate: "0"},
+		},
+	}
+
+	exchangeRatesMap := resp.ToExchangeRatesMap()
+	assert.Equal(t, 2, len(exchangeRatesMap))
+	assert.Equal(t, float64(1), exchangeRatesMap["EUR"])
+	assert.Equal(t, 1.25, exchangeRatesMap["USD"])
+}
+
+func TestExchangeRatesMapConvertAmount(t *testing.T) {
+	exchangeRatesMap := ExchangeRatesMap{
+		"EUR": 1,
+		"USD": 1.25,
+		"JPY": 160,
+	}
+
+	amount, success := exchangeRatesMap.ConvertAmount(10000, "EUR", "USD")
+	assert.True(t, success)
+	assert.Equal(t, int64(12500), amount)
+
+	amount, success = exchangeRatesMap.ConvertAmount(12500, "USD", "JPY")
+	assert.True(t, success)
+	assert.Equal(t, int64(1600000), amount)
+
+	amount, success = exchangeRatesMap.ConvertAmount(-333, "USD", "EUR")
+	assert.True(t, success)
+	assert.Equal(t, int64(-266), amount)
+
+	amount, success = exchangeRatesMap.ConvertAmount(100, "CNY", "CNY")
+	assert.True(t, success)
+	assert.Equal(t, int64(100), amount)
+
+	_, success = exchangeRatesMap.ConvertAmount(100, "CNY", "EUR")
+	assert.False(t, success)
+
+	_, success = exchangeRatesMap.ConvertAmount(100, "EUR", "CNY")
+	assert.False(t, success)
+}
+
+func TestExchangeRatesMapConvertAmountWithPrecision(t *testing.T) {
+	exchangeRatesMap := ExchangeRatesMap{
+		"USD": 1,
+		"BTC": 0.00001,
+		"PTS": 100,
+	}
+
+	amount, success := exchangeRatesMap.ConvertAmountWithPrecision(150000000, "BTC", 8, "USD", 2)
+	assert.True(t, success)
+	assert.Equal(t, int64(15000000), amount)
+
+	amount, success = exchangeRatesMap.ConvertAmountWithPrecision(1000, "USD", 2, "BTC", 8)
+	assert.True(t, success)
+	assert.Equal(t, int64(10000), amount)
+
+	amount, success = exchangeRatesMap.ConvertAmountWithPrecision(250, "PTS", 0, "USD", 2)
+	assert.True(t, success)
+	assert.Equal(t, int64(250), amount)
+
+	amount, success = exchangeRatesMap.ConvertAmountWithPrecision(123, "ETH", 8, "ETH", 8)
+	assert.True(t, success)
+	assert.Equal(t, int64(123), amount)
+
+	amount, success = exchangeRatesMap.ConvertAmountWithPrecision(123, "ETH", 0, "ETH", 2)
+	assert.True(t, success)
+	assert.Equal(t, int64(12300), amount)
+
+	_, success = exchangeRatesMap.ConvertAmountWithPrecision(100, "ETH", 8, "USD", 2)
+	assert.False(t, success)
+}
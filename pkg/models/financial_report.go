@@ -0,0 +1,174 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+const financialReportCsvIndent = "  "
+
+// FinancialReportComparisonType represents the comparison period type of financial report
+type FinancialReportComparisonType byte
+
+// Financial report comparison types
+const (
+	FINANCIAL_REPORT_COMPARISON_TYPE_NONE                  FinancialReportComparisonType = 0
+	FINANCIAL_REPORT_COMPARISON_TYPE_PREVIOUS_PERIOD       FinancialReportComparisonType = 1
+	FINANCIAL_REPORT_COMPARISON_TYPE_SAME_PERIOD_LAST_YEAR FinancialReportComparisonType = 2
+)
+
+// FinancialReportRequest represents all parameters of income statement or balance sheet request
+type FinancialReportRequest struct {
+	StartTime              int64                         `form:"start_time" binding:"required,min=1"`
+	EndTime                int64                         `form:"end_time" binding:"required,min=1"`
+	ComparisonType         FinancialReportComparisonType `form:"comparison_type" binding:"min=0,max=2"`
+	UseTransactionTimezone bool                          `form:"use_transaction_timezone"`
+}
+
+// FinancialReportItem represents an item of the financial report tree, the amount of the item which has children is the subtotal of its children
+type FinancialReportItem struct {
+	Id               int64                  `json:"id,string"`
+	Name             string                 `json:"name"`
+	Amount           int64                  `json:"amount"`
+	ComparisonAmount int64                  `json:"comparisonAmount"`
+	Children         []*FinancialReportItem `json:"children,omitempty"`
+}
+
+// IncomeStatementResponse represents a view-object of income statement (profit and loss)
+type IncomeStatementResponse struct {
+	StartTime               int64                `json:"startTime"`
+	EndTime                 int64                `json:"endTime"`
+	ComparisonStartTime     int64                `json:"comparisonStartTime,omitempty"`
+	ComparisonEndTime       int64                `json:"comparisonEndTime,omitempty"`
+	Currency                string               `json:"currency"`
+	Income                  *FinancialReportItem `json:"income"`
+	Expense                 *FinancialReportItem `json:"expense"`
+	NetIncome               int64                `json:"netIncome"`
+	ComparisonNetIncome     int64                `json:"comparisonNetIncome"`
+	UnconvertibleCurrencies []string             `json:"unconvertibleCurrencies,omitempty"`
+}
+
+// BalanceSheetResponse represents a view-object of balance sheet, the liabilities are presented as positive amounts
+type BalanceSheetResponse struct {
+	Time                    int64                `json:"time"`
+	ComparisonTime          int64                `json:"comparisonTime,omitempty"`
+	Currency                string               `json:"currency"`
+	Assets                  *FinancialReportItem `json:"assets"`
+	Liabilities             *FinancialReportItem `json:"liabilities"`
+	NetAssets               int64                `json:"netAssets"`
+	ComparisonNetAssets     int64                `json:"comparisonNetAssets"`
+	UnconvertibleCurrencies []string             `json:"unconvertibleCurrencies,omitempty"`
+}
+
+// GetComparisonTimeRange returns the comparison time range of the specified time range, and returns false if there is no comparison.
+// The time range which consists of whole calendar months is shifted by months, otherwise the previous period has the same length
+func (t FinancialReportComparisonType) GetComparisonTimeRange(startUnixTime int64, endUnixTime int64, timezone *time.Location) (int64, int64, bool) {
+	startTime := time.Unix(startUnixTime, 0).In(timezone)
+	nextEndTime := time.Unix(endUnixTime+1, 0).In(timezone)
+	isWholeMonths := isFirstSecondOfMonth(startTime) && isFirstSecondOfMonth(nextEndTime)
+
+	if t == FINANCIAL_REPORT_COMPARISON_TYPE_PREVIOUS_PERIOD {
+		if isWholeMonths {
+			months := (nextEndTime.Year()-startTime.Year())*12 + int(nextEndTime.Month()) - int(startTime.Month())
+			return startTime.AddDate(0, -months, 0).Unix(), startUnixTime - 1, true
+		}
+
+		return startUnixTime - (endUnixTime - startUnixTime) - 1, startUnixTime - 1, true
+	} else if t == FINANCIAL_REPORT_COMPARISON_TYPE_SAME_PERIOD_LAST_YEAR {
+		if isWholeMonths {
+			return startTime.AddDate(-1, 0, 0).Unix(), nextEndTime.AddDate(-1, 0, 0).Unix() - 1, true
+		}
+
+		return startTime.AddDate(-1, 0, 0).Unix(), time.Unix(endUnixTime, 0).In(timezone).AddDate(-1, 0, 0).Unix(), true
+	}
+
+	return 0, 0, false
+}
+
+// UpdateSubtotals sets the amount and comparison amount of every item which has children to the sum of its children
+func (i *FinancialReportItem) UpdateSubtotals() {
+	if len(i.Children) < 1 {
+		return
+	}
+
+	i.Amount = 0
+	i.ComparisonAmount = 0
+
+	for j := 0; j < len(i.Children); j++ {
+		child := i.Children[j]
+		child.UpdateSubtotals()
+
+		i.Amount += child.Amount
+		i.ComparisonAmount += child.ComparisonAmount
+	}
+}
+
+// RemoveEmptyChildren removes the descendant items whose amount and comparison amount are both zero
+func (i *FinancialReportItem) RemoveEmptyChildren() {
+	children := make([]*FinancialReportItem, 0, len(i.Children))
+
+	for j := 0; j < len(i.Children); j++ {
+		child := i.Children[j]
+		child.RemoveEmptyChildren()
+
+		if child.Amount != 0 || child.ComparisonAmount != 0 {
+			children = append(children, child)
+		}
+	}
+
+	i.Children = children
+}
+
+// ToCsvRecords returns the csv records of income statement, the child items are indented by their levels
+func (r *IncomeStatementResponse) ToCsvRecords(withComparison bool) [][]string {
+	records := [][]string{getFinancialReportCsvHeader(withComparison)}
+	records = r.Income.appendCsvRecords(records, 0, withComparison)
+	records = r.Expense.appendCsvRecords(records, 0, withComparison)
+	records = append(records, getFinancialReportCsvRecord("Net Income", 0, r.NetIncome, r.ComparisonNetIncome, withComparison))
+
+	return records
+}
+
+// ToCsvRecords returns the csv records of balance sheet, the child items are indented by their levels
+func (r *BalanceSheetResponse) ToCsvRecords(withComparison bool) [][]string {
+	records := [][]string{getFinancialReportCsvHeader(withComparison)}
+	records = r.Assets.appendCsvRecords(records, 0, withComparison)
+	records = r.Liabilities.appendCsvRecords(records, 0, withComparison)
+	records = append(records, getFinancialReportCsvRecord("Net Assets", 0, r.NetAssets, r.ComparisonNetAssets, withComparison))
+
+	return records
+}
+
+func (i *FinancialReportItem) appendCsvRecords(records [][]string, level int, withComparison bool) [][]string {
+	records = append(records, getFinancialReportCsvRecord(i.Name, level, i.Amount, i.ComparisonAmount, withComparison))
+
+	for j := 0; j < len(i.Children); j++ {
+		records = i.Children[j].appendCsvRecords(records, level+1, withComparison)
+	}
+
+	return records
+}
+
+func getFinancialReportCsvHeader(withComparison bool) []string {
+	if withComparison {
+		return []string{"Item", "Amount", "Comparison Amount"}
+	}
+
+	return []string{"Item", "Amount"}
+}
+
+func getFinancialReportCsvRecord(name string, level int, amount int64, comparisonAmount int64, withComparison bool) []string {
+	displayName := strings.Repeat(financialReportCsvIndent, level) + name
+
+	if withComparison {
+		return []string{displayName, utils.FormatAmount(amount), utils.FormatAmount(comparisonAmount)}
+	}
+
+	return []string{displayName, utils.FormatAmount(amount)}
+}
+
+func isFirstSecondOfMonth(t time.Time) bool {
+	return t.Day() == 1 && t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0
+}
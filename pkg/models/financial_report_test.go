@@ -0,0 +1,153 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFinancialReportComparisonTypeGetComparisonTimeRange_PreviousPeriod(t *testing.T) {
+	timezone := time.FixedZone("Client Timezone", 8*60*60)
+
+	startTime := time.Date(2024, 3, 1, 0, 0, 0, 0, timezone).Unix()
+	endTime := time.Date(2024, 3, 31, 23, 59, 59, 0, timezone).Unix()
+	comparisonStartTime, comparisonEndTime, hasComparison := FINANCIAL_REPORT_COMPARISON_TYPE_PREVIOUS_PERIOD.GetComparisonTimeRange(startTime, endTime, timezone)
+	assert.True(t, hasComparison)
+	assert.Equal(t, time.Date(2024, 2, 1, 0, 0, 0, 0, timezone).Unix(), comparisonStartTime)
+	assert.Equal(t, time.Date(2024, 2, 29, 23, 59, 59, 0, timezone).Unix(), comparisonEndTime)
+
+	startTime = time.Date(2024, 4, 1, 0, 0, 0, 0, timezone).Unix()
+	endTime = time.Date(2024, 6, 30, 23, 59, 59, 0, timezone).Unix()
+	comparisonStartTime, comparisonEndTime, hasComparison = FINANCIAL_REPORT_COMPARISON_TYPE_PREVIOUS_PERIOD.GetComparisonTimeRange(startTime, endTime, timezone)
+	assert.True(t, hasComparison)
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, timezone).Unix(), comparisonStartTime)
+	assert.Equal(t, time.Date(2024, 3, 31, 23, 59, 59, 0, timezone).Unix(), comparisonEndTime)
+
+	startTime = time.Date(2024, 3, 11, 0, 0, 0, 0, timezone).Unix()
+	endTime = time.Date(2024, 3, 17, 23, 59, 59, 0, timezone).Unix()
+	comparisonStartTime, comparisonEndTime, hasComparison = FINANCIAL_REPORT_COMPARISON_TYPE_PREVIOUS_PERIOD.GetComparisonTimeRange(startTime, endTime, timezone)
+	assert.True(t, hasComparison)
+	assert.Equal(t, time.Date(2024, 3, 4, 0, 0, 0, 0, timezone).Unix(), comparisonStartTime)
+	assert.Equal(t, time.Date(2024, 3, 10, 23, 59, 59, 0, timezone).Unix(), comparisonEndTime)
+}
+
+func TestFinancialReportComparisonTypeGetComparisonTimeRange_SamePeriodLastYear(t *testing.T) {
+	timezone := time.FixedZone("Client Timezone", -5*60*60)
+
+	startTime := time.Date(2024, 2, 1, 0, 0, 0, 0, timezone).Unix()
+	endTime := time.Date(2024, 2, 29, 23, 59, 59, 0, timezone).Unix()
+	comparisonStartTime, comparisonEndTime, hasComparison := FINANCIAL_REPORT_COMPARISON_TYPE_SAME_PERIOD_LAST_YEAR.GetComparisonTimeRange(startTime, endTime, timezone)
+	assert.True(t, hasComparison)
+	assert.Equal(t, time.Date(2023, 2, 1, 0, 0, 0, 0, timezone).Unix(), comparisonStartTime)
+	assert.Equal(t, time.Date(2023, 2, 28, 23, 59, 59, 0, timezone).Unix(), comparisonEndTime)
+
+	startTime = time.Date(2024, 5, 10, 0, 0, 0, 0, timezone).Unix()
+	endTime = time.Date(2024, 5, 20, 23, 59, 59, 0, timezone).Unix()
+	comparisonStartTime, comparisonEndTime, hasComparison = FINANCIAL_REPORT_COMPARISON_TYPE_SAME_PERIOD_LAST_YEAR.GetComparisonTimeRange(startTime, endTime, timezone)
+	assert.True(t, hasComparison)
+	assert.Equal(t, time.Date(2023, 5, 10, 0, 0, 0, 0, timezone).Unix(), comparisonStartTime)
+	assert.Equal(t, time.Date(2023, 5, 20, 23, 59, 59, 0, timezone).Unix(), comparisonEndTime)
+}
+
+func TestFinancialReportComparisonTypeGetComparisonTimeRange_None(t *testing.T) {
+	_, _, hasComparison := FINANCIAL_REPORT_COMPARISON_TYPE_NONE.GetComparisonTimeRange(1704067200, 1706745599, time.UTC)
+	assert.False(t, hasComparison)
+}
+
+func TestFinancialReportItemUpdateSubtotalsAndRemoveEmptyChildren(t *testing.T) {
+	item := &FinancialReportItem{
+		Name: "Expense",
+		Children: []*FinancialReportItem{
+			{
+				Id:   1,
+				Name: "Food",
+				Children: []*FinancialReportItem{
+					{Id: 11, Name: "Lunch", Amount: 1200, ComparisonAmount: 1000},
+					{Id: 12, Name: "Dinner", Amount: 3000},
+					{Id: 13, Name: "Snacks"},
+				},
+			},
+			{
+				Id:       2,
+				Name:     "Transport",
+				Children: []*FinancialReportItem{{Id: 21, Name: "Taxi"}},
+			},
+		},
+	}
+
+	item.UpdateSubtotals()
+	item.RemoveEmptyChildren()
+
+	assert.Equal(t, int64(4200), item.Amount)
+	assert.Equal(t, int64(1000), item.ComparisonAmount)
+	assert.Equal(t, 1, len(item.Children))
+	assert.Equal(t, int64(4200), item.Children[0].Amount)
+	assert.Equal(t, 2, len(item.Children[0].Children))
+	assert.Equal(t, "Dinner", item.Children[0].Children[1].Name)
+}
+
+func TestIncomeStatementResponseToCsvRecords(t *testing.T) {
+	resp := &IncomeStatementResponse{
+		Income: &FinancialReportItem{
+			Name:             "Income",
+			Amount:           500000,
+			ComparisonAmount: 450000,
+			Children: []*FinancialReportItem{
+				{Name: "Salary", Amount: 500000, ComparisonAmount: 450000},
+			},
+		},
+		Expense: &FinancialReportItem{
+			Name:   "Expense",
+			Amount: 123456,
+		},
+		NetIncome:           376544,
+		ComparisonNetIncome: 450000,
+	}
+
+	assert.Equal(t, [][]string{
+		{"Item", "Amount"},
+		{"Income", "5000.00"},
+		{"  Salary", "5000.00"},
+		{"Expense", "1234.56"},
+		{"Net Income", "3765.44"},
+	}, resp.ToCsvRecords(false))
+
+	assert.Equal(t, [][]string{
+		{"Item", "Amount", "Comparison Amount"},
+		{"Income", "5000.00", "4500.00"},
+		{"  Salary", "5000.00", "4500.00"},
+		{"Expense", "1234.56", "0.00"},
+		{"Net Income", "3765.44", "4500.00"},
+	}, resp.ToCsvRecords(true))
+}
+
+func TestBalanceSheetResponseToCsvRecords(t *testing.T) {
+	resp := &BalanceSheetResponse{
+		Assets: &FinancialReportItem{
+			Name:   "Assets",
+			Amount: 100000,
+			Children: []*FinancialReportItem{
+				{
+					Name:     "Cash",
+					Amount:   100000,
+					Children: []*FinancialReportItem{{Name: "Wallet", Amount: 100000}},
+				},
+			},
+		},
+		Liabilities: &FinancialReportItem{
+			Name:   "Liabilities",
+			Amount: 25000,
+		},
+		NetAssets: 75000,
+	}
+
+	assert.Equal(t, [][]string{
+		{"Item", "Amount"},
+		{"Assets", "1000.00"},
+		{"  Cash", "1000.00"},
+		{"    Wallet", "1000.00"},
+		{"Liabilities", "250.00"},
+		{"Net Assets", "750.00"},
+	}, resp.ToCsvRecords(false))
+}
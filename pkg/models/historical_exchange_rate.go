@@ -0,0 +1,260 @@
+package models
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+// StatisticCurrencyConversionType represents how the amounts in statistics are converted to the default currency of user
+type StatisticCurrencyConversionType byte
+
+// Statistic currency conversion types
+const (
+	STATISTIC_CURRENCY_CONVERSION_TYPE_NONE            StatisticCurrencyConversionType = 0
+	STATISTIC_CURRENCY_CONVERSION_TYPE_SPOT            StatisticCurrencyConversionType = 1
+	STATISTIC_CURRENCY_CONVERSION_TYPE_MONTHLY_AVERAGE StatisticCurrencyConversionType = 2
+)
+
+// HistoricalExchangeRate represents the exchange rate of one currency on a specific date stored in database
+type HistoricalExchangeRate struct {
+	DataSource      string `xorm:"VARCHAR(64) PK NOT NULL"`
+	RateDate        int32  `xorm:"PK NOT NULL"`
+	Currency        string `xorm:"VARCHAR(3) PK NOT NULL"`
+	BaseCurrency    string `xorm:"VARCHAR(3) NOT NULL"`
+	Rate            string `xorm:"VARCHAR(32) NOT NULL"`
+	CreatedUnixTime int64
+	UpdatedUnixTime int64
+}
+
+// HistoricalExchangeRateGetRequest represents all parameters of historical exchange rates getting request
+type HistoricalExchangeRateGetRequest struct {
+	Date string `form:"date" binding:"required"`
+}
+
+// HistoricalExchangeRateRangeRequest represents all parameters of historical exchange rates in date range getting request
+type HistoricalExchangeRateRangeRequest struct {
+	StartDate string `form:"start_date" binding:"required"`
+	EndDate   string `form:"end_date" binding:"required"`
+}
+
+// HistoricalExchangeRateResponse represents a view-object of the exchange rates of one day
+type HistoricalExchangeRateResponse struct {
+	DataSource    string                  `json:"dataSource"`
+	Date          string                  `json:"date"`
+	BaseCurrency  string                  `json:"baseCurrency"`
+	ExchangeRates LatestExchangeRateSlice `json:"exchangeRates"`
+}
+
+// ToHistoricalExchangeRateResponses returns the view-objects of every day according to the historical exchange rate models, the view-objects are sorted by date
+func ToHistoricalExchangeRateResponses(historicalExchangeRates []*HistoricalExchangeRate) []*HistoricalExchangeRateResponse {
+	exchangeRateResps := make([]*HistoricalExchangeRateResponse, 0)
+	exchangeRateRespsMap := make(map[int32]*HistoricalExchangeRateResponse)
+
+	for i := 0; i < len(historicalExchangeRates); i++ {
+		historicalExchangeRate := historicalExchangeRates[i]
+		exchangeRateResp, exists := exchangeRateRespsMap[historicalExchangeRate.RateDate]
+
+		if !exists {
+			exchangeRateResp = &HistoricalExchangeRateResponse{
+				DataSource:    historicalExchangeRate.DataSource,
+				Date:          utils.FormatNumericDateToLongDate(historicalExchangeRate.RateDate),
+				BaseCurrency:  historicalExchangeRate.BaseCurrency,
+				ExchangeRates: make(LatestExchangeRateSlice, 0),
+			}
+
+			exchangeRateRespsMap[historicalExchangeRate.RateDate] = exchangeRateResp
+			exchangeRateResps = append(exchangeRateResps, exchangeRateResp)
+		}
+
+		exchangeRateResp.ExchangeRates = append(exchangeRateResp.ExchangeRates, &LatestExchangeRate{
+			Currency: historicalExchangeRate.Currency,
+			Rate:     historicalExchangeRate.Rate,
+		})
+	}
+
+	for i := 0; i < len(exchangeRateResps); i++ {
+		sort.Sort(exchangeRateResps[i].ExchangeRates)
+	}
+
+	sort.Slice(exchangeRateResps, func(i, j int) bool {
+		return exchangeRateResps[i].Date < exchangeRateResps[j].Date
+	})
+
+	return exchangeRateResps
+}
+
+// HistoricalExchangeRates represents the daily exchange rates of a time range, all the rates of the same day are relative to the same base currency
+type HistoricalExchangeRates struct {
+	dates                []int32
+	dailyExchangeRates   map[int32]ExchangeRatesMap
+	dailyBaseCurrencies  map[int32]string
+	monthlyExchangeRates map[int32]ExchangeRatesMap
+	fallbackRates        ExchangeRatesMap
+	userExchangeRates    UserExchangeRates
+	mergedExchangeRates  map[int32]ExchangeRatesMap
+}
+
+// NewHistoricalExchangeRates returns the daily exchange rates built from the historical exchange rate models,
+// the fallback exchange rates are used when there is no historical exchange rate at all
+func NewHistoricalExchangeRates(historicalExchangeRates []*HistoricalExchangeRate, fallbackRates ExchangeRatesMap) *HistoricalExchangeRates {
+	dailyExchangeRates := make(map[int32]ExchangeRatesMap)
+	dailyBaseCurrencies := make(map[int32]string)
+
+	for i := 0; i < len(historicalExchangeRates); i++ {
+		historicalExchangeRate := historicalExchangeRates[i]
+		rate, err := strconv.ParseFloat(historicalExchangeRate.Rate, 64)
+
+		if err != nil || rate <= 0 {
+			continue
+		}
+
+		exchangeRates, exists := dailyExchangeRates[historicalExchangeRate.RateDate]
+
+		if !exists {
+			exchangeRates = make(ExchangeRatesMap)
+			dailyExchangeRates[historicalExchangeRate.RateDate] = exchangeRates
+			dailyBaseCurrencies[historicalExchangeRate.RateDate] = historicalExchangeRate.BaseCurrency
+		}
+
+		exchangeRates[historicalExchangeRate.Currency] = rate
+	}
+
+	dates := make([]int32, 0, len(dailyExchangeRates))
+
+	for date := range dailyExchangeRates {
+		dates = append(dates, date)
+	}
+
+	sort.Slice(dates, func(i, j int) bool {
+		return dates[i] < dates[j]
+	})
+
+	return &HistoricalExchangeRates{
+		dates:                dates,
+		dailyExchangeRates:   dailyExchangeRates,
+		dailyBaseCurrencies:  dailyBaseCurrencies,
+		monthlyExchangeRates: make(map[int32]ExchangeRatesMap),
+		fallbackRates:        fallbackRates,
+		mergedExchangeRates:  make(map[int32]ExchangeRatesMap),
+	}
+}
+
+// SetUserExchangeRates sets the user defined exchange rates which are merged over the historical exchange rates on the days they are effective
+func (r *HistoricalExchangeRates) SetUserExchangeRates(userExchangeRates UserExchangeRates) {
+	r.userExchangeRates = userExchangeRates
+	r.mergedExchangeRates = make(map[int32]ExchangeRatesMap)
+}
+
+// IsEmpty returns whether there is no historical exchange rate
+func (r *HistoricalExchangeRates) IsEmpty() bool {
+	return len(r.dates) < 1
+}
+
+// GetExchangeRates returns the exchange rates which are effective on the specified date (YYYYMMDD) according to the conversion type,
+// the user defined exchange rates which are effective on that date are merged over
+func (r *HistoricalExchangeRates) GetExchangeRates(date int32, conversionType StatisticCurrencyConversionType) ExchangeRatesMap {
+	var exchangeRates ExchangeRatesMap
+
+	if conversionType == STATISTIC_CURRENCY_CONVERSION_TYPE_MONTHLY_AVERAGE {
+		exchangeRates = r.GetMonthlyAverageExchangeRates(date)
+	} else {
+		exchangeRates = r.GetSpotExchangeRates(date)
+	}
+
+	if len(r.userExchangeRates) < 1 {
+		return exchangeRates
+	}
+
+	cacheKey := date*10 + int32(conversionType)
+
+	if mergedExchangeRates, exists := r.mergedExchangeRates[cacheKey]; exists {
+		return mergedExchangeRates
+	}
+
+	effectiveUserExchangeRates := r.userExchangeRates.GetEffectiveExchangeRates(date)
+
+	if len(effectiveUserExchangeRates) > 0 {
+		exchangeRates = exchangeRates.WithUserExchangeRates(effectiveUserExchangeRates)
+	}
+
+	r.mergedExchangeRates[cacheKey] = exchangeRates
+
+	return exchangeRates
+}
+
+// GetSpotExchangeRates returns the exchange rates of the latest day not later than the specified date (YYYYMMDD),
+// or the exchange rates of the earliest day if the specified date is earlier than all the days
+func (r *HistoricalExchangeRates) GetSpotExchangeRates(date int32) ExchangeRatesMap {
+	if len(r.dates) < 1 {
+		return r.fallbackRates
+	}
+
+	index := sort.Search(len(r.dates), func(i int) bool {
+		return r.dates[i] > date
+	})
+
+	if index > 0 {
+		index--
+	}
+
+	return r.dailyExchangeRates[r.dates[index]]
+}
+
+// GetMonthlyAverageExchangeRates returns the average exchange rates of all the days in the month of the specified date (YYYYMMDD),
+// or the spot exchange rates of the specified date if there is no exchange rate in that month,
+// the rates of the days with different base currency are converted to the base currency of the first day in that month before averaging
+func (r *HistoricalExchangeRates) GetMonthlyAverageExchangeRates(date int32) ExchangeRatesMap {
+	yearMonth := date / 100
+
+	if exchangeRates, exists := r.monthlyExchangeRates[yearMonth]; exists {
+		return exchangeRates
+	}
+
+	totalRates := make(map[string]float64)
+	rateCounts := make(map[string]int)
+	baseCurrency := ""
+
+	for i := 0; i < len(r.dates); i++ {
+		if r.dates[i]/100 != yearMonth {
+			continue
+		}
+
+		dailyExchangeRates := r.dailyExchangeRates[r.dates[i]]
+		baseCurrencyRate := 1.0
+
+		if baseCurrency == "" {
+			baseCurrency = r.dailyBaseCurrencies[r.dates[i]]
+		} else if r.dailyBaseCurrencies[r.dates[i]] != baseCurrency {
+			rate, exists := dailyExchangeRates[baseCurrency]
+
+			if !exists {
+				continue
+			}
+
+			baseCurrencyRate = rate
+		}
+
+		for currency, rate := range dailyExchangeRates {
+			totalRates[currency] += rate / baseCurrencyRate
+			rateCounts[currency]++
+		}
+	}
+
+	var exchangeRates ExchangeRatesMap
+
+	if len(totalRates) > 0 {
+		exchangeRates = make(ExchangeRatesMap, len(totalRates))
+
+		for currency, totalRate := range totalRates {
+			exchangeRates[currency] = totalRate / float64(rateCounts[currency])
+		}
+	} else {
+		exchangeRates = r.GetSpotExchangeRates(date)
+	}
+
+	r.monthlyExchangeRates[yearMonth] = exchangeRates
+
+	return exchangeRates
+}
@@ -0,0 +1,95 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func getTestHistoricalExchangeRates() []*HistoricalExchangeRate {
+	return []*HistoricalExchangeRate{
+		{DataSource: "test", RateDate: 20240102, Currency: "EUR", BaseCurrency: "EUR", Rate: "1"},
+		{DataSource: "test", RateDate: 20240102, Currency: "USD", BaseCurrency: "EUR", Rate: "1.1"},
+		{DataSource: "test", RateDate: 20240110, Currency: "EUR", BaseCurrency: "EUR", Rate: "1"},
+		{DataSource: "test", RateDate: 20240110, Currency: "USD", BaseCurrency: "EUR", Rate: "1.2"},
+		{DataSource: "test", RateDate: 20240205, Currency: "EUR", BaseCurrency: "EUR", Rate: "1"},
+		{DataSource: "test", RateDate: 20240205, Currency: "USD", BaseCurrency: "EUR", Rate: "1.0"},
+		{DataSource: "test", RateDate: 20240205, Currency: "GBP", BaseCurrency: "EUR", Rate: "invalid"},
+	}
+}
+
+func TestHistoricalExchangeRatesGetSpotExchangeRates(t *testing.T) {
+	exchangeRates := NewHistoricalExchangeRates(getTestHistoricalExchangeRates(), nil)
+
+	assert.Equal(t, false, exchangeRates.IsEmpty())
+	assert.Equal(t, 1.1, exchangeRates.GetSpotExchangeRates(20231231)["USD"])
+	assert.Equal(t, 1.1, exchangeRates.GetSpotExchangeRates(20240102)["USD"])
+	assert.Equal(t, 1.1, exchangeRates.GetSpotExchangeRates(20240109)["USD"])
+	assert.Equal(t, 1.2, exchangeRates.GetSpotExchangeRates(20240110)["USD"])
+	assert.Equal(t, 1.0, exchangeRates.GetSpotExchangeRates(20240301)["USD"])
+
+	_, exists := exchangeRates.GetSpotExchangeRates(20240205)["GBP"]
+	assert.Equal(t, false, exists)
+}
+
+func TestHistoricalExchangeRatesGetMonthlyAverageExchangeRates(t *testing.T) {
+	exchangeRates := NewHistoricalExchangeRates(getTestHistoricalExchangeRates(), nil)
+
+	assert.InDelta(t, 1.15, exchangeRates.GetMonthlyAverageExchangeRates(20240101)["USD"], 0.0000001)
+	assert.InDelta(t, 1.15, exchangeRates.GetMonthlyAverageExchangeRates(20240131)["USD"], 0.0000001)
+	assert.Equal(t, 1.0, exchangeRates.GetMonthlyAverageExchangeRates(20240228)["USD"])
+	assert.Equal(t, 1.0, exchangeRates.GetMonthlyAverageExchangeRates(20240315)["USD"])
+	assert.Equal(t, 1.1, exchangeRates.GetMonthlyAverageExchangeRates(20231215)["USD"])
+}
+
+func TestHistoricalExchangeRatesGetMonthlyAverageExchangeRates_DifferentBaseCurrency(t *testing.T) {
+	exchangeRates := NewHistoricalExchangeRates([]*HistoricalExchangeRate{
+		{DataSource: "test", RateDate: 20240102, Currency: "EUR", BaseCurrency: "EUR", Rate: "1"},
+		{DataSource: "test", RateDate: 20240102, Currency: "USD", BaseCurrency: "EUR", Rate: "1.1"},
+		{DataSource: "test", RateDate: 20240110, Currency: "USD", BaseCurrency: "USD", Rate: "1"},
+		{DataSource: "test", RateDate: 20240110, Currency: "EUR", BaseCurrency: "USD", Rate: "0.8"},
+		{DataSource: "test", RateDate: 20240115, Currency: "GBP", BaseCurrency: "GBP", Rate: "1"},
+		{DataSource: "test", RateDate: 20240115, Currency: "USD", BaseCurrency: "GBP", Rate: "1.3"},
+	}, nil)
+
+	monthlyExchangeRates := exchangeRates.GetMonthlyAverageExchangeRates(20240131)
+	assert.InDelta(t, 1.0, monthlyExchangeRates["EUR"], 0.0000001)
+	assert.InDelta(t, 1.175, monthlyExchangeRates["USD"], 0.0000001)
+
+	_, exists := monthlyExchangeRates["GBP"]
+	assert.Equal(t, false, exists)
+}
+
+func TestHistoricalExchangeRatesGetExchangeRates_Empty(t *testing.T) {
+	fallbackRates := ExchangeRatesMap{"EUR": 1, "USD": 1.05}
+	exchangeRates := NewHistoricalExchangeRates(nil, fallbackRates)
+
+	assert.Equal(t, true, exchangeRates.IsEmpty())
+	assert.Equal(t, fallbackRates, exchangeRates.GetExchangeRates(20240110, STATISTIC_CURRENCY_CONVERSION_TYPE_SPOT))
+	assert.Equal(t, fallbackRates, exchangeRates.GetExchangeRates(20240110, STATISTIC_CURRENCY_CONVERSION_TYPE_MONTHLY_AVERAGE))
+}
+
+func TestToHistoricalExchangeRateResponses(t *testing.T) {
+	exchangeRateResps := ToHistoricalExchangeRateResponses([]*HistoricalExchangeRate{
+		{DataSource: "test", RateDate: 20240110, Currency: "USD", BaseCurrency: "EUR", Rate: "1.2"},
+		{DataSource: "test", RateDate: 20240102, Currency: "USD", BaseCurrency: "EUR", Rate: "1.1"},
+		{DataSource: "test", RateDate: 20240102, Currency: "EUR", BaseCurrency: "EUR", Rate: "1"},
+	})
+
+	assert.Equal(t, 2, len(exchangeRateResps))
+
+	assert.Equal(t, "test", exchangeRateResps[0].DataSource)
+	assert.Equal(t, "2024-01-02", exchangeRateResps[0].Date)
+	assert.Equal(t, "EUR", exchangeRateResps[0].BaseCurrency)
+	assert.Equal(t, LatestExchangeRateSlice{
+		{Currency: "EUR", Rate: "1"},
+		{Currency: "USD", Rate: "1.1"},
+	}, exchangeRateResps[0].ExchangeRates)
+
+	assert.Equal(t, "2024-01-10", exchangeRateResps[1].Date)
+	assert.Equal(t, LatestExchangeRateSlice{
+		{Currency: "USD", Rate: "1.2"},
+	}, exchangeRateResps[1].ExchangeRates)
+
+	assert.Equal(t, 0, len(ToHistoricalExchangeRateResponses(nil)))
+}
@@ -12,6 +12,9 @@ type ImportTransaction struct {
 	OriginalDestinationAccountName     string
 	OriginalDestinationAccountCurrency string
 	OriginalTagNames                   []string
+	OriginalPayee                      string
+	CategorySuggested                  bool
+	TagsSuggested                      bool
 }
 
 // ImportTransactionResponse represents a view-object of the imported transaction data
@@ -31,14 +34,18 @@ type ImportTransactionResponse struct {
 	DestinationAmount                  int64                           `json:"destinationAmount,omitempty"`
 	TagIds                             []string                        `json:"tagIds"`
 	OriginalTagNames                   []string                        `json:"originalTagNames"`
+	OriginalPayee                      string                          `json:"originalPayee,omitempty"`
+	CategorySuggested                  bool                            `json:"categorySuggested,omitempty"`
+	TagsSuggested                      bool                            `json:"tagsSuggested,omitempty"`
 	Comment                            string                          `json:"comment"`
 	GeoLocation                        *TransactionGeoLocationResponse `json:"geoLocation,omitempty"`
 }
 
 // ImportTransactionResponsePageWrapper represents a response of imported transaction which contains items and count
 type ImportTransactionResponsePageWrapper struct {
-	Items      []*ImportTransactionResponse `json:"items"`
-	TotalCount int64                        `json:"totalCount"`
+	Items             []*ImportTransactionResponse             `json:"items"`
+	TotalCount        int64                                    `json:"totalCount"`
+	BalanceAssertions []*ImportAccountBalanceAssertionResponse `json:"balanceAssertions,omitempty"`
 }
 
 // ToImportTransactionResponse returns the a view-objects according to imported transaction data
@@ -74,6 +81,9 @@ func (t ImportTransaction) ToImportTransactionResponse() *ImportTransactionRespo
 		DestinationAmount:                  t.RelatedAccountAmount,
 		TagIds:                             t.TagIds,
 		OriginalTagNames:                   t.OriginalTagNames,
+		OriginalPayee:                      t.OriginalPayee,
+		CategorySuggested:                  t.CategorySuggested,
+		TagsSuggested:                      t.TagsSuggested,
 		Comment:                            t.Comment,
 		GeoLocation:                        geoLocation,
 	}
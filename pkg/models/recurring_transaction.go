@@ -0,0 +1,55 @@
+package models
+
+// RecurringTransactionPeriod represents the period of recurring transaction series
+type RecurringTransactionPeriod byte
+
+// Recurring transaction periods
+const (
+	RECURRING_TRANSACTION_PERIOD_WEEKLY    RecurringTransactionPeriod = 1
+	RECURRING_TRANSACTION_PERIOD_BIWEEKLY  RecurringTransactionPeriod = 2
+	RECURRING_TRANSACTION_PERIOD_MONTHLY   RecurringTransactionPeriod = 3
+	RECURRING_TRANSACTION_PERIOD_QUARTERLY RecurringTransactionPeriod = 4
+	RECURRING_TRANSACTION_PERIOD_YEARLY    RecurringTransactionPeriod = 5
+)
+
+// RecurringTransactionListRequest represents all parameters of recurring transaction series listing request
+type RecurringTransactionListRequest struct {
+	Months         int32 `form:"months" binding:"min=0,max=120"`
+	MinOccurrences int32 `form:"min_occurrences" binding:"min=0,max=100"`
+	ActiveOnly     bool  `form:"active_only"`
+}
+
+// RecurringTransactionConvertRequest represents all parameters of converting recurring transaction series to scheduled transaction template request
+type RecurringTransactionConvertRequest struct {
+	Key             string `json:"key" binding:"required,notBlank"`
+	Name            string `json:"name" binding:"max=64"`
+	Months          int32  `json:"months" binding:"min=0,max=120"`
+	MinOccurrences  int32  `json:"minOccurrences" binding:"min=0,max=100"`
+	ClientSessionId string `json:"clientSessionId"`
+}
+
+// RecurringTransactionPriceChangeResponse represents a view-object of amount change of recurring transaction series
+type RecurringTransactionPriceChangeResponse struct {
+	Time      int64 `json:"time"`
+	OldAmount int64 `json:"oldAmount"`
+	NewAmount int64 `json:"newAmount"`
+}
+
+// RecurringTransactionSeriesResponse represents a view-object of recurring transaction series
+type RecurringTransactionSeriesResponse struct {
+	Key              string                                     `json:"key"`
+	Name             string                                     `json:"name"`
+	Type             TransactionType                            `json:"type"`
+	CategoryId       int64                                      `json:"categoryId,string"`
+	AccountId        int64                                      `json:"accountId,string"`
+	Period           RecurringTransactionPeriod                 `json:"period"`
+	Occurrences      int32                                      `json:"occurrences"`
+	AverageAmount    int64                                      `json:"averageAmount"`
+	LastAmount       int64                                      `json:"lastAmount"`
+	FirstTime        int64                                      `json:"firstTime"`
+	LastTime         int64                                      `json:"lastTime"`
+	NextExpectedTime int64                                      `json:"nextExpectedTime"`
+	UtcOffset        int16                                      `json:"utcOffset"`
+	Active           bool                                       `json:"active"`
+	PriceChanges     []*RecurringTransactionPriceChangeResponse `json:"priceChanges"`
+}
@@ -0,0 +1,155 @@
+package models
+
+import (
+	"time"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+)
+
+// ScheduledReportFrequencyType represents the frequency type of scheduled report
+type ScheduledReportFrequencyType byte
+
+// Scheduled report frequency types
+const (
+	SCHEDULED_REPORT_FREQUENCY_TYPE_WEEKLY  ScheduledReportFrequencyType = 1
+	SCHEDULED_REPORT_FREQUENCY_TYPE_MONTHLY ScheduledReportFrequencyType = 2
+)
+
+// String returns a textual representation of the scheduled report frequency type
+func (t ScheduledReportFrequencyType) String() string {
+	switch t {
+	case SCHEDULED_REPORT_FREQUENCY_TYPE_WEEKLY:
+		return "Weekly"
+	case SCHEDULED_REPORT_FREQUENCY_TYPE_MONTHLY:
+		return "Monthly"
+	default:
+		return "Invalid"
+	}
+}
+
+// ScheduledReport represents scheduled report data stored in database
+type ScheduledReport struct {
+	ReportId          int64                        `xorm:"PK"`
+	Uid               int64                        `xorm:"INDEX(IDX_scheduled_report_uid_deleted) NOT NULL"`
+	Deleted           bool                         `xorm:"INDEX(IDX_scheduled_report_uid_deleted) INDEX(IDX_scheduled_report_deleted_disabled_next_send_time) NOT NULL"`
+	FrequencyType     ScheduledReportFrequencyType `xorm:"NOT NULL"`
+	TimezoneUtcOffset int16                        `xorm:"NOT NULL"`
+	AttachCsv         bool                         `xorm:"NOT NULL"`
+	AttachPdf         bool                         `xorm:"NOT NULL"`
+	Disabled          bool                         `xorm:"INDEX(IDX_scheduled_report_deleted_disabled_next_send_time) NOT NULL"`
+	LastSentUnixTime  int64
+	NextSendUnixTime  int64 `xorm:"INDEX(IDX_scheduled_report_deleted_disabled_next_send_time) NOT NULL"`
+	CreatedUnixTime   int64
+	UpdatedUnixTime   int64
+	DeletedUnixTime   int64
+}
+
+// ScheduledReportGetRequest represents all parameters of scheduled report getting request
+type ScheduledReportGetRequest struct {
+	Id int64 `form:"id,string" binding:"required,min=1"`
+}
+
+// ScheduledReportCreateRequest represents all parameters of scheduled report creation request
+type ScheduledReportCreateRequest struct {
+	FrequencyType ScheduledReportFrequencyType `json:"frequencyType" binding:"required,min=1,max=2"`
+	AttachCsv     bool                         `json:"attachCsv"`
+	AttachPdf     bool                         `json:"attachPdf"`
+}
+
+// ScheduledReportModifyRequest represents all parameters of scheduled report modification request
+type ScheduledReportModifyRequest struct {
+	Id            int64                        `json:"id,string" binding:"required,min=1"`
+	FrequencyType ScheduledReportFrequencyType `json:"frequencyType" binding:"required,min=1,max=2"`
+	AttachCsv     bool                         `json:"attachCsv"`
+	AttachPdf     bool                         `json:"attachPdf"`
+	Disabled      bool                         `json:"disabled"`
+}
+
+// ScheduledReportDeleteRequest represents all parameters of scheduled report deleting request
+type ScheduledReportDeleteRequest struct {
+	Id int64 `json:"id,string" binding:"required,min=1"`
+}
+
+// ScheduledReportInfoResponse represents a view-object of scheduled report
+type ScheduledReportInfoResponse struct {
+	Id                int64                        `json:"id,string"`
+	FrequencyType     ScheduledReportFrequencyType `json:"frequencyType"`
+	TimezoneUtcOffset int16                        `json:"timezoneUtcOffset"`
+	AttachCsv         bool                         `json:"attachCsv"`
+	AttachPdf         bool                         `json:"attachPdf"`
+	Disabled          bool                         `json:"disabled"`
+	LastSentTime      int64                        `json:"lastSentTime,omitempty"`
+	NextSendTime      int64                        `json:"nextSendTime"`
+}
+
+// GetPeriodTimeRange returns the first and the last unix time of the report period which contains the specified unix time,
+// the weekly period starts from the specified first day of week and all the periods are calculated in the timezone of scheduled report
+func (r *ScheduledReport) GetPeriodTimeRange(unixTime int64, firstDayOfWeek core.WeekDay) (int64, int64) {
+	timezone := time.FixedZone("Report Timezone", int(r.TimezoneUtcOffset)*60)
+	t := time.Unix(unixTime, 0).In(timezone)
+	var periodStartTime, nextPeriodStartTime time.Time
+
+	if r.FrequencyType == SCHEDULED_REPORT_FREQUENCY_TYPE_MONTHLY {
+		periodStartTime = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, timezone)
+		nextPeriodStartTime = periodStartTime.AddDate(0, 1, 0)
+	} else {
+		dayOfWeek := int(t.Weekday()) - int(firstDayOfWeek)
+
+		if dayOfWeek < 0 {
+			dayOfWeek += 7
+		}
+
+		periodStartTime = time.Date(t.Year(), t.Month(), t.Day()-dayOfWeek, 0, 0, 0, 0, timezone)
+		nextPeriodStartTime = periodStartTime.AddDate(0, 0, 7)
+	}
+
+	return periodStartTime.Unix(), nextPeriodStartTime.Unix() - 1
+}
+
+// GetLastCompletedPeriodTimeRange returns the first and the last unix time of the latest report period which has ended before the specified unix time
+func (r *ScheduledReport) GetLastCompletedPeriodTimeRange(currentUnixTime int64, firstDayOfWeek core.WeekDay) (int64, int64) {
+	currentPeriodStartTime, _ := r.GetPeriodTimeRange(currentUnixTime, firstDayOfWeek)
+	return r.GetPeriodTimeRange(currentPeriodStartTime-1, firstDayOfWeek)
+}
+
+// GetNextSendUnixTime returns the unix time when the report of the period which contains the specified unix time should be sent
+func (r *ScheduledReport) GetNextSendUnixTime(currentUnixTime int64, firstDayOfWeek core.WeekDay) int64 {
+	_, currentPeriodEndTime := r.GetPeriodTimeRange(currentUnixTime, firstDayOfWeek)
+	return currentPeriodEndTime + 1
+}
+
+// ToScheduledReportInfoResponse returns a view-object according to database model
+func (r *ScheduledReport) ToScheduledReportInfoResponse() *ScheduledReportInfoResponse {
+	return &ScheduledReportInfoResponse{
+		Id:                r.ReportId,
+		FrequencyType:     r.FrequencyType,
+		TimezoneUtcOffset: r.TimezoneUtcOffset,
+		AttachCsv:         r.AttachCsv,
+		AttachPdf:         r.AttachPdf,
+		Disabled:          r.Disabled,
+		LastSentTime:      r.LastSentUnixTime,
+		NextSendTime:      r.NextSendUnixTime,
+	}
+}
+
+// ScheduledReportInfoResponseSlice represents the slice data structure of ScheduledReportInfoResponse
+type ScheduledReportInfoResponseSlice []*ScheduledReportInfoResponse
+
+// Len returns the count of items
+func (s ScheduledReportInfoResponseSlice) Len() int {
+	return len(s)
+}
+
+// Swap swaps two items
+func (s ScheduledReportInfoResponseSlice) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+}
+
+// Less reports whether the first item is less than the second one
+func (s ScheduledReportInfoResponseSlice) Less(i, j int) bool {
+	if s[i].FrequencyType != s[j].FrequencyType {
+		return s[i].FrequencyType < s[j].FrequencyType
+	}
+
+	return s[i].Id < s[j].Id
+}
@@ -0,0 +1,80 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+)
+
+func TestScheduledReportGetPeriodTimeRange_Weekly(t *testing.T) {
+	timezone := time.FixedZone("Test Timezone", 480*60)
+	report := &ScheduledReport{
+		FrequencyType:     SCHEDULED_REPORT_FREQUENCY_TYPE_WEEKLY,
+		TimezoneUtcOffset: 480,
+	}
+
+	currentTime := time.Date(2024, 5, 15, 10, 0, 0, 0, timezone).Unix()
+
+	startTime, endTime := report.GetPeriodTimeRange(currentTime, core.WEEKDAY_MONDAY)
+	assert.Equal(t, time.Date(2024, 5, 13, 0, 0, 0, 0, timezone).Unix(), startTime)
+	assert.Equal(t, time.Date(2024, 5, 19, 23, 59, 59, 0, timezone).Unix(), endTime)
+
+	startTime, endTime = report.GetPeriodTimeRange(currentTime, core.WEEKDAY_SUNDAY)
+	assert.Equal(t, time.Date(2024, 5, 12, 0, 0, 0, 0, timezone).Unix(), startTime)
+	assert.Equal(t, time.Date(2024, 5, 18, 23, 59, 59, 0, timezone).Unix(), endTime)
+
+	startTime, endTime = report.GetPeriodTimeRange(currentTime, core.WEEKDAY_THURSDAY)
+	assert.Equal(t, time.Date(2024, 5, 9, 0, 0, 0, 0, timezone).Unix(), startTime)
+	assert.Equal(t, time.Date(2024, 5, 15, 23, 59, 59, 0, timezone).Unix(), endTime)
+}
+
+func TestScheduledReportGetPeriodTimeRange_Monthly(t *testing.T) {
+	timezone := time.FixedZone("Test Timezone", -300*60)
+	report := &ScheduledReport{
+		FrequencyType:     SCHEDULED_REPORT_FREQUENCY_TYPE_MONTHLY,
+		TimezoneUtcOffset: -300,
+	}
+
+	startTime, endTime := report.GetPeriodTimeRange(time.Date(2024, 2, 29, 23, 30, 0, 0, timezone).Unix(), core.WEEKDAY_MONDAY)
+	assert.Equal(t, time.Date(2024, 2, 1, 0, 0, 0, 0, timezone).Unix(), startTime)
+	assert.Equal(t, time.Date(2024, 2, 29, 23, 59, 59, 0, timezone).Unix(), endTime)
+
+	startTime, endTime = report.GetPeriodTimeRange(time.Date(2024, 3, 1, 0, 0, 0, 0, timezone).Unix(), core.WEEKDAY_MONDAY)
+	assert.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 0, timezone).Unix(), startTime)
+	assert.Equal(t, time.Date(2024, 3, 31, 23, 59, 59, 0, timezone).Unix(), endTime)
+}
+
+func TestScheduledReportGetLastCompletedPeriodTimeRange(t *testing.T) {
+	timezone := time.FixedZone("Test Timezone", 480*60)
+	report := &ScheduledReport{
+		FrequencyType:     SCHEDULED_REPORT_FREQUENCY_TYPE_WEEKLY,
+		TimezoneUtcOffset: 480,
+	}
+
+	startTime, endTime := report.GetLastCompletedPeriodTimeRange(time.Date(2024, 5, 13, 0, 10, 0, 0, timezone).Unix(), core.WEEKDAY_MONDAY)
+	assert.Equal(t, time.Date(2024, 5, 6, 0, 0, 0, 0, timezone).Unix(), startTime)
+	assert.Equal(t, time.Date(2024, 5, 12, 23, 59, 59, 0, timezone).Unix(), endTime)
+
+	report.FrequencyType = SCHEDULED_REPORT_FREQUENCY_TYPE_MONTHLY
+
+	startTime, endTime = report.GetLastCompletedPeriodTimeRange(time.Date(2024, 1, 10, 8, 0, 0, 0, timezone).Unix(), core.WEEKDAY_MONDAY)
+	assert.Equal(t, time.Date(2023, 12, 1, 0, 0, 0, 0, timezone).Unix(), startTime)
+	assert.Equal(t, time.Date(2023, 12, 31, 23, 59, 59, 0, timezone).Unix(), endTime)
+}
+
+func TestScheduledReportGetNextSendUnixTime(t *testing.T) {
+	timezone := time.FixedZone("Test Timezone", 480*60)
+	report := &ScheduledReport{
+		FrequencyType:     SCHEDULED_REPORT_FREQUENCY_TYPE_WEEKLY,
+		TimezoneUtcOffset: 480,
+	}
+
+	currentTime := time.Date(2024, 5, 15, 10, 0, 0, 0, timezone).Unix()
+	assert.Equal(t, time.Date(2024, 5, 20, 0, 0, 0, 0, timezone).Unix(), report.GetNextSendUnixTime(currentTime, core.WEEKDAY_MONDAY))
+
+	report.FrequencyType = SCHEDULED_REPORT_FREQUENCY_TYPE_MONTHLY
+	assert.Equal(t, time.Date(2024, 6, 1, 0, 0, 0, 0, timezone).Unix(), report.GetNextSendUnixTime(currentTime, core.WEEKDAY_MONDAY))
+}
@@ -0,0 +1,273 @@
+package models
+
+import (
+	"sort"
+	"strings"
+)
+
+// ScheduledTransactionForecastRequest represents all parameters of scheduled transaction forecast request
+type ScheduledTransactionForecastRequest struct {
+	StartTime int64 `form:"start_time" binding:"min=0"`
+	EndTime   int64 `form:"end_time" binding:"required,min=1"`
+}
+
+// ScheduledTransactionOccurrence represents a future transaction which will be created by the scheduled transaction template
+type ScheduledTransactionOccurrence struct {
+	Template        *TransactionTemplate
+	TransactionTime int64
+}
+
+// ScheduledTransactionOccurrenceResponse represents a view-object of future transaction which will be created by the scheduled transaction template
+type ScheduledTransactionOccurrenceResponse struct {
+	TemplateId           int64           `json:"templateId,string"`
+	Name                 string          `json:"name"`
+	Type                 TransactionType `json:"type"`
+	CategoryId           int64           `json:"categoryId,string"`
+	Time                 int64           `json:"time"`
+	UtcOffset            int16           `json:"utcOffset"`
+	SourceAccountId      int64           `json:"sourceAccountId,string"`
+	DestinationAccountId int64           `json:"destinationAccountId,string"`
+	SourceAmount         int64           `json:"sourceAmount"`
+	DestinationAmount    int64           `json:"destinationAmount"`
+	AmountExpression     string          `json:"amountExpression,omitempty"`
+	Estimated            bool            `json:"estimated"`
+	HideAmount           bool            `json:"hideAmount"`
+	TagIds               []string        `json:"tagIds"`
+	Comment              string          `json:"comment"`
+}
+
+// ScheduledTransactionBalanceChangeResponse represents a view-object of balance change caused by scheduled transaction
+type ScheduledTransactionBalanceChangeResponse struct {
+	Time    int64 `json:"time"`
+	Amount  int64 `json:"amount"`
+	Balance int64 `json:"balance"`
+}
+
+// ScheduledTransactionBalanceForecastResponse represents a view-object of balance forecast of an account or all accounts in the same currency,
+// the estimated occurrences are not included in the balances
+type ScheduledTransactionBalanceForecastResponse struct {
+	AccountId                int64                                        `json:"accountId,string,omitempty"`
+	Currency                 string                                       `json:"currency"`
+	CurrentBalance           int64                                        `json:"currentBalance"`
+	FinalBalance             int64                                        `json:"finalBalance"`
+	MinBalance               int64                                        `json:"minBalance"`
+	MinBalanceTime           int64                                        `json:"minBalanceTime"`
+	FirstNegativeBalanceTime int64                                        `json:"firstNegativeBalanceTime,omitempty"`
+	HasEstimatedOccurrences  bool                                         `json:"hasEstimatedOccurrences,omitempty"`
+	Balances                 []*ScheduledTransactionBalanceChangeResponse `json:"balances"`
+}
+
+// ScheduledTransactionForecastResponse represents a view-object of scheduled transaction forecast
+type ScheduledTransactionForecastResponse struct {
+	StartTime   int64                                          `json:"startTime"`
+	EndTime     int64                                          `json:"endTime"`
+	Occurrences []*ScheduledTransactionOccurrenceResponse      `json:"occurrences"`
+	Accounts    []*ScheduledTransactionBalanceForecastResponse `json:"accounts"`
+	Totals      []*ScheduledTransactionBalanceForecastResponse `json:"totals"`
+}
+
+// ToScheduledTransactionOccurrenceResponse returns a view-object according to the future transaction,
+// the amount of template which has amount expression is only an estimate because it is computed when the transaction is created
+func (o *ScheduledTransactionOccurrence) ToScheduledTransactionOccurrenceResponse() *ScheduledTransactionOccurrenceResponse {
+	tagIds := make([]string, 0)
+
+	if o.Template.TagIds != "" {
+		tagIds = strings.Split(o.Template.TagIds, ",")
+	}
+
+	response := &ScheduledTransactionOccurrenceResponse{
+		TemplateId:       o.Template.TemplateId,
+		Name:             o.Template.Name,
+		Type:             o.Template.Type,
+		CategoryId:       o.Template.CategoryId,
+		Time:             o.TransactionTime,
+		UtcOffset:        o.Template.ScheduledTimezoneUtcOffset,
+		SourceAccountId:  o.Template.AccountId,
+		SourceAmount:     o.Template.Amount,
+		AmountExpression: o.Template.AmountExpression,
+		Estimated:        o.Template.AmountExpression != "",
+		HideAmount:       o.Template.HideAmount,
+		TagIds:           tagIds,
+		Comment:          o.Template.Comment,
+	}
+
+	if o.Template.Type == TRANSACTION_TYPE_TRANSFER {
+		response.DestinationAccountId = o.Template.RelatedAccountId
+		response.DestinationAmount = o.Template.RelatedAccountAmount
+	}
+
+	return response
+}
+
+// ScheduledTransactionOccurrenceSlice represents the slice data structure of ScheduledTransactionOccurrence
+type ScheduledTransactionOccurrenceSlice []*ScheduledTransactionOccurrence
+
+// Len returns the count of items
+func (s ScheduledTransactionOccurrenceSlice) Len() int {
+	return len(s)
+}
+
+// Swap swaps two items
+func (s ScheduledTransactionOccurrenceSlice) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+}
+
+// Less reports whether the first item is less than the second one
+func (s ScheduledTransactionOccurrenceSlice) Less(i, j int) bool {
+	if s[i].TransactionTime != s[j].TransactionTime {
+		return s[i].TransactionTime < s[j].TransactionTime
+	}
+
+	if s[i].Template.DisplayOrder != s[j].Template.DisplayOrder {
+		return s[i].Template.DisplayOrder < s[j].Template.DisplayOrder
+	}
+
+	return s[i].Template.TemplateId < s[j].Template.TemplateId
+}
+
+// ToScheduledTransactionForecastResponse returns the scheduled transaction forecast according to the current balances of the specified accounts,
+// the occurrences of templates which have amount expression are returned as estimated and are not applied to the balances
+func (s ScheduledTransactionOccurrenceSlice) ToScheduledTransactionForecastResponse(accounts []*Account, startTime int64, endTime int64) *ScheduledTransactionForecastResponse {
+	sort.Sort(s)
+
+	accountForecasts := make([]*ScheduledTransactionBalanceForecastResponse, 0, len(accounts))
+	accountForecastMap := make(map[int64]*ScheduledTransactionBalanceForecastResponse, len(accounts))
+	totalForecasts := make([]*ScheduledTransactionBalanceForecastResponse, 0)
+	totalForecastMap := make(map[string]*ScheduledTransactionBalanceForecastResponse)
+
+	for i := 0; i < len(accounts); i++ {
+		account := accounts[i]
+
+		if account.Type != ACCOUNT_TYPE_SINGLE_ACCOUNT {
+			continue
+		}
+
+		accountForecast := newScheduledTransactionBalanceForecastResponse(account.AccountId, account.Currency, account.Balance, startTime)
+		accountForecasts = append(accountForecasts, accountForecast)
+		accountForecastMap[account.AccountId] = accountForecast
+
+		totalForecast, exists := totalForecastMap[account.Currency]
+
+		if !exists {
+			totalForecast = newScheduledTransactionBalanceForecastResponse(0, account.Currency, 0, startTime)
+			totalForecasts = append(totalForecasts, totalForecast)
+			totalForecastMap[account.Currency] = totalForecast
+		}
+
+		totalForecast.CurrentBalance += account.Balance
+		totalForecast.FinalBalance += account.Balance
+		totalForecast.MinBalance += account.Balance
+
+		if totalForecast.FinalBalance < 0 {
+			totalForecast.FirstNegativeBalanceTime = startTime
+		} else {
+			totalForecast.FirstNegativeBalanceTime = 0
+		}
+	}
+
+	occurrenceResps := make([]*ScheduledTransactionOccurrenceResponse, 0, len(s))
+
+	for i := 0; i < len(s); i++ {
+		occurrence := s[i]
+		template := occurrence.Template
+		sourceAccountForecast := accountForecastMap[template.AccountId]
+
+		if sourceAccountForecast == nil {
+			continue
+		}
+
+		var destinationAccountForecast *ScheduledTransactionBalanceForecastResponse
+
+		if template.Type == TRANSACTION_TYPE_TRANSFER {
+			destinationAccountForecast = accountForecastMap[template.RelatedAccountId]
+
+			if destinationAccountForecast == nil {
+				continue
+			}
+		}
+
+		if template.AmountExpression != "" {
+			if template.Type != TRANSACTION_TYPE_INCOME && template.Type != TRANSACTION_TYPE_EXPENSE && template.Type != TRANSACTION_TYPE_TRANSFER {
+				continue
+			}
+
+			sourceAccountForecast.HasEstimatedOccurrences = true
+			totalForecastMap[sourceAccountForecast.Currency].HasEstimatedOccurrences = true
+
+			if destinationAccountForecast != nil {
+				destinationAccountForecast.HasEstimatedOccurrences = true
+				totalForecastMap[destinationAccountForecast.Currency].HasEstimatedOccurrences = true
+			}
+
+			occurrenceResps = append(occurrenceResps, occurrence.ToScheduledTransactionOccurrenceResponse())
+			continue
+		}
+
+		currencyChangedAmounts := make(map[string]int64)
+
+		if template.Type == TRANSACTION_TYPE_INCOME {
+			sourceAccountForecast.applyBalanceChange(occurrence.TransactionTime, template.Amount)
+			currencyChangedAmounts[sourceAccountForecast.Currency] += template.Amount
+		} else if template.Type == TRANSACTION_TYPE_EXPENSE {
+			sourceAccountForecast.applyBalanceChange(occurrence.TransactionTime, -template.Amount)
+			currencyChangedAmounts[sourceAccountForecast.Currency] -= template.Amount
+		} else if template.Type == TRANSACTION_TYPE_TRANSFER {
+			sourceAccountForecast.applyBalanceChange(occurrence.TransactionTime, -template.Amount)
+			destinationAccountForecast.applyBalanceChange(occurrence.TransactionTime, template.RelatedAccountAmount)
+			currencyChangedAmounts[sourceAccountForecast.Currency] -= template.Amount
+			currencyChangedAmounts[destinationAccountForecast.Currency] += template.RelatedAccountAmount
+		} else {
+			continue
+		}
+
+		for currency, changedAmount := range currencyChangedAmounts {
+			totalForecastMap[currency].applyBalanceChange(occurrence.TransactionTime, changedAmount)
+		}
+
+		occurrenceResps = append(occurrenceResps, occurrence.ToScheduledTransactionOccurrenceResponse())
+	}
+
+	return &ScheduledTransactionForecastResponse{
+		StartTime:   startTime,
+		EndTime:     endTime,
+		Occurrences: occurrenceResps,
+		Accounts:    accountForecasts,
+		Totals:      totalForecasts,
+	}
+}
+
+func newScheduledTransactionBalanceForecastResponse(accountId int64, currency string, currentBalance int64, startTime int64) *ScheduledTransactionBalanceForecastResponse {
+	forecast := &ScheduledTransactionBalanceForecastResponse{
+		AccountId:      accountId,
+		Currency:       currency,
+		CurrentBalance: currentBalance,
+		FinalBalance:   currentBalance,
+		MinBalance:     currentBalance,
+		MinBalanceTime: startTime,
+		Balances:       make([]*ScheduledTransactionBalanceChangeResponse, 0),
+	}
+
+	if currentBalance < 0 {
+		forecast.FirstNegativeBalanceTime = startTime
+	}
+
+	return forecast
+}
+
+func (f *ScheduledTransactionBalanceForecastResponse) applyBalanceChange(transactionTime int64, changedAmount int64) {
+	f.FinalBalance += changedAmount
+	f.Balances = append(f.Balances, &ScheduledTransactionBalanceChangeResponse{
+		Time:    transactionTime,
+		Amount:  changedAmount,
+		Balance: f.FinalBalance,
+	})
+
+	if f.FinalBalance < f.MinBalance {
+		f.MinBalance = f.FinalBalance
+		f.MinBalanceTime = transactionTime
+	}
+
+	if f.FinalBalance < 0 && f.FirstNegativeBalanceTime == 0 {
+		f.FirstNegativeBalanceTime = transactionTime
+	}
+}
@@ -0,0 +1,145 @@
+package models
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScheduledTransactionOccurrenceSliceLess(t *testing.T) {
+	template1 := &TransactionTemplate{TemplateId: 1, DisplayOrder: 2}
+	template2 := &TransactionTemplate{TemplateId: 2, DisplayOrder: 1}
+
+	occurrences := ScheduledTransactionOccurrenceSlice{
+		{Template: template1, TransactionTime: 200},
+		{Template: template1, TransactionTime: 100},
+		{Template: template2, TransactionTime: 100},
+	}
+
+	sort.Sort(occurrences)
+
+	assert.Equal(t, int64(100), occurrences[0].TransactionTime)
+	assert.Equal(t, int64(2), occurrences[0].Template.TemplateId)
+	assert.Equal(t, int64(100), occurrences[1].TransactionTime)
+	assert.Equal(t, int64(1), occurrences[1].Template.TemplateId)
+	assert.Equal(t, int64(200), occurrences[2].TransactionTime)
+}
+
+func TestScheduledTransactionOccurrenceSliceToScheduledTransactionForecastResponse(t *testing.T) {
+	accounts := []*Account{
+		{AccountId: 1, Type: ACCOUNT_TYPE_SINGLE_ACCOUNT, Currency: "USD", Balance: 10000},
+		{AccountId: 2, Type: ACCOUNT_TYPE_SINGLE_ACCOUNT, Currency: "USD", Balance: 5000},
+		{AccountId: 3, Type: ACCOUNT_TYPE_SINGLE_ACCOUNT, Currency: "EUR", Balance: 0},
+		{AccountId: 4, Type: ACCOUNT_TYPE_MULTI_SUB_ACCOUNTS, Currency: "USD", Balance: 0},
+	}
+
+	rentTemplate := &TransactionTemplate{TemplateId: 1, Type: TRANSACTION_TYPE_EXPENSE, AccountId: 1, Amount: 12000, TagIds: "1,2"}
+	salaryTemplate := &TransactionTemplate{TemplateId: 2, Type: TRANSACTION_TYPE_INCOME, AccountId: 1, Amount: 30000}
+	transferTemplate := &TransactionTemplate{TemplateId: 3, Type: TRANSACTION_TYPE_TRANSFER, AccountId: 2, Amount: 1000, RelatedAccountId: 3, RelatedAccountAmount: 900}
+	deletedAccountTemplate := &TransactionTemplate{TemplateId: 4, Type: TRANSACTION_TYPE_EXPENSE, AccountId: 99, Amount: 100}
+
+	occurrences := ScheduledTransactionOccurrenceSlice{
+		{Template: salaryTemplate, TransactionTime: 300},
+		{Template: rentTemplate, TransactionTime: 200},
+		{Template: transferTemplate, TransactionTime: 250},
+		{Template: deletedAccountTemplate, TransactionTime: 260},
+	}
+
+	forecast := occurrences.ToScheduledTransactionForecastResponse(accounts, 100, 400)
+
+	assert.Equal(t, int64(100), forecast.StartTime)
+	assert.Equal(t, int64(400), forecast.EndTime)
+
+	assert.Equal(t, 3, len(forecast.Occurrences))
+	assert.Equal(t, int64(1), forecast.Occurrences[0].TemplateId)
+	assert.Equal(t, []string{"1", "2"}, forecast.Occurrences[0].TagIds)
+	assert.Equal(t, int64(3), forecast.Occurrences[1].TemplateId)
+	assert.Equal(t, int64(3), forecast.Occurrences[1].DestinationAccountId)
+	assert.Equal(t, int64(2), forecast.Occurrences[2].TemplateId)
+	assert.Equal(t, int64(0), forecast.Occurrences[2].DestinationAccountId)
+
+	assert.Equal(t, 3, len(forecast.Accounts))
+
+	assert.Equal(t, int64(1), forecast.Accounts[0].AccountId)
+	assert.Equal(t, int64(10000), forecast.Accounts[0].CurrentBalance)
+	assert.Equal(t, int64(28000), forecast.Accounts[0].FinalBalance)
+	assert.Equal(t, int64(-2000), forecast.Accounts[0].MinBalance)
+	assert.Equal(t, int64(200), forecast.Accounts[0].MinBalanceTime)
+	assert.Equal(t, int64(200), forecast.Accounts[0].FirstNegativeBalanceTime)
+	assert.Equal(t, 2, len(forecast.Accounts[0].Balances))
+	assert.Equal(t, int64(-12000), forecast.Accounts[0].Balances[0].Amount)
+	assert.Equal(t, int64(-2000), forecast.Accounts[0].Balances[0].Balance)
+	assert.Equal(t, int64(30000), forecast.Accounts[0].Balances[1].Amount)
+	assert.Equal(t, int64(28000), forecast.Accounts[0].Balances[1].Balance)
+
+	assert.Equal(t, int64(2), forecast.Accounts[1].AccountId)
+	assert.Equal(t, int64(4000), forecast.Accounts[1].FinalBalance)
+	assert.Equal(t, int64(4000), forecast.Accounts[1].MinBalance)
+	assert.Equal(t, int64(250), forecast.Accounts[1].MinBalanceTime)
+	assert.Equal(t, int64(0), forecast.Accounts[1].FirstNegativeBalanceTime)
+
+	assert.Equal(t, int64(3), forecast.Accounts[2].AccountId)
+	assert.Equal(t, int64(900), forecast.Accounts[2].FinalBalance)
+	assert.Equal(t, int64(0), forecast.Accounts[2].MinBalance)
+	assert.Equal(t, int64(100), forecast.Accounts[2].MinBalanceTime)
+
+	assert.Equal(t, 2, len(forecast.Totals))
+
+	assert.Equal(t, "USD", forecast.Totals[0].Currency)
+	assert.Equal(t, int64(0), forecast.Totals[0].AccountId)
+	assert.Equal(t, int64(15000), forecast.Totals[0].CurrentBalance)
+	assert.Equal(t, int64(32000), forecast.Totals[0].FinalBalance)
+	assert.Equal(t, int64(2000), forecast.Totals[0].MinBalance)
+	assert.Equal(t, int64(250), forecast.Totals[0].MinBalanceTime)
+	assert.Equal(t, int64(0), forecast.Totals[0].FirstNegativeBalanceTime)
+	assert.Equal(t, 3, len(forecast.Totals[0].Balances))
+
+	assert.Equal(t, "EUR", forecast.Totals[1].Currency)
+	assert.Equal(t, int64(900), forecast.Totals[1].FinalBalance)
+	assert.Equal(t, 1, len(forecast.Totals[1].Balances))
+}
+
+func TestScheduledTransactionOccurrenceSliceToScheduledTransactionForecastResponse_AmountExpression(t *testing.T) {
+	accounts := []*Account{
+		{AccountId: 1, Type: ACCOUNT_TYPE_SINGLE_ACCOUNT, Currency: "USD", Balance: 10000},
+		{AccountId: 2, Type: ACCOUNT_TYPE_SINGLE_ACCOUNT, Currency: "USD", Balance: 5000},
+		{AccountId: 3, Type: ACCOUNT_TYPE_SINGLE_ACCOUNT, Currency: "EUR", Balance: 0},
+	}
+
+	rentTemplate := &TransactionTemplate{TemplateId: 1, Type: TRANSACTION_TYPE_EXPENSE, AccountId: 1, Amount: 12000}
+	sweepTemplate := &TransactionTemplate{TemplateId: 2, Type: TRANSACTION_TYPE_TRANSFER, AccountId: 2, Amount: 1000, RelatedAccountId: 3, RelatedAccountAmount: 900, AmountExpression: "balance - 100"}
+
+	occurrences := ScheduledTransactionOccurrenceSlice{
+		{Template: rentTemplate, TransactionTime: 200},
+		{Template: sweepTemplate, TransactionTime: 250},
+	}
+
+	forecast := occurrences.ToScheduledTransactionForecastResponse(accounts, 100, 400)
+
+	assert.Equal(t, 2, len(forecast.Occurrences))
+	assert.Equal(t, false, forecast.Occurrences[0].Estimated)
+	assert.Equal(t, true, forecast.Occurrences[1].Estimated)
+	assert.Equal(t, "balance - 100", forecast.Occurrences[1].AmountExpression)
+	assert.Equal(t, int64(1000), forecast.Occurrences[1].SourceAmount)
+
+	assert.Equal(t, false, forecast.Accounts[0].HasEstimatedOccurrences)
+	assert.Equal(t, int64(-2000), forecast.Accounts[0].FinalBalance)
+
+	assert.Equal(t, true, forecast.Accounts[1].HasEstimatedOccurrences)
+	assert.Equal(t, int64(5000), forecast.Accounts[1].FinalBalance)
+	assert.Equal(t, 0, len(forecast.Accounts[1].Balances))
+
+	assert.Equal(t, true, forecast.Accounts[2].HasEstimatedOccurrences)
+	assert.Equal(t, int64(0), forecast.Accounts[2].FinalBalance)
+
+	assert.Equal(t, "USD", forecast.Totals[0].Currency)
+	assert.Equal(t, true, forecast.Totals[0].HasEstimatedOccurrences)
+	assert.Equal(t, int64(3000), forecast.Totals[0].FinalBalance)
+	assert.Equal(t, 1, len(forecast.Totals[0].Balances))
+
+	assert.Equal(t, "EUR", forecast.Totals[1].Currency)
+	assert.Equal(t, true, forecast.Totals[1].HasEstimatedOccurrences)
+	assert.Equal(t, int64(0), forecast.Totals[1].FinalBalance)
+	assert.Equal(t, 0, len(forecast.Totals[1].Balances))
+}
@@ -0,0 +1,95 @@
+package models
+
+import (
+	"time"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+)
+
+// StatisticPeriodGranularity represents the granularity of statistic trends periods
+type StatisticPeriodGranularity byte
+
+// Statistic period granularities
+const (
+	STATISTIC_PERIOD_GRANULARITY_DEFAULT StatisticPeriodGranularity = 0
+	STATISTIC_PERIOD_GRANULARITY_DAY     StatisticPeriodGranularity = 1
+	STATISTIC_PERIOD_GRANULARITY_WEEK    StatisticPeriodGranularity = 2
+	STATISTIC_PERIOD_GRANULARITY_MONTH   StatisticPeriodGranularity = 3
+	STATISTIC_PERIOD_GRANULARITY_QUARTER StatisticPeriodGranularity = 4
+	STATISTIC_PERIOD_GRANULARITY_YEAR    StatisticPeriodGranularity = 5
+)
+
+// StatisticPeriodOptions represents how the local dates are grouped into statistic periods,
+// the period start day and period start month are used for custom periods (e.g. fiscal month starts on the 25th or fiscal year starts in April)
+type StatisticPeriodOptions struct {
+	Granularity      StatisticPeriodGranularity
+	FirstDayOfWeek   core.WeekDay
+	PeriodStartDay   int32
+	PeriodStartMonth int32
+}
+
+// GetPeriodStartDate returns the first date (at midnight) of the period which contains the specified date, the location of the specified date is kept
+func (o *StatisticPeriodOptions) GetPeriodStartDate(date time.Time) time.Time {
+	year, month, day := date.Date()
+
+	switch o.Granularity {
+	case STATISTIC_PERIOD_GRANULARITY_DAY:
+		return time.Date(year, month, day, 0, 0, 0, 0, date.Location())
+	case STATISTIC_PERIOD_GRANULARITY_WEEK:
+		days := (int(date.Weekday()) - int(o.FirstDayOfWeek) + 7) % 7
+		return time.Date(year, month, day-days, 0, 0, 0, 0, date.Location())
+	}
+
+	periodStartDay := o.getPeriodStartDay()
+
+	if day < periodStartDay {
+		month--
+	}
+
+	monthsAfterPeriodStart := 0
+
+	if o.Granularity == STATISTIC_PERIOD_GRANULARITY_QUARTER {
+		monthsAfterPeriodStart = (int(month) - o.getPeriodStartMonth() + 24) % 12 % 3
+	} else if o.Granularity == STATISTIC_PERIOD_GRANULARITY_YEAR {
+		monthsAfterPeriodStart = (int(month) - o.getPeriodStartMonth() + 24) % 12
+	}
+
+	return time.Date(year, month-time.Month(monthsAfterPeriodStart), periodStartDay, 0, 0, 0, 0, date.Location())
+}
+
+// GetNextPeriodStartDate returns the first date of the next period of the period which starts at the specified date
+func (o *StatisticPeriodOptions) GetNextPeriodStartDate(periodStartDate time.Time) time.Time {
+	switch o.Granularity {
+	case STATISTIC_PERIOD_GRANULARITY_DAY:
+		return periodStartDate.AddDate(0, 0, 1)
+	case STATISTIC_PERIOD_GRANULARITY_WEEK:
+		return periodStartDate.AddDate(0, 0, 7)
+	case STATISTIC_PERIOD_GRANULARITY_QUARTER:
+		return periodStartDate.AddDate(0, 3, 0)
+	case STATISTIC_PERIOD_GRANULARITY_YEAR:
+		return periodStartDate.AddDate(1, 0, 0)
+	default:
+		return periodStartDate.AddDate(0, 1, 0)
+	}
+}
+
+// GetQuarter returns the quarter number (from 1 to 4) of the quarter period which starts at the specified date, the quarters are counted from the period start month
+func (o *StatisticPeriodOptions) GetQuarter(periodStartDate time.Time) int32 {
+	return int32((int(periodStartDate.Month())-o.getPeriodStartMonth()+12)%12/3 + 1)
+}
+
+func (o *StatisticPeriodOptions) getPeriodStartDay() int {
+	if o.PeriodStartDay < 1 {
+		return 1
+	}
+
+	return int(o.PeriodStartDay)
+}
+
+func (o *StatisticPeriodOptions) getPeriodStartMonth() int {
+	if o.PeriodStartMonth < 1 {
+		return 1
+	}
+
+	return int(o.PeriodStartMonth)
+}
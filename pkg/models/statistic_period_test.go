@@ -0,0 +1,69 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+)
+
+func TestStatisticPeriodOptionsGetPeriodStartDate_Day(t *testing.T) {
+	timezone := time.FixedZone("Client Timezone", 9*60*60)
+	options := &StatisticPeriodOptions{Granularity: STATISTIC_PERIOD_GRANULARITY_DAY}
+
+	assert.Equal(t, time.Date(2024, 3, 15, 0, 0, 0, 0, timezone), options.GetPeriodStartDate(time.Date(2024, 3, 15, 23, 59, 59, 0, timezone)))
+	assert.Equal(t, time.Date(2024, 3, 16, 0, 0, 0, 0, timezone), options.GetNextPeriodStartDate(time.Date(2024, 3, 15, 0, 0, 0, 0, timezone)))
+}
+
+func TestStatisticPeriodOptionsGetPeriodStartDate_Week(t *testing.T) {
+	options := &StatisticPeriodOptions{Granularity: STATISTIC_PERIOD_GRANULARITY_WEEK, FirstDayOfWeek: core.WEEKDAY_MONDAY}
+
+	assert.Equal(t, time.Date(2024, 2, 26, 0, 0, 0, 0, time.UTC), options.GetPeriodStartDate(time.Date(2024, 3, 3, 12, 0, 0, 0, time.UTC)))
+	assert.Equal(t, time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC), options.GetPeriodStartDate(time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC), options.GetNextPeriodStartDate(time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)))
+
+	options.FirstDayOfWeek = core.WEEKDAY_SUNDAY
+	assert.Equal(t, time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC), options.GetPeriodStartDate(time.Date(2024, 3, 3, 12, 0, 0, 0, time.UTC)))
+	assert.Equal(t, time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC), options.GetPeriodStartDate(time.Date(2024, 1, 6, 12, 0, 0, 0, time.UTC)))
+}
+
+func TestStatisticPeriodOptionsGetPeriodStartDate_Month(t *testing.T) {
+	options := &StatisticPeriodOptions{Granularity: STATISTIC_PERIOD_GRANULARITY_MONTH}
+
+	assert.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), options.GetPeriodStartDate(time.Date(2024, 3, 31, 12, 0, 0, 0, time.UTC)))
+	assert.Equal(t, time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), options.GetNextPeriodStartDate(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)))
+
+	options.PeriodStartDay = 25
+	assert.Equal(t, time.Date(2024, 3, 25, 0, 0, 0, 0, time.UTC), options.GetPeriodStartDate(time.Date(2024, 3, 25, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, time.Date(2024, 2, 25, 0, 0, 0, 0, time.UTC), options.GetPeriodStartDate(time.Date(2024, 3, 24, 23, 59, 59, 0, time.UTC)))
+	assert.Equal(t, time.Date(2023, 12, 25, 0, 0, 0, 0, time.UTC), options.GetPeriodStartDate(time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, time.Date(2024, 1, 25, 0, 0, 0, 0, time.UTC), options.GetNextPeriodStartDate(time.Date(2023, 12, 25, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestStatisticPeriodOptionsGetPeriodStartDate_Quarter(t *testing.T) {
+	options := &StatisticPeriodOptions{Granularity: STATISTIC_PERIOD_GRANULARITY_QUARTER}
+
+	periodStartDate := options.GetPeriodStartDate(time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), periodStartDate)
+	assert.Equal(t, int32(2), options.GetQuarter(periodStartDate))
+	assert.Equal(t, time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC), options.GetNextPeriodStartDate(periodStartDate))
+
+	options.PeriodStartMonth = 4
+	options.PeriodStartDay = 6
+	periodStartDate = options.GetPeriodStartDate(time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, time.Date(2023, 10, 6, 0, 0, 0, 0, time.UTC), periodStartDate)
+	assert.Equal(t, int32(3), options.GetQuarter(periodStartDate))
+}
+
+func TestStatisticPeriodOptionsGetPeriodStartDate_Year(t *testing.T) {
+	options := &StatisticPeriodOptions{Granularity: STATISTIC_PERIOD_GRANULARITY_YEAR}
+
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), options.GetPeriodStartDate(time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), options.GetNextPeriodStartDate(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)))
+
+	options.PeriodStartMonth = 4
+	assert.Equal(t, time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC), options.GetPeriodStartDate(time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), options.GetPeriodStartDate(time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)))
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/mayswind/ezbookkeeping/pkg/core"
 	"github.com/mayswind/ezbookkeeping/pkg/errs"
 	"github.com/mayswind/ezbookkeeping/pkg/utils"
 )
@@ -80,8 +81,8 @@ const (
 // Transaction represents transaction data stored in database
 type Transaction struct {
 	TransactionId        int64             `xorm:"PK"`
-	Uid                  int64             `xorm:"UNIQUE(UQE_transaction_uid_time) INDEX(IDX_transaction_uid_deleted_time) INDEX(IDX_transaction_uid_deleted_type_time) INDEX(IDX_transaction_uid_deleted_type_account_id_time) INDEX(IDX_transaction_uid_deleted_category_id_time) INDEX(IDX_transaction_uid_deleted_account_id_time) INDEX(IDX_transaction_uid_deleted_time_longitude_latitude) NOT NULL"`
-	Deleted              bool              `xorm:"INDEX(IDX_transaction_uid_deleted_time) INDEX(IDX_transaction_uid_deleted_type_time) INDEX(IDX_transaction_uid_deleted_type_account_id_time) INDEX(IDX_transaction_uid_deleted_category_id_time) INDEX(IDX_transaction_uid_deleted_account_id_time) INDEX(IDX_transaction_uid_deleted_time_longitude_latitude) NOT NULL"`
+	Uid                  int64             `xorm:"UNIQUE(UQE_transaction_uid_time) INDEX(IDX_transaction_uid_deleted_time) INDEX(IDX_transaction_uid_deleted_type_time) INDEX(IDX_transaction_uid_deleted_type_account_id_time) INDEX(IDX_transaction_uid_deleted_category_id_time) INDEX(IDX_transaction_uid_deleted_account_id_time) INDEX(IDX_transaction_uid_deleted_time_longitude_latitude) INDEX(IDX_transaction_uid_deleted_scheduled_template_id_time) NOT NULL"`
+	Deleted              bool              `xorm:"INDEX(IDX_transaction_uid_deleted_time) INDEX(IDX_transaction_uid_deleted_type_time) INDEX(IDX_transaction_uid_deleted_type_account_id_time) INDEX(IDX_transaction_uid_deleted_category_id_time) INDEX(IDX_transaction_uid_deleted_account_id_time) INDEX(IDX_transaction_uid_deleted_time_longitude_latitude) INDEX(IDX_transaction_uid_deleted_scheduled_template_id_time) NOT NULL"`
 	Type                 TransactionDbType `xorm:"INDEX(IDX_transaction_uid_deleted_type_time) INDEX(IDX_transaction_uid_deleted_type_account_id_time) NOT NULL"`
 	CategoryId           int64             `xorm:"INDEX(IDX_transaction_uid_deleted_category_id_time) NOT NULL"`
 	AccountId            int64             `xorm:"INDEX(IDX_transaction_uid_deleted_account_id_time) INDEX(IDX_transaction_uid_deleted_type_account_id_time) NOT NULL"`
@@ -97,6 +98,8 @@ type Transaction struct {
 	GeoLatitude          float64           `xorm:"INDEX(IDX_transaction_uid_deleted_time_longitude_latitude)"`
 	CreatedIp            string            `xorm:"VARCHAR(39)"`
 	ScheduledCreated     bool
+	ScheduledTemplateId  int64 `xorm:"INDEX(IDX_transaction_uid_deleted_scheduled_template_id_time)"`
+	ScheduledTime        int64 `xorm:"INDEX(IDX_transaction_uid_deleted_scheduled_template_id_time)"`
 	CreatedUnixTime      int64
 	UpdatedUnixTime      int64
 	DeletedUnixTime      int64
@@ -145,8 +148,9 @@ type TransactionModifyRequest struct {
 
 // TransactionImportRequest represents all parameters of transaction import request
 type TransactionImportRequest struct {
-	Transactions    []*TransactionCreateRequest `json:"transactions"`
-	ClientSessionId string                      `json:"clientSessionId"`
+	Transactions      []*TransactionCreateRequest                   `json:"transactions"`
+	BalanceAssertions []*ImportAccountBalanceAssertionCreateRequest `json:"balanceAssertions"`
+	ClientSessionId   string                                        `json:"clientSessionId"`
 }
 
 // TransactionImportProcessRequest represents all parameters of transaction import process request
@@ -165,6 +169,10 @@ type TransactionCountRequest struct {
 	Keyword       string                   `form:"keyword"`
 	MaxTime       int64                    `form:"max_time" binding:"min=0"`
 	MinTime       int64                    `form:"min_time" binding:"min=0"`
+	GeoLatitude   float64                  `form:"latitude" binding:"min=-90,max=90"`
+	GeoLongitude  float64                  `form:"longitude" binding:"min=-180,max=180"`
+	GeoRadius     float64                  `form:"radius" binding:"min=0,max=100000"`
+	AnomalyOnly   bool                     `form:"anomaly_only"`
 }
 
 // TransactionListByMaxTimeRequest represents all parameters of transaction listing by max time request
@@ -178,10 +186,15 @@ type TransactionListByMaxTimeRequest struct {
 	Keyword       string                   `form:"keyword"`
 	MaxTime       int64                    `form:"max_time" binding:"min=0"`
 	MinTime       int64                    `form:"min_time" binding:"min=0"`
+	GeoLatitude   float64                  `form:"latitude" binding:"min=-90,max=90"`
+	GeoLongitude  float64                  `form:"longitude" binding:"min=-180,max=180"`
+	GeoRadius     float64                  `form:"radius" binding:"min=0,max=100000"`
+	AnomalyOnly   bool                     `form:"anomaly_only"`
 	Page          int32                    `form:"page" binding:"min=0"`
 	Count         int32                    `form:"count" binding:"required,min=1,max=50"`
 	WithCount     bool                     `form:"with_count"`
 	WithPictures  bool                     `form:"with_pictures"`
+	WithAnomalies bool                     `form:"with_anomalies"`
 	TrimAccount   bool                     `form:"trim_account"`
 	TrimCategory  bool                     `form:"trim_category"`
 	TrimTag       bool                     `form:"trim_tag"`
@@ -198,6 +211,9 @@ type TransactionListInMonthByPageRequest struct {
 	TagFilterType TransactionTagFilterType `form:"tag_filter_type" binding:"min=0,max=3"`
 	AmountFilter  string                   `form:"amount_filter" binding:"validAmountFilter"`
 	Keyword       string                   `form:"keyword"`
+	GeoLatitude   float64                  `form:"latitude" binding:"min=-90,max=90"`
+	GeoLongitude  float64                  `form:"longitude" binding:"min=-180,max=180"`
+	GeoRadius     float64                  `form:"radius" binding:"min=0,max=100000"`
 	WithPictures  bool                     `form:"with_pictures"`
 	TrimAccount   bool                     `form:"trim_account"`
 	TrimCategory  bool                     `form:"trim_category"`
@@ -206,19 +222,40 @@ type TransactionListInMonthByPageRequest struct {
 
 // TransactionStatisticRequest represents all parameters of transaction statistic request
 type TransactionStatisticRequest struct {
-	StartTime              int64                    `form:"start_time" binding:"min=0"`
-	EndTime                int64                    `form:"end_time" binding:"min=0"`
+	StartTime              int64                            `form:"start_time" binding:"min=0"`
+	EndTime                int64                            `form:"end_time" binding:"min=0"`
+	TagIds                 string                           `form:"tag_ids"`
+	TagFilterType          TransactionTagFilterType         `form:"tag_filter_type" binding:"min=0,max=3"`
+	Breakdown              string                           `form:"breakdown"`
+	MultiTagRule           TransactionStatisticMultiTagRule `form:"multi_tag_rule" binding:"min=0,max=1"`
+	UseTransactionTimezone bool                             `form:"use_transaction_timezone"`
+	CurrencyConversion     StatisticCurrencyConversionType  `form:"currency_conversion" binding:"min=0,max=2"`
+}
+
+// TransactionReportRequest represents all parameters of transaction report request
+type TransactionReportRequest struct {
+	StartTime              int64                    `form:"start_time" binding:"required,min=1"`
+	EndTime                int64                    `form:"end_time" binding:"required,min=1"`
+	Type                   TransactionDbType        `form:"type" binding:"min=0,max=4"`
+	CategoryIds            string                   `form:"category_ids"`
+	AccountIds             string                   `form:"account_ids"`
 	TagIds                 string                   `form:"tag_ids"`
 	TagFilterType          TransactionTagFilterType `form:"tag_filter_type" binding:"min=0,max=3"`
+	AmountFilter           string                   `form:"amount_filter" binding:"validAmountFilter"`
+	Keyword                string                   `form:"keyword"`
 	UseTransactionTimezone bool                     `form:"use_transaction_timezone"`
 }
 
 // TransactionStatisticTrendsRequest represents all parameters of transaction statistic trends request
 type TransactionStatisticTrendsRequest struct {
 	YearMonthRangeRequest
-	TagIds                 string                   `form:"tag_ids"`
-	TagFilterType          TransactionTagFilterType `form:"tag_filter_type" binding:"min=0,max=3"`
-	UseTransactionTimezone bool                     `form:"use_transaction_timezone"`
+	TagIds                 string                          `form:"tag_ids"`
+	TagFilterType          TransactionTagFilterType        `form:"tag_filter_type" binding:"min=0,max=3"`
+	Granularity            StatisticPeriodGranularity      `form:"granularity" binding:"min=0,max=5"`
+	PeriodStartDay         int32                           `form:"period_start_day" binding:"min=0,max=28"`
+	PeriodStartMonth       int32                           `form:"period_start_month" binding:"min=0,max=12"`
+	UseTransactionTimezone bool                            `form:"use_transaction_timezone"`
+	CurrencyConversion     StatisticCurrencyConversionType `form:"currency_conversion" binding:"min=0,max=2"`
 }
 
 // TransactionAmountsRequest represents all parameters of transaction amounts request
@@ -281,6 +318,7 @@ type TransactionInfoResponse struct {
 	Pictures             TransactionPictureInfoBasicResponseSlice `json:"pictures,omitempty"`
 	Comment              string                                   `json:"comment"`
 	GeoLocation          *TransactionGeoLocationResponse          `json:"geoLocation,omitempty"`
+	Anomalies            []*TransactionAnomalyResponse            `json:"anomalies,omitempty"`
 	Editable             bool                                     `json:"editable"`
 }
 
@@ -311,16 +349,21 @@ type TransactionStatisticResponse struct {
 
 // TransactionStatisticResponseItem represents total amount item for a response
 type TransactionStatisticResponseItem struct {
-	CategoryId  int64 `json:"categoryId,string"`
-	AccountId   int64 `json:"accountId,string"`
-	TotalAmount int64 `json:"amount"`
+	CategoryId  int64  `json:"categoryId,string"`
+	AccountId   int64  `json:"accountId,string"`
+	TotalAmount int64  `json:"amount"`
+	Currency    string `json:"currency,omitempty"`
 }
 
-// TransactionStatisticTrendsResponseItem represents the data within each statistic interval
+// TransactionStatisticTrendsResponseItem represents the data within each statistic interval, the year, month and day are the first date of the interval
 type TransactionStatisticTrendsResponseItem struct {
-	Year  int32                               `json:"year"`
-	Month int32                               `json:"month"`
-	Items []*TransactionStatisticResponseItem `json:"items"`
+	Year      int32                               `json:"year"`
+	Month     int32                               `json:"month"`
+	Day       int32                               `json:"day"`
+	Quarter   int32                               `json:"quarter,omitempty"`
+	StartTime int64                               `json:"startTime"`
+	EndTime   int64                               `json:"endTime"`
+	Items     []*TransactionStatisticResponseItem `json:"items"`
 }
 
 // TransactionAmountsResponseItem represents an item of transaction amounts
@@ -452,6 +495,22 @@ func (t *TransactionAmountsRequest) GetTransactionAmountsRequestItems() ([]*Tran
 	return requestItems, nil
 }
 
+// GetStatisticPeriodOptions returns the statistic period options of the request, the default granularity is month
+func (t *TransactionStatisticTrendsRequest) GetStatisticPeriodOptions(firstDayOfWeek core.WeekDay) *StatisticPeriodOptions {
+	granularity := t.Granularity
+
+	if granularity == STATISTIC_PERIOD_GRANULARITY_DEFAULT {
+		granularity = STATISTIC_PERIOD_GRANULARITY_MONTH
+	}
+
+	return &StatisticPeriodOptions{
+		Granularity:      granularity,
+		FirstDayOfWeek:   firstDayOfWeek,
+		PeriodStartDay:   t.PeriodStartDay,
+		PeriodStartMonth: t.PeriodStartMonth,
+	}
+}
+
 // GetNumericYearMonthRange returns numeric start year, start month, end year and end month
 func (t *YearMonthRangeRequest) GetNumericYearMonthRange() (int32, int32, int32, int32, error) {
 	var startYear, startMonth, endYear, endMonth int32
@@ -517,7 +576,11 @@ func (s TransactionStatisticTrendsResponseItemSlice) Less(i, j int) bool {
 		return s[i].Year < s[j].Year
 	}
 
-	return s[i].Month < s[j].Month
+	if s[i].Month != s[j].Month {
+		return s[i].Month < s[j].Month
+	}
+
+	return s[i].Day < s[j].Day
 }
 
 // TransactionAmountsResponseItemAmountInfoSlice represents the slice data structure of TransactionAmountsResponseItemAmountInfo
@@ -0,0 +1,63 @@
+package models
+
+// TransactionAnomalyType represents the type of transaction anomaly
+type TransactionAnomalyType byte
+
+// Transaction anomaly types
+const (
+	TRANSACTION_ANOMALY_TYPE_CATEGORY_OUTLIER       TransactionAnomalyType = 1
+	TRANSACTION_ANOMALY_TYPE_NEW_PAYEE_LARGE_AMOUNT TransactionAnomalyType = 2
+	TRANSACTION_ANOMALY_TYPE_POSSIBLE_DUPLICATE     TransactionAnomalyType = 3
+)
+
+// String returns a textual representation of the transaction anomaly type
+func (t TransactionAnomalyType) String() string {
+	switch t {
+	case TRANSACTION_ANOMALY_TYPE_CATEGORY_OUTLIER:
+		return "Category Outlier"
+	case TRANSACTION_ANOMALY_TYPE_NEW_PAYEE_LARGE_AMOUNT:
+		return "New Payee Large Amount"
+	case TRANSACTION_ANOMALY_TYPE_POSSIBLE_DUPLICATE:
+		return "Possible Duplicate"
+	default:
+		return "Invalid"
+	}
+}
+
+// TransactionAnomaly represents transaction anomaly data stored in database
+type TransactionAnomaly struct {
+	AnomalyId            int64                  `xorm:"PK"`
+	Uid                  int64                  `xorm:"INDEX(IDX_transaction_anomaly_uid_deleted_transaction_id) INDEX(IDX_transaction_anomaly_uid_deleted_notified) NOT NULL"`
+	Deleted              bool                   `xorm:"INDEX(IDX_transaction_anomaly_uid_deleted_transaction_id) INDEX(IDX_transaction_anomaly_uid_deleted_notified) NOT NULL"`
+	TransactionId        int64                  `xorm:"INDEX(IDX_transaction_anomaly_uid_deleted_transaction_id) NOT NULL"`
+	AnomalyType          TransactionAnomalyType `xorm:"NOT NULL"`
+	RelatedTransactionId int64                  `xorm:"NOT NULL"`
+	ReferenceAmount      int64                  `xorm:"NOT NULL"`
+	Notified             bool                   `xorm:"INDEX(IDX_transaction_anomaly_uid_deleted_notified) NOT NULL"`
+	CreatedUnixTime      int64
+	UpdatedUnixTime      int64
+	DeletedUnixTime      int64
+}
+
+// TransactionAnomalyDetectionTask represents the pending anomaly detection of new or modified transaction stored in database
+type TransactionAnomalyDetectionTask struct {
+	Uid             int64 `xorm:"PK"`
+	TransactionId   int64 `xorm:"PK"`
+	CreatedUnixTime int64 `xorm:"INDEX(IDX_transaction_anomaly_detection_task_created_time) NOT NULL"`
+}
+
+// TransactionAnomalyResponse represents a view-object of transaction anomaly
+type TransactionAnomalyResponse struct {
+	Type                 TransactionAnomalyType `json:"type"`
+	RelatedTransactionId int64                  `json:"relatedTransactionId,string,omitempty"`
+	ReferenceAmount      int64                  `json:"referenceAmount"`
+}
+
+// ToTransactionAnomalyResponse returns a view-object according to database model
+func (a *TransactionAnomaly) ToTransactionAnomalyResponse() *TransactionAnomalyResponse {
+	return &TransactionAnomalyResponse{
+		Type:                 a.AnomalyType,
+		RelatedTransactionId: a.RelatedTransactionId,
+		ReferenceAmount:      a.ReferenceAmount,
+	}
+}
@@ -0,0 +1,290 @@
+package models
+
+import (
+	"math"
+	"sort"
+)
+
+const earthMeanRadiusInMeters = 6371008.8
+const metersPerLatitudeDegree = earthMeanRadiusInMeters * math.Pi / 180
+
+// DefaultTransactionLocationClusterRadius represents the default cluster radius (in meters) of transaction location statistics
+const DefaultTransactionLocationClusterRadius = 500
+
+// TransactionGeoRadiusFilter represents the filter which only matches the transactions located within the specified radius (in meters) of the center point
+type TransactionGeoRadiusFilter struct {
+	CenterLatitude  float64
+	CenterLongitude float64
+	Radius          float64
+}
+
+// TransactionLocationStatisticRequest represents all parameters of transaction location statistic request
+type TransactionLocationStatisticRequest struct {
+	StartTime              int64                    `form:"start_time" binding:"min=0"`
+	EndTime                int64                    `form:"end_time" binding:"min=0"`
+	Type                   TransactionType          `form:"type" binding:"min=0,max=3"`
+	TagIds                 string                   `form:"tag_ids"`
+	TagFilterType          TransactionTagFilterType `form:"tag_filter_type" binding:"min=0,max=3"`
+	ClusterRadius          float64                  `form:"cluster_radius" binding:"min=0,max=100000"`
+	UseTransactionTimezone bool                     `form:"use_transaction_timezone"`
+}
+
+// TransactionLocationStatisticResponse represents transaction location statistic response
+type TransactionLocationStatisticResponse struct {
+	StartTime     int64                                   `json:"startTime"`
+	EndTime       int64                                   `json:"endTime"`
+	ClusterRadius float64                                 `json:"clusterRadius"`
+	Clusters      TransactionLocationClusterResponseSlice `json:"clusters"`
+}
+
+// TransactionLocationClusterResponse represents a cluster of nearby transactions, the location is the centroid of all transactions in the cluster
+type TransactionLocationClusterResponse struct {
+	Latitude  float64                                 `json:"latitude"`
+	Longitude float64                                 `json:"longitude"`
+	Count     int32                                   `json:"count"`
+	Amounts   []*TransactionLocationClusterAmountItem `json:"amounts"`
+}
+
+// TransactionLocationClusterAmountItem represents total amount of the specified transaction type and currency in a cluster
+type TransactionLocationClusterAmountItem struct {
+	Type        TransactionType `json:"type"`
+	Currency    string          `json:"currency"`
+	TotalAmount int64           `json:"amount"`
+}
+
+// TransactionLocationClusterResponseSlice represents the slice data structure of TransactionLocationClusterResponse
+type TransactionLocationClusterResponseSlice []*TransactionLocationClusterResponse
+
+// TransactionGeoLongitudeRange represents a longitude range (both inclusive) of the bounding box of geographic radius filter,
+// the center longitude is shifted by 360 degrees for the range on the other side of the antimeridian, so the longitude difference can be calculated directly
+type TransactionGeoLongitudeRange struct {
+	MinLongitude    float64
+	MaxLongitude    float64
+	CenterLongitude float64
+}
+
+type transactionLocationCluster struct {
+	latitudeSum  float64
+	longitudeSum float64
+	response     *TransactionLocationClusterResponse
+	amounts      map[TransactionType]map[string]*TransactionLocationClusterAmountItem
+}
+
+type transactionLocationClusterKey struct {
+	latitudeIndex  int64
+	longitudeIndex int64
+}
+
+// GetGeoRadiusFilter returns the geographic radius filter of transaction list request, or nil if the radius is not set
+func (t *TransactionListByMaxTimeRequest) GetGeoRadiusFilter() *TransactionGeoRadiusFilter {
+	return newTransactionGeoRadiusFilter(t.GeoLatitude, t.GeoLongitude, t.GeoRadius)
+}
+
+// GetGeoRadiusFilter returns the geographic radius filter of transaction list by month request, or nil if the radius is not set
+func (t *TransactionListInMonthByPageRequest) GetGeoRadiusFilter() *TransactionGeoRadiusFilter {
+	return newTransactionGeoRadiusFilter(t.GeoLatitude, t.GeoLongitude, t.GeoRadius)
+}
+
+// GetGeoRadiusFilter returns the geographic radius filter of transaction count request, or nil if the radius is not set
+func (t *TransactionCountRequest) GetGeoRadiusFilter() *TransactionGeoRadiusFilter {
+	return newTransactionGeoRadiusFilter(t.GeoLatitude, t.GeoLongitude, t.GeoRadius)
+}
+
+// GetBoundingBox returns the minimum and maximum latitude and the longitude ranges of the box which contains the whole circle,
+// the latitude is clamped to the valid coordinate range, and the box is split into two longitude ranges if it crosses the antimeridian
+func (f *TransactionGeoRadiusFilter) GetBoundingBox() (minLatitude float64, maxLatitude float64, longitudeRanges []*TransactionGeoLongitudeRange) {
+	latitudeDelta := f.Radius / metersPerLatitudeDegree
+	minLatitude = math.Max(f.CenterLatitude-latitudeDelta, -90)
+	maxLatitude = math.Min(f.CenterLatitude+latitudeDelta, 90)
+
+	// the longitude degree is shorter away from the center latitude, so use the latitude which is closest to the pole
+	farthestLatitudeCos := math.Cos(math.Max(math.Abs(minLatitude), math.Abs(maxLatitude)) * math.Pi / 180)
+	longitudeDelta := float64(180)
+
+	if farthestLatitudeCos > 0 && f.Radius < 180*metersPerLatitudeDegree*farthestLatitudeCos {
+		longitudeDelta = f.Radius / (metersPerLatitudeDegree * farthestLatitudeCos)
+	}
+
+	minLongitude := f.CenterLongitude - longitudeDelta
+	maxLongitude := f.CenterLongitude + longitudeDelta
+
+	if minLongitude < -180 {
+		longitudeRanges = []*TransactionGeoLongitudeRange{
+			{MinLongitude: -180, MaxLongitude: maxLongitude, CenterLongitude: f.CenterLongitude},
+			{MinLongitude: minLongitude + 360, MaxLongitude: 180, CenterLongitude: f.CenterLongitude + 360},
+		}
+	} else if maxLongitude > 180 {
+		longitudeRanges = []*TransactionGeoLongitudeRange{
+			{MinLongitude: minLongitude, MaxLongitude: 180, CenterLongitude: f.CenterLongitude},
+			{MinLongitude: -180, MaxLongitude: maxLongitude - 360, CenterLongitude: f.CenterLongitude - 360},
+		}
+	} else {
+		longitudeRanges = []*TransactionGeoLongitudeRange{
+			{MinLongitude: minLongitude, MaxLongitude: maxLongitude, CenterLongitude: f.CenterLongitude},
+		}
+	}
+
+	return minLatitude, maxLatitude, longitudeRanges
+}
+
+// GetDistanceScales returns how many meters one degree of latitude and one degree of longitude are near the center point,
+// the approximate distance is sqrt((Δlatitude * latitudeScale)^2 + (Δlongitude * longitudeScale)^2), which is accurate enough for small radius
+func (f *TransactionGeoRadiusFilter) GetDistanceScales() (latitudeScale float64, longitudeScale float64) {
+	return metersPerLatitudeDegree, metersPerLatitudeDegree * math.Cos(f.CenterLatitude*math.Pi/180)
+}
+
+// IsWithinRadius returns whether the specified location is within the radius of the center point by approximate distance
+func (f *TransactionGeoRadiusFilter) IsWithinRadius(latitude float64, longitude float64) bool {
+	latitudeScale, longitudeScale := f.GetDistanceScales()
+	latitudeDistance := (latitude - f.CenterLatitude) * latitudeScale
+	longitudeDifference := longitude - f.CenterLongitude
+
+	// the shorter way is across the antimeridian
+	if longitudeDifference > 180 {
+		longitudeDifference -= 360
+	} else if longitudeDifference < -180 {
+		longitudeDifference += 360
+	}
+
+	longitudeDistance := longitudeDifference * longitudeScale
+
+	return latitudeDistance*latitudeDistance+longitudeDistance*longitudeDistance <= f.Radius*f.Radius
+}
+
+// GetTransactionLocationClusters returns the clusters of income and expense transactions which have geographic location,
+// transactions are grouped into grid cells whose width is twice the cluster radius, and the clusters are sorted by transaction count in descending order
+func GetTransactionLocationClusters(transactions []*Transaction, accountMap map[int64]*Account, transactionType TransactionType, clusterRadius float64) TransactionLocationClusterResponseSlice {
+	if clusterRadius <= 0 {
+		clusterRadius = DefaultTransactionLocationClusterRadius
+	}
+
+	latitudeCellSize := clusterRadius * 2 / metersPerLatitudeDegree
+	clustersMap := make(map[transactionLocationClusterKey]*transactionLocationCluster)
+	clusterKeys := make([]transactionLocationClusterKey, 0)
+
+	for i := 0; i < len(transactions); i++ {
+		transaction := transactions[i]
+
+		if transaction.GeoLatitude == 0 && transaction.GeoLongitude == 0 {
+			continue
+		}
+
+		account, exists := accountMap[transaction.AccountId]
+
+		if !exists {
+			continue
+		}
+
+		currentTransactionType, err := transaction.Type.ToTransactionType()
+
+		if err != nil || (currentTransactionType != TRANSACTION_TYPE_INCOME && currentTransactionType != TRANSACTION_TYPE_EXPENSE) {
+			continue
+		}
+
+		if transactionType != 0 && currentTransactionType != transactionType {
+			continue
+		}
+
+		latitudeIndex := int64(math.Floor(transaction.GeoLatitude / latitudeCellSize))
+		cellCenterLatitude := (float64(latitudeIndex) + 0.5) * latitudeCellSize
+		longitudeCellSize := latitudeCellSize / math.Max(math.Cos(cellCenterLatitude*math.Pi/180), 0.01)
+		key := transactionLocationClusterKey{
+			latitudeIndex:  latitudeIndex,
+			longitudeIndex: int64(math.Floor(transaction.GeoLongitude / longitudeCellSize)),
+		}
+
+		cluster, exists := clustersMap[key]
+
+		if !exists {
+			cluster = &transactionLocationCluster{
+				response: &TransactionLocationClusterResponse{},
+				amounts:  make(map[TransactionType]map[string]*TransactionLocationClusterAmountItem),
+			}
+
+			clustersMap[key] = cluster
+			clusterKeys = append(clusterKeys, key)
+		}
+
+		cluster.latitudeSum += transaction.GeoLatitude
+		cluster.longitudeSum += transaction.GeoLongitude
+		cluster.response.Count++
+
+		amountsByCurrency, exists := cluster.amounts[currentTransactionType]
+
+		if !exists {
+			amountsByCurrency = make(map[string]*TransactionLocationClusterAmountItem)
+			cluster.amounts[currentTransactionType] = amountsByCurrency
+		}
+
+		amountItem, exists := amountsByCurrency[account.Currency]
+
+		if !exists {
+			amountItem = &TransactionLocationClusterAmountItem{
+				Type:     currentTransactionType,
+				Currency: account.Currency,
+			}
+
+			amountsByCurrency[account.Currency] = amountItem
+			cluster.response.Amounts = append(cluster.response.Amounts, amountItem)
+		}
+
+		amountItem.TotalAmount += transaction.Amount
+	}
+
+	clusters := make(TransactionLocationClusterResponseSlice, len(clusterKeys))
+
+	for i := 0; i < len(clusterKeys); i++ {
+		cluster := clustersMap[clusterKeys[i]]
+		cluster.response.Latitude = cluster.latitudeSum / float64(cluster.response.Count)
+		cluster.response.Longitude = cluster.longitudeSum / float64(cluster.response.Count)
+
+		sort.Slice(cluster.response.Amounts, func(i, j int) bool {
+			if cluster.response.Amounts[i].Type != cluster.response.Amounts[j].Type {
+				return cluster.response.Amounts[i].Type < cluster.response.Amounts[j].Type
+			}
+
+			return cluster.response.Amounts[i].Currency < cluster.response.Amounts[j].Currency
+		})
+
+		clusters[i] = cluster.response
+	}
+
+	sort.Sort(clusters)
+
+	return clusters
+}
+
+func newTransactionGeoRadiusFilter(latitude float64, longitude float64, radius float64) *TransactionGeoRadiusFilter {
+	if radius <= 0 {
+		return nil
+	}
+
+	return &TransactionGeoRadiusFilter{
+		CenterLatitude:  latitude,
+		CenterLongitude: longitude,
+		Radius:          radius,
+	}
+}
+
+// Len returns the count of items
+func (s TransactionLocationClusterResponseSlice) Len() int {
+	return len(s)
+}
+
+// Swap swaps two items
+func (s TransactionLocationClusterResponseSlice) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+}
+
+// Less reports whether the first item is less than the second one
+func (s TransactionLocationClusterResponseSlice) Less(i, j int) bool {
+	if s[i].Count != s[j].Count {
+		return s[i].Count > s[j].Count
+	}
+
+	if s[i].Latitude != s[j].Latitude {
+		return s[i].Latitude < s[j].Latitude
+	}
+
+	return s[i].Longitude < s[j].Longitude
+}
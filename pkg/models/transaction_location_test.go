@@ -0,0 +1,184 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransactionListByMaxTimeRequestGetGeoRadiusFilter(t *testing.T) {
+	request := &TransactionListByMaxTimeRequest{}
+	assert.Nil(t, request.GetGeoRadiusFilter())
+
+	request = &TransactionListByMaxTimeRequest{
+		GeoLatitude:  31.2304,
+		GeoLongitude: 121.4737,
+		GeoRadius:    1000,
+	}
+	assert.Equal(t, &TransactionGeoRadiusFilter{
+		CenterLatitude:  31.2304,
+		CenterLongitude: 121.4737,
+		Radius:          1000,
+	}, request.GetGeoRadiusFilter())
+}
+
+func TestTransactionListInMonthByPageRequestGetGeoRadiusFilter(t *testing.T) {
+	request := &TransactionListInMonthByPageRequest{}
+	assert.Nil(t, request.GetGeoRadiusFilter())
+
+	request = &TransactionListInMonthByPageRequest{
+		GeoLatitude:  31.2304,
+		GeoLongitude: 121.4737,
+		GeoRadius:    1000,
+	}
+	assert.Equal(t, &TransactionGeoRadiusFilter{
+		CenterLatitude:  31.2304,
+		CenterLongitude: 121.4737,
+		Radius:          1000,
+	}, request.GetGeoRadiusFilter())
+}
+
+func TestTransactionGeoRadiusFilterGetBoundingBox(t *testing.T) {
+	filter := &TransactionGeoRadiusFilter{
+		CenterLatitude:  0,
+		CenterLongitude: 0,
+		Radius:          111195.08,
+	}
+
+	minLatitude, maxLatitude, longitudeRanges := filter.GetBoundingBox()
+	assert.InDelta(t, -1, minLatitude, 0.0001)
+	assert.InDelta(t, 1, maxLatitude, 0.0001)
+	assert.Equal(t, 1, len(longitudeRanges))
+	assert.InDelta(t, -1.0002, longitudeRanges[0].MinLongitude, 0.0001)
+	assert.InDelta(t, 1.0002, longitudeRanges[0].MaxLongitude, 0.0001)
+	assert.Equal(t, float64(0), longitudeRanges[0].CenterLongitude)
+
+	filter = &TransactionGeoRadiusFilter{
+		CenterLatitude:  60,
+		CenterLongitude: 10,
+		Radius:          1000,
+	}
+
+	minLatitude, maxLatitude, longitudeRanges = filter.GetBoundingBox()
+	assert.InDelta(t, 59.991, minLatitude, 0.0001)
+	assert.InDelta(t, 60.009, maxLatitude, 0.0001)
+	assert.Equal(t, 1, len(longitudeRanges))
+	assert.InDelta(t, 9.982, longitudeRanges[0].MinLongitude, 0.0001)
+	assert.InDelta(t, 10.018, longitudeRanges[0].MaxLongitude, 0.0001)
+	assert.True(t, filter.IsWithinRadius(60, longitudeRanges[0].MaxLongitude-0.0005))
+
+	filter = &TransactionGeoRadiusFilter{
+		CenterLatitude:  89.999,
+		CenterLongitude: 179.999,
+		Radius:          10000,
+	}
+
+	_, maxLatitude, longitudeRanges = filter.GetBoundingBox()
+	assert.Equal(t, float64(90), maxLatitude)
+	assert.Equal(t, 2, len(longitudeRanges))
+	assert.InDelta(t, -0.001, longitudeRanges[0].MinLongitude, 0.0001)
+	assert.Equal(t, float64(180), longitudeRanges[0].MaxLongitude)
+	assert.Equal(t, float64(-180), longitudeRanges[1].MinLongitude)
+	assert.InDelta(t, -0.001, longitudeRanges[1].MaxLongitude, 0.0001)
+	assert.InDelta(t, -180.001, longitudeRanges[1].CenterLongitude, 0.0001)
+}
+
+func TestTransactionGeoRadiusFilterGetBoundingBox_AcrossAntimeridian(t *testing.T) {
+	filter := &TransactionGeoRadiusFilter{
+		CenterLatitude:  0,
+		CenterLongitude: 179.9995,
+		Radius:          111195.08,
+	}
+
+	_, _, longitudeRanges := filter.GetBoundingBox()
+	assert.Equal(t, 2, len(longitudeRanges))
+	assert.InDelta(t, 178.9993, longitudeRanges[0].MinLongitude, 0.0001)
+	assert.Equal(t, float64(180), longitudeRanges[0].MaxLongitude)
+	assert.Equal(t, 179.9995, longitudeRanges[0].CenterLongitude)
+	assert.Equal(t, float64(-180), longitudeRanges[1].MinLongitude)
+	assert.InDelta(t, -179.0003, longitudeRanges[1].MaxLongitude, 0.0001)
+	assert.InDelta(t, -180.0005, longitudeRanges[1].CenterLongitude, 0.0001)
+
+	filter = &TransactionGeoRadiusFilter{
+		CenterLatitude:  0,
+		CenterLongitude: -179.9995,
+		Radius:          111195.08,
+	}
+
+	_, _, longitudeRanges = filter.GetBoundingBox()
+	assert.Equal(t, 2, len(longitudeRanges))
+	assert.Equal(t, float64(-180), longitudeRanges[0].MinLongitude)
+	assert.InDelta(t, -178.9993, longitudeRanges[0].MaxLongitude, 0.0001)
+	assert.Equal(t, -179.9995, longitudeRanges[0].CenterLongitude)
+	assert.InDelta(t, 179.0003, longitudeRanges[1].MinLongitude, 0.0001)
+	assert.Equal(t, float64(180), longitudeRanges[1].MaxLongitude)
+	assert.InDelta(t, 180.0005, longitudeRanges[1].CenterLongitude, 0.0001)
+}
+
+func TestTransactionGeoRadiusFilterIsWithinRadius(t *testing.T) {
+	filter := &TransactionGeoRadiusFilter{
+		CenterLatitude:  40,
+		CenterLongitude: 116,
+		Radius:          1000,
+	}
+
+	assert.True(t, filter.IsWithinRadius(40, 116))
+	assert.True(t, filter.IsWithinRadius(40.008, 116))
+	assert.False(t, filter.IsWithinRadius(40.01, 116))
+	assert.True(t, filter.IsWithinRadius(40, 116.0115))
+	assert.False(t, filter.IsWithinRadius(40, 116.0125))
+
+	filter = &TransactionGeoRadiusFilter{
+		CenterLatitude:  0,
+		CenterLongitude: 179.999,
+		Radius:          1000,
+	}
+
+	assert.True(t, filter.IsWithinRadius(0, -179.997))
+	assert.False(t, filter.IsWithinRadius(0, -179.99))
+}
+
+func TestGetTransactionLocationClusters(t *testing.T) {
+	accountMap := map[int64]*Account{
+		1: {AccountId: 1, Currency: "USD"},
+		2: {AccountId: 2, Currency: "EUR"},
+	}
+
+	transactions := []*Transaction{
+		{Type: TRANSACTION_DB_TYPE_EXPENSE, AccountId: 1, Amount: 1000, GeoLatitude: 40.0001, GeoLongitude: 116.0001},
+		{Type: TRANSACTION_DB_TYPE_EXPENSE, AccountId: 1, Amount: 2000, GeoLatitude: 40.0003, GeoLongitude: 116.0003},
+		{Type: TRANSACTION_DB_TYPE_EXPENSE, AccountId: 2, Amount: 500, GeoLatitude: 40.0002, GeoLongitude: 116.0002},
+		{Type: TRANSACTION_DB_TYPE_INCOME, AccountId: 1, Amount: 300, GeoLatitude: 40.0002, GeoLongitude: 116.0002},
+		{Type: TRANSACTION_DB_TYPE_EXPENSE, AccountId: 1, Amount: 700, GeoLatitude: 31.2304, GeoLongitude: 121.4737},
+		{Type: TRANSACTION_DB_TYPE_EXPENSE, AccountId: 1, Amount: 900},
+		{Type: TRANSACTION_DB_TYPE_EXPENSE, AccountId: 3, Amount: 100, GeoLatitude: 40.0001, GeoLongitude: 116.0001},
+		{Type: TRANSACTION_DB_TYPE_TRANSFER_OUT, AccountId: 1, Amount: 100, GeoLatitude: 40.0001, GeoLongitude: 116.0001},
+	}
+
+	clusters := GetTransactionLocationClusters(transactions, accountMap, 0, 500)
+	assert.Equal(t, 2, len(clusters))
+
+	assert.Equal(t, int32(4), clusters[0].Count)
+	assert.InDelta(t, 40.0002, clusters[0].Latitude, 0.000001)
+	assert.InDelta(t, 116.0002, clusters[0].Longitude, 0.000001)
+	assert.Equal(t, []*TransactionLocationClusterAmountItem{
+		{Type: TRANSACTION_TYPE_INCOME, Currency: "USD", TotalAmount: 300},
+		{Type: TRANSACTION_TYPE_EXPENSE, Currency: "EUR", TotalAmount: 500},
+		{Type: TRANSACTION_TYPE_EXPENSE, Currency: "USD", TotalAmount: 3000},
+	}, clusters[0].Amounts)
+
+	assert.Equal(t, int32(1), clusters[1].Count)
+	assert.InDelta(t, 31.2304, clusters[1].Latitude, 0.000001)
+	assert.InDelta(t, 121.4737, clusters[1].Longitude, 0.000001)
+
+	clusters = GetTransactionLocationClusters(transactions, accountMap, TRANSACTION_TYPE_INCOME, 500)
+	assert.Equal(t, 1, len(clusters))
+	assert.Equal(t, int32(1), clusters[0].Count)
+	assert.Equal(t, []*TransactionLocationClusterAmountItem{
+		{Type: TRANSACTION_TYPE_INCOME, Currency: "USD", TotalAmount: 300},
+	}, clusters[0].Amounts)
+
+	clusters = GetTransactionLocationClusters(transactions, accountMap, TRANSACTION_TYPE_EXPENSE, 100000)
+	assert.Equal(t, 2, len(clusters))
+	assert.Equal(t, int32(3), clusters[0].Count)
+}
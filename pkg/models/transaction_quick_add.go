@@ -0,0 +1,20 @@
+package models
+
+// TransactionQuickAddRequest represents all parameters of transaction quick add request
+type TransactionQuickAddRequest struct {
+	Text            string `json:"text" binding:"required,notBlank,max=255"`
+	UtcOffset       int16  `json:"utcOffset" binding:"min=-720,max=840"`
+	Commit          bool   `json:"commit"`
+	ClientSessionId string `json:"clientSessionId"`
+}
+
+// TransactionQuickAddResponse represents a view-object of transaction quick add result
+type TransactionQuickAddResponse struct {
+	Preview                     *TransactionCreateRequest `json:"preview"`
+	AmountRecognized            bool                      `json:"amountRecognized"`
+	AccountRecognized           bool                      `json:"accountRecognized"`
+	DestinationAmountRecognized bool                      `json:"destinationAmountRecognized"`
+	UnresolvedTags              []string                  `json:"unresolvedTags"`
+	Committed                   bool                      `json:"committed"`
+	Transaction                 *TransactionInfoResponse  `json:"transaction,omitempty"`
+}
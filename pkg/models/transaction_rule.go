@@ -0,0 +1,461 @@
+package models
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+// TransactionRuleConditionField represents the transaction field which is checked by transaction rule condition
+type TransactionRuleConditionField byte
+
+// Transaction rule condition fields
+const (
+	TRANSACTION_RULE_CONDITION_FIELD_COMMENT                TransactionRuleConditionField = 1
+	TRANSACTION_RULE_CONDITION_FIELD_AMOUNT                 TransactionRuleConditionField = 2
+	TRANSACTION_RULE_CONDITION_FIELD_ACCOUNT                TransactionRuleConditionField = 3
+	TRANSACTION_RULE_CONDITION_FIELD_ORIGINAL_CATEGORY_NAME TransactionRuleConditionField = 4
+	TRANSACTION_RULE_CONDITION_FIELD_PAYEE                  TransactionRuleConditionField = 5
+)
+
+// TransactionRuleConditionOperator represents the operator of transaction rule condition
+type TransactionRuleConditionOperator byte
+
+// Transaction rule condition operators
+const (
+	TRANSACTION_RULE_CONDITION_OPERATOR_EQUALS       TransactionRuleConditionOperator = 1
+	TRANSACTION_RULE_CONDITION_OPERATOR_NOT_EQUALS   TransactionRuleConditionOperator = 2
+	TRANSACTION_RULE_CONDITION_OPERATOR_CONTAINS     TransactionRuleConditionOperator = 3
+	TRANSACTION_RULE_CONDITION_OPERATOR_NOT_CONTAINS TransactionRuleConditionOperator = 4
+	TRANSACTION_RULE_CONDITION_OPERATOR_STARTS_WITH  TransactionRuleConditionOperator = 5
+	TRANSACTION_RULE_CONDITION_OPERATOR_ENDS_WITH    TransactionRuleConditionOperator = 6
+	TRANSACTION_RULE_CONDITION_OPERATOR_MATCHES      TransactionRuleConditionOperator = 7
+	TRANSACTION_RULE_CONDITION_OPERATOR_GREATER_THAN TransactionRuleConditionOperator = 8
+	TRANSACTION_RULE_CONDITION_OPERATOR_LESS_THAN    TransactionRuleConditionOperator = 9
+)
+
+const maximumTransactionRuleConditionCount = 10
+
+// TransactionRule represents transaction rule data stored in database
+type TransactionRule struct {
+	RuleId               int64                       `xorm:"PK"`
+	Uid                  int64                       `xorm:"INDEX(IDX_transaction_rule_uid_deleted_order) NOT NULL"`
+	Deleted              bool                        `xorm:"INDEX(IDX_transaction_rule_uid_deleted_order) NOT NULL"`
+	Name                 string                      `xorm:"VARCHAR(64) NOT NULL"`
+	TransactionType      TransactionType             `xorm:"NOT NULL"`
+	MatchAllConditions   bool                        `xorm:"NOT NULL"`
+	Conditions           []*TransactionRuleCondition `xorm:"BLOB"`
+	CategoryId           int64                       `xorm:"NOT NULL"`
+	AddTagIds            string                      `xorm:"VARCHAR(255) NOT NULL"`
+	ReplaceComment       bool                        `xorm:"NOT NULL"`
+	Comment              string                      `xorm:"VARCHAR(255) NOT NULL"`
+	DestinationAccountId int64                       `xorm:"NOT NULL"`
+	StopProcessing       bool                        `xorm:"NOT NULL"`
+	DisplayOrder         int32                       `xorm:"INDEX(IDX_transaction_rule_uid_deleted_order) NOT NULL"`
+	Disabled             bool                        `xorm:"NOT NULL"`
+	CreatedUnixTime      int64
+	UpdatedUnixTime      int64
+	DeletedUnixTime      int64
+}
+
+// TransactionRuleCondition represents a condition of transaction rule
+type TransactionRuleCondition struct {
+	Field    TransactionRuleConditionField    `json:"field" binding:"required,min=1,max=5"`
+	Operator TransactionRuleConditionOperator `json:"operator" binding:"required,min=1,max=9"`
+	Value    string                           `json:"value" binding:"max=255"`
+}
+
+// TransactionRuleMatchingData represents the transaction data which is used for matching transaction rules
+type TransactionRuleMatchingData struct {
+	Comment              string
+	Amount               int64
+	AccountId            int64
+	OriginalCategoryName string
+	Payee                string
+}
+
+// TransactionRuleGetRequest represents all parameters of transaction rule getting request
+type TransactionRuleGetRequest struct {
+	Id int64 `form:"id,string" binding:"required,min=1"`
+}
+
+// TransactionRuleCreateRequest represents all parameters of transaction rule creation request
+type TransactionRuleCreateRequest struct {
+	Name                 string                      `json:"name" binding:"required,notBlank,max=64"`
+	TransactionType      TransactionType             `json:"transactionType" binding:"min=0,max=4"`
+	MatchAllConditions   bool                        `json:"matchAllConditions"`
+	Conditions           []*TransactionRuleCondition `json:"conditions" binding:"required,min=1,max=10,dive"`
+	CategoryId           int64                       `json:"categoryId,string" binding:"min=0"`
+	AddTagIds            []string                    `json:"addTagIds"`
+	ReplaceComment       bool                        `json:"replaceComment"`
+	Comment              string                      `json:"comment" binding:"max=255"`
+	DestinationAccountId int64                       `json:"destinationAccountId,string" binding:"min=0"`
+	StopProcessing       bool                        `json:"stopProcessing"`
+}
+
+// TransactionRuleModifyRequest represents all parameters of transaction rule modification request
+type TransactionRuleModifyRequest struct {
+	Id                   int64                       `json:"id,string" binding:"required,min=1"`
+	Name                 string                      `json:"name" binding:"required,notBlank,max=64"`
+	TransactionType      TransactionType             `json:"transactionType" binding:"min=0,max=4"`
+	MatchAllConditions   bool                        `json:"matchAllConditions"`
+	Conditions           []*TransactionRuleCondition `json:"conditions" binding:"required,min=1,max=10,dive"`
+	CategoryId           int64                       `json:"categoryId,string" binding:"min=0"`
+	AddTagIds            []string                    `json:"addTagIds"`
+	ReplaceComment       bool                        `json:"replaceComment"`
+	Comment              string                      `json:"comment" binding:"max=255"`
+	DestinationAccountId int64                       `json:"destinationAccountId,string" binding:"min=0"`
+	StopProcessing       bool                        `json:"stopProcessing"`
+	Disabled             bool                        `json:"disabled"`
+}
+
+// TransactionRuleMoveRequest represents all parameters of transaction rule moving request
+type TransactionRuleMoveRequest struct {
+	NewDisplayOrders []*TransactionRuleNewDisplayOrderRequest `json:"newDisplayOrders" binding:"required,min=1"`
+}
+
+// TransactionRuleNewDisplayOrderRequest represents a data pair of id and display order
+type TransactionRuleNewDisplayOrderRequest struct {
+	Id           int64 `json:"id,string" binding:"required,min=1"`
+	DisplayOrder int32 `json:"displayOrder"`
+}
+
+// TransactionRuleDeleteRequest represents all parameters of transaction rule deleting request
+type TransactionRuleDeleteRequest struct {
+	Id int64 `json:"id,string" binding:"required,min=1"`
+}
+
+// TransactionRuleApplyRequest represents all parameters of applying transaction rules to existed transactions request
+type TransactionRuleApplyRequest struct {
+	RuleIds   []string `json:"ruleIds"`
+	StartTime int64    `json:"startTime" binding:"min=0"`
+	EndTime   int64    `json:"endTime" binding:"min=0"`
+	DryRun    bool     `json:"dryRun"`
+}
+
+// TransactionRuleApplyResponse represents the result of applying transaction rules to existed transactions
+type TransactionRuleApplyResponse struct {
+	MatchedCount  int `json:"matchedCount"`
+	ModifiedCount int `json:"modifiedCount"`
+	FailedCount   int `json:"failedCount"`
+}
+
+// TransactionRuleInfoResponse represents a view-object of transaction rule
+type TransactionRuleInfoResponse struct {
+	Id                   int64                       `json:"id,string"`
+	Name                 string                      `json:"name"`
+	TransactionType      TransactionType             `json:"transactionType"`
+	MatchAllConditions   bool                        `json:"matchAllConditions"`
+	Conditions           []*TransactionRuleCondition `json:"conditions"`
+	CategoryId           int64                       `json:"categoryId,string"`
+	AddTagIds            []string                    `json:"addTagIds"`
+	ReplaceComment       bool                        `json:"replaceComment"`
+	Comment              string                      `json:"comment"`
+	DestinationAccountId int64                       `json:"destinationAccountId,string"`
+	StopProcessing       bool                        `json:"stopProcessing"`
+	DisplayOrder         int32                       `json:"displayOrder"`
+	Disabled             bool                        `json:"disabled"`
+}
+
+// GetAddTagIds returns the tag ids which will be added to the matched transaction
+func (r *TransactionRule) GetAddTagIds() []int64 {
+	if r.AddTagIds == "" {
+		return nil
+	}
+
+	tagIds, err := utils.StringArrayToInt64Array(strings.Split(r.AddTagIds, ","))
+
+	if err != nil {
+		return nil
+	}
+
+	return tagIds
+}
+
+// IsConditionsValid returns whether all the conditions of the transaction rule are valid
+func (r *TransactionRule) IsConditionsValid() bool {
+	if len(r.Conditions) < 1 || len(r.Conditions) > maximumTransactionRuleConditionCount {
+		return false
+	}
+
+	for i := 0; i < len(r.Conditions); i++ {
+		if r.Conditions[i] == nil || !r.Conditions[i].IsValid() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// IsActionsValid returns whether the actions of the transaction rule are valid
+func (r *TransactionRule) IsActionsValid() bool {
+	if r.CategoryId > 0 && (r.TransactionType < TRANSACTION_TYPE_INCOME || r.TransactionType > TRANSACTION_TYPE_TRANSFER) {
+		return false
+	}
+
+	if r.DestinationAccountId > 0 && r.TransactionType != TRANSACTION_TYPE_TRANSFER {
+		return false
+	}
+
+	return r.CategoryId > 0 || r.AddTagIds != "" || r.ReplaceComment || r.DestinationAccountId > 0
+}
+
+// IsMatch returns whether the transaction rule matches the specified transaction data
+func (r *TransactionRule) IsMatch(transactionType TransactionType, data *TransactionRuleMatchingData) bool {
+	if len(r.Conditions) < 1 {
+		return false
+	}
+
+	if r.TransactionType > 0 && r.TransactionType != transactionType {
+		return false
+	}
+
+	for i := 0; i < len(r.Conditions); i++ {
+		matched := r.Conditions[i].IsMatch(data)
+
+		if r.MatchAllConditions && !matched {
+			return false
+		} else if !r.MatchAllConditions && matched {
+			return true
+		}
+	}
+
+	return r.MatchAllConditions
+}
+
+// ApplyTo applies the actions of the transaction rule to the specified transaction, and returns the new tag ids of the transaction
+func (r *TransactionRule) ApplyTo(transaction *Transaction, tagIds []int64) []int64 {
+	if r.CategoryId > 0 {
+		transaction.CategoryId = r.CategoryId
+	}
+
+	addTagIds := r.GetAddTagIds()
+
+	if len(addTagIds) > 0 {
+		existedTagIds := utils.ToSet(tagIds)
+
+		for i := 0; i < len(addTagIds); i++ {
+			if !existedTagIds[addTagIds[i]] {
+				tagIds = append(tagIds, addTagIds[i])
+				existedTagIds[addTagIds[i]] = true
+			}
+		}
+	}
+
+	if r.ReplaceComment {
+		transaction.Comment = r.Comment
+	}
+
+	if r.DestinationAccountId > 0 && transaction.Type == TRANSACTION_DB_TYPE_TRANSFER_OUT && r.DestinationAccountId != transaction.AccountId {
+		transaction.RelatedAccountId = r.DestinationAccountId
+	}
+
+	return tagIds
+}
+
+// IsValid returns whether the field, operator and value of the transaction rule condition are valid
+func (c *TransactionRuleCondition) IsValid() bool {
+	switch c.Field {
+	case TRANSACTION_RULE_CONDITION_FIELD_COMMENT, TRANSACTION_RULE_CONDITION_FIELD_ORIGINAL_CATEGORY_NAME, TRANSACTION_RULE_CONDITION_FIELD_PAYEE:
+		if c.Operator < TRANSACTION_RULE_CONDITION_OPERATOR_EQUALS || c.Operator > TRANSACTION_RULE_CONDITION_OPERATOR_MATCHES {
+			return false
+		}
+
+		if c.Operator == TRANSACTION_RULE_CONDITION_OPERATOR_MATCHES {
+			_, err := regexp.Compile(c.Value)
+			return err == nil
+		}
+
+		return true
+	case TRANSACTION_RULE_CONDITION_FIELD_AMOUNT:
+		if c.Operator != TRANSACTION_RULE_CONDITION_OPERATOR_EQUALS && c.Operator != TRANSACTION_RULE_CONDITION_OPERATOR_NOT_EQUALS &&
+			c.Operator != TRANSACTION_RULE_CONDITION_OPERATOR_GREATER_THAN && c.Operator != TRANSACTION_RULE_CONDITION_OPERATOR_LESS_THAN {
+			return false
+		}
+
+		_, err := utils.StringToInt64(c.Value)
+		return err == nil
+	case TRANSACTION_RULE_CONDITION_FIELD_ACCOUNT:
+		if c.Operator != TRANSACTION_RULE_CONDITION_OPERATOR_EQUALS && c.Operator != TRANSACTION_RULE_CONDITION_OPERATOR_NOT_EQUALS {
+			return false
+		}
+
+		accountId, err := utils.StringToInt64(c.Value)
+		return err == nil && accountId > 0
+	default:
+		return false
+	}
+}
+
+// IsMatch returns whether the transaction rule condition matches the specified transaction data
+func (c *TransactionRuleCondition) IsMatch(data *TransactionRuleMatchingData) bool {
+	switch c.Field {
+	case TRANSACTION_RULE_CONDITION_FIELD_COMMENT:
+		return c.isTextMatch(data.Comment)
+	case TRANSACTION_RULE_CONDITION_FIELD_ORIGINAL_CATEGORY_NAME:
+		return c.isTextMatch(data.OriginalCategoryName)
+	case TRANSACTION_RULE_CONDITION_FIELD_PAYEE:
+		return c.isTextMatch(data.Payee)
+	case TRANSACTION_RULE_CONDITION_FIELD_AMOUNT:
+		return c.isNumberMatch(data.Amount)
+	case TRANSACTION_RULE_CONDITION_FIELD_ACCOUNT:
+		return c.isNumberMatch(data.AccountId)
+	default:
+		return false
+	}
+}
+
+func (c *TransactionRuleCondition) isTextMatch(text string) bool {
+	if c.Operator == TRANSACTION_RULE_CONDITION_OPERATOR_MATCHES {
+		matched, err := regexp.MatchString(c.Value, text)
+		return err == nil && matched
+	}
+
+	text = strings.ToLower(text)
+	value := strings.ToLower(c.Value)
+
+	switch c.Operator {
+	case TRANSACTION_RULE_CONDITION_OPERATOR_EQUALS:
+		return text == value
+	case TRANSACTION_RULE_CONDITION_OPERATOR_NOT_EQUALS:
+		return text != value
+	case TRANSACTION_RULE_CONDITION_OPERATOR_CONTAINS:
+		return strings.Contains(text, value)
+	case TRANSACTION_RULE_CONDITION_OPERATOR_NOT_CONTAINS:
+		return !strings.Contains(text, value)
+	case TRANSACTION_RULE_CONDITION_OPERATOR_STARTS_WITH:
+		return strings.HasPrefix(text, value)
+	case TRANSACTION_RULE_CONDITION_OPERATOR_ENDS_WITH:
+		return strings.HasSuffix(text, value)
+	default:
+		return false
+	}
+}
+
+func (c *TransactionRuleCondition) isNumberMatch(number int64) bool {
+	value, err := utils.StringToInt64(c.Value)
+
+	if err != nil {
+		return false
+	}
+
+	switch c.Operator {
+	case TRANSACTION_RULE_CONDITION_OPERATOR_EQUALS:
+		return number == value
+	case TRANSACTION_RULE_CONDITION_OPERATOR_NOT_EQUALS:
+		return number != value
+	case TRANSACTION_RULE_CONDITION_OPERATOR_GREATER_THAN:
+		return number > value
+	case TRANSACTION_RULE_CONDITION_OPERATOR_LESS_THAN:
+		return number < value
+	default:
+		return false
+	}
+}
+
+// ToTransactionRuleInfoResponse returns a view-object according to database model
+func (r *TransactionRule) ToTransactionRuleInfoResponse() *TransactionRuleInfoResponse {
+	addTagIds := make([]string, 0)
+
+	if r.AddTagIds != "" {
+		addTagIds = strings.Split(r.AddTagIds, ",")
+	}
+
+	conditions := r.Conditions
+
+	if conditions == nil {
+		conditions = make([]*TransactionRuleCondition, 0)
+	}
+
+	return &TransactionRuleInfoResponse{
+		Id:                   r.RuleId,
+		Name:                 r.Name,
+		TransactionType:      r.TransactionType,
+		MatchAllConditions:   r.MatchAllConditions,
+		Conditions:           conditions,
+		CategoryId:           r.CategoryId,
+		AddTagIds:            addTagIds,
+		ReplaceComment:       r.ReplaceComment,
+		Comment:              r.Comment,
+		DestinationAccountId: r.DestinationAccountId,
+		StopProcessing:       r.StopProcessing,
+		DisplayOrder:         r.DisplayOrder,
+		Disabled:             r.Disabled,
+	}
+}
+
+// TransactionRuleSlice represents the slice data structure of TransactionRule
+type TransactionRuleSlice []*TransactionRule
+
+// Len returns the count of items
+func (s TransactionRuleSlice) Len() int {
+	return len(s)
+}
+
+// Swap swaps two items
+func (s TransactionRuleSlice) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+}
+
+// Less reports whether the first item is less than the second one
+func (s TransactionRuleSlice) Less(i, j int) bool {
+	if s[i].DisplayOrder != s[j].DisplayOrder {
+		return s[i].DisplayOrder < s[j].DisplayOrder
+	}
+
+	return s[i].RuleId < s[j].RuleId
+}
+
+// ApplyTo applies all the matched transaction rules (in display order) to the specified transaction, and returns the new tag ids of the transaction and whether any rule matched
+func (s TransactionRuleSlice) ApplyTo(transaction *Transaction, tagIds []int64, originalCategoryName string, payee string) ([]int64, bool) {
+	transactionType, err := transaction.Type.ToTransactionType()
+
+	if err != nil || transaction.Type == TRANSACTION_DB_TYPE_TRANSFER_IN {
+		return tagIds, false
+	}
+
+	anyMatched := false
+
+	for i := 0; i < len(s); i++ {
+		rule := s[i]
+		data := &TransactionRuleMatchingData{
+			Comment:              transaction.Comment,
+			Amount:               transaction.Amount,
+			AccountId:            transaction.AccountId,
+			OriginalCategoryName: originalCategoryName,
+			Payee:                payee,
+		}
+
+		if !rule.IsMatch(transactionType, data) {
+			continue
+		}
+
+		tagIds = rule.ApplyTo(transaction, tagIds)
+		anyMatched = true
+
+		if rule.StopProcessing {
+			break
+		}
+	}
+
+	return tagIds, anyMatched
+}
+
+// TransactionRuleInfoResponseSlice represents the slice data structure of TransactionRuleInfoResponse
+type TransactionRuleInfoResponseSlice []*TransactionRuleInfoResponse
+
+// Len returns the count of items
+func (s TransactionRuleInfoResponseSlice) Len() int {
+	return len(s)
+}
+
+// Swap swaps two items
+func (s TransactionRuleInfoResponseSlice) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+}
+
+// Less reports whether the first item is less than the second one
+func (s TransactionRuleInfoResponseSlice) Less(i, j int) bool {
+	return s[i].DisplayOrder < s[j].DisplayOrder
+}
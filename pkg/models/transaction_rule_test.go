@@ -0,0 +1,264 @@
+package models
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransactionRuleConditionIsMatch_Comment(t *testing.T) {
+	data := &TransactionRuleMatchingData{
+		Comment: "NETFLIX.COM Monthly",
+	}
+
+	condition := &TransactionRuleCondition{Field: TRANSACTION_RULE_CONDITION_FIELD_COMMENT, Operator: TRANSACTION_RULE_CONDITION_OPERATOR_CONTAINS, Value: "netflix"}
+	assert.Equal(t, true, condition.IsMatch(data))
+
+	condition = &TransactionRuleCondition{Field: TRANSACTION_RULE_CONDITION_FIELD_COMMENT, Operator: TRANSACTION_RULE_CONDITION_OPERATOR_NOT_CONTAINS, Value: "netflix"}
+	assert.Equal(t, false, condition.IsMatch(data))
+
+	condition = &TransactionRuleCondition{Field: TRANSACTION_RULE_CONDITION_FIELD_COMMENT, Operator: TRANSACTION_RULE_CONDITION_OPERATOR_STARTS_WITH, Value: "Netflix"}
+	assert.Equal(t, true, condition.IsMatch(data))
+
+	condition = &TransactionRuleCondition{Field: TRANSACTION_RULE_CONDITION_FIELD_COMMENT, Operator: TRANSACTION_RULE_CONDITION_OPERATOR_ENDS_WITH, Value: "monthly"}
+	assert.Equal(t, true, condition.IsMatch(data))
+
+	condition = &TransactionRuleCondition{Field: TRANSACTION_RULE_CONDITION_FIELD_COMMENT, Operator: TRANSACTION_RULE_CONDITION_OPERATOR_EQUALS, Value: "netflix.com monthly"}
+	assert.Equal(t, true, condition.IsMatch(data))
+
+	condition = &TransactionRuleCondition{Field: TRANSACTION_RULE_CONDITION_FIELD_COMMENT, Operator: TRANSACTION_RULE_CONDITION_OPERATOR_NOT_EQUALS, Value: "netflix"}
+	assert.Equal(t, true, condition.IsMatch(data))
+
+	condition = &TransactionRuleCondition{Field: TRANSACTION_RULE_CONDITION_FIELD_COMMENT, Operator: TRANSACTION_RULE_CONDITION_OPERATOR_MATCHES, Value: "^NETFLIX\\.COM"}
+	assert.Equal(t, true, condition.IsMatch(data))
+
+	condition = &TransactionRuleCondition{Field: TRANSACTION_RULE_CONDITION_FIELD_COMMENT, Operator: TRANSACTION_RULE_CONDITION_OPERATOR_MATCHES, Value: "^netflix"}
+	assert.Equal(t, false, condition.IsMatch(data))
+}
+
+func TestTransactionRuleConditionIsMatch_OriginalCategoryNameAndPayee(t *testing.T) {
+	data := &TransactionRuleMatchingData{
+		OriginalCategoryName: "Entertainment",
+		Payee:                "Netflix",
+	}
+
+	condition := &TransactionRuleCondition{Field: TRANSACTION_RULE_CONDITION_FIELD_ORIGINAL_CATEGORY_NAME, Operator: TRANSACTION_RULE_CONDITION_OPERATOR_EQUALS, Value: "entertainment"}
+	assert.Equal(t, true, condition.IsMatch(data))
+
+	condition = &TransactionRuleCondition{Field: TRANSACTION_RULE_CONDITION_FIELD_PAYEE, Operator: TRANSACTION_RULE_CONDITION_OPERATOR_EQUALS, Value: "NETFLIX"}
+	assert.Equal(t, true, condition.IsMatch(data))
+
+	condition = &TransactionRuleCondition{Field: TRANSACTION_RULE_CONDITION_FIELD_PAYEE, Operator: TRANSACTION_RULE_CONDITION_OPERATOR_EQUALS, Value: "Spotify"}
+	assert.Equal(t, false, condition.IsMatch(data))
+}
+
+func TestTransactionRuleConditionIsMatch_AmountAndAccount(t *testing.T) {
+	data := &TransactionRuleMatchingData{
+		Amount:    1599,
+		AccountId: 123,
+	}
+
+	condition := &TransactionRuleCondition{Field: TRANSACTION_RULE_CONDITION_FIELD_AMOUNT, Operator: TRANSACTION_RULE_CONDITION_OPERATOR_GREATER_THAN, Value: "1000"}
+	assert.Equal(t, true, condition.IsMatch(data))
+
+	condition = &TransactionRuleCondition{Field: TRANSACTION_RULE_CONDITION_FIELD_AMOUNT, Operator: TRANSACTION_RULE_CONDITION_OPERATOR_LESS_THAN, Value: "1000"}
+	assert.Equal(t, false, condition.IsMatch(data))
+
+	condition = &TransactionRuleCondition{Field: TRANSACTION_RULE_CONDITION_FIELD_AMOUNT, Operator: TRANSACTION_RULE_CONDITION_OPERATOR_EQUALS, Value: "1599"}
+	assert.Equal(t, true, condition.IsMatch(data))
+
+	condition = &TransactionRuleCondition{Field: TRANSACTION_RULE_CONDITION_FIELD_AMOUNT, Operator: TRANSACTION_RULE_CONDITION_OPERATOR_CONTAINS, Value: "1599"}
+	assert.Equal(t, false, condition.IsMatch(data))
+
+	condition = &TransactionRuleCondition{Field: TRANSACTION_RULE_CONDITION_FIELD_ACCOUNT, Operator: TRANSACTION_RULE_CONDITION_OPERATOR_EQUALS, Value: "123"}
+	assert.Equal(t, true, condition.IsMatch(data))
+
+	condition = &TransactionRuleCondition{Field: TRANSACTION_RULE_CONDITION_FIELD_ACCOUNT, Operator: TRANSACTION_RULE_CONDITION_OPERATOR_NOT_EQUALS, Value: "123"}
+	assert.Equal(t, false, condition.IsMatch(data))
+}
+
+func TestTransactionRuleConditionIsValid(t *testing.T) {
+	condition := &TransactionRuleCondition{Field: TRANSACTION_RULE_CONDITION_FIELD_COMMENT, Operator: TRANSACTION_RULE_CONDITION_OPERATOR_CONTAINS, Value: "netflix"}
+	assert.Equal(t, true, condition.IsValid())
+
+	condition = &TransactionRuleCondition{Field: TRANSACTION_RULE_CONDITION_FIELD_COMMENT, Operator: TRANSACTION_RULE_CONDITION_OPERATOR_GREATER_THAN, Value: "netflix"}
+	assert.Equal(t, false, condition.IsValid())
+
+	condition = &TransactionRuleCondition{Field: TRANSACTION_RULE_CONDITION_FIELD_COMMENT, Operator: TRANSACTION_RULE_CONDITION_OPERATOR_MATCHES, Value: "(netflix"}
+	assert.Equal(t, false, condition.IsValid())
+
+	condition = &TransactionRuleCondition{Field: TRANSACTION_RULE_CONDITION_FIELD_AMOUNT, Operator: TRANSACTION_RULE_CONDITION_OPERATOR_LESS_THAN, Value: "100"}
+	assert.Equal(t, true, condition.IsValid())
+
+	condition = &TransactionRuleCondition{Field: TRANSACTION_RULE_CONDITION_FIELD_AMOUNT, Operator: TRANSACTION_RULE_CONDITION_OPERATOR_LESS_THAN, Value: "1.00"}
+	assert.Equal(t, false, condition.IsValid())
+
+	condition = &TransactionRuleCondition{Field: TRANSACTION_RULE_CONDITION_FIELD_AMOUNT, Operator: TRANSACTION_RULE_CONDITION_OPERATOR_STARTS_WITH, Value: "100"}
+	assert.Equal(t, false, condition.IsValid())
+
+	condition = &TransactionRuleCondition{Field: TRANSACTION_RULE_CONDITION_FIELD_ACCOUNT, Operator: TRANSACTION_RULE_CONDITION_OPERATOR_EQUALS, Value: "0"}
+	assert.Equal(t, false, condition.IsValid())
+
+	condition = &TransactionRuleCondition{Field: 0, Operator: TRANSACTION_RULE_CONDITION_OPERATOR_EQUALS, Value: "netflix"}
+	assert.Equal(t, false, condition.IsValid())
+}
+
+func TestTransactionRuleIsMatch_MatchAllConditions(t *testing.T) {
+	rule := &TransactionRule{
+		MatchAllConditions: true,
+		Conditions: []*TransactionRuleCondition{
+			{Field: TRANSACTION_RULE_CONDITION_FIELD_COMMENT, Operator: TRANSACTION_RULE_CONDITION_OPERATOR_CONTAINS, Value: "netflix"},
+			{Field: TRANSACTION_RULE_CONDITION_FIELD_AMOUNT, Operator: TRANSACTION_RULE_CONDITION_OPERATOR_LESS_THAN, Value: "2000"},
+		},
+	}
+
+	assert.Equal(t, true, rule.IsMatch(TRANSACTION_TYPE_EXPENSE, &TransactionRuleMatchingData{Comment: "Netflix", Amount: 1599}))
+	assert.Equal(t, false, rule.IsMatch(TRANSACTION_TYPE_EXPENSE, &TransactionRuleMatchingData{Comment: "Netflix", Amount: 2599}))
+
+	rule.MatchAllConditions = false
+	assert.Equal(t, true, rule.IsMatch(TRANSACTION_TYPE_EXPENSE, &TransactionRuleMatchingData{Comment: "Netflix", Amount: 2599}))
+	assert.Equal(t, false, rule.IsMatch(TRANSACTION_TYPE_EXPENSE, &TransactionRuleMatchingData{Comment: "Spotify", Amount: 2599}))
+}
+
+func TestTransactionRuleIsMatch_TransactionType(t *testing.T) {
+	rule := &TransactionRule{
+		TransactionType: TRANSACTION_TYPE_EXPENSE,
+		Conditions: []*TransactionRuleCondition{
+			{Field: TRANSACTION_RULE_CONDITION_FIELD_COMMENT, Operator: TRANSACTION_RULE_CONDITION_OPERATOR_CONTAINS, Value: "netflix"},
+		},
+	}
+
+	assert.Equal(t, true, rule.IsMatch(TRANSACTION_TYPE_EXPENSE, &TransactionRuleMatchingData{Comment: "Netflix"}))
+	assert.Equal(t, false, rule.IsMatch(TRANSACTION_TYPE_INCOME, &TransactionRuleMatchingData{Comment: "Netflix"}))
+
+	rule.TransactionType = 0
+	assert.Equal(t, true, rule.IsMatch(TRANSACTION_TYPE_INCOME, &TransactionRuleMatchingData{Comment: "Netflix"}))
+}
+
+func TestTransactionRuleIsActionsValid(t *testing.T) {
+	rule := &TransactionRule{CategoryId: 1, TransactionType: TRANSACTION_TYPE_EXPENSE}
+	assert.Equal(t, true, rule.IsActionsValid())
+
+	rule = &TransactionRule{CategoryId: 1}
+	assert.Equal(t, false, rule.IsActionsValid())
+
+	rule = &TransactionRule{DestinationAccountId: 1, TransactionType: TRANSACTION_TYPE_EXPENSE}
+	assert.Equal(t, false, rule.IsActionsValid())
+
+	rule = &TransactionRule{DestinationAccountId: 1, TransactionType: TRANSACTION_TYPE_TRANSFER}
+	assert.Equal(t, true, rule.IsActionsValid())
+
+	rule = &TransactionRule{AddTagIds: "1,2"}
+	assert.Equal(t, true, rule.IsActionsValid())
+
+	rule = &TransactionRule{}
+	assert.Equal(t, false, rule.IsActionsValid())
+}
+
+func TestTransactionRuleApplyTo(t *testing.T) {
+	rule := &TransactionRule{
+		CategoryId:     10,
+		AddTagIds:      "1,2",
+		ReplaceComment: true,
+		Comment:        "Netflix subscription",
+	}
+
+	transaction := &Transaction{
+		Type:       TRANSACTION_DB_TYPE_EXPENSE,
+		CategoryId: 5,
+		Comment:    "NETFLIX.COM",
+	}
+
+	tagIds := rule.ApplyTo(transaction, []int64{2, 3})
+
+	assert.Equal(t, int64(10), transaction.CategoryId)
+	assert.Equal(t, "Netflix subscription", transaction.Comment)
+	assert.Equal(t, []int64{2, 3, 1}, tagIds)
+}
+
+func TestTransactionRuleApplyTo_DestinationAccount(t *testing.T) {
+	rule := &TransactionRule{
+		DestinationAccountId: 200,
+	}
+
+	transaction := &Transaction{
+		Type:             TRANSACTION_DB_TYPE_TRANSFER_OUT,
+		AccountId:        100,
+		RelatedAccountId: 300,
+	}
+
+	rule.ApplyTo(transaction, nil)
+	assert.Equal(t, int64(200), transaction.RelatedAccountId)
+
+	transaction = &Transaction{
+		Type:             TRANSACTION_DB_TYPE_TRANSFER_OUT,
+		AccountId:        200,
+		RelatedAccountId: 300,
+	}
+
+	rule.ApplyTo(transaction, nil)
+	assert.Equal(t, int64(300), transaction.RelatedAccountId)
+}
+
+func TestTransactionRuleSliceApplyTo(t *testing.T) {
+	rules := TransactionRuleSlice{
+		{
+			Conditions: []*TransactionRuleCondition{
+				{Field: TRANSACTION_RULE_CONDITION_FIELD_PAYEE, Operator: TRANSACTION_RULE_CONDITION_OPERATOR_EQUALS, Value: "netflix"},
+			},
+			AddTagIds: "1",
+		},
+		{
+			Conditions: []*TransactionRuleCondition{
+				{Field: TRANSACTION_RULE_CONDITION_FIELD_COMMENT, Operator: TRANSACTION_RULE_CONDITION_OPERATOR_CONTAINS, Value: "netflix"},
+			},
+			AddTagIds:      "2",
+			StopProcessing: true,
+		},
+		{
+			Conditions: []*TransactionRuleCondition{
+				{Field: TRANSACTION_RULE_CONDITION_FIELD_COMMENT, Operator: TRANSACTION_RULE_CONDITION_OPERATOR_CONTAINS, Value: "netflix"},
+			},
+			AddTagIds: "3",
+		},
+	}
+
+	transaction := &Transaction{
+		Type:    TRANSACTION_DB_TYPE_EXPENSE,
+		Comment: "NETFLIX.COM",
+	}
+
+	tagIds, matched := rules.ApplyTo(transaction, nil, "", "Netflix")
+	assert.Equal(t, true, matched)
+	assert.Equal(t, []int64{1, 2}, tagIds)
+
+	tagIds, matched = rules.ApplyTo(&Transaction{Type: TRANSACTION_DB_TYPE_EXPENSE, Comment: "Spotify"}, nil, "", "")
+	assert.Equal(t, false, matched)
+	assert.Nil(t, tagIds)
+
+	_, matched = rules.ApplyTo(&Transaction{Type: TRANSACTION_DB_TYPE_TRANSFER_IN, Comment: "NETFLIX.COM"}, nil, "", "")
+	assert.Equal(t, false, matched)
+}
+
+func TestTransactionRuleInfoResponseSliceLess(t *testing.T) {
+	var transactionRuleRespSlice TransactionRuleInfoResponseSlice
+	transactionRuleRespSlice = append(transactionRuleRespSlice, &TransactionRuleInfoResponse{
+		Id:           1,
+		DisplayOrder: 3,
+	})
+	transactionRuleRespSlice = append(transactionRuleRespSlice, &TransactionRuleInfoResponse{
+		Id:           2,
+		DisplayOrder: 1,
+	})
+	transactionRuleRespSlice = append(transactionRuleRespSlice, &TransactionRuleInfoResponse{
+		Id:           3,
+		DisplayOrder: 2,
+	})
+
+	sort.Sort(transactionRuleRespSlice)
+
+	assert.Equal(t, int64(2), transactionRuleRespSlice[0].Id)
+	assert.Equal(t, int64(3), transactionRuleRespSlice[1].Id)
+	assert.Equal(t, int64(1), transactionRuleRespSlice[2].Id)
+}
@@ -0,0 +1,141 @@
+package models
+
+import (
+	"time"
+
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+// TransactionStatisticConvertedAmount represents the total amount of the same category and account in statistics,
+// the currency is the target currency if the amount is converted successfully, otherwise it is the currency of account
+type TransactionStatisticConvertedAmount struct {
+	CategoryId int64
+	AccountId  int64
+	Currency   string
+	Amount     int64
+}
+
+// TransactionStatisticAmountConverter converts the amount of each transaction to the target currency by the exchange rates effective on the transaction date
+type TransactionStatisticAmountConverter struct {
+	exchangeRates          *HistoricalExchangeRates
+	conversionType         StatisticCurrencyConversionType
+	accountCurrencies      map[int64]string
+	accountPrecisions      map[int64]int
+	targetCurrency         string
+	clientTimezone         *time.Location
+	useTransactionTimezone bool
+}
+
+type transactionStatisticConvertedAmountKey struct {
+	periodStartDate int32
+	categoryId      int64
+	accountId       int64
+	currency        string
+}
+
+// NewTransactionStatisticAmountConverter returns a new transaction statistic amount converter
+func NewTransactionStatisticAmountConverter(exchangeRates *HistoricalExchangeRates, conversionType StatisticCurrencyConversionType, accountCurrencies map[int64]string, targetCurrency string, clientTimezone *time.Location, useTransactionTimezone bool) *TransactionStatisticAmountConverter {
+	return &TransactionStatisticAmountConverter{
+		exchangeRates:          exchangeRates,
+		conversionType:         conversionType,
+		accountCurrencies:      accountCurrencies,
+		targetCurrency:         targetCurrency,
+		clientTimezone:         clientTimezone,
+		useTransactionTimezone: useTransactionTimezone,
+	}
+}
+
+// SetAccountCurrencyPrecisions sets the decimal precisions of account currencies, the precision of account which is not set is the default currency precision
+func (c *TransactionStatisticAmountConverter) SetAccountCurrencyPrecisions(accountPrecisions map[int64]int) {
+	c.accountPrecisions = accountPrecisions
+}
+
+// ConvertTransactionAmount returns the converted amount and the currency of the amount, the original amount and the account currency are returned if the exchange rate is missing
+func (c *TransactionStatisticAmountConverter) ConvertTransactionAmount(transaction *Transaction) (int64, string) {
+	currency := c.accountCurrencies[transaction.AccountId]
+
+	if currency == c.targetCurrency {
+		return transaction.Amount, currency
+	}
+
+	exchangeRates := c.exchangeRates.GetExchangeRates(utils.FormatTimeToNumericDate(c.getTransactionLocalTime(transaction)), c.conversionType)
+	amount, success := exchangeRates.ConvertAmountWithPrecision(transaction.Amount, currency, c.getAccountCurrencyPrecision(transaction.AccountId), c.targetCurrency, DEFAULT_CURRENCY_PRECISION)
+
+	if !success {
+		return transaction.Amount, currency
+	}
+
+	return amount, c.targetCurrency
+}
+
+// GetTotalAmounts returns the converted total amounts of each category and account
+func (c *TransactionStatisticAmountConverter) GetTotalAmounts(transactions []*Transaction) []*TransactionStatisticConvertedAmount {
+	totalAmounts := c.getPeriodicTotalAmounts(transactions, nil)
+	return totalAmounts[0]
+}
+
+// GetPeriodicTotalAmounts returns the converted total amounts of each category and account in each period, the key of the result is the start date (YYYYMMDD) of period
+func (c *TransactionStatisticAmountConverter) GetPeriodicTotalAmounts(transactions []*Transaction, periodOptions *StatisticPeriodOptions) map[int32][]*TransactionStatisticConvertedAmount {
+	return c.getPeriodicTotalAmounts(transactions, periodOptions)
+}
+
+func (c *TransactionStatisticAmountConverter) getPeriodicTotalAmounts(transactions []*Transaction, periodOptions *StatisticPeriodOptions) map[int32][]*TransactionStatisticConvertedAmount {
+	totalAmountsMap := make(map[transactionStatisticConvertedAmountKey]*TransactionStatisticConvertedAmount)
+	result := make(map[int32][]*TransactionStatisticConvertedAmount)
+
+	if periodOptions == nil {
+		result[0] = make([]*TransactionStatisticConvertedAmount, 0)
+	}
+
+	for i := 0; i < len(transactions); i++ {
+		transaction := transactions[i]
+		amount, currency := c.ConvertTransactionAmount(transaction)
+		periodStartDate := int32(0)
+
+		if periodOptions != nil {
+			periodStartDate = utils.FormatTimeToNumericDate(periodOptions.GetPeriodStartDate(c.getTransactionLocalTime(transaction)))
+		}
+
+		key := transactionStatisticConvertedAmountKey{
+			periodStartDate: periodStartDate,
+			categoryId:      transaction.CategoryId,
+			accountId:       transaction.AccountId,
+			currency:        currency,
+		}
+
+		totalAmount, exists := totalAmountsMap[key]
+
+		if !exists {
+			totalAmount = &TransactionStatisticConvertedAmount{
+				CategoryId: transaction.CategoryId,
+				AccountId:  transaction.AccountId,
+				Currency:   currency,
+			}
+
+			totalAmountsMap[key] = totalAmount
+			result[periodStartDate] = append(result[periodStartDate], totalAmount)
+		}
+
+		totalAmount.Amount += amount
+	}
+
+	return result
+}
+
+func (c *TransactionStatisticAmountConverter) getAccountCurrencyPrecision(accountId int64) int {
+	if precision, exists := c.accountPrecisions[accountId]; exists {
+		return precision
+	}
+
+	return DEFAULT_CURRENCY_PRECISION
+}
+
+func (c *TransactionStatisticAmountConverter) getTransactionLocalTime(transaction *Transaction) time.Time {
+	timezone := c.clientTimezone
+
+	if c.useTransactionTimezone {
+		timezone = time.FixedZone("Transaction Timezone", int(transaction.TimezoneUtcOffset)*60)
+	}
+
+	return time.Unix(utils.GetUnixTimeFromTransactionTime(transaction.TransactionTime), 0).In(timezone)
+}
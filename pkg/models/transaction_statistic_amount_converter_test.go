@@ -0,0 +1,103 @@
+package models
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+func getTestStatisticTransactions() []*Transaction {
+	return []*Transaction{
+		{CategoryId: 10, AccountId: 1, Amount: 1100, TransactionTime: utils.GetMinTransactionTimeFromUnixTime(time.Date(2024, 1, 3, 12, 0, 0, 0, time.UTC).Unix())},
+		{CategoryId: 10, AccountId: 1, Amount: 1200, TransactionTime: utils.GetMinTransactionTimeFromUnixTime(time.Date(2024, 1, 11, 12, 0, 0, 0, time.UTC).Unix())},
+		{CategoryId: 11, AccountId: 2, Amount: 500, TransactionTime: utils.GetMinTransactionTimeFromUnixTime(time.Date(2024, 1, 12, 12, 0, 0, 0, time.UTC).Unix())},
+		{CategoryId: 12, AccountId: 3, Amount: 300, TransactionTime: utils.GetMinTransactionTimeFromUnixTime(time.Date(2024, 1, 13, 12, 0, 0, 0, time.UTC).Unix())},
+		{CategoryId: 10, AccountId: 1, Amount: 1000, TransactionTime: utils.GetMinTransactionTimeFromUnixTime(time.Date(2024, 2, 6, 12, 0, 0, 0, time.UTC).Unix())},
+	}
+}
+
+func getTestStatisticAmountConverter(conversionType StatisticCurrencyConversionType) *TransactionStatisticAmountConverter {
+	accountCurrencies := map[int64]string{
+		1: "USD",
+		2: "EUR",
+		3: "JPY",
+	}
+
+	return NewTransactionStatisticAmountConverter(NewHistoricalExchangeRates(getTestHistoricalExchangeRates(), nil), conversionType, accountCurrencies, "EUR", time.UTC, false)
+}
+
+func sortTestConvertedAmounts(amounts []*TransactionStatisticConvertedAmount) {
+	sort.Slice(amounts, func(i, j int) bool {
+		return amounts[i].CategoryId < amounts[j].CategoryId
+	})
+}
+
+func TestTransactionStatisticAmountConverterConvertTransactionAmount(t *testing.T) {
+	transactions := getTestStatisticTransactions()
+	converter := getTestStatisticAmountConverter(STATISTIC_CURRENCY_CONVERSION_TYPE_SPOT)
+
+	amount, currency := converter.ConvertTransactionAmount(transactions[1])
+	assert.Equal(t, int64(1000), amount)
+	assert.Equal(t, "EUR", currency)
+
+	amount, currency = converter.ConvertTransactionAmount(transactions[3])
+	assert.Equal(t, int64(300), amount)
+	assert.Equal(t, "JPY", currency)
+
+	converter = getTestStatisticAmountConverter(STATISTIC_CURRENCY_CONVERSION_TYPE_MONTHLY_AVERAGE)
+
+	amount, currency = converter.ConvertTransactionAmount(transactions[1])
+	assert.Equal(t, int64(1043), amount)
+	assert.Equal(t, "EUR", currency)
+}
+
+func TestTransactionStatisticAmountConverterConvertTransactionAmount_WithAccountCurrencyPrecisions(t *testing.T) {
+	transactions := getTestStatisticTransactions()
+	converter := getTestStatisticAmountConverter(STATISTIC_CURRENCY_CONVERSION_TYPE_SPOT)
+	converter.SetAccountCurrencyPrecisions(map[int64]int{
+		1: 4,
+	})
+
+	amount, currency := converter.ConvertTransactionAmount(transactions[1])
+	assert.Equal(t, int64(10), amount)
+	assert.Equal(t, "EUR", currency)
+
+	amount, currency = converter.ConvertTransactionAmount(transactions[2])
+	assert.Equal(t, int64(500), amount)
+	assert.Equal(t, "EUR", currency)
+}
+
+func TestTransactionStatisticAmountConverterGetTotalAmounts(t *testing.T) {
+	converter := getTestStatisticAmountConverter(STATISTIC_CURRENCY_CONVERSION_TYPE_SPOT)
+	totalAmounts := converter.GetTotalAmounts(getTestStatisticTransactions())
+	sortTestConvertedAmounts(totalAmounts)
+
+	assert.Equal(t, 3, len(totalAmounts))
+	assert.Equal(t, &TransactionStatisticConvertedAmount{CategoryId: 10, AccountId: 1, Currency: "EUR", Amount: 3000}, totalAmounts[0])
+	assert.Equal(t, &TransactionStatisticConvertedAmount{CategoryId: 11, AccountId: 2, Currency: "EUR", Amount: 500}, totalAmounts[1])
+	assert.Equal(t, &TransactionStatisticConvertedAmount{CategoryId: 12, AccountId: 3, Currency: "JPY", Amount: 300}, totalAmounts[2])
+
+	totalAmounts = converter.GetTotalAmounts(nil)
+	assert.Equal(t, 0, len(totalAmounts))
+}
+
+func TestTransactionStatisticAmountConverterGetPeriodicTotalAmounts(t *testing.T) {
+	converter := getTestStatisticAmountConverter(STATISTIC_CURRENCY_CONVERSION_TYPE_MONTHLY_AVERAGE)
+	periodicTotalAmounts := converter.GetPeriodicTotalAmounts(getTestStatisticTransactions(), &StatisticPeriodOptions{Granularity: STATISTIC_PERIOD_GRANULARITY_MONTH})
+
+	assert.Equal(t, 2, len(periodicTotalAmounts))
+
+	januaryAmounts := periodicTotalAmounts[20240101]
+	sortTestConvertedAmounts(januaryAmounts)
+	assert.Equal(t, 3, len(januaryAmounts))
+	assert.Equal(t, &TransactionStatisticConvertedAmount{CategoryId: 10, AccountId: 1, Currency: "EUR", Amount: 957 + 1043}, januaryAmounts[0])
+	assert.Equal(t, &TransactionStatisticConvertedAmount{CategoryId: 12, AccountId: 3, Currency: "JPY", Amount: 300}, januaryAmounts[2])
+
+	februaryAmounts := periodicTotalAmounts[20240201]
+	assert.Equal(t, 1, len(februaryAmounts))
+	assert.Equal(t, &TransactionStatisticConvertedAmount{CategoryId: 10, AccountId: 1, Currency: "EUR", Amount: 1000}, februaryAmounts[0])
+}
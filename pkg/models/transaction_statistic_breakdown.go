@@ -0,0 +1,238 @@
+package models
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+const transactionStatisticBreakdownDimensionSeparator = ","
+const transactionStatisticBreakdownMaxDimensionCount = 2
+
+// TransactionStatisticNoTagKey represents the breakdown key of the transactions which have no tags
+const TransactionStatisticNoTagKey = "0"
+
+// TransactionStatisticBreakdownDimension represents the dimension which transaction totals are grouped by
+type TransactionStatisticBreakdownDimension string
+
+// Transaction statistic breakdown dimensions
+const (
+	TRANSACTION_STATISTIC_BREAKDOWN_DIMENSION_CATEGORY TransactionStatisticBreakdownDimension = "category"
+	TRANSACTION_STATISTIC_BREAKDOWN_DIMENSION_ACCOUNT  TransactionStatisticBreakdownDimension = "account"
+	TRANSACTION_STATISTIC_BREAKDOWN_DIMENSION_TAG      TransactionStatisticBreakdownDimension = "tag"
+	TRANSACTION_STATISTIC_BREAKDOWN_DIMENSION_MONTH    TransactionStatisticBreakdownDimension = "month"
+)
+
+var allTransactionStatisticBreakdownDimensions = map[TransactionStatisticBreakdownDimension]bool{
+	TRANSACTION_STATISTIC_BREAKDOWN_DIMENSION_CATEGORY: true,
+	TRANSACTION_STATISTIC_BREAKDOWN_DIMENSION_ACCOUNT:  true,
+	TRANSACTION_STATISTIC_BREAKDOWN_DIMENSION_TAG:      true,
+	TRANSACTION_STATISTIC_BREAKDOWN_DIMENSION_MONTH:    true,
+}
+
+// TransactionStatisticMultiTagRule represents how the amount of transaction which has multiple tags is counted in tag breakdown
+type TransactionStatisticMultiTagRule byte
+
+// Transaction statistic multi-tag rules
+const (
+	TRANSACTION_STATISTIC_MULTI_TAG_RULE_FULL_AMOUNT  TransactionStatisticMultiTagRule = 0 // every tag is counted with the full amount, so the sum of all tags may exceed the total amount
+	TRANSACTION_STATISTIC_MULTI_TAG_RULE_SPLIT_EVENLY TransactionStatisticMultiTagRule = 1 // the amount is split evenly among tags, the remaining cents are counted to the tags with smaller ids
+)
+
+// TransactionStatisticBreakdownOptions represents the options of grouping transactions into breakdown items
+type TransactionStatisticBreakdownOptions struct {
+	Dimensions             []TransactionStatisticBreakdownDimension
+	MultiTagRule           TransactionStatisticMultiTagRule
+	ClientTimezone         *time.Location
+	UseTransactionTimezone bool
+}
+
+// TransactionStatisticBreakdownResponse represents transaction statistic response grouped by breakdown dimensions
+type TransactionStatisticBreakdownResponse struct {
+	StartTime  int64                                          `json:"startTime"`
+	EndTime    int64                                          `json:"endTime"`
+	Dimensions []TransactionStatisticBreakdownDimension       `json:"dimensions"`
+	Items      TransactionStatisticBreakdownResponseItemSlice `json:"items"`
+}
+
+// TransactionStatisticBreakdownResponseItem represents total amount of the specified dimension values, transaction type and currency,
+// the key of category, account and tag dimension is the id, the key of month dimension is year and month (e.g. 2024-05)
+type TransactionStatisticBreakdownResponseItem struct {
+	Type         TransactionType `json:"type"`
+	PrimaryKey   string          `json:"primaryKey"`
+	SecondaryKey string          `json:"secondaryKey,omitempty"`
+	Currency     string          `json:"currency"`
+	TotalAmount  int64           `json:"amount"`
+}
+
+// TransactionStatisticBreakdownResponseItemSlice represents the slice data structure of TransactionStatisticBreakdownResponseItem
+type TransactionStatisticBreakdownResponseItemSlice []*TransactionStatisticBreakdownResponseItem
+
+// ParseTransactionStatisticBreakdownDimensions returns the breakdown dimensions of the comma separated textual content, at most two different dimensions are allowed
+func ParseTransactionStatisticBreakdownDimensions(breakdown string) ([]TransactionStatisticBreakdownDimension, error) {
+	items := strings.Split(breakdown, transactionStatisticBreakdownDimensionSeparator)
+
+	if len(items) < 1 || len(items) > transactionStatisticBreakdownMaxDimensionCount {
+		return nil, errs.ErrTransactionStatisticBreakdownInvalid
+	}
+
+	dimensions := make([]TransactionStatisticBreakdownDimension, 0, len(items))
+
+	for i := 0; i < len(items); i++ {
+		dimension := TransactionStatisticBreakdownDimension(strings.TrimSpace(items[i]))
+
+		if !allTransactionStatisticBreakdownDimensions[dimension] {
+			return nil, errs.ErrTransactionStatisticBreakdownInvalid
+		}
+
+		if len(dimensions) > 0 && dimensions[0] == dimension {
+			return nil, errs.ErrTransactionStatisticBreakdownInvalid
+		}
+
+		dimensions = append(dimensions, dimension)
+	}
+
+	return dimensions, nil
+}
+
+// GetTransactionStatisticBreakdownItems returns the total amounts of transactions grouped by breakdown dimensions, transaction type and account currency,
+// the transactions without tags are grouped into the tag key "0"
+func GetTransactionStatisticBreakdownItems(transactions []*Transaction, accountMap map[int64]*Account, allTransactionTagIds map[int64][]int64, options *TransactionStatisticBreakdownOptions) TransactionStatisticBreakdownResponseItemSlice {
+	itemsMap := make(map[string]*TransactionStatisticBreakdownResponseItem)
+
+	for i := 0; i < len(transactions); i++ {
+		transaction := transactions[i]
+		account, exists := accountMap[transaction.AccountId]
+
+		if !exists {
+			continue
+		}
+
+		transactionType, err := transaction.Type.ToTransactionType()
+
+		if err != nil {
+			continue
+		}
+
+		primaryKeys, primaryAmounts := getTransactionStatisticBreakdownKeys(transaction, options.Dimensions[0], allTransactionTagIds, options)
+		secondaryKeys := []string{""}
+		secondaryAmounts := []int64{transaction.Amount}
+
+		if len(options.Dimensions) > 1 {
+			secondaryKeys, secondaryAmounts = getTransactionStatisticBreakdownKeys(transaction, options.Dimensions[1], allTransactionTagIds, options)
+		}
+
+		for j := 0; j < len(primaryKeys); j++ {
+			for k := 0; k < len(secondaryKeys); k++ {
+				// only tag dimension may split the amount and the dimensions are different, so at least one of the amounts equals the transaction amount
+				amount := primaryAmounts[j] + secondaryAmounts[k] - transaction.Amount
+				groupKey := strings.Join([]string{utils.IntToString(int(transactionType)), primaryKeys[j], secondaryKeys[k], account.Currency}, "|")
+				item, exists := itemsMap[groupKey]
+
+				if !exists {
+					item = &TransactionStatisticBreakdownResponseItem{
+						Type:         transactionType,
+						PrimaryKey:   primaryKeys[j],
+						SecondaryKey: secondaryKeys[k],
+						Currency:     account.Currency,
+					}
+
+					itemsMap[groupKey] = item
+				}
+
+				item.TotalAmount += amount
+			}
+		}
+	}
+
+	items := make(TransactionStatisticBreakdownResponseItemSlice, 0, len(itemsMap))
+
+	for _, item := range itemsMap {
+		items = append(items, item)
+	}
+
+	sort.Sort(items)
+
+	return items
+}
+
+func getTransactionStatisticBreakdownKeys(transaction *Transaction, dimension TransactionStatisticBreakdownDimension, allTransactionTagIds map[int64][]int64, options *TransactionStatisticBreakdownOptions) ([]string, []int64) {
+	switch dimension {
+	case TRANSACTION_STATISTIC_BREAKDOWN_DIMENSION_CATEGORY:
+		return []string{utils.Int64ToString(transaction.CategoryId)}, []int64{transaction.Amount}
+	case TRANSACTION_STATISTIC_BREAKDOWN_DIMENSION_ACCOUNT:
+		return []string{utils.Int64ToString(transaction.AccountId)}, []int64{transaction.Amount}
+	case TRANSACTION_STATISTIC_BREAKDOWN_DIMENSION_MONTH:
+		timezone := options.ClientTimezone
+
+		if options.UseTransactionTimezone {
+			timezone = time.FixedZone("Transaction Timezone", int(transaction.TimezoneUtcOffset)*60)
+		}
+
+		return []string{utils.FormatUnixTimeToYearMonth(utils.GetUnixTimeFromTransactionTime(transaction.TransactionTime), timezone)}, []int64{transaction.Amount}
+	case TRANSACTION_STATISTIC_BREAKDOWN_DIMENSION_TAG:
+		return getTransactionStatisticTagKeys(transaction, allTransactionTagIds[transaction.TransactionId], options.MultiTagRule)
+	}
+
+	return nil, nil
+}
+
+func getTransactionStatisticTagKeys(transaction *Transaction, tagIds []int64, multiTagRule TransactionStatisticMultiTagRule) ([]string, []int64) {
+	if len(tagIds) < 1 {
+		return []string{TransactionStatisticNoTagKey}, []int64{transaction.Amount}
+	}
+
+	sortedTagIds := make([]int64, len(tagIds))
+	copy(sortedTagIds, tagIds)
+	sort.Slice(sortedTagIds, func(i, j int) bool {
+		return sortedTagIds[i] < sortedTagIds[j]
+	})
+
+	keys := make([]string, len(sortedTagIds))
+	amounts := make([]int64, len(sortedTagIds))
+
+	for i := 0; i < len(sortedTagIds); i++ {
+		keys[i] = utils.Int64ToString(sortedTagIds[i])
+		amounts[i] = transaction.Amount
+
+		if multiTagRule == TRANSACTION_STATISTIC_MULTI_TAG_RULE_SPLIT_EVENLY {
+			amounts[i] = transaction.Amount / int64(len(sortedTagIds))
+
+			if int64(i) < transaction.Amount%int64(len(sortedTagIds)) {
+				amounts[i]++
+			}
+		}
+	}
+
+	return keys, amounts
+}
+
+// Len returns the count of items
+func (s TransactionStatisticBreakdownResponseItemSlice) Len() int {
+	return len(s)
+}
+
+// Swap swaps two items
+func (s TransactionStatisticBreakdownResponseItemSlice) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+}
+
+// Less reports whether the first item is less than the second one
+func (s TransactionStatisticBreakdownResponseItemSlice) Less(i, j int) bool {
+	if s[i].Type != s[j].Type {
+		return s[i].Type < s[j].Type
+	}
+
+	if s[i].PrimaryKey != s[j].PrimaryKey {
+		return s[i].PrimaryKey < s[j].PrimaryKey
+	}
+
+	if s[i].SecondaryKey != s[j].SecondaryKey {
+		return s[i].SecondaryKey < s[j].SecondaryKey
+	}
+
+	return s[i].Currency < s[j].Currency
+}
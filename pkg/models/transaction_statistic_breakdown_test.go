@@ -0,0 +1,127 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+)
+
+func TestParseTransactionStatisticBreakdownDimensions(t *testing.T) {
+	dimensions, err := ParseTransactionStatisticBreakdownDimensions("tag")
+	assert.Nil(t, err)
+	assert.Equal(t, []TransactionStatisticBreakdownDimension{TRANSACTION_STATISTIC_BREAKDOWN_DIMENSION_TAG}, dimensions)
+
+	dimensions, err = ParseTransactionStatisticBreakdownDimensions("category, tag")
+	assert.Nil(t, err)
+	assert.Equal(t, []TransactionStatisticBreakdownDimension{TRANSACTION_STATISTIC_BREAKDOWN_DIMENSION_CATEGORY, TRANSACTION_STATISTIC_BREAKDOWN_DIMENSION_TAG}, dimensions)
+
+	_, err = ParseTransactionStatisticBreakdownDimensions("tag,tag")
+	assert.Equal(t, errs.ErrTransactionStatisticBreakdownInvalid, err)
+
+	_, err = ParseTransactionStatisticBreakdownDimensions("category,tag,month")
+	assert.Equal(t, errs.ErrTransactionStatisticBreakdownInvalid, err)
+
+	_, err = ParseTransactionStatisticBreakdownDimensions("payee")
+	assert.Equal(t, errs.ErrTransactionStatisticBreakdownInvalid, err)
+}
+
+func TestGetTransactionStatisticBreakdownItems_TagWithFullAmount(t *testing.T) {
+	transactions, accountMap, allTransactionTagIds := getTestTransactionStatisticBreakdownData()
+	items := GetTransactionStatisticBreakdownItems(transactions, accountMap, allTransactionTagIds, &TransactionStatisticBreakdownOptions{
+		Dimensions:     []TransactionStatisticBreakdownDimension{TRANSACTION_STATISTIC_BREAKDOWN_DIMENSION_TAG},
+		MultiTagRule:   TRANSACTION_STATISTIC_MULTI_TAG_RULE_FULL_AMOUNT,
+		ClientTimezone: time.UTC,
+	})
+
+	assert.Equal(t, TransactionStatisticBreakdownResponseItemSlice{
+		{Type: TRANSACTION_TYPE_INCOME, PrimaryKey: "0", Currency: "USD", TotalAmount: 50000},
+		{Type: TRANSACTION_TYPE_EXPENSE, PrimaryKey: "0", Currency: "EUR", TotalAmount: 700},
+		{Type: TRANSACTION_TYPE_EXPENSE, PrimaryKey: "101", Currency: "USD", TotalAmount: 1001 + 300},
+		{Type: TRANSACTION_TYPE_EXPENSE, PrimaryKey: "102", Currency: "USD", TotalAmount: 1001},
+	}, items)
+}
+
+func TestGetTransactionStatisticBreakdownItems_TagWithSplitEvenly(t *testing.T) {
+	transactions, accountMap, allTransactionTagIds := getTestTransactionStatisticBreakdownData()
+	items := GetTransactionStatisticBreakdownItems(transactions, accountMap, allTransactionTagIds, &TransactionStatisticBreakdownOptions{
+		Dimensions:     []TransactionStatisticBreakdownDimension{TRANSACTION_STATISTIC_BREAKDOWN_DIMENSION_TAG},
+		MultiTagRule:   TRANSACTION_STATISTIC_MULTI_TAG_RULE_SPLIT_EVENLY,
+		ClientTimezone: time.UTC,
+	})
+
+	assert.Equal(t, TransactionStatisticBreakdownResponseItemSlice{
+		{Type: TRANSACTION_TYPE_INCOME, PrimaryKey: "0", Currency: "USD", TotalAmount: 50000},
+		{Type: TRANSACTION_TYPE_EXPENSE, PrimaryKey: "0", Currency: "EUR", TotalAmount: 700},
+		{Type: TRANSACTION_TYPE_EXPENSE, PrimaryKey: "101", Currency: "USD", TotalAmount: 501 + 300},
+		{Type: TRANSACTION_TYPE_EXPENSE, PrimaryKey: "102", Currency: "USD", TotalAmount: 500},
+	}, items)
+}
+
+func TestGetTransactionStatisticBreakdownItems_CategoryAndTagPivot(t *testing.T) {
+	transactions, accountMap, allTransactionTagIds := getTestTransactionStatisticBreakdownData()
+	items := GetTransactionStatisticBreakdownItems(transactions, accountMap, allTransactionTagIds, &TransactionStatisticBreakdownOptions{
+		Dimensions:     []TransactionStatisticBreakdownDimension{TRANSACTION_STATISTIC_BREAKDOWN_DIMENSION_CATEGORY, TRANSACTION_STATISTIC_BREAKDOWN_DIMENSION_TAG},
+		MultiTagRule:   TRANSACTION_STATISTIC_MULTI_TAG_RULE_SPLIT_EVENLY,
+		ClientTimezone: time.UTC,
+	})
+
+	assert.Equal(t, TransactionStatisticBreakdownResponseItemSlice{
+		{Type: TRANSACTION_TYPE_INCOME, PrimaryKey: "30", SecondaryKey: "0", Currency: "USD", TotalAmount: 50000},
+		{Type: TRANSACTION_TYPE_EXPENSE, PrimaryKey: "10", SecondaryKey: "101", Currency: "USD", TotalAmount: 501},
+		{Type: TRANSACTION_TYPE_EXPENSE, PrimaryKey: "10", SecondaryKey: "102", Currency: "USD", TotalAmount: 500},
+		{Type: TRANSACTION_TYPE_EXPENSE, PrimaryKey: "20", SecondaryKey: "0", Currency: "EUR", TotalAmount: 700},
+		{Type: TRANSACTION_TYPE_EXPENSE, PrimaryKey: "20", SecondaryKey: "101", Currency: "USD", TotalAmount: 300},
+	}, items)
+}
+
+func TestGetTransactionStatisticBreakdownItems_AccountAndMonthPivot(t *testing.T) {
+	transactions, accountMap, allTransactionTagIds := getTestTransactionStatisticBreakdownData()
+	items := GetTransactionStatisticBreakdownItems(transactions, accountMap, allTransactionTagIds, &TransactionStatisticBreakdownOptions{
+		Dimensions:     []TransactionStatisticBreakdownDimension{TRANSACTION_STATISTIC_BREAKDOWN_DIMENSION_ACCOUNT, TRANSACTION_STATISTIC_BREAKDOWN_DIMENSION_MONTH},
+		ClientTimezone: time.FixedZone("Client Timezone", 8*60*60),
+	})
+
+	assert.Equal(t, TransactionStatisticBreakdownResponseItemSlice{
+		{Type: TRANSACTION_TYPE_INCOME, PrimaryKey: "1", SecondaryKey: "2024-05", Currency: "USD", TotalAmount: 50000},
+		{Type: TRANSACTION_TYPE_EXPENSE, PrimaryKey: "1", SecondaryKey: "2024-05", Currency: "USD", TotalAmount: 1001},
+		{Type: TRANSACTION_TYPE_EXPENSE, PrimaryKey: "1", SecondaryKey: "2024-06", Currency: "USD", TotalAmount: 300},
+		{Type: TRANSACTION_TYPE_EXPENSE, PrimaryKey: "2", SecondaryKey: "2024-06", Currency: "EUR", TotalAmount: 700},
+	}, items)
+
+	items = GetTransactionStatisticBreakdownItems(transactions, accountMap, allTransactionTagIds, &TransactionStatisticBreakdownOptions{
+		Dimensions:             []TransactionStatisticBreakdownDimension{TRANSACTION_STATISTIC_BREAKDOWN_DIMENSION_MONTH},
+		ClientTimezone:         time.FixedZone("Client Timezone", 8*60*60),
+		UseTransactionTimezone: true,
+	})
+
+	assert.Equal(t, TransactionStatisticBreakdownResponseItemSlice{
+		{Type: TRANSACTION_TYPE_INCOME, PrimaryKey: "2024-05", Currency: "USD", TotalAmount: 50000},
+		{Type: TRANSACTION_TYPE_EXPENSE, PrimaryKey: "2024-05", Currency: "USD", TotalAmount: 1001 + 300},
+		{Type: TRANSACTION_TYPE_EXPENSE, PrimaryKey: "2024-06", Currency: "EUR", TotalAmount: 700},
+	}, items)
+}
+
+func getTestTransactionStatisticBreakdownData() ([]*Transaction, map[int64]*Account, map[int64][]int64) {
+	transactions := []*Transaction{
+		{TransactionId: 1001, Type: TRANSACTION_DB_TYPE_EXPENSE, CategoryId: 10, AccountId: 1, TransactionTime: time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC).Unix() * 1000, Amount: 1001},
+		{TransactionId: 1002, Type: TRANSACTION_DB_TYPE_EXPENSE, CategoryId: 20, AccountId: 1, TransactionTime: time.Date(2024, 5, 31, 20, 0, 0, 0, time.UTC).Unix() * 1000, Amount: 300},
+		{TransactionId: 1003, Type: TRANSACTION_DB_TYPE_EXPENSE, CategoryId: 20, AccountId: 2, TransactionTime: time.Date(2024, 6, 1, 8, 0, 0, 0, time.UTC).Unix() * 1000, Amount: 700},
+		{TransactionId: 1004, Type: TRANSACTION_DB_TYPE_INCOME, CategoryId: 30, AccountId: 1, TransactionTime: time.Date(2024, 5, 25, 8, 0, 0, 0, time.UTC).Unix() * 1000, Amount: 50000},
+		{TransactionId: 1005, Type: TRANSACTION_DB_TYPE_EXPENSE, CategoryId: 10, AccountId: 3, TransactionTime: time.Date(2024, 5, 25, 8, 0, 0, 0, time.UTC).Unix() * 1000, Amount: 100},
+	}
+
+	accountMap := map[int64]*Account{
+		1: {AccountId: 1, Currency: "USD"},
+		2: {AccountId: 2, Currency: "EUR"},
+	}
+
+	allTransactionTagIds := map[int64][]int64{
+		1001: {102, 101},
+		1002: {101},
+	}
+
+	return transactions, accountMap, allTransactionTagIds
+}
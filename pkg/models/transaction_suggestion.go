@@ -0,0 +1,21 @@
+package models
+
+// TransactionSuggestionRequest represents all parameters of transaction category and tag suggestion request
+type TransactionSuggestionRequest struct {
+	Type      TransactionType `form:"type" binding:"required,min=2,max=4"`
+	AccountId int64           `form:"account_id,string" binding:"min=0"`
+	Amount    int64           `form:"amount"`
+	Comment   string          `form:"comment" binding:"max=255"`
+}
+
+// TransactionSuggestionResponse represents a view-object of transaction category and tag suggestion
+type TransactionSuggestionResponse struct {
+	Categories []*TransactionCategorySuggestionResponse `json:"categories"`
+	TagIds     []string                                 `json:"tagIds"`
+}
+
+// TransactionCategorySuggestionResponse represents a view-object of suggested transaction category
+type TransactionCategorySuggestionResponse struct {
+	CategoryId  int64   `json:"categoryId,string"`
+	Probability float64 `json:"probability"`
+}
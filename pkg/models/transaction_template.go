@@ -4,6 +4,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/mayswind/ezbookkeeping/pkg/core"
 	"github.com/mayswind/ezbookkeeping/pkg/utils"
 )
 
@@ -21,31 +22,69 @@ type TransactionScheduleFrequencyType byte
 
 // Transaction template schedule frequency types
 const (
-	TRANSACTION_SCHEDULE_FREQUENCY_TYPE_DISABLED TransactionScheduleFrequencyType = 0
-	TRANSACTION_SCHEDULE_FREQUENCY_TYPE_WEEKLY   TransactionScheduleFrequencyType = 1
-	TRANSACTION_SCHEDULE_FREQUENCY_TYPE_MONTHLY  TransactionScheduleFrequencyType = 2
+	TRANSACTION_SCHEDULE_FREQUENCY_TYPE_DISABLED          TransactionScheduleFrequencyType = 0
+	TRANSACTION_SCHEDULE_FREQUENCY_TYPE_WEEKLY            TransactionScheduleFrequencyType = 1
+	TRANSACTION_SCHEDULE_FREQUENCY_TYPE_MONTHLY           TransactionScheduleFrequencyType = 2
+	TRANSACTION_SCHEDULE_FREQUENCY_TYPE_DAILY             TransactionScheduleFrequencyType = 3
+	TRANSACTION_SCHEDULE_FREQUENCY_TYPE_EVERY_N_DAYS      TransactionScheduleFrequencyType = 4
+	TRANSACTION_SCHEDULE_FREQUENCY_TYPE_EVERY_N_WEEKS     TransactionScheduleFrequencyType = 5
+	TRANSACTION_SCHEDULE_FREQUENCY_TYPE_QUARTERLY         TransactionScheduleFrequencyType = 6
+	TRANSACTION_SCHEDULE_FREQUENCY_TYPE_YEARLY            TransactionScheduleFrequencyType = 7
+	TRANSACTION_SCHEDULE_FREQUENCY_TYPE_LAST_DAY_OF_MONTH TransactionScheduleFrequencyType = 8
 )
 
+// Variables which can be used in the amount expression of scheduled transaction template
+const (
+	TRANSACTION_TEMPLATE_AMOUNT_EXPRESSION_VARIABLE_AMOUNT              = "amount"
+	TRANSACTION_TEMPLATE_AMOUNT_EXPRESSION_VARIABLE_BALANCE             = "balance"
+	TRANSACTION_TEMPLATE_AMOUNT_EXPRESSION_VARIABLE_DESTINATION_BALANCE = "destination_balance"
+	TRANSACTION_TEMPLATE_AMOUNT_EXPRESSION_VARIABLE_THIS_MONTH_INCOME   = "this_month_income"
+	TRANSACTION_TEMPLATE_AMOUNT_EXPRESSION_VARIABLE_THIS_MONTH_EXPENSE  = "this_month_expense"
+	TRANSACTION_TEMPLATE_AMOUNT_EXPRESSION_VARIABLE_LAST_MONTH_INCOME   = "last_month_income"
+	TRANSACTION_TEMPLATE_AMOUNT_EXPRESSION_VARIABLE_LAST_MONTH_EXPENSE  = "last_month_expense"
+)
+
+var transactionTemplateAmountExpressionVariables = map[string]bool{
+	TRANSACTION_TEMPLATE_AMOUNT_EXPRESSION_VARIABLE_AMOUNT:              true,
+	TRANSACTION_TEMPLATE_AMOUNT_EXPRESSION_VARIABLE_BALANCE:             true,
+	TRANSACTION_TEMPLATE_AMOUNT_EXPRESSION_VARIABLE_DESTINATION_BALANCE: true,
+	TRANSACTION_TEMPLATE_AMOUNT_EXPRESSION_VARIABLE_THIS_MONTH_INCOME:   true,
+	TRANSACTION_TEMPLATE_AMOUNT_EXPRESSION_VARIABLE_THIS_MONTH_EXPENSE:  true,
+	TRANSACTION_TEMPLATE_AMOUNT_EXPRESSION_VARIABLE_LAST_MONTH_INCOME:   true,
+	TRANSACTION_TEMPLATE_AMOUNT_EXPRESSION_VARIABLE_LAST_MONTH_EXPENSE:  true,
+}
+
+const maximumScheduledInterval = 999
+const maximumScheduledTransactionSearchDays = 366*8 + 1
+
 // TransactionTemplate represents transaction template stored in database
 type TransactionTemplate struct {
 	TemplateId                 int64                            `xorm:"PK"`
 	Uid                        int64                            `xorm:"INDEX(IDX_transaction_template_uid_deleted_template_type_order) NOT NULL"`
-	Deleted                    bool                             `xorm:"INDEX(IDX_transaction_template_uid_deleted_template_type_order) INDEX(IDX_transaction_template_deleted_type_freqtype_scheduled_time) NOT NULL"`
-	TemplateType               TransactionTemplateType          `xorm:"INDEX(IDX_transaction_template_uid_deleted_template_type_order) INDEX(IDX_transaction_template_deleted_type_freqtype_scheduled_time) NOT NULL"`
+	Deleted                    bool                             `xorm:"INDEX(IDX_transaction_template_uid_deleted_template_type_order) INDEX(IDX_transaction_template_deleted_type_freqtype_scheduled_time) INDEX(IDX_transaction_template_deleted_type_freqtype_next_run_time) NOT NULL"`
+	TemplateType               TransactionTemplateType          `xorm:"INDEX(IDX_transaction_template_uid_deleted_template_type_order) INDEX(IDX_transaction_template_deleted_type_freqtype_scheduled_time) INDEX(IDX_transaction_template_deleted_type_freqtype_next_run_time) NOT NULL"`
 	Name                       string                           `xorm:"VARCHAR(64) NOT NULL"`
 	Type                       TransactionType                  `xorm:"NOT NULL"`
 	CategoryId                 int64                            `xorm:"NOT NULL"`
 	AccountId                  int64                            `xorm:"NOT NULL"`
-	ScheduledFrequencyType     TransactionScheduleFrequencyType `xorm:"INDEX(IDX_transaction_template_deleted_type_freqtype_scheduled_time)"`
+	ScheduledFrequencyType     TransactionScheduleFrequencyType `xorm:"INDEX(IDX_transaction_template_deleted_type_freqtype_scheduled_time) INDEX(IDX_transaction_template_deleted_type_freqtype_next_run_time)"`
 	ScheduledFrequency         string                           `xorm:"VARCHAR(100)"`
 	ScheduledStartTime         *int64                           `xorm:"INDEX(IDX_transaction_template_deleted_type_freqtype_scheduled_time)"`
 	ScheduledEndTime           *int64                           `xorm:"INDEX(IDX_transaction_template_deleted_type_freqtype_scheduled_time)"`
 	ScheduledAt                int16                            `xorm:"INDEX(IDX_transaction_template_deleted_type_freqtype_scheduled_time)"`
 	ScheduledTimezoneUtcOffset int16
+	ScheduledInterval          int16
+	ScheduledFirstDayOfWeek    core.WeekDay `xorm:"TINYINT"`
+	ScheduledNextBusinessDay   bool
+	ScheduledLastRunTime       int64
+	ScheduledNextRunTime       int64 `xorm:"INDEX(IDX_transaction_template_deleted_type_freqtype_next_run_time)"`
+	ReminderDays               int16
+	ReminderDigest             bool
 	TagIds                     string `xorm:"VARCHAR(255) NOT NULL"`
 	Amount                     int64  `xorm:"NOT NULL"`
 	RelatedAccountId           int64  `xorm:"NOT NULL"`
 	RelatedAccountAmount       int64  `xorm:"NOT NULL"`
+	AmountExpression           string `xorm:"VARCHAR(255)"`
 	HideAmount                 bool   `xorm:"NOT NULL"`
 	Comment                    string `xorm:"VARCHAR(255) NOT NULL"`
 	DisplayOrder               int32  `xorm:"INDEX(IDX_transaction_template_uid_deleted_template_type_order) NOT NULL"`
@@ -83,6 +122,11 @@ type TransactionTemplateCreateRequest struct {
 	ScheduledStartDate         *string                           `json:"scheduledStartDate" binding:"omitempty"`
 	ScheduledEndDate           *string                           `json:"scheduledEndDate" binding:"omitempty"`
 	ScheduledTimezoneUtcOffset *int16                            `json:"utcOffset" binding:"omitempty,min=-720,max=840"`
+	ScheduledInterval          int16                             `json:"scheduledInterval" binding:"min=0,max=999"`
+	ScheduledNextBusinessDay   bool                              `json:"scheduledNextBusinessDay"`
+	AmountExpression           string                            `json:"amountExpression" binding:"max=255"`
+	ReminderDays               int16                             `json:"reminderDays" binding:"min=0,max=30"`
+	ReminderDigest             bool                              `json:"reminderDigest"`
 	ClientSessionId            string                            `json:"clientSessionId"`
 }
 
@@ -110,6 +154,11 @@ type TransactionTemplateModifyRequest struct {
 	ScheduledStartDate         *string                           `json:"scheduledStartDate" binding:"omitempty"`
 	ScheduledEndDate           *string                           `json:"scheduledEndDate" binding:"omitempty"`
 	ScheduledTimezoneUtcOffset *int16                            `json:"utcOffset" binding:"omitempty,min=-720,max=840"`
+	ScheduledInterval          int16                             `json:"scheduledInterval" binding:"min=0,max=999"`
+	ScheduledNextBusinessDay   bool                              `json:"scheduledNextBusinessDay"`
+	AmountExpression           string                            `json:"amountExpression" binding:"max=255"`
+	ReminderDays               int16                             `json:"reminderDays" binding:"min=0,max=30"`
+	ReminderDigest             bool                              `json:"reminderDigest"`
 }
 
 // TransactionTemplateHideRequest represents all parameters of transaction template hiding request
@@ -136,15 +185,22 @@ type TransactionTemplateDeleteRequest struct {
 
 type TransactionTemplateInfoResponse struct {
 	*TransactionInfoResponse
-	TemplateType           TransactionTemplateType           `json:"templateType"`
-	Name                   string                            `json:"name"`
-	ScheduledFrequencyType *TransactionScheduleFrequencyType `json:"scheduledFrequencyType,omitempty"`
-	ScheduledFrequency     *string                           `json:"scheduledFrequency,omitempty"`
-	ScheduledStartDate     *string                           `json:"scheduledStartDate" binding:"omitempty"`
-	ScheduledEndDate       *string                           `json:"scheduledEndDate" binding:"omitempty"`
-	ScheduledAt            *int16                            `json:"scheduledAt,omitempty"`
-	DisplayOrder           int32                             `json:"displayOrder"`
-	Hidden                 bool                              `json:"hidden"`
+	TemplateType             TransactionTemplateType           `json:"templateType"`
+	Name                     string                            `json:"name"`
+	ScheduledFrequencyType   *TransactionScheduleFrequencyType `json:"scheduledFrequencyType,omitempty"`
+	ScheduledFrequency       *string                           `json:"scheduledFrequency,omitempty"`
+	ScheduledStartDate       *string                           `json:"scheduledStartDate" binding:"omitempty"`
+	ScheduledEndDate         *string                           `json:"scheduledEndDate" binding:"omitempty"`
+	ScheduledAt              *int16                            `json:"scheduledAt,omitempty"`
+	ScheduledInterval        *int16                            `json:"scheduledInterval,omitempty"`
+	ScheduledNextBusinessDay *bool                             `json:"scheduledNextBusinessDay,omitempty"`
+	ScheduledLastRunTime     *int64                            `json:"scheduledLastRunTime,omitempty"`
+	ScheduledNextRunTime     *int64                            `json:"scheduledNextRunTime,omitempty"`
+	AmountExpression         *string                           `json:"amountExpression,omitempty"`
+	ReminderDays             *int16                            `json:"reminderDays,omitempty"`
+	ReminderDigest           *bool                             `json:"reminderDigest,omitempty"`
+	DisplayOrder             int32                             `json:"displayOrder"`
+	Hidden                   bool                              `json:"hidden"`
 }
 
 // GetTagIds returns all tag ids of the transaction template
@@ -160,6 +216,258 @@ func (t *TransactionTemplate) GetTagIds() []int64 {
 	return result
 }
 
+// IsAmountExpressionVariableSupported returns whether the variable can be used in the amount expression of this template
+func (t *TransactionTemplate) IsAmountExpressionVariableSupported(name string) bool {
+	if !transactionTemplateAmountExpressionVariables[name] {
+		return false
+	}
+
+	if name == TRANSACTION_TEMPLATE_AMOUNT_EXPRESSION_VARIABLE_DESTINATION_BALANCE && t.Type != TRANSACTION_TYPE_TRANSFER {
+		return false
+	}
+
+	return true
+}
+
+// GetScheduledFrequencyValues returns all scheduled frequency values of the transaction template
+func (t *TransactionTemplate) GetScheduledFrequencyValues() ([]int64, error) {
+	if t.ScheduledFrequency == "" {
+		return []int64{}, nil
+	}
+
+	return utils.StringArrayToInt64Array(strings.Split(t.ScheduledFrequency, ","))
+}
+
+// GetReminderScheduledTransactionTime returns the time of the scheduled transaction which will be created on the day of reminder days later (in template timezone), returns 0 if no transaction will be created on that day
+func (t *TransactionTemplate) GetReminderScheduledTransactionTime(currentUnixTime int64) int64 {
+	if t.ReminderDays <= 0 {
+		return 0
+	}
+
+	templateTimeZone := time.FixedZone("Template Timezone", int(t.ScheduledTimezoneUtcOffset)*60)
+	currentTime := time.Unix(currentUnixTime, 0).In(templateTimeZone)
+	reminderDate := time.Date(currentTime.Year(), currentTime.Month(), currentTime.Day()+int(t.ReminderDays), 0, 0, 0, 0, templateTimeZone)
+	scheduledTimes := t.GetScheduledTransactionTimes(reminderDate.Unix(), reminderDate.AddDate(0, 0, 1).Unix()-1)
+
+	if len(scheduledTimes) < 1 {
+		return 0
+	}
+
+	return scheduledTimes[0]
+}
+
+// IsScheduledFrequencyValid returns whether the scheduled frequency settings of the transaction template are valid
+func (t *TransactionTemplate) IsScheduledFrequencyValid() bool {
+	frequencyValues, err := t.GetScheduledFrequencyValues()
+
+	if err != nil {
+		return false
+	}
+
+	if t.ScheduledInterval < 0 || t.ScheduledInterval > maximumScheduledInterval {
+		return false
+	}
+
+	switch t.ScheduledFrequencyType {
+	case TRANSACTION_SCHEDULE_FREQUENCY_TYPE_DISABLED,
+		TRANSACTION_SCHEDULE_FREQUENCY_TYPE_DAILY,
+		TRANSACTION_SCHEDULE_FREQUENCY_TYPE_LAST_DAY_OF_MONTH:
+		return len(frequencyValues) == 0
+	case TRANSACTION_SCHEDULE_FREQUENCY_TYPE_WEEKLY:
+		return len(frequencyValues) > 0 && isAllValuesInRange(frequencyValues, int64(time.Sunday), int64(time.Saturday))
+	case TRANSACTION_SCHEDULE_FREQUENCY_TYPE_MONTHLY, TRANSACTION_SCHEDULE_FREQUENCY_TYPE_QUARTERLY:
+		return len(frequencyValues) > 0 && isAllValuesInRange(frequencyValues, 1, 31)
+	case TRANSACTION_SCHEDULE_FREQUENCY_TYPE_EVERY_N_DAYS:
+		return len(frequencyValues) == 0 && t.ScheduledInterval > 0 && t.ScheduledStartTime != nil
+	case TRANSACTION_SCHEDULE_FREQUENCY_TYPE_EVERY_N_WEEKS:
+		return len(frequencyValues) > 0 && isAllValuesInRange(frequencyValues, int64(time.Sunday), int64(time.Saturday)) && t.ScheduledInterval > 0 && t.ScheduledStartTime != nil
+	case TRANSACTION_SCHEDULE_FREQUENCY_TYPE_YEARLY:
+		if len(frequencyValues) < 1 {
+			return false
+		}
+
+		for i := 0; i < len(frequencyValues); i++ {
+			month := frequencyValues[i] / 100
+			day := frequencyValues[i] % 100
+
+			// use a leap year to allow February 29
+			if month < 1 || month > 12 || day < 1 || day > int64(getDaysOfMonth(2000, time.Month(month))) {
+				return false
+			}
+		}
+
+		return true
+	default:
+		return false
+	}
+}
+
+// IsScheduledDate returns whether the transaction template should create transaction on the specified date (in the template timezone)
+func (t *TransactionTemplate) IsScheduledDate(date time.Time) bool {
+	if t.ScheduledFrequencyType == TRANSACTION_SCHEDULE_FREQUENCY_TYPE_DISABLED {
+		return false
+	}
+
+	frequencyValues, err := t.GetScheduledFrequencyValues()
+
+	if err != nil {
+		return false
+	}
+
+	frequencyValueSet := utils.ToSet(frequencyValues)
+
+	if !t.ScheduledNextBusinessDay {
+		return t.isOriginalScheduledDate(date, frequencyValueSet)
+	}
+
+	if !isBusinessDay(date) {
+		return false
+	}
+
+	if t.isOriginalScheduledDate(date, frequencyValueSet) {
+		return true
+	}
+
+	// the occurrences on the non-business days right before this date are moved to this date
+	for previousDate := date.AddDate(0, 0, -1); !isBusinessDay(previousDate); previousDate = previousDate.AddDate(0, 0, -1) {
+		if t.isOriginalScheduledDate(previousDate, frequencyValueSet) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetScheduledTransactionTimes returns the unix times of all scheduled transactions between the start unix time and the end unix time (both inclusive)
+func (t *TransactionTemplate) GetScheduledTransactionTimes(startUnixTime int64, endUnixTime int64) []int64 {
+	return t.getScheduledTransactionTimes(startUnixTime, endUnixTime, -1)
+}
+
+// GetNextScheduledTransactionTime returns the unix time of the first scheduled transaction after the specified unix time, or 0 if there is no more scheduled transaction
+func (t *TransactionTemplate) GetNextScheduledTransactionTime(afterUnixTime int64) int64 {
+	scheduledTimes := t.getScheduledTransactionTimes(afterUnixTime+1, afterUnixTime+maximumScheduledTransactionSearchDays*24*60*60, 1)
+
+	if len(scheduledTimes) < 1 {
+		return 0
+	}
+
+	return scheduledTimes[0]
+}
+
+func (t *TransactionTemplate) getScheduledTransactionTimes(startUnixTime int64, endUnixTime int64, maxCount int) []int64 {
+	scheduledTimes := make([]int64, 0)
+
+	if t.TemplateType != TRANSACTION_TEMPLATE_TYPE_SCHEDULE || t.ScheduledFrequencyType == TRANSACTION_SCHEDULE_FREQUENCY_TYPE_DISABLED || !t.IsScheduledFrequencyValid() || startUnixTime > endUnixTime {
+		return scheduledTimes
+	}
+
+	templateTimeZone := time.FixedZone("Template Timezone", int(t.ScheduledTimezoneUtcOffset)*60)
+	startTime := time.Unix(startUnixTime, 0).In(templateTimeZone)
+	date := time.Date(startTime.Year(), startTime.Month(), startTime.Day(), 0, 0, 0, 0, templateTimeZone)
+
+	// the scheduled transaction is created at the time of "ScheduledAt" (minutes elapsed of day in UTC) which is in the same date in the template timezone
+	firstDateInUtc := date.In(time.UTC)
+	firstDateMinutesElapsedOfDayInUtc := firstDateInUtc.Hour()*60 + firstDateInUtc.Minute()
+	minutesElapsedOfDay := (int(t.ScheduledAt) - firstDateMinutesElapsedOfDayInUtc + 24*60) % (24 * 60)
+
+	for ; date.Unix() <= endUnixTime; date = date.AddDate(0, 0, 1) {
+		transactionUnixTime := date.Unix() + int64(minutesElapsedOfDay)*60
+
+		if transactionUnixTime < startUnixTime || (t.ScheduledStartTime != nil && transactionUnixTime < *t.ScheduledStartTime) {
+			continue
+		}
+
+		if transactionUnixTime > endUnixTime || (t.ScheduledEndTime != nil && transactionUnixTime > *t.ScheduledEndTime) {
+			break
+		}
+
+		if !t.IsScheduledDate(date) {
+			continue
+		}
+
+		scheduledTimes = append(scheduledTimes, transactionUnixTime)
+
+		if maxCount > 0 && len(scheduledTimes) >= maxCount {
+			break
+		}
+	}
+
+	return scheduledTimes
+}
+
+func (t *TransactionTemplate) isOriginalScheduledDate(date time.Time, frequencyValueSet map[int64]bool) bool {
+	switch t.ScheduledFrequencyType {
+	case TRANSACTION_SCHEDULE_FREQUENCY_TYPE_WEEKLY:
+		return frequencyValueSet[int64(date.Weekday())]
+	case TRANSACTION_SCHEDULE_FREQUENCY_TYPE_MONTHLY:
+		return frequencyValueSet[int64(date.Day())]
+	case TRANSACTION_SCHEDULE_FREQUENCY_TYPE_DAILY:
+		return true
+	case TRANSACTION_SCHEDULE_FREQUENCY_TYPE_EVERY_N_DAYS:
+		if t.ScheduledStartTime == nil || t.ScheduledInterval < 1 {
+			return false
+		}
+
+		days := getDaysBetweenDates(t.getScheduledStartDate(date.Location()), date)
+
+		return days >= 0 && days%int64(t.ScheduledInterval) == 0
+	case TRANSACTION_SCHEDULE_FREQUENCY_TYPE_EVERY_N_WEEKS:
+		if t.ScheduledStartTime == nil || t.ScheduledInterval < 1 || !frequencyValueSet[int64(date.Weekday())] {
+			return false
+		}
+
+		// the weeks are counted from the first day of week of the user when the template is saved
+		startDate := t.getScheduledStartDate(date.Location())
+		firstDayOfStartWeek := startDate.AddDate(0, 0, -((int(startDate.Weekday()) - int(t.ScheduledFirstDayOfWeek) + 7) % 7))
+		days := getDaysBetweenDates(firstDayOfStartWeek, date)
+
+		return days >= 0 && (days/7)%int64(t.ScheduledInterval) == 0
+	case TRANSACTION_SCHEDULE_FREQUENCY_TYPE_QUARTERLY:
+		firstMonthOfQuarter := int(time.January)
+
+		if t.ScheduledStartTime != nil {
+			firstMonthOfQuarter = int(t.getScheduledStartDate(date.Location()).Month())
+		}
+
+		return (int(date.Month())-firstMonthOfQuarter+12)%3 == 0 && frequencyValueSet[int64(date.Day())]
+	case TRANSACTION_SCHEDULE_FREQUENCY_TYPE_YEARLY:
+		return frequencyValueSet[int64(date.Month())*100+int64(date.Day())]
+	case TRANSACTION_SCHEDULE_FREQUENCY_TYPE_LAST_DAY_OF_MONTH:
+		return date.Day() == getDaysOfMonth(date.Year(), date.Month())
+	default:
+		return false
+	}
+}
+
+func (t *TransactionTemplate) getScheduledStartDate(location *time.Location) time.Time {
+	return time.Unix(*t.ScheduledStartTime, 0).In(location)
+}
+
+func isAllValuesInRange(values []int64, min int64, max int64) bool {
+	for i := 0; i < len(values); i++ {
+		if values[i] < min || values[i] > max {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isBusinessDay(date time.Time) bool {
+	return date.Weekday() != time.Saturday && date.Weekday() != time.Sunday
+}
+
+func getDaysOfMonth(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+func getDaysBetweenDates(startDate time.Time, endDate time.Time) int64 {
+	startDay := time.Date(startDate.Year(), startDate.Month(), startDate.Day(), 0, 0, 0, 0, time.UTC).Unix() / 86400
+	endDay := time.Date(endDate.Year(), endDate.Month(), endDate.Day(), 0, 0, 0, 0, time.UTC).Unix() / 86400
+
+	return endDay - startDay
+}
+
 // ToTransactionTemplateInfoResponse returns a view-object according to database model
 func (t *TransactionTemplate) ToTransactionTemplateInfoResponse(serverUtcOffset int16) *TransactionTemplateInfoResponse {
 	utcOffset := serverUtcOffset
@@ -180,6 +488,13 @@ func (t *TransactionTemplate) ToTransactionTemplateInfoResponse(serverUtcOffset
 		response.ScheduledFrequencyType = &t.ScheduledFrequencyType
 		response.ScheduledFrequency = &t.ScheduledFrequency
 		response.ScheduledAt = &t.ScheduledAt
+		response.ScheduledInterval = &t.ScheduledInterval
+		response.ScheduledNextBusinessDay = &t.ScheduledNextBusinessDay
+		response.ScheduledLastRunTime = &t.ScheduledLastRunTime
+		response.ScheduledNextRunTime = &t.ScheduledNextRunTime
+		response.AmountExpression = &t.AmountExpression
+		response.ReminderDays = &t.ReminderDays
+		response.ReminderDigest = &t.ReminderDigest
 
 		templateTimeZone := time.FixedZone("Template Timezone", int(t.ScheduledTimezoneUtcOffset)*60)
 
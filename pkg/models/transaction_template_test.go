@@ -3,8 +3,11 @@ package models
 import (
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
 )
 
 func TestTransactionTemplateGetTagIds(t *testing.T) {
@@ -43,3 +46,270 @@ func TestTransactionTemplateInfoResponseSliceLess(t *testing.T) {
 	assert.Equal(t, int64(3), transactionTemplateRespSlice[1].Id)
 	assert.Equal(t, int64(1), transactionTemplateRespSlice[2].Id)
 }
+
+func TestTransactionTemplateIsScheduledFrequencyValid(t *testing.T) {
+	startTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).Unix()
+
+	assert.True(t, (&TransactionTemplate{ScheduledFrequencyType: TRANSACTION_SCHEDULE_FREQUENCY_TYPE_DISABLED}).IsScheduledFrequencyValid())
+	assert.True(t, (&TransactionTemplate{ScheduledFrequencyType: TRANSACTION_SCHEDULE_FREQUENCY_TYPE_WEEKLY, ScheduledFrequency: "0,6"}).IsScheduledFrequencyValid())
+	assert.True(t, (&TransactionTemplate{ScheduledFrequencyType: TRANSACTION_SCHEDULE_FREQUENCY_TYPE_MONTHLY, ScheduledFrequency: "1,15,28"}).IsScheduledFrequencyValid())
+	assert.True(t, (&TransactionTemplate{ScheduledFrequencyType: TRANSACTION_SCHEDULE_FREQUENCY_TYPE_DAILY}).IsScheduledFrequencyValid())
+	assert.True(t, (&TransactionTemplate{ScheduledFrequencyType: TRANSACTION_SCHEDULE_FREQUENCY_TYPE_EVERY_N_DAYS, ScheduledInterval: 3, ScheduledStartTime: &startTime}).IsScheduledFrequencyValid())
+	assert.True(t, (&TransactionTemplate{ScheduledFrequencyType: TRANSACTION_SCHEDULE_FREQUENCY_TYPE_EVERY_N_WEEKS, ScheduledFrequency: "1", ScheduledInterval: 2, ScheduledStartTime: &startTime}).IsScheduledFrequencyValid())
+	assert.True(t, (&TransactionTemplate{ScheduledFrequencyType: TRANSACTION_SCHEDULE_FREQUENCY_TYPE_QUARTERLY, ScheduledFrequency: "15"}).IsScheduledFrequencyValid())
+	assert.True(t, (&TransactionTemplate{ScheduledFrequencyType: TRANSACTION_SCHEDULE_FREQUENCY_TYPE_YEARLY, ScheduledFrequency: "229,1231"}).IsScheduledFrequencyValid())
+	assert.True(t, (&TransactionTemplate{ScheduledFrequencyType: TRANSACTION_SCHEDULE_FREQUENCY_TYPE_LAST_DAY_OF_MONTH}).IsScheduledFrequencyValid())
+
+	assert.False(t, (&TransactionTemplate{ScheduledFrequencyType: TRANSACTION_SCHEDULE_FREQUENCY_TYPE_DISABLED, ScheduledFrequency: "1"}).IsScheduledFrequencyValid())
+	assert.False(t, (&TransactionTemplate{ScheduledFrequencyType: TRANSACTION_SCHEDULE_FREQUENCY_TYPE_WEEKLY}).IsScheduledFrequencyValid())
+	assert.False(t, (&TransactionTemplate{ScheduledFrequencyType: TRANSACTION_SCHEDULE_FREQUENCY_TYPE_WEEKLY, ScheduledFrequency: "7"}).IsScheduledFrequencyValid())
+	assert.False(t, (&TransactionTemplate{ScheduledFrequencyType: TRANSACTION_SCHEDULE_FREQUENCY_TYPE_MONTHLY, ScheduledFrequency: "a"}).IsScheduledFrequencyValid())
+	assert.False(t, (&TransactionTemplate{ScheduledFrequencyType: TRANSACTION_SCHEDULE_FREQUENCY_TYPE_DAILY, ScheduledFrequency: "1"}).IsScheduledFrequencyValid())
+	assert.False(t, (&TransactionTemplate{ScheduledFrequencyType: TRANSACTION_SCHEDULE_FREQUENCY_TYPE_EVERY_N_DAYS, ScheduledInterval: 3}).IsScheduledFrequencyValid())
+	assert.False(t, (&TransactionTemplate{ScheduledFrequencyType: TRANSACTION_SCHEDULE_FREQUENCY_TYPE_EVERY_N_DAYS, ScheduledStartTime: &startTime}).IsScheduledFrequencyValid())
+	assert.False(t, (&TransactionTemplate{ScheduledFrequencyType: TRANSACTION_SCHEDULE_FREQUENCY_TYPE_EVERY_N_WEEKS, ScheduledInterval: 2, ScheduledStartTime: &startTime}).IsScheduledFrequencyValid())
+	assert.False(t, (&TransactionTemplate{ScheduledFrequencyType: TRANSACTION_SCHEDULE_FREQUENCY_TYPE_YEARLY, ScheduledFrequency: "230"}).IsScheduledFrequencyValid())
+	assert.False(t, (&TransactionTemplate{ScheduledFrequencyType: TRANSACTION_SCHEDULE_FREQUENCY_TYPE_YEARLY, ScheduledFrequency: "1301"}).IsScheduledFrequencyValid())
+	assert.False(t, (&TransactionTemplate{ScheduledFrequencyType: 100}).IsScheduledFrequencyValid())
+}
+
+func TestTransactionTemplateIsScheduledDate_WeeklyAndMonthly(t *testing.T) {
+	template := &TransactionTemplate{
+		ScheduledFrequencyType: TRANSACTION_SCHEDULE_FREQUENCY_TYPE_WEEKLY,
+		ScheduledFrequency:     "1,5",
+	}
+
+	assert.True(t, template.IsScheduledDate(time.Date(2024, 9, 2, 8, 0, 0, 0, time.UTC)))
+	assert.False(t, template.IsScheduledDate(time.Date(2024, 9, 3, 8, 0, 0, 0, time.UTC)))
+	assert.True(t, template.IsScheduledDate(time.Date(2024, 9, 6, 8, 0, 0, 0, time.UTC)))
+
+	template = &TransactionTemplate{
+		ScheduledFrequencyType: TRANSACTION_SCHEDULE_FREQUENCY_TYPE_MONTHLY,
+		ScheduledFrequency:     "10",
+	}
+
+	assert.True(t, template.IsScheduledDate(time.Date(2024, 9, 10, 8, 0, 0, 0, time.UTC)))
+	assert.False(t, template.IsScheduledDate(time.Date(2024, 9, 11, 8, 0, 0, 0, time.UTC)))
+}
+
+func TestTransactionTemplateIsScheduledDate_Daily(t *testing.T) {
+	template := &TransactionTemplate{
+		ScheduledFrequencyType: TRANSACTION_SCHEDULE_FREQUENCY_TYPE_DAILY,
+	}
+
+	assert.True(t, template.IsScheduledDate(time.Date(2024, 9, 1, 8, 0, 0, 0, time.UTC)))
+	assert.True(t, template.IsScheduledDate(time.Date(2024, 9, 2, 8, 0, 0, 0, time.UTC)))
+}
+
+func TestTransactionTemplateIsScheduledDate_EveryNDays(t *testing.T) {
+	timezone := time.FixedZone("Template Timezone", 480*60)
+	startTime := time.Date(2024, 9, 1, 0, 0, 0, 0, timezone).Unix()
+	template := &TransactionTemplate{
+		ScheduledFrequencyType:     TRANSACTION_SCHEDULE_FREQUENCY_TYPE_EVERY_N_DAYS,
+		ScheduledInterval:          3,
+		ScheduledStartTime:         &startTime,
+		ScheduledTimezoneUtcOffset: 480,
+	}
+
+	assert.False(t, template.IsScheduledDate(time.Date(2024, 8, 29, 8, 0, 0, 0, timezone)))
+	assert.True(t, template.IsScheduledDate(time.Date(2024, 9, 1, 8, 0, 0, 0, timezone)))
+	assert.False(t, template.IsScheduledDate(time.Date(2024, 9, 2, 8, 0, 0, 0, timezone)))
+	assert.False(t, template.IsScheduledDate(time.Date(2024, 9, 3, 8, 0, 0, 0, timezone)))
+	assert.True(t, template.IsScheduledDate(time.Date(2024, 9, 4, 8, 0, 0, 0, timezone)))
+	assert.True(t, template.IsScheduledDate(time.Date(2024, 10, 1, 8, 0, 0, 0, timezone)))
+}
+
+func TestTransactionTemplateIsScheduledDate_EveryNWeeks(t *testing.T) {
+	startTime := time.Date(2024, 9, 4, 0, 0, 0, 0, time.UTC).Unix()
+	template := &TransactionTemplate{
+		ScheduledFrequencyType: TRANSACTION_SCHEDULE_FREQUENCY_TYPE_EVERY_N_WEEKS,
+		ScheduledFrequency:     "1,5",
+		ScheduledInterval:      2,
+		ScheduledStartTime:     &startTime,
+	}
+
+	assert.True(t, template.IsScheduledDate(time.Date(2024, 9, 2, 8, 0, 0, 0, time.UTC)))
+	assert.True(t, template.IsScheduledDate(time.Date(2024, 9, 6, 8, 0, 0, 0, time.UTC)))
+	assert.False(t, template.IsScheduledDate(time.Date(2024, 9, 9, 8, 0, 0, 0, time.UTC)))
+	assert.False(t, template.IsScheduledDate(time.Date(2024, 9, 13, 8, 0, 0, 0, time.UTC)))
+	assert.True(t, template.IsScheduledDate(time.Date(2024, 9, 16, 8, 0, 0, 0, time.UTC)))
+	assert.False(t, template.IsScheduledDate(time.Date(2024, 9, 17, 8, 0, 0, 0, time.UTC)))
+}
+
+func TestTransactionTemplateIsScheduledDate_EveryNWeeksWithFirstDayOfWeek(t *testing.T) {
+	// 2024-09-08 is Sunday, which is in the same week of the start date if the week starts on Sunday,
+	// and is in the week before the start date if the week starts on Monday
+	startTime := time.Date(2024, 9, 9, 0, 0, 0, 0, time.UTC).Unix()
+	template := &TransactionTemplate{
+		ScheduledFrequencyType: TRANSACTION_SCHEDULE_FREQUENCY_TYPE_EVERY_N_WEEKS,
+		ScheduledFrequency:     "0,1",
+		ScheduledInterval:      2,
+		ScheduledStartTime:     &startTime,
+	}
+
+	assert.True(t, template.IsScheduledDate(time.Date(2024, 9, 8, 8, 0, 0, 0, time.UTC)))
+	assert.True(t, template.IsScheduledDate(time.Date(2024, 9, 9, 8, 0, 0, 0, time.UTC)))
+	assert.False(t, template.IsScheduledDate(time.Date(2024, 9, 15, 8, 0, 0, 0, time.UTC)))
+	assert.False(t, template.IsScheduledDate(time.Date(2024, 9, 16, 8, 0, 0, 0, time.UTC)))
+	assert.True(t, template.IsScheduledDate(time.Date(2024, 9, 22, 8, 0, 0, 0, time.UTC)))
+	assert.True(t, template.IsScheduledDate(time.Date(2024, 9, 23, 8, 0, 0, 0, time.UTC)))
+
+	template.ScheduledFirstDayOfWeek = core.WEEKDAY_MONDAY
+
+	assert.False(t, template.IsScheduledDate(time.Date(2024, 9, 8, 8, 0, 0, 0, time.UTC)))
+	assert.True(t, template.IsScheduledDate(time.Date(2024, 9, 9, 8, 0, 0, 0, time.UTC)))
+	assert.True(t, template.IsScheduledDate(time.Date(2024, 9, 15, 8, 0, 0, 0, time.UTC)))
+	assert.False(t, template.IsScheduledDate(time.Date(2024, 9, 16, 8, 0, 0, 0, time.UTC)))
+	assert.False(t, template.IsScheduledDate(time.Date(2024, 9, 22, 8, 0, 0, 0, time.UTC)))
+	assert.True(t, template.IsScheduledDate(time.Date(2024, 9, 23, 8, 0, 0, 0, time.UTC)))
+}
+
+func TestTransactionTemplateIsScheduledDate_Quarterly(t *testing.T) {
+	template := &TransactionTemplate{
+		ScheduledFrequencyType: TRANSACTION_SCHEDULE_FREQUENCY_TYPE_QUARTERLY,
+		ScheduledFrequency:     "15",
+	}
+
+	assert.True(t, template.IsScheduledDate(time.Date(2024, 1, 15, 8, 0, 0, 0, time.UTC)))
+	assert.False(t, template.IsScheduledDate(time.Date(2024, 2, 15, 8, 0, 0, 0, time.UTC)))
+	assert.True(t, template.IsScheduledDate(time.Date(2024, 4, 15, 8, 0, 0, 0, time.UTC)))
+	assert.True(t, template.IsScheduledDate(time.Date(2024, 10, 15, 8, 0, 0, 0, time.UTC)))
+
+	startTime := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC).Unix()
+	template.ScheduledStartTime = &startTime
+
+	assert.False(t, template.IsScheduledDate(time.Date(2024, 1, 15, 8, 0, 0, 0, time.UTC)))
+	assert.True(t, template.IsScheduledDate(time.Date(2024, 2, 15, 8, 0, 0, 0, time.UTC)))
+	assert.True(t, template.IsScheduledDate(time.Date(2024, 11, 15, 8, 0, 0, 0, time.UTC)))
+}
+
+func TestTransactionTemplateIsScheduledDate_Yearly(t *testing.T) {
+	template := &TransactionTemplate{
+		ScheduledFrequencyType: TRANSACTION_SCHEDULE_FREQUENCY_TYPE_YEARLY,
+		ScheduledFrequency:     "229,1225",
+	}
+
+	assert.True(t, template.IsScheduledDate(time.Date(2024, 2, 29, 8, 0, 0, 0, time.UTC)))
+	assert.True(t, template.IsScheduledDate(time.Date(2024, 12, 25, 8, 0, 0, 0, time.UTC)))
+	assert.False(t, template.IsScheduledDate(time.Date(2024, 12, 24, 8, 0, 0, 0, time.UTC)))
+	assert.False(t, template.IsScheduledDate(time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC)))
+}
+
+func TestTransactionTemplateIsScheduledDate_LastDayOfMonth(t *testing.T) {
+	template := &TransactionTemplate{
+		ScheduledFrequencyType: TRANSACTION_SCHEDULE_FREQUENCY_TYPE_LAST_DAY_OF_MONTH,
+	}
+
+	assert.True(t, template.IsScheduledDate(time.Date(2024, 2, 29, 8, 0, 0, 0, time.UTC)))
+	assert.False(t, template.IsScheduledDate(time.Date(2024, 2, 28, 8, 0, 0, 0, time.UTC)))
+	assert.True(t, template.IsScheduledDate(time.Date(2025, 2, 28, 8, 0, 0, 0, time.UTC)))
+	assert.True(t, template.IsScheduledDate(time.Date(2024, 4, 30, 8, 0, 0, 0, time.UTC)))
+	assert.True(t, template.IsScheduledDate(time.Date(2024, 12, 31, 8, 0, 0, 0, time.UTC)))
+}
+
+func TestTransactionTemplateIsScheduledDate_NextBusinessDay(t *testing.T) {
+	template := &TransactionTemplate{
+		ScheduledFrequencyType:   TRANSACTION_SCHEDULE_FREQUENCY_TYPE_MONTHLY,
+		ScheduledFrequency:       "14,15",
+		ScheduledNextBusinessDay: true,
+	}
+
+	// 2024-09-14 is Saturday and 2024-09-15 is Sunday
+	assert.False(t, template.IsScheduledDate(time.Date(2024, 9, 14, 8, 0, 0, 0, time.UTC)))
+	assert.False(t, template.IsScheduledDate(time.Date(2024, 9, 15, 8, 0, 0, 0, time.UTC)))
+	assert.True(t, template.IsScheduledDate(time.Date(2024, 9, 16, 8, 0, 0, 0, time.UTC)))
+	assert.False(t, template.IsScheduledDate(time.Date(2024, 9, 17, 8, 0, 0, 0, time.UTC)))
+
+	// 2024-10-14 is Monday and 2024-10-15 is Tuesday
+	assert.True(t, template.IsScheduledDate(time.Date(2024, 10, 14, 8, 0, 0, 0, time.UTC)))
+	assert.True(t, template.IsScheduledDate(time.Date(2024, 10, 15, 8, 0, 0, 0, time.UTC)))
+}
+
+func TestTransactionTemplateGetScheduledTransactionTimes(t *testing.T) {
+	timezone := time.FixedZone("Template Timezone", 480*60)
+	endTime := time.Date(2024, 9, 10, 23, 59, 59, 0, timezone).Unix()
+	template := &TransactionTemplate{
+		TemplateType:               TRANSACTION_TEMPLATE_TYPE_SCHEDULE,
+		ScheduledFrequencyType:     TRANSACTION_SCHEDULE_FREQUENCY_TYPE_WEEKLY,
+		ScheduledFrequency:         "1,3",
+		ScheduledAt:                960,
+		ScheduledTimezoneUtcOffset: 480,
+		ScheduledEndTime:           &endTime,
+	}
+
+	actualTimes := template.GetScheduledTransactionTimes(time.Date(2024, 9, 2, 0, 0, 0, 0, timezone).Unix(), time.Date(2024, 9, 30, 0, 0, 0, 0, timezone).Unix())
+	expectedTimes := []int64{
+		time.Date(2024, 9, 2, 0, 0, 0, 0, timezone).Unix(),
+		time.Date(2024, 9, 4, 0, 0, 0, 0, timezone).Unix(),
+		time.Date(2024, 9, 9, 0, 0, 0, 0, timezone).Unix(),
+	}
+	assert.Equal(t, expectedTimes, actualTimes)
+
+	actualTimes = template.GetScheduledTransactionTimes(time.Date(2024, 9, 2, 0, 0, 1, 0, timezone).Unix(), time.Date(2024, 9, 4, 0, 0, 0, 0, timezone).Unix())
+	expectedTimes = []int64{
+		time.Date(2024, 9, 4, 0, 0, 0, 0, timezone).Unix(),
+	}
+	assert.Equal(t, expectedTimes, actualTimes)
+}
+
+func TestTransactionTemplateGetScheduledTransactionTimes_DisabledOrNormalTemplate(t *testing.T) {
+	template := &TransactionTemplate{
+		TemplateType:           TRANSACTION_TEMPLATE_TYPE_SCHEDULE,
+		ScheduledFrequencyType: TRANSACTION_SCHEDULE_FREQUENCY_TYPE_DISABLED,
+	}
+	assert.Empty(t, template.GetScheduledTransactionTimes(0, 86400*30))
+
+	template = &TransactionTemplate{
+		TemplateType:           TRANSACTION_TEMPLATE_TYPE_NORMAL,
+		ScheduledFrequencyType: TRANSACTION_SCHEDULE_FREQUENCY_TYPE_DAILY,
+	}
+	assert.Empty(t, template.GetScheduledTransactionTimes(0, 86400*30))
+}
+
+func TestTransactionTemplateGetNextScheduledTransactionTime(t *testing.T) {
+	template := &TransactionTemplate{
+		TemplateType:           TRANSACTION_TEMPLATE_TYPE_SCHEDULE,
+		ScheduledFrequencyType: TRANSACTION_SCHEDULE_FREQUENCY_TYPE_YEARLY,
+		ScheduledFrequency:     "229",
+	}
+
+	assert.Equal(t, time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC).Unix(), template.GetNextScheduledTransactionTime(time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC).Unix()))
+	assert.Equal(t, time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC).Unix(), template.GetNextScheduledTransactionTime(time.Date(2024, 2, 28, 23, 59, 59, 0, time.UTC).Unix()))
+
+	endTime := time.Date(2027, 12, 31, 23, 59, 59, 0, time.UTC).Unix()
+	template.ScheduledEndTime = &endTime
+	assert.Equal(t, int64(0), template.GetNextScheduledTransactionTime(time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC).Unix()))
+}
+
+func TestTransactionTemplateIsAmountExpressionVariableSupported(t *testing.T) {
+	expenseTemplate := &TransactionTemplate{Type: TRANSACTION_TYPE_EXPENSE}
+	assert.True(t, expenseTemplate.IsAmountExpressionVariableSupported("balance"))
+	assert.True(t, expenseTemplate.IsAmountExpressionVariableSupported("amount"))
+	assert.True(t, expenseTemplate.IsAmountExpressionVariableSupported("last_month_income"))
+	assert.False(t, expenseTemplate.IsAmountExpressionVariableSupported("destination_balance"))
+	assert.False(t, expenseTemplate.IsAmountExpressionVariableSupported("unknown"))
+
+	transferTemplate := &TransactionTemplate{Type: TRANSACTION_TYPE_TRANSFER}
+	assert.True(t, transferTemplate.IsAmountExpressionVariableSupported("destination_balance"))
+	assert.True(t, transferTemplate.IsAmountExpressionVariableSupported("this_month_expense"))
+}
+
+func TestTransactionTemplateGetReminderScheduledTransactionTime(t *testing.T) {
+	timezone := time.FixedZone("Template Timezone", 480*60)
+	template := &TransactionTemplate{
+		TemplateType:               TRANSACTION_TEMPLATE_TYPE_SCHEDULE,
+		ScheduledFrequencyType:     TRANSACTION_SCHEDULE_FREQUENCY_TYPE_MONTHLY,
+		ScheduledFrequency:         "15",
+		ScheduledAt:                960,
+		ScheduledTimezoneUtcOffset: 480,
+		ReminderDays:               3,
+	}
+
+	assert.Equal(t, time.Date(2024, 9, 15, 0, 0, 0, 0, timezone).Unix(), template.GetReminderScheduledTransactionTime(time.Date(2024, 9, 12, 8, 0, 0, 0, timezone).Unix()))
+	assert.Equal(t, time.Date(2024, 9, 15, 0, 0, 0, 0, timezone).Unix(), template.GetReminderScheduledTransactionTime(time.Date(2024, 9, 12, 23, 59, 59, 0, timezone).Unix()))
+	assert.Equal(t, int64(0), template.GetReminderScheduledTransactionTime(time.Date(2024, 9, 11, 8, 0, 0, 0, timezone).Unix()))
+	assert.Equal(t, int64(0), template.GetReminderScheduledTransactionTime(time.Date(2024, 9, 13, 8, 0, 0, 0, timezone).Unix()))
+
+	template.ReminderDays = 0
+	assert.Equal(t, int64(0), template.GetReminderScheduledTransactionTime(time.Date(2024, 9, 12, 8, 0, 0, 0, timezone).Unix()))
+}
@@ -0,0 +1,91 @@
+package models
+
+// DEFAULT_CURRENCY_PRECISION represents the decimal precision of the currencies in ISO 4217 (amounts are stored in cents)
+const DEFAULT_CURRENCY_PRECISION = 2
+
+// MAX_CURRENCY_PRECISION represents the maximum decimal precision of user defined currencies
+const MAX_CURRENCY_PRECISION = 8
+
+// UserCustomCurrency represents user defined currency data stored in database
+type UserCustomCurrency struct {
+	CurrencyId      int64  `xorm:"PK"`
+	Uid             int64  `xorm:"INDEX(IDX_user_custom_currency_uid_deleted_code) NOT NULL"`
+	Deleted         bool   `xorm:"INDEX(IDX_user_custom_currency_uid_deleted_code) NOT NULL"`
+	Code            string `xorm:"INDEX(IDX_user_custom_currency_uid_deleted_code) VARCHAR(3) NOT NULL"`
+	Name            string `xorm:"VARCHAR(64) NOT NULL"`
+	Symbol          string `xorm:"VARCHAR(16) NOT NULL"`
+	Precision       int32  `xorm:"NOT NULL"`
+	CreatedUnixTime int64
+	UpdatedUnixTime int64
+	DeletedUnixTime int64
+}
+
+// UserCustomCurrencyGetRequest represents all parameters of user defined currency getting request
+type UserCustomCurrencyGetRequest struct {
+	Id int64 `form:"id,string" binding:"required,min=1"`
+}
+
+// UserCustomCurrencyCreateRequest represents all parameters of user defined currency creation request
+type UserCustomCurrencyCreateRequest struct {
+	Code      string `json:"code" binding:"required,len=3,validCustomCurrencyCode"`
+	Name      string `json:"name" binding:"required,notBlank,max=64"`
+	Symbol    string `json:"symbol" binding:"max=16"`
+	Precision int32  `json:"precision" binding:"min=0,max=8"`
+}
+
+// UserCustomCurrencyModifyRequest represents all parameters of user defined currency modification request
+type UserCustomCurrencyModifyRequest struct {
+	Id     int64  `json:"id,string" binding:"required,min=1"`
+	Name   string `json:"name" binding:"required,notBlank,max=64"`
+	Symbol string `json:"symbol" binding:"max=16"`
+}
+
+// UserCustomCurrencyDeleteRequest represents all parameters of user defined currency deleting request
+type UserCustomCurrencyDeleteRequest struct {
+	Id int64 `json:"id,string" binding:"required,min=1"`
+}
+
+// UserCustomCurrencyInfoResponse represents a view-object of user defined currency
+type UserCustomCurrencyInfoResponse struct {
+	Id        int64  `json:"id,string"`
+	Code      string `json:"code"`
+	Name      string `json:"name"`
+	Symbol    string `json:"symbol"`
+	Precision int32  `json:"precision"`
+}
+
+// ToUserCustomCurrencyInfoResponse returns a view-object according to database model
+func (c *UserCustomCurrency) ToUserCustomCurrencyInfoResponse() *UserCustomCurrencyInfoResponse {
+	return &UserCustomCurrencyInfoResponse{
+		Id:        c.CurrencyId,
+		Code:      c.Code,
+		Name:      c.Name,
+		Symbol:    c.Symbol,
+		Precision: c.Precision,
+	}
+}
+
+// UserCustomCurrencies represents all the user defined currencies of a user
+type UserCustomCurrencies []*UserCustomCurrency
+
+// GetCurrencyByCode returns the user defined currency of the specified code, or nil if it does not exist
+func (s UserCustomCurrencies) GetCurrencyByCode(code string) *UserCustomCurrency {
+	for i := 0; i < len(s); i++ {
+		if s[i].Code == code {
+			return s[i]
+		}
+	}
+
+	return nil
+}
+
+// ToUserCustomCurrencyInfoResponses returns the view-objects according to database models
+func (s UserCustomCurrencies) ToUserCustomCurrencyInfoResponses() []*UserCustomCurrencyInfoResponse {
+	currencyResps := make([]*UserCustomCurrencyInfoResponse, len(s))
+
+	for i := 0; i < len(s); i++ {
+		currencyResps[i] = s[i].ToUserCustomCurrencyInfoResponse()
+	}
+
+	return currencyResps
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserCustomCurrenciesGetCurrencyByCode(t *testing.T) {
+	currencies := UserCustomCurrencies{
+		{CurrencyId: 1, Code: "BTC", Precision: 8},
+		{CurrencyId: 2, Code: "PTS", Precision: 0},
+	}
+
+	currency := currencies.GetCurrencyByCode("PTS")
+	assert.NotNil(t, currency)
+	assert.Equal(t, int64(2), currency.CurrencyId)
+
+	currency = currencies.GetCurrencyByCode("ETH")
+	assert.Nil(t, currency)
+}
+
+func TestAccountGetCurrencyPrecision(t *testing.T) {
+	account := &Account{Currency: "USD"}
+	assert.Equal(t, DEFAULT_CURRENCY_PRECISION, account.GetCurrencyPrecision())
+
+	account.Extend = &AccountExtend{}
+	assert.Equal(t, DEFAULT_CURRENCY_PRECISION, account.GetCurrencyPrecision())
+
+	precision := 8
+	account.Currency = "BTC"
+	account.Extend.CurrencyPrecision = &precision
+	assert.Equal(t, 8, account.GetCurrencyPrecision())
+	assert.Equal(t, 8, *account.ToAccountInfoResponse().CurrencyPrecision)
+}
@@ -0,0 +1,200 @@
+package models
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+// UserExchangeRateDataSource is the data source name of the exchange rates which are defined by user
+const UserExchangeRateDataSource = "user_defined"
+
+// UserExchangeRate represents user defined exchange rate data stored in database
+type UserExchangeRate struct {
+	ExchangeRateId  int64  `xorm:"PK"`
+	Uid             int64  `xorm:"INDEX(IDX_user_exchange_rate_uid_deleted_start_date) NOT NULL"`
+	Deleted         bool   `xorm:"INDEX(IDX_user_exchange_rate_uid_deleted_start_date) NOT NULL"`
+	BaseCurrency    string `xorm:"VARCHAR(3) NOT NULL"`
+	TargetCurrency  string `xorm:"VARCHAR(3) NOT NULL"`
+	Rate            string `xorm:"VARCHAR(32) NOT NULL"`
+	StartDate       int32  `xorm:"INDEX(IDX_user_exchange_rate_uid_deleted_start_date) NOT NULL"`
+	EndDate         int32  `xorm:"NOT NULL"`
+	Comment         string `xorm:"VARCHAR(255) NOT NULL"`
+	CreatedUnixTime int64
+	UpdatedUnixTime int64
+	DeletedUnixTime int64
+}
+
+// UserExchangeRateGetRequest represents all parameters of user defined exchange rate getting request
+type UserExchangeRateGetRequest struct {
+	Id int64 `form:"id,string" binding:"required,min=1"`
+}
+
+// UserExchangeRateCreateRequest represents all parameters of user defined exchange rate creation request
+type UserExchangeRateCreateRequest struct {
+	BaseCurrency   string `json:"baseCurrency" binding:"required,len=3,validCurrencyCode"`
+	TargetCurrency string `json:"targetCurrency" binding:"required,len=3,validCurrencyCode"`
+	Rate           string `json:"rate" binding:"required,max=32"`
+	StartDate      string `json:"startDate" binding:"required"`
+	EndDate        string `json:"endDate"`
+	Comment        string `json:"comment" binding:"max=255"`
+}
+
+// UserExchangeRateModifyRequest represents all parameters of user defined exchange rate modification request
+type UserExchangeRateModifyRequest struct {
+	Id             int64  `json:"id,string" binding:"required,min=1"`
+	BaseCurrency   string `json:"baseCurrency" binding:"required,len=3,validCurrencyCode"`
+	TargetCurrency string `json:"targetCurrency" binding:"required,len=3,validCurrencyCode"`
+	Rate           string `json:"rate" binding:"required,max=32"`
+	StartDate      string `json:"startDate" binding:"required"`
+	EndDate        string `json:"endDate"`
+	Comment        string `json:"comment" binding:"max=255"`
+}
+
+// UserExchangeRateDeleteRequest represents all parameters of user defined exchange rate deleting request
+type UserExchangeRateDeleteRequest struct {
+	Id int64 `json:"id,string" binding:"required,min=1"`
+}
+
+// UserExchangeRateInfoResponse represents a view-object of user defined exchange rate
+type UserExchangeRateInfoResponse struct {
+	Id             int64  `json:"id,string"`
+	BaseCurrency   string `json:"baseCurrency"`
+	TargetCurrency string `json:"targetCurrency"`
+	Rate           string `json:"rate"`
+	StartDate      string `json:"startDate"`
+	EndDate        string `json:"endDate,omitempty"`
+	Comment        string `json:"comment"`
+}
+
+// IsEffectiveOn returns whether the user defined exchange rate is effective on the specified date (YYYYMMDD)
+func (r *UserExchangeRate) IsEffectiveOn(date int32) bool {
+	return r.StartDate <= date && (r.EndDate <= 0 || date <= r.EndDate)
+}
+
+// ToUserExchangeRateInfoResponse returns a view-object according to database model
+func (r *UserExchangeRate) ToUserExchangeRateInfoResponse() *UserExchangeRateInfoResponse {
+	endDate := ""
+
+	if r.EndDate > 0 {
+		endDate = utils.FormatNumericDateToLongDate(r.EndDate)
+	}
+
+	return &UserExchangeRateInfoResponse{
+		Id:             r.ExchangeRateId,
+		BaseCurrency:   r.BaseCurrency,
+		TargetCurrency: r.TargetCurrency,
+		Rate:           r.Rate,
+		StartDate:      utils.FormatNumericDateToLongDate(r.StartDate),
+		EndDate:        endDate,
+		Comment:        r.Comment,
+	}
+}
+
+// UserExchangeRates represents all the user defined exchange rates of a user
+type UserExchangeRates []*UserExchangeRate
+
+// GetEffectiveExchangeRates returns the user defined exchange rates which are effective on the specified date (YYYYMMDD)
+func (s UserExchangeRates) GetEffectiveExchangeRates(date int32) UserExchangeRates {
+	var effectiveExchangeRates UserExchangeRates
+
+	for i := 0; i < len(s); i++ {
+		if s[i].IsEffectiveOn(date) {
+			effectiveExchangeRates = append(effectiveExchangeRates, s[i])
+		}
+	}
+
+	return effectiveExchangeRates
+}
+
+// ToUserExchangeRateInfoResponses returns the view-objects according to database models
+func (s UserExchangeRates) ToUserExchangeRateInfoResponses() []*UserExchangeRateInfoResponse {
+	exchangeRateResps := make([]*UserExchangeRateInfoResponse, len(s))
+
+	for i := 0; i < len(s); i++ {
+		exchangeRateResps[i] = s[i].ToUserExchangeRateInfoResponse()
+	}
+
+	return exchangeRateResps
+}
+
+// WithUserExchangeRates returns a new exchange rates map which the user defined exchange rates are merged over,
+// the latter user defined exchange rate overrides the former one if they set the same currency
+func (m ExchangeRatesMap) WithUserExchangeRates(userExchangeRates UserExchangeRates) ExchangeRatesMap {
+	exchangeRates, _ := m.mergeUserExchangeRates(userExchangeRates)
+	return exchangeRates
+}
+
+func (m ExchangeRatesMap) mergeUserExchangeRates(userExchangeRates UserExchangeRates) (ExchangeRatesMap, map[string]bool) {
+	exchangeRates := make(ExchangeRatesMap, len(m)+len(userExchangeRates))
+	overriddenCurrencies := make(map[string]bool, len(userExchangeRates))
+
+	for currency, rate := range m {
+		exchangeRates[currency] = rate
+	}
+
+	for i := 0; i < len(userExchangeRates); i++ {
+		userExchangeRate := userExchangeRates[i]
+		rate, err := strconv.ParseFloat(userExchangeRate.Rate, 64)
+
+		if err != nil || rate <= 0 || userExchangeRate.BaseCurrency == userExchangeRate.TargetCurrency {
+			continue
+		}
+
+		if baseCurrencyRate, exists := exchangeRates[userExchangeRate.BaseCurrency]; exists {
+			exchangeRates[userExchangeRate.TargetCurrency] = baseCurrencyRate * rate
+			overriddenCurrencies[userExchangeRate.TargetCurrency] = true
+		} else if targetCurrencyRate, exists := exchangeRates[userExchangeRate.TargetCurrency]; exists {
+			exchangeRates[userExchangeRate.BaseCurrency] = targetCurrencyRate / rate
+			overriddenCurrencies[userExchangeRate.BaseCurrency] = true
+		} else if len(exchangeRates) < 1 {
+			exchangeRates[userExchangeRate.BaseCurrency] = 1
+			exchangeRates[userExchangeRate.TargetCurrency] = rate
+			overriddenCurrencies[userExchangeRate.BaseCurrency] = true
+			overriddenCurrencies[userExchangeRate.TargetCurrency] = true
+		}
+	}
+
+	return exchangeRates, overriddenCurrencies
+}
+
+// WithUserExchangeRates returns a new latest exchange rate response which the user defined exchange rates are merged over,
+// the exchange rates set by user are marked with the user defined data source
+func (r *LatestExchangeRateResponse) WithUserExchangeRates(userExchangeRates UserExchangeRates) *LatestExchangeRateResponse {
+	if len(userExchangeRates) < 1 {
+		return r
+	}
+
+	exchangeRates, overriddenCurrencies := r.ToExchangeRatesMap().mergeUserExchangeRates(userExchangeRates)
+
+	if len(overriddenCurrencies) < 1 {
+		return r
+	}
+
+	allExchangeRates := make(LatestExchangeRateSlice, 0, len(exchangeRates))
+
+	for i := 0; i < len(r.ExchangeRates); i++ {
+		if !overriddenCurrencies[r.ExchangeRates[i].Currency] {
+			allExchangeRates = append(allExchangeRates, r.ExchangeRates[i])
+		}
+	}
+
+	for currency := range overriddenCurrencies {
+		allExchangeRates = append(allExchangeRates, &LatestExchangeRate{
+			Currency:   currency,
+			Rate:       utils.Float64ToString(exchangeRates[currency]),
+			DataSource: UserExchangeRateDataSource,
+		})
+	}
+
+	sort.Sort(allExchangeRates)
+
+	return &LatestExchangeRateResponse{
+		DataSource:    r.DataSource,
+		ReferenceUrl:  r.ReferenceUrl,
+		UpdateTime:    r.UpdateTime,
+		BaseCurrency:  r.BaseCurrency,
+		ExchangeRates: allExchangeRates,
+	}
+}
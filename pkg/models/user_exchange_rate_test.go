@@ -0,0 +1,155 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserExchangeRateIsEffectiveOn(t *testing.T) {
+	exchangeRate := &UserExchangeRate{
+		StartDate: 20240501,
+		EndDate:   20240510,
+	}
+
+	assert.False(t, exchangeRate.IsEffectiveOn(20240430))
+	assert.True(t, exchangeRate.IsEffectiveOn(20240501))
+	assert.True(t, exchangeRate.IsEffectiveOn(20240510))
+	assert.False(t, exchangeRate.IsEffectiveOn(20240511))
+
+	exchangeRate.EndDate = 0
+
+	assert.False(t, exchangeRate.IsEffectiveOn(20240430))
+	assert.True(t, exchangeRate.IsEffectiveOn(20991231))
+}
+
+func TestUserExchangeRateToUserExchangeRateInfoResponse(t *testing.T) {
+	exchangeRate := &UserExchangeRate{
+		ExchangeRateId: 1,
+		BaseCurrency:   "USD",
+		TargetCurrency: "THB",
+		Rate:           "35.5",
+		StartDate:      20240501,
+		Comment:        "Trip",
+	}
+
+	actualResponse := exchangeRate.ToUserExchangeRateInfoResponse()
+	assert.Equal(t, int64(1), actualResponse.Id)
+	assert.Equal(t, "2024-05-01", actualResponse.StartDate)
+	assert.Equal(t, "", actualResponse.EndDate)
+
+	exchangeRate.EndDate = 20240510
+
+	actualResponse = exchangeRate.ToUserExchangeRateInfoResponse()
+	assert.Equal(t, "2024-05-10", actualResponse.EndDate)
+}
+
+func TestUserExchangeRatesGetEffectiveExchangeRates(t *testing.T) {
+	exchangeRates := UserExchangeRates{
+		{ExchangeRateId: 1, StartDate: 20240101},
+		{ExchangeRateId: 2, StartDate: 20240501, EndDate: 20240510},
+		{ExchangeRateId: 3, StartDate: 20240601},
+	}
+
+	actualExchangeRates := exchangeRates.GetEffectiveExchangeRates(20240505)
+	assert.Equal(t, 2, len(actualExchangeRates))
+	assert.Equal(t, int64(1), actualExchangeRates[0].ExchangeRateId)
+	assert.Equal(t, int64(2), actualExchangeRates[1].ExchangeRateId)
+
+	actualExchangeRates = exchangeRates.GetEffectiveExchangeRates(20231231)
+	assert.Equal(t, 0, len(actualExchangeRates))
+}
+
+func TestExchangeRatesMapWithUserExchangeRates(t *testing.T) {
+	exchangeRates := ExchangeRatesMap{
+		"EUR": 1,
+		"USD": 2,
+	}
+
+	actualExchangeRates := exchangeRates.WithUserExchangeRates(UserExchangeRates{
+		{BaseCurrency: "USD", TargetCurrency: "THB", Rate: "40"},
+		{BaseCurrency: "XAU", TargetCurrency: "EUR", Rate: "0.25"},
+		{BaseCurrency: "AAA", TargetCurrency: "BBB", Rate: "3"},
+		{BaseCurrency: "USD", TargetCurrency: "JPY", Rate: "invalid"},
+	})
+
+	assert.Equal(t, 4, len(actualExchangeRates))
+	assert.Equal(t, float64(1), actualExchangeRates["EUR"])
+	assert.Equal(t, float64(2), actualExchangeRates["USD"])
+	assert.Equal(t, float64(80), actualExchangeRates["THB"])
+	assert.Equal(t, float64(4), actualExchangeRates["XAU"])
+
+	assert.Equal(t, 2, len(exchangeRates))
+}
+
+func TestExchangeRatesMapWithUserExchangeRates_EmptyExchangeRates(t *testing.T) {
+	var exchangeRates ExchangeRatesMap
+
+	actualExchangeRates := exchangeRates.WithUserExchangeRates(UserExchangeRates{
+		{BaseCurrency: "USD", TargetCurrency: "THB", Rate: "40"},
+	})
+
+	convertedAmount, success := actualExchangeRates.ConvertAmount(100, "USD", "THB")
+	assert.True(t, success)
+	assert.Equal(t, int64(4000), convertedAmount)
+}
+
+func TestLatestExchangeRateResponseWithUserExchangeRates(t *testing.T) {
+	exchangeRateResponse := &LatestExchangeRateResponse{
+		DataSource:   "European Central Bank",
+		BaseCurrency: "EUR",
+		ExchangeRates: LatestExchangeRateSlice{
+			{Currency: "EUR", Rate: "1", DataSource: "European Central Bank"},
+			{Currency: "USD", Rate: "1.1", DataSource: "European Central Bank"},
+			{Currency: "THB", Rate: "39", DataSource: "European Central Bank"},
+		},
+	}
+
+	actualResponse := exchangeRateResponse.WithUserExchangeRates(UserExchangeRates{
+		{BaseCurrency: "EUR", TargetCurrency: "THB", Rate: "40"},
+		{BaseCurrency: "EUR", TargetCurrency: "XYZ", Rate: "2.5"},
+	})
+
+	assert.Equal(t, "EUR", actualResponse.BaseCurrency)
+	assert.Equal(t, 4, len(actualResponse.ExchangeRates))
+
+	assert.Equal(t, "EUR", actualResponse.ExchangeRates[0].Currency)
+	assert.Equal(t, "European Central Bank", actualResponse.ExchangeRates[0].DataSource)
+
+	assert.Equal(t, "THB", actualResponse.ExchangeRates[1].Currency)
+	assert.Equal(t, "40", actualResponse.ExchangeRates[1].Rate)
+	assert.Equal(t, UserExchangeRateDataSource, actualResponse.ExchangeRates[1].DataSource)
+
+	assert.Equal(t, "USD", actualResponse.ExchangeRates[2].Currency)
+	assert.Equal(t, "1.1", actualResponse.ExchangeRates[2].Rate)
+	assert.Equal(t, "European Central Bank", actualResponse.ExchangeRates[2].DataSource)
+
+	assert.Equal(t, "XYZ", actualResponse.ExchangeRates[3].Currency)
+	assert.Equal(t, "2.5", actualResponse.ExchangeRates[3].Rate)
+	assert.Equal(t, UserExchangeRateDataSource, actualResponse.ExchangeRates[3].DataSource)
+
+	assert.Equal(t, "39", exchangeRateResponse.ExchangeRates[2].Rate)
+}
+
+func TestHistoricalExchangeRatesGetExchangeRates_WithUserExchangeRates(t *testing.T) {
+	historicalExchangeRates := NewHistoricalExchangeRates([]*HistoricalExchangeRate{
+		{RateDate: 20240501, Currency: "EUR", Rate: "1"},
+		{RateDate: 20240501, Currency: "USD", Rate: "2"},
+	}, nil)
+
+	historicalExchangeRates.SetUserExchangeRates(UserExchangeRates{
+		{BaseCurrency: "EUR", TargetCurrency: "USD", Rate: "3", StartDate: 20240503, EndDate: 20240504},
+	})
+
+	actualExchangeRates := historicalExchangeRates.GetExchangeRates(20240502, STATISTIC_CURRENCY_CONVERSION_TYPE_SPOT)
+	assert.Equal(t, float64(2), actualExchangeRates["USD"])
+
+	actualExchangeRates = historicalExchangeRates.GetExchangeRates(20240503, STATISTIC_CURRENCY_CONVERSION_TYPE_SPOT)
+	assert.Equal(t, float64(3), actualExchangeRates["USD"])
+
+	actualExchangeRates = historicalExchangeRates.GetExchangeRates(20240504, STATISTIC_CURRENCY_CONVERSION_TYPE_MONTHLY_AVERAGE)
+	assert.Equal(t, float64(3), actualExchangeRates["USD"])
+
+	actualExchangeRates = historicalExchangeRates.GetExchangeRates(20240505, STATISTIC_CURRENCY_CONVERSION_TYPE_SPOT)
+	assert.Equal(t, float64(2), actualExchangeRates["USD"])
+}
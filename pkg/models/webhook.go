@@ -0,0 +1,278 @@
+package models
+
+import (
+	"strings"
+)
+
+// WebhookEventType represents the event type which can trigger webhook
+type WebhookEventType byte
+
+// Webhook event types
+const (
+	WEBHOOK_EVENT_TYPE_TRANSACTION_CREATED     WebhookEventType = 1
+	WEBHOOK_EVENT_TYPE_TRANSACTION_MODIFIED    WebhookEventType = 2
+	WEBHOOK_EVENT_TYPE_TRANSACTION_DELETED     WebhookEventType = 3
+	WEBHOOK_EVENT_TYPE_ACCOUNT_BALANCE_CHANGED WebhookEventType = 4
+	WEBHOOK_EVENT_TYPE_IMPORT_COMPLETED        WebhookEventType = 5
+)
+
+var webhookEventTypeNames = map[WebhookEventType]string{
+	WEBHOOK_EVENT_TYPE_TRANSACTION_CREATED:     "transaction.created",
+	WEBHOOK_EVENT_TYPE_TRANSACTION_MODIFIED:    "transaction.modified",
+	WEBHOOK_EVENT_TYPE_TRANSACTION_DELETED:     "transaction.deleted",
+	WEBHOOK_EVENT_TYPE_ACCOUNT_BALANCE_CHANGED: "account.balance_changed",
+	WEBHOOK_EVENT_TYPE_IMPORT_COMPLETED:        "import.completed",
+}
+
+// String returns a textual representation of the webhook event type
+func (t WebhookEventType) String() string {
+	if name, exists := webhookEventTypeNames[t]; exists {
+		return name
+	}
+
+	return "unknown"
+}
+
+// ParseWebhookEventType returns the webhook event type according to the textual representation
+func ParseWebhookEventType(name string) (WebhookEventType, bool) {
+	for eventType, eventTypeName := range webhookEventTypeNames {
+		if eventTypeName == name {
+			return eventType, true
+		}
+	}
+
+	return 0, false
+}
+
+// WebhookDeliveryStatus represents the status of webhook delivery
+type WebhookDeliveryStatus byte
+
+// Webhook delivery statuses
+const (
+	WEBHOOK_DELIVERY_STATUS_PENDING   WebhookDeliveryStatus = 1
+	WEBHOOK_DELIVERY_STATUS_SUCCEEDED WebhookDeliveryStatus = 2
+	WEBHOOK_DELIVERY_STATUS_FAILED    WebhookDeliveryStatus = 3
+)
+
+const webhookDeliveryMinimumRetryIntervalSeconds = 30
+const webhookDeliveryMaximumRetryIntervalSeconds = 6 * 60 * 60
+
+// Webhook represents webhook data stored in database
+type Webhook struct {
+	WebhookId       int64  `xorm:"PK"`
+	Uid             int64  `xorm:"INDEX(IDX_webhook_uid_deleted) NOT NULL"`
+	Deleted         bool   `xorm:"INDEX(IDX_webhook_uid_deleted) NOT NULL"`
+	Name            string `xorm:"VARCHAR(64) NOT NULL"`
+	Url             string `xorm:"VARCHAR(255) NOT NULL"`
+	Secret          string `xorm:"VARCHAR(255) NOT NULL"`
+	EventTypes      string `xorm:"VARCHAR(64) NOT NULL"`
+	Disabled        bool   `xorm:"NOT NULL"`
+	CreatedUnixTime int64
+	UpdatedUnixTime int64
+	DeletedUnixTime int64
+}
+
+// WebhookDelivery represents webhook delivery data stored in database, which is also used as the delivery queue
+type WebhookDelivery struct {
+	DeliveryId             int64                 `xorm:"PK"`
+	Uid                    int64                 `xorm:"INDEX(IDX_webhook_delivery_uid_webhook_id_created_time) NOT NULL"`
+	WebhookId              int64                 `xorm:"INDEX(IDX_webhook_delivery_uid_webhook_id_created_time) NOT NULL"`
+	EventType              WebhookEventType      `xorm:"NOT NULL"`
+	Payload                string                `xorm:"TEXT NOT NULL"`
+	Status                 WebhookDeliveryStatus `xorm:"INDEX(IDX_webhook_delivery_status_next_attempt_time) NOT NULL"`
+	AttemptCount           int32                 `xorm:"NOT NULL"`
+	NextAttemptUnixTime    int64                 `xorm:"INDEX(IDX_webhook_delivery_status_next_attempt_time) NOT NULL"`
+	LastAttemptUnixTime    int64
+	LastResponseStatusCode int32
+	LastError              string `xorm:"VARCHAR(255)"`
+	CreatedUnixTime        int64  `xorm:"INDEX(IDX_webhook_delivery_uid_webhook_id_created_time)"`
+	UpdatedUnixTime        int64
+}
+
+// WebhookEventPayload represents the json body which is sent to the webhook url
+type WebhookEventPayload struct {
+	Event     string `json:"event"`
+	Uid       int64  `json:"uid,string"`
+	Timestamp int64  `json:"timestamp"`
+	Data      any    `json:"data"`
+}
+
+// WebhookTransactionEventData represents the data of transaction event
+type WebhookTransactionEventData struct {
+	Transaction *TransactionInfoResponse `json:"transaction"`
+}
+
+// WebhookAccountBalanceChangedEventData represents the data of account balance changed event
+type WebhookAccountBalanceChangedEventData struct {
+	AccountId int64  `json:"accountId,string"`
+	Currency  string `json:"currency"`
+	Balance   int64  `json:"balance"`
+}
+
+// WebhookImportCompletedEventData represents the data of import completed event
+type WebhookImportCompletedEventData struct {
+	TransactionCount int `json:"transactionCount"`
+}
+
+// WebhookGetRequest represents all parameters of webhook getting request
+type WebhookGetRequest struct {
+	Id int64 `form:"id,string" binding:"required,min=1"`
+}
+
+// WebhookCreateRequest represents all parameters of webhook creation request
+type WebhookCreateRequest struct {
+	Name       string   `json:"name" binding:"required,notBlank,max=64"`
+	Url        string   `json:"url" binding:"required,notBlank,max=255"`
+	EventTypes []string `json:"eventTypes" binding:"required,min=1,max=5"`
+}
+
+// WebhookModifyRequest represents all parameters of webhook modification request
+type WebhookModifyRequest struct {
+	Id               int64    `json:"id,string" binding:"required,min=1"`
+	Name             string   `json:"name" binding:"required,notBlank,max=64"`
+	Url              string   `json:"url" binding:"required,notBlank,max=255"`
+	EventTypes       []string `json:"eventTypes" binding:"required,min=1,max=5"`
+	RegenerateSecret bool     `json:"regenerateSecret"`
+	Disabled         bool     `json:"disabled"`
+}
+
+// WebhookDeleteRequest represents all parameters of webhook deleting request
+type WebhookDeleteRequest struct {
+	Id int64 `json:"id,string" binding:"required,min=1"`
+}
+
+// WebhookDeliveryListRequest represents all parameters of webhook delivery listing request
+type WebhookDeliveryListRequest struct {
+	WebhookId int64 `form:"webhook_id,string" binding:"required,min=1"`
+	Page      int32 `form:"page" binding:"omitempty,min=1"`
+	Count     int32 `form:"count" binding:"required,min=1,max=50"`
+}
+
+// WebhookInfoResponse represents a view-object of webhook
+type WebhookInfoResponse struct {
+	Id         int64    `json:"id,string"`
+	Name       string   `json:"name"`
+	Url        string   `json:"url"`
+	Secret     string   `json:"secret,omitempty"`
+	EventTypes []string `json:"eventTypes"`
+	Disabled   bool     `json:"disabled"`
+}
+
+// WebhookDeliveryInfoResponse represents a view-object of webhook delivery
+type WebhookDeliveryInfoResponse struct {
+	Id                     int64                 `json:"id,string"`
+	WebhookId              int64                 `json:"webhookId,string"`
+	EventType              string                `json:"eventType"`
+	Payload                string                `json:"payload"`
+	Status                 WebhookDeliveryStatus `json:"status"`
+	AttemptCount           int32                 `json:"attemptCount"`
+	NextAttemptTime        int64                 `json:"nextAttemptTime,omitempty"`
+	LastAttemptTime        int64                 `json:"lastAttemptTime,omitempty"`
+	LastResponseStatusCode int32                 `json:"lastResponseStatusCode,omitempty"`
+	LastError              string                `json:"lastError,omitempty"`
+	CreatedTime            int64                 `json:"createdTime"`
+}
+
+// GetEventTypes returns all the event types which the webhook subscribes
+func (w *Webhook) GetEventTypes() []WebhookEventType {
+	if w.EventTypes == "" {
+		return nil
+	}
+
+	names := strings.Split(w.EventTypes, ",")
+	eventTypes := make([]WebhookEventType, 0, len(names))
+
+	for i := 0; i < len(names); i++ {
+		if eventType, ok := ParseWebhookEventType(names[i]); ok {
+			eventTypes = append(eventTypes, eventType)
+		}
+	}
+
+	return eventTypes
+}
+
+// IsEventTypeSubscribed returns whether the webhook subscribes the specified event type
+func (w *Webhook) IsEventTypeSubscribed(eventType WebhookEventType) bool {
+	eventTypes := w.GetEventTypes()
+
+	for i := 0; i < len(eventTypes); i++ {
+		if eventTypes[i] == eventType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ToWebhookInfoResponse returns a view-object according to database model
+func (w *Webhook) ToWebhookInfoResponse() *WebhookInfoResponse {
+	eventTypes := make([]string, 0)
+
+	if w.EventTypes != "" {
+		eventTypes = strings.Split(w.EventTypes, ",")
+	}
+
+	return &WebhookInfoResponse{
+		Id:         w.WebhookId,
+		Name:       w.Name,
+		Url:        w.Url,
+		EventTypes: eventTypes,
+		Disabled:   w.Disabled,
+	}
+}
+
+// GetNextRetryUnixTime returns the next attempt time after the current failed attempt, the retry interval doubles for every attempt
+func (d *WebhookDelivery) GetNextRetryUnixTime(currentUnixTime int64) int64 {
+	interval := int64(webhookDeliveryMinimumRetryIntervalSeconds)
+
+	for i := int32(1); i < d.AttemptCount && interval < webhookDeliveryMaximumRetryIntervalSeconds; i++ {
+		interval *= 2
+	}
+
+	if interval > webhookDeliveryMaximumRetryIntervalSeconds {
+		interval = webhookDeliveryMaximumRetryIntervalSeconds
+	}
+
+	return currentUnixTime + interval
+}
+
+// ToWebhookDeliveryInfoResponse returns a view-object according to database model
+func (d *WebhookDelivery) ToWebhookDeliveryInfoResponse() *WebhookDeliveryInfoResponse {
+	nextAttemptTime := int64(0)
+
+	if d.Status == WEBHOOK_DELIVERY_STATUS_PENDING {
+		nextAttemptTime = d.NextAttemptUnixTime
+	}
+
+	return &WebhookDeliveryInfoResponse{
+		Id:                     d.DeliveryId,
+		WebhookId:              d.WebhookId,
+		EventType:              d.EventType.String(),
+		Payload:                d.Payload,
+		Status:                 d.Status,
+		AttemptCount:           d.AttemptCount,
+		NextAttemptTime:        nextAttemptTime,
+		LastAttemptTime:        d.LastAttemptUnixTime,
+		LastResponseStatusCode: d.LastResponseStatusCode,
+		LastError:              d.LastError,
+		CreatedTime:            d.CreatedUnixTime,
+	}
+}
+
+// WebhookInfoResponseSlice represents the slice data structure of WebhookInfoResponse
+type WebhookInfoResponseSlice []*WebhookInfoResponse
+
+// Len returns the count of items
+func (s WebhookInfoResponseSlice) Len() int {
+	return len(s)
+}
+
+// Swap swaps two items
+func (s WebhookInfoResponseSlice) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+}
+
+// Less reports whether the first item is less than the second one
+func (s WebhookInfoResponseSlice) Less(i, j int) bool {
+	return s[i].Id < s[j].Id
+}
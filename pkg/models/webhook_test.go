@@ -0,0 +1,54 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseWebhookEventType(t *testing.T) {
+	eventType, ok := ParseWebhookEventType("transaction.created")
+	assert.Equal(t, true, ok)
+	assert.Equal(t, WEBHOOK_EVENT_TYPE_TRANSACTION_CREATED, eventType)
+
+	eventType, ok = ParseWebhookEventType("import.completed")
+	assert.Equal(t, true, ok)
+	assert.Equal(t, WEBHOOK_EVENT_TYPE_IMPORT_COMPLETED, eventType)
+
+	_, ok = ParseWebhookEventType("transaction.unknown")
+	assert.Equal(t, false, ok)
+}
+
+func TestWebhookEventTypeString(t *testing.T) {
+	assert.Equal(t, "account.balance_changed", WEBHOOK_EVENT_TYPE_ACCOUNT_BALANCE_CHANGED.String())
+	assert.Equal(t, "unknown", WebhookEventType(0).String())
+}
+
+func TestWebhookIsEventTypeSubscribed(t *testing.T) {
+	webhook := &Webhook{
+		EventTypes: "transaction.created,transaction.deleted,invalid",
+	}
+
+	assert.Equal(t, []WebhookEventType{WEBHOOK_EVENT_TYPE_TRANSACTION_CREATED, WEBHOOK_EVENT_TYPE_TRANSACTION_DELETED}, webhook.GetEventTypes())
+	assert.Equal(t, true, webhook.IsEventTypeSubscribed(WEBHOOK_EVENT_TYPE_TRANSACTION_CREATED))
+	assert.Equal(t, true, webhook.IsEventTypeSubscribed(WEBHOOK_EVENT_TYPE_TRANSACTION_DELETED))
+	assert.Equal(t, false, webhook.IsEventTypeSubscribed(WEBHOOK_EVENT_TYPE_TRANSACTION_MODIFIED))
+
+	webhook = &Webhook{}
+	assert.Equal(t, 0, len(webhook.GetEventTypes()))
+	assert.Equal(t, false, webhook.IsEventTypeSubscribed(WEBHOOK_EVENT_TYPE_TRANSACTION_CREATED))
+}
+
+func TestWebhookDeliveryGetNextRetryUnixTime(t *testing.T) {
+	delivery := &WebhookDelivery{AttemptCount: 1}
+	assert.Equal(t, int64(1030), delivery.GetNextRetryUnixTime(1000))
+
+	delivery = &WebhookDelivery{AttemptCount: 2}
+	assert.Equal(t, int64(1060), delivery.GetNextRetryUnixTime(1000))
+
+	delivery = &WebhookDelivery{AttemptCount: 5}
+	assert.Equal(t, int64(1480), delivery.GetNextRetryUnixTime(1000))
+
+	delivery = &WebhookDelivery{AttemptCount: 20}
+	assert.Equal(t, int64(1000+6*60*60), delivery.GetNextRetryUnixTime(1000))
+}
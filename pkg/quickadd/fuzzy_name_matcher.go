@@ -0,0 +1,130 @@
+package quickadd
+
+import (
+	"strings"
+)
+
+// MinimumFuzzyMatchScore represents the minimum score of a name to be treated as matched
+const MinimumFuzzyMatchScore = 0.7
+
+const maximumFuzzyMatchWordCount = 3
+
+// NameCandidate represents an item (e.g. account, category or tag) which can be matched by name
+type NameCandidate struct {
+	Id   int64
+	Name string
+}
+
+// NameMatchResult represents the matched item and the words in quick add text which are used for matching
+type NameMatchResult struct {
+	Candidate      *NameCandidate
+	Score          float64
+	WordStartIndex int
+	WordCount      int
+}
+
+// GetNameMatchScore returns the similarity score (from 0 to 1) between the query text and the candidate name,
+// exact match (case insensitive) scores 1, prefix and substring match score a bit less, otherwise the score is based on edit distance
+func GetNameMatchScore(query string, name string) float64 {
+	query = strings.ToLower(strings.TrimSpace(query))
+	name = strings.ToLower(strings.TrimSpace(name))
+
+	if query == "" || name == "" {
+		return 0
+	}
+
+	if query == name {
+		return 1
+	}
+
+	queryRunes := []rune(query)
+	nameRunes := []rune(name)
+
+	if len(queryRunes) >= 2 {
+		if strings.HasPrefix(name, query) {
+			return 0.9
+		} else if strings.Contains(name, query) {
+			return 0.8
+		}
+	}
+
+	maxLength := len(queryRunes)
+
+	if len(nameRunes) > maxLength {
+		maxLength = len(nameRunes)
+	}
+
+	return 1 - float64(getLevenshteinDistance(queryRunes, nameRunes))/float64(maxLength)
+}
+
+// FindBestNameMatch returns the candidate whose name best matches the consecutive words (up to 3 words),
+// it returns nil if no candidate reaches the minimum score
+func FindBestNameMatch(words []string, candidates []*NameCandidate) *NameMatchResult {
+	var bestResult *NameMatchResult
+
+	for start := 0; start < len(words); start++ {
+		for count := 1; count <= maximumFuzzyMatchWordCount && start+count <= len(words); count++ {
+			query := strings.Join(words[start:start+count], " ")
+
+			for i := 0; i < len(candidates); i++ {
+				score := GetNameMatchScore(query, candidates[i].Name)
+
+				if score < MinimumFuzzyMatchScore {
+					continue
+				}
+
+				// prefer higher score, and then more matched words
+				if bestResult == nil || score > bestResult.Score || (score == bestResult.Score && count > bestResult.WordCount) {
+					bestResult = &NameMatchResult{
+						Candidate:      candidates[i],
+						Score:          score,
+						WordStartIndex: start,
+						WordCount:      count,
+					}
+				}
+			}
+		}
+	}
+
+	return bestResult
+}
+
+// RemoveMatchedWords returns the words excluding the words which are used by the match result
+func RemoveMatchedWords(words []string, result *NameMatchResult) []string {
+	if result == nil {
+		return words
+	}
+
+	remainingWords := make([]string, 0, len(words)-result.WordCount)
+	remainingWords = append(remainingWords, words[:result.WordStartIndex]...)
+	remainingWords = append(remainingWords, words[result.WordStartIndex+result.WordCount:]...)
+
+	return remainingWords
+}
+
+func getLevenshteinDistance(source []rune, target []rune) int {
+	previousRow := make([]int, len(target)+1)
+	currentRow := make([]int, len(target)+1)
+
+	for j := 0; j <= len(target); j++ {
+		previousRow[j] = j
+	}
+
+	for i := 1; i <= len(source); i++ {
+		currentRow[0] = i
+
+		for j := 1; j <= len(target); j++ {
+			cost := 1
+
+			if source[i-1] == target[j-1] {
+				cost = 0
+			}
+
+			currentRow[j] = min(previousRow[j]+1, currentRow[j-1]+1, previousRow[j-1]+cost)
+		}
+
+		previousRow, currentRow = currentRow, previousRow
+	}
+
+	return previousRow[len(target)]
+}
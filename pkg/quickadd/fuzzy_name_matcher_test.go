@@ -0,0 +1,40 @@
+package quickadd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetNameMatchScore(t *testing.T) {
+	assert.Equal(t, float64(1), GetNameMatchScore("visa", "VISA"))
+	assert.Equal(t, 0.9, GetNameMatchScore("vis", "Visa Card"))
+	assert.Equal(t, 0.8, GetNameMatchScore("card", "Visa Card"))
+	assert.InDelta(t, 0.8, GetNameMatchScore("lunh", "lunch"), 0.0001)
+	assert.Less(t, GetNameMatchScore("bus", "Salary"), MinimumFuzzyMatchScore)
+	assert.Equal(t, float64(0), GetNameMatchScore("", "Salary"))
+}
+
+func TestFindBestNameMatch(t *testing.T) {
+	candidates := []*NameCandidate{
+		{Id: 1, Name: "Cash"},
+		{Id: 2, Name: "Visa Card"},
+		{Id: 3, Name: "Savings"},
+	}
+
+	result := FindBestNameMatch([]string{"lunch", "visa", "card"}, candidates)
+	assert.NotNil(t, result)
+	assert.Equal(t, int64(2), result.Candidate.Id)
+	assert.Equal(t, 1, result.WordStartIndex)
+	assert.Equal(t, 2, result.WordCount)
+	assert.Equal(t, []string{"lunch"}, RemoveMatchedWords([]string{"lunch", "visa", "card"}, result))
+
+	result = FindBestNameMatch([]string{"coffee", "savngs"}, candidates)
+	assert.NotNil(t, result)
+	assert.Equal(t, int64(3), result.Candidate.Id)
+	assert.Equal(t, []string{"coffee"}, RemoveMatchedWords([]string{"coffee", "savngs"}, result))
+
+	result = FindBestNameMatch([]string{"coffee"}, candidates)
+	assert.Nil(t, result)
+	assert.Equal(t, []string{"coffee"}, RemoveMatchedWords([]string{"coffee"}, result))
+}
@@ -0,0 +1,253 @@
+package quickadd
+
+import (
+	"strings"
+	"time"
+
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+const tagTokenPrefix = "#"
+
+var currencySymbols = []string{"$", "€", "£", "¥", "₩", "₹", "₽", "₴", "₺", "₫", "₪", "฿"}
+
+var weekdayNames = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"sun":       time.Sunday,
+	"monday":    time.Monday,
+	"mon":       time.Monday,
+	"tuesday":   time.Tuesday,
+	"tue":       time.Tuesday,
+	"wednesday": time.Wednesday,
+	"wed":       time.Wednesday,
+	"thursday":  time.Thursday,
+	"thu":       time.Thursday,
+	"friday":    time.Friday,
+	"fri":       time.Friday,
+	"saturday":  time.Saturday,
+	"sat":       time.Saturday,
+}
+
+var absoluteDateLayouts = []string{
+	"2006-01-02",
+	"2006/01/02",
+	"2006.01.02",
+}
+
+// QuickAddParseOptions represents the user preferences which are used for parsing quick add text
+type QuickAddParseOptions struct {
+	DecimalSeparator    rune
+	DigitGroupingSymbol rune
+	CurrentTime         time.Time
+}
+
+// QuickAddParseResult represents the transaction fields which are recognized from quick add text,
+// the amount text should be parsed by ParseLocalizedAmount with the precision of the resolved account
+type QuickAddParseResult struct {
+	AmountText string
+	HasAmount  bool
+	IsIncome   bool
+	Time       time.Time
+	HasTime    bool
+	TagNames   []string
+	Words      []string
+}
+
+// ParseQuickAddText returns the amount, date, tag names and the remaining words recognized from quick add text,
+// the first amount-like token (in any supported currency precision) is used as amount, relative dates are based on the current time in options
+func ParseQuickAddText(text string, options *QuickAddParseOptions) *QuickAddParseResult {
+	tokens := strings.Fields(text)
+	result := &QuickAddParseResult{
+		TagNames: make([]string, 0),
+		Words:    make([]string, 0, len(tokens)),
+	}
+
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+		lowerToken := strings.ToLower(token)
+
+		if strings.HasPrefix(token, tagTokenPrefix) && len(token) > len(tagTokenPrefix) {
+			result.TagNames = append(result.TagNames, token[len(tagTokenPrefix):])
+			continue
+		}
+
+		if !result.HasTime {
+			date, consumedCount := parseDateTokens(tokens[i:], options.CurrentTime)
+
+			if consumedCount > 0 {
+				result.Time = date
+				result.HasTime = true
+				i += consumedCount - 1
+				continue
+			}
+		}
+
+		if !result.HasAmount {
+			amount, isIncome, err := ParseLocalizedAmount(token, options.DecimalSeparator, options.DigitGroupingSymbol, models.MAX_CURRENCY_PRECISION)
+
+			if err == nil && amount > 0 {
+				result.AmountText = token
+				result.IsIncome = isIncome
+				result.HasAmount = true
+				continue
+			}
+		}
+
+		if lowerToken == "-" || lowerToken == "->" || lowerToken == "@" {
+			continue
+		}
+
+		result.Words = append(result.Words, token)
+	}
+
+	return result
+}
+
+// ParseLocalizedAmount returns the amount (with the specified count of implied decimal places) of the textual amount which uses the specified decimal separator and digit grouping symbol,
+// the currency symbols are ignored and the amount which starts with "+" is treated as income
+func ParseLocalizedAmount(text string, decimalSeparator rune, digitGroupingSymbol rune, precision int) (int64, bool, error) {
+	isIncome := false
+
+	if strings.HasPrefix(text, "+") {
+		isIncome = true
+		text = text[1:]
+	} else if strings.HasPrefix(text, "-") {
+		text = text[1:]
+	}
+
+	for i := 0; i < len(currencySymbols); i++ {
+		text = strings.TrimPrefix(text, currencySymbols[i])
+		text = strings.TrimSuffix(text, currencySymbols[i])
+	}
+
+	if text == "" {
+		return 0, false, errs.ErrNumberInvalid
+	}
+
+	integerPart := text
+	decimalPart := ""
+
+	if decimalSeparatorIndex := strings.LastIndex(text, string(decimalSeparator)); decimalSeparatorIndex >= 0 {
+		integerPart = text[:decimalSeparatorIndex]
+		decimalPart = text[decimalSeparatorIndex+1:]
+
+		if decimalPart == "" || len(decimalPart) > precision || !isAllDigits(decimalPart) {
+			return 0, false, errs.ErrNumberInvalid
+		}
+	}
+
+	if digitGroupingSymbol != 0 && strings.ContainsRune(integerPart, digitGroupingSymbol) {
+		groups := strings.Split(integerPart, string(digitGroupingSymbol))
+
+		if len(groups[0]) < 1 || len(groups[0]) > 3 {
+			return 0, false, errs.ErrNumberInvalid
+		}
+
+		for i := 1; i < len(groups); i++ {
+			if len(groups[i]) != 3 {
+				return 0, false, errs.ErrNumberInvalid
+			}
+		}
+
+		integerPart = strings.Join(groups, "")
+	}
+
+	if integerPart == "" {
+		integerPart = "0"
+	}
+
+	if !isAllDigits(integerPart) {
+		return 0, false, errs.ErrNumberInvalid
+	}
+
+	if decimalPart != "" {
+		integerPart = integerPart + "." + decimalPart
+	}
+
+	amount, err := utils.ParseAmountWithPrecision(integerPart, precision)
+
+	if err != nil {
+		return 0, false, err
+	}
+
+	return amount, isIncome, nil
+}
+
+func parseDateTokens(tokens []string, currentTime time.Time) (time.Time, int) {
+	first := strings.ToLower(tokens[0])
+
+	switch first {
+	case "today":
+		return currentTime, 1
+	case "yesterday":
+		return currentTime.AddDate(0, 0, -1), 1
+	case "tomorrow":
+		return currentTime.AddDate(0, 0, 1), 1
+	}
+
+	if weekday, exists := weekdayNames[first]; exists {
+		return getLastWeekday(currentTime, weekday, false), 1
+	}
+
+	if len(tokens) >= 2 && first == "last" {
+		if weekday, exists := weekdayNames[strings.ToLower(tokens[1])]; exists {
+			return getLastWeekday(currentTime, weekday, true), 2
+		}
+	}
+
+	if len(tokens) >= 3 && strings.ToLower(tokens[2]) == "ago" && isAllDigits(first) {
+		count, err := utils.StringToInt(first)
+		unit := strings.TrimSuffix(strings.ToLower(tokens[1]), "s")
+
+		if err == nil && count > 0 {
+			if unit == "day" {
+				return currentTime.AddDate(0, 0, -count), 3
+			} else if unit == "week" {
+				return currentTime.AddDate(0, 0, -count*7), 3
+			} else if unit == "month" {
+				return currentTime.AddDate(0, -count, 0), 3
+			}
+		}
+	}
+
+	for i := 0; i < len(absoluteDateLayouts); i++ {
+		date, err := time.ParseInLocation(absoluteDateLayouts[i], first, currentTime.Location())
+
+		if err == nil {
+			return time.Date(date.Year(), date.Month(), date.Day(), currentTime.Hour(), currentTime.Minute(), currentTime.Second(), 0, currentTime.Location()), 1
+		}
+	}
+
+	return time.Time{}, 0
+}
+
+// getLastWeekday returns the most recent date of the weekday (today is included unless excludeToday is true)
+func getLastWeekday(currentTime time.Time, weekday time.Weekday, excludeToday bool) time.Time {
+	days := int(currentTime.Weekday()) - int(weekday)
+
+	if days < 0 {
+		days += 7
+	}
+
+	if days == 0 && excludeToday {
+		days = 7
+	}
+
+	return currentTime.AddDate(0, 0, -days)
+}
+
+func isAllDigits(text string) bool {
+	if text == "" {
+		return false
+	}
+
+	for _, ch := range text {
+		if ch < '0' || ch > '9' {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,118 @@
+package quickadd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLocalizedAmount_DotDecimalSeparator(t *testing.T) {
+	amount, isIncome, err := ParseLocalizedAmount("12.50", '.', ',', 2)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1250), amount)
+	assert.Equal(t, false, isIncome)
+
+	amount, _, err = ParseLocalizedAmount("1,234.5", '.', ',', 2)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(123450), amount)
+
+	amount, _, err = ParseLocalizedAmount("$8", '.', ',', 2)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(800), amount)
+
+	amount, isIncome, err = ParseLocalizedAmount("+3000", '.', ',', 2)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(300000), amount)
+	assert.Equal(t, true, isIncome)
+
+	_, _, err = ParseLocalizedAmount("12,50", '.', ',', 2)
+	assert.NotNil(t, err)
+
+	_, _, err = ParseLocalizedAmount("1.234", '.', ',', 2)
+	assert.NotNil(t, err)
+
+	_, _, err = ParseLocalizedAmount("visa", '.', ',', 2)
+	assert.NotNil(t, err)
+}
+
+func TestParseLocalizedAmount_CurrencyPrecision(t *testing.T) {
+	amount, _, err := ParseLocalizedAmount("0.12345678", '.', ',', 8)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(12345678), amount)
+
+	amount, _, err = ParseLocalizedAmount("1,234.5", '.', ',', 3)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1234500), amount)
+
+	amount, _, err = ParseLocalizedAmount("¥1,234", '.', ',', 0)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1234), amount)
+
+	_, _, err = ParseLocalizedAmount("12.5", '.', ',', 0)
+	assert.NotNil(t, err)
+
+	_, _, err = ParseLocalizedAmount("12.345", '.', ',', 2)
+	assert.NotNil(t, err)
+}
+
+func TestParseLocalizedAmount_CommaDecimalSeparator(t *testing.T) {
+	amount, _, err := ParseLocalizedAmount("12,50", ',', '.', 2)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1250), amount)
+
+	amount, _, err = ParseLocalizedAmount("1.234,56€", ',', '.', 2)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(123456), amount)
+
+	amount, _, err = ParseLocalizedAmount("1'234", ',', '\'', 2)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(123400), amount)
+
+	_, _, err = ParseLocalizedAmount("12.50", ',', '.', 2)
+	assert.NotNil(t, err)
+}
+
+func TestParseQuickAddText(t *testing.T) {
+	currentTime := time.Date(2024, 5, 15, 12, 30, 0, 0, time.FixedZone("Client Timezone", 8*60*60)) // Wednesday
+	options := &QuickAddParseOptions{
+		DecimalSeparator:    '.',
+		DigitGroupingSymbol: ',',
+		CurrentTime:         currentTime,
+	}
+
+	result := ParseQuickAddText("lunch 12.50 visa #work yesterday", options)
+	assert.Equal(t, true, result.HasAmount)
+	assert.Equal(t, "12.50", result.AmountText)
+	assert.Equal(t, false, result.IsIncome)
+	assert.Equal(t, true, result.HasTime)
+	assert.Equal(t, time.Date(2024, 5, 14, 12, 30, 0, 0, currentTime.Location()), result.Time)
+	assert.Equal(t, []string{"work"}, result.TagNames)
+	assert.Equal(t, []string{"lunch", "visa"}, result.Words)
+
+	result = ParseQuickAddText("salary +3,000 2 days ago", options)
+	assert.Equal(t, "+3,000", result.AmountText)
+	assert.Equal(t, true, result.IsIncome)
+	assert.Equal(t, time.Date(2024, 5, 13, 12, 30, 0, 0, currentTime.Location()), result.Time)
+	assert.Equal(t, []string{"salary"}, result.Words)
+
+	result = ParseQuickAddText("taxi 25 last wed", options)
+	assert.Equal(t, time.Date(2024, 5, 8, 12, 30, 0, 0, currentTime.Location()), result.Time)
+
+	result = ParseQuickAddText("taxi 25 monday", options)
+	assert.Equal(t, time.Date(2024, 5, 13, 12, 30, 0, 0, currentTime.Location()), result.Time)
+
+	result = ParseQuickAddText("rent 2024-05-01 1200", options)
+	assert.Equal(t, time.Date(2024, 5, 1, 12, 30, 0, 0, currentTime.Location()), result.Time)
+	assert.Equal(t, "1200", result.AmountText)
+
+	result = ParseQuickAddText("btc 0.00012345 wallet", options)
+	assert.Equal(t, true, result.HasAmount)
+	assert.Equal(t, "0.00012345", result.AmountText)
+	assert.Equal(t, []string{"btc", "wallet"}, result.Words)
+
+	result = ParseQuickAddText("coffee", options)
+	assert.Equal(t, false, result.HasAmount)
+	assert.Equal(t, false, result.HasTime)
+	assert.Equal(t, []string{"coffee"}, result.Words)
+}
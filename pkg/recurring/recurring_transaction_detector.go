@@ -0,0 +1,403 @@
+package recurring
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+const recurringTransactionSeriesKeySeparator = "|"
+const recurringTransactionCategoryPayeeKeyPrefix = "category:"
+const maximumTemplateNameLength = 64
+
+// RecurringTransactionDetectorOptions represents the options of recurring transaction detection
+type RecurringTransactionDetectorOptions struct {
+	MinOccurrences       int
+	AmountTolerance      float64
+	RegularityThreshold  float64
+	CurrentUnixTime      int64
+	MaxMissedOccurrences int
+}
+
+// RecurringTransactionPriceChange represents the amount change of recurring transaction series
+type RecurringTransactionPriceChange struct {
+	UnixTime  int64
+	OldAmount int64
+	NewAmount int64
+}
+
+// RecurringTransactionSeries represents a series of transactions which occur periodically with similar payee and amount
+type RecurringTransactionSeries struct {
+	Key                 string
+	Name                string
+	Type                models.TransactionType
+	CategoryId          int64
+	AccountId           int64
+	Period              models.RecurringTransactionPeriod
+	Occurrences         int
+	AverageAmount       int64
+	LastAmount          int64
+	LastComment         string
+	FirstUnixTime       int64
+	LastUnixTime        int64
+	LastUtcOffset       int16
+	NextExpectedTime    int64
+	Active              bool
+	PriceChanges        []*RecurringTransactionPriceChange
+	lastLocalDate       time.Time
+	typicalDayOfMonth   int
+	lastDayOfMonthCount int
+}
+
+type recurringTransactionPeriodRange struct {
+	period  models.RecurringTransactionPeriod
+	minDays int
+	maxDays int
+}
+
+var recurringTransactionPeriodRanges = []*recurringTransactionPeriodRange{
+	{period: models.RECURRING_TRANSACTION_PERIOD_WEEKLY, minDays: 6, maxDays: 8},
+	{period: models.RECURRING_TRANSACTION_PERIOD_BIWEEKLY, minDays: 13, maxDays: 15},
+	{period: models.RECURRING_TRANSACTION_PERIOD_MONTHLY, minDays: 27, maxDays: 34},
+	{period: models.RECURRING_TRANSACTION_PERIOD_QUARTERLY, minDays: 86, maxDays: 96},
+	{period: models.RECURRING_TRANSACTION_PERIOD_YEARLY, minDays: 358, maxDays: 373},
+}
+
+// DefaultRecurringTransactionDetectorOptions returns the default options of recurring transaction detection at the specified time
+func DefaultRecurringTransactionDetectorOptions(currentUnixTime int64) *RecurringTransactionDetectorOptions {
+	return &RecurringTransactionDetectorOptions{
+		MinOccurrences:       3,
+		AmountTolerance:      0.2,
+		RegularityThreshold:  0.75,
+		CurrentUnixTime:      currentUnixTime,
+		MaxMissedOccurrences: 1,
+	}
+}
+
+// DetectRecurringTransactionSeries returns all recurring series of the income and expense transactions,
+// transactions are grouped by type, account and payee (the comment without digits and punctuations, or the category if comment is empty),
+// then the groups whose intervals and amounts are regular enough are returned and ordered by the last transaction time in descending order
+func DetectRecurringTransactionSeries(transactions []*models.Transaction, options *RecurringTransactionDetectorOptions) []*RecurringTransactionSeries {
+	groups := make(map[string][]*models.Transaction)
+	groupKeys := make([]string, 0)
+
+	for i := 0; i < len(transactions); i++ {
+		transaction := transactions[i]
+
+		if transaction.Type != models.TRANSACTION_DB_TYPE_INCOME && transaction.Type != models.TRANSACTION_DB_TYPE_EXPENSE {
+			continue
+		}
+
+		key := GetRecurringTransactionSeriesKey(transaction)
+
+		if _, exists := groups[key]; !exists {
+			groupKeys = append(groupKeys, key)
+		}
+
+		groups[key] = append(groups[key], transaction)
+	}
+
+	allSeries := make([]*RecurringTransactionSeries, 0)
+
+	for i := 0; i < len(groupKeys); i++ {
+		groupTransactions := groups[groupKeys[i]]
+
+		if len(groupTransactions) < options.MinOccurrences || len(groupTransactions) < 2 {
+			continue
+		}
+
+		sort.SliceStable(groupTransactions, func(i, j int) bool {
+			return groupTransactions[i].TransactionTime < groupTransactions[j].TransactionTime
+		})
+
+		series := detectRecurringTransactionSeries(groupKeys[i], groupTransactions, options)
+
+		if series != nil {
+			allSeries = append(allSeries, series)
+		}
+	}
+
+	sort.Slice(allSeries, func(i, j int) bool {
+		if allSeries[i].LastUnixTime != allSeries[j].LastUnixTime {
+			return allSeries[i].LastUnixTime > allSeries[j].LastUnixTime
+		}
+
+		return allSeries[i].Key < allSeries[j].Key
+	})
+
+	return allSeries
+}
+
+// GetRecurringTransactionSeriesKey returns the key of recurring transaction series which the specified transaction may belong to
+func GetRecurringTransactionSeriesKey(transaction *models.Transaction) string {
+	payeeKey := utils.GetNormalizedPayeeName(transaction.Comment)
+
+	if payeeKey == "" {
+		payeeKey = recurringTransactionCategoryPayeeKeyPrefix + utils.Int64ToString(transaction.CategoryId)
+	}
+
+	return strings.Join([]string{utils.IntToString(int(transaction.Type)), utils.Int64ToString(transaction.AccountId), payeeKey}, recurringTransactionSeriesKeySeparator)
+}
+
+// ToScheduledTransactionTemplate returns a new scheduled transaction template which creates the following transactions of this series,
+// the schedule starts from the next expected date so that the existed transactions would not be created again, and the series name is used if the specified name is empty
+func (s *RecurringTransactionSeries) ToScheduledTransactionTemplate(name string) *models.TransactionTemplate {
+	timezone := time.FixedZone("Template Timezone", int(s.LastUtcOffset)*60)
+	nextExpectedDate := time.Unix(s.NextExpectedTime, 0).In(timezone)
+	startUnixTime := time.Date(nextExpectedDate.Year(), nextExpectedDate.Month(), nextExpectedDate.Day(), 0, 0, 0, 0, timezone).Unix()
+
+	if name == "" {
+		name = utils.SubString(s.Name, 0, maximumTemplateNameLength)
+	}
+
+	template := &models.TransactionTemplate{
+		TemplateType:               models.TRANSACTION_TEMPLATE_TYPE_SCHEDULE,
+		Name:                       name,
+		Type:                       s.Type,
+		CategoryId:                 s.CategoryId,
+		AccountId:                  s.AccountId,
+		ScheduledStartTime:         &startUnixTime,
+		ScheduledTimezoneUtcOffset: s.LastUtcOffset,
+		Amount:                     s.LastAmount,
+		Comment:                    s.LastComment,
+	}
+
+	switch s.Period {
+	case models.RECURRING_TRANSACTION_PERIOD_WEEKLY:
+		template.ScheduledFrequencyType = models.TRANSACTION_SCHEDULE_FREQUENCY_TYPE_WEEKLY
+		template.ScheduledFrequency = utils.IntToString(int(nextExpectedDate.Weekday()))
+	case models.RECURRING_TRANSACTION_PERIOD_BIWEEKLY:
+		template.ScheduledFrequencyType = models.TRANSACTION_SCHEDULE_FREQUENCY_TYPE_EVERY_N_WEEKS
+		template.ScheduledFrequency = utils.IntToString(int(nextExpectedDate.Weekday()))
+		template.ScheduledInterval = 2
+	case models.RECURRING_TRANSACTION_PERIOD_MONTHLY:
+		if s.isOnLastDayOfMonth() {
+			template.ScheduledFrequencyType = models.TRANSACTION_SCHEDULE_FREQUENCY_TYPE_LAST_DAY_OF_MONTH
+		} else {
+			template.ScheduledFrequencyType = models.TRANSACTION_SCHEDULE_FREQUENCY_TYPE_MONTHLY
+			template.ScheduledFrequency = utils.IntToString(s.typicalDayOfMonth)
+		}
+	case models.RECURRING_TRANSACTION_PERIOD_QUARTERLY:
+		template.ScheduledFrequencyType = models.TRANSACTION_SCHEDULE_FREQUENCY_TYPE_QUARTERLY
+		template.ScheduledFrequency = utils.IntToString(nextExpectedDate.Day())
+	case models.RECURRING_TRANSACTION_PERIOD_YEARLY:
+		template.ScheduledFrequencyType = models.TRANSACTION_SCHEDULE_FREQUENCY_TYPE_YEARLY
+		template.ScheduledFrequency = utils.IntToString(int(nextExpectedDate.Month())*100 + nextExpectedDate.Day())
+	}
+
+	return template
+}
+
+// ToRecurringTransactionSeriesResponse returns a view-object according to the recurring transaction series
+func (s *RecurringTransactionSeries) ToRecurringTransactionSeriesResponse() *models.RecurringTransactionSeriesResponse {
+	priceChanges := make([]*models.RecurringTransactionPriceChangeResponse, len(s.PriceChanges))
+
+	for i := 0; i < len(s.PriceChanges); i++ {
+		priceChanges[i] = &models.RecurringTransactionPriceChangeResponse{
+			Time:      s.PriceChanges[i].UnixTime,
+			OldAmount: s.PriceChanges[i].OldAmount,
+			NewAmount: s.PriceChanges[i].NewAmount,
+		}
+	}
+
+	return &models.RecurringTransactionSeriesResponse{
+		Key:              s.Key,
+		Name:             s.Name,
+		Type:             s.Type,
+		CategoryId:       s.CategoryId,
+		AccountId:        s.AccountId,
+		Period:           s.Period,
+		Occurrences:      int32(s.Occurrences),
+		AverageAmount:    s.AverageAmount,
+		LastAmount:       s.LastAmount,
+		FirstTime:        s.FirstUnixTime,
+		LastTime:         s.LastUnixTime,
+		NextExpectedTime: s.NextExpectedTime,
+		UtcOffset:        s.LastUtcOffset,
+		Active:           s.Active,
+		PriceChanges:     priceChanges,
+	}
+}
+
+func detectRecurringTransactionSeries(key string, transactions []*models.Transaction, options *RecurringTransactionDetectorOptions) *RecurringTransactionSeries {
+	localDates := make([]time.Time, len(transactions))
+	intervals := make([]int, 0, len(transactions)-1)
+
+	for i := 0; i < len(transactions); i++ {
+		timezone := time.FixedZone("Transaction Timezone", int(transactions[i].TimezoneUtcOffset)*60)
+		localTime := time.Unix(utils.GetUnixTimeFromTransactionTime(transactions[i].TransactionTime), 0).In(timezone)
+		localDates[i] = time.Date(localTime.Year(), localTime.Month(), localTime.Day(), 0, 0, 0, 0, time.UTC)
+
+		if i > 0 {
+			intervals = append(intervals, int(localDates[i].Sub(localDates[i-1]).Hours()/24))
+		}
+	}
+
+	periodRange := getRecurringTransactionPeriodRange(intervals)
+
+	if periodRange == nil {
+		return nil
+	}
+
+	regularIntervalCount := 0
+
+	for i := 0; i < len(intervals); i++ {
+		if periodRange.minDays <= intervals[i] && intervals[i] <= periodRange.maxDays {
+			regularIntervalCount++
+		}
+	}
+
+	if float64(regularIntervalCount) < float64(len(intervals))*options.RegularityThreshold {
+		return nil
+	}
+
+	similarAmountCount := 0
+	totalAmount := int64(0)
+
+	for i := 0; i < len(transactions); i++ {
+		totalAmount += transactions[i].Amount
+
+		if i > 0 && isSimilarAmount(transactions[i-1].Amount, transactions[i].Amount, options.AmountTolerance) {
+			similarAmountCount++
+		}
+	}
+
+	if float64(similarAmountCount) < float64(len(transactions)-1)*options.RegularityThreshold {
+		return nil
+	}
+
+	lastTransaction := transactions[len(transactions)-1]
+	transactionType, _ := lastTransaction.Type.ToTransactionType()
+
+	series := &RecurringTransactionSeries{
+		Key:           key,
+		Name:          strings.TrimSpace(lastTransaction.Comment),
+		Type:          transactionType,
+		CategoryId:    lastTransaction.CategoryId,
+		AccountId:     lastTransaction.AccountId,
+		Period:        periodRange.period,
+		Occurrences:   len(transactions),
+		AverageAmount: int64(math.Round(float64(totalAmount) / float64(len(transactions)))),
+		LastAmount:    lastTransaction.Amount,
+		LastComment:   lastTransaction.Comment,
+		FirstUnixTime: utils.GetUnixTimeFromTransactionTime(transactions[0].TransactionTime),
+		LastUnixTime:  utils.GetUnixTimeFromTransactionTime(lastTransaction.TransactionTime),
+		LastUtcOffset: lastTransaction.TimezoneUtcOffset,
+		PriceChanges:  make([]*RecurringTransactionPriceChange, 0),
+		lastLocalDate: localDates[len(localDates)-1],
+	}
+
+	series.typicalDayOfMonth, series.lastDayOfMonthCount = getTypicalDayOfMonth(localDates)
+
+	for i := 1; i < len(transactions); i++ {
+		// the amount which keeps the same in the next occurrence (or the latest amount) is regarded as a new price
+		if transactions[i].Amount != transactions[i-1].Amount && (i == len(transactions)-1 || transactions[i+1].Amount == transactions[i].Amount) {
+			series.PriceChanges = append(series.PriceChanges, &RecurringTransactionPriceChange{
+				UnixTime:  utils.GetUnixTimeFromTransactionTime(transactions[i].TransactionTime),
+				OldAmount: transactions[i-1].Amount,
+				NewAmount: transactions[i].Amount,
+			})
+		}
+	}
+
+	nextExpectedLocalDate := series.getNextExpectedLocalDate()
+	lastLocalTime := time.Unix(series.LastUnixTime, 0).In(time.FixedZone("Transaction Timezone", int(series.LastUtcOffset)*60))
+	series.NextExpectedTime = series.LastUnixTime + int64(nextExpectedLocalDate.Sub(time.Date(lastLocalTime.Year(), lastLocalTime.Month(), lastLocalTime.Day(), 0, 0, 0, 0, time.UTC)).Seconds())
+
+	// the series is still active if the current time is not later than the last allowed missed occurrence (with the tolerance of period)
+	allowedDelayDays := int64(periodRange.maxDays-periodRange.minDays) + int64(periodRange.maxDays)*int64(options.MaxMissedOccurrences)
+	series.Active = options.CurrentUnixTime <= series.NextExpectedTime+allowedDelayDays*24*60*60
+
+	return series
+}
+
+func (s *RecurringTransactionSeries) getNextExpectedLocalDate() time.Time {
+	switch s.Period {
+	case models.RECURRING_TRANSACTION_PERIOD_WEEKLY:
+		return s.lastLocalDate.AddDate(0, 0, 7)
+	case models.RECURRING_TRANSACTION_PERIOD_BIWEEKLY:
+		return s.lastLocalDate.AddDate(0, 0, 14)
+	case models.RECURRING_TRANSACTION_PERIOD_MONTHLY:
+		if s.isOnLastDayOfMonth() {
+			return getDateInMonth(s.lastLocalDate.Year(), s.lastLocalDate.Month()+1, 31)
+		}
+
+		return getDateInMonth(s.lastLocalDate.Year(), s.lastLocalDate.Month()+1, s.typicalDayOfMonth)
+	case models.RECURRING_TRANSACTION_PERIOD_QUARTERLY:
+		return getDateInMonth(s.lastLocalDate.Year(), s.lastLocalDate.Month()+3, s.lastLocalDate.Day())
+	case models.RECURRING_TRANSACTION_PERIOD_YEARLY:
+		return getDateInMonth(s.lastLocalDate.Year()+1, s.lastLocalDate.Month(), s.lastLocalDate.Day())
+	}
+
+	return s.lastLocalDate
+}
+
+func (s *RecurringTransactionSeries) isOnLastDayOfMonth() bool {
+	return s.lastDayOfMonthCount*2 > s.Occurrences && s.typicalDayOfMonth >= 28
+}
+
+func getRecurringTransactionPeriodRange(intervals []int) *recurringTransactionPeriodRange {
+	if len(intervals) < 1 {
+		return nil
+	}
+
+	sortedIntervals := make([]int, len(intervals))
+	copy(sortedIntervals, intervals)
+	sort.Ints(sortedIntervals)
+
+	medianInterval := sortedIntervals[len(sortedIntervals)/2]
+
+	for i := 0; i < len(recurringTransactionPeriodRanges); i++ {
+		periodRange := recurringTransactionPeriodRanges[i]
+
+		if periodRange.minDays <= medianInterval && medianInterval <= periodRange.maxDays {
+			return periodRange
+		}
+	}
+
+	return nil
+}
+
+func getTypicalDayOfMonth(localDates []time.Time) (int, int) {
+	dayCounts := make(map[int]int)
+	lastDayOfMonthCount := 0
+	typicalDay := localDates[len(localDates)-1].Day()
+
+	for i := 0; i < len(localDates); i++ {
+		dayCounts[localDates[i].Day()]++
+
+		if localDates[i].AddDate(0, 0, 1).Day() == 1 {
+			lastDayOfMonthCount++
+		}
+	}
+
+	for day, count := range dayCounts {
+		if count > dayCounts[typicalDay] || (count == dayCounts[typicalDay] && day > typicalDay) {
+			typicalDay = day
+		}
+	}
+
+	return typicalDay, lastDayOfMonthCount
+}
+
+func getDateInMonth(year int, month time.Month, day int) time.Time {
+	firstDayOfMonth := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	lastDayOfMonth := firstDayOfMonth.AddDate(0, 1, -1).Day()
+
+	return time.Date(firstDayOfMonth.Year(), firstDayOfMonth.Month(), min(day, lastDayOfMonth), 0, 0, 0, 0, time.UTC)
+}
+
+func isSimilarAmount(amount1 int64, amount2 int64, tolerance float64) bool {
+	if amount1 == amount2 {
+		return true
+	}
+
+	difference := math.Abs(float64(amount1 - amount2))
+	maxAmount := math.Max(math.Abs(float64(amount1)), math.Abs(float64(amount2)))
+
+	return difference <= maxAmount*tolerance
+}
@@ -0,0 +1,191 @@
+package recurring
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+func TestGetRecurringTransactionSeriesKey(t *testing.T) {
+	assert.Equal(t, "3|1001|netflix", GetRecurringTransactionSeriesKey(&models.Transaction{Type: models.TRANSACTION_DB_TYPE_EXPENSE, AccountId: 1001, Comment: "NETFLIX #2024-05"}))
+	assert.Equal(t, "3|1001|netflix", GetRecurringTransactionSeriesKey(&models.Transaction{Type: models.TRANSACTION_DB_TYPE_EXPENSE, AccountId: 1001, Comment: " Netflix  2024/06 "}))
+	assert.Equal(t, "2|1002|acme corp salary", GetRecurringTransactionSeriesKey(&models.Transaction{Type: models.TRANSACTION_DB_TYPE_INCOME, AccountId: 1002, Comment: "ACME Corp. salary"}))
+	assert.Equal(t, "3|1001|category:2001", GetRecurringTransactionSeriesKey(&models.Transaction{Type: models.TRANSACTION_DB_TYPE_EXPENSE, AccountId: 1001, CategoryId: 2001, Comment: "12345"}))
+}
+
+func TestDetectRecurringTransactionSeries_MonthlySubscriptionWithPriceChange(t *testing.T) {
+	transactions := []*models.Transaction{
+		newTestTransaction(models.TRANSACTION_DB_TYPE_EXPENSE, 2024, time.January, 15, 999, "Netflix 2024-01"),
+		newTestTransaction(models.TRANSACTION_DB_TYPE_EXPENSE, 2024, time.February, 15, 999, "Netflix 2024-02"),
+		newTestTransaction(models.TRANSACTION_DB_TYPE_EXPENSE, 2024, time.March, 16, 999, "Netflix 2024-03"),
+		newTestTransaction(models.TRANSACTION_DB_TYPE_EXPENSE, 2024, time.April, 15, 1099, "Netflix 2024-04"),
+		newTestTransaction(models.TRANSACTION_DB_TYPE_EXPENSE, 2024, time.May, 15, 1099, "Netflix 2024-05"),
+		newTestTransaction(models.TRANSACTION_DB_TYPE_EXPENSE, 2024, time.March, 3, 4523, "Supermarket"),
+		newTestTransaction(models.TRANSACTION_DB_TYPE_EXPENSE, 2024, time.April, 20, 1280, "Supermarket"),
+		newTestTransaction(models.TRANSACTION_DB_TYPE_EXPENSE, 2024, time.April, 22, 9800, "Supermarket"),
+	}
+
+	currentUnixTime := time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC).Unix()
+	allSeries := DetectRecurringTransactionSeries(transactions, DefaultRecurringTransactionDetectorOptions(currentUnixTime))
+	assert.Equal(t, 1, len(allSeries))
+
+	series := allSeries[0]
+	assert.Equal(t, "3|1001|netflix", series.Key)
+	assert.Equal(t, "Netflix 2024-05", series.Name)
+	assert.Equal(t, models.TRANSACTION_TYPE_EXPENSE, series.Type)
+	assert.Equal(t, models.RECURRING_TRANSACTION_PERIOD_MONTHLY, series.Period)
+	assert.Equal(t, 5, series.Occurrences)
+	assert.Equal(t, int64(1039), series.AverageAmount)
+	assert.Equal(t, int64(1099), series.LastAmount)
+	assert.Equal(t, time.Date(2024, time.January, 15, 12, 0, 0, 0, time.UTC).Unix(), series.FirstUnixTime)
+	assert.Equal(t, time.Date(2024, time.May, 15, 12, 0, 0, 0, time.UTC).Unix(), series.LastUnixTime)
+	assert.Equal(t, time.Date(2024, time.June, 15, 12, 0, 0, 0, time.UTC).Unix(), series.NextExpectedTime)
+	assert.True(t, series.Active)
+	assert.Equal(t, []*RecurringTransactionPriceChange{
+		{UnixTime: time.Date(2024, time.April, 15, 12, 0, 0, 0, time.UTC).Unix(), OldAmount: 999, NewAmount: 1099},
+	}, series.PriceChanges)
+
+	allSeries = DetectRecurringTransactionSeries(transactions, DefaultRecurringTransactionDetectorOptions(time.Date(2024, time.October, 1, 0, 0, 0, 0, time.UTC).Unix()))
+	assert.Equal(t, 1, len(allSeries))
+	assert.False(t, allSeries[0].Active)
+}
+
+func TestDetectRecurringTransactionSeries_WeeklyAndYearly(t *testing.T) {
+	transactions := []*models.Transaction{
+		newTestTransaction(models.TRANSACTION_DB_TYPE_EXPENSE, 2024, time.May, 6, 500, "Gym"),
+		newTestTransaction(models.TRANSACTION_DB_TYPE_EXPENSE, 2024, time.May, 13, 500, "Gym"),
+		newTestTransaction(models.TRANSACTION_DB_TYPE_EXPENSE, 2024, time.May, 20, 500, "Gym"),
+		newTestTransaction(models.TRANSACTION_DB_TYPE_EXPENSE, 2024, time.May, 27, 500, "Gym"),
+		newTestTransaction(models.TRANSACTION_DB_TYPE_EXPENSE, 2022, time.March, 1, 12000, "Domain renewal"),
+		newTestTransaction(models.TRANSACTION_DB_TYPE_EXPENSE, 2023, time.March, 2, 12000, "Domain renewal"),
+		newTestTransaction(models.TRANSACTION_DB_TYPE_EXPENSE, 2024, time.March, 1, 13000, "Domain renewal"),
+	}
+
+	currentUnixTime := time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC).Unix()
+	allSeries := DetectRecurringTransactionSeries(transactions, DefaultRecurringTransactionDetectorOptions(currentUnixTime))
+	assert.Equal(t, 2, len(allSeries))
+
+	assert.Equal(t, "3|1001|gym", allSeries[0].Key)
+	assert.Equal(t, models.RECURRING_TRANSACTION_PERIOD_WEEKLY, allSeries[0].Period)
+	assert.Equal(t, time.Date(2024, time.June, 3, 12, 0, 0, 0, time.UTC).Unix(), allSeries[0].NextExpectedTime)
+	assert.Equal(t, 0, len(allSeries[0].PriceChanges))
+
+	assert.Equal(t, "3|1001|domain renewal", allSeries[1].Key)
+	assert.Equal(t, models.RECURRING_TRANSACTION_PERIOD_YEARLY, allSeries[1].Period)
+	assert.Equal(t, time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC).Unix(), allSeries[1].NextExpectedTime)
+	assert.Equal(t, 1, len(allSeries[1].PriceChanges))
+}
+
+func TestDetectRecurringTransactionSeries_IrregularIntervalsOrAmounts(t *testing.T) {
+	transactions := []*models.Transaction{
+		newTestTransaction(models.TRANSACTION_DB_TYPE_EXPENSE, 2024, time.January, 1, 1000, "Coffee"),
+		newTestTransaction(models.TRANSACTION_DB_TYPE_EXPENSE, 2024, time.January, 3, 1000, "Coffee"),
+		newTestTransaction(models.TRANSACTION_DB_TYPE_EXPENSE, 2024, time.February, 20, 1000, "Coffee"),
+		newTestTransaction(models.TRANSACTION_DB_TYPE_EXPENSE, 2024, time.February, 21, 1000, "Coffee"),
+		newTestTransaction(models.TRANSACTION_DB_TYPE_EXPENSE, 2024, time.January, 10, 1000, "Electricity"),
+		newTestTransaction(models.TRANSACTION_DB_TYPE_EXPENSE, 2024, time.February, 10, 5000, "Electricity"),
+		newTestTransaction(models.TRANSACTION_DB_TYPE_EXPENSE, 2024, time.March, 10, 1500, "Electricity"),
+		newTestTransaction(models.TRANSACTION_DB_TYPE_EXPENSE, 2024, time.April, 10, 9000, "Electricity"),
+		newTestTransaction(models.TRANSACTION_DB_TYPE_TRANSFER_OUT, 2024, time.January, 10, 1000, "Saving"),
+		newTestTransaction(models.TRANSACTION_DB_TYPE_TRANSFER_OUT, 2024, time.February, 10, 1000, "Saving"),
+		newTestTransaction(models.TRANSACTION_DB_TYPE_TRANSFER_OUT, 2024, time.March, 10, 1000, "Saving"),
+	}
+
+	allSeries := DetectRecurringTransactionSeries(transactions, DefaultRecurringTransactionDetectorOptions(time.Date(2024, time.May, 1, 0, 0, 0, 0, time.UTC).Unix()))
+	assert.Equal(t, 0, len(allSeries))
+}
+
+func TestDetectRecurringTransactionSeries_MinOccurrences(t *testing.T) {
+	transactions := []*models.Transaction{
+		newTestTransaction(models.TRANSACTION_DB_TYPE_INCOME, 2024, time.January, 25, 500000, "Salary"),
+		newTestTransaction(models.TRANSACTION_DB_TYPE_INCOME, 2024, time.February, 26, 500000, "Salary"),
+	}
+
+	options := DefaultRecurringTransactionDetectorOptions(time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC).Unix())
+	allSeries := DetectRecurringTransactionSeries(transactions, options)
+	assert.Equal(t, 0, len(allSeries))
+
+	options.MinOccurrences = 2
+	allSeries = DetectRecurringTransactionSeries(transactions, options)
+	assert.Equal(t, 1, len(allSeries))
+	assert.Equal(t, models.TRANSACTION_TYPE_INCOME, allSeries[0].Type)
+	assert.Equal(t, models.RECURRING_TRANSACTION_PERIOD_MONTHLY, allSeries[0].Period)
+}
+
+func TestRecurringTransactionSeriesToScheduledTransactionTemplate_Monthly(t *testing.T) {
+	transactions := []*models.Transaction{
+		newTestTransaction(models.TRANSACTION_DB_TYPE_EXPENSE, 2024, time.January, 15, 999, "Netflix"),
+		newTestTransaction(models.TRANSACTION_DB_TYPE_EXPENSE, 2024, time.February, 15, 999, "Netflix"),
+		newTestTransaction(models.TRANSACTION_DB_TYPE_EXPENSE, 2024, time.March, 15, 999, "Netflix"),
+	}
+
+	allSeries := DetectRecurringTransactionSeries(transactions, DefaultRecurringTransactionDetectorOptions(time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC).Unix()))
+	assert.Equal(t, 1, len(allSeries))
+
+	template := allSeries[0].ToScheduledTransactionTemplate("")
+	assert.Equal(t, models.TRANSACTION_TEMPLATE_TYPE_SCHEDULE, template.TemplateType)
+	assert.Equal(t, "Netflix", template.Name)
+	assert.Equal(t, models.TRANSACTION_TYPE_EXPENSE, template.Type)
+	assert.Equal(t, int64(2001), template.CategoryId)
+	assert.Equal(t, int64(1001), template.AccountId)
+	assert.Equal(t, int64(999), template.Amount)
+	assert.Equal(t, models.TRANSACTION_SCHEDULE_FREQUENCY_TYPE_MONTHLY, template.ScheduledFrequencyType)
+	assert.Equal(t, "15", template.ScheduledFrequency)
+	assert.Equal(t, time.Date(2024, time.April, 15, 0, 0, 0, 0, time.UTC).Unix(), *template.ScheduledStartTime)
+	assert.True(t, template.IsScheduledFrequencyValid())
+
+	template = allSeries[0].ToScheduledTransactionTemplate("Streaming")
+	assert.Equal(t, "Streaming", template.Name)
+}
+
+func TestRecurringTransactionSeriesToScheduledTransactionTemplate_LastDayOfMonth(t *testing.T) {
+	transactions := []*models.Transaction{
+		newTestTransaction(models.TRANSACTION_DB_TYPE_EXPENSE, 2024, time.January, 31, 20000, "Rent"),
+		newTestTransaction(models.TRANSACTION_DB_TYPE_EXPENSE, 2024, time.February, 29, 20000, "Rent"),
+		newTestTransaction(models.TRANSACTION_DB_TYPE_EXPENSE, 2024, time.March, 31, 20000, "Rent"),
+		newTestTransaction(models.TRANSACTION_DB_TYPE_EXPENSE, 2024, time.April, 30, 20000, "Rent"),
+	}
+
+	allSeries := DetectRecurringTransactionSeries(transactions, DefaultRecurringTransactionDetectorOptions(time.Date(2024, time.May, 1, 0, 0, 0, 0, time.UTC).Unix()))
+	assert.Equal(t, 1, len(allSeries))
+	assert.Equal(t, time.Date(2024, time.May, 31, 12, 0, 0, 0, time.UTC).Unix(), allSeries[0].NextExpectedTime)
+
+	template := allSeries[0].ToScheduledTransactionTemplate("")
+	assert.Equal(t, models.TRANSACTION_SCHEDULE_FREQUENCY_TYPE_LAST_DAY_OF_MONTH, template.ScheduledFrequencyType)
+	assert.Equal(t, "", template.ScheduledFrequency)
+	assert.True(t, template.IsScheduledFrequencyValid())
+}
+
+func TestRecurringTransactionSeriesToScheduledTransactionTemplate_Biweekly(t *testing.T) {
+	transactions := []*models.Transaction{
+		newTestTransaction(models.TRANSACTION_DB_TYPE_INCOME, 2024, time.May, 3, 200000, "Payroll"),
+		newTestTransaction(models.TRANSACTION_DB_TYPE_INCOME, 2024, time.May, 17, 200000, "Payroll"),
+		newTestTransaction(models.TRANSACTION_DB_TYPE_INCOME, 2024, time.May, 31, 200000, "Payroll"),
+	}
+
+	allSeries := DetectRecurringTransactionSeries(transactions, DefaultRecurringTransactionDetectorOptions(time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC).Unix()))
+	assert.Equal(t, 1, len(allSeries))
+	assert.Equal(t, models.RECURRING_TRANSACTION_PERIOD_BIWEEKLY, allSeries[0].Period)
+
+	template := allSeries[0].ToScheduledTransactionTemplate("")
+	assert.Equal(t, models.TRANSACTION_SCHEDULE_FREQUENCY_TYPE_EVERY_N_WEEKS, template.ScheduledFrequencyType)
+	assert.Equal(t, utils.IntToString(int(time.Friday)), template.ScheduledFrequency)
+	assert.Equal(t, int16(2), template.ScheduledInterval)
+	assert.True(t, template.IsScheduledFrequencyValid())
+	assert.Equal(t, []int64{time.Date(2024, time.June, 14, 0, 0, 0, 0, time.UTC).Unix(), time.Date(2024, time.June, 28, 0, 0, 0, 0, time.UTC).Unix()}, template.GetScheduledTransactionTimes(time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC).Unix(), time.Date(2024, time.June, 30, 0, 0, 0, 0, time.UTC).Unix()))
+}
+
+func newTestTransaction(transactionType models.TransactionDbType, year int, month time.Month, day int, amount int64, comment string) *models.Transaction {
+	return &models.Transaction{
+		Type:            transactionType,
+		CategoryId:      2001,
+		AccountId:       1001,
+		TransactionTime: utils.GetMinTransactionTimeFromUnixTime(time.Date(year, month, day, 12, 0, 0, 0, time.UTC).Unix()),
+		Amount:          amount,
+		Comment:         comment,
+	}
+}
@@ -0,0 +1,600 @@
+package reports
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf16"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+// PdfPageWidth represents the width of A4 page in points
+const PdfPageWidth = 595.28
+
+// PdfPageHeight represents the height of A4 page in points
+const PdfPageHeight = 841.89
+
+const pdfUnsupportedCharacter = '?'
+
+const pdfSimulatedBoldStrokeWidthRatio = 0.03
+const pdfToUnicodeMaxEntryCount = 100
+
+// PdfFont represents the standard font which is used in pdf document
+type PdfFont byte
+
+// Pdf standard fonts
+const (
+	PDF_FONT_REGULAR PdfFont = 1
+	PDF_FONT_BOLD    PdfFont = 2
+)
+
+// PdfColor represents the rgb color which is used in pdf document
+type PdfColor struct {
+	R byte
+	G byte
+	B byte
+}
+
+// PdfDocument represents a minimal pdf document writer which uses the standard fonts or the embedded TrueType fonts,
+// all the coordinates are measured in points from the top left corner of the page
+type PdfDocument struct {
+	title              string
+	creationTime       time.Time
+	pages              []*bytes.Buffer
+	currentPageIndex   int
+	unicodeFonts       map[PdfFont]*PdfTrueTypeFont
+	simulateBold       bool
+	usedGlyphs         map[*PdfTrueTypeFont]map[uint16]rune
+	hasUnsupportedText bool
+}
+
+// NewPdfDocument returns a new empty pdf document
+func NewPdfDocument(title string, creationTime time.Time) *PdfDocument {
+	return &PdfDocument{
+		title:        title,
+		creationTime: creationTime,
+		pages:        make([]*bytes.Buffer, 0),
+	}
+}
+
+// SetUnicodeFonts sets the TrueType fonts which are embedded in the document instead of the standard fonts,
+// the regular font is also used as the bold font with simulated bold style if the bold font is nil
+func (d *PdfDocument) SetUnicodeFonts(regularFont *PdfTrueTypeFont, boldFont *PdfTrueTypeFont) {
+	if regularFont == nil {
+		return
+	}
+
+	d.simulateBold = boldFont == nil
+
+	if boldFont == nil {
+		boldFont = regularFont
+	}
+
+	d.unicodeFonts = map[PdfFont]*PdfTrueTypeFont{
+		PDF_FONT_REGULAR: regularFont,
+		PDF_FONT_BOLD:    boldFont,
+	}
+	d.usedGlyphs = make(map[*PdfTrueTypeFont]map[uint16]rune, 2)
+}
+
+// AddPage appends a new page to the document, all the following drawing operations are applied to this page
+func (d *PdfDocument) AddPage() {
+	d.pages = append(d.pages, &bytes.Buffer{})
+	d.currentPageIndex = len(d.pages) - 1
+}
+
+// PageCount returns the page count of the document
+func (d *PdfDocument) PageCount() int {
+	return len(d.pages)
+}
+
+// SetCurrentPage sets the current page by the specified page index (starting from 0)
+func (d *PdfDocument) SetCurrentPage(pageIndex int) {
+	if pageIndex < 0 || pageIndex >= len(d.pages) {
+		return
+	}
+
+	d.currentPageIndex = pageIndex
+}
+
+// DrawText draws the text whose baseline starts from the specified position
+func (d *PdfDocument) DrawText(x float64, y float64, text string, font PdfFont, fontSize float64, color PdfColor) {
+	page := d.getCurrentPage()
+
+	if page == nil || text == "" {
+		return
+	}
+
+	if !d.CanDisplayText(text) {
+		d.hasUnsupportedText = true
+	}
+
+	unicodeFont := d.unicodeFonts[font]
+
+	if unicodeFont == nil {
+		fmt.Fprintf(page, "BT %s rg /F%d %s Tf %s %s Td (%s) Tj ET\n", getPdfColorOperands(color), font, formatPdfNumber(fontSize), formatPdfNumber(x), formatPdfNumber(PdfPageHeight-y), escapePdfText(encodePdfText(text)))
+	} else if font == PDF_FONT_BOLD && d.simulateBold {
+		// the bold style is simulated by filling and stroking the glyph outlines, and the graphics state is restored after drawing
+		fmt.Fprintf(page, "q BT %s rg %s RG 2 Tr %s w /F%d %s Tf %s %s Td <%s> Tj ET Q\n", getPdfColorOperands(color), getPdfColorOperands(color), formatPdfNumber(fontSize*pdfSimulatedBoldStrokeWidthRatio), font, formatPdfNumber(fontSize), formatPdfNumber(x), formatPdfNumber(PdfPageHeight-y), d.encodeUnicodeText(unicodeFont, text))
+	} else {
+		fmt.Fprintf(page, "BT %s rg /F%d %s Tf %s %s Td <%s> Tj ET\n", getPdfColorOperands(color), font, formatPdfNumber(fontSize), formatPdfNumber(x), formatPdfNumber(PdfPageHeight-y), d.encodeUnicodeText(unicodeFont, text))
+	}
+}
+
+// DrawTextAlignRight draws the text whose baseline ends at the specified position
+func (d *PdfDocument) DrawTextAlignRight(x float64, y float64, text string, font PdfFont, fontSize float64, color PdfColor) {
+	d.DrawText(x-d.GetTextWidth(text, font, fontSize), y, text, font, fontSize, color)
+}
+
+// GetTextWidth returns the width of the text in points when it is drawn with the specified font and font size in this document
+func (d *PdfDocument) GetTextWidth(text string, font PdfFont, fontSize float64) float64 {
+	unicodeFont := d.unicodeFonts[font]
+
+	if unicodeFont == nil {
+		return GetPdfTextWidth(text, font, fontSize)
+	}
+
+	totalWidth := 0
+
+	for _, r := range text {
+		if r < 32 {
+			r = ' '
+		}
+
+		glyphIndex, _ := unicodeFont.GetGlyphIndex(r)
+		totalWidth += unicodeFont.getGlyphWidth(glyphIndex)
+	}
+
+	return float64(totalWidth) * fontSize / 1000
+}
+
+// TruncateText returns the text which is truncated with ellipsis so that its width in this document does not exceed the max width
+func (d *PdfDocument) TruncateText(text string, font PdfFont, fontSize float64, maxWidth float64) string {
+	return truncatePdfText(text, maxWidth, func(text string) float64 {
+		return d.GetTextWidth(text, font, fontSize)
+	})
+}
+
+// CanDisplayText returns whether all the characters in the text can be drawn with the fonts of this document
+func (d *PdfDocument) CanDisplayText(text string) bool {
+	if d.unicodeFonts == nil {
+		return CanEncodePdfText(text)
+	}
+
+	for _, r := range text {
+		if r < 32 {
+			continue
+		}
+
+		if _, exists := d.unicodeFonts[PDF_FONT_REGULAR].GetGlyphIndex(r); !exists {
+			return false
+		}
+
+		if _, exists := d.unicodeFonts[PDF_FONT_BOLD].GetGlyphIndex(r); !exists {
+			return false
+		}
+	}
+
+	return true
+}
+
+// HasUnsupportedText returns whether any drawn text contains the characters which cannot be displayed with the fonts of this document
+func (d *PdfDocument) HasUnsupportedText() bool {
+	return d.hasUnsupportedText
+}
+
+// DrawLine draws a straight line between the specified positions
+func (d *PdfDocument) DrawLine(x1 float64, y1 float64, x2 float64, y2 float64, lineWidth float64, color PdfColor) {
+	page := d.getCurrentPage()
+
+	if page == nil {
+		return
+	}
+
+	fmt.Fprintf(page, "%s RG %s w %s %s m %s %s l S\n", getPdfColorOperands(color), formatPdfNumber(lineWidth), formatPdfNumber(x1), formatPdfNumber(PdfPageHeight-y1), formatPdfNumber(x2), formatPdfNumber(PdfPageHeight-y2))
+}
+
+// FillRect draws a filled rectangle whose top left corner is at the specified position
+func (d *PdfDocument) FillRect(x float64, y float64, width float64, height float64, color PdfColor) {
+	page := d.getCurrentPage()
+
+	if page == nil || width <= 0 || height <= 0 {
+		return
+	}
+
+	fmt.Fprintf(page, "%s rg %s %s %s %s re f\n", getPdfColorOperands(color), formatPdfNumber(x), formatPdfNumber(PdfPageHeight-y-height), formatPdfNumber(width), formatPdfNumber(height))
+}
+
+// Bytes returns the whole content of the pdf document
+func (d *PdfDocument) Bytes() ([]byte, error) {
+	var buffer bytes.Buffer
+	objectOffsets := make([]int, 0, 5+len(d.pages)*2)
+
+	beginObject := func() int {
+		objectOffsets = append(objectOffsets, buffer.Len())
+		objectId := len(objectOffsets)
+		fmt.Fprintf(&buffer, "%d 0 obj\n", objectId)
+		return objectId
+	}
+
+	endObject := func() {
+		buffer.WriteString("endobj\n")
+	}
+
+	buffer.WriteString("%PDF-1.4\n%\xE2\xE3\xCF\xD3\n")
+
+	// object 1 and 2 are catalog and page tree, object 3 and 4 are fonts, object 5 is document information
+	pageObjectIds := make([]string, len(d.pages))
+
+	for i := 0; i < len(d.pages); i++ {
+		pageObjectIds[i] = fmt.Sprintf("%d 0 R", 6+i*2)
+	}
+
+	beginObject()
+	buffer.WriteString("<< /Type /Catalog /Pages 2 0 R >>\n")
+	endObject()
+
+	beginObject()
+	fmt.Fprintf(&buffer, "<< /Type /Pages /Kids [%s] /Count %d >>\n", strings.Join(pageObjectIds, " "), len(d.pages))
+	endObject()
+
+	// the objects of embedded fonts are written after all the pages
+	embeddedFonts := d.getEmbeddedFonts()
+	embeddedFontObjectIds := make(map[*PdfTrueTypeFont]int, len(embeddedFonts))
+
+	for i := 0; i < len(embeddedFonts); i++ {
+		embeddedFontObjectIds[embeddedFonts[i]] = 6 + len(d.pages)*2 + i*4
+	}
+
+	if len(embeddedFonts) > 0 {
+		for _, font := range []PdfFont{PDF_FONT_REGULAR, PDF_FONT_BOLD} {
+			unicodeFont := d.unicodeFonts[font]
+			objectId := embeddedFontObjectIds[unicodeFont]
+
+			beginObject()
+			fmt.Fprintf(&buffer, "<< /Type /Font /Subtype /Type0 /BaseFont /%s /Encoding /Identity-H /DescendantFonts [%d 0 R] /ToUnicode %d 0 R >>\n", getEmbeddedPdfFontName(embeddedFonts, unicodeFont), objectId, objectId+3)
+			endObject()
+		}
+	} else {
+		beginObject()
+		buffer.WriteString("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>\n")
+		endObject()
+
+		beginObject()
+		buffer.WriteString("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>\n")
+		endObject()
+	}
+
+	beginObject()
+	fmt.Fprintf(&buffer, "<< /Title %s /Producer (ezBookkeeping) /CreationDate (D:%s) >>\n", getPdfTextString(d.title), d.creationTime.UTC().Format("20060102150405Z"))
+	endObject()
+
+	for i := 0; i < len(d.pages); i++ {
+		content, err := compressPdfStream(d.pages[i].Bytes())
+
+		if err != nil {
+			return nil, err
+		}
+
+		pageObjectId := beginObject()
+		fmt.Fprintf(&buffer, "<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources << /Font << /F%d 3 0 R /F%d 4 0 R >> >> /Contents %d 0 R >>\n", formatPdfNumber(PdfPageWidth), formatPdfNumber(PdfPageHeight), PDF_FONT_REGULAR, PDF_FONT_BOLD, pageObjectId+1)
+		endObject()
+
+		beginObject()
+		fmt.Fprintf(&buffer, "<< /Length %d /Filter /FlateDecode >>\nstream\n", len(content))
+		buffer.Write(content)
+		buffer.WriteString("\nendstream\n")
+		endObject()
+	}
+
+	for i := 0; i < len(embeddedFonts); i++ {
+		if err := d.writeEmbeddedFontObjects(&buffer, embeddedFonts, embeddedFonts[i], beginObject, endObject); err != nil {
+			return nil, err
+		}
+	}
+
+	xrefOffset := buffer.Len()
+	fmt.Fprintf(&buffer, "xref\n0 %d\n0000000000 65535 f \n", len(objectOffsets)+1)
+
+	for i := 0; i < len(objectOffsets); i++ {
+		fmt.Fprintf(&buffer, "%010d 00000 n \n", objectOffsets[i])
+	}
+
+	fmt.Fprintf(&buffer, "trailer\n<< /Size %d /Root 1 0 R /Info 5 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objectOffsets)+1, xrefOffset)
+
+	return buffer.Bytes(), nil
+}
+
+func (d *PdfDocument) getCurrentPage() *bytes.Buffer {
+	if len(d.pages) < 1 {
+		return nil
+	}
+
+	return d.pages[d.currentPageIndex]
+}
+
+// encodeUnicodeText returns the hexadecimal glyph indexes of the text in the embedded font, and records the used glyphs for font subsetting
+func (d *PdfDocument) encodeUnicodeText(unicodeFont *PdfTrueTypeFont, text string) string {
+	usedGlyphs := d.usedGlyphs[unicodeFont]
+
+	if usedGlyphs == nil {
+		usedGlyphs = make(map[uint16]rune)
+		d.usedGlyphs[unicodeFont] = usedGlyphs
+	}
+
+	var builder strings.Builder
+
+	for _, r := range text {
+		if r < 32 {
+			r = ' '
+		}
+
+		glyphIndex, exists := unicodeFont.GetGlyphIndex(r)
+
+		if exists {
+			usedGlyphs[glyphIndex] = r
+		}
+
+		fmt.Fprintf(&builder, "%04X", glyphIndex)
+	}
+
+	return builder.String()
+}
+
+// getEmbeddedFonts returns the distinct TrueType fonts which need to be embedded in the document
+func (d *PdfDocument) getEmbeddedFonts() []*PdfTrueTypeFont {
+	if d.unicodeFonts == nil {
+		return nil
+	}
+
+	embeddedFonts := []*PdfTrueTypeFont{d.unicodeFonts[PDF_FONT_REGULAR]}
+
+	if d.unicodeFonts[PDF_FONT_BOLD] != d.unicodeFonts[PDF_FONT_REGULAR] {
+		embeddedFonts = append(embeddedFonts, d.unicodeFonts[PDF_FONT_BOLD])
+	}
+
+	return embeddedFonts
+}
+
+// writeEmbeddedFontObjects writes the cid font, font descriptor, font file and to unicode cmap objects of the embedded font
+func (d *PdfDocument) writeEmbeddedFontObjects(buffer *bytes.Buffer, embeddedFonts []*PdfTrueTypeFont, unicodeFont *PdfTrueTypeFont, beginObject func() int, endObject func()) error {
+	fontName := getEmbeddedPdfFontName(embeddedFonts, unicodeFont)
+	usedGlyphs := d.usedGlyphs[unicodeFont]
+	glyphIndexes := make([]int, 0, len(usedGlyphs))
+	subsetGlyphIndexes := make(map[uint16]bool, len(usedGlyphs))
+
+	for glyphIndex := range usedGlyphs {
+		glyphIndexes = append(glyphIndexes, int(glyphIndex))
+		subsetGlyphIndexes[glyphIndex] = true
+	}
+
+	sort.Ints(glyphIndexes)
+
+	var widths strings.Builder
+
+	for i := 0; i < len(glyphIndexes); i++ {
+		fmt.Fprintf(&widths, "%d [%d] ", glyphIndexes[i], unicodeFont.getGlyphWidth(uint16(glyphIndexes[i])))
+	}
+
+	cidFontObjectId := beginObject()
+	fmt.Fprintf(buffer, "<< /Type /Font /Subtype /CIDFontType2 /BaseFont /%s /CIDSystemInfo << /Registry (Adobe) /Ordering (Identity) /Supplement 0 >> /FontDescriptor %d 0 R /CIDToGIDMap /Identity /DW %d /W [%s] >>\n", fontName, cidFontObjectId+1, unicodeFont.getGlyphWidth(0), strings.TrimSpace(widths.String()))
+	endObject()
+
+	beginObject()
+	fmt.Fprintf(buffer, "<< /Type /FontDescriptor /FontName /%s /Flags 4 /FontBBox [%d %d %d %d] /ItalicAngle 0 /Ascent %d /Descent %d /CapHeight %d /StemV 80 /FontFile2 %d 0 R >>\n", fontName,
+		unicodeFont.scale(int(unicodeFont.boundingBox[0])), unicodeFont.scale(int(unicodeFont.boundingBox[1])), unicodeFont.scale(int(unicodeFont.boundingBox[2])), unicodeFont.scale(int(unicodeFont.boundingBox[3])),
+		unicodeFont.scale(int(unicodeFont.ascent)), unicodeFont.scale(int(unicodeFont.descent)), unicodeFont.scale(int(unicodeFont.capHeight)), cidFontObjectId+2)
+	endObject()
+
+	fontFile := unicodeFont.subset(subsetGlyphIndexes)
+	fontFileContent, err := compressPdfStream(fontFile)
+
+	if err != nil {
+		return err
+	}
+
+	beginObject()
+	fmt.Fprintf(buffer, "<< /Length %d /Length1 %d /Filter /FlateDecode >>\nstream\n", len(fontFileContent), len(fontFile))
+	buffer.Write(fontFileContent)
+	buffer.WriteString("\nendstream\n")
+	endObject()
+
+	toUnicodeContent, err := compressPdfStream(getPdfToUnicodeCMap(glyphIndexes, usedGlyphs))
+
+	if err != nil {
+		return err
+	}
+
+	beginObject()
+	fmt.Fprintf(buffer, "<< /Length %d /Filter /FlateDecode >>\nstream\n", len(toUnicodeContent))
+	buffer.Write(toUnicodeContent)
+	buffer.WriteString("\nendstream\n")
+	endObject()
+
+	return nil
+}
+
+// getEmbeddedPdfFontName returns the font name with subset tag of the embedded font
+func getEmbeddedPdfFontName(embeddedFonts []*PdfTrueTypeFont, unicodeFont *PdfTrueTypeFont) string {
+	for i := 0; i < len(embeddedFonts); i++ {
+		if embeddedFonts[i] == unicodeFont {
+			return fmt.Sprintf("EZBKS%c+EmbeddedFont%d", 'A'+i, i+1)
+		}
+	}
+
+	return ""
+}
+
+// getPdfToUnicodeCMap returns the cmap which maps the glyph indexes to unicode characters, so that the text in pdf document can be searched and copied
+func getPdfToUnicodeCMap(glyphIndexes []int, usedGlyphs map[uint16]rune) []byte {
+	var buffer bytes.Buffer
+	buffer.WriteString("/CIDInit /ProcSet findresource begin\n12 dict begin\nbegincmap\n")
+	buffer.WriteString("/CIDSystemInfo << /Registry (Adobe) /Ordering (UCS) /Supplement 0 >> def\n")
+	buffer.WriteString("/CMapName /Adobe-Identity-UCS def\n/CMapType 2 def\n")
+	buffer.WriteString("1 begincodespacerange\n<0000> <FFFF>\nendcodespacerange\n")
+
+	for i := 0; i < len(glyphIndexes); i += pdfToUnicodeMaxEntryCount {
+		entryCount := min(pdfToUnicodeMaxEntryCount, len(glyphIndexes)-i)
+		fmt.Fprintf(&buffer, "%d beginbfchar\n", entryCount)
+
+		for j := i; j < i+entryCount; j++ {
+			fmt.Fprintf(&buffer, "<%04X> <%s>\n", glyphIndexes[j], getUtf16HexString(string(usedGlyphs[uint16(glyphIndexes[j])])))
+		}
+
+		buffer.WriteString("endbfchar\n")
+	}
+
+	buffer.WriteString("endcmap\nCMapName currentdict /CMap defineresource pop\nend\nend\n")
+
+	return buffer.Bytes()
+}
+
+// getPdfTextString returns the literal string if the text can be encoded with the standard encoding, or the utf-16 hexadecimal string otherwise
+func getPdfTextString(text string) string {
+	if CanEncodePdfText(text) {
+		return "(" + escapePdfText(encodePdfText(text)) + ")"
+	}
+
+	return "<FEFF" + getUtf16HexString(text) + ">"
+}
+
+func getUtf16HexString(text string) string {
+	var builder strings.Builder
+	codeUnits := utf16.Encode([]rune(text))
+
+	for i := 0; i < len(codeUnits); i++ {
+		fmt.Fprintf(&builder, "%04X", codeUnits[i])
+	}
+
+	return builder.String()
+}
+
+// GetPdfTextWidth returns the width of the text in points when it is drawn with the specified font and font size
+func GetPdfTextWidth(text string, font PdfFont, fontSize float64) float64 {
+	widths := &pdfHelveticaWidths
+
+	if font == PDF_FONT_BOLD {
+		widths = &pdfHelveticaBoldWidths
+	}
+
+	encodedText := encodePdfText(text)
+	totalWidth := 0
+
+	for i := 0; i < len(encodedText); i++ {
+		if encodedText[i] >= 32 {
+			totalWidth += widths[encodedText[i]-32]
+		}
+	}
+
+	return float64(totalWidth) * fontSize / 1000
+}
+
+// TruncatePdfText returns the text which is truncated with ellipsis so that its width does not exceed the max width
+func TruncatePdfText(text string, font PdfFont, fontSize float64, maxWidth float64) string {
+	return truncatePdfText(text, maxWidth, func(text string) float64 {
+		return GetPdfTextWidth(text, font, fontSize)
+	})
+}
+
+// CanEncodePdfText returns whether all the characters in the text can be drawn with the standard fonts
+func CanEncodePdfText(text string) bool {
+	for _, r := range text {
+		if _, ok := charmap.Windows1252.EncodeRune(r); !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+func truncatePdfText(text string, maxWidth float64, getTextWidth func(string) float64) string {
+	if getTextWidth(text) <= maxWidth {
+		return text
+	}
+
+	runes := []rune(text)
+
+	for len(runes) > 0 {
+		runes = runes[:len(runes)-1]
+		truncatedText := strings.TrimRight(string(runes), " ") + "..."
+
+		if getTextWidth(truncatedText) <= maxWidth {
+			return truncatedText
+		}
+	}
+
+	return ""
+}
+
+// encodePdfText converts the text to WinAnsi encoding which the standard fonts use, and the characters which are not supported are replaced with question mark
+func encodePdfText(text string) []byte {
+	result := make([]byte, 0, len(text))
+
+	for _, r := range text {
+		if r < 32 {
+			result = append(result, ' ')
+			continue
+		}
+
+		b, ok := charmap.Windows1252.EncodeRune(r)
+
+		if !ok {
+			b = pdfUnsupportedCharacter
+		}
+
+		result = append(result, b)
+	}
+
+	return result
+}
+
+func escapePdfText(text []byte) string {
+	var builder strings.Builder
+
+	for i := 0; i < len(text); i++ {
+		switch text[i] {
+		case '(', ')', '\\':
+			builder.WriteByte('\\')
+			builder.WriteByte(text[i])
+		default:
+			builder.WriteByte(text[i])
+		}
+	}
+
+	return builder.String()
+}
+
+func compressPdfStream(content []byte) ([]byte, error) {
+	var buffer bytes.Buffer
+	writer := zlib.NewWriter(&buffer)
+
+	if _, err := writer.Write(content); err != nil {
+		return nil, err
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+func getPdfColorOperands(color PdfColor) string {
+	return fmt.Sprintf("%s %s %s", formatPdfNumber(float64(color.R)/255), formatPdfNumber(float64(color.G)/255), formatPdfNumber(float64(color.B)/255))
+}
+
+func formatPdfNumber(value float64) string {
+	result := fmt.Sprintf("%.3f", value)
+	result = strings.TrimRight(result, "0")
+	result = strings.TrimRight(result, ".")
+
+	if result == "-0" || result == "" {
+		return "0"
+	}
+
+	return result
+}
@@ -0,0 +1,170 @@
+package reports
+
+import (
+	"bytes"
+	"compress/zlib"
+	"io"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPdfDocumentBytes(t *testing.T) {
+	document := NewPdfDocument("Report (2024)", time.Unix(1700000000, 0))
+	document.AddPage()
+	document.DrawText(40, 40, "Hello (World)", PDF_FONT_BOLD, 12, PdfColor{R: 0xFF})
+	document.AddPage()
+	document.FillRect(40, 40, 100, 20, PdfColor{G: 0xFF})
+
+	content, err := document.Bytes()
+	assert.Nil(t, err)
+
+	assert.True(t, bytes.HasPrefix(content, []byte("%PDF-1.4\n")))
+	assert.True(t, bytes.HasSuffix(content, []byte("%%EOF\n")))
+	assert.Contains(t, string(content), "/Count 2")
+	assert.Contains(t, string(content), "/Title (Report \\(2024\\))")
+	assert.Contains(t, string(content), "/CreationDate (D:20231114221320Z)")
+
+	// the xref offsets should point to the objects
+	startXrefMatches := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(content)
+	assert.NotNil(t, startXrefMatches)
+
+	xrefOffset, _ := strconv.Atoi(string(startXrefMatches[1]))
+	assert.True(t, bytes.HasPrefix(content[xrefOffset:], []byte("xref\n0 10\n")))
+
+	objectOffsets := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(content, -1)
+	assert.Equal(t, 9, len(objectOffsets))
+
+	for i := 0; i < len(objectOffsets); i++ {
+		offset, _ := strconv.Atoi(string(objectOffsets[i][1]))
+		assert.True(t, bytes.HasPrefix(content[offset:], []byte(strconv.Itoa(i+1)+" 0 obj\n")))
+	}
+
+	// the first page content stream
+	streamMatches := regexp.MustCompile(`(?s)7 0 obj\n<< /Length (\d+) /Filter /FlateDecode >>\nstream\n`).FindIndex(content)
+	assert.NotNil(t, streamMatches)
+
+	reader, err := zlib.NewReader(bytes.NewReader(content[streamMatches[1]:]))
+	assert.Nil(t, err)
+
+	pageContent, err := io.ReadAll(reader)
+	assert.Nil(t, err)
+	assert.Equal(t, "BT 1 0 0 rg /F2 12 Tf 40 801.89 Td (Hello \\(World\\)) Tj ET\n", string(pageContent))
+}
+
+func TestPdfDocumentDrawOnCurrentPage(t *testing.T) {
+	document := NewPdfDocument("", time.Unix(0, 0))
+	document.DrawText(0, 0, "Ignored", PDF_FONT_REGULAR, 10, PdfColor{})
+	assert.Equal(t, 0, document.PageCount())
+
+	document.AddPage()
+	document.AddPage()
+	document.SetCurrentPage(0)
+	document.DrawLine(0, 0, 10, 10, 1, PdfColor{})
+
+	assert.Equal(t, 2, document.PageCount())
+	assert.NotEqual(t, 0, document.pages[0].Len())
+	assert.Equal(t, 0, document.pages[1].Len())
+}
+
+func TestPdfDocumentBytes_UnicodeFonts(t *testing.T) {
+	document := NewPdfDocument("报表", time.Unix(1700000000, 0))
+	document.SetUnicodeFonts(newTestPdfTrueTypeFont(t), nil)
+	document.AddPage()
+	document.DrawText(40, 40, "A中", PDF_FONT_REGULAR, 10, PdfColor{})
+	document.DrawText(40, 60, "文", PDF_FONT_BOLD, 10, PdfColor{R: 0xFF})
+
+	assert.True(t, document.CanDisplayText("A中文"))
+	assert.False(t, document.CanDisplayText("B"))
+	assert.False(t, document.HasUnsupportedText())
+	assert.InDelta(t, 16, document.GetTextWidth("A中", PDF_FONT_REGULAR, 10), 0.0001)
+	assert.Equal(t, "A...", document.TruncateText("A中文", PDF_FONT_REGULAR, 10, 22))
+
+	content, err := document.Bytes()
+	assert.Nil(t, err)
+
+	assert.Contains(t, string(content), "/Title <FEFF62A58868>")
+	assert.Contains(t, string(content), "3 0 obj\n<< /Type /Font /Subtype /Type0 /BaseFont /EZBKSA+EmbeddedFont1 /Encoding /Identity-H /DescendantFonts [8 0 R] /ToUnicode 11 0 R >>")
+	assert.Contains(t, string(content), "4 0 obj\n<< /Type /Font /Subtype /Type0 /BaseFont /EZBKSA+EmbeddedFont1 /Encoding /Identity-H /DescendantFonts [8 0 R] /ToUnicode 11 0 R >>")
+	assert.Contains(t, string(content), "8 0 obj\n<< /Type /Font /Subtype /CIDFontType2 /BaseFont /EZBKSA+EmbeddedFont1 /CIDSystemInfo << /Registry (Adobe) /Ordering (Identity) /Supplement 0 >> /FontDescriptor 9 0 R /CIDToGIDMap /Identity /DW 500 /W [1 [600] 2 [1000] 3 [1000]] >>")
+	assert.Contains(t, string(content), "9 0 obj\n<< /Type /FontDescriptor /FontName /EZBKSA+EmbeddedFont1 /Flags 4 /FontBBox [0 -100 1000 800] /ItalicAngle 0 /Ascent 800 /Descent -200 /CapHeight 800 /StemV 80 /FontFile2 10 0 R >>")
+
+	objectOffsets := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(content, -1)
+	assert.Equal(t, 11, len(objectOffsets))
+
+	for i := 0; i < len(objectOffsets); i++ {
+		offset, _ := strconv.Atoi(string(objectOffsets[i][1]))
+		assert.True(t, bytes.HasPrefix(content[offset:], []byte(strconv.Itoa(i+1)+" 0 obj\n")))
+	}
+
+	assert.Equal(t, "BT 0 0 0 rg /F1 10 Tf 40 801.89 Td <00010002> Tj ET\nq BT 1 0 0 rg 1 0 0 RG 2 Tr 0.3 w /F2 10 Tf 40 781.89 Td <0003> Tj ET Q\n", readTestPdfStream(t, content, 7))
+	assert.Contains(t, readTestPdfStream(t, content, 11), "3 beginbfchar\n<0001> <0041>\n<0002> <4E2D>\n<0003> <6587>\nendbfchar\n")
+}
+
+func TestPdfDocumentDrawText_UnsupportedText(t *testing.T) {
+	document := NewPdfDocument("", time.Unix(0, 0))
+	document.AddPage()
+	document.DrawText(40, 40, "Überweisung €", PDF_FONT_REGULAR, 10, PdfColor{})
+	assert.False(t, document.HasUnsupportedText())
+
+	document.DrawText(40, 40, "中", PDF_FONT_REGULAR, 10, PdfColor{})
+	assert.True(t, document.HasUnsupportedText())
+
+	document = NewPdfDocument("", time.Unix(0, 0))
+	document.SetUnicodeFonts(newTestPdfTrueTypeFont(t), newTestPdfTrueTypeFont(t))
+	document.AddPage()
+	document.DrawText(40, 40, "B", PDF_FONT_REGULAR, 10, PdfColor{})
+	assert.True(t, document.HasUnsupportedText())
+
+	content, err := document.Bytes()
+	assert.Nil(t, err)
+	assert.Contains(t, string(content), "/BaseFont /EZBKSB+EmbeddedFont2")
+}
+
+func readTestPdfStream(t *testing.T, content []byte, objectId int) string {
+	streamMatches := regexp.MustCompile(`(?s)\n` + strconv.Itoa(objectId) + ` 0 obj\n<< /Length (\d+)[^>]*>>\nstream\n`).FindIndex(content)
+	assert.NotNil(t, streamMatches)
+
+	reader, err := zlib.NewReader(bytes.NewReader(content[streamMatches[1]:]))
+	assert.Nil(t, err)
+
+	streamContent, err := io.ReadAll(reader)
+	assert.Nil(t, err)
+
+	return string(streamContent)
+}
+
+func TestGetPdfTextWidth(t *testing.T) {
+	assert.InDelta(t, 5.56, GetPdfTextWidth("a", PDF_FONT_REGULAR, 10), 0.0001)
+	assert.InDelta(t, 6.11, GetPdfTextWidth("b", PDF_FONT_BOLD, 10), 0.0001)
+	assert.InDelta(t, 30.024, GetPdfTextWidth("1,234", PDF_FONT_REGULAR, 12), 0.0001)
+	assert.InDelta(t, 5.56, GetPdfTextWidth("€", PDF_FONT_REGULAR, 10), 0.0001)
+	assert.InDelta(t, 5.56, GetPdfTextWidth("中", PDF_FONT_REGULAR, 10), 0.0001)
+}
+
+func TestTruncatePdfText(t *testing.T) {
+	assert.Equal(t, "Groceries", TruncatePdfText("Groceries", PDF_FONT_REGULAR, 10, 100))
+	assert.Equal(t, "Superma...", TruncatePdfText("Supermarket Shopping", PDF_FONT_REGULAR, 10, 50))
+	assert.Equal(t, "", TruncatePdfText("Supermarket", PDF_FONT_REGULAR, 10, 5))
+}
+
+func TestCanEncodePdfText(t *testing.T) {
+	assert.True(t, CanEncodePdfText("Überweisung 12,50 €"))
+	assert.False(t, CanEncodePdfText("交易报表"))
+	assert.False(t, CanEncodePdfText("Báo cáo giao dịch"))
+}
+
+func TestEncodePdfText(t *testing.T) {
+	assert.Equal(t, []byte{'A', 0x80, ' ', '?'}, encodePdfText("A€\n中"))
+}
+
+func TestFormatPdfNumber(t *testing.T) {
+	assert.Equal(t, "0", formatPdfNumber(0))
+	assert.Equal(t, "0", formatPdfNumber(-0.0001))
+	assert.Equal(t, "12", formatPdfNumber(12))
+	assert.Equal(t, "841.89", formatPdfNumber(841.89))
+	assert.Equal(t, "0.333", formatPdfNumber(1.0/3))
+}
@@ -0,0 +1,557 @@
+package reports
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"os"
+	"sort"
+
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/settings"
+)
+
+const pdfTrueTypeFontCollectionTag = "ttcf"
+
+var pdfTrueTypeFontRequiredTables = []string{"head", "hhea", "hmtx", "maxp", "cmap", "loca", "glyf"}
+
+// the tables which are kept in the embedded font subset, the cmap table is not required because the glyphs are selected by glyph index in pdf
+var pdfTrueTypeFontSubsetTables = []string{"cvt ", "fpgm", "glyf", "head", "hhea", "hmtx", "loca", "maxp", "prep"}
+
+var errInvalidTrueTypeFont = errors.New("invalid truetype font")
+
+var pdfRegularUnicodeFont *PdfTrueTypeFont
+var pdfBoldUnicodeFont *PdfTrueTypeFont
+
+// PdfTrueTypeFont represents a TrueType font which can be embedded in pdf document to display the characters
+// that the standard fonts do not support, only the fonts with TrueType outlines are supported
+type PdfTrueTypeFont struct {
+	tables           map[string][]byte
+	unitsPerEm       uint16
+	indexToLocFormat int16
+	numGlyphs        uint16
+	boundingBox      [4]int16
+	ascent           int16
+	descent          int16
+	capHeight        int16
+	advanceWidths    []uint16
+	runeGlyphIndexes map[rune]uint16
+}
+
+// InitializePdfFonts loads the TrueType fonts which are embedded in the pdf reports according to the config
+func InitializePdfFonts(config *settings.Config) error {
+	var regularFont *PdfTrueTypeFont
+	var boldFont *PdfTrueTypeFont
+	var err error
+
+	if config.PdfFontPath != "" {
+		regularFont, err = loadPdfTrueTypeFont(config.PdfFontPath)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	if config.PdfBoldFontPath != "" {
+		boldFont, err = loadPdfTrueTypeFont(config.PdfBoldFontPath)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	pdfRegularUnicodeFont = regularFont
+	pdfBoldUnicodeFont = boldFont
+
+	return nil
+}
+
+// ParsePdfTrueTypeFont returns the TrueType font which is parsed from the font file content,
+// the first font is used if the content is a TrueType collection
+func ParsePdfTrueTypeFont(content []byte) (*PdfTrueTypeFont, error) {
+	fontOffset := uint32(0)
+
+	if len(content) >= 16 && string(content[0:4]) == pdfTrueTypeFontCollectionTag {
+		fontOffset = binary.BigEndian.Uint32(content[12:16])
+	}
+
+	if uint64(fontOffset)+12 > uint64(len(content)) {
+		return nil, errInvalidTrueTypeFont
+	}
+
+	// the fonts with CFF outlines ("OTTO") are not supported
+	if binary.BigEndian.Uint32(content[fontOffset:fontOffset+4]) != 0x00010000 && string(content[fontOffset:fontOffset+4]) != "true" {
+		return nil, errInvalidTrueTypeFont
+	}
+
+	tables, err := readTrueTypeFontTables(content, int(fontOffset))
+
+	if err != nil {
+		return nil, err
+	}
+
+	for i := 0; i < len(pdfTrueTypeFontRequiredTables); i++ {
+		if _, exists := tables[pdfTrueTypeFontRequiredTables[i]]; !exists {
+			return nil, errInvalidTrueTypeFont
+		}
+	}
+
+	font := &PdfTrueTypeFont{
+		tables: tables,
+	}
+
+	if err := font.parseHeaderTables(); err != nil {
+		return nil, err
+	}
+
+	if err := font.parseHorizontalMetrics(); err != nil {
+		return nil, err
+	}
+
+	if err := font.parseCharacterMap(); err != nil {
+		return nil, err
+	}
+
+	return font, nil
+}
+
+// readTrueTypeFontTables returns all the tables of the font whose offset table starts at the specified offset
+func readTrueTypeFontTables(content []byte, fontOffset int) (map[string][]byte, error) {
+	tableCount := int(binary.BigEndian.Uint16(content[fontOffset+4 : fontOffset+6]))
+	tables := make(map[string][]byte, tableCount)
+
+	for i := 0; i < tableCount; i++ {
+		recordOffset := fontOffset + 12 + i*16
+
+		if recordOffset+16 > len(content) {
+			return nil, errInvalidTrueTypeFont
+		}
+
+		tag := string(content[recordOffset : recordOffset+4])
+		offset := binary.BigEndian.Uint32(content[recordOffset+8 : recordOffset+12])
+		length := binary.BigEndian.Uint32(content[recordOffset+12 : recordOffset+16])
+
+		if uint64(offset)+uint64(length) > uint64(len(content)) {
+			return nil, errInvalidTrueTypeFont
+		}
+
+		tables[tag] = content[offset : offset+length]
+	}
+
+	return tables, nil
+}
+
+func loadPdfTrueTypeFont(path string) (*PdfTrueTypeFont, error) {
+	content, err := os.ReadFile(path)
+
+	if err != nil {
+		return nil, errs.ErrInvalidPdfFontPath
+	}
+
+	font, err := ParsePdfTrueTypeFont(content)
+
+	if err != nil {
+		return nil, errs.ErrInvalidPdfFont
+	}
+
+	return font, nil
+}
+
+// GetGlyphIndex returns the glyph index of the specified character, and whether the font contains the glyph of this character
+func (f *PdfTrueTypeFont) GetGlyphIndex(r rune) (uint16, bool) {
+	glyphIndex, exists := f.runeGlyphIndexes[r]
+	return glyphIndex, exists && glyphIndex > 0
+}
+
+// getGlyphWidth returns the advance width of the specified glyph in the 1/1000 unit of text space
+func (f *PdfTrueTypeFont) getGlyphWidth(glyphIndex uint16) int {
+	return f.scale(int(f.advanceWidths[min(int(glyphIndex), len(f.advanceWidths)-1)]))
+}
+
+func (f *PdfTrueTypeFont) scale(value int) int {
+	return value * 1000 / int(f.unitsPerEm)
+}
+
+func (f *PdfTrueTypeFont) parseHeaderTables() error {
+	head := f.tables["head"]
+	hhea := f.tables["hhea"]
+	maxp := f.tables["maxp"]
+
+	if len(head) < 54 || len(hhea) < 36 || len(maxp) < 6 {
+		return errInvalidTrueTypeFont
+	}
+
+	f.unitsPerEm = binary.BigEndian.Uint16(head[18:20])
+	f.indexToLocFormat = int16(binary.BigEndian.Uint16(head[50:52]))
+	f.numGlyphs = binary.BigEndian.Uint16(maxp[4:6])
+
+	for i := 0; i < 4; i++ {
+		f.boundingBox[i] = int16(binary.BigEndian.Uint16(head[36+i*2 : 38+i*2]))
+	}
+
+	f.ascent = int16(binary.BigEndian.Uint16(hhea[4:6]))
+	f.descent = int16(binary.BigEndian.Uint16(hhea[6:8]))
+	f.capHeight = f.ascent
+
+	// the cap height is only available in the os/2 table whose version is 2 or later
+	if os2 := f.tables["OS/2"]; len(os2) >= 90 && binary.BigEndian.Uint16(os2[0:2]) >= 2 {
+		f.capHeight = int16(binary.BigEndian.Uint16(os2[88:90]))
+	}
+
+	if f.unitsPerEm == 0 || f.numGlyphs == 0 || (f.indexToLocFormat != 0 && f.indexToLocFormat != 1) {
+		return errInvalidTrueTypeFont
+	}
+
+	return nil
+}
+
+func (f *PdfTrueTypeFont) parseHorizontalMetrics() error {
+	metricCount := int(binary.BigEndian.Uint16(f.tables["hhea"][34:36]))
+	hmtx := f.tables["hmtx"]
+
+	if metricCount < 1 || len(hmtx) < metricCount*4 {
+		return errInvalidTrueTypeFont
+	}
+
+	// the glyphs after the last metric record use the same advance width as the last one
+	f.advanceWidths = make([]uint16, metricCount)
+
+	for i := 0; i < metricCount; i++ {
+		f.advanceWidths[i] = binary.BigEndian.Uint16(hmtx[i*4 : i*4+2])
+	}
+
+	return nil
+}
+
+func (f *PdfTrueTypeFont) parseCharacterMap() error {
+	cmap := f.tables["cmap"]
+
+	if len(cmap) < 4 {
+		return errInvalidTrueTypeFont
+	}
+
+	subtableCount := int(binary.BigEndian.Uint16(cmap[2:4]))
+	var bmpSubtable []byte
+	var fullSubtable []byte
+
+	for i := 0; i < subtableCount; i++ {
+		recordOffset := 4 + i*8
+
+		if recordOffset+8 > len(cmap) {
+			return errInvalidTrueTypeFont
+		}
+
+		platformId := binary.BigEndian.Uint16(cmap[recordOffset : recordOffset+2])
+		encodingId := binary.BigEndian.Uint16(cmap[recordOffset+2 : recordOffset+4])
+		offset := binary.BigEndian.Uint32(cmap[recordOffset+4 : recordOffset+8])
+
+		if uint64(offset)+2 > uint64(len(cmap)) {
+			return errInvalidTrueTypeFont
+		}
+
+		subtable := cmap[offset:]
+		format := binary.BigEndian.Uint16(subtable[0:2])
+
+		if format == 12 && ((platformId == 3 && encodingId == 10) || platformId == 0) {
+			fullSubtable = subtable
+		} else if format == 4 && ((platformId == 3 && encodingId == 1) || platformId == 0) {
+			bmpSubtable = subtable
+		}
+	}
+
+	f.runeGlyphIndexes = make(map[rune]uint16)
+
+	if fullSubtable != nil {
+		return f.parseSegmentedCoverageSubtable(fullSubtable)
+	} else if bmpSubtable != nil {
+		return f.parseSegmentMappingSubtable(bmpSubtable)
+	}
+
+	return errInvalidTrueTypeFont
+}
+
+// parseSegmentMappingSubtable parses the format 4 subtable of cmap table, which maps the characters in basic multilingual plane
+func (f *PdfTrueTypeFont) parseSegmentMappingSubtable(subtable []byte) error {
+	if len(subtable) < 14 {
+		return errInvalidTrueTypeFont
+	}
+
+	segmentCount := int(binary.BigEndian.Uint16(subtable[6:8])) / 2
+	endCodesOffset := 14
+	startCodesOffset := endCodesOffset + segmentCount*2 + 2
+	idDeltasOffset := startCodesOffset + segmentCount*2
+	idRangeOffsetsOffset := idDeltasOffset + segmentCount*2
+
+	if idRangeOffsetsOffset+segmentCount*2 > len(subtable) {
+		return errInvalidTrueTypeFont
+	}
+
+	for i := 0; i < segmentCount; i++ {
+		endCode := int(binary.BigEndian.Uint16(subtable[endCodesOffset+i*2:]))
+		startCode := int(binary.BigEndian.Uint16(subtable[startCodesOffset+i*2:]))
+		idDelta := int(binary.BigEndian.Uint16(subtable[idDeltasOffset+i*2:]))
+		idRangeOffsetPosition := idRangeOffsetsOffset + i*2
+		idRangeOffset := int(binary.BigEndian.Uint16(subtable[idRangeOffsetPosition:]))
+
+		for code := startCode; code <= endCode && code < 0xFFFF; code++ {
+			glyphIndex := 0
+
+			if idRangeOffset == 0 {
+				glyphIndex = (code + idDelta) & 0xFFFF
+			} else {
+				glyphIndexPosition := idRangeOffsetPosition + idRangeOffset + (code-startCode)*2
+
+				if glyphIndexPosition+2 > len(subtable) {
+					continue
+				}
+
+				glyphIndex = int(binary.BigEndian.Uint16(subtable[glyphIndexPosition:]))
+
+				if glyphIndex != 0 {
+					glyphIndex = (glyphIndex + idDelta) & 0xFFFF
+				}
+			}
+
+			if glyphIndex > 0 && glyphIndex < int(f.numGlyphs) {
+				f.runeGlyphIndexes[rune(code)] = uint16(glyphIndex)
+			}
+		}
+	}
+
+	return nil
+}
+
+// parseSegmentedCoverageSubtable parses the format 12 subtable of cmap table, which maps all the unicode characters
+func (f *PdfTrueTypeFont) parseSegmentedCoverageSubtable(subtable []byte) error {
+	if len(subtable) < 16 {
+		return errInvalidTrueTypeFont
+	}
+
+	groupCount := int(binary.BigEndian.Uint32(subtable[12:16]))
+
+	if 16+groupCount*12 > len(subtable) {
+		return errInvalidTrueTypeFont
+	}
+
+	for i := 0; i < groupCount; i++ {
+		groupOffset := 16 + i*12
+		startCode := binary.BigEndian.Uint32(subtable[groupOffset : groupOffset+4])
+		endCode := binary.BigEndian.Uint32(subtable[groupOffset+4 : groupOffset+8])
+		startGlyphIndex := binary.BigEndian.Uint32(subtable[groupOffset+8 : groupOffset+12])
+
+		for code := startCode; code <= endCode && code <= 0x10FFFF; code++ {
+			glyphIndex := startGlyphIndex + code - startCode
+
+			if glyphIndex > 0 && glyphIndex < uint32(f.numGlyphs) {
+				f.runeGlyphIndexes[rune(code)] = uint16(glyphIndex)
+			}
+		}
+	}
+
+	return nil
+}
+
+// getGlyphData returns the outline data of the specified glyph in glyf table
+func (f *PdfTrueTypeFont) getGlyphData(glyphIndex uint16) []byte {
+	loca := f.tables["loca"]
+	glyf := f.tables["glyf"]
+	var start, end uint32
+
+	if f.indexToLocFormat == 0 {
+		if int(glyphIndex)*2+4 > len(loca) {
+			return nil
+		}
+
+		start = uint32(binary.BigEndian.Uint16(loca[glyphIndex*2:])) * 2
+		end = uint32(binary.BigEndian.Uint16(loca[glyphIndex*2+2:])) * 2
+	} else {
+		if int(glyphIndex)*4+8 > len(loca) {
+			return nil
+		}
+
+		start = binary.BigEndian.Uint32(loca[glyphIndex*4:])
+		end = binary.BigEndian.Uint32(loca[glyphIndex*4+4:])
+	}
+
+	if start >= end || end > uint32(len(glyf)) {
+		return nil
+	}
+
+	return glyf[start:end]
+}
+
+// getComponentGlyphIndexes returns the glyph indexes which the composite glyph refers to
+func (f *PdfTrueTypeFont) getComponentGlyphIndexes(glyphData []byte) []uint16 {
+	if len(glyphData) < 10 || int16(binary.BigEndian.Uint16(glyphData[0:2])) >= 0 {
+		return nil
+	}
+
+	componentGlyphIndexes := make([]uint16, 0)
+	offset := 10
+
+	for offset+4 <= len(glyphData) {
+		flags := binary.BigEndian.Uint16(glyphData[offset : offset+2])
+		componentGlyphIndexes = append(componentGlyphIndexes, binary.BigEndian.Uint16(glyphData[offset+2:offset+4]))
+		offset += 4
+
+		if flags&0x0001 != 0 { // ARG_1_AND_2_ARE_WORDS
+			offset += 4
+		} else {
+			offset += 2
+		}
+
+		if flags&0x0008 != 0 { // WE_HAVE_A_SCALE
+			offset += 2
+		} else if flags&0x0040 != 0 { // WE_HAVE_AN_X_AND_Y_SCALE
+			offset += 4
+		} else if flags&0x0080 != 0 { // WE_HAVE_A_TWO_BY_TWO
+			offset += 8
+		}
+
+		if flags&0x0020 == 0 { // MORE_COMPONENTS
+			break
+		}
+	}
+
+	return componentGlyphIndexes
+}
+
+// subset returns the font file content which only contains the outlines of the specified glyphs and the glyphs they refer to,
+// the glyph indexes are not changed so that the text can still be encoded by the original glyph indexes
+func (f *PdfTrueTypeFont) subset(glyphIndexes map[uint16]bool) []byte {
+	includedGlyphIndexes := map[uint16]bool{0: true}
+	pendingGlyphIndexes := []uint16{0}
+
+	for glyphIndex := range glyphIndexes {
+		pendingGlyphIndexes = append(pendingGlyphIndexes, glyphIndex)
+	}
+
+	for len(pendingGlyphIndexes) > 0 {
+		glyphIndex := pendingGlyphIndexes[len(pendingGlyphIndexes)-1]
+		pendingGlyphIndexes = pendingGlyphIndexes[:len(pendingGlyphIndexes)-1]
+		includedGlyphIndexes[glyphIndex] = true
+
+		componentGlyphIndexes := f.getComponentGlyphIndexes(f.getGlyphData(glyphIndex))
+
+		for i := 0; i < len(componentGlyphIndexes); i++ {
+			if !includedGlyphIndexes[componentGlyphIndexes[i]] && componentGlyphIndexes[i] < f.numGlyphs {
+				pendingGlyphIndexes = append(pendingGlyphIndexes, componentGlyphIndexes[i])
+			}
+		}
+	}
+
+	var glyf bytes.Buffer
+	loca := make([]byte, (int(f.numGlyphs)+1)*4)
+
+	for glyphIndex := 0; glyphIndex < int(f.numGlyphs); glyphIndex++ {
+		binary.BigEndian.PutUint32(loca[glyphIndex*4:], uint32(glyf.Len()))
+
+		if includedGlyphIndexes[uint16(glyphIndex)] {
+			glyf.Write(f.getGlyphData(uint16(glyphIndex)))
+
+			for glyf.Len()%4 != 0 {
+				glyf.WriteByte(0)
+			}
+		}
+	}
+
+	binary.BigEndian.PutUint32(loca[int(f.numGlyphs)*4:], uint32(glyf.Len()))
+
+	// the subset uses the long format of loca table, and the whole font checksum is not recalculated
+	head := make([]byte, len(f.tables["head"]))
+	copy(head, f.tables["head"])
+	binary.BigEndian.PutUint32(head[8:12], 0)
+	binary.BigEndian.PutUint16(head[50:52], 1)
+
+	subsetTables := map[string][]byte{
+		"glyf": glyf.Bytes(),
+		"head": head,
+		"loca": loca,
+	}
+
+	tags := make([]string, 0, len(pdfTrueTypeFontSubsetTables))
+
+	for i := 0; i < len(pdfTrueTypeFontSubsetTables); i++ {
+		tag := pdfTrueTypeFontSubsetTables[i]
+
+		if _, exists := subsetTables[tag]; !exists {
+			table, exists := f.tables[tag]
+
+			if !exists {
+				continue
+			}
+
+			subsetTables[tag] = table
+		}
+
+		tags = append(tags, tag)
+	}
+
+	sort.Strings(tags)
+
+	return writeTrueTypeFontTables(tags, subsetTables)
+}
+
+func writeTrueTypeFontTables(tags []string, tables map[string][]byte) []byte {
+	var buffer bytes.Buffer
+	searchRange := 1
+	entrySelector := 0
+
+	for searchRange*2 <= len(tags) {
+		searchRange *= 2
+		entrySelector++
+	}
+
+	header := make([]byte, 12)
+	binary.BigEndian.PutUint32(header[0:4], 0x00010000)
+	binary.BigEndian.PutUint16(header[4:6], uint16(len(tags)))
+	binary.BigEndian.PutUint16(header[6:8], uint16(searchRange*16))
+	binary.BigEndian.PutUint16(header[8:10], uint16(entrySelector))
+	binary.BigEndian.PutUint16(header[10:12], uint16(len(tags)*16-searchRange*16))
+	buffer.Write(header)
+
+	offset := 12 + len(tags)*16
+
+	for i := 0; i < len(tags); i++ {
+		table := tables[tags[i]]
+		record := make([]byte, 16)
+		copy(record[0:4], tags[i])
+		binary.BigEndian.PutUint32(record[4:8], getTrueTypeFontTableChecksum(table))
+		binary.BigEndian.PutUint32(record[8:12], uint32(offset))
+		binary.BigEndian.PutUint32(record[12:16], uint32(len(table)))
+		buffer.Write(record)
+
+		offset += (len(table) + 3) / 4 * 4
+	}
+
+	for i := 0; i < len(tags); i++ {
+		table := tables[tags[i]]
+		buffer.Write(table)
+
+		for j := len(table); j%4 != 0; j++ {
+			buffer.WriteByte(0)
+		}
+	}
+
+	return buffer.Bytes()
+}
+
+func getTrueTypeFontTableChecksum(table []byte) uint32 {
+	checksum := uint32(0)
+
+	for i := 0; i < len(table); i += 4 {
+		value := uint32(0)
+
+		for j := 0; j < 4; j++ {
+			value <<= 8
+
+			if i+j < len(table) {
+				value |= uint32(table[i+j])
+			}
+		}
+
+		checksum += value
+	}
+
+	return checksum
+}
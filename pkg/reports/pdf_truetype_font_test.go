@@ -0,0 +1,189 @@
+package reports
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestTrueTypeFontContent returns a minimal TrueType font which maps "A" to glyph 1, "中" to glyph 2,
+// and "文" to glyph 3 which is a composite glyph referring to glyph 1
+func newTestTrueTypeFontContent() []byte {
+	head := make([]byte, 54)
+	binary.BigEndian.PutUint32(head[0:4], 0x00010000)
+	binary.BigEndian.PutUint16(head[18:20], 2000)
+	binary.BigEndian.PutUint16(head[36:38], uint16(0))
+	binary.BigEndian.PutUint16(head[38:40], uint16(0xFF38)) // -200
+	binary.BigEndian.PutUint16(head[40:42], 2000)
+	binary.BigEndian.PutUint16(head[42:44], 1600)
+
+	hhea := make([]byte, 36)
+	binary.BigEndian.PutUint16(hhea[4:6], 1600)
+	binary.BigEndian.PutUint16(hhea[6:8], uint16(0xFE70)) // -400
+	binary.BigEndian.PutUint16(hhea[34:36], 3)
+
+	maxp := make([]byte, 6)
+	binary.BigEndian.PutUint32(maxp[0:4], 0x00005000)
+	binary.BigEndian.PutUint16(maxp[4:6], 4)
+
+	hmtx := make([]byte, 14)
+	binary.BigEndian.PutUint16(hmtx[0:2], 1000)
+	binary.BigEndian.PutUint16(hmtx[4:6], 1200)
+	binary.BigEndian.PutUint16(hmtx[8:10], 2000)
+
+	// "文" is mapped by the glyph index array, and the others are mapped by the id deltas
+	segments := []struct {
+		code          uint16
+		idDelta       uint16
+		idRangeOffset uint16
+	}{
+		{code: 'A', idDelta: 0x10000 + 1 - 'A'},
+		{code: '中', idDelta: 0x10000 + 2 - '中'},
+		{code: '文', idRangeOffset: 2 * 2}, // the glyph index array is right after the id range offsets
+		{code: 0xFFFF, idDelta: 1},
+	}
+
+	subtable := make([]byte, 14+2+len(segments)*8+2)
+	binary.BigEndian.PutUint16(subtable[0:2], 4)
+	binary.BigEndian.PutUint16(subtable[2:4], uint16(len(subtable)))
+	binary.BigEndian.PutUint16(subtable[6:8], uint16(len(segments)*2))
+
+	for i := 0; i < len(segments); i++ {
+		binary.BigEndian.PutUint16(subtable[14+i*2:], segments[i].code)
+		binary.BigEndian.PutUint16(subtable[14+len(segments)*2+2+i*2:], segments[i].code)
+		binary.BigEndian.PutUint16(subtable[14+len(segments)*4+2+i*2:], segments[i].idDelta)
+		binary.BigEndian.PutUint16(subtable[14+len(segments)*6+2+i*2:], segments[i].idRangeOffset)
+	}
+
+	binary.BigEndian.PutUint16(subtable[14+len(segments)*8+2:], 3)
+
+	cmap := make([]byte, 12)
+	binary.BigEndian.PutUint16(cmap[2:4], 1)
+	binary.BigEndian.PutUint16(cmap[4:6], 3)
+	binary.BigEndian.PutUint16(cmap[6:8], 1)
+	binary.BigEndian.PutUint32(cmap[8:12], 12)
+	cmap = append(cmap, subtable...)
+
+	simpleGlyph := []byte{0, 1, 0, 0, 0, 0, 0, 10, 0, 10, 0, 0, 0, 0, 0x37, 10, 10, 0}
+	anotherSimpleGlyph := []byte{0, 1, 0, 0, 0, 0, 0, 20, 0, 20, 0, 0, 0, 0, 0x37, 20, 20, 0}
+	compositeGlyph := []byte{0xFF, 0xFF, 0, 0, 0, 0, 0, 10, 0, 10, 0, 0x03, 0, 1, 0, 5, 0, 5}
+
+	glyf := make([]byte, 0)
+	loca := make([]byte, 10)
+	glyphs := [][]byte{nil, simpleGlyph, anotherSimpleGlyph, compositeGlyph}
+
+	for i := 0; i < len(glyphs); i++ {
+		binary.BigEndian.PutUint16(loca[i*2:], uint16(len(glyf)/2))
+		glyf = append(glyf, glyphs[i]...)
+	}
+
+	binary.BigEndian.PutUint16(loca[8:], uint16(len(glyf)/2))
+
+	return writeTrueTypeFontTables([]string{"cmap", "glyf", "head", "hhea", "hmtx", "loca", "maxp"}, map[string][]byte{
+		"cmap": cmap,
+		"glyf": glyf,
+		"head": head,
+		"hhea": hhea,
+		"hmtx": hmtx,
+		"loca": loca,
+		"maxp": maxp,
+	})
+}
+
+func newTestPdfTrueTypeFont(t *testing.T) *PdfTrueTypeFont {
+	font, err := ParsePdfTrueTypeFont(newTestTrueTypeFontContent())
+	assert.Nil(t, err)
+
+	return font
+}
+
+func TestParsePdfTrueTypeFont(t *testing.T) {
+	font := newTestPdfTrueTypeFont(t)
+
+	assert.Equal(t, uint16(2000), font.unitsPerEm)
+	assert.Equal(t, uint16(4), font.numGlyphs)
+	assert.Equal(t, [4]int16{0, -200, 2000, 1600}, font.boundingBox)
+	assert.Equal(t, int16(1600), font.ascent)
+	assert.Equal(t, int16(-400), font.descent)
+
+	glyphIndex, exists := font.GetGlyphIndex('A')
+	assert.True(t, exists)
+	assert.Equal(t, uint16(1), glyphIndex)
+
+	glyphIndex, exists = font.GetGlyphIndex('中')
+	assert.True(t, exists)
+	assert.Equal(t, uint16(2), glyphIndex)
+
+	glyphIndex, exists = font.GetGlyphIndex('文')
+	assert.True(t, exists)
+	assert.Equal(t, uint16(3), glyphIndex)
+
+	_, exists = font.GetGlyphIndex('B')
+	assert.False(t, exists)
+
+	assert.Equal(t, 500, font.getGlyphWidth(0))
+	assert.Equal(t, 600, font.getGlyphWidth(1))
+	assert.Equal(t, 1000, font.getGlyphWidth(2))
+	assert.Equal(t, 1000, font.getGlyphWidth(3))
+}
+
+func TestParsePdfTrueTypeFont_Collection(t *testing.T) {
+	fontContent := newTestTrueTypeFontContent()
+	collectionHeader := make([]byte, 16)
+	copy(collectionHeader[0:4], pdfTrueTypeFontCollectionTag)
+	binary.BigEndian.PutUint32(collectionHeader[4:8], 0x00010000)
+	binary.BigEndian.PutUint32(collectionHeader[8:12], 1)
+	binary.BigEndian.PutUint32(collectionHeader[12:16], 16)
+
+	// the table offsets in the collection are relative to the beginning of the file
+	tables, err := readTrueTypeFontTables(fontContent, 0)
+	assert.Nil(t, err)
+
+	tags := []string{"cmap", "glyf", "head", "hhea", "hmtx", "loca", "maxp"}
+	content := writeTrueTypeFontTables(tags, tables)
+
+	for i := 0; i < len(tags); i++ {
+		recordOffset := 12 + i*16
+		binary.BigEndian.PutUint32(content[recordOffset+8:], binary.BigEndian.Uint32(content[recordOffset+8:])+16)
+	}
+
+	font, err := ParsePdfTrueTypeFont(append(collectionHeader, content...))
+	assert.Nil(t, err)
+
+	glyphIndex, exists := font.GetGlyphIndex('中')
+	assert.True(t, exists)
+	assert.Equal(t, uint16(2), glyphIndex)
+}
+
+func TestParsePdfTrueTypeFont_InvalidContent(t *testing.T) {
+	_, err := ParsePdfTrueTypeFont([]byte{})
+	assert.Equal(t, errInvalidTrueTypeFont, err)
+
+	_, err = ParsePdfTrueTypeFont([]byte("OTTO\x00\x00\x00\x00\x00\x00\x00\x00"))
+	assert.Equal(t, errInvalidTrueTypeFont, err)
+
+	content := newTestTrueTypeFontContent()
+	binary.BigEndian.PutUint16(content[4:6], 1)
+	_, err = ParsePdfTrueTypeFont(content)
+	assert.Equal(t, errInvalidTrueTypeFont, err)
+}
+
+func TestPdfTrueTypeFontSubset(t *testing.T) {
+	font := newTestPdfTrueTypeFont(t)
+	content := font.subset(map[uint16]bool{3: true})
+
+	tables, err := readTrueTypeFontTables(content, 0)
+	assert.Nil(t, err)
+	assert.NotContains(t, tables, "cmap")
+
+	subsetFont := &PdfTrueTypeFont{tables: tables}
+	assert.Nil(t, subsetFont.parseHeaderTables())
+	assert.Equal(t, int16(1), subsetFont.indexToLocFormat)
+
+	// the composite glyph and the glyph it refers to are kept with padding, and the glyph indexes are not changed
+	assert.True(t, bytes.HasPrefix(subsetFont.getGlyphData(1), font.getGlyphData(1)))
+	assert.True(t, bytes.HasPrefix(subsetFont.getGlyphData(3), font.getGlyphData(3)))
+	assert.Nil(t, subsetFont.getGlyphData(2))
+}
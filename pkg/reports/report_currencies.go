@@ -0,0 +1,173 @@
+package reports
+
+// reportCurrencyInfo represents the display information of currency which is used in report
+type reportCurrencyInfo struct {
+	Fraction     int
+	Symbol       string
+	PluralSymbol string
+	Unit         string
+	Name         string
+}
+
+// allReportCurrencyInfos represents the display information of all currencies, which is the same as the frontend
+var allReportCurrencyInfos = map[string]*reportCurrencyInfo{
+	"AED": {Fraction: 2, Symbol: "Dh", PluralSymbol: "Dhs", Unit: "Dirham", Name: "UAE Dirham"},
+	"AFN": {Fraction: 2, Symbol: "Af.", PluralSymbol: "Afs.", Unit: "Afghani", Name: "Afghani"},
+	"ALL": {Fraction: 2, Symbol: "L", PluralSymbol: "", Unit: "Lek", Name: "Lek"},
+	"AMD": {Fraction: 2, Symbol: "֏", PluralSymbol: "", Unit: "Dram", Name: "Armenian Dram"},
+	"ANG": {Fraction: 2, Symbol: "ƒ", PluralSymbol: "", Unit: "Guilder", Name: "Netherlands Antillean Guilder"},
+	"AOA": {Fraction: 2, Symbol: "Kz", PluralSymbol: "", Unit: "Kwanza", Name: "Kwanza"},
+	"ARS": {Fraction: 2, Symbol: "$", PluralSymbol: "", Unit: "Peso", Name: "Argentine Peso"},
+	"AUD": {Fraction: 2, Symbol: "$", PluralSymbol: "", Unit: "Dollar", Name: "Australian Dollar"},
+	"AWG": {Fraction: 2, Symbol: "Afl.", PluralSymbol: "", Unit: "Florin", Name: "Aruban Florin"},
+	"AZN": {Fraction: 2, Symbol: "₼", PluralSymbol: "", Unit: "Manat", Name: "Azerbaijan Manat"},
+	"BAM": {Fraction: 2, Symbol: "KM", PluralSymbol: "", Unit: "Mark", Name: "Convertible Mark"},
+	"BBD": {Fraction: 2, Symbol: "$", PluralSymbol: "", Unit: "Dollar", Name: "Barbados Dollar"},
+	"BDT": {Fraction: 2, Symbol: "৳", PluralSymbol: "", Unit: "Taka", Name: "Taka"},
+	"BGN": {Fraction: 2, Symbol: "лв", PluralSymbol: "", Unit: "Lev", Name: "Bulgarian Lev"},
+	"BHD": {Fraction: 3, Symbol: "BD", PluralSymbol: "", Unit: "Dinar", Name: "Bahraini Dinar"},
+	"BIF": {Fraction: 0, Symbol: "FBu", PluralSymbol: "", Unit: "Franc", Name: "Burundi Franc"},
+	"BMD": {Fraction: 2, Symbol: "$", PluralSymbol: "", Unit: "Dollar", Name: "Bermudian Dollar"},
+	"BND": {Fraction: 2, Symbol: "$", PluralSymbol: "", Unit: "Dollar", Name: "Brunei Dollar"},
+	"BOB": {Fraction: 2, Symbol: "Bs", PluralSymbol: "", Unit: "Boliviano", Name: "Boliviano"},
+	"BRL": {Fraction: 2, Symbol: "R$", PluralSymbol: "", Unit: "Real", Name: "Brazilian Real"},
+	"BSD": {Fraction: 2, Symbol: "$", PluralSymbol: "", Unit: "Dollar", Name: "Bahamian Dollar"},
+	"BTN": {Fraction: 2, Symbol: "Nu.", PluralSymbol: "", Unit: "Ngultrum", Name: "Ngultrum"},
+	"BWP": {Fraction: 2, Symbol: "P", PluralSymbol: "", Unit: "Pula", Name: "Pula"},
+	"BYN": {Fraction: 2, Symbol: "Rbl", PluralSymbol: "Rbls", Unit: "Ruble", Name: "Belarusian Ruble"},
+	"BZD": {Fraction: 2, Symbol: "$", PluralSymbol: "", Unit: "Dollar", Name: "Belize Dollar"},
+	"CAD": {Fraction: 2, Symbol: "$", PluralSymbol: "", Unit: "Dollar", Name: "Canadian Dollar"},
+	"CDF": {Fraction: 2, Symbol: "FC", PluralSymbol: "", Unit: "Franc", Name: "Congolese Franc"},
+	"CHF": {Fraction: 2, Symbol: "CHF", PluralSymbol: "", Unit: "Franc", Name: "Swiss Franc"},
+	"CLP": {Fraction: 0, Symbol: "$", PluralSymbol: "", Unit: "Peso", Name: "Chilean Peso"},
+	"CNY": {Fraction: 2, Symbol: "¥", PluralSymbol: "", Unit: "Yuan", Name: "Yuan Renminbi"},
+	"COP": {Fraction: 2, Symbol: "$", PluralSymbol: "", Unit: "Peso", Name: "Colombian Peso"},
+	"CRC": {Fraction: 2, Symbol: "₡", PluralSymbol: "", Unit: "Colon", Name: "Costa Rican Colon"},
+	"CUC": {Fraction: 2, Symbol: "$", PluralSymbol: "", Unit: "Peso", Name: "Peso Convertible"},
+	"CUP": {Fraction: 2, Symbol: "$", PluralSymbol: "", Unit: "Peso", Name: "Cuban Peso"},
+	"CVE": {Fraction: 2, Symbol: "$", PluralSymbol: "", Unit: "Escudo", Name: "Cabo Verde Escudo"},
+	"CZK": {Fraction: 2, Symbol: "Kč", PluralSymbol: "", Unit: "Koruna", Name: "Czech Koruna"},
+	"DJF": {Fraction: 0, Symbol: "Fdj", PluralSymbol: "", Unit: "Franc", Name: "Djibouti Franc"},
+	"DKK": {Fraction: 2, Symbol: "kr.", PluralSymbol: "", Unit: "Krone", Name: "Danish Krone"},
+	"DOP": {Fraction: 2, Symbol: "$", PluralSymbol: "", Unit: "Peso", Name: "Dominican Peso"},
+	"DZD": {Fraction: 2, Symbol: "DA", PluralSymbol: "", Unit: "Dinar", Name: "Algerian Dinar"},
+	"EGP": {Fraction: 2, Symbol: "£", PluralSymbol: "", Unit: "Pound", Name: "Egyptian Pound"},
+	"ERN": {Fraction: 2, Symbol: "Nkf", PluralSymbol: "", Unit: "Nakfa", Name: "Nakfa"},
+	"ETB": {Fraction: 2, Symbol: "Br", PluralSymbol: "", Unit: "Birr", Name: "Ethiopian Birr"},
+	"EUR": {Fraction: 2, Symbol: "€", PluralSymbol: "", Unit: "Euro", Name: "Euro"},
+	"FJD": {Fraction: 2, Symbol: "$", PluralSymbol: "", Unit: "Dollar", Name: "Fiji Dollar"},
+	"FKP": {Fraction: 2, Symbol: "£", PluralSymbol: "", Unit: "Pound", Name: "Falkland Islands Pound"},
+	"GBP": {Fraction: 2, Symbol: "£", PluralSymbol: "", Unit: "Pound", Name: "Pound Sterling"},
+	"GEL": {Fraction: 2, Symbol: "ლ", PluralSymbol: "", Unit: "Lari", Name: "Lari"},
+	"GHS": {Fraction: 2, Symbol: "GH₵", PluralSymbol: "", Unit: "Cedi", Name: "Ghana Cedi"},
+	"GIP": {Fraction: 2, Symbol: "£", PluralSymbol: "", Unit: "Pound", Name: "Gibraltar Pound"},
+	"GMD": {Fraction: 2, Symbol: "D", PluralSymbol: "", Unit: "Dalasi", Name: "Dalasi"},
+	"GNF": {Fraction: 0, Symbol: "FG", PluralSymbol: "", Unit: "Franc", Name: "Guinean Franc"},
+	"GTQ": {Fraction: 2, Symbol: "Q", PluralSymbol: "", Unit: "Quetzal", Name: "Quetzal"},
+	"GYD": {Fraction: 2, Symbol: "$", PluralSymbol: "", Unit: "Dollar", Name: "Guyana Dollar"},
+	"HKD": {Fraction: 2, Symbol: "HK$", PluralSymbol: "", Unit: "Dollar", Name: "Hong Kong Dollar"},
+	"HNL": {Fraction: 2, Symbol: "L", PluralSymbol: "", Unit: "Lempira", Name: "Lempira"},
+	"HTG": {Fraction: 2, Symbol: "G", PluralSymbol: "", Unit: "Gourde", Name: "Gourde"},
+	"HUF": {Fraction: 2, Symbol: "Ft", PluralSymbol: "", Unit: "Forint", Name: "Forint"},
+	"IDR": {Fraction: 2, Symbol: "Rp", PluralSymbol: "", Unit: "Rupiah", Name: "Rupiah"},
+	"ILS": {Fraction: 2, Symbol: "₪", PluralSymbol: "", Unit: "Shekel", Name: "New Israeli Sheqel"},
+	"INR": {Fraction: 2, Symbol: "₹", PluralSymbol: "", Unit: "Rupee", Name: "Indian Rupee"},
+	"IQD": {Fraction: 3, Symbol: "ID", PluralSymbol: "", Unit: "Dinar", Name: "Iraqi Dinar"},
+	"IRR": {Fraction: 2, Symbol: "Rl", PluralSymbol: "Rls", Unit: "Rial", Name: "Iranian Rial"},
+	"ISK": {Fraction: 0, Symbol: "kr", PluralSymbol: "", Unit: "Krona", Name: "Iceland Krona"},
+	"JMD": {Fraction: 2, Symbol: "$", PluralSymbol: "", Unit: "Dollar", Name: "Jamaican Dollar"},
+	"JOD": {Fraction: 3, Symbol: "د.أ", PluralSymbol: "", Unit: "Dinar", Name: "Jordanian Dinar"},
+	"JPY": {Fraction: 0, Symbol: "¥", PluralSymbol: "", Unit: "Yen", Name: "Yen"},
+	"KES": {Fraction: 2, Symbol: "/=", PluralSymbol: "", Unit: "Shilling", Name: "Kenyan Shilling"},
+	"KGS": {Fraction: 2, Symbol: "⃀", PluralSymbol: "", Unit: "Som", Name: "Som"},
+	"KHR": {Fraction: 2, Symbol: "៛", PluralSymbol: "", Unit: "Riel", Name: "Riel"},
+	"KMF": {Fraction: 0, Symbol: "CF", PluralSymbol: "", Unit: "Franc", Name: "Comorian Franc"},
+	"KPW": {Fraction: 2, Symbol: "₩", PluralSymbol: "", Unit: "Won", Name: "North Korean Won"},
+	"KRW": {Fraction: 0, Symbol: "₩", PluralSymbol: "", Unit: "Won", Name: "Won"},
+	"KWD": {Fraction: 3, Symbol: "KD", PluralSymbol: "", Unit: "Dinar", Name: "Kuwaiti Dinar"},
+	"KYD": {Fraction: 2, Symbol: "$", PluralSymbol: "", Unit: "Dollar", Name: "Cayman Islands Dollar"},
+	"KZT": {Fraction: 2, Symbol: "₸", PluralSymbol: "", Unit: "Tenge", Name: "Tenge"},
+	"LAK": {Fraction: 2, Symbol: "₭", PluralSymbol: "", Unit: "Kip", Name: "Lao Kip"},
+	"LBP": {Fraction: 2, Symbol: "LL", PluralSymbol: "", Unit: "Pound", Name: "Lebanese Pound"},
+	"LKR": {Fraction: 2, Symbol: "රු", PluralSymbol: "", Unit: "Rupee", Name: "Sri Lanka Rupee"},
+	"LRD": {Fraction: 2, Symbol: "$", PluralSymbol: "", Unit: "Dollar", Name: "Liberian Dollar"},
+	"LSL": {Fraction: 2, Symbol: "L", PluralSymbol: "M", Unit: "Loti", Name: "Loti"},
+	"LYD": {Fraction: 3, Symbol: "LD", PluralSymbol: "", Unit: "Dinar", Name: "Libyan Dinar"},
+	"MAD": {Fraction: 2, Symbol: "DH", PluralSymbol: "", Unit: "Dirham", Name: "Moroccan Dirham"},
+	"MDL": {Fraction: 2, Symbol: "L", PluralSymbol: "", Unit: "Leu", Name: "Moldovan Leu"},
+	"MGA": {Fraction: 2, Symbol: "Ar", PluralSymbol: "", Unit: "Ariary", Name: "Malagasy Ariary"},
+	"MKD": {Fraction: 2, Symbol: "DEN", PluralSymbol: "", Unit: "Denar", Name: "Denar"},
+	"MMK": {Fraction: 2, Symbol: "K", PluralSymbol: "Ks.", Unit: "Kyat", Name: "Kyat"},
+	"MNT": {Fraction: 2, Symbol: "₮", PluralSymbol: "", Unit: "Tugrik", Name: "Tugrik"},
+	"MOP": {Fraction: 2, Symbol: "$", PluralSymbol: "", Unit: "Pataca", Name: "Pataca"},
+	"MRU": {Fraction: 2, Symbol: "UM", PluralSymbol: "", Unit: "Ouguiya", Name: "Ouguiya"},
+	"MUR": {Fraction: 2, Symbol: "Re.", PluralSymbol: "Rs.", Unit: "Rupee", Name: "Mauritius Rupee"},
+	"MVR": {Fraction: 2, Symbol: "Rf.", PluralSymbol: "", Unit: "Rufiyaa", Name: "Rufiyaa"},
+	"MWK": {Fraction: 2, Symbol: "K", PluralSymbol: "", Unit: "Kwacha", Name: "Malawi Kwacha"},
+	"MXN": {Fraction: 2, Symbol: "$", PluralSymbol: "", Unit: "Peso", Name: "Mexican Peso"},
+	"MYR": {Fraction: 2, Symbol: "RM", PluralSymbol: "", Unit: "Ringgit", Name: "Malaysian Ringgit"},
+	"MZN": {Fraction: 2, Symbol: "MT", PluralSymbol: "", Unit: "Metical", Name: "Mozambique Metical"},
+	"NAD": {Fraction: 2, Symbol: "$", PluralSymbol: "", Unit: "Dollar", Name: "Namibia Dollar"},
+	"NGN": {Fraction: 2, Symbol: "₦", PluralSymbol: "", Unit: "Naira", Name: "Naira"},
+	"NIO": {Fraction: 2, Symbol: "C$", PluralSymbol: "", Unit: "Cordoba", Name: "Cordoba Oro"},
+	"NOK": {Fraction: 2, Symbol: "kr", PluralSymbol: "", Unit: "Krone", Name: "Norwegian Krone"},
+	"NPR": {Fraction: 2, Symbol: "रु", PluralSymbol: "", Unit: "Rupee", Name: "Nepalese Rupee"},
+	"NZD": {Fraction: 2, Symbol: "$", PluralSymbol: "", Unit: "Dollar", Name: "New Zealand Dollar"},
+	"OMR": {Fraction: 3, Symbol: "R.O", PluralSymbol: "", Unit: "Rial", Name: "Rial Omani"},
+	"PAB": {Fraction: 2, Symbol: "B/.", PluralSymbol: "", Unit: "Balboa", Name: "Balboa"},
+	"PEN": {Fraction: 2, Symbol: "S/", PluralSymbol: "", Unit: "Sol", Name: "Sol"},
+	"PGK": {Fraction: 2, Symbol: "K", PluralSymbol: "", Unit: "Kina", Name: "Kina"},
+	"PHP": {Fraction: 2, Symbol: "₱", PluralSymbol: "", Unit: "Peso", Name: "Philippine Peso"},
+	"PKR": {Fraction: 2, Symbol: "Re.", PluralSymbol: "Rs.", Unit: "Rupee", Name: "Pakistan Rupee"},
+	"PLN": {Fraction: 2, Symbol: "zł", PluralSymbol: "", Unit: "Zloty", Name: "Zloty"},
+	"PYG": {Fraction: 0, Symbol: "₲", PluralSymbol: "", Unit: "Guarani", Name: "Guarani"},
+	"QAR": {Fraction: 2, Symbol: "QR", PluralSymbol: "", Unit: "Rial", Name: "Qatari Rial"},
+	"RON": {Fraction: 2, Symbol: "L", PluralSymbol: "", Unit: "Leu", Name: "Romanian Leu"},
+	"RSD": {Fraction: 2, Symbol: "din.", PluralSymbol: "", Unit: "Dinar", Name: "Serbian Dinar"},
+	"RUB": {Fraction: 2, Symbol: "₽", PluralSymbol: "", Unit: "Ruble", Name: "Russian Ruble"},
+	"RWF": {Fraction: 0, Symbol: "FRw", PluralSymbol: "", Unit: "Franc", Name: "Rwanda Franc"},
+	"SAR": {Fraction: 2, Symbol: "SAR", PluralSymbol: "", Unit: "Riyal", Name: "Saudi Riyal"},
+	"SBD": {Fraction: 2, Symbol: "$", PluralSymbol: "", Unit: "Dollar", Name: "Solomon Islands Dollar"},
+	"SCR": {Fraction: 2, Symbol: "Re.", PluralSymbol: "Rs.", Unit: "Rupee", Name: "Seychelles Rupee"},
+	"SDG": {Fraction: 2, Symbol: "LS", PluralSymbol: "", Unit: "Pound", Name: "Sudanese Pound"},
+	"SEK": {Fraction: 2, Symbol: "kr", PluralSymbol: "", Unit: "Krona", Name: "Swedish Krona"},
+	"SGD": {Fraction: 2, Symbol: "$", PluralSymbol: "", Unit: "Dollar", Name: "Singapore Dollar"},
+	"SHP": {Fraction: 2, Symbol: "£", PluralSymbol: "", Unit: "Pound", Name: "Saint Helena Pound"},
+	"SLE": {Fraction: 2, Symbol: "Le", PluralSymbol: "", Unit: "Leone", Name: "Leone"},
+	"SOS": {Fraction: 2, Symbol: "Sh.So.", PluralSymbol: "", Unit: "Shilling", Name: "Somali Shilling"},
+	"SRD": {Fraction: 2, Symbol: "$", PluralSymbol: "", Unit: "Dollar", Name: "Surinam Dollar"},
+	"SSP": {Fraction: 2, Symbol: "SS£", PluralSymbol: "", Unit: "Pound", Name: "South Sudanese Pound"},
+	"STN": {Fraction: 2, Symbol: "Db", PluralSymbol: "", Unit: "Dobra", Name: "Dobra"},
+	"SVC": {Fraction: 2, Symbol: "₡", PluralSymbol: "", Unit: "Colon", Name: "El Salvador Colon"},
+	"SYP": {Fraction: 2, Symbol: "LS", PluralSymbol: "", Unit: "Pound", Name: "Syrian Pound"},
+	"SZL": {Fraction: 2, Symbol: "E", PluralSymbol: "", Unit: "Lilangeni", Name: "Lilangeni"},
+	"THB": {Fraction: 2, Symbol: "฿", PluralSymbol: "", Unit: "Baht", Name: "Baht"},
+	"TJS": {Fraction: 2, Symbol: "SM", PluralSymbol: "", Unit: "Somoni", Name: "Somoni"},
+	"TMT": {Fraction: 2, Symbol: "m", PluralSymbol: "", Unit: "Manat", Name: "Turkmenistan New Manat"},
+	"TND": {Fraction: 3, Symbol: "DT", PluralSymbol: "", Unit: "Dinar", Name: "Tunisian Dinar"},
+	"TOP": {Fraction: 2, Symbol: "T$", PluralSymbol: "", Unit: "Paanga", Name: "Pa’anga"},
+	"TRY": {Fraction: 2, Symbol: "₺", PluralSymbol: "", Unit: "Lira", Name: "Turkish Lira"},
+	"TTD": {Fraction: 2, Symbol: "$", PluralSymbol: "", Unit: "Dollar", Name: "Trinidad and Tobago Dollar"},
+	"TWD": {Fraction: 2, Symbol: "NT$", PluralSymbol: "", Unit: "Dollar", Name: "New Taiwan Dollar"},
+	"TZS": {Fraction: 2, Symbol: "/=", PluralSymbol: "", Unit: "Shilling", Name: "Tanzanian Shilling"},
+	"UAH": {Fraction: 2, Symbol: "₴", PluralSymbol: "", Unit: "Hryvnia", Name: "Hryvnia"},
+	"UGX": {Fraction: 0, Symbol: "/=", PluralSymbol: "", Unit: "Shilling", Name: "Uganda Shilling"},
+	"USD": {Fraction: 2, Symbol: "$", PluralSymbol: "", Unit: "Dollar", Name: "US Dollar"},
+	"UYU": {Fraction: 2, Symbol: "$", PluralSymbol: "", Unit: "Peso", Name: "Peso Uruguayo"},
+	"UZS": {Fraction: 2, Symbol: "", PluralSymbol: "", Unit: "Sum", Name: "Uzbekistan Sum"},
+	"VED": {Fraction: 2, Symbol: "Bs.D", PluralSymbol: "", Unit: "Bolivar", Name: "Bolívar Soberano"},
+	"VES": {Fraction: 2, Symbol: "Bs.S", PluralSymbol: "", Unit: "Bolivar", Name: "Bolívar Soberano"},
+	"VND": {Fraction: 0, Symbol: "₫", PluralSymbol: "", Unit: "Dong", Name: "Dong"},
+	"VUV": {Fraction: 0, Symbol: "VT", PluralSymbol: "", Unit: "Vatu", Name: "Vatu"},
+	"WST": {Fraction: 2, Symbol: "$", PluralSymbol: "", Unit: "Tala", Name: "Tala"},
+	"XAF": {Fraction: 0, Symbol: "F.CFA", PluralSymbol: "", Unit: "Franc", Name: "CFA Franc BEAC"},
+	"XCD": {Fraction: 2, Symbol: "$", PluralSymbol: "", Unit: "Dollar", Name: "East Caribbean Dollar"},
+	"XOF": {Fraction: 0, Symbol: "F.CFA", PluralSymbol: "", Unit: "Franc", Name: "CFA Franc BCEAO"},
+	"XPF": {Fraction: 0, Symbol: "F", PluralSymbol: "", Unit: "Franc", Name: "CFP Franc"},
+	"XSU": {Fraction: 2, Symbol: "S/.", PluralSymbol: "", Unit: "Sucre", Name: "Sucre"},
+	"YER": {Fraction: 2, Symbol: "YRl", PluralSymbol: "YRls", Unit: "Rial", Name: "Yemeni Rial"},
+	"ZAR": {Fraction: 2, Symbol: "R", PluralSymbol: "", Unit: "Rand", Name: "Rand"},
+	"ZMW": {Fraction: 2, Symbol: "K", PluralSymbol: "", Unit: "Kwacha", Name: "Zambian Kwacha"},
+	"ZWG": {Fraction: 2, Symbol: "ZiG", PluralSymbol: "", Unit: "ZiG", Name: "Zimbabwe Gold"},
+	"ZWL": {Fraction: 2, Symbol: "$", PluralSymbol: "", Unit: "Dollar", Name: "Zimbabwe Dollar"},
+}
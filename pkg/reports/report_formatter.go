@@ -0,0 +1,234 @@
+package reports
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/locales"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+const defaultReportCurrencySymbol = "¤"
+
+// ReportFormatter formats the dates and amounts in report according to the date, number and currency formats of user
+type ReportFormatter struct {
+	longDateFormat      core.LongDateFormat
+	decimalSeparator    rune
+	digitGroupingSymbol rune
+	digitGrouping       core.DigitGroupingType
+	currencyDisplayType core.CurrencyDisplayType
+}
+
+// NewReportFormatter returns a new report formatter for the specified user, the default formats of user language are used if user does not set
+func NewReportFormatter(user *models.User) *ReportFormatter {
+	defaultTypes := locales.GetLocaleTextItems(user.Language).DefaultTypes
+
+	longDateFormat := user.LongDateFormat
+	decimalSeparator := user.DecimalSeparator
+	digitGroupingSymbol := user.DigitGroupingSymbol
+	digitGrouping := user.DigitGrouping
+	currencyDisplayType := user.CurrencyDisplayType
+
+	if longDateFormat == core.LONG_DATE_FORMAT_DEFAULT {
+		longDateFormat = defaultTypes.LongDateFormat
+	}
+
+	if decimalSeparator == core.DECIMAL_SEPARATOR_DEFAULT {
+		decimalSeparator = defaultTypes.DecimalSeparator
+	}
+
+	if digitGroupingSymbol == core.DIGIT_GROUPING_SYMBOL_DEFAULT {
+		digitGroupingSymbol = defaultTypes.DigitGroupingSymbol
+	}
+
+	if digitGrouping == core.DIGIT_GROUPING_TYPE_DEFAULT {
+		digitGrouping = defaultTypes.DigitGrouping
+	}
+
+	if currencyDisplayType == core.CURRENCY_DISPLAY_TYPE_DEFAULT {
+		currencyDisplayType = defaultTypes.CurrencyDisplayType
+	}
+
+	formatter := &ReportFormatter{
+		longDateFormat:      longDateFormat,
+		decimalSeparator:    decimalSeparator.Rune(),
+		digitGroupingSymbol: digitGroupingSymbol.Rune(),
+		digitGrouping:       digitGrouping,
+		currencyDisplayType: currencyDisplayType,
+	}
+
+	if formatter.decimalSeparator == 0 {
+		formatter.decimalSeparator = '.'
+	}
+
+	if formatter.digitGroupingSymbol == 0 {
+		formatter.digitGroupingSymbol = ','
+	}
+
+	return formatter
+}
+
+// FormatDate returns the textual date of the specified unix time in the long date format of user
+func (f *ReportFormatter) FormatDate(unixTime int64, timezone *time.Location) string {
+	t := time.Unix(unixTime, 0).In(timezone)
+
+	switch f.longDateFormat {
+	case core.LONG_DATE_FORMAT_M_D_YYYY:
+		return fmt.Sprintf("%02d/%02d/%04d", t.Month(), t.Day(), t.Year())
+	case core.LONG_DATE_FORMAT_D_M_YYYY:
+		return fmt.Sprintf("%02d/%02d/%04d", t.Day(), t.Month(), t.Year())
+	default:
+		return fmt.Sprintf("%04d-%02d-%02d", t.Year(), t.Month(), t.Day())
+	}
+}
+
+// FormatDateTime returns the textual date and time (without second) of the specified unix time in the long date format of user
+func (f *ReportFormatter) FormatDateTime(unixTime int64, timezone *time.Location) string {
+	t := time.Unix(unixTime, 0).In(timezone)
+	return fmt.Sprintf("%s %02d:%02d", f.FormatDate(unixTime, timezone), t.Hour(), t.Minute())
+}
+
+// FormatAmount returns the textual amount with currency in the number and currency formats of user
+func (f *ReportFormatter) FormatAmount(amount int64, currency string) string {
+	return f.formatAmount(amount, currency, models.DEFAULT_CURRENCY_PRECISION, nil)
+}
+
+// FormatAmountWithPrecision returns the textual amount with currency in the number and currency formats of user, the amount is scaled by the specified decimal precision
+func (f *ReportFormatter) FormatAmountWithPrecision(amount int64, currency string, precision int) string {
+	return f.formatAmount(amount, currency, precision, nil)
+}
+
+// FormatPercentage returns the textual percentage with one decimal place in the number format of user
+func (f *ReportFormatter) FormatPercentage(value float64) string {
+	textualValue := fmt.Sprintf("%.1f", value*100)
+
+	if f.decimalSeparator != '.' {
+		textualValue = strings.Replace(textualValue, ".", string(f.decimalSeparator), 1)
+	}
+
+	return textualValue + "%"
+}
+
+func (f *ReportFormatter) formatAmount(amount int64, currency string, precision int, isSymbolSupported func(string) bool) string {
+	currencyInfo := allReportCurrencyInfos[currency]
+	textualValue := f.formatNumber(amount, precision, currencyInfo)
+	currencySymbol := ""
+	separator := " "
+
+	switch f.currencyDisplayType {
+	case core.CURRENCY_DISPLAY_TYPE_SYMBOL_BEFORE_AMOUNT,
+		core.CURRENCY_DISPLAY_TYPE_SYMBOL_AFTER_AMOUNT,
+		core.CURRENCY_DISPLAY_TYPE_SYMBOL_BEFORE_AMOUNT_WITHOUT_SPACE,
+		core.CURRENCY_DISPLAY_TYPE_SYMBOL_AFTER_AMOUNT_WITHOUT_SPACE:
+		currencySymbol = defaultReportCurrencySymbol
+
+		if currencyInfo != nil && currencyInfo.Symbol != "" {
+			currencySymbol = currencyInfo.Symbol
+
+			if currencyInfo.PluralSymbol != "" && amount != 100 && amount != -100 {
+				currencySymbol = currencyInfo.PluralSymbol
+			}
+		}
+
+		if f.currencyDisplayType == core.CURRENCY_DISPLAY_TYPE_SYMBOL_BEFORE_AMOUNT_WITHOUT_SPACE || f.currencyDisplayType == core.CURRENCY_DISPLAY_TYPE_SYMBOL_AFTER_AMOUNT_WITHOUT_SPACE {
+			separator = ""
+		}
+
+		// fall back to currency code when the symbol cannot be displayed, e.g. in pdf document with standard fonts
+		if isSymbolSupported != nil && !isSymbolSupported(currencySymbol) {
+			currencySymbol = currency
+			separator = " "
+		}
+	case core.CURRENCY_DISPLAY_TYPE_CODE_BEFORE_AMOUNT, core.CURRENCY_DISPLAY_TYPE_CODE_AFTER_AMOUNT:
+		currencySymbol = currency
+	case core.CURRENCY_DISPLAY_TYPE_UNIT_BEFORE_AMOUNT, core.CURRENCY_DISPLAY_TYPE_UNIT_AFTER_AMOUNT:
+		currencySymbol = currency
+
+		if currencyInfo != nil && currencyInfo.Unit != "" {
+			currencySymbol = currencyInfo.Unit
+		}
+	case core.CURRENCY_DISPLAY_TYPE_NAME_BEFORE_AMOUNT, core.CURRENCY_DISPLAY_TYPE_NAME_AFTER_AMOUNT:
+		currencySymbol = currency
+
+		if currencyInfo != nil && currencyInfo.Name != "" {
+			currencySymbol = currencyInfo.Name
+		}
+	default:
+		return textualValue
+	}
+
+	switch f.currencyDisplayType {
+	case core.CURRENCY_DISPLAY_TYPE_SYMBOL_AFTER_AMOUNT,
+		core.CURRENCY_DISPLAY_TYPE_SYMBOL_AFTER_AMOUNT_WITHOUT_SPACE,
+		core.CURRENCY_DISPLAY_TYPE_CODE_AFTER_AMOUNT,
+		core.CURRENCY_DISPLAY_TYPE_UNIT_AFTER_AMOUNT,
+		core.CURRENCY_DISPLAY_TYPE_NAME_AFTER_AMOUNT:
+		return textualValue + separator + currencySymbol
+	default:
+		return currencySymbol + separator + textualValue
+	}
+}
+
+func (f *ReportFormatter) formatNumber(amount int64, precision int, currencyInfo *reportCurrencyInfo) string {
+	textualValue := utils.FormatAmountWithPrecision(amount, precision)
+	negative := false
+
+	if textualValue[0] == '-' {
+		negative = true
+		textualValue = textualValue[1:]
+	}
+
+	integer := textualValue
+	decimals := ""
+
+	if decimalSeparatorIndex := strings.IndexByte(textualValue, '.'); decimalSeparatorIndex >= 0 {
+		integer = textualValue[:decimalSeparatorIndex]
+		decimals = textualValue[decimalSeparatorIndex+1:]
+	}
+
+	// the fraction digits of currency are only applied to the amounts in cents
+	if precision == models.DEFAULT_CURRENCY_PRECISION && currencyInfo != nil && currencyInfo.Fraction == 0 {
+		if decimals == "00" {
+			decimals = ""
+		} else if decimals[1] == '0' {
+			decimals = decimals[:1]
+		}
+	} else if precision == models.DEFAULT_CURRENCY_PRECISION && currencyInfo != nil && currencyInfo.Fraction == 1 {
+		if decimals[1] == '0' {
+			decimals = decimals[:1]
+		}
+	}
+
+	if f.digitGrouping == core.DIGIT_GROUPING_TYPE_THOUSANDS_SEPARATOR && len(integer) > 3 {
+		var builder strings.Builder
+		firstGroupLength := len(integer) % 3
+
+		if firstGroupLength == 0 {
+			firstGroupLength = 3
+		}
+
+		builder.WriteString(integer[:firstGroupLength])
+
+		for i := firstGroupLength; i < len(integer); i += 3 {
+			builder.WriteRune(f.digitGroupingSymbol)
+			builder.WriteString(integer[i : i+3])
+		}
+
+		integer = builder.String()
+	}
+
+	result := integer
+
+	if decimals != "" {
+		result = integer + string(f.decimalSeparator) + decimals
+	}
+
+	if negative {
+		result = "-" + result
+	}
+
+	return result
+}
@@ -0,0 +1,88 @@
+package reports
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+)
+
+func TestReportFormatterFormatDate(t *testing.T) {
+	timezone := time.FixedZone("Test Timezone", 8*3600)
+	unixTime := int64(1709222400) // 2024-03-01 00:00:00 +08:00
+
+	formatter := NewReportFormatter(&models.User{Language: "en"})
+	assert.Equal(t, "03/01/2024", formatter.FormatDate(unixTime, timezone))
+	assert.Equal(t, "03/01/2024 00:00", formatter.FormatDateTime(unixTime, timezone))
+
+	formatter = NewReportFormatter(&models.User{Language: "de"})
+	assert.Equal(t, "01/03/2024", formatter.FormatDate(unixTime, timezone))
+
+	formatter = NewReportFormatter(&models.User{Language: "en", LongDateFormat: core.LONG_DATE_FORMAT_YYYY_M_D})
+	assert.Equal(t, "2024-03-01", formatter.FormatDate(unixTime, timezone))
+	assert.Equal(t, "2024-02-29", formatter.FormatDate(unixTime, time.UTC))
+}
+
+func TestReportFormatterFormatAmount_DefaultFormats(t *testing.T) {
+	formatter := NewReportFormatter(&models.User{Language: "en"})
+	assert.Equal(t, "$ 1,234,567.89", formatter.FormatAmount(123456789, "USD"))
+	assert.Equal(t, "$ -0.05", formatter.FormatAmount(-5, "USD"))
+	assert.Equal(t, "¥ 1,235", formatter.FormatAmount(123500, "JPY"))
+	assert.Equal(t, "¥ 1,234.5", formatter.FormatAmount(123450, "JPY"))
+	assert.Equal(t, "¤ 100.00", formatter.FormatAmount(10000, "XXX"))
+
+	formatter = NewReportFormatter(&models.User{Language: "de"})
+	assert.Equal(t, "€ 1.234.567,89", formatter.FormatAmount(123456789, "EUR"))
+}
+
+func TestReportFormatterFormatAmount_UserFormats(t *testing.T) {
+	formatter := NewReportFormatter(&models.User{
+		Language:            "en",
+		DecimalSeparator:    core.DECIMAL_SEPARATOR_COMMA,
+		DigitGroupingSymbol: core.DIGIT_GROUPING_SYMBOL_SPACE,
+		CurrencyDisplayType: core.CURRENCY_DISPLAY_TYPE_SYMBOL_AFTER_AMOUNT_WITHOUT_SPACE,
+	})
+	assert.Equal(t, "1 234,00€", formatter.FormatAmount(123400, "EUR"))
+
+	formatter = NewReportFormatter(&models.User{Language: "en", DigitGrouping: core.DIGIT_GROUPING_TYPE_NONE, CurrencyDisplayType: core.CURRENCY_DISPLAY_TYPE_CODE_AFTER_AMOUNT})
+	assert.Equal(t, "1234.00 USD", formatter.FormatAmount(123400, "USD"))
+
+	formatter = NewReportFormatter(&models.User{Language: "en", CurrencyDisplayType: core.CURRENCY_DISPLAY_TYPE_NONE})
+	assert.Equal(t, "1,234.00", formatter.FormatAmount(123400, "USD"))
+
+	formatter = NewReportFormatter(&models.User{Language: "en", CurrencyDisplayType: core.CURRENCY_DISPLAY_TYPE_UNIT_AFTER_AMOUNT})
+	assert.Equal(t, "12.00 Euro", formatter.FormatAmount(1200, "EUR"))
+
+	formatter = NewReportFormatter(&models.User{Language: "en", CurrencyDisplayType: core.CURRENCY_DISPLAY_TYPE_NAME_BEFORE_AMOUNT})
+	assert.Equal(t, "US Dollar 12.00", formatter.FormatAmount(1200, "USD"))
+
+	formatter = NewReportFormatter(&models.User{Language: "en", CurrencyDisplayType: core.CURRENCY_DISPLAY_TYPE_SYMBOL_BEFORE_AMOUNT})
+	assert.Equal(t, "Dh 1.00", formatter.FormatAmount(100, "AED"))
+	assert.Equal(t, "Dhs 2.00", formatter.FormatAmount(200, "AED"))
+}
+
+func TestReportFormatterFormatAmount_UnsupportedSymbol(t *testing.T) {
+	formatter := NewReportFormatter(&models.User{Language: "en", CurrencyDisplayType: core.CURRENCY_DISPLAY_TYPE_SYMBOL_BEFORE_AMOUNT_WITHOUT_SPACE})
+	assert.Equal(t, "₽1,000.00", formatter.FormatAmount(100000, "RUB"))
+	assert.Equal(t, "RUB 1,000.00", formatter.formatAmount(100000, "RUB", models.DEFAULT_CURRENCY_PRECISION, CanEncodePdfText))
+	assert.Equal(t, "€1,000.00", formatter.formatAmount(100000, "EUR", models.DEFAULT_CURRENCY_PRECISION, CanEncodePdfText))
+}
+
+func TestReportFormatterFormatAmountWithPrecision(t *testing.T) {
+	formatter := NewReportFormatter(&models.User{Language: "en", CurrencyDisplayType: core.CURRENCY_DISPLAY_TYPE_CODE_BEFORE_AMOUNT})
+	assert.Equal(t, "BTC 0.12345678", formatter.FormatAmountWithPrecision(12345678, "BTC", 8))
+	assert.Equal(t, "PTS 12,345", formatter.FormatAmountWithPrecision(12345, "PTS", 0))
+	assert.Equal(t, "ETH -1,234.5678", formatter.FormatAmountWithPrecision(-12345678, "ETH", 4))
+	assert.Equal(t, "JPY 12", formatter.FormatAmountWithPrecision(1200, "JPY", 2))
+}
+
+func TestReportFormatterFormatPercentage(t *testing.T) {
+	formatter := NewReportFormatter(&models.User{Language: "en"})
+	assert.Equal(t, "12.3%", formatter.FormatPercentage(0.1234))
+
+	formatter = NewReportFormatter(&models.User{Language: "de"})
+	assert.Equal(t, "100,0%", formatter.FormatPercentage(1))
+}
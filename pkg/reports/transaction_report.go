@@ -0,0 +1,262 @@
+package reports
+
+import (
+	"sort"
+	"time"
+
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+// TransactionReportOptions represents the options of building transaction report
+type TransactionReportOptions struct {
+	StartTime              int64
+	EndTime                int64
+	Currency               string
+	ExchangeRates          models.ExchangeRatesMap
+	ClientTimezone         *time.Location
+	UseTransactionTimezone bool
+}
+
+// TransactionReport represents the summary totals, category breakdowns and transaction listing of the specified time range,
+// all the totals are converted to the specified currency
+type TransactionReport struct {
+	StartTime               int64
+	EndTime                 int64
+	Timezone                *time.Location
+	Currency                string
+	TotalIncome             int64
+	TotalExpense            int64
+	TransactionCount        int
+	IncomeCategories        []*TransactionReportCategoryItem
+	ExpenseCategories       []*TransactionReportCategoryItem
+	Transactions            []*TransactionReportTransactionItem
+	UnconvertibleCurrencies []string
+}
+
+// TransactionReportCategoryItem represents the total amount of category in transaction report
+type TransactionReportCategoryItem struct {
+	CategoryId    int64
+	Name          string
+	Amount        int64
+	Percentage    float64
+	SubCategories []*TransactionReportCategoryItem
+}
+
+// TransactionReportTransactionItem represents a transaction in the transaction listing of transaction report
+type TransactionReportTransactionItem struct {
+	TransactionId     int64
+	Type              models.TransactionDbType
+	Time              int64
+	Timezone          *time.Location
+	CategoryName      string
+	AccountName       string
+	Amount            int64
+	Currency          string
+	CurrencyPrecision int
+	Comment           string
+}
+
+// NewTransactionReport returns the transaction report of the specified transactions, the transfer transactions are only listed and are not counted in totals
+func NewTransactionReport(transactions []*models.Transaction, accountMap map[int64]*models.Account, categoryMap map[int64]*models.TransactionCategory, options *TransactionReportOptions) *TransactionReport {
+	report := &TransactionReport{
+		StartTime:         options.StartTime,
+		EndTime:           options.EndTime,
+		Timezone:          options.ClientTimezone,
+		Currency:          options.Currency,
+		TransactionCount:  len(transactions),
+		IncomeCategories:  make([]*TransactionReportCategoryItem, 0),
+		ExpenseCategories: make([]*TransactionReportCategoryItem, 0),
+		Transactions:      make([]*TransactionReportTransactionItem, 0, len(transactions)),
+	}
+
+	statisticItems := models.GetTransactionStatisticBreakdownItems(transactions, accountMap, nil, &models.TransactionStatisticBreakdownOptions{
+		Dimensions:             []models.TransactionStatisticBreakdownDimension{models.TRANSACTION_STATISTIC_BREAKDOWN_DIMENSION_CATEGORY},
+		ClientTimezone:         options.ClientTimezone,
+		UseTransactionTimezone: options.UseTransactionTimezone,
+	})
+
+	categoryAmounts := make(map[int64]int64)
+	unconvertibleCurrencies := make(map[string]bool)
+	currencyPrecisions := getCurrencyPrecisions(accountMap)
+
+	for i := 0; i < len(statisticItems); i++ {
+		item := statisticItems[i]
+
+		if item.Type != models.TRANSACTION_TYPE_INCOME && item.Type != models.TRANSACTION_TYPE_EXPENSE {
+			continue
+		}
+
+		amount, success := options.ExchangeRates.ConvertAmountWithPrecision(item.TotalAmount, item.Currency, getCurrencyPrecision(currencyPrecisions, item.Currency), options.Currency, getCurrencyPrecision(currencyPrecisions, options.Currency))
+
+		if !success {
+			unconvertibleCurrencies[item.Currency] = true
+			continue
+		}
+
+		categoryId, err := utils.StringToInt64(item.PrimaryKey)
+
+		if err != nil {
+			continue
+		}
+
+		categoryAmounts[categoryId] += amount
+
+		if item.Type == models.TRANSACTION_TYPE_INCOME {
+			report.TotalIncome += amount
+		} else {
+			report.TotalExpense += amount
+		}
+	}
+
+	report.IncomeCategories = getTransactionReportCategoryItems(models.CATEGORY_TYPE_INCOME, categoryMap, categoryAmounts, report.TotalIncome)
+	report.ExpenseCategories = getTransactionReportCategoryItems(models.CATEGORY_TYPE_EXPENSE, categoryMap, categoryAmounts, report.TotalExpense)
+
+	for i := 0; i < len(transactions); i++ {
+		transaction := transactions[i]
+		item := &TransactionReportTransactionItem{
+			TransactionId:     transaction.TransactionId,
+			Type:              transaction.Type,
+			Time:              utils.GetUnixTimeFromTransactionTime(transaction.TransactionTime),
+			Timezone:          options.ClientTimezone,
+			Amount:            transaction.Amount,
+			CurrencyPrecision: models.DEFAULT_CURRENCY_PRECISION,
+			Comment:           transaction.Comment,
+		}
+
+		if options.UseTransactionTimezone {
+			item.Timezone = time.FixedZone("Transaction Timezone", int(transaction.TimezoneUtcOffset)*60)
+		}
+
+		if category, exists := categoryMap[transaction.CategoryId]; exists {
+			item.CategoryName = category.Name
+		}
+
+		if account, exists := accountMap[transaction.AccountId]; exists {
+			item.AccountName = account.Name
+			item.Currency = account.Currency
+			item.CurrencyPrecision = account.GetCurrencyPrecision()
+		}
+
+		if relatedAccount, exists := accountMap[transaction.RelatedAccountId]; exists {
+			if transaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_OUT {
+				item.AccountName = item.AccountName + " > " + relatedAccount.Name
+			} else if transaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_IN {
+				item.AccountName = relatedAccount.Name + " > " + item.AccountName
+			}
+		}
+
+		report.Transactions = append(report.Transactions, item)
+	}
+
+	sort.SliceStable(report.Transactions, func(i, j int) bool {
+		return report.Transactions[i].Time < report.Transactions[j].Time
+	})
+
+	if len(unconvertibleCurrencies) > 0 {
+		report.UnconvertibleCurrencies = make([]string, 0, len(unconvertibleCurrencies))
+
+		for currency := range unconvertibleCurrencies {
+			report.UnconvertibleCurrencies = append(report.UnconvertibleCurrencies, currency)
+		}
+
+		sort.Strings(report.UnconvertibleCurrencies)
+	}
+
+	return report
+}
+
+// NetIncome returns the total income minus the total expense
+func (r *TransactionReport) NetIncome() int64 {
+	return r.TotalIncome - r.TotalExpense
+}
+
+// getCurrencyPrecisions returns the decimal precisions of all the account currencies, the accounts in the same currency always have the same precision
+func getCurrencyPrecisions(accountMap map[int64]*models.Account) map[string]int {
+	currencyPrecisions := make(map[string]int)
+
+	for _, account := range accountMap {
+		currencyPrecisions[account.Currency] = account.GetCurrencyPrecision()
+	}
+
+	return currencyPrecisions
+}
+
+func getCurrencyPrecision(currencyPrecisions map[string]int, currency string) int {
+	if precision, exists := currencyPrecisions[currency]; exists {
+		return precision
+	}
+
+	return models.DEFAULT_CURRENCY_PRECISION
+}
+
+func getTransactionReportCategoryItems(categoryType models.TransactionCategoryType, categoryMap map[int64]*models.TransactionCategory, categoryAmounts map[int64]int64, totalAmount int64) []*TransactionReportCategoryItem {
+	primaryCategoryItems := make(map[int64]*TransactionReportCategoryItem)
+	items := make([]*TransactionReportCategoryItem, 0)
+
+	for categoryId, amount := range categoryAmounts {
+		category, exists := categoryMap[categoryId]
+
+		if !exists || category.Type != categoryType {
+			continue
+		}
+
+		primaryCategoryId := category.CategoryId
+		primaryCategoryName := category.Name
+
+		if category.ParentCategoryId != models.LevelOneTransactionCategoryParentId {
+			primaryCategoryId = category.ParentCategoryId
+
+			if primaryCategory, exists := categoryMap[category.ParentCategoryId]; exists {
+				primaryCategoryName = primaryCategory.Name
+			}
+		}
+
+		primaryCategoryItem, exists := primaryCategoryItems[primaryCategoryId]
+
+		if !exists {
+			primaryCategoryItem = &TransactionReportCategoryItem{
+				CategoryId:    primaryCategoryId,
+				Name:          primaryCategoryName,
+				SubCategories: make([]*TransactionReportCategoryItem, 0),
+			}
+
+			primaryCategoryItems[primaryCategoryId] = primaryCategoryItem
+			items = append(items, primaryCategoryItem)
+		}
+
+		primaryCategoryItem.Amount += amount
+
+		if category.ParentCategoryId != models.LevelOneTransactionCategoryParentId {
+			primaryCategoryItem.SubCategories = append(primaryCategoryItem.SubCategories, &TransactionReportCategoryItem{
+				CategoryId: category.CategoryId,
+				Name:       category.Name,
+				Amount:     amount,
+			})
+		}
+	}
+
+	sortTransactionReportCategoryItems(items, totalAmount)
+
+	for i := 0; i < len(items); i++ {
+		sortTransactionReportCategoryItems(items[i].SubCategories, totalAmount)
+	}
+
+	return items
+}
+
+func sortTransactionReportCategoryItems(items []*TransactionReportCategoryItem, totalAmount int64) {
+	for i := 0; i < len(items); i++ {
+		if totalAmount != 0 {
+			items[i].Percentage = float64(items[i].Amount) / float64(totalAmount)
+		}
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Amount != items[j].Amount {
+			return items[i].Amount > items[j].Amount
+		}
+
+		return items[i].CategoryId < items[j].CategoryId
+	})
+}
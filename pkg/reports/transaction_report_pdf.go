@@ -0,0 +1,405 @@
+package reports
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/locales"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+const transactionReportPdfPageMargin = 40
+const transactionReportPdfContentWidth = PdfPageWidth - transactionReportPdfPageMargin*2
+const transactionReportPdfFooterHeight = 30
+const transactionReportPdfTableRowHeight = 16
+const transactionReportPdfTableFontSize = 8
+const transactionReportPdfMaxChartItems = 8
+
+var (
+	transactionReportPdfTextColor       = PdfColor{R: 0x33, G: 0x33, B: 0x33}
+	transactionReportPdfSecondaryColor  = PdfColor{R: 0x88, G: 0x88, B: 0x88}
+	transactionReportPdfPrimaryColor    = PdfColor{R: 0xC6, G: 0x7E, B: 0x48}
+	transactionReportPdfIncomeColor     = PdfColor{R: 0x2E, G: 0x7D, B: 0x32}
+	transactionReportPdfExpenseColor    = PdfColor{R: 0xC6, G: 0x28, B: 0x28}
+	transactionReportPdfBorderColor     = PdfColor{R: 0xDD, G: 0xDD, B: 0xDD}
+	transactionReportPdfBackgroundColor = PdfColor{R: 0xF5, G: 0xF5, B: 0xF5}
+)
+
+// transactionReportPdfTableColumn represents the column of table in transaction report pdf
+type transactionReportPdfTableColumn struct {
+	title      string
+	width      float64
+	alignRight bool
+}
+
+// transactionReportPdfTableCell represents the cell of table in transaction report pdf
+type transactionReportPdfTableCell struct {
+	text  string
+	font  PdfFont
+	color PdfColor
+}
+
+// transactionReportPdfRenderer renders the transaction report to pdf document
+type transactionReportPdfRenderer struct {
+	document  *PdfDocument
+	report    *TransactionReport
+	formatter *ReportFormatter
+	textItems *locales.TransactionReportTextItems
+	currentY  float64
+}
+
+// RenderTransactionReportPdf returns the pdf document of the specified transaction report,
+// the document uses the configured TrueType fonts if they are set, otherwise uses the standard fonts,
+// the english text items are used when the localized text cannot be displayed with the fonts,
+// and it returns error instead of the document if the user data contains the characters which cannot be displayed
+func RenderTransactionReportPdf(report *TransactionReport, formatter *ReportFormatter, textItems *locales.TransactionReportTextItems, generatedTime time.Time) ([]byte, error) {
+	document := NewPdfDocument("", generatedTime)
+	document.SetUnicodeFonts(pdfRegularUnicodeFont, pdfBoldUnicodeFont)
+
+	if !canDisplayAllPdfTextItems(document, textItems) {
+		textItems = locales.DefaultLanguage.TransactionReportTextItems
+	}
+
+	document.title = textItems.Title
+
+	renderer := &transactionReportPdfRenderer{
+		document:  document,
+		report:    report,
+		formatter: formatter,
+		textItems: textItems,
+	}
+
+	renderer.addPage()
+	renderer.renderHeader(generatedTime)
+	renderer.renderSummary()
+	renderer.renderCharts()
+	renderer.renderCategoryTable(textItems.IncomeByCategory, report.IncomeCategories)
+	renderer.renderCategoryTable(textItems.ExpenseByCategory, report.ExpenseCategories)
+	renderer.renderTransactionTable()
+	renderer.renderFooters()
+
+	if renderer.document.HasUnsupportedText() {
+		return nil, errs.ErrReportPdfTextUnsupported
+	}
+
+	return renderer.document.Bytes()
+}
+
+func (r *transactionReportPdfRenderer) renderHeader(generatedTime time.Time) {
+	r.currentY += 20
+	r.document.DrawText(transactionReportPdfPageMargin, r.currentY, r.textItems.Title, PDF_FONT_BOLD, 18, transactionReportPdfPrimaryColor)
+
+	r.currentY += 18
+	period := fmt.Sprintf(r.textItems.PeriodFormat, r.formatter.FormatDate(r.report.StartTime, r.report.Timezone), r.formatter.FormatDate(r.report.EndTime, r.report.Timezone))
+	r.document.DrawText(transactionReportPdfPageMargin, r.currentY, period, PDF_FONT_REGULAR, 10, transactionReportPdfTextColor)
+
+	r.currentY += 14
+	generated := fmt.Sprintf(r.textItems.GeneratedTimeFormat, r.formatter.FormatDateTime(generatedTime.Unix(), r.report.Timezone))
+	r.document.DrawText(transactionReportPdfPageMargin, r.currentY, generated, PDF_FONT_REGULAR, 8, transactionReportPdfSecondaryColor)
+
+	r.currentY += 10
+	r.document.DrawLine(transactionReportPdfPageMargin, r.currentY, PdfPageWidth-transactionReportPdfPageMargin, r.currentY, 1, transactionReportPdfPrimaryColor)
+	r.currentY += 10
+}
+
+func (r *transactionReportPdfRenderer) renderSummary() {
+	r.renderSectionTitle(r.textItems.Summary, 60)
+
+	boxGap := float64(8)
+	boxWidth := (transactionReportPdfContentWidth - boxGap*3) / 4
+	boxHeight := float64(40)
+	netIncomeColor := transactionReportPdfIncomeColor
+
+	if r.report.NetIncome() < 0 {
+		netIncomeColor = transactionReportPdfExpenseColor
+	}
+
+	summaryItems := []struct {
+		label string
+		value string
+		color PdfColor
+	}{
+		{label: r.textItems.TotalIncome, value: r.formatAmount(r.report.TotalIncome, r.report.Currency), color: transactionReportPdfIncomeColor},
+		{label: r.textItems.TotalExpense, value: r.formatAmount(r.report.TotalExpense, r.report.Currency), color: transactionReportPdfExpenseColor},
+		{label: r.textItems.NetIncome, value: r.formatAmount(r.report.NetIncome(), r.report.Currency), color: netIncomeColor},
+		{label: r.textItems.TransactionCount, value: utils.IntToString(r.report.TransactionCount), color: transactionReportPdfTextColor},
+	}
+
+	for i := 0; i < len(summaryItems); i++ {
+		x := transactionReportPdfPageMargin + float64(i)*(boxWidth+boxGap)
+		r.document.FillRect(x, r.currentY, boxWidth, boxHeight, transactionReportPdfBackgroundColor)
+		r.document.DrawText(x+8, r.currentY+14, r.document.TruncateText(summaryItems[i].label, PDF_FONT_REGULAR, 8, boxWidth-16), PDF_FONT_REGULAR, 8, transactionReportPdfSecondaryColor)
+		r.document.DrawText(x+8, r.currentY+31, r.document.TruncateText(summaryItems[i].value, PDF_FONT_BOLD, 11, boxWidth-16), PDF_FONT_BOLD, 11, summaryItems[i].color)
+	}
+
+	r.currentY += boxHeight + 14
+	r.document.DrawText(transactionReportPdfPageMargin, r.currentY, fmt.Sprintf(r.textItems.CurrencyDescriptionFormat, r.report.Currency), PDF_FONT_REGULAR, 8, transactionReportPdfSecondaryColor)
+
+	if len(r.report.UnconvertibleCurrencies) > 0 {
+		r.currentY += 12
+		r.document.DrawText(transactionReportPdfPageMargin, r.currentY, fmt.Sprintf(r.textItems.UnconvertibleCurrenciesFormat, strings.Join(r.report.UnconvertibleCurrencies, ", ")), PDF_FONT_REGULAR, 8, transactionReportPdfExpenseColor)
+	}
+
+	r.currentY += 20
+}
+
+func (r *transactionReportPdfRenderer) renderCharts() {
+	labelWidth := float64(130)
+	valueWidth := float64(110)
+	barMaxWidth := transactionReportPdfContentWidth - labelWidth - valueWidth - 10
+	barHeight := float64(10)
+	rowHeight := float64(16)
+
+	// income and expense chart
+	r.renderSectionTitle(r.textItems.IncomeAndExpenseChart, rowHeight*2+10)
+
+	maxAmount := max(r.report.TotalIncome, r.report.TotalExpense)
+	chartItems := []struct {
+		label  string
+		amount int64
+		color  PdfColor
+	}{
+		{label: r.textItems.TotalIncome, amount: r.report.TotalIncome, color: transactionReportPdfIncomeColor},
+		{label: r.textItems.TotalExpense, amount: r.report.TotalExpense, color: transactionReportPdfExpenseColor},
+	}
+
+	for i := 0; i < len(chartItems); i++ {
+		r.renderChartBar(chartItems[i].label, r.formatAmount(chartItems[i].amount, r.report.Currency), chartItems[i].amount, maxAmount, chartItems[i].color, labelWidth, barMaxWidth, barHeight)
+		r.currentY += rowHeight
+	}
+
+	r.currentY += 14
+
+	// top expense categories chart
+	expenseCategoryCount := min(len(r.report.ExpenseCategories), transactionReportPdfMaxChartItems)
+	r.renderSectionTitle(r.textItems.TopExpenseCategoriesChart, rowHeight*float64(max(expenseCategoryCount, 1))+10)
+
+	if expenseCategoryCount < 1 {
+		r.renderNoData()
+		r.currentY += 14
+		return
+	}
+
+	maxAmount = r.report.ExpenseCategories[0].Amount
+
+	for i := 0; i < expenseCategoryCount; i++ {
+		item := r.report.ExpenseCategories[i]
+		value := fmt.Sprintf("%s (%s)", r.formatAmount(item.Amount, r.report.Currency), r.formatter.FormatPercentage(item.Percentage))
+		r.renderChartBar(item.Name, value, item.Amount, maxAmount, transactionReportPdfPrimaryColor, labelWidth, barMaxWidth, barHeight)
+		r.currentY += rowHeight
+	}
+
+	r.currentY += 14
+}
+
+func (r *transactionReportPdfRenderer) renderChartBar(label string, value string, amount int64, maxAmount int64, color PdfColor, labelWidth float64, barMaxWidth float64, barHeight float64) {
+	x := float64(transactionReportPdfPageMargin)
+	textY := r.currentY + barHeight - 2
+
+	r.document.DrawText(x, textY, r.document.TruncateText(label, PDF_FONT_REGULAR, transactionReportPdfTableFontSize, labelWidth-8), PDF_FONT_REGULAR, transactionReportPdfTableFontSize, transactionReportPdfTextColor)
+	r.document.FillRect(x+labelWidth, r.currentY, barMaxWidth, barHeight, transactionReportPdfBackgroundColor)
+
+	if amount > 0 && maxAmount > 0 {
+		r.document.FillRect(x+labelWidth, r.currentY, barMaxWidth*float64(amount)/float64(maxAmount), barHeight, color)
+	}
+
+	r.document.DrawTextAlignRight(PdfPageWidth-transactionReportPdfPageMargin, textY, value, PDF_FONT_REGULAR, transactionReportPdfTableFontSize, transactionReportPdfTextColor)
+}
+
+func (r *transactionReportPdfRenderer) renderCategoryTable(title string, items []*TransactionReportCategoryItem) {
+	columns := []*transactionReportPdfTableColumn{
+		{title: r.textItems.Category, width: transactionReportPdfContentWidth - 200},
+		{title: r.textItems.Amount, width: 130, alignRight: true},
+		{title: r.textItems.Percentage, width: 70, alignRight: true},
+	}
+
+	r.renderSectionTitle(title, transactionReportPdfTableRowHeight*2)
+
+	if len(items) < 1 {
+		r.renderNoData()
+		r.currentY += 20
+		return
+	}
+
+	r.renderTableHeader(columns)
+
+	for i := 0; i < len(items); i++ {
+		item := items[i]
+
+		r.renderTableRow(columns, []*transactionReportPdfTableCell{
+			{text: item.Name, font: PDF_FONT_BOLD, color: transactionReportPdfTextColor},
+			{text: r.formatAmount(item.Amount, r.report.Currency), font: PDF_FONT_BOLD, color: transactionReportPdfTextColor},
+			{text: r.formatter.FormatPercentage(item.Percentage), font: PDF_FONT_BOLD, color: transactionReportPdfTextColor},
+		})
+
+		for j := 0; j < len(item.SubCategories); j++ {
+			subItem := item.SubCategories[j]
+
+			r.renderTableRow(columns, []*transactionReportPdfTableCell{
+				{text: "    " + subItem.Name, font: PDF_FONT_REGULAR, color: transactionReportPdfTextColor},
+				{text: r.formatAmount(subItem.Amount, r.report.Currency), font: PDF_FONT_REGULAR, color: transactionReportPdfTextColor},
+				{text: r.formatter.FormatPercentage(subItem.Percentage), font: PDF_FONT_REGULAR, color: transactionReportPdfSecondaryColor},
+			})
+		}
+	}
+
+	r.currentY += 20
+}
+
+func (r *transactionReportPdfRenderer) renderTransactionTable() {
+	columns := []*transactionReportPdfTableColumn{
+		{title: r.textItems.Date, width: 62},
+		{title: r.textItems.Type, width: 55},
+		{title: r.textItems.Category, width: 85},
+		{title: r.textItems.Account, width: 95},
+		{title: r.textItems.Description, width: transactionReportPdfContentWidth - 62 - 55 - 85 - 95 - 105},
+		{title: r.textItems.Amount, width: 105, alignRight: true},
+	}
+
+	r.renderSectionTitle(r.textItems.Transactions, transactionReportPdfTableRowHeight*2)
+
+	if len(r.report.Transactions) < 1 {
+		r.renderNoData()
+		return
+	}
+
+	r.renderTableHeader(columns)
+
+	for i := 0; i < len(r.report.Transactions); i++ {
+		transaction := r.report.Transactions[i]
+		typeName := ""
+		amountColor := transactionReportPdfTextColor
+
+		switch transaction.Type {
+		case models.TRANSACTION_DB_TYPE_INCOME:
+			typeName = r.textItems.Income
+			amountColor = transactionReportPdfIncomeColor
+		case models.TRANSACTION_DB_TYPE_EXPENSE:
+			typeName = r.textItems.Expense
+			amountColor = transactionReportPdfExpenseColor
+		case models.TRANSACTION_DB_TYPE_TRANSFER_OUT, models.TRANSACTION_DB_TYPE_TRANSFER_IN:
+			typeName = r.textItems.Transfer
+		}
+
+		r.renderTableRow(columns, []*transactionReportPdfTableCell{
+			{text: r.formatter.FormatDate(transaction.Time, transaction.Timezone), font: PDF_FONT_REGULAR, color: transactionReportPdfTextColor},
+			{text: typeName, font: PDF_FONT_REGULAR, color: transactionReportPdfTextColor},
+			{text: transaction.CategoryName, font: PDF_FONT_REGULAR, color: transactionReportPdfTextColor},
+			{text: transaction.AccountName, font: PDF_FONT_REGULAR, color: transactionReportPdfTextColor},
+			{text: transaction.Comment, font: PDF_FONT_REGULAR, color: transactionReportPdfSecondaryColor},
+			{text: r.formatAmountWithPrecision(transaction.Amount, transaction.Currency, transaction.CurrencyPrecision), font: PDF_FONT_REGULAR, color: amountColor},
+		})
+	}
+}
+
+func (r *transactionReportPdfRenderer) renderSectionTitle(title string, minContentHeight float64) {
+	r.ensureSpace(20 + minContentHeight)
+	r.currentY += 12
+	r.document.DrawText(transactionReportPdfPageMargin, r.currentY, title, PDF_FONT_BOLD, 12, transactionReportPdfTextColor)
+	r.currentY += 10
+}
+
+func (r *transactionReportPdfRenderer) renderNoData() {
+	r.currentY += 12
+	r.document.DrawText(transactionReportPdfPageMargin, r.currentY, r.textItems.NoData, PDF_FONT_REGULAR, transactionReportPdfTableFontSize, transactionReportPdfSecondaryColor)
+}
+
+func (r *transactionReportPdfRenderer) renderTableHeader(columns []*transactionReportPdfTableColumn) {
+	r.document.FillRect(transactionReportPdfPageMargin, r.currentY, transactionReportPdfContentWidth, transactionReportPdfTableRowHeight, transactionReportPdfBackgroundColor)
+
+	cells := make([]*transactionReportPdfTableCell, len(columns))
+
+	for i := 0; i < len(columns); i++ {
+		cells[i] = &transactionReportPdfTableCell{text: columns[i].title, font: PDF_FONT_BOLD, color: transactionReportPdfTextColor}
+	}
+
+	r.drawTableCells(columns, cells)
+}
+
+// renderTableRow draws the table row, and repeats the table header in the new page if current page has no enough space
+func (r *transactionReportPdfRenderer) renderTableRow(columns []*transactionReportPdfTableColumn, cells []*transactionReportPdfTableCell) {
+	if r.ensureSpace(transactionReportPdfTableRowHeight) {
+		r.renderTableHeader(columns)
+	}
+
+	r.drawTableCells(columns, cells)
+}
+
+func (r *transactionReportPdfRenderer) drawTableCells(columns []*transactionReportPdfTableColumn, cells []*transactionReportPdfTableCell) {
+	x := float64(transactionReportPdfPageMargin)
+	textY := r.currentY + transactionReportPdfTableRowHeight - 5
+	cellPadding := float64(4)
+
+	for i := 0; i < len(columns); i++ {
+		column := columns[i]
+		cell := cells[i]
+		text := r.document.TruncateText(cell.text, cell.font, transactionReportPdfTableFontSize, column.width-cellPadding*2)
+
+		if column.alignRight {
+			r.document.DrawTextAlignRight(x+column.width-cellPadding, textY, text, cell.font, transactionReportPdfTableFontSize, cell.color)
+		} else {
+			r.document.DrawText(x+cellPadding, textY, text, cell.font, transactionReportPdfTableFontSize, cell.color)
+		}
+
+		x += column.width
+	}
+
+	r.currentY += transactionReportPdfTableRowHeight
+	r.document.DrawLine(transactionReportPdfPageMargin, r.currentY, PdfPageWidth-transactionReportPdfPageMargin, r.currentY, 0.5, transactionReportPdfBorderColor)
+}
+
+func (r *transactionReportPdfRenderer) renderFooters() {
+	pageCount := r.document.PageCount()
+
+	for i := 0; i < pageCount; i++ {
+		r.document.SetCurrentPage(i)
+		pageText := fmt.Sprintf(r.textItems.PageFormat, i+1, pageCount)
+		textWidth := r.document.GetTextWidth(pageText, PDF_FONT_REGULAR, 8)
+		r.document.DrawText((PdfPageWidth-textWidth)/2, PdfPageHeight-transactionReportPdfFooterHeight+10, pageText, PDF_FONT_REGULAR, 8, transactionReportPdfSecondaryColor)
+	}
+}
+
+// ensureSpace adds a new page and returns true if current page has no enough space for the specified height
+func (r *transactionReportPdfRenderer) ensureSpace(height float64) bool {
+	if r.currentY+height <= PdfPageHeight-transactionReportPdfPageMargin-transactionReportPdfFooterHeight {
+		return false
+	}
+
+	r.addPage()
+	return true
+}
+
+func (r *transactionReportPdfRenderer) addPage() {
+	r.document.AddPage()
+	r.currentY = transactionReportPdfPageMargin
+}
+
+func (r *transactionReportPdfRenderer) formatAmount(amount int64, currency string) string {
+	return r.formatAmountWithPrecision(amount, currency, models.DEFAULT_CURRENCY_PRECISION)
+}
+
+func (r *transactionReportPdfRenderer) formatAmountWithPrecision(amount int64, currency string, precision int) string {
+	return r.formatter.formatAmount(amount, currency, precision, r.document.CanDisplayText)
+}
+
+func canDisplayAllPdfTextItems(document *PdfDocument, textItems *locales.TransactionReportTextItems) bool {
+	if textItems == nil {
+		return false
+	}
+
+	value := reflect.ValueOf(textItems).Elem()
+
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Field(i)
+
+		if field.Kind() == reflect.String && !document.CanDisplayText(field.String()) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,193 @@
+package reports
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/locales"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+var testReportAccountMap = map[int64]*models.Account{
+	1: {AccountId: 1, Name: "Cash", Currency: "USD"},
+	2: {AccountId: 2, Name: "Bank", Currency: "USD"},
+	3: {AccountId: 3, Name: "Euro Card", Currency: "EUR"},
+	4: {AccountId: 4, Name: "Yen Wallet", Currency: "JPY"},
+}
+
+var testReportCategoryMap = map[int64]*models.TransactionCategory{
+	10: {CategoryId: 10, Name: "Food", Type: models.CATEGORY_TYPE_EXPENSE},
+	11: {CategoryId: 11, Name: "Groceries", Type: models.CATEGORY_TYPE_EXPENSE, ParentCategoryId: 10},
+	12: {CategoryId: 12, Name: "Restaurants", Type: models.CATEGORY_TYPE_EXPENSE, ParentCategoryId: 10},
+	20: {CategoryId: 20, Name: "Transport", Type: models.CATEGORY_TYPE_EXPENSE},
+	21: {CategoryId: 21, Name: "Taxi", Type: models.CATEGORY_TYPE_EXPENSE, ParentCategoryId: 20},
+	30: {CategoryId: 30, Name: "Salary", Type: models.CATEGORY_TYPE_INCOME},
+	31: {CategoryId: 31, Name: "Monthly Salary", Type: models.CATEGORY_TYPE_INCOME, ParentCategoryId: 30},
+	40: {CategoryId: 40, Name: "Transfer", Type: models.CATEGORY_TYPE_TRANSFER},
+	41: {CategoryId: 41, Name: "Bank Transfer", Type: models.CATEGORY_TYPE_TRANSFER, ParentCategoryId: 40},
+}
+
+func newTestReportTransaction(transactionId int64, transactionType models.TransactionDbType, unixTime int64, accountId int64, categoryId int64, amount int64) *models.Transaction {
+	return &models.Transaction{
+		TransactionId:   transactionId,
+		Type:            transactionType,
+		TransactionTime: utils.GetMinTransactionTimeFromUnixTime(unixTime),
+		AccountId:       accountId,
+		CategoryId:      categoryId,
+		Amount:          amount,
+	}
+}
+
+func newTestTransactionReport() *TransactionReport {
+	transactions := []*models.Transaction{
+		newTestReportTransaction(5, models.TRANSACTION_DB_TYPE_EXPENSE, 1709400000, 3, 21, 2000),
+		newTestReportTransaction(1, models.TRANSACTION_DB_TYPE_INCOME, 1709300000, 2, 31, 500000),
+		newTestReportTransaction(2, models.TRANSACTION_DB_TYPE_EXPENSE, 1709310000, 1, 11, 6000),
+		newTestReportTransaction(3, models.TRANSACTION_DB_TYPE_EXPENSE, 1709320000, 1, 12, 4000),
+		newTestReportTransaction(4, models.TRANSACTION_DB_TYPE_TRANSFER_OUT, 1709330000, 2, 41, 10000),
+		newTestReportTransaction(6, models.TRANSACTION_DB_TYPE_EXPENSE, 1709500000, 4, 21, 100000),
+	}
+	transactions[4].RelatedAccountId = 1
+	transactions[2].Comment = "Supermarket"
+
+	return NewTransactionReport(transactions, testReportAccountMap, testReportCategoryMap, &TransactionReportOptions{
+		StartTime:      1709222400,
+		EndTime:        1711900799,
+		Currency:       "USD",
+		ExchangeRates:  models.ExchangeRatesMap{"USD": 1, "EUR": 0.5},
+		ClientTimezone: time.UTC,
+	})
+}
+
+func TestNewTransactionReport_Totals(t *testing.T) {
+	report := newTestTransactionReport()
+
+	assert.Equal(t, "USD", report.Currency)
+	assert.Equal(t, int64(500000), report.TotalIncome)
+	assert.Equal(t, int64(14000), report.TotalExpense)
+	assert.Equal(t, int64(486000), report.NetIncome())
+	assert.Equal(t, 6, report.TransactionCount)
+	assert.Equal(t, []string{"JPY"}, report.UnconvertibleCurrencies)
+}
+
+func TestNewTransactionReport_CategoryBreakdown(t *testing.T) {
+	report := newTestTransactionReport()
+
+	assert.Equal(t, 1, len(report.IncomeCategories))
+	assert.Equal(t, "Salary", report.IncomeCategories[0].Name)
+	assert.Equal(t, int64(500000), report.IncomeCategories[0].Amount)
+	assert.Equal(t, float64(1), report.IncomeCategories[0].Percentage)
+
+	assert.Equal(t, 2, len(report.ExpenseCategories))
+	assert.Equal(t, "Food", report.ExpenseCategories[0].Name)
+	assert.Equal(t, int64(10000), report.ExpenseCategories[0].Amount)
+	assert.InDelta(t, 10000.0/14000.0, report.ExpenseCategories[0].Percentage, 0.0001)
+	assert.Equal(t, 2, len(report.ExpenseCategories[0].SubCategories))
+	assert.Equal(t, "Groceries", report.ExpenseCategories[0].SubCategories[0].Name)
+	assert.Equal(t, int64(6000), report.ExpenseCategories[0].SubCategories[0].Amount)
+	assert.Equal(t, "Restaurants", report.ExpenseCategories[0].SubCategories[1].Name)
+
+	assert.Equal(t, "Transport", report.ExpenseCategories[1].Name)
+	assert.Equal(t, int64(4000), report.ExpenseCategories[1].Amount)
+}
+
+func TestNewTransactionReport_TransactionListing(t *testing.T) {
+	report := newTestTransactionReport()
+
+	assert.Equal(t, 6, len(report.Transactions))
+
+	for i := 1; i < len(report.Transactions); i++ {
+		assert.True(t, report.Transactions[i-1].Time <= report.Transactions[i].Time)
+	}
+
+	assert.Equal(t, int64(1), report.Transactions[0].TransactionId)
+	assert.Equal(t, "Monthly Salary", report.Transactions[0].CategoryName)
+	assert.Equal(t, "Supermarket", report.Transactions[1].Comment)
+	assert.Equal(t, "Bank > Cash", report.Transactions[3].AccountName)
+	assert.Equal(t, "EUR", report.Transactions[4].Currency)
+	assert.Equal(t, int64(2000), report.Transactions[4].Amount)
+}
+
+func TestNewTransactionReport_AccountCurrencyPrecision(t *testing.T) {
+	currencyPrecision := 8
+	accountMap := map[int64]*models.Account{
+		1: {AccountId: 1, Name: "Cash", Currency: "USD"},
+		2: {AccountId: 2, Name: "Bitcoin Wallet", Currency: "BTC", Extend: &models.AccountExtend{CurrencyPrecision: &currencyPrecision}},
+	}
+	transactions := []*models.Transaction{
+		newTestReportTransaction(1, models.TRANSACTION_DB_TYPE_EXPENSE, 1709300000, 1, 11, 1000),
+		newTestReportTransaction(2, models.TRANSACTION_DB_TYPE_EXPENSE, 1709310000, 2, 21, 50000000),
+	}
+
+	report := NewTransactionReport(transactions, accountMap, testReportCategoryMap, &TransactionReportOptions{
+		StartTime:      1709222400,
+		EndTime:        1711900799,
+		Currency:       "USD",
+		ExchangeRates:  models.ExchangeRatesMap{"USD": 1, "BTC": 0.00002},
+		ClientTimezone: time.UTC,
+	})
+
+	assert.Equal(t, int64(2501000), report.TotalExpense)
+	assert.Equal(t, 0, len(report.UnconvertibleCurrencies))
+	assert.Equal(t, models.DEFAULT_CURRENCY_PRECISION, report.Transactions[0].CurrencyPrecision)
+	assert.Equal(t, 8, report.Transactions[1].CurrencyPrecision)
+}
+
+func TestRenderTransactionReportPdf(t *testing.T) {
+	report := newTestTransactionReport()
+	formatter := NewReportFormatter(&models.User{Language: "en"})
+
+	content, err := RenderTransactionReportPdf(report, formatter, locales.GetLocaleTextItems("en").TransactionReportTextItems, time.Unix(1712000000, 0))
+	assert.Nil(t, err)
+	assert.True(t, bytes.HasPrefix(content, []byte("%PDF-1.4\n")))
+	assert.Contains(t, string(content), "/Title (Transaction Report)")
+	assert.Contains(t, string(content), "/Count 1 ")
+}
+
+func TestRenderTransactionReportPdf_MultiplePages(t *testing.T) {
+	transactions := make([]*models.Transaction, 0, 200)
+
+	for i := 0; i < 200; i++ {
+		transactions = append(transactions, newTestReportTransaction(int64(i+1), models.TRANSACTION_DB_TYPE_EXPENSE, 1709300000+int64(i)*60, 1, 11, 100))
+	}
+
+	report := NewTransactionReport(transactions, testReportAccountMap, testReportCategoryMap, &TransactionReportOptions{
+		StartTime:      1709222400,
+		EndTime:        1711900799,
+		Currency:       "USD",
+		ClientTimezone: time.UTC,
+	})
+	formatter := NewReportFormatter(&models.User{Language: "en"})
+
+	content, err := RenderTransactionReportPdf(report, formatter, locales.GetLocaleTextItems("en").TransactionReportTextItems, time.Unix(1712000000, 0))
+	assert.Nil(t, err)
+	assert.False(t, strings.Contains(string(content), "/Count 1 "))
+}
+
+func TestRenderTransactionReportPdf_UnsupportedLanguageFallback(t *testing.T) {
+	report := newTestTransactionReport()
+	formatter := NewReportFormatter(&models.User{Language: "zh-Hans"})
+
+	document := NewPdfDocument("", time.Unix(0, 0))
+	assert.False(t, canDisplayAllPdfTextItems(document, locales.GetLocaleTextItems("zh-Hans").TransactionReportTextItems))
+	assert.True(t, canDisplayAllPdfTextItems(document, locales.GetLocaleTextItems("de").TransactionReportTextItems))
+
+	content, err := RenderTransactionReportPdf(report, formatter, locales.GetLocaleTextItems("zh-Hans").TransactionReportTextItems, time.Unix(1712000000, 0))
+	assert.Nil(t, err)
+	assert.Contains(t, string(content), "/Title (Transaction Report)")
+}
+
+func TestRenderTransactionReportPdf_UnsupportedUserData(t *testing.T) {
+	report := newTestTransactionReport()
+	report.Transactions[0].Comment = "超市"
+	formatter := NewReportFormatter(&models.User{Language: "en"})
+
+	_, err := RenderTransactionReportPdf(report, formatter, locales.GetLocaleTextItems("en").TransactionReportTextItems, time.Unix(1712000000, 0))
+	assert.Equal(t, errs.ErrReportPdfTextUnsupported, err)
+}
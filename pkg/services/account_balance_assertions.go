@@ -0,0 +1,376 @@
+package services
+
+import (
+	"time"
+
+	"xorm.io/xorm"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/datastore"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+	"github.com/mayswind/ezbookkeeping/pkg/uuid"
+)
+
+// AccountBalanceAssertionService represents account balance assertion service
+type AccountBalanceAssertionService struct {
+	ServiceUsingDB
+	ServiceUsingUuid
+}
+
+// Initialize a account balance assertion service singleton instance
+var (
+	AccountBalanceAssertions = &AccountBalanceAssertionService{
+		ServiceUsingDB: ServiceUsingDB{
+			container: datastore.Container,
+		},
+		ServiceUsingUuid: ServiceUsingUuid{
+			container: uuid.Container,
+		},
+	}
+)
+
+// GetAllAssertionsByUid returns all account balance assertion models of user
+func (s *AccountBalanceAssertionService) GetAllAssertionsByUid(c core.Context, uid int64) ([]*models.AccountBalanceAssertion, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	var assertions []*models.AccountBalanceAssertion
+	err := s.UserDataDB(uid).NewSession(c).Where("uid=? AND deleted=?", uid, false).OrderBy("account_id asc, assertion_time asc").Find(&assertions)
+
+	return assertions, err
+}
+
+// GetAssertionsByAccountId returns all account balance assertion models of specified account
+func (s *AccountBalanceAssertionService) GetAssertionsByAccountId(c core.Context, uid int64, accountId int64) ([]*models.AccountBalanceAssertion, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	if accountId <= 0 {
+		return nil, errs.ErrAccountIdInvalid
+	}
+
+	var assertions []*models.AccountBalanceAssertion
+	err := s.UserDataDB(uid).NewSession(c).Where("uid=? AND deleted=? AND account_id=?", uid, false, accountId).OrderBy("assertion_time asc").Find(&assertions)
+
+	return assertions, err
+}
+
+// GetAssertionByAssertionId returns an account balance assertion model according to assertion id
+func (s *AccountBalanceAssertionService) GetAssertionByAssertionId(c core.Context, uid int64, assertionId int64) (*models.AccountBalanceAssertion, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	if assertionId <= 0 {
+		return nil, errs.ErrAccountBalanceAssertionIdInvalid
+	}
+
+	assertion := &models.AccountBalanceAssertion{}
+	has, err := s.UserDataDB(uid).NewSession(c).ID(assertionId).Where("uid=? AND deleted=?", uid, false).Get(assertion)
+
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, errs.ErrAccountBalanceAssertionNotFound
+	}
+
+	return assertion, nil
+}
+
+// CreateAssertion saves a new account balance assertion model to database
+func (s *AccountBalanceAssertionService) CreateAssertion(c core.Context, assertion *models.AccountBalanceAssertion) error {
+	if assertion.Uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	assertion.AssertionId = s.GenerateUuid(uuid.UUID_TYPE_ASSERTION)
+
+	if assertion.AssertionId < 1 {
+		return errs.ErrSystemIsBusy
+	}
+
+	assertion.Deleted = false
+	assertion.CreatedUnixTime = time.Now().Unix()
+	assertion.UpdatedUnixTime = time.Now().Unix()
+
+	return s.UserDataDB(assertion.Uid).DoTransaction(c, func(sess *xorm.Session) error {
+		err := s.isAccountValid(sess, assertion.Uid, assertion.AccountId)
+
+		if err != nil {
+			return err
+		}
+
+		exists, err := sess.Cols("uid", "deleted", "account_id", "assertion_time").Where("uid=? AND deleted=? AND account_id=? AND assertion_time=?", assertion.Uid, false, assertion.AccountId, assertion.AssertionTime).Limit(1).Exist(&models.AccountBalanceAssertion{})
+
+		if err != nil {
+			return err
+		} else if exists {
+			return errs.ErrAccountBalanceAssertionAlreadyExists
+		}
+
+		_, err = sess.Insert(assertion)
+		return err
+	})
+}
+
+// CreateAssertions saves a few account balance assertion models to database, the assertions which already exist at the same time will be skipped
+func (s *AccountBalanceAssertionService) CreateAssertions(c core.Context, uid int64, assertions []*models.AccountBalanceAssertion) (int, error) {
+	if uid <= 0 {
+		return 0, errs.ErrUserIdInvalid
+	}
+
+	if len(assertions) < 1 {
+		return 0, nil
+	}
+
+	assertionUuids := s.GenerateUuids(uuid.UUID_TYPE_ASSERTION, uint16(len(assertions)))
+
+	if len(assertionUuids) < len(assertions) {
+		return 0, errs.ErrSystemIsBusy
+	}
+
+	for i := 0; i < len(assertions); i++ {
+		assertion := assertions[i]
+		assertion.AssertionId = assertionUuids[i]
+		assertion.Uid = uid
+		assertion.Deleted = false
+		assertion.CreatedUnixTime = time.Now().Unix()
+		assertion.UpdatedUnixTime = time.Now().Unix()
+	}
+
+	createdCount := 0
+
+	err := s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
+		checkedAccountIds := make(map[int64]bool)
+
+		for i := 0; i < len(assertions); i++ {
+			assertion := assertions[i]
+
+			if _, exists := checkedAccountIds[assertion.AccountId]; !exists {
+				err := s.isAccountValid(sess, uid, assertion.AccountId)
+
+				if err != nil {
+					return err
+				}
+
+				checkedAccountIds[assertion.AccountId] = true
+			}
+
+			exists, err := sess.Cols("uid", "deleted", "account_id", "assertion_time").Where("uid=? AND deleted=? AND account_id=? AND assertion_time=?", uid, false, assertion.AccountId, assertion.AssertionTime).Limit(1).Exist(&models.AccountBalanceAssertion{})
+
+			if err != nil {
+				return err
+			} else if exists {
+				continue
+			}
+
+			_, err = sess.Insert(assertion)
+
+			if err != nil {
+				return err
+			}
+
+			createdCount++
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return 0, err
+	}
+
+	return createdCount, nil
+}
+
+// ModifyAssertion saves an existed account balance assertion model to database
+func (s *AccountBalanceAssertionService) ModifyAssertion(c core.Context, assertion *models.AccountBalanceAssertion) error {
+	if assertion.Uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	assertion.UpdatedUnixTime = time.Now().Unix()
+
+	return s.UserDataDB(assertion.Uid).DoTransaction(c, func(sess *xorm.Session) error {
+		exists, err := sess.Cols("uid", "deleted", "account_id", "assertion_time").Where("uid=? AND deleted=? AND account_id=? AND assertion_time=? AND assertion_id<>?", assertion.Uid, false, assertion.AccountId, assertion.AssertionTime, assertion.AssertionId).Limit(1).Exist(&models.AccountBalanceAssertion{})
+
+		if err != nil {
+			return err
+		} else if exists {
+			return errs.ErrAccountBalanceAssertionAlreadyExists
+		}
+
+		updatedRows, err := sess.ID(assertion.AssertionId).Cols("assertion_time", "timezone_utc_offset", "expected_balance", "comment", "updated_unix_time").Where("uid=? AND deleted=?", assertion.Uid, false).Update(assertion)
+
+		if err != nil {
+			return err
+		} else if updatedRows < 1 {
+			return errs.ErrAccountBalanceAssertionNotFound
+		}
+
+		return err
+	})
+}
+
+// DeleteAssertion deletes an existed account balance assertion from database
+func (s *AccountBalanceAssertionService) DeleteAssertion(c core.Context, uid int64, assertionId int64) error {
+	if uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	now := time.Now().Unix()
+
+	updateModel := &models.AccountBalanceAssertion{
+		Deleted:         true,
+		DeletedUnixTime: now,
+	}
+
+	return s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
+		deletedRows, err := sess.ID(assertionId).Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=?", uid, false).Update(updateModel)
+
+		if err != nil {
+			return err
+		} else if deletedRows < 1 {
+			return errs.ErrAccountBalanceAssertionNotFound
+		}
+
+		return err
+	})
+}
+
+// CheckAssertions returns the check results of all account balance assertions of user (or of specified account if account id is set)
+func (s *AccountBalanceAssertionService) CheckAssertions(c core.Context, uid int64, accountId int64) ([]*models.AccountBalanceAssertionCheckResult, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	var assertions []*models.AccountBalanceAssertion
+	var err error
+
+	if accountId > 0 {
+		assertions, err = s.GetAssertionsByAccountId(c, uid, accountId)
+	} else {
+		assertions, err = s.GetAllAssertionsByUid(c, uid)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	accountAssertions := make(map[int64][]*models.AccountBalanceAssertion)
+	accountIds := make([]int64, 0)
+
+	for i := 0; i < len(assertions); i++ {
+		assertion := assertions[i]
+
+		if _, exists := accountAssertions[assertion.AccountId]; !exists {
+			accountIds = append(accountIds, assertion.AccountId)
+		}
+
+		accountAssertions[assertion.AccountId] = append(accountAssertions[assertion.AccountId], assertion)
+	}
+
+	results := make([]*models.AccountBalanceAssertionCheckResult, 0, len(assertions))
+
+	for i := 0; i < len(accountIds); i++ {
+		currentAccountAssertions := accountAssertions[accountIds[i]]
+		maxAssertionTime := currentAccountAssertions[len(currentAccountAssertions)-1].AssertionTime
+
+		var transactions []*models.Transaction
+		err = s.UserDataDB(uid).NewSession(c).Where("uid=? AND deleted=? AND account_id=? AND transaction_time<?", uid, false, accountIds[i], utils.GetMinTransactionTimeFromUnixTime(maxAssertionTime)).OrderBy("transaction_time asc").Find(&transactions)
+
+		if err != nil {
+			return nil, err
+		}
+
+		accountResults, err := s.checkAccountAssertions(currentAccountAssertions, transactions)
+
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, accountResults...)
+	}
+
+	return results, nil
+}
+
+func (s *AccountBalanceAssertionService) checkAccountAssertions(assertions []*models.AccountBalanceAssertion, transactions []*models.Transaction) ([]*models.AccountBalanceAssertionCheckResult, error) {
+	results := make([]*models.AccountBalanceAssertionCheckResult, len(assertions))
+	balance := int64(0)
+	transactionIndex := 0
+	lastPassedTransactionIndex := 0
+
+	for i := 0; i < len(assertions); i++ {
+		assertion := assertions[i]
+		minTransactionTime := utils.GetMinTransactionTimeFromUnixTime(assertion.AssertionTime)
+
+		for ; transactionIndex < len(transactions) && transactions[transactionIndex].TransactionTime < minTransactionTime; transactionIndex++ {
+			amount, err := s.getAccountBalanceChangedAmount(transactions[transactionIndex])
+
+			if err != nil {
+				return nil, err
+			}
+
+			balance += amount
+		}
+
+		result := &models.AccountBalanceAssertionCheckResult{
+			Assertion:     assertion,
+			ActualBalance: balance,
+			Passed:        balance == assertion.ExpectedBalance,
+		}
+
+		if result.Passed {
+			lastPassedTransactionIndex = transactionIndex
+		} else {
+			result.TransactionsSinceLastPassed = transactions[lastPassedTransactionIndex:transactionIndex]
+		}
+
+		results[i] = result
+	}
+
+	return results, nil
+}
+
+func (s *AccountBalanceAssertionService) getAccountBalanceChangedAmount(transaction *models.Transaction) (int64, error) {
+	switch transaction.Type {
+	case models.TRANSACTION_DB_TYPE_MODIFY_BALANCE:
+		return transaction.RelatedAccountAmount, nil
+	case models.TRANSACTION_DB_TYPE_INCOME:
+		return transaction.Amount, nil
+	case models.TRANSACTION_DB_TYPE_EXPENSE:
+		return -transaction.Amount, nil
+	case models.TRANSACTION_DB_TYPE_TRANSFER_OUT:
+		return -transaction.Amount, nil
+	case models.TRANSACTION_DB_TYPE_TRANSFER_IN:
+		return transaction.Amount, nil
+	default:
+		return 0, errs.ErrTransactionTypeInvalid
+	}
+}
+
+func (s *AccountBalanceAssertionService) isAccountValid(sess *xorm.Session, uid int64, accountId int64) error {
+	if accountId <= 0 {
+		return errs.ErrAccountIdInvalid
+	}
+
+	account := &models.Account{}
+	has, err := sess.ID(accountId).Where("uid=? AND deleted=?", uid, false).Get(account)
+
+	if err != nil {
+		return err
+	} else if !has {
+		return errs.ErrAccountNotFound
+	}
+
+	if account.Type == models.ACCOUNT_TYPE_MULTI_SUB_ACCOUNTS {
+		return errs.ErrAccountBalanceAssertionCannotAddToParentAccount
+	}
+
+	return nil
+}
@@ -19,6 +19,7 @@ import (
 type AccountService struct {
 	ServiceUsingDB
 	ServiceUsingUuid
+	ServiceUsingWebhook
 }
 
 // Initialize a account service singleton instance
@@ -30,6 +31,9 @@ var (
 		ServiceUsingUuid: ServiceUsingUuid{
 			container: uuid.Container,
 		},
+		ServiceUsingWebhook: ServiceUsingWebhook{
+			container: Webhooks,
+		},
 	}
 )
 
@@ -273,7 +277,7 @@ func (s *AccountService) CreateAccounts(c core.Context, mainAccount *models.Acco
 
 	userDataDb := s.UserDataDB(mainAccount.Uid)
 
-	return userDataDb.DoTransaction(c, func(sess *xorm.Session) error {
+	err := userDataDb.DoTransaction(c, func(sess *xorm.Session) error {
 		for i := 0; i < len(allAccounts); i++ {
 			account := allAccounts[i]
 			_, err := sess.Insert(account)
@@ -340,6 +344,22 @@ func (s *AccountService) CreateAccounts(c core.Context, mainAccount *models.Acco
 
 		return nil
 	})
+
+	if err != nil {
+		return err
+	}
+
+	balanceChangedAccountIds := make([]int64, 0, len(allAccounts))
+
+	for i := 0; i < len(allAccounts); i++ {
+		if allAccounts[i].Balance != 0 {
+			balanceChangedAccountIds = append(balanceChangedAccountIds, allAccounts[i].AccountId)
+		}
+	}
+
+	s.EmitAccountBalanceChangedWebhookEvents(c, mainAccount.Uid, balanceChangedAccountIds)
+
+	return nil
 }
 
 // ModifyAccounts saves an existed account model to database
@@ -413,7 +433,7 @@ func (s *AccountService) ModifyAccounts(c core.Context, mainAccount *models.Acco
 
 	userDataDb := s.UserDataDB(mainAccount.Uid)
 
-	return userDataDb.DoTransaction(c, func(sess *xorm.Session) error {
+	err := userDataDb.DoTransaction(c, func(sess *xorm.Session) error {
 		// update accounts
 		for i := 0; i < len(updateAccounts); i++ {
 			account := updateAccounts[i]
@@ -562,6 +582,22 @@ func (s *AccountService) ModifyAccounts(c core.Context, mainAccount *models.Acco
 
 		return nil
 	})
+
+	if err != nil {
+		return err
+	}
+
+	balanceChangedAccountIds := make([]int64, 0, len(addSubAccounts))
+
+	for i := 0; i < len(addSubAccounts); i++ {
+		if addSubAccounts[i].Balance != 0 {
+			balanceChangedAccountIds = append(balanceChangedAccountIds, addSubAccounts[i].AccountId)
+		}
+	}
+
+	s.EmitAccountBalanceChangedWebhookEvents(c, mainAccount.Uid, balanceChangedAccountIds)
+
+	return nil
 }
 
 // HideAccount updates hidden field of given accounts
@@ -4,9 +4,11 @@ import (
 	"fmt"
 	"path/filepath"
 
+	"github.com/mayswind/ezbookkeeping/pkg/core"
 	"github.com/mayswind/ezbookkeeping/pkg/datastore"
 	"github.com/mayswind/ezbookkeeping/pkg/errs"
 	"github.com/mayswind/ezbookkeeping/pkg/mail"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
 	"github.com/mayswind/ezbookkeeping/pkg/settings"
 	"github.com/mayswind/ezbookkeeping/pkg/storage"
 	"github.com/mayswind/ezbookkeeping/pkg/utils"
@@ -77,6 +79,41 @@ func (s *ServiceUsingMailer) SendMail(message *mail.MailMessage) error {
 	return s.container.Current.SendMail(message)
 }
 
+// ServiceUsingWebhook represents a service that need to emit webhook events
+type ServiceUsingWebhook struct {
+	container *WebhookService
+}
+
+// EmitTransactionWebhookEvent adds the transaction event to the webhook delivery queue
+func (s *ServiceUsingWebhook) EmitTransactionWebhookEvent(c core.Context, eventType models.WebhookEventType, transaction *models.Transaction) {
+	s.container.EnqueueTransactionEvent(c, eventType, transaction)
+}
+
+// EmitAccountBalanceChangedWebhookEvents adds the account balance changed events to the webhook delivery queue
+func (s *ServiceUsingWebhook) EmitAccountBalanceChangedWebhookEvents(c core.Context, uid int64, accountIds []int64) {
+	s.container.EnqueueAccountBalanceChangedEvents(c, uid, accountIds)
+}
+
+// EmitImportCompletedWebhookEvent adds the import completed event to the webhook delivery queue
+func (s *ServiceUsingWebhook) EmitImportCompletedWebhookEvent(c core.Context, uid int64, transactionCount int) {
+	s.container.EnqueueImportCompletedEvent(c, uid, transactionCount)
+}
+
+// ServiceUsingAnomalyDetector represents a service that need to detect transaction anomalies
+type ServiceUsingAnomalyDetector struct {
+	container *TransactionAnomalyService
+}
+
+// EnqueueTransactionAnomalyDetection adds the specified new transactions to the anomaly detection queue
+func (s *ServiceUsingAnomalyDetector) EnqueueTransactionAnomalyDetection(c core.Context, uid int64, transactions []*models.Transaction) {
+	s.container.EnqueueNewTransactionAnomalyDetection(c, uid, transactions)
+}
+
+// EnqueueTransactionAnomalyRedetection removes the existed anomalies of the specified modified transaction and adds it to the anomaly detection queue
+func (s *ServiceUsingAnomalyDetector) EnqueueTransactionAnomalyRedetection(c core.Context, uid int64, transaction *models.Transaction) {
+	s.container.EnqueueModifiedTransactionAnomalyRedetection(c, uid, transaction)
+}
+
 // ServiceUsingUuid represents a service that need to use uuid
 type ServiceUsingUuid struct {
 	container *uuid.UuidContainer
@@ -0,0 +1,354 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/datastore"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/locales"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
+	"github.com/mayswind/ezbookkeeping/pkg/mail"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/settings"
+	"github.com/mayswind/ezbookkeeping/pkg/templates"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+const pageCountForLoadBillReminderItems = 1000
+
+// BillReminderService represents bill reminder service
+type BillReminderService struct {
+	ServiceUsingDB
+	ServiceUsingConfig
+	ServiceUsingMailer
+}
+
+// Initialize a bill reminder service singleton instance
+var (
+	BillReminders = &BillReminderService{
+		ServiceUsingDB: ServiceUsingDB{
+			container: datastore.Container,
+		},
+		ServiceUsingConfig: ServiceUsingConfig{
+			container: settings.Container,
+		},
+		ServiceUsingMailer: ServiceUsingMailer{
+			container: mail.Container,
+		},
+	}
+)
+
+type billReminderItem struct {
+	name                  string
+	isCreditCardStatement bool
+	unixTime              int64
+	timezone              *time.Location
+	accountId             int64
+	amount                int64
+	hideAmount            bool
+	digest                bool
+}
+
+type userBillReminderItems struct {
+	uid   int64
+	items []*billReminderItem
+}
+
+// SendBillReminders sends the reminder mails of the scheduled transactions and credit card statement dates which will arrive after the reminder days
+func (s *BillReminderService) SendBillReminders(c core.Context, currentUnixTime int64) error {
+	if !s.CurrentConfig().EnableSMTP {
+		return errs.ErrSMTPServerNotEnabled
+	}
+
+	allUserItems := make([]*userBillReminderItems, 0)
+	allUserItemsMap := make(map[int64]*userBillReminderItems)
+
+	addItem := func(uid int64, item *billReminderItem) {
+		userItems, exists := allUserItemsMap[uid]
+
+		if !exists {
+			userItems = &userBillReminderItems{
+				uid:   uid,
+				items: make([]*billReminderItem, 0),
+			}
+			allUserItems = append(allUserItems, userItems)
+			allUserItemsMap[uid] = userItems
+		}
+
+		userItems.items = append(userItems.items, item)
+	}
+
+	for i := 0; i < s.UserDataDBCount(); i++ {
+		err := s.loadScheduledTransactionBillReminderItems(c, i, currentUnixTime, addItem)
+
+		if err != nil {
+			return err
+		}
+
+		err = s.loadCreditCardStatementBillReminderItems(c, i, currentUnixTime, addItem)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(allUserItems) < 1 {
+		return nil
+	}
+
+	log.Infof(c, "[bill_reminders.SendBillReminders] should send bill reminders to %d users now", len(allUserItems))
+
+	successCount := 0
+	failedCount := 0
+
+	for i := 0; i < len(allUserItems); i++ {
+		userItems := allUserItems[i]
+		sentCount, err := s.sendUserBillReminders(c, userItems.uid, userItems.items)
+
+		if err != nil {
+			failedCount++
+			log.Errorf(c, "[bill_reminders.SendBillReminders] failed to send bill reminders to user \"uid:%d\", because %s", userItems.uid, err.Error())
+			continue
+		}
+
+		successCount += sentCount
+	}
+
+	log.Infof(c, "[bill_reminders.SendBillReminders] %d bill reminder mails have been sent, %d users failed", successCount, failedCount)
+
+	return nil
+}
+
+// loadScheduledTransactionBillReminderItems loads the scheduled transaction templates in the specified user data database page by page,
+// and adds the bill reminder items of the templates which should be reminded now
+func (s *BillReminderService) loadScheduledTransactionBillReminderItems(c core.Context, dbIndex int, currentUnixTime int64, addItem func(uid int64, item *billReminderItem)) error {
+	minTemplateId := int64(0)
+
+	for {
+		var templates []*models.TransactionTemplate
+		err := s.UserDataDBByIndex(dbIndex).NewSession(c).Where("template_id>? AND deleted=? AND template_type=? AND scheduled_frequency_type>? AND reminder_days>?", minTemplateId, false, models.TRANSACTION_TEMPLATE_TYPE_SCHEDULE, models.TRANSACTION_SCHEDULE_FREQUENCY_TYPE_DISABLED, 0).Limit(pageCountForLoadBillReminderItems, 0).OrderBy("template_id asc").Find(&templates)
+
+		if err != nil {
+			return err
+		}
+
+		for i := 0; i < len(templates); i++ {
+			template := templates[i]
+			reminderTime := template.GetReminderScheduledTransactionTime(currentUnixTime)
+
+			if reminderTime <= 0 {
+				continue
+			}
+
+			addItem(template.Uid, &billReminderItem{
+				name:       template.Name,
+				unixTime:   reminderTime,
+				timezone:   time.FixedZone("Template Timezone", int(template.ScheduledTimezoneUtcOffset)*60),
+				accountId:  template.AccountId,
+				amount:     template.Amount,
+				hideAmount: template.HideAmount || template.AmountExpression != "",
+				digest:     template.ReminderDigest,
+			})
+		}
+
+		if len(templates) < pageCountForLoadBillReminderItems {
+			return nil
+		}
+
+		minTemplateId = templates[len(templates)-1].TemplateId
+	}
+}
+
+// loadCreditCardStatementBillReminderItems loads the credit card accounts in the specified user data database page by page,
+// and adds the bill reminder items of the accounts whose statement date should be reminded now in the timezone which the reminder is set in
+func (s *BillReminderService) loadCreditCardStatementBillReminderItems(c core.Context, dbIndex int, currentUnixTime int64, addItem func(uid int64, item *billReminderItem)) error {
+	minAccountId := int64(0)
+
+	for {
+		var accounts []*models.Account
+		err := s.UserDataDBByIndex(dbIndex).NewSession(c).Where("account_id>? AND deleted=? AND category=? AND parent_account_id=?", minAccountId, false, models.ACCOUNT_CATEGORY_CREDIT_CARD, models.LevelOneAccountParentId).Limit(pageCountForLoadBillReminderItems, 0).OrderBy("account_id asc").Find(&accounts)
+
+		if err != nil {
+			return err
+		}
+
+		for i := 0; i < len(accounts); i++ {
+			account := accounts[i]
+			timezone := account.Extend.GetCreditCardReminderTimezone()
+			reminderTime := account.GetReminderCreditCardStatementTime(currentUnixTime, timezone)
+
+			if reminderTime <= 0 {
+				continue
+			}
+
+			addItem(account.Uid, &billReminderItem{
+				name:                  account.Name,
+				isCreditCardStatement: true,
+				unixTime:              reminderTime,
+				timezone:              timezone,
+				accountId:             account.AccountId,
+				amount:                -account.Balance,
+				hideAmount:            account.Balance >= 0,
+				digest:                account.Extend.IsCreditCardReminderDigest(),
+			})
+		}
+
+		if len(accounts) < pageCountForLoadBillReminderItems {
+			return nil
+		}
+
+		minAccountId = accounts[len(accounts)-1].AccountId
+	}
+}
+
+func (s *BillReminderService) sendUserBillReminders(c core.Context, uid int64, items []*billReminderItem) (int, error) {
+	user := &models.User{}
+	has, err := s.UserDB().NewSession(c).ID(uid).Where("deleted=?", false).Get(user)
+
+	if err != nil {
+		return 0, err
+	} else if !has {
+		return 0, errs.ErrUserNotFound
+	}
+
+	if user.Disabled || user.Email == "" {
+		return 0, nil
+	}
+
+	if s.CurrentConfig().EnableUserVerifyEmail && !user.EmailVerified {
+		log.Infof(c, "[bill_reminders.sendUserBillReminders] skip sending bill reminders to user \"uid:%d\", because email is not verified", uid)
+		return 0, nil
+	}
+
+	accountIds := make([]int64, 0, len(items))
+
+	for i := 0; i < len(items); i++ {
+		accountIds = append(accountIds, items[i].accountId)
+	}
+
+	var accounts []*models.Account
+	err = s.UserDataDB(uid).NewSession(c).Cols("account_id", "currency", "extend").Where("uid=? AND deleted=?", uid, false).In("account_id", accountIds).Find(&accounts)
+
+	if err != nil {
+		return 0, err
+	}
+
+	accountMap := make(map[int64]*models.Account, len(accounts))
+
+	for i := 0; i < len(accounts); i++ {
+		accountMap[accounts[i].AccountId] = accounts[i]
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].unixTime < items[j].unixTime
+	})
+
+	textItems := locales.GetLocaleTextItems(user.Language).BillReminderMailTextItems
+	digestItems := make([]map[string]any, 0)
+	sentCount := 0
+
+	for i := 0; i < len(items); i++ {
+		item := items[i]
+		account, exists := accountMap[item.accountId]
+
+		if !exists {
+			continue
+		}
+
+		itemParams := s.getBillReminderItemParams(textItems, item, account)
+
+		if item.digest {
+			digestItems = append(digestItems, itemParams)
+			continue
+		}
+
+		err = s.sendBillReminderMail(user, templates.TEMPLATE_BILL_REMINDER, fmt.Sprintf(textItems.TitleFormat, itemParams["Name"]), map[string]any{
+			"Title":            fmt.Sprintf(textItems.TitleFormat, itemParams["Name"]),
+			"Salutation":       fmt.Sprintf(textItems.SalutationFormat, user.Nickname),
+			"Description":      textItems.Description,
+			"DateLabel":        textItems.Date,
+			"AmountLabel":      textItems.Amount,
+			"Item":             itemParams,
+			"DescriptionBelow": fmt.Sprintf(textItems.DescriptionBelowFormat, s.CurrentConfig().AppName),
+		})
+
+		if err != nil {
+			return sentCount, err
+		}
+
+		sentCount++
+	}
+
+	if len(digestItems) > 0 {
+		err = s.sendBillReminderMail(user, templates.TEMPLATE_BILL_REMINDER_DIGEST, textItems.DigestTitle, map[string]any{
+			"Title":            textItems.DigestTitle,
+			"Salutation":       fmt.Sprintf(textItems.SalutationFormat, user.Nickname),
+			"Description":      textItems.DigestDescription,
+			"NameLabel":        textItems.Name,
+			"DateLabel":        textItems.Date,
+			"AmountLabel":      textItems.Amount,
+			"Items":            digestItems,
+			"DescriptionBelow": fmt.Sprintf(textItems.DescriptionBelowFormat, s.CurrentConfig().AppName),
+		})
+
+		if err != nil {
+			return sentCount, err
+		}
+
+		sentCount++
+	}
+
+	return sentCount, nil
+}
+
+func (s *BillReminderService) getBillReminderItemParams(textItems *locales.BillReminderMailTextItems, item *billReminderItem, account *models.Account) map[string]any {
+	name := item.name
+
+	if item.isCreditCardStatement {
+		name = fmt.Sprintf(textItems.CreditCardStatementFormat, item.name)
+	}
+
+	amount := ""
+
+	if !item.hideAmount {
+		amount = fmt.Sprintf("%s %s", account.Currency, utils.FormatAmountWithPrecision(item.amount, account.GetCurrencyPrecision()))
+	}
+
+	return map[string]any{
+		"Name":   name,
+		"Date":   utils.FormatUnixTimeToLongDate(item.unixTime, item.timezone),
+		"Amount": amount,
+	}
+}
+
+func (s *BillReminderService) sendBillReminderMail(user *models.User, templateName templates.KnownTemplate, subject string, mailParams map[string]any) error {
+	tmpl, err := templates.GetTemplate(templateName)
+
+	if err != nil {
+		return err
+	}
+
+	templateParams := map[string]any{
+		"AppName":          s.CurrentConfig().AppName,
+		"BillReminderMail": mailParams,
+	}
+
+	var bodyBuffer bytes.Buffer
+	err = tmpl.Execute(&bodyBuffer, templateParams)
+
+	if err != nil {
+		return err
+	}
+
+	message := &mail.MailMessage{
+		To:      user.Email,
+		Subject: subject,
+		Body:    bodyBuffer.String(),
+	}
+
+	return s.SendMail(message)
+}
@@ -0,0 +1,167 @@
+package services
+
+import (
+	"time"
+
+	"xorm.io/xorm"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/datastore"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/exchangerates"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/settings"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+// ExchangeRateHistoryService represents historical exchange rate service
+type ExchangeRateHistoryService struct {
+	ServiceUsingDB
+	ServiceUsingConfig
+}
+
+// Initialize a historical exchange rate service singleton instance
+var (
+	ExchangeRateHistories = &ExchangeRateHistoryService{
+		ServiceUsingDB: ServiceUsingDB{
+			container: datastore.Container,
+		},
+		ServiceUsingConfig: ServiceUsingConfig{
+			container: settings.Container,
+		},
+	}
+)
+
+// GetExchangeRatesByDate returns the historical exchange rate models of the data source which are effective on the specified date (YYYYMMDD),
+// that is the exchange rates of the latest day not later than the specified date
+func (s *ExchangeRateHistoryService) GetExchangeRatesByDate(c core.Context, dataSource string, date int32) ([]*models.HistoricalExchangeRate, error) {
+	if dataSource == "" {
+		return nil, errs.ErrInvalidExchangeRatesDataSource
+	}
+
+	latestExchangeRate := &models.HistoricalExchangeRate{}
+	has, err := s.UserDB().NewSession(c).Where("data_source=? AND rate_date<=?", dataSource, date).OrderBy("rate_date desc").Limit(1).Get(latestExchangeRate)
+
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, errs.ErrHistoricalExchangeRatesNotFound
+	}
+
+	var exchangeRates []*models.HistoricalExchangeRate
+	err = s.UserDB().NewSession(c).Where("data_source=? AND rate_date=?", dataSource, latestExchangeRate.RateDate).OrderBy("currency asc").Find(&exchangeRates)
+
+	return exchangeRates, err
+}
+
+// GetExchangeRatesInDateRange returns all historical exchange rate models of the data source in the date range (YYYYMMDD)
+func (s *ExchangeRateHistoryService) GetExchangeRatesInDateRange(c core.Context, dataSource string, startDate int32, endDate int32) ([]*models.HistoricalExchangeRate, error) {
+	if dataSource == "" {
+		return nil, errs.ErrInvalidExchangeRatesDataSource
+	}
+
+	var exchangeRates []*models.HistoricalExchangeRate
+	err := s.UserDB().NewSession(c).Where("data_source=? AND rate_date>=? AND rate_date<=?", dataSource, startDate, endDate).OrderBy("rate_date asc, currency asc").Find(&exchangeRates)
+
+	return exchangeRates, err
+}
+
+// GetEffectiveExchangeRatesInDateRange returns all historical exchange rate models of the data source in the date range (YYYYMMDD),
+// the exchange rates of the latest day before the start date are also returned so that the rates on the start date can be determined
+func (s *ExchangeRateHistoryService) GetEffectiveExchangeRatesInDateRange(c core.Context, dataSource string, startDate int32, endDate int32) ([]*models.HistoricalExchangeRate, error) {
+	if dataSource == "" {
+		return nil, errs.ErrInvalidExchangeRatesDataSource
+	}
+
+	actualStartDate := startDate
+	latestExchangeRate := &models.HistoricalExchangeRate{}
+	has, err := s.UserDB().NewSession(c).Where("data_source=? AND rate_date<=?", dataSource, startDate).OrderBy("rate_date desc").Limit(1).Get(latestExchangeRate)
+
+	if err != nil {
+		return nil, err
+	} else if has {
+		actualStartDate = latestExchangeRate.RateDate
+	}
+
+	return s.GetExchangeRatesInDateRange(c, dataSource, actualStartDate, endDate)
+}
+
+// SaveLatestExchangeRates requests the latest exchange rates from the exchange rates data sources without cache,
+// the exchange rates of the current data source are saved as historical exchange rates by the refreshed handler
+func (s *ExchangeRateHistoryService) SaveLatestExchangeRates(c core.Context) error {
+	_, err := exchangerates.Container.RefreshLatestExchangeRates(c, 0, s.CurrentConfig())
+
+	return err
+}
+
+// SaveRefreshedLatestExchangeRates saves the latest exchange rates requested from the current data source as historical exchange rates,
+// it is called each time the latest exchange rates are refreshed, so the exchange rates merged from the fallback data sources are never saved
+func (s *ExchangeRateHistoryService) SaveRefreshedLatestExchangeRates(c core.Context, exchangeRateResponse *models.LatestExchangeRateResponse) {
+	err := s.SaveExchangeRates(c, s.CurrentConfig().ExchangeRatesDataSource, exchangeRateResponse)
+
+	if err != nil {
+		log.Warnf(c, "[exchange_rate_histories.SaveRefreshedLatestExchangeRates] failed to save latest exchange rates to history, because %s", err.Error())
+	}
+}
+
+// SaveHistoricalExchangeRates requests the exchange rates of every day in the date range from the current exchange rates data source and saves them as historical exchange rates,
+// returns the count of days which exchange rates are saved
+func (s *ExchangeRateHistoryService) SaveHistoricalExchangeRates(c core.Context, startDate time.Time, endDate time.Time) (int, error) {
+	exchangeRateResponses, err := exchangerates.Container.GetHistoricalExchangeRates(c, s.CurrentConfig(), startDate, endDate)
+
+	if err != nil {
+		return 0, err
+	}
+
+	for i := 0; i < len(exchangeRateResponses); i++ {
+		err = s.SaveExchangeRates(c, s.CurrentConfig().ExchangeRatesDataSource, exchangeRateResponses[i])
+
+		if err != nil {
+			return i, err
+		}
+	}
+
+	return len(exchangeRateResponses), nil
+}
+
+// SaveExchangeRates saves the exchange rates of the data source as the historical exchange rates of the update date (in UTC) of exchange rates
+func (s *ExchangeRateHistoryService) SaveExchangeRates(c core.Context, dataSource string, exchangeRateResponse *models.LatestExchangeRateResponse) error {
+	if dataSource == "" {
+		return errs.ErrInvalidExchangeRatesDataSource
+	}
+
+	if exchangeRateResponse == nil || exchangeRateResponse.UpdateTime <= 0 || len(exchangeRateResponse.ExchangeRates) < 1 {
+		return nil
+	}
+
+	rateDate := utils.FormatTimeToNumericDate(time.Unix(exchangeRateResponse.UpdateTime, 0).In(time.UTC))
+	now := time.Now().Unix()
+	exchangeRates := make([]*models.HistoricalExchangeRate, 0, len(exchangeRateResponse.ExchangeRates))
+
+	for i := 0; i < len(exchangeRateResponse.ExchangeRates); i++ {
+		exchangeRate := exchangeRateResponse.ExchangeRates[i]
+
+		exchangeRates = append(exchangeRates, &models.HistoricalExchangeRate{
+			DataSource:      dataSource,
+			RateDate:        rateDate,
+			Currency:        exchangeRate.Currency,
+			BaseCurrency:    exchangeRateResponse.BaseCurrency,
+			Rate:            exchangeRate.Rate,
+			CreatedUnixTime: now,
+			UpdatedUnixTime: now,
+		})
+	}
+
+	return s.UserDB().DoTransaction(c, func(sess *xorm.Session) error {
+		_, err := sess.Where("data_source=? AND rate_date=?", dataSource, rateDate).Delete(&models.HistoricalExchangeRate{})
+
+		if err != nil {
+			return err
+		}
+
+		_, err = sess.Insert(exchangeRates)
+
+		return err
+	})
+}
@@ -0,0 +1,76 @@
+package services
+
+import (
+	"time"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/datastore"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/recurring"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+const defaultRecurringTransactionDetectionMonths = 24
+
+// RecurringTransactionService represents recurring transaction detection service
+type RecurringTransactionService struct {
+	ServiceUsingDB
+}
+
+// Initialize a recurring transaction service singleton instance
+var (
+	RecurringTransactions = &RecurringTransactionService{
+		ServiceUsingDB: ServiceUsingDB{
+			container: datastore.Container,
+		},
+	}
+)
+
+// GetRecurringTransactionSeries returns the recurring series detected from the income and expense transactions of user in recent months
+func (s *RecurringTransactionService) GetRecurringTransactionSeries(c core.Context, uid int64, months int32, minOccurrences int32) ([]*recurring.RecurringTransactionSeries, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	if months <= 0 {
+		months = defaultRecurringTransactionDetectionMonths
+	}
+
+	now := time.Now()
+	minTransactionTime := utils.GetMinTransactionTimeFromUnixTime(now.AddDate(0, -int(months), 0).Unix())
+	maxTransactionTime := int64(0)
+	var allTransactions []*models.Transaction
+
+	for maxTransactionTime >= 0 {
+		var transactions []*models.Transaction
+
+		sess := s.UserDataDB(uid).NewSession(c).Cols("transaction_id", "type", "category_id", "account_id", "transaction_time", "timezone_utc_offset", "amount", "comment").Where("uid=? AND deleted=? AND transaction_time>=?", uid, false, minTransactionTime).In("type", models.TRANSACTION_DB_TYPE_INCOME, models.TRANSACTION_DB_TYPE_EXPENSE)
+
+		if maxTransactionTime > 0 {
+			sess = sess.And("transaction_time<=?", maxTransactionTime)
+		}
+
+		err := sess.Limit(pageCountForLoadTransactionAmounts, 0).OrderBy("transaction_time desc").Find(&transactions)
+
+		if err != nil {
+			return nil, err
+		}
+
+		allTransactions = append(allTransactions, transactions...)
+
+		if len(transactions) < pageCountForLoadTransactionAmounts {
+			break
+		}
+
+		maxTransactionTime = transactions[len(transactions)-1].TransactionTime - 1
+	}
+
+	options := recurring.DefaultRecurringTransactionDetectorOptions(now.Unix())
+
+	if minOccurrences > 0 {
+		options.MinOccurrences = int(minOccurrences)
+	}
+
+	return recurring.DetectRecurringTransactionSeries(allTransactions, options), nil
+}
@@ -0,0 +1,544 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"xorm.io/xorm"
+
+	"github.com/mayswind/ezbookkeeping/pkg/converters"
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/datastore"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/exchangerates"
+	"github.com/mayswind/ezbookkeeping/pkg/locales"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
+	"github.com/mayswind/ezbookkeeping/pkg/mail"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/reports"
+	"github.com/mayswind/ezbookkeeping/pkg/settings"
+	"github.com/mayswind/ezbookkeeping/pkg/templates"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+	"github.com/mayswind/ezbookkeeping/pkg/uuid"
+)
+
+const maxScheduledReportMailTopCategories = 5
+
+// ScheduledReportService represents scheduled report service
+type ScheduledReportService struct {
+	ServiceUsingDB
+	ServiceUsingConfig
+	ServiceUsingMailer
+	ServiceUsingUuid
+}
+
+// Initialize a scheduled report service singleton instance
+var (
+	ScheduledReports = &ScheduledReportService{
+		ServiceUsingDB: ServiceUsingDB{
+			container: datastore.Container,
+		},
+		ServiceUsingConfig: ServiceUsingConfig{
+			container: settings.Container,
+		},
+		ServiceUsingMailer: ServiceUsingMailer{
+			container: mail.Container,
+		},
+		ServiceUsingUuid: ServiceUsingUuid{
+			container: uuid.Container,
+		},
+	}
+)
+
+// GetAllScheduledReportsByUid returns all scheduled report models of user
+func (s *ScheduledReportService) GetAllScheduledReportsByUid(c core.Context, uid int64) ([]*models.ScheduledReport, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	var scheduledReports []*models.ScheduledReport
+	err := s.UserDataDB(uid).NewSession(c).Where("uid=? AND deleted=?", uid, false).OrderBy("report_id asc").Find(&scheduledReports)
+
+	return scheduledReports, err
+}
+
+// GetScheduledReportByReportId returns a scheduled report model according to report id
+func (s *ScheduledReportService) GetScheduledReportByReportId(c core.Context, uid int64, reportId int64) (*models.ScheduledReport, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	if reportId <= 0 {
+		return nil, errs.ErrScheduledReportIdInvalid
+	}
+
+	scheduledReport := &models.ScheduledReport{}
+	has, err := s.UserDataDB(uid).NewSession(c).ID(reportId).Where("uid=? AND deleted=?", uid, false).Get(scheduledReport)
+
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, errs.ErrScheduledReportNotFound
+	}
+
+	return scheduledReport, nil
+}
+
+// CreateScheduledReport saves a new scheduled report model to database, the report of current period will be sent after the period ends
+func (s *ScheduledReportService) CreateScheduledReport(c core.Context, scheduledReport *models.ScheduledReport, firstDayOfWeek core.WeekDay) error {
+	if scheduledReport.Uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	scheduledReport.ReportId = s.GenerateUuid(uuid.UUID_TYPE_REPORT)
+
+	if scheduledReport.ReportId < 1 {
+		return errs.ErrSystemIsBusy
+	}
+
+	now := time.Now().Unix()
+
+	scheduledReport.Deleted = false
+	scheduledReport.NextSendUnixTime = scheduledReport.GetNextSendUnixTime(now, firstDayOfWeek)
+	scheduledReport.CreatedUnixTime = now
+	scheduledReport.UpdatedUnixTime = now
+
+	return s.UserDataDB(scheduledReport.Uid).DoTransaction(c, func(sess *xorm.Session) error {
+		_, err := sess.Insert(scheduledReport)
+		return err
+	})
+}
+
+// ModifyScheduledReport saves an existed scheduled report model to database, the next send time is recalculated according to the new frequency
+func (s *ScheduledReportService) ModifyScheduledReport(c core.Context, scheduledReport *models.ScheduledReport, firstDayOfWeek core.WeekDay) error {
+	if scheduledReport.Uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	now := time.Now().Unix()
+
+	scheduledReport.NextSendUnixTime = scheduledReport.GetNextSendUnixTime(now, firstDayOfWeek)
+	scheduledReport.UpdatedUnixTime = now
+
+	return s.UserDataDB(scheduledReport.Uid).DoTransaction(c, func(sess *xorm.Session) error {
+		updatedRows, err := sess.ID(scheduledReport.ReportId).Cols("frequency_type", "timezone_utc_offset", "attach_csv", "attach_pdf", "disabled", "next_send_unix_time", "updated_unix_time").Where("uid=? AND deleted=?", scheduledReport.Uid, false).Update(scheduledReport)
+
+		if err != nil {
+			return err
+		} else if updatedRows < 1 {
+			return errs.ErrScheduledReportNotFound
+		}
+
+		return err
+	})
+}
+
+// DeleteScheduledReport deletes an existed scheduled report from database
+func (s *ScheduledReportService) DeleteScheduledReport(c core.Context, uid int64, reportId int64) error {
+	if uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	now := time.Now().Unix()
+
+	updateModel := &models.ScheduledReport{
+		Deleted:         true,
+		DeletedUnixTime: now,
+	}
+
+	return s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
+		deletedRows, err := sess.ID(reportId).Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=?", uid, false).Update(updateModel)
+
+		if err != nil {
+			return err
+		} else if deletedRows < 1 {
+			return errs.ErrScheduledReportNotFound
+		}
+
+		return err
+	})
+}
+
+// SendScheduledReports sends the report mails of all the scheduled reports whose periods have ended, the report which fails to send will be retried in the next run
+func (s *ScheduledReportService) SendScheduledReports(c core.Context, currentUnixTime int64) error {
+	if !s.CurrentConfig().EnableSMTP {
+		return errs.ErrSMTPServerNotEnabled
+	}
+
+	successCount := 0
+	failedCount := 0
+
+	for i := 0; i < s.UserDataDBCount(); i++ {
+		var scheduledReports []*models.ScheduledReport
+		err := s.UserDataDBByIndex(i).NewSession(c).Where("deleted=? AND disabled=? AND next_send_unix_time<=?", false, false, currentUnixTime).OrderBy("uid asc, report_id asc").Find(&scheduledReports)
+
+		if err != nil {
+			return err
+		}
+
+		for j := 0; j < len(scheduledReports); j++ {
+			scheduledReport := scheduledReports[j]
+			user := &models.User{}
+			has, err := s.UserDB().NewSession(c).ID(scheduledReport.Uid).Where("deleted=?", false).Get(user)
+
+			if err != nil {
+				failedCount++
+				log.Errorf(c, "[scheduled_reports.SendScheduledReports] failed to get user \"uid:%d\" of scheduled report \"id:%d\", because %s", scheduledReport.Uid, scheduledReport.ReportId, err.Error())
+				continue
+			}
+
+			sent := false
+
+			if has {
+				sent, err = s.sendUserScheduledReport(c, user, scheduledReport, currentUnixTime)
+
+				if err != nil {
+					failedCount++
+					log.Errorf(c, "[scheduled_reports.SendScheduledReports] failed to send scheduled report \"id:%d\" to user \"uid:%d\", because %s", scheduledReport.ReportId, scheduledReport.Uid, err.Error())
+					continue
+				}
+			}
+
+			if sent {
+				successCount++
+			}
+
+			err = s.updateScheduledReportSentTime(c, scheduledReport, user.FirstDayOfWeek, currentUnixTime, sent)
+
+			if err != nil {
+				log.Errorf(c, "[scheduled_reports.SendScheduledReports] failed to update next send time of scheduled report \"id:%d\" for user \"uid:%d\", because %s", scheduledReport.ReportId, scheduledReport.Uid, err.Error())
+			}
+		}
+	}
+
+	if successCount > 0 || failedCount > 0 {
+		log.Infof(c, "[scheduled_reports.SendScheduledReports] %d scheduled report mails have been sent, %d reports failed", successCount, failedCount)
+	}
+
+	return nil
+}
+
+func (s *ScheduledReportService) sendUserScheduledReport(c core.Context, user *models.User, scheduledReport *models.ScheduledReport, currentUnixTime int64) (bool, error) {
+	uid := user.Uid
+
+	if user.Disabled || user.Email == "" {
+		return false, nil
+	}
+
+	if s.CurrentConfig().EnableUserVerifyEmail && !user.EmailVerified {
+		log.Infof(c, "[scheduled_reports.sendUserScheduledReport] skip sending scheduled report to user \"uid:%d\", because email is not verified", uid)
+		return false, nil
+	}
+
+	startTime, endTime := scheduledReport.GetLastCompletedPeriodTimeRange(currentUnixTime, user.FirstDayOfWeek)
+	timezone := time.FixedZone("Report Timezone", int(scheduledReport.TimezoneUtcOffset)*60)
+
+	var accounts []*models.Account
+	err := s.UserDataDB(uid).NewSession(c).Where("uid=? AND deleted=?", uid, false).OrderBy("parent_account_id asc, display_order asc").Find(&accounts)
+
+	if err != nil {
+		return false, err
+	}
+
+	var categories []*models.TransactionCategory
+	err = s.UserDataDB(uid).NewSession(c).Where("uid=? AND deleted=?", uid, false).Find(&categories)
+
+	if err != nil {
+		return false, err
+	}
+
+	transactions, err := s.getTransactionsInTimeRange(c, uid, utils.GetMinTransactionTimeFromUnixTime(startTime), utils.GetMaxTransactionTimeFromUnixTime(endTime))
+
+	if err != nil {
+		return false, err
+	}
+
+	accountMap := make(map[int64]*models.Account, len(accounts))
+	categoryMap := make(map[int64]*models.TransactionCategory, len(categories))
+	exchangeRates := make(models.ExchangeRatesMap)
+	needExchangeRates := false
+
+	for i := 0; i < len(accounts); i++ {
+		accountMap[accounts[i].AccountId] = accounts[i]
+
+		if accounts[i].Type == models.ACCOUNT_TYPE_SINGLE_ACCOUNT && accounts[i].Currency != user.DefaultCurrency {
+			needExchangeRates = true
+		}
+	}
+
+	for i := 0; i < len(categories); i++ {
+		categoryMap[categories[i].CategoryId] = categories[i]
+	}
+
+	if needExchangeRates {
+		exchangeRatesResp, err := exchangerates.Container.GetLatestExchangeRates(c, uid, s.CurrentConfig())
+
+		if err != nil {
+			log.Warnf(c, "[scheduled_reports.sendUserScheduledReport] failed to get latest exchange rates for user \"uid:%d\", because %s", uid, err.Error())
+		} else {
+			exchangeRates = exchangeRatesResp.ToExchangeRatesMap()
+		}
+	}
+
+	transactionReport := reports.NewTransactionReport(transactions, accountMap, categoryMap, &reports.TransactionReportOptions{
+		StartTime:      startTime,
+		EndTime:        endTime,
+		Currency:       user.DefaultCurrency,
+		ExchangeRates:  exchangeRates,
+		ClientTimezone: timezone,
+	})
+
+	formatter := reports.NewReportFormatter(user)
+	textItems := locales.GetLocaleTextItems(user.Language).ScheduledReportMailTextItems
+	unconvertibleCurrencies := make(map[string]bool)
+
+	for i := 0; i < len(transactionReport.UnconvertibleCurrencies); i++ {
+		unconvertibleCurrencies[transactionReport.UnconvertibleCurrencies[i]] = true
+	}
+
+	topExpenseCategories := make([]map[string]any, 0, maxScheduledReportMailTopCategories)
+
+	for i := 0; i < len(transactionReport.ExpenseCategories) && i < maxScheduledReportMailTopCategories; i++ {
+		categoryItem := transactionReport.ExpenseCategories[i]
+		topExpenseCategories = append(topExpenseCategories, map[string]any{
+			"Name":       categoryItem.Name,
+			"Amount":     formatter.FormatAmount(categoryItem.Amount, user.DefaultCurrency),
+			"Percentage": formatter.FormatPercentage(categoryItem.Percentage),
+		})
+	}
+
+	accountBalances := make([]map[string]any, 0, len(accounts))
+	netAssets := int64(0)
+
+	for i := 0; i < len(accounts); i++ {
+		account := accounts[i]
+
+		if account.Type != models.ACCOUNT_TYPE_SINGLE_ACCOUNT || account.Hidden {
+			continue
+		}
+
+		accountName := account.Name
+
+		if parentAccount, exists := accountMap[account.ParentAccountId]; exists {
+			if parentAccount.Hidden {
+				continue
+			}
+
+			accountName = parentAccount.Name + " > " + account.Name
+		}
+
+		accountBalances = append(accountBalances, map[string]any{
+			"Name":    accountName,
+			"Balance": formatter.FormatAmountWithPrecision(account.Balance, account.Currency, account.GetCurrencyPrecision()),
+		})
+
+		balance, success := exchangeRates.ConvertAmountWithPrecision(account.Balance, account.Currency, account.GetCurrencyPrecision(), user.DefaultCurrency, models.DEFAULT_CURRENCY_PRECISION)
+
+		if !success {
+			unconvertibleCurrencies[account.Currency] = true
+			continue
+		}
+
+		netAssets += balance
+	}
+
+	attachments, err := s.getScheduledReportAttachments(c, user, scheduledReport, transactionReport, formatter, transactions, accountMap, categoryMap, startTime, endTime, timezone)
+
+	if err != nil {
+		return false, err
+	}
+
+	title := textItems.WeeklyTitle
+
+	if scheduledReport.FrequencyType == models.SCHEDULED_REPORT_FREQUENCY_TYPE_MONTHLY {
+		title = textItems.MonthlyTitle
+	}
+
+	unconvertibleCurrenciesDescription := ""
+
+	if len(unconvertibleCurrencies) > 0 {
+		currencies := make([]string, 0, len(unconvertibleCurrencies))
+
+		for currency := range unconvertibleCurrencies {
+			currencies = append(currencies, currency)
+		}
+
+		sort.Strings(currencies)
+		unconvertibleCurrenciesDescription = fmt.Sprintf(textItems.UnconvertibleCurrenciesFormat, strings.Join(currencies, ", "))
+	}
+
+	attachmentDescription := ""
+
+	if len(attachments) > 0 {
+		attachmentDescription = textItems.AttachmentDescription
+	}
+
+	tmpl, err := templates.GetTemplate(templates.TEMPLATE_SCHEDULED_REPORT)
+
+	if err != nil {
+		return false, err
+	}
+
+	templateParams := map[string]any{
+		"AppName": s.CurrentConfig().AppName,
+		"ScheduledReportMail": map[string]any{
+			"Title":                     title,
+			"Salutation":                fmt.Sprintf(textItems.SalutationFormat, user.Nickname),
+			"Description":               fmt.Sprintf(textItems.DescriptionFormat, formatter.FormatDate(startTime, timezone), formatter.FormatDate(endTime, timezone)),
+			"TotalIncomeLabel":          textItems.TotalIncome,
+			"TotalIncome":               formatter.FormatAmount(transactionReport.TotalIncome, user.DefaultCurrency),
+			"TotalExpenseLabel":         textItems.TotalExpense,
+			"TotalExpense":              formatter.FormatAmount(transactionReport.TotalExpense, user.DefaultCurrency),
+			"NetIncomeLabel":            textItems.NetIncome,
+			"NetIncome":                 formatter.FormatAmount(transactionReport.NetIncome(), user.DefaultCurrency),
+			"TopExpenseCategoriesLabel": textItems.TopExpenseCategories,
+			"CategoryLabel":             textItems.Category,
+			"AmountLabel":               textItems.Amount,
+			"PercentageLabel":           textItems.Percentage,
+			"TopExpenseCategories":      topExpenseCategories,
+			"NoExpense":                 textItems.NoExpense,
+			"AccountBalancesLabel":      textItems.AccountBalances,
+			"AccountLabel":              textItems.Account,
+			"BalanceLabel":              textItems.Balance,
+			"AccountBalances":           accountBalances,
+			"NetAssetsLabel":            textItems.NetAssets,
+			"NetAssets":                 formatter.FormatAmount(netAssets, user.DefaultCurrency),
+			"UnconvertibleCurrencies":   unconvertibleCurrenciesDescription,
+			"AttachmentDescription":     attachmentDescription,
+			"DescriptionBelow":          fmt.Sprintf(textItems.DescriptionBelowFormat, s.CurrentConfig().AppName),
+		},
+	}
+
+	var bodyBuffer bytes.Buffer
+	err = tmpl.Execute(&bodyBuffer, templateParams)
+
+	if err != nil {
+		return false, err
+	}
+
+	message := &mail.MailMessage{
+		To:          user.Email,
+		Subject:     fmt.Sprintf("%s (%s - %s)", title, formatter.FormatDate(startTime, timezone), formatter.FormatDate(endTime, timezone)),
+		Body:        bodyBuffer.String(),
+		Attachments: attachments,
+	}
+
+	err = s.SendMail(message)
+
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (s *ScheduledReportService) getScheduledReportAttachments(c core.Context, user *models.User, scheduledReport *models.ScheduledReport, transactionReport *reports.TransactionReport, formatter *reports.ReportFormatter, transactions []*models.Transaction, accountMap map[int64]*models.Account, categoryMap map[int64]*models.TransactionCategory, startTime int64, endTime int64, timezone *time.Location) ([]*mail.MailAttachment, error) {
+	attachments := make([]*mail.MailAttachment, 0, 2)
+	fileNamePrefix := fmt.Sprintf("report_%s_%s", utils.FormatUnixTimeToLongDate(startTime, timezone), utils.FormatUnixTimeToLongDate(endTime, timezone))
+
+	if scheduledReport.AttachCsv && s.CurrentConfig().EnableDataExport && !user.FeatureRestriction.Contains(core.USER_FEATURE_RESTRICTION_TYPE_EXPORT_TRANSACTION) {
+		var tags []*models.TransactionTag
+		err := s.UserDataDB(user.Uid).NewSession(c).Where("uid=? AND deleted=?", user.Uid, false).Find(&tags)
+
+		if err != nil {
+			return nil, err
+		}
+
+		var tagIndexes []*models.TransactionTagIndex
+		err = s.UserDataDB(user.Uid).NewSession(c).Where("uid=? AND deleted=? AND transaction_time>=? AND transaction_time<=?", user.Uid, false, utils.GetMinTransactionTimeFromUnixTime(startTime), utils.GetMaxTransactionTimeFromUnixTime(endTime)).OrderBy("transaction_id asc, tag_index_id asc").Find(&tagIndexes)
+
+		if err != nil {
+			return nil, err
+		}
+
+		tagMap := make(map[int64]*models.TransactionTag, len(tags))
+
+		for i := 0; i < len(tags); i++ {
+			tagMap[tags[i].TagId] = tags[i]
+		}
+
+		allTransactionTagIds := make(map[int64][]int64)
+
+		for i := 0; i < len(tagIndexes); i++ {
+			allTransactionTagIds[tagIndexes[i].TransactionId] = append(allTransactionTagIds[tagIndexes[i].TransactionId], tagIndexes[i].TagId)
+		}
+
+		for _, tagIds := range allTransactionTagIds {
+			utils.Int64Sort(tagIds)
+		}
+
+		content, err := converters.GetTransactionDataExporter("csv").ToExportedContent(c, user.Uid, transactions, accountMap, categoryMap, tagMap, allTransactionTagIds)
+
+		if err != nil {
+			return nil, err
+		}
+
+		attachments = append(attachments, &mail.MailAttachment{
+			FileName:    fileNamePrefix + ".csv",
+			ContentType: "text/csv",
+			Content:     content,
+		})
+	}
+
+	if scheduledReport.AttachPdf {
+		content, err := reports.RenderTransactionReportPdf(transactionReport, formatter, locales.GetLocaleTextItems(user.Language).TransactionReportTextItems, time.Now())
+
+		if err == errs.ErrReportPdfTextUnsupported {
+			log.Warnf(c, "[scheduled_reports.getScheduledReportAttachments] skip pdf attachment of scheduled report \"id:%d\" for user \"uid:%d\", because %s", scheduledReport.ReportId, user.Uid, err.Error())
+		} else if err != nil {
+			return nil, err
+		} else {
+			attachments = append(attachments, &mail.MailAttachment{
+				FileName:    fileNamePrefix + ".pdf",
+				ContentType: "application/pdf",
+				Content:     content,
+			})
+		}
+	}
+
+	return attachments, nil
+}
+
+func (s *ScheduledReportService) getTransactionsInTimeRange(c core.Context, uid int64, minTransactionTime int64, maxTransactionTime int64) ([]*models.Transaction, error) {
+	var allTransactions []*models.Transaction
+
+	for maxTransactionTime >= minTransactionTime {
+		var transactions []*models.Transaction
+		err := s.UserDataDB(uid).NewSession(c).Where("uid=? AND deleted=? AND type<>? AND transaction_time>=? AND transaction_time<=?", uid, false, models.TRANSACTION_DB_TYPE_TRANSFER_IN, minTransactionTime, maxTransactionTime).Limit(pageCountForLoadTransactionAmounts, 0).OrderBy("transaction_time desc").Find(&transactions)
+
+		if err != nil {
+			return nil, err
+		}
+
+		allTransactions = append(allTransactions, transactions...)
+
+		if len(transactions) < pageCountForLoadTransactionAmounts {
+			break
+		}
+
+		maxTransactionTime = transactions[len(transactions)-1].TransactionTime - 1
+	}
+
+	return allTransactions, nil
+}
+
+func (s *ScheduledReportService) updateScheduledReportSentTime(c core.Context, scheduledReport *models.ScheduledReport, firstDayOfWeek core.WeekDay, currentUnixTime int64, sent bool) error {
+	updateCols := []string{"next_send_unix_time", "updated_unix_time"}
+	updateModel := &models.ScheduledReport{
+		NextSendUnixTime: scheduledReport.GetNextSendUnixTime(currentUnixTime, firstDayOfWeek),
+		UpdatedUnixTime:  time.Now().Unix(),
+	}
+
+	if sent {
+		updateModel.LastSentUnixTime = currentUnixTime
+		updateCols = append(updateCols, "last_sent_unix_time")
+	}
+
+	return s.UserDataDB(scheduledReport.Uid).DoTransaction(c, func(sess *xorm.Session) error {
+		_, err := sess.ID(scheduledReport.ReportId).Cols(updateCols...).Where("uid=? AND deleted=?", scheduledReport.Uid, false).Update(updateModel)
+		return err
+	})
+}
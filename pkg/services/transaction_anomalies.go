@@ -0,0 +1,667 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"xorm.io/xorm"
+
+	"github.com/mayswind/ezbookkeeping/pkg/anomalies"
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/datastore"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/locales"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
+	"github.com/mayswind/ezbookkeeping/pkg/mail"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/settings"
+	"github.com/mayswind/ezbookkeeping/pkg/templates"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+	"github.com/mayswind/ezbookkeeping/pkg/uuid"
+)
+
+const transactionAnomalyBaselineMonths = 12
+const pageCountForDetectTransactionAnomalies = 500
+const maxTransactionAnomalyAlertMailItems = 50
+
+// TransactionAnomalyService represents transaction anomaly detection service
+type TransactionAnomalyService struct {
+	ServiceUsingDB
+	ServiceUsingConfig
+	ServiceUsingMailer
+	ServiceUsingUuid
+}
+
+// Initialize a transaction anomaly service singleton instance
+var (
+	TransactionAnomalies = &TransactionAnomalyService{
+		ServiceUsingDB: ServiceUsingDB{
+			container: datastore.Container,
+		},
+		ServiceUsingConfig: ServiceUsingConfig{
+			container: settings.Container,
+		},
+		ServiceUsingMailer: ServiceUsingMailer{
+			container: mail.Container,
+		},
+		ServiceUsingUuid: ServiceUsingUuid{
+			container: uuid.Container,
+		},
+	}
+)
+
+// GetAnomaliesByTransactionIds returns the anomaly models of the specified transactions, grouped by transaction id
+func (s *TransactionAnomalyService) GetAnomaliesByTransactionIds(c core.Context, uid int64, transactionIds []int64) (map[int64][]*models.TransactionAnomaly, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	result := make(map[int64][]*models.TransactionAnomaly)
+
+	if len(transactionIds) < 1 {
+		return result, nil
+	}
+
+	var transactionAnomalies []*models.TransactionAnomaly
+	err := s.UserDataDB(uid).NewSession(c).Where("uid=? AND deleted=?", uid, false).In("transaction_id", transactionIds).OrderBy("anomaly_type asc, anomaly_id asc").Find(&transactionAnomalies)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for i := 0; i < len(transactionAnomalies); i++ {
+		anomaly := transactionAnomalies[i]
+		result[anomaly.TransactionId] = append(result[anomaly.TransactionId], anomaly)
+	}
+
+	return result, nil
+}
+
+// DetectAndSaveTransactionAnomalies compares the specified saved transactions with the historical transactions of user, and saves the found anomalies to database
+func (s *TransactionAnomalyService) DetectAndSaveTransactionAnomalies(c core.Context, uid int64, transactions []*models.Transaction) error {
+	if uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	minTransactionTime := int64(0)
+	maxTransactionTime := int64(0)
+	newTransactionIds := make(map[int64]bool, len(transactions))
+
+	for i := 0; i < len(transactions); i++ {
+		transaction := transactions[i]
+
+		if transaction.Type != models.TRANSACTION_DB_TYPE_EXPENSE {
+			continue
+		}
+
+		if minTransactionTime == 0 || transaction.TransactionTime < minTransactionTime {
+			minTransactionTime = transaction.TransactionTime
+		}
+
+		if transaction.TransactionTime > maxTransactionTime {
+			maxTransactionTime = transaction.TransactionTime
+		}
+
+		newTransactionIds[transaction.TransactionId] = true
+	}
+
+	if len(newTransactionIds) < 1 {
+		return nil
+	}
+
+	duplicateTimeWindow := int64(s.CurrentConfig().AnomalyDuplicateTimeWindowDuration.Seconds())
+	minBaselineUnixTime := time.Unix(utils.GetUnixTimeFromTransactionTime(minTransactionTime), 0).AddDate(0, -transactionAnomalyBaselineMonths, 0).Unix()
+	maxBaselineUnixTime := utils.GetUnixTimeFromTransactionTime(maxTransactionTime) + duplicateTimeWindow
+
+	historicalTransactions, err := s.getBaselineExpenseTransactions(c, uid, utils.GetMinTransactionTimeFromUnixTime(minBaselineUnixTime), utils.GetMaxTransactionTimeFromUnixTime(maxBaselineUnixTime), newTransactionIds)
+
+	if err != nil {
+		return err
+	}
+
+	var accounts []*models.Account
+	err = s.UserDataDB(uid).NewSession(c).Cols("account_id", "currency").Where("uid=? AND deleted=?", uid, false).Find(&accounts)
+
+	if err != nil {
+		return err
+	}
+
+	accountCurrencies := make(map[int64]string, len(accounts))
+
+	for i := 0; i < len(accounts); i++ {
+		accountCurrencies[accounts[i].AccountId] = accounts[i].Currency
+	}
+
+	detector := anomalies.NewTransactionAnomalyDetector(historicalTransactions, accountCurrencies, anomalies.DefaultTransactionAnomalyDetectorOptions(duplicateTimeWindow))
+	err = s.addEarlierKnownPayees(c, uid, detector, transactions, newTransactionIds)
+
+	if err != nil {
+		return err
+	}
+
+	detectedAnomalies := detector.DetectTransactionAnomalies(transactions)
+
+	if len(detectedAnomalies) < 1 {
+		return nil
+	}
+
+	anomalyIds := make([]int64, 0, len(detectedAnomalies))
+
+	for len(anomalyIds) < len(detectedAnomalies) {
+		needAnomalyUuidCount := len(detectedAnomalies) - len(anomalyIds)
+
+		if needAnomalyUuidCount > 65535 {
+			needAnomalyUuidCount = 65535
+		}
+
+		uuids := s.GenerateUuids(uuid.UUID_TYPE_ANOMALY, uint16(needAnomalyUuidCount))
+
+		if len(uuids) < needAnomalyUuidCount {
+			return errs.ErrSystemIsBusy
+		}
+
+		anomalyIds = append(anomalyIds, uuids...)
+	}
+
+	now := time.Now().Unix()
+	transactionAnomalies := make([]*models.TransactionAnomaly, len(detectedAnomalies))
+
+	for i := 0; i < len(detectedAnomalies); i++ {
+		transactionAnomalies[i] = &models.TransactionAnomaly{
+			AnomalyId:            anomalyIds[i],
+			Uid:                  uid,
+			Deleted:              false,
+			TransactionId:        detectedAnomalies[i].TransactionId,
+			AnomalyType:          detectedAnomalies[i].Type,
+			RelatedTransactionId: detectedAnomalies[i].RelatedTransactionId,
+			ReferenceAmount:      detectedAnomalies[i].ReferenceAmount,
+			Notified:             false,
+			CreatedUnixTime:      now,
+			UpdatedUnixTime:      now,
+		}
+	}
+
+	log.Infof(c, "[transaction_anomalies.DetectAndSaveTransactionAnomalies] found %d anomalies in %d transactions for user \"uid:%d\"", len(transactionAnomalies), len(transactions), uid)
+
+	return s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
+		for i := 0; i < len(transactionAnomalies); i++ {
+			_, err := sess.Insert(transactionAnomalies[i])
+
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// DeleteAnomaliesByTransactionId deletes all the anomalies of the specified transaction
+func (s *TransactionAnomalyService) DeleteAnomaliesByTransactionId(c core.Context, uid int64, transactionId int64) error {
+	if uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	now := time.Now().Unix()
+
+	updateModel := &models.TransactionAnomaly{
+		Deleted:         true,
+		DeletedUnixTime: now,
+	}
+
+	return s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
+		_, err := sess.Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=? AND transaction_id=?", uid, false, transactionId).Update(updateModel)
+		return err
+	})
+}
+
+// EnqueueNewTransactionAnomalyDetection adds the specified new expense transactions to the anomaly detection queue if anomaly detection is enabled, the error is only logged
+func (s *TransactionAnomalyService) EnqueueNewTransactionAnomalyDetection(c core.Context, uid int64, transactions []*models.Transaction) {
+	if !s.CurrentConfig().EnableAnomalyDetection || len(transactions) < 1 {
+		return
+	}
+
+	now := time.Now().Unix()
+	tasks := make([]*models.TransactionAnomalyDetectionTask, 0, len(transactions))
+
+	for i := 0; i < len(transactions); i++ {
+		if transactions[i].Type != models.TRANSACTION_DB_TYPE_EXPENSE {
+			continue
+		}
+
+		tasks = append(tasks, &models.TransactionAnomalyDetectionTask{
+			Uid:             uid,
+			TransactionId:   transactions[i].TransactionId,
+			CreatedUnixTime: now,
+		})
+	}
+
+	if len(tasks) < 1 {
+		return
+	}
+
+	err := s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
+		for i := 0; i < len(tasks); i++ {
+			// the modified transaction may be still in the queue
+			_, err := sess.Where("uid=? AND transaction_id=?", uid, tasks[i].TransactionId).Delete(&models.TransactionAnomalyDetectionTask{})
+
+			if err != nil {
+				return err
+			}
+
+			_, err = sess.Insert(tasks[i])
+
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		log.Errorf(c, "[transaction_anomalies.EnqueueNewTransactionAnomalyDetection] failed to add %d transactions to anomaly detection queue for user \"uid:%d\", because %s", len(tasks), uid, err.Error())
+	}
+}
+
+// EnqueueModifiedTransactionAnomalyRedetection removes the existed anomalies of the specified modified transaction and adds it to the anomaly detection queue if anomaly detection is enabled, the error is only logged
+func (s *TransactionAnomalyService) EnqueueModifiedTransactionAnomalyRedetection(c core.Context, uid int64, transaction *models.Transaction) {
+	if !s.CurrentConfig().EnableAnomalyDetection || transaction == nil {
+		return
+	}
+
+	err := s.DeleteAnomaliesByTransactionId(c, uid, transaction.TransactionId)
+
+	if err != nil {
+		log.Errorf(c, "[transaction_anomalies.EnqueueModifiedTransactionAnomalyRedetection] failed to delete anomalies of transaction \"id:%d\" for user \"uid:%d\", because %s", transaction.TransactionId, uid, err.Error())
+		return
+	}
+
+	s.EnqueueNewTransactionAnomalyDetection(c, uid, []*models.Transaction{transaction})
+}
+
+// DetectPendingTransactionAnomalies finds and saves the anomalies of the transactions in the anomaly detection queue which are added before the specified time,
+// the transactions of the same user are detected together, and the failed detections are only logged and not retried
+func (s *TransactionAnomalyService) DetectPendingTransactionAnomalies(c core.Context, currentUnixTime int64) error {
+	if !s.CurrentConfig().EnableAnomalyDetection {
+		return nil
+	}
+
+	successCount := 0
+	failedCount := 0
+
+	for i := 0; i < s.UserDataDBCount(); i++ {
+		database := s.UserDataDBByIndex(i)
+
+		var tasks []*models.TransactionAnomalyDetectionTask
+		err := database.NewSession(c).Where("created_unix_time<=?", currentUnixTime).OrderBy("created_unix_time asc, uid asc, transaction_id asc").Limit(pageCountForDetectTransactionAnomalies).Find(&tasks)
+
+		if err != nil {
+			return err
+		}
+
+		allUserTransactionIds := make(map[int64][]int64)
+		allUids := make([]int64, 0)
+
+		for j := 0; j < len(tasks); j++ {
+			task := tasks[j]
+
+			if _, exists := allUserTransactionIds[task.Uid]; !exists {
+				allUids = append(allUids, task.Uid)
+			}
+
+			allUserTransactionIds[task.Uid] = append(allUserTransactionIds[task.Uid], task.TransactionId)
+		}
+
+		for j := 0; j < len(allUids); j++ {
+			uid := allUids[j]
+			transactionIds := allUserTransactionIds[uid]
+
+			var transactions []*models.Transaction
+			err = database.NewSession(c).Where("uid=? AND deleted=?", uid, false).In("transaction_id", transactionIds).Find(&transactions)
+
+			if err == nil && len(transactions) > 0 {
+				err = s.DetectAndSaveTransactionAnomalies(c, uid, transactions)
+			}
+
+			if err != nil {
+				failedCount++
+				log.Errorf(c, "[transaction_anomalies.DetectPendingTransactionAnomalies] failed to detect anomalies of %d transactions for user \"uid:%d\", because %s", len(transactionIds), uid, err.Error())
+			} else {
+				successCount++
+			}
+
+			_, err = database.NewSession(c).Where("uid=? AND created_unix_time<=?", uid, currentUnixTime).In("transaction_id", transactionIds).Delete(&models.TransactionAnomalyDetectionTask{})
+
+			if err != nil {
+				log.Errorf(c, "[transaction_anomalies.DetectPendingTransactionAnomalies] failed to remove anomaly detection tasks for user \"uid:%d\", because %s", uid, err.Error())
+			}
+		}
+	}
+
+	if successCount > 0 || failedCount > 0 {
+		log.Infof(c, "[transaction_anomalies.DetectPendingTransactionAnomalies] transaction anomalies of %d users have been detected, %d users failed", successCount, failedCount)
+	}
+
+	return nil
+}
+
+// SendTransactionAnomalyAlerts sends the alert mails of all anomalies which have not been notified, one digest mail for each user
+func (s *TransactionAnomalyService) SendTransactionAnomalyAlerts(c core.Context) error {
+	if !s.CurrentConfig().EnableSMTP {
+		return errs.ErrSMTPServerNotEnabled
+	}
+
+	successCount := 0
+	failedCount := 0
+
+	for i := 0; i < s.UserDataDBCount(); i++ {
+		var transactionAnomalies []*models.TransactionAnomaly
+		err := s.UserDataDBByIndex(i).NewSession(c).Where("deleted=? AND notified=?", false, false).OrderBy("uid asc, anomaly_id asc").Find(&transactionAnomalies)
+
+		if err != nil {
+			return err
+		}
+
+		allUserAnomalies := make(map[int64][]*models.TransactionAnomaly)
+		allUids := make([]int64, 0)
+
+		for j := 0; j < len(transactionAnomalies); j++ {
+			anomaly := transactionAnomalies[j]
+
+			if _, exists := allUserAnomalies[anomaly.Uid]; !exists {
+				allUids = append(allUids, anomaly.Uid)
+			}
+
+			allUserAnomalies[anomaly.Uid] = append(allUserAnomalies[anomaly.Uid], anomaly)
+		}
+
+		for j := 0; j < len(allUids); j++ {
+			uid := allUids[j]
+			userAnomalies := allUserAnomalies[uid]
+			sent, err := s.sendUserTransactionAnomalyAlert(c, uid, userAnomalies)
+
+			if err != nil {
+				failedCount++
+				log.Errorf(c, "[transaction_anomalies.SendTransactionAnomalyAlerts] failed to send transaction anomaly alert to user \"uid:%d\", because %s", uid, err.Error())
+				continue
+			}
+
+			if sent {
+				successCount++
+			}
+
+			err = s.setAnomaliesNotified(c, uid, userAnomalies)
+
+			if err != nil {
+				log.Errorf(c, "[transaction_anomalies.SendTransactionAnomalyAlerts] failed to update notified state of anomalies for user \"uid:%d\", because %s", uid, err.Error())
+			}
+		}
+	}
+
+	if successCount > 0 || failedCount > 0 {
+		log.Infof(c, "[transaction_anomalies.SendTransactionAnomalyAlerts] %d transaction anomaly alert mails have been sent, %d users failed", successCount, failedCount)
+	}
+
+	return nil
+}
+
+func (s *TransactionAnomalyService) getBaselineExpenseTransactions(c core.Context, uid int64, minTransactionTime int64, maxTransactionTime int64, excludedTransactionIds map[int64]bool) ([]*models.Transaction, error) {
+	var allTransactions []*models.Transaction
+
+	for maxTransactionTime >= minTransactionTime {
+		var transactions []*models.Transaction
+		err := s.UserDataDB(uid).NewSession(c).Cols("transaction_id", "type", "category_id", "account_id", "transaction_time", "amount", "comment").Where("uid=? AND deleted=? AND type=? AND transaction_time>=? AND transaction_time<=?", uid, false, models.TRANSACTION_DB_TYPE_EXPENSE, minTransactionTime, maxTransactionTime).Limit(pageCountForLoadTransactionAmounts, 0).OrderBy("transaction_time desc").Find(&transactions)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for i := 0; i < len(transactions); i++ {
+			if !excludedTransactionIds[transactions[i].TransactionId] {
+				allTransactions = append(allTransactions, transactions[i])
+			}
+		}
+
+		if len(transactions) < pageCountForLoadTransactionAmounts {
+			break
+		}
+
+		maxTransactionTime = transactions[len(transactions)-1].TransactionTime - 1
+	}
+
+	return allTransactions, nil
+}
+
+// addEarlierKnownPayees marks the payees of the specified new transactions as known if they are not used in the baseline but have ever been used by the earlier transactions
+func (s *TransactionAnomalyService) addEarlierKnownPayees(c core.Context, uid int64, detector *anomalies.TransactionAnomalyDetector, transactions []*models.Transaction, excludedTransactionIds map[int64]bool) error {
+	checkedPayees := make(map[string]bool)
+
+	for i := 0; i < len(transactions); i++ {
+		payeeName := utils.GetNormalizedPayeeName(transactions[i].Comment)
+
+		if transactions[i].Type != models.TRANSACTION_DB_TYPE_EXPENSE || payeeName == "" || checkedPayees[payeeName] || detector.IsKnownPayee(payeeName) {
+			continue
+		}
+
+		checkedPayees[payeeName] = true
+		everUsed, err := s.isPayeeEverUsed(c, uid, payeeName, excludedTransactionIds)
+
+		if err != nil {
+			return err
+		}
+
+		if everUsed {
+			detector.AddKnownPayee(payeeName)
+		}
+	}
+
+	return nil
+}
+
+// isPayeeEverUsed returns whether the normalized payee name has ever been used by the expense transactions of user except the excluded ones,
+// the transactions are pre-filtered by the lowercase comment which contains all the words of payee name in order
+func (s *TransactionAnomalyService) isPayeeEverUsed(c core.Context, uid int64, payeeName string, excludedTransactionIds map[int64]bool) (bool, error) {
+	commentPattern := "%" + strings.ReplaceAll(payeeName, " ", "%") + "%"
+	minTransactionId := int64(0)
+
+	for {
+		var transactions []*models.Transaction
+		err := s.UserDataDB(uid).NewSession(c).Cols("transaction_id", "comment").Where("uid=? AND deleted=? AND type=? AND transaction_id>? AND LOWER(comment) LIKE ?", uid, false, models.TRANSACTION_DB_TYPE_EXPENSE, minTransactionId, commentPattern).Limit(pageCountForLoadTransactionAmounts, 0).OrderBy("transaction_id asc").Find(&transactions)
+
+		if err != nil {
+			return false, err
+		}
+
+		for i := 0; i < len(transactions); i++ {
+			if !excludedTransactionIds[transactions[i].TransactionId] && utils.GetNormalizedPayeeName(transactions[i].Comment) == payeeName {
+				return true, nil
+			}
+		}
+
+		if len(transactions) < pageCountForLoadTransactionAmounts {
+			return false, nil
+		}
+
+		minTransactionId = transactions[len(transactions)-1].TransactionId
+	}
+}
+
+func (s *TransactionAnomalyService) sendUserTransactionAnomalyAlert(c core.Context, uid int64, transactionAnomalies []*models.TransactionAnomaly) (bool, error) {
+	user := &models.User{}
+	has, err := s.UserDB().NewSession(c).ID(uid).Where("deleted=?", false).Get(user)
+
+	if err != nil {
+		return false, err
+	} else if !has {
+		return false, nil
+	}
+
+	if user.Disabled || user.Email == "" {
+		return false, nil
+	}
+
+	if s.CurrentConfig().EnableUserVerifyEmail && !user.EmailVerified {
+		log.Infof(c, "[transaction_anomalies.sendUserTransactionAnomalyAlert] skip sending transaction anomaly alert to user \"uid:%d\", because email is not verified", uid)
+		return false, nil
+	}
+
+	transactionIds := make([]int64, 0, len(transactionAnomalies))
+
+	for i := 0; i < len(transactionAnomalies); i++ {
+		transactionIds = append(transactionIds, transactionAnomalies[i].TransactionId)
+	}
+
+	var transactions []*models.Transaction
+	err = s.UserDataDB(uid).NewSession(c).Where("uid=? AND deleted=?", uid, false).In("transaction_id", utils.ToUniqueInt64Slice(transactionIds)).Find(&transactions)
+
+	if err != nil {
+		return false, err
+	}
+
+	if len(transactions) < 1 {
+		return false, nil
+	}
+
+	var accounts []*models.Account
+	err = s.UserDataDB(uid).NewSession(c).Cols("account_id", "currency", "extend").Where("uid=? AND deleted=?", uid, false).Find(&accounts)
+
+	if err != nil {
+		return false, err
+	}
+
+	accountMap := make(map[int64]*models.Account, len(accounts))
+
+	for i := 0; i < len(accounts); i++ {
+		accountMap[accounts[i].AccountId] = accounts[i]
+	}
+
+	transactionMap := make(map[int64]*models.Transaction, len(transactions))
+
+	for i := 0; i < len(transactions); i++ {
+		transactionMap[transactions[i].TransactionId] = transactions[i]
+	}
+
+	sort.SliceStable(transactionAnomalies, func(i, j int) bool {
+		transaction1, transaction2 := transactionMap[transactionAnomalies[i].TransactionId], transactionMap[transactionAnomalies[j].TransactionId]
+
+		if transaction1 == nil || transaction2 == nil {
+			return transaction1 != nil
+		}
+
+		return transaction1.TransactionTime > transaction2.TransactionTime
+	})
+
+	textItems := locales.GetLocaleTextItems(user.Language).TransactionAnomalyAlertMailTextItems
+	items := make([]map[string]any, 0)
+
+	for i := 0; i < len(transactionAnomalies) && len(items) < maxTransactionAnomalyAlertMailItems; i++ {
+		anomaly := transactionAnomalies[i]
+		transaction, exists := transactionMap[anomaly.TransactionId]
+
+		if !exists {
+			continue
+		}
+
+		account, exists := accountMap[transaction.AccountId]
+
+		if !exists {
+			account = &models.Account{}
+		}
+
+		items = append(items, map[string]any{
+			"Date":    utils.FormatUnixTimeToLongDate(utils.GetUnixTimeFromTransactionTime(transaction.TransactionTime), time.FixedZone("Transaction Timezone", int(transaction.TimezoneUtcOffset)*60)),
+			"Comment": transaction.Comment,
+			"Amount":  s.getDisplayAmount(transaction.Amount, account, transaction.HideAmount),
+			"Reason":  s.getAnomalyReason(textItems, anomaly, account, transaction.HideAmount),
+		})
+	}
+
+	if len(items) < 1 {
+		return false, nil
+	}
+
+	tmpl, err := templates.GetTemplate(templates.TEMPLATE_TRANSACTION_ANOMALY_ALERT)
+
+	if err != nil {
+		return false, err
+	}
+
+	templateParams := map[string]any{
+		"AppName": s.CurrentConfig().AppName,
+		"TransactionAnomalyAlertMail": map[string]any{
+			"Title":            textItems.Title,
+			"Salutation":       fmt.Sprintf(textItems.SalutationFormat, user.Nickname),
+			"Description":      textItems.Description,
+			"DateLabel":        textItems.Date,
+			"CommentLabel":     textItems.Comment,
+			"AmountLabel":      textItems.Amount,
+			"ReasonLabel":      textItems.Reason,
+			"Items":            items,
+			"DescriptionBelow": fmt.Sprintf(textItems.DescriptionBelowFormat, s.CurrentConfig().AppName),
+		},
+	}
+
+	var bodyBuffer bytes.Buffer
+	err = tmpl.Execute(&bodyBuffer, templateParams)
+
+	if err != nil {
+		return false, err
+	}
+
+	message := &mail.MailMessage{
+		To:      user.Email,
+		Subject: textItems.Title,
+		Body:    bodyBuffer.String(),
+	}
+
+	err = s.SendMail(message)
+
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (s *TransactionAnomalyService) getAnomalyReason(textItems *locales.TransactionAnomalyAlertMailTextItems, anomaly *models.TransactionAnomaly, account *models.Account, hideAmount bool) string {
+	switch anomaly.AnomalyType {
+	case models.TRANSACTION_ANOMALY_TYPE_CATEGORY_OUTLIER:
+		return fmt.Sprintf(textItems.CategoryOutlierFormat, s.getDisplayAmount(anomaly.ReferenceAmount, account, hideAmount))
+	case models.TRANSACTION_ANOMALY_TYPE_NEW_PAYEE_LARGE_AMOUNT:
+		return fmt.Sprintf(textItems.NewPayeeLargeAmountFormat, s.getDisplayAmount(anomaly.ReferenceAmount, account, hideAmount))
+	case models.TRANSACTION_ANOMALY_TYPE_POSSIBLE_DUPLICATE:
+		return textItems.PossibleDuplicate
+	default:
+		return ""
+	}
+}
+
+func (s *TransactionAnomalyService) getDisplayAmount(amount int64, account *models.Account, hideAmount bool) string {
+	if hideAmount {
+		return "***"
+	}
+
+	return fmt.Sprintf("%s %s", account.Currency, utils.FormatAmountWithPrecision(amount, account.GetCurrencyPrecision()))
+}
+
+func (s *TransactionAnomalyService) setAnomaliesNotified(c core.Context, uid int64, transactionAnomalies []*models.TransactionAnomaly) error {
+	anomalyIds := make([]int64, len(transactionAnomalies))
+
+	for i := 0; i < len(transactionAnomalies); i++ {
+		anomalyIds[i] = transactionAnomalies[i].AnomalyId
+	}
+
+	updateModel := &models.TransactionAnomaly{
+		Notified:        true,
+		UpdatedUnixTime: time.Now().Unix(),
+	}
+
+	return s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
+		_, err := sess.Cols("notified", "updated_unix_time").Where("uid=? AND deleted=? AND notified=?", uid, false, false).In("anomaly_id", anomalyIds).Update(updateModel)
+		return err
+	})
+}
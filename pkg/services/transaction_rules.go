@@ -0,0 +1,232 @@
+package services
+
+import (
+	"time"
+
+	"xorm.io/xorm"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/datastore"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/uuid"
+)
+
+// TransactionRuleService represents transaction rule service
+type TransactionRuleService struct {
+	ServiceUsingDB
+	ServiceUsingUuid
+}
+
+// Initialize a transaction rule service singleton instance
+var (
+	TransactionRules = &TransactionRuleService{
+		ServiceUsingDB: ServiceUsingDB{
+			container: datastore.Container,
+		},
+		ServiceUsingUuid: ServiceUsingUuid{
+			container: uuid.Container,
+		},
+	}
+)
+
+// GetAllRulesByUid returns all transaction rule models of user
+func (s *TransactionRuleService) GetAllRulesByUid(c core.Context, uid int64) ([]*models.TransactionRule, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	var rules []*models.TransactionRule
+	err := s.UserDataDB(uid).NewSession(c).Where("uid=? AND deleted=?", uid, false).OrderBy("display_order asc, rule_id asc").Find(&rules)
+
+	return rules, err
+}
+
+// GetAllEnabledRulesByUid returns all enabled transaction rule models of user in display order
+func (s *TransactionRuleService) GetAllEnabledRulesByUid(c core.Context, uid int64) (models.TransactionRuleSlice, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	var rules []*models.TransactionRule
+	err := s.UserDataDB(uid).NewSession(c).Where("uid=? AND deleted=? AND disabled=?", uid, false, false).OrderBy("display_order asc, rule_id asc").Find(&rules)
+
+	return rules, err
+}
+
+// GetRuleByRuleId returns a transaction rule model according to transaction rule id
+func (s *TransactionRuleService) GetRuleByRuleId(c core.Context, uid int64, ruleId int64) (*models.TransactionRule, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	if ruleId <= 0 {
+		return nil, errs.ErrTransactionRuleIdInvalid
+	}
+
+	rule := &models.TransactionRule{}
+	has, err := s.UserDataDB(uid).NewSession(c).ID(ruleId).Where("uid=? AND deleted=?", uid, false).Get(rule)
+
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, errs.ErrTransactionRuleNotFound
+	}
+
+	return rule, nil
+}
+
+// GetRulesByRuleIds returns transaction rule models according to transaction rule ids
+func (s *TransactionRuleService) GetRulesByRuleIds(c core.Context, uid int64, ruleIds []int64) (models.TransactionRuleSlice, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	if len(ruleIds) < 1 {
+		return nil, errs.ErrTransactionRuleIdInvalid
+	}
+
+	var rules []*models.TransactionRule
+	err := s.UserDataDB(uid).NewSession(c).Where("uid=? AND deleted=?", uid, false).In("rule_id", ruleIds).OrderBy("display_order asc, rule_id asc").Find(&rules)
+
+	return rules, err
+}
+
+// GetMaxDisplayOrder returns the max display order
+func (s *TransactionRuleService) GetMaxDisplayOrder(c core.Context, uid int64) (int32, error) {
+	if uid <= 0 {
+		return 0, errs.ErrUserIdInvalid
+	}
+
+	rule := &models.TransactionRule{}
+	has, err := s.UserDataDB(uid).NewSession(c).Cols("uid", "deleted", "display_order").Where("uid=? AND deleted=?", uid, false).OrderBy("display_order desc").Limit(1).Get(rule)
+
+	if err != nil {
+		return 0, err
+	}
+
+	if has {
+		return rule.DisplayOrder, nil
+	} else {
+		return 0, nil
+	}
+}
+
+// CreateRule saves a new transaction rule model to database
+func (s *TransactionRuleService) CreateRule(c core.Context, rule *models.TransactionRule) error {
+	if rule.Uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	rule.RuleId = s.GenerateUuid(uuid.UUID_TYPE_RULE)
+
+	if rule.RuleId < 1 {
+		return errs.ErrSystemIsBusy
+	}
+
+	rule.Deleted = false
+	rule.CreatedUnixTime = time.Now().Unix()
+	rule.UpdatedUnixTime = time.Now().Unix()
+
+	return s.UserDataDB(rule.Uid).DoTransaction(c, func(sess *xorm.Session) error {
+		_, err := sess.Insert(rule)
+		return err
+	})
+}
+
+// ModifyRule saves an existed transaction rule model to database
+func (s *TransactionRuleService) ModifyRule(c core.Context, rule *models.TransactionRule) error {
+	if rule.Uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	rule.UpdatedUnixTime = time.Now().Unix()
+
+	return s.UserDataDB(rule.Uid).DoTransaction(c, func(sess *xorm.Session) error {
+		updatedRows, err := sess.ID(rule.RuleId).Cols("name", "transaction_type", "match_all_conditions", "conditions", "category_id", "add_tag_ids", "replace_comment", "comment", "destination_account_id", "stop_processing", "disabled", "updated_unix_time").Where("uid=? AND deleted=?", rule.Uid, false).Update(rule)
+
+		if err != nil {
+			return err
+		} else if updatedRows < 1 {
+			return errs.ErrTransactionRuleNotFound
+		}
+
+		return err
+	})
+}
+
+// ModifyRuleDisplayOrders updates display order of given transaction rules
+func (s *TransactionRuleService) ModifyRuleDisplayOrders(c core.Context, uid int64, rules []*models.TransactionRule) error {
+	if uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	for i := 0; i < len(rules); i++ {
+		rules[i].UpdatedUnixTime = time.Now().Unix()
+	}
+
+	return s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
+		for i := 0; i < len(rules); i++ {
+			rule := rules[i]
+			updatedRows, err := sess.ID(rule.RuleId).Cols("display_order", "updated_unix_time").Where("uid=? AND deleted=?", uid, false).Update(rule)
+
+			if err != nil {
+				return err
+			} else if updatedRows < 1 {
+				return errs.ErrTransactionRuleNotFound
+			}
+		}
+
+		return nil
+	})
+}
+
+// DeleteRule deletes an existed transaction rule from database
+func (s *TransactionRuleService) DeleteRule(c core.Context, uid int64, ruleId int64) error {
+	if uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	now := time.Now().Unix()
+
+	updateModel := &models.TransactionRule{
+		Deleted:         true,
+		DeletedUnixTime: now,
+	}
+
+	return s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
+		deletedRows, err := sess.ID(ruleId).Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=?", uid, false).Update(updateModel)
+
+		if err != nil {
+			return err
+		} else if deletedRows < 1 {
+			return errs.ErrTransactionRuleNotFound
+		}
+
+		return err
+	})
+}
+
+// DeleteAllRules deletes all existed transaction rules from database
+func (s *TransactionRuleService) DeleteAllRules(c core.Context, uid int64) error {
+	if uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	now := time.Now().Unix()
+
+	updateModel := &models.TransactionRule{
+		Deleted:         true,
+		DeletedUnixTime: now,
+	}
+
+	return s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
+		_, err := sess.Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=?", uid, false).Update(updateModel)
+
+		if err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
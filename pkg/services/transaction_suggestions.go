@@ -0,0 +1,78 @@
+package services
+
+import (
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/datastore"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/suggestions"
+)
+
+const maximumTrainingTransactionCountForSuggestion = 5000
+
+// TransactionSuggestionService represents transaction category and tag suggestion service
+type TransactionSuggestionService struct {
+	ServiceUsingDB
+}
+
+// Initialize a transaction suggestion service singleton instance
+var (
+	TransactionSuggestions = &TransactionSuggestionService{
+		ServiceUsingDB: ServiceUsingDB{
+			container: datastore.Container,
+		},
+	}
+)
+
+// GetTransactionSuggestionModels returns the suggestion models of each transaction type which are trained by the recent transactions of user
+func (s *TransactionSuggestionService) GetTransactionSuggestionModels(c core.Context, uid int64) (map[models.TransactionDbType]*suggestions.TransactionSuggestionModel, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	var transactions []*models.Transaction
+	err := s.UserDataDB(uid).NewSession(c).Cols("transaction_id", "type", "category_id", "account_id", "amount", "comment", "transaction_time").Where("uid=? AND deleted=? AND category_id>?", uid, false, 0).In("type", models.TRANSACTION_DB_TYPE_INCOME, models.TRANSACTION_DB_TYPE_EXPENSE, models.TRANSACTION_DB_TYPE_TRANSFER_OUT).OrderBy("transaction_time desc").Limit(maximumTrainingTransactionCountForSuggestion).Find(&transactions)
+
+	if err != nil {
+		return nil, err
+	}
+
+	suggestionModels := map[models.TransactionDbType]*suggestions.TransactionSuggestionModel{
+		models.TRANSACTION_DB_TYPE_INCOME:       suggestions.NewTransactionSuggestionModel(),
+		models.TRANSACTION_DB_TYPE_EXPENSE:      suggestions.NewTransactionSuggestionModel(),
+		models.TRANSACTION_DB_TYPE_TRANSFER_OUT: suggestions.NewTransactionSuggestionModel(),
+	}
+
+	if len(transactions) < 1 {
+		return suggestionModels, nil
+	}
+
+	minTransactionTime := transactions[len(transactions)-1].TransactionTime
+
+	var tagIndexes []*models.TransactionTagIndex
+	err = s.UserDataDB(uid).NewSession(c).Cols("transaction_id", "tag_id").Where("uid=? AND deleted=? AND transaction_time>=?", uid, false, minTransactionTime).Find(&tagIndexes)
+
+	if err != nil {
+		return nil, err
+	}
+
+	allTransactionTagIds := make(map[int64][]int64)
+
+	for i := 0; i < len(tagIndexes); i++ {
+		tagIndex := tagIndexes[i]
+		allTransactionTagIds[tagIndex.TransactionId] = append(allTransactionTagIds[tagIndex.TransactionId], tagIndex.TagId)
+	}
+
+	for i := len(transactions) - 1; i >= 0; i-- {
+		transaction := transactions[i]
+		suggestionModel, exists := suggestionModels[transaction.Type]
+
+		if !exists {
+			continue
+		}
+
+		suggestionModel.Train(transaction.Comment, transaction.Amount, transaction.AccountId, transaction.CategoryId, allTransactionTagIds[transaction.TransactionId])
+	}
+
+	return suggestionModels, nil
+}
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"sort"
 	"time"
 	"xorm.io/xorm"
 
@@ -85,6 +86,38 @@ func (s *TransactionTemplateService) GetTemplateByTemplateId(c core.Context, uid
 	return template, nil
 }
 
+// GetScheduledTransactionOccurrences returns all future transactions which will be created by the scheduled transaction templates of user between the start time and the end time (both inclusive)
+func (s *TransactionTemplateService) GetScheduledTransactionOccurrences(c core.Context, uid int64, startTime int64, endTime int64) (models.ScheduledTransactionOccurrenceSlice, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	var templates []*models.TransactionTemplate
+	err := s.UserDataDB(uid).NewSession(c).Where("uid=? AND deleted=? AND template_type=? AND scheduled_frequency_type>? AND (scheduled_end_time IS NULL OR scheduled_end_time>=?)", uid, false, models.TRANSACTION_TEMPLATE_TYPE_SCHEDULE, models.TRANSACTION_SCHEDULE_FREQUENCY_TYPE_DISABLED, startTime).Find(&templates)
+
+	if err != nil {
+		return nil, err
+	}
+
+	occurrences := make(models.ScheduledTransactionOccurrenceSlice, 0)
+
+	for i := 0; i < len(templates); i++ {
+		template := templates[i]
+		scheduledTimes := template.GetScheduledTransactionTimes(startTime, endTime)
+
+		for j := 0; j < len(scheduledTimes); j++ {
+			occurrences = append(occurrences, &models.ScheduledTransactionOccurrence{
+				Template:        template,
+				TransactionTime: scheduledTimes[j],
+			})
+		}
+	}
+
+	sort.Sort(occurrences)
+
+	return occurrences, nil
+}
+
 // GetMaxDisplayOrder returns the max display order
 func (s *TransactionTemplateService) GetMaxDisplayOrder(c core.Context, uid int64, templateType models.TransactionTemplateType) (int32, error) {
 	if uid <= 0 {
@@ -105,6 +138,40 @@ func (s *TransactionTemplateService) GetMaxDisplayOrder(c core.Context, uid int6
 	}
 }
 
+// InitializeScheduledTemplatesNextRunTime sets the next run time of the scheduled transaction templates which have never been run since the run times are recorded,
+// so that the scheduled transactions missed after now can be created by catch-up run, returns the count of updated templates
+func (s *TransactionTemplateService) InitializeScheduledTemplatesNextRunTime(c core.Context, currentUnixTime int64) (int, error) {
+	updatedCount := 0
+
+	for i := 0; i < s.UserDataDBCount(); i++ {
+		var templates []*models.TransactionTemplate
+		err := s.UserDataDBByIndex(i).NewSession(c).Where("deleted=? AND template_type=? AND scheduled_frequency_type>? AND scheduled_last_run_time=? AND scheduled_next_run_time=?", false, models.TRANSACTION_TEMPLATE_TYPE_SCHEDULE, models.TRANSACTION_SCHEDULE_FREQUENCY_TYPE_DISABLED, 0, 0).Find(&templates)
+
+		if err != nil {
+			return updatedCount, err
+		}
+
+		for j := 0; j < len(templates); j++ {
+			template := templates[j]
+			template.ScheduledNextRunTime = template.GetNextScheduledTransactionTime(currentUnixTime)
+
+			if template.ScheduledNextRunTime <= 0 {
+				continue
+			}
+
+			updatedRows, err := s.UserDataDBByIndex(i).NewSession(c).ID(template.TemplateId).Cols("scheduled_next_run_time").Where("uid=? AND deleted=? AND scheduled_last_run_time=? AND scheduled_next_run_time=?", template.Uid, false, 0, 0).Update(template)
+
+			if err != nil {
+				return updatedCount, err
+			}
+
+			updatedCount += int(updatedRows)
+		}
+	}
+
+	return updatedCount, nil
+}
+
 // CreateTemplate saves a new transaction template model to database
 func (s *TransactionTemplateService) CreateTemplate(c core.Context, template *models.TransactionTemplate) error {
 	if template.Uid <= 0 {
@@ -136,7 +203,7 @@ func (s *TransactionTemplateService) ModifyTemplate(c core.Context, template *mo
 	template.UpdatedUnixTime = time.Now().Unix()
 
 	return s.UserDataDB(template.Uid).DoTransaction(c, func(sess *xorm.Session) error {
-		updatedRows, err := sess.ID(template.TemplateId).Cols("name", "type", "category_id", "account_id", "scheduled_frequency_type", "scheduled_frequency", "scheduled_start_time", "scheduled_end_time", "scheduled_at", "scheduled_timezone_utc_offset", "tag_ids", "amount", "related_account_id", "related_account_amount", "hide_amount", "comment", "updated_unix_time").Where("uid=? AND deleted=?", template.Uid, false).Update(template)
+		updatedRows, err := sess.ID(template.TemplateId).Cols("name", "type", "category_id", "account_id", "scheduled_frequency_type", "scheduled_frequency", "scheduled_start_time", "scheduled_end_time", "scheduled_at", "scheduled_timezone_utc_offset", "scheduled_interval", "scheduled_first_day_of_week", "scheduled_next_business_day", "scheduled_next_run_time", "reminder_days", "reminder_digest", "tag_ids", "amount", "related_account_id", "related_account_amount", "amount_expression", "hide_amount", "comment", "updated_unix_time").Where("uid=? AND deleted=?", template.Uid, false).Update(template)
 
 		if err != nil {
 			return err
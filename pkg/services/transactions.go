@@ -12,6 +12,7 @@ import (
 	"github.com/mayswind/ezbookkeeping/pkg/core"
 	"github.com/mayswind/ezbookkeeping/pkg/datastore"
 	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/expressions"
 	"github.com/mayswind/ezbookkeeping/pkg/log"
 	"github.com/mayswind/ezbookkeeping/pkg/models"
 	"github.com/mayswind/ezbookkeeping/pkg/utils"
@@ -24,6 +25,8 @@ const pageCountForLoadTransactionAmounts = 1000
 type TransactionService struct {
 	ServiceUsingDB
 	ServiceUsingUuid
+	ServiceUsingWebhook
+	ServiceUsingAnomalyDetector
 }
 
 // Initialize a transaction service singleton instance
@@ -35,6 +38,12 @@ var (
 		ServiceUsingUuid: ServiceUsingUuid{
 			container: uuid.Container,
 		},
+		ServiceUsingWebhook: ServiceUsingWebhook{
+			container: Webhooks,
+		},
+		ServiceUsingAnomalyDetector: ServiceUsingAnomalyDetector{
+			container: TransactionAnomalies,
+		},
 	}
 )
 
@@ -76,11 +85,11 @@ func (s *TransactionService) GetAllTransactions(c core.Context, uid int64, pageC
 
 // GetAllTransactionsByMaxTime returns all transactions before given time
 func (s *TransactionService) GetAllTransactionsByMaxTime(c core.Context, uid int64, maxTransactionTime int64, count int32, noDuplicated bool) ([]*models.Transaction, error) {
-	return s.GetTransactionsByMaxTime(c, uid, maxTransactionTime, 0, 0, nil, nil, nil, false, models.TRANSACTION_TAG_FILTER_HAS_ANY, "", "", 1, count, false, noDuplicated)
+	return s.GetTransactionsByMaxTime(c, uid, maxTransactionTime, 0, 0, nil, nil, nil, false, models.TRANSACTION_TAG_FILTER_HAS_ANY, "", "", nil, false, 1, count, false, noDuplicated)
 }
 
 // GetTransactionsByMaxTime returns transactions before given time
-func (s *TransactionService) GetTransactionsByMaxTime(c core.Context, uid int64, maxTransactionTime int64, minTransactionTime int64, transactionType models.TransactionDbType, categoryIds []int64, accountIds []int64, tagIds []int64, noTags bool, tagFilterType models.TransactionTagFilterType, amountFilter string, keyword string, page int32, count int32, needOneMoreItem bool, noDuplicated bool) ([]*models.Transaction, error) {
+func (s *TransactionService) GetTransactionsByMaxTime(c core.Context, uid int64, maxTransactionTime int64, minTransactionTime int64, transactionType models.TransactionDbType, categoryIds []int64, accountIds []int64, tagIds []int64, noTags bool, tagFilterType models.TransactionTagFilterType, amountFilter string, keyword string, geoFilter *models.TransactionGeoRadiusFilter, anomalyOnly bool, page int32, count int32, needOneMoreItem bool, noDuplicated bool) ([]*models.Transaction, error) {
 	if uid <= 0 {
 		return nil, errs.ErrUserIdInvalid
 	}
@@ -104,9 +113,10 @@ func (s *TransactionService) GetTransactionsByMaxTime(c core.Context, uid int64,
 		actualCount++
 	}
 
-	condition, conditionParams := s.buildTransactionQueryCondition(uid, maxTransactionTime, minTransactionTime, transactionType, categoryIds, accountIds, tagIds, amountFilter, keyword, noDuplicated)
+	condition, conditionParams := s.buildTransactionQueryCondition(uid, maxTransactionTime, minTransactionTime, transactionType, categoryIds, accountIds, tagIds, amountFilter, keyword, geoFilter, noDuplicated)
 	sess := s.UserDataDB(uid).NewSession(c).Where(condition, conditionParams...)
 	sess = s.appendFilterTagIdsConditionToQuery(sess, uid, maxTransactionTime, minTransactionTime, tagIds, noTags, tagFilterType)
+	sess = s.appendFilterAnomalyConditionToQuery(sess, uid, anomalyOnly)
 
 	err = sess.Limit(int(actualCount), int(count*(page-1))).OrderBy("transaction_time desc").Find(&transactions)
 
@@ -114,7 +124,7 @@ func (s *TransactionService) GetTransactionsByMaxTime(c core.Context, uid int64,
 }
 
 // GetTransactionsInMonthByPage returns all transactions in given year and month
-func (s *TransactionService) GetTransactionsInMonthByPage(c core.Context, uid int64, year int32, month int32, transactionType models.TransactionDbType, categoryIds []int64, accountIds []int64, tagIds []int64, noTags bool, tagFilterType models.TransactionTagFilterType, amountFilter string, keyword string) ([]*models.Transaction, error) {
+func (s *TransactionService) GetTransactionsInMonthByPage(c core.Context, uid int64, year int32, month int32, transactionType models.TransactionDbType, categoryIds []int64, accountIds []int64, tagIds []int64, noTags bool, tagFilterType models.TransactionTagFilterType, amountFilter string, keyword string, geoFilter *models.TransactionGeoRadiusFilter) ([]*models.Transaction, error) {
 	if uid <= 0 {
 		return nil, errs.ErrUserIdInvalid
 	}
@@ -127,7 +137,7 @@ func (s *TransactionService) GetTransactionsInMonthByPage(c core.Context, uid in
 
 	var transactions []*models.Transaction
 
-	condition, conditionParams := s.buildTransactionQueryCondition(uid, maxTransactionTime, minTransactionTime, transactionType, categoryIds, accountIds, tagIds, amountFilter, keyword, true)
+	condition, conditionParams := s.buildTransactionQueryCondition(uid, maxTransactionTime, minTransactionTime, transactionType, categoryIds, accountIds, tagIds, amountFilter, keyword, geoFilter, true)
 	sess := s.UserDataDB(uid).NewSession(c).Where(condition, conditionParams...)
 	sess = s.appendFilterTagIdsConditionToQuery(sess, uid, maxTransactionTime, minTransactionTime, tagIds, noTags, tagFilterType)
 
@@ -172,33 +182,41 @@ func (s *TransactionService) GetTransactionByTransactionId(c core.Context, uid i
 
 // GetAllTransactionCount returns total count of transactions
 func (s *TransactionService) GetAllTransactionCount(c core.Context, uid int64) (int64, error) {
-	return s.GetTransactionCount(c, uid, 0, 0, 0, nil, nil, nil, false, models.TRANSACTION_TAG_FILTER_HAS_ANY, "", "")
+	return s.GetTransactionCount(c, uid, 0, 0, 0, nil, nil, nil, false, models.TRANSACTION_TAG_FILTER_HAS_ANY, "", "", nil, false)
 }
 
 // GetTransactionCount returns count of transactions
-func (s *TransactionService) GetTransactionCount(c core.Context, uid int64, maxTransactionTime int64, minTransactionTime int64, transactionType models.TransactionDbType, categoryIds []int64, accountIds []int64, tagIds []int64, noTags bool, tagFilterType models.TransactionTagFilterType, amountFilter string, keyword string) (int64, error) {
+func (s *TransactionService) GetTransactionCount(c core.Context, uid int64, maxTransactionTime int64, minTransactionTime int64, transactionType models.TransactionDbType, categoryIds []int64, accountIds []int64, tagIds []int64, noTags bool, tagFilterType models.TransactionTagFilterType, amountFilter string, keyword string, geoFilter *models.TransactionGeoRadiusFilter, anomalyOnly bool) (int64, error) {
 	if uid <= 0 {
 		return 0, errs.ErrUserIdInvalid
 	}
 
-	condition, conditionParams := s.buildTransactionQueryCondition(uid, maxTransactionTime, minTransactionTime, transactionType, categoryIds, accountIds, tagIds, amountFilter, keyword, true)
+	condition, conditionParams := s.buildTransactionQueryCondition(uid, maxTransactionTime, minTransactionTime, transactionType, categoryIds, accountIds, tagIds, amountFilter, keyword, geoFilter, true)
 	sess := s.UserDataDB(uid).NewSession(c).Where(condition, conditionParams...)
 	sess = s.appendFilterTagIdsConditionToQuery(sess, uid, maxTransactionTime, minTransactionTime, tagIds, noTags, tagFilterType)
+	sess = s.appendFilterAnomalyConditionToQuery(sess, uid, anomalyOnly)
 
 	return sess.Count(&models.Transaction{})
 }
 
 // CreateTransaction saves a new transaction to database
 func (s *TransactionService) CreateTransaction(c core.Context, transaction *models.Transaction, tagIds []int64, pictureIds []int64) error {
+	_, err := s.createTransaction(c, transaction, tagIds, pictureIds, nil)
+	return err
+}
+
+// createTransaction saves a new transaction to database if the skip checker (which runs in the same database transaction before inserting) returns false,
+// returns whether the transaction is created
+func (s *TransactionService) createTransaction(c core.Context, transaction *models.Transaction, tagIds []int64, pictureIds []int64, shouldSkip func(sess *xorm.Session) (bool, error)) (bool, error) {
 	if transaction.Uid <= 0 {
-		return errs.ErrUserIdInvalid
+		return false, errs.ErrUserIdInvalid
 	}
 
 	// Check whether account id is valid
 	err := s.isAccountIdValid(transaction)
 
 	if err != nil {
-		return err
+		return false, err
 	}
 
 	now := time.Now().Unix()
@@ -212,7 +230,7 @@ func (s *TransactionService) CreateTransaction(c core.Context, transaction *mode
 	transactionUuids := s.GenerateUuids(uuid.UUID_TYPE_TRANSACTION, uint16(needTransactionUuidCount))
 
 	if len(transactionUuids) < needTransactionUuidCount {
-		return errs.ErrSystemIsBusy
+		return false, errs.ErrSystemIsBusy
 	}
 
 	tagIds = utils.ToUniqueInt64Slice(tagIds)
@@ -220,7 +238,7 @@ func (s *TransactionService) CreateTransaction(c core.Context, transaction *mode
 	tagIndexUuids := s.GenerateUuids(uuid.UUID_TYPE_TAG_INDEX, needTagIndexUuidCount)
 
 	if len(tagIndexUuids) < int(needTagIndexUuidCount) {
-		return errs.ErrSystemIsBusy
+		return false, errs.ErrSystemIsBusy
 	}
 
 	transaction.TransactionId = transactionUuids[0]
@@ -255,9 +273,31 @@ func (s *TransactionService) CreateTransaction(c core.Context, transaction *mode
 
 	userDataDb := s.UserDataDB(transaction.Uid)
 
-	return userDataDb.DoTransaction(c, func(sess *xorm.Session) error {
+	skipped := false
+
+	err = userDataDb.DoTransaction(c, func(sess *xorm.Session) error {
+		if shouldSkip != nil {
+			skipped, err = shouldSkip(sess)
+
+			if err != nil || skipped {
+				return err
+			}
+		}
+
 		return s.doCreateTransaction(c, userDataDb, sess, transaction, transactionTagIndexes, tagIds, pictureIds, pictureUpdateModel)
 	})
+
+	if err != nil {
+		return false, err
+	} else if skipped {
+		return false, nil
+	}
+
+	s.EmitTransactionWebhookEvent(c, models.WEBHOOK_EVENT_TYPE_TRANSACTION_CREATED, transaction)
+	s.EmitAccountBalanceChangedWebhookEvents(c, transaction.Uid, s.getBalanceChangedAccountIds(nil, transaction))
+	s.EnqueueTransactionAnomalyDetection(c, transaction.Uid, []*models.Transaction{transaction})
+
+	return true, nil
 }
 
 // BatchCreateTransactions saves new transactions to database
@@ -363,7 +403,7 @@ func (s *TransactionService) BatchCreateTransactions(c core.Context, uid int64,
 
 	userDataDb := s.UserDataDB(uid)
 
-	return userDataDb.DoTransaction(c, func(sess *xorm.Session) error {
+	err := userDataDb.DoTransaction(c, func(sess *xorm.Session) error {
 		for i := 0; i < len(transactions); i++ {
 			transaction := transactions[i]
 			transactionTagIndexes := allTransactionTagIndexes[transaction.TransactionId]
@@ -386,6 +426,22 @@ func (s *TransactionService) BatchCreateTransactions(c core.Context, uid int64,
 
 		return nil
 	})
+
+	if err != nil {
+		return err
+	}
+
+	balanceChangedAccountIds := make([]int64, 0)
+
+	for i := 0; i < len(transactions); i++ {
+		balanceChangedAccountIds = append(balanceChangedAccountIds, s.getBalanceChangedAccountIds(nil, transactions[i])...)
+	}
+
+	s.EmitImportCompletedWebhookEvent(c, uid, len(transactions))
+	s.EmitAccountBalanceChangedWebhookEvents(c, uid, balanceChangedAccountIds)
+	s.EnqueueTransactionAnomalyDetection(c, uid, transactions)
+
+	return nil
 }
 
 // CreateScheduledTransactions saves all scheduled transactions that should be created now
@@ -406,9 +462,15 @@ func (s *TransactionService) CreateScheduledTransactions(c core.Context, current
 	minScheduledAt := minutesElapsedOfDayInUtc
 	maxScheduledAt := minScheduledAt + intervalMinute
 
+	err := s.CreateMissedScheduledTransactions(c, startTime.Unix())
+
+	if err != nil {
+		log.Errorf(c, "[transactions.CreateScheduledTransactions] failed to create missed scheduled transactions, because %s", err.Error())
+	}
+
 	for i := 0; i < s.UserDataDBCount(); i++ {
 		var templates []*models.TransactionTemplate
-		err := s.UserDataDBByIndex(i).NewSession(c).Where("deleted=? AND template_type=? AND (scheduled_frequency_type=? OR scheduled_frequency_type=?) AND (scheduled_start_time IS NULL OR scheduled_start_time<=?) AND (scheduled_end_time IS NULL OR scheduled_end_time>=?) AND scheduled_at>=? AND scheduled_at<?", false, models.TRANSACTION_TEMPLATE_TYPE_SCHEDULE, models.TRANSACTION_SCHEDULE_FREQUENCY_TYPE_WEEKLY, models.TRANSACTION_SCHEDULE_FREQUENCY_TYPE_MONTHLY, startTime.Unix(), startTime.Unix(), minScheduledAt, maxScheduledAt).Find(&templates)
+		err := s.UserDataDBByIndex(i).NewSession(c).Where("deleted=? AND template_type=? AND scheduled_frequency_type>? AND (scheduled_start_time IS NULL OR scheduled_start_time<=?) AND (scheduled_end_time IS NULL OR scheduled_end_time>=?) AND scheduled_at>=? AND scheduled_at<?", false, models.TRANSACTION_TEMPLATE_TYPE_SCHEDULE, models.TRANSACTION_SCHEDULE_FREQUENCY_TYPE_DISABLED, startTime.Unix(), startTime.Unix(), minScheduledAt, maxScheduledAt).Find(&templates)
 
 		if err != nil {
 			return err
@@ -436,34 +498,19 @@ func (s *TransactionService) CreateScheduledTransactions(c core.Context, current
 			continue
 		}
 
-		if (template.ScheduledFrequencyType != models.TRANSACTION_SCHEDULE_FREQUENCY_TYPE_WEEKLY &&
-			template.ScheduledFrequencyType != models.TRANSACTION_SCHEDULE_FREQUENCY_TYPE_MONTHLY) ||
-			template.ScheduledFrequency == "" {
+		if !template.IsScheduledFrequencyValid() {
 			skipCount++
 			log.Warnf(c, "[transactions.CreateScheduledTransactions] transaction template \"id:%d\" has invalid scheduled transaction frequency", template.TemplateId)
 			continue
 		}
 
-		frequencyValues, err := utils.StringArrayToInt64Array(strings.Split(template.ScheduledFrequency, ","))
-
-		if err != nil {
-			skipCount++
-			log.Warnf(c, "[transactions.CreateScheduledTransactions] transaction template \"id:%d\" has invalid scheduled transaction frequency, because %s", template.TemplateId, err.Error())
-			continue
-		}
-
-		frequencyValueSet := utils.ToSet(frequencyValues)
 		templateTimeZone := time.FixedZone("Template Timezone", int(template.ScheduledTimezoneUtcOffset)*60)
 		transactionUnixTime := todayFirstUnixTimeInUTC + int64(template.ScheduledAt)*60
 		transactionTime := time.Unix(transactionUnixTime, 0).In(templateTimeZone)
 
-		if template.ScheduledFrequencyType == models.TRANSACTION_SCHEDULE_FREQUENCY_TYPE_WEEKLY && !frequencyValueSet[int64(transactionTime.Weekday())] {
-			skipCount++
-			log.Infof(c, "[transactions.CreateScheduledTransactions] transaction template \"id:%d\" does not need to create transaction, today is %s", template.TemplateId, startTimeInUTC.Weekday())
-			continue
-		} else if template.ScheduledFrequencyType == models.TRANSACTION_SCHEDULE_FREQUENCY_TYPE_MONTHLY && !frequencyValueSet[int64(transactionTime.Day())] {
+		if !template.IsScheduledDate(transactionTime) {
 			skipCount++
-			log.Infof(c, "[transactions.CreateScheduledTransactions] transaction template \"id:%d\" does not need to create transaction, today is %d of month", template.TemplateId, startTimeInUTC.Day())
+			log.Infof(c, "[transactions.CreateScheduledTransactions] transaction template \"id:%d\" does not need to create transaction, today is %s", template.TemplateId, transactionTime.Format("2006-01-02 Monday"))
 			continue
 		}
 
@@ -479,48 +526,34 @@ func (s *TransactionService) CreateScheduledTransactions(c core.Context, current
 			continue
 		}
 
-		var transactionDbType models.TransactionDbType
-
-		if template.Type == models.TRANSACTION_TYPE_EXPENSE {
-			transactionDbType = models.TRANSACTION_DB_TYPE_EXPENSE
-		} else if template.Type == models.TRANSACTION_TYPE_INCOME {
-			transactionDbType = models.TRANSACTION_DB_TYPE_INCOME
-		} else if template.Type == models.TRANSACTION_TYPE_TRANSFER {
-			transactionDbType = models.TRANSACTION_DB_TYPE_TRANSFER_OUT
-		} else {
+		if !s.isScheduledTransactionTypeValid(template) {
 			skipCount++
 			log.Warnf(c, "[transactions.CreateScheduledTransactions] transaction template \"id:%d\" has invalid transaction type", template.TemplateId)
 			continue
 		}
 
-		transaction := &models.Transaction{
-			Uid:               template.Uid,
-			Type:              transactionDbType,
-			CategoryId:        template.CategoryId,
-			TransactionTime:   utils.GetMinTransactionTimeFromUnixTime(transactionTime.Unix()),
-			TimezoneUtcOffset: template.ScheduledTimezoneUtcOffset,
-			AccountId:         template.AccountId,
-			Amount:            template.Amount,
-			HideAmount:        template.HideAmount,
-			Comment:           template.Comment,
-			CreatedIp:         "127.0.0.1",
-			ScheduledCreated:  true,
-		}
+		transaction, err := s.createScheduledTransaction(c, template, transactionUnixTime)
 
-		if template.Type == models.TRANSACTION_TYPE_TRANSFER {
-			transaction.RelatedAccountId = template.RelatedAccountId
-			transaction.RelatedAccountAmount = template.RelatedAccountAmount
-		}
+		if err != nil {
+			failedCount++
+			log.Errorf(c, "[transactions.CreateScheduledTransactions] transaction template \"id:%d\" failed to create new trasaction", template.TemplateId)
 
-		tagIds := template.GetTagIds()
-		err = s.CreateTransaction(c, transaction, tagIds, nil)
+			// keep the scheduled run time, so that the failed transaction can be created again by the catch-up run
+			continue
+		}
 
-		if err == nil {
+		if transaction == nil {
+			skipCount++
+			log.Infof(c, "[transactions.CreateScheduledTransactions] transaction template \"id:%d\" does not need to create transaction, the trasaction has already been created", template.TemplateId)
+		} else {
 			successCount++
 			log.Infof(c, "[transactions.CreateScheduledTransactions] transaction template \"id:%d\" has created a new trasaction \"id:%d\"", template.TemplateId, transaction.TransactionId)
-		} else {
-			failedCount++
-			log.Errorf(c, "[transactions.CreateScheduledTransactions] transaction template \"id:%d\" failed to create new trasaction", template.TemplateId)
+		}
+
+		err = s.updateTemplateScheduledRunTime(c, template, transactionUnixTime, template.GetNextScheduledTransactionTime(transactionUnixTime))
+
+		if err != nil {
+			log.Errorf(c, "[transactions.CreateScheduledTransactions] failed to update scheduled run time of transaction template \"id:%d\", because %s", template.TemplateId, err.Error())
 		}
 	}
 
@@ -529,6 +562,132 @@ func (s *TransactionService) CreateScheduledTransactions(c core.Context, current
 	return nil
 }
 
+// CreateMissedScheduledTransactions saves all scheduled transactions which should be created before the specified time but have been missed (e.g. the server was down)
+func (s *TransactionService) CreateMissedScheduledTransactions(c core.Context, beforeUnixTime int64) error {
+	var allTemplates []*models.TransactionTemplate
+
+	for i := 0; i < s.UserDataDBCount(); i++ {
+		var templates []*models.TransactionTemplate
+		err := s.UserDataDBByIndex(i).NewSession(c).Where("deleted=? AND template_type=? AND scheduled_frequency_type>? AND scheduled_next_run_time>? AND scheduled_next_run_time<?", false, models.TRANSACTION_TEMPLATE_TYPE_SCHEDULE, models.TRANSACTION_SCHEDULE_FREQUENCY_TYPE_DISABLED, 0, beforeUnixTime).Find(&templates)
+
+		if err != nil {
+			return err
+		}
+
+		allTemplates = append(allTemplates, templates...)
+	}
+
+	if len(allTemplates) < 1 {
+		return nil
+	}
+
+	log.Infof(c, "[transactions.CreateMissedScheduledTransactions] should process %d scheduled transaction templates which have missed scheduled transactions before %d", len(allTemplates), beforeUnixTime)
+
+	successCount := 0
+	skipCount := 0
+	failedCount := 0
+
+	for i := 0; i < len(allTemplates); i++ {
+		template := allTemplates[i]
+		scheduledTimes := template.GetScheduledTransactionTimes(template.ScheduledNextRunTime, beforeUnixTime-1)
+
+		if len(scheduledTimes) < 1 || !s.isScheduledTransactionTypeValid(template) {
+			skipCount++
+			log.Warnf(c, "[transactions.CreateMissedScheduledTransactions] transaction template \"id:%d\" does not have valid missed scheduled transactions", template.TemplateId)
+
+			err := s.updateTemplateScheduledRunTime(c, template, template.ScheduledLastRunTime, template.GetNextScheduledTransactionTime(beforeUnixTime-1))
+
+			if err != nil {
+				log.Errorf(c, "[transactions.CreateMissedScheduledTransactions] failed to update scheduled run time of transaction template \"id:%d\", because %s", template.TemplateId, err.Error())
+			}
+
+			continue
+		}
+
+		for j := 0; j < len(scheduledTimes); j++ {
+			transaction, err := s.createScheduledTransaction(c, template, scheduledTimes[j])
+
+			if err != nil {
+				failedCount++
+				log.Errorf(c, "[transactions.CreateMissedScheduledTransactions] transaction template \"id:%d\" failed to create missed trasaction at %d, because %s", template.TemplateId, scheduledTimes[j], err.Error())
+				break
+			} else if transaction == nil {
+				skipCount++
+				log.Infof(c, "[transactions.CreateMissedScheduledTransactions] transaction template \"id:%d\" does not need to create transaction at %d, the trasaction has already been created", template.TemplateId, scheduledTimes[j])
+			} else {
+				successCount++
+				log.Infof(c, "[transactions.CreateMissedScheduledTransactions] transaction template \"id:%d\" has created a missed trasaction \"id:%d\" at %d", template.TemplateId, transaction.TransactionId, scheduledTimes[j])
+			}
+
+			err = s.updateTemplateScheduledRunTime(c, template, scheduledTimes[j], template.GetNextScheduledTransactionTime(scheduledTimes[j]))
+
+			if err != nil {
+				log.Errorf(c, "[transactions.CreateMissedScheduledTransactions] failed to update scheduled run time of transaction template \"id:%d\", because %s", template.TemplateId, err.Error())
+				break
+			}
+		}
+	}
+
+	log.Infof(c, "[transactions.CreateMissedScheduledTransactions] %d missed transactions has been created successfully, %d transactions does not need to create and %d transactions failed to create", successCount, skipCount, failedCount)
+
+	return nil
+}
+
+// CreateScheduledTransactionsByTimeRange saves all scheduled transactions of the specified user which should be created between the start time and the end time (both inclusive), returns the count of created transactions and the count of existed transactions
+func (s *TransactionService) CreateScheduledTransactionsByTimeRange(c core.Context, uid int64, startUnixTime int64, endUnixTime int64) (int, int, error) {
+	if uid <= 0 {
+		return 0, 0, errs.ErrUserIdInvalid
+	}
+
+	var templates []*models.TransactionTemplate
+	err := s.UserDataDB(uid).NewSession(c).Where("uid=? AND deleted=? AND template_type=? AND scheduled_frequency_type>?", uid, false, models.TRANSACTION_TEMPLATE_TYPE_SCHEDULE, models.TRANSACTION_SCHEDULE_FREQUENCY_TYPE_DISABLED).Find(&templates)
+
+	if err != nil {
+		return 0, 0, err
+	}
+
+	createdCount := 0
+	existedCount := 0
+
+	for i := 0; i < len(templates); i++ {
+		template := templates[i]
+
+		if !s.isScheduledTransactionTypeValid(template) {
+			log.Warnf(c, "[transactions.CreateScheduledTransactionsByTimeRange] transaction template \"id:%d\" has invalid transaction type", template.TemplateId)
+			continue
+		}
+
+		scheduledTimes := template.GetScheduledTransactionTimes(startUnixTime, endUnixTime)
+
+		for j := 0; j < len(scheduledTimes); j++ {
+			transaction, err := s.createScheduledTransaction(c, template, scheduledTimes[j])
+
+			if err != nil {
+				log.Errorf(c, "[transactions.CreateScheduledTransactionsByTimeRange] transaction template \"id:%d\" failed to create trasaction at %d, because %s", template.TemplateId, scheduledTimes[j], err.Error())
+				return createdCount, existedCount, err
+			}
+
+			if transaction == nil {
+				existedCount++
+				continue
+			}
+
+			createdCount++
+			log.Infof(c, "[transactions.CreateScheduledTransactionsByTimeRange] transaction template \"id:%d\" has created a new trasaction \"id:%d\" at %d", template.TemplateId, transaction.TransactionId, scheduledTimes[j])
+		}
+
+		if len(scheduledTimes) > 0 {
+			err = s.updateTemplateScheduledRunTime(c, template, scheduledTimes[len(scheduledTimes)-1], template.GetNextScheduledTransactionTime(scheduledTimes[len(scheduledTimes)-1]))
+
+			if err != nil {
+				return createdCount, existedCount, err
+			}
+		}
+	}
+
+	return createdCount, existedCount, nil
+}
+
 // ModifyTransaction saves an existed transaction to database
 func (s *TransactionService) ModifyTransaction(c core.Context, transaction *models.Transaction, currentTagIdsCount int, addTagIds []int64, removeTagIds []int64, addPictureIds []int64, removePictureIds []int64) error {
 	if transaction.Uid <= 0 {
@@ -567,9 +726,10 @@ func (s *TransactionService) ModifyTransaction(c core.Context, transaction *mode
 		}
 	}
 
+	oldTransaction := &models.Transaction{}
+
 	err := s.UserDataDB(transaction.Uid).DoTransaction(c, func(sess *xorm.Session) error {
 		// Get and verify current transaction
-		oldTransaction := &models.Transaction{}
 		has, err := sess.ID(transaction.TransactionId).Where("uid=? AND deleted=?", transaction.Uid, false).Get(oldTransaction)
 
 		if err != nil {
@@ -1015,6 +1175,17 @@ func (s *TransactionService) ModifyTransaction(c core.Context, transaction *mode
 		return err
 	}
 
+	newTransaction, err := s.GetTransactionByTransactionId(c, transaction.Uid, transaction.TransactionId)
+
+	if err != nil {
+		log.Warnf(c, "[transactions.ModifyTransaction] failed to reload transaction \"id:%d\" for webhook event, because %s", transaction.TransactionId, err.Error())
+		return nil
+	}
+
+	s.EmitTransactionWebhookEvent(c, models.WEBHOOK_EVENT_TYPE_TRANSACTION_MODIFIED, newTransaction)
+	s.EmitAccountBalanceChangedWebhookEvents(c, transaction.Uid, s.getBalanceChangedAccountIds(oldTransaction, newTransaction))
+	s.EnqueueTransactionAnomalyRedetection(c, transaction.Uid, newTransaction)
+
 	return nil
 }
 
@@ -1041,9 +1212,15 @@ func (s *TransactionService) DeleteTransaction(c core.Context, uid int64, transa
 		DeletedUnixTime: now,
 	}
 
-	return s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
+	anomalyUpdateModel := &models.TransactionAnomaly{
+		Deleted:         true,
+		DeletedUnixTime: now,
+	}
+
+	oldTransaction := &models.Transaction{}
+
+	err := s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
 		// Get and verify current transaction
-		oldTransaction := &models.Transaction{}
 		has, err := sess.ID(transactionId).Where("uid=? AND deleted=?", uid, false).Get(oldTransaction)
 
 		if err != nil {
@@ -1100,6 +1277,13 @@ func (s *TransactionService) DeleteTransaction(c core.Context, uid int64, transa
 			return err
 		}
 
+		// Update transaction anomalies of this transaction and the anomalies which refer to this transaction
+		_, err = sess.Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=? AND (transaction_id=? OR related_transaction_id=?)", uid, false, oldTransaction.TransactionId, oldTransaction.TransactionId).Update(anomalyUpdateModel)
+
+		if err != nil {
+			return err
+		}
+
 		// Update account table
 		if oldTransaction.Type == models.TRANSACTION_DB_TYPE_MODIFY_BALANCE {
 			if oldTransaction.RelatedAccountAmount != 0 {
@@ -1167,6 +1351,15 @@ func (s *TransactionService) DeleteTransaction(c core.Context, uid int64, transa
 
 		return err
 	})
+
+	if err != nil {
+		return err
+	}
+
+	s.EmitTransactionWebhookEvent(c, models.WEBHOOK_EVENT_TYPE_TRANSACTION_DELETED, oldTransaction)
+	s.EmitAccountBalanceChangedWebhookEvents(c, uid, s.getBalanceChangedAccountIds(oldTransaction, nil))
+
+	return nil
 }
 
 // DeleteAllTransactions deletes all existed transactions from database
@@ -1192,6 +1385,11 @@ func (s *TransactionService) DeleteAllTransactions(c core.Context, uid int64) er
 		DeletedUnixTime: now,
 	}
 
+	anomalyUpdateModel := &models.TransactionAnomaly{
+		Deleted:         true,
+		DeletedUnixTime: now,
+	}
+
 	accountUpdateModel := &models.Account{
 		Balance:         0,
 		Deleted:         true,
@@ -1220,6 +1418,13 @@ func (s *TransactionService) DeleteAllTransactions(c core.Context, uid int64) er
 			return err
 		}
 
+		// Update all transaction anomaly to deleted
+		_, err = sess.Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=?", uid, false).Update(anomalyUpdateModel)
+
+		if err != nil {
+			return err
+		}
+
 		// Update all account table to deleted
 		_, err = sess.Cols("balance", "deleted", "deleted_unix_time").Where("uid=? AND deleted=?", uid, false).Update(accountUpdateModel)
 
@@ -1360,8 +1565,101 @@ func (s *TransactionService) GetAccountsTotalIncomeAndExpense(c core.Context, ui
 	return incomeAmounts, expenseAmounts, nil
 }
 
+// GetAccountsBalanceChangesAfterTime returns the every accounts total balance changes of all transactions which are later than specific time
+func (s *TransactionService) GetAccountsBalanceChangesAfterTime(c core.Context, uid int64, unixTime int64) (map[int64]int64, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	minTransactionTime := utils.GetMaxTransactionTimeFromUnixTime(unixTime) + 1
+	maxTransactionTime := int64(0)
+	var allTransactions []*models.Transaction
+
+	for maxTransactionTime >= 0 {
+		var transactions []*models.Transaction
+
+		condition := "uid=? AND deleted=? AND transaction_time>=?"
+		conditionParams := make([]any, 0, 4)
+		conditionParams = append(conditionParams, uid)
+		conditionParams = append(conditionParams, false)
+		conditionParams = append(conditionParams, minTransactionTime)
+
+		if maxTransactionTime > 0 {
+			condition = condition + " AND transaction_time<=?"
+			conditionParams = append(conditionParams, maxTransactionTime)
+		}
+
+		err := s.UserDataDB(uid).NewSession(c).Select("type, account_id, transaction_time, amount").Where(condition, conditionParams...).Limit(pageCountForLoadTransactionAmounts, 0).OrderBy("transaction_time desc").Find(&transactions)
+
+		if err != nil {
+			return nil, err
+		}
+
+		allTransactions = append(allTransactions, transactions...)
+
+		if len(transactions) < pageCountForLoadTransactionAmounts {
+			maxTransactionTime = -1
+			break
+		}
+
+		maxTransactionTime = transactions[len(transactions)-1].TransactionTime - 1
+	}
+
+	balanceChanges := make(map[int64]int64)
+
+	for i := 0; i < len(allTransactions); i++ {
+		transaction := allTransactions[i]
+
+		switch transaction.Type {
+		case models.TRANSACTION_DB_TYPE_MODIFY_BALANCE, models.TRANSACTION_DB_TYPE_INCOME, models.TRANSACTION_DB_TYPE_TRANSFER_IN:
+			balanceChanges[transaction.AccountId] += transaction.Amount
+		case models.TRANSACTION_DB_TYPE_EXPENSE, models.TRANSACTION_DB_TYPE_TRANSFER_OUT:
+			balanceChanges[transaction.AccountId] -= transaction.Amount
+		}
+	}
+
+	return balanceChanges, nil
+}
+
 // GetAccountsAndCategoriesTotalIncomeAndExpense returns the every accounts and categories total income and expense amount by specific date range
 func (s *TransactionService) GetAccountsAndCategoriesTotalIncomeAndExpense(c core.Context, uid int64, startUnixTime int64, endUnixTime int64, tagIds []int64, noTags bool, tagFilterType models.TransactionTagFilterType, utcOffset int16, useTransactionTimezone bool) ([]*models.Transaction, error) {
+	allTransactions, err := s.GetIncomeAndExpenseTransactionsForStatistics(c, uid, startUnixTime, endUnixTime, tagIds, noTags, tagFilterType, utcOffset, useTransactionTimezone)
+
+	if err != nil {
+		return nil, err
+	}
+
+	transactionTotalAmountsMap := make(map[string]*models.Transaction)
+
+	for i := 0; i < len(allTransactions); i++ {
+		transaction := allTransactions[i]
+		groupKey := fmt.Sprintf("%d_%d", transaction.CategoryId, transaction.AccountId)
+		totalAmounts, exists := transactionTotalAmountsMap[groupKey]
+
+		if !exists {
+			totalAmounts = &models.Transaction{
+				CategoryId: transaction.CategoryId,
+				AccountId:  transaction.AccountId,
+				Amount:     0,
+			}
+
+			transactionTotalAmountsMap[groupKey] = totalAmounts
+		}
+
+		totalAmounts.Amount += transaction.Amount
+	}
+
+	transactionTotalAmounts := make([]*models.Transaction, 0, len(transactionTotalAmountsMap))
+
+	for _, totalAmounts := range transactionTotalAmountsMap {
+		transactionTotalAmounts = append(transactionTotalAmounts, totalAmounts)
+	}
+
+	return transactionTotalAmounts, nil
+}
+
+// GetIncomeAndExpenseTransactionsForStatistics returns all income and expense transactions (only the fields for statistics are loaded) which local time is in specific date range
+func (s *TransactionService) GetIncomeAndExpenseTransactionsForStatistics(c core.Context, uid int64, startUnixTime int64, endUnixTime int64, tagIds []int64, noTags bool, tagFilterType models.TransactionTagFilterType, utcOffset int16, useTransactionTimezone bool) ([]*models.Transaction, error) {
 	if uid <= 0 {
 		return nil, errs.ErrUserIdInvalid
 	}
@@ -1409,7 +1707,7 @@ func (s *TransactionService) GetAccountsAndCategoriesTotalIncomeAndExpense(c cor
 			finalConditionParams = append(finalConditionParams, maxTransactionTime)
 		}
 
-		sess := s.UserDataDB(uid).NewSession(c).Select("category_id, account_id, transaction_time, timezone_utc_offset, amount").Where(finalCondition, finalConditionParams...)
+		sess := s.UserDataDB(uid).NewSession(c).Select("transaction_id, type, category_id, account_id, transaction_time, timezone_utc_offset, amount, geo_longitude, geo_latitude").Where(finalCondition, finalConditionParams...)
 		sess = s.appendFilterTagIdsConditionToQuery(sess, uid, maxTransactionTime, minTransactionTime, tagIds, noTags, tagFilterType)
 
 		err := sess.Limit(pageCountForLoadTransactionAmounts, 0).OrderBy("transaction_time desc").Find(&transactions)
@@ -1428,7 +1726,7 @@ func (s *TransactionService) GetAccountsAndCategoriesTotalIncomeAndExpense(c cor
 		maxTransactionTime = transactions[len(transactions)-1].TransactionTime - 1
 	}
 
-	transactionTotalAmountsMap := make(map[string]*models.Transaction)
+	transactions := make([]*models.Transaction, 0, len(allTransactions))
 
 	for i := 0; i < len(allTransactions); i++ {
 		transaction := allTransactions[i]
@@ -1444,55 +1742,168 @@ func (s *TransactionService) GetAccountsAndCategoriesTotalIncomeAndExpense(c cor
 			continue
 		}
 
-		groupKey := fmt.Sprintf("%d_%d", transaction.CategoryId, transaction.AccountId)
-		totalAmounts, exists := transactionTotalAmountsMap[groupKey]
+		transactions = append(transactions, transaction)
+	}
+
+	return transactions, nil
+}
+
+// GetTransactionsInTimeRangeByFilters returns all transactions matching the filters in the specified local date time range like statistics, the transfer-in transactions are excluded unless filtering by one account
+func (s *TransactionService) GetTransactionsInTimeRangeByFilters(c core.Context, uid int64, startUnixTime int64, endUnixTime int64, transactionType models.TransactionDbType, categoryIds []int64, accountIds []int64, tagIds []int64, noTags bool, tagFilterType models.TransactionTagFilterType, amountFilter string, keyword string, utcOffset int16, useTransactionTimezone bool) ([]*models.Transaction, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	clientLocation := time.FixedZone("Client Timezone", int(utcOffset)*60)
+	var startLocalDateTime, endLocalDateTime, startTransactionTime, endTransactionTime int64
+
+	if startUnixTime > 0 {
+		startLocalDateTime = utils.FormatUnixTimeToNumericLocalDateTime(startUnixTime, clientLocation)
+		startUnixTime = utils.GetMinUnixTimeWithSameLocalDateTime(startUnixTime, utcOffset)
+		startTransactionTime = utils.GetMinTransactionTimeFromUnixTime(startUnixTime)
+	}
+
+	if endUnixTime > 0 {
+		endLocalDateTime = utils.FormatUnixTimeToNumericLocalDateTime(endUnixTime, clientLocation)
+		endUnixTime = utils.GetMaxUnixTimeWithSameLocalDateTime(endUnixTime, utcOffset)
+		endTransactionTime = utils.GetMaxTransactionTimeFromUnixTime(endUnixTime)
+	}
+
+	maxTransactionTime := endTransactionTime
+	var allTransactions []*models.Transaction
+
+	for maxTransactionTime >= 0 {
+		var transactions []*models.Transaction
+
+		condition, conditionParams := s.buildTransactionQueryCondition(uid, maxTransactionTime, startTransactionTime, transactionType, categoryIds, accountIds, tagIds, amountFilter, keyword, nil, true)
+		sess := s.UserDataDB(uid).NewSession(c).Where(condition, conditionParams...)
+		sess = s.appendFilterTagIdsConditionToQuery(sess, uid, maxTransactionTime, startTransactionTime, tagIds, noTags, tagFilterType)
+
+		err := sess.Limit(pageCountForLoadTransactionAmounts, 0).OrderBy("transaction_time desc").Find(&transactions)
+
+		if err != nil {
+			return nil, err
+		}
+
+		allTransactions = append(allTransactions, transactions...)
+
+		if len(transactions) < pageCountForLoadTransactionAmounts {
+			maxTransactionTime = -1
+			break
+		}
+
+		maxTransactionTime = transactions[len(transactions)-1].TransactionTime - 1
+	}
+
+	transactions := make([]*models.Transaction, 0, len(allTransactions))
+
+	for i := 0; i < len(allTransactions); i++ {
+		transaction := allTransactions[i]
+		timeZone := clientLocation
+
+		if useTransactionTimezone {
+			timeZone = time.FixedZone("Transaction Timezone", int(transaction.TimezoneUtcOffset)*60)
+		}
+
+		localDateTime := utils.FormatUnixTimeToNumericLocalDateTime(utils.GetUnixTimeFromTransactionTime(transaction.TransactionTime), timeZone)
+
+		if (startLocalDateTime > 0 && localDateTime < startLocalDateTime) || (endLocalDateTime > 0 && localDateTime > endLocalDateTime) {
+			continue
+		}
+
+		transactions = append(transactions, transaction)
+	}
+
+	return transactions, nil
+}
+
+// GetAccountsAndCategoriesPeriodicIncomeAndExpense returns the every accounts and categories income and expense amount of each period by specific date range,
+// the date range is expanded to whole periods, and the key of returned map is the first date of period in YYYYMMDD format
+func (s *TransactionService) GetAccountsAndCategoriesPeriodicIncomeAndExpense(c core.Context, uid int64, startYear int32, startMonth int32, endYear int32, endMonth int32, periodOptions *models.StatisticPeriodOptions, tagIds []int64, noTags bool, tagFilterType models.TransactionTagFilterType, utcOffset int16, useTransactionTimezone bool) (map[int32][]*models.Transaction, error) {
+	allTransactions, err := s.GetIncomeAndExpenseTransactionsForPeriodicStatistics(c, uid, startYear, startMonth, endYear, endMonth, periodOptions, tagIds, noTags, tagFilterType, utcOffset, useTransactionTimezone)
+
+	if err != nil {
+		return nil, err
+	}
+
+	clientLocation := time.FixedZone("Client Timezone", int(utcOffset)*60)
+	transactionsPeriodicAmountsMap := make(map[string]*models.Transaction)
+	transactionsPeriodicAmounts := make(map[int32][]*models.Transaction)
+
+	for i := 0; i < len(allTransactions); i++ {
+		transaction := allTransactions[i]
+		timeZone := clientLocation
+
+		if useTransactionTimezone {
+			timeZone = time.FixedZone("Transaction Timezone", int(transaction.TimezoneUtcOffset)*60)
+		}
+
+		transactionLocalTime := time.Unix(utils.GetUnixTimeFromTransactionTime(transaction.TransactionTime), 0).In(timeZone)
+		periodStartDate := utils.FormatTimeToNumericDate(periodOptions.GetPeriodStartDate(transactionLocalTime))
+		groupKey := fmt.Sprintf("%d_%d_%d", periodStartDate, transaction.CategoryId, transaction.AccountId)
+		transactionAmounts, exists := transactionsPeriodicAmountsMap[groupKey]
 
 		if !exists {
-			totalAmounts = &models.Transaction{
+			transactionAmounts = &models.Transaction{
 				CategoryId: transaction.CategoryId,
 				AccountId:  transaction.AccountId,
-				Amount:     0,
 			}
-
-			transactionTotalAmountsMap[groupKey] = totalAmounts
+			transactionsPeriodicAmountsMap[groupKey] = transactionAmounts
 		}
 
-		totalAmounts.Amount += transaction.Amount
+		transactionAmounts.Amount += transaction.Amount
 	}
 
-	transactionTotalAmounts := make([]*models.Transaction, 0, len(transactionTotalAmountsMap))
+	for groupKey, transaction := range transactionsPeriodicAmountsMap {
+		groupKeyParts := strings.Split(groupKey, "_")
+		periodStartDate, _ := utils.StringToInt32(groupKeyParts[0])
+		periodicAmounts, exists := transactionsPeriodicAmounts[periodStartDate]
 
-	for _, totalAmounts := range transactionTotalAmountsMap {
-		transactionTotalAmounts = append(transactionTotalAmounts, totalAmounts)
+		if !exists {
+			periodicAmounts = make([]*models.Transaction, 0, 0)
+		}
+
+		periodicAmounts = append(periodicAmounts, transaction)
+		transactionsPeriodicAmounts[periodStartDate] = periodicAmounts
 	}
 
-	return transactionTotalAmounts, nil
+	return transactionsPeriodicAmounts, nil
 }
 
-// GetAccountsAndCategoriesMonthlyIncomeAndExpense returns the every accounts monthly income and expense amount by specific date range
-func (s *TransactionService) GetAccountsAndCategoriesMonthlyIncomeAndExpense(c core.Context, uid int64, startYear int32, startMonth int32, endYear int32, endMonth int32, tagIds []int64, noTags bool, tagFilterType models.TransactionTagFilterType, utcOffset int16, useTransactionTimezone bool) (map[int32][]*models.Transaction, error) {
+// GetIncomeAndExpenseTransactionsForPeriodicStatistics returns all income and expense transactions (only the fields for statistics are loaded) which local date is in specific date range,
+// the date range is expanded to whole periods
+func (s *TransactionService) GetIncomeAndExpenseTransactionsForPeriodicStatistics(c core.Context, uid int64, startYear int32, startMonth int32, endYear int32, endMonth int32, periodOptions *models.StatisticPeriodOptions, tagIds []int64, noTags bool, tagFilterType models.TransactionTagFilterType, utcOffset int16, useTransactionTimezone bool) ([]*models.Transaction, error) {
 	if uid <= 0 {
 		return nil, errs.ErrUserIdInvalid
 	}
 
 	clientLocation := time.FixedZone("Client Timezone", int(utcOffset)*60)
 	var startTransactionTime, endTransactionTime int64
-	var err error
+	var startDate, endDate int32
 
 	if startYear > 0 && startMonth > 0 {
-		startTransactionTime, _, err = utils.GetTransactionTimeRangeByYearMonth(startYear, startMonth)
+		rangeStartDate := periodOptions.GetPeriodStartDate(time.Date(int(startYear), time.Month(startMonth), 1, 0, 0, 0, 0, time.UTC))
+		rangeStartUnixTime, err := utils.ParseFromLongDateTimeToMinUnixTime(rangeStartDate.Format("2006-01-02 15:04:05"))
 
 		if err != nil {
 			return nil, errs.ErrSystemError
 		}
+
+		startDate = utils.FormatTimeToNumericDate(rangeStartDate)
+		startTransactionTime = utils.GetMinTransactionTimeFromUnixTime(rangeStartUnixTime.Unix())
 	}
 
 	if endYear > 0 && endMonth > 0 {
-		_, endTransactionTime, err = utils.GetTransactionTimeRangeByYearMonth(endYear, endMonth)
+		lastDateOfEndMonth := time.Date(int(endYear), time.Month(endMonth)+1, 0, 0, 0, 0, 0, time.UTC)
+		rangeEndDate := periodOptions.GetNextPeriodStartDate(periodOptions.GetPeriodStartDate(lastDateOfEndMonth))
+		rangeEndUnixTime, err := utils.ParseFromLongDateTimeToMaxUnixTime(rangeEndDate.Format("2006-01-02 15:04:05"))
 
 		if err != nil {
 			return nil, errs.ErrSystemError
 		}
+
+		endDate = utils.FormatTimeToNumericDate(rangeEndDate)
+		endTransactionTime = utils.GetMinTransactionTimeFromUnixTime(rangeEndUnixTime.Unix()) - 1
 	}
 
 	condition := "uid=? AND deleted=? AND (type=? OR type=?)"
@@ -1542,10 +1953,7 @@ func (s *TransactionService) GetAccountsAndCategoriesMonthlyIncomeAndExpense(c c
 		maxTransactionTime = transactions[len(transactions)-1].TransactionTime - 1
 	}
 
-	startYearMonth := startYear*100 + startMonth
-	endYearMonth := endYear*100 + endMonth
-	transactionsMonthlyAmountsMap := make(map[string]*models.Transaction)
-	transactionsMonthlyAmounts := make(map[int32][]*models.Transaction)
+	transactions := make([]*models.Transaction, 0, len(allTransactions))
 
 	for i := 0; i < len(allTransactions); i++ {
 		transaction := allTransactions[i]
@@ -1555,40 +1963,17 @@ func (s *TransactionService) GetAccountsAndCategoriesMonthlyIncomeAndExpense(c c
 			timeZone = time.FixedZone("Transaction Timezone", int(transaction.TimezoneUtcOffset)*60)
 		}
 
-		yearMonth := utils.FormatUnixTimeToNumericYearMonth(utils.GetUnixTimeFromTransactionTime(transaction.TransactionTime), timeZone)
+		transactionLocalTime := time.Unix(utils.GetUnixTimeFromTransactionTime(transaction.TransactionTime), 0).In(timeZone)
+		transactionDate := utils.FormatTimeToNumericDate(transactionLocalTime)
 
-		if (startYearMonth > 0 && yearMonth < startYearMonth) || (endYearMonth > 0 && yearMonth > endYearMonth) {
+		if (startDate > 0 && transactionDate < startDate) || (endDate > 0 && transactionDate >= endDate) {
 			continue
 		}
 
-		groupKey := fmt.Sprintf("%d_%d_%d", yearMonth, transaction.CategoryId, transaction.AccountId)
-		transactionAmounts, exists := transactionsMonthlyAmountsMap[groupKey]
-
-		if !exists {
-			transactionAmounts = &models.Transaction{
-				CategoryId: transaction.CategoryId,
-				AccountId:  transaction.AccountId,
-			}
-			transactionsMonthlyAmountsMap[groupKey] = transactionAmounts
-		}
-
-		transactionAmounts.Amount += transaction.Amount
+		transactions = append(transactions, transaction)
 	}
 
-	for groupKey, transaction := range transactionsMonthlyAmountsMap {
-		groupKeyParts := strings.Split(groupKey, "_")
-		yearMonth, _ := utils.StringToInt32(groupKeyParts[0])
-		monthlyAmounts, exists := transactionsMonthlyAmounts[yearMonth]
-
-		if !exists {
-			monthlyAmounts = make([]*models.Transaction, 0, 0)
-		}
-
-		monthlyAmounts = append(monthlyAmounts, transaction)
-		transactionsMonthlyAmounts[yearMonth] = monthlyAmounts
-	}
-
-	return transactionsMonthlyAmounts, nil
+	return transactions, nil
 }
 
 // GetTransactionMapByList returns a transaction map by a list
@@ -1868,7 +2253,253 @@ func (s *TransactionService) doCreateTransaction(c core.Context, database *datas
 	return err
 }
 
-func (s *TransactionService) buildTransactionQueryCondition(uid int64, maxTransactionTime int64, minTransactionTime int64, transactionType models.TransactionDbType, categoryIds []int64, accountIds []int64, tagIds []int64, amountFilter string, keyword string, noDuplicated bool) (string, []any) {
+// getBalanceChangedAccountIds returns the ids of accounts whose balance are changed by creating, modifying or deleting the transaction
+func (s *TransactionService) getBalanceChangedAccountIds(oldTransaction *models.Transaction, newTransaction *models.Transaction) []int64 {
+	if oldTransaction != nil && newTransaction != nil &&
+		oldTransaction.AccountId == newTransaction.AccountId && oldTransaction.Amount == newTransaction.Amount &&
+		oldTransaction.RelatedAccountId == newTransaction.RelatedAccountId && oldTransaction.RelatedAccountAmount == newTransaction.RelatedAccountAmount {
+		return nil
+	}
+
+	accountIds := make([]int64, 0, 4)
+
+	for _, transaction := range []*models.Transaction{oldTransaction, newTransaction} {
+		if transaction == nil {
+			continue
+		}
+
+		accountIds = append(accountIds, transaction.AccountId)
+
+		if transaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_OUT || transaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_IN {
+			accountIds = append(accountIds, transaction.RelatedAccountId)
+		}
+	}
+
+	return utils.ToUniqueInt64Slice(accountIds)
+}
+
+func (s *TransactionService) isScheduledTransactionTypeValid(template *models.TransactionTemplate) bool {
+	return template.Type == models.TRANSACTION_TYPE_EXPENSE || template.Type == models.TRANSACTION_TYPE_INCOME || template.Type == models.TRANSACTION_TYPE_TRANSFER
+}
+
+func (s *TransactionService) createScheduledTransaction(c core.Context, template *models.TransactionTemplate, transactionUnixTime int64) (*models.Transaction, error) {
+	var transactionDbType models.TransactionDbType
+
+	if template.Type == models.TRANSACTION_TYPE_EXPENSE {
+		transactionDbType = models.TRANSACTION_DB_TYPE_EXPENSE
+	} else if template.Type == models.TRANSACTION_TYPE_INCOME {
+		transactionDbType = models.TRANSACTION_DB_TYPE_INCOME
+	} else if template.Type == models.TRANSACTION_TYPE_TRANSFER {
+		transactionDbType = models.TRANSACTION_DB_TYPE_TRANSFER_OUT
+	} else {
+		return nil, errs.ErrTransactionTypeInvalid
+	}
+
+	transaction := &models.Transaction{
+		Uid:                 template.Uid,
+		Type:                transactionDbType,
+		CategoryId:          template.CategoryId,
+		TransactionTime:     utils.GetMinTransactionTimeFromUnixTime(transactionUnixTime),
+		TimezoneUtcOffset:   template.ScheduledTimezoneUtcOffset,
+		AccountId:           template.AccountId,
+		Amount:              template.Amount,
+		HideAmount:          template.HideAmount,
+		Comment:             template.Comment,
+		CreatedIp:           "127.0.0.1",
+		ScheduledCreated:    true,
+		ScheduledTemplateId: template.TemplateId,
+		ScheduledTime:       transactionUnixTime,
+	}
+
+	if template.Type == models.TRANSACTION_TYPE_TRANSFER {
+		transaction.RelatedAccountId = template.RelatedAccountId
+		transaction.RelatedAccountAmount = template.RelatedAccountAmount
+	}
+
+	// the same scheduled transaction may have been created by the regular run, the catch-up run or the manual backfill,
+	// check it before evaluating the amount expression, and check it again in the same database transaction when inserting
+	exists, err := s.isScheduledTransactionCreated(s.UserDataDB(template.Uid).NewSession(c), template, transaction, transactionUnixTime)
+
+	if err != nil {
+		return nil, err
+	} else if exists {
+		return nil, nil
+	}
+
+	if template.AmountExpression != "" {
+		amount, err := s.evaluateScheduledTransactionAmount(c, template, transactionUnixTime)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if amount <= 0 {
+			log.Infof(c, "[transactions.createScheduledTransaction] skip creating transaction by template \"id:%d\" at %d for user \"uid:%d\", because the computed amount %d is not positive", template.TemplateId, transactionUnixTime, template.Uid, amount)
+			return nil, nil
+		}
+
+		transaction.Amount = amount
+
+		if template.Type == models.TRANSACTION_TYPE_TRANSFER {
+			if template.Amount != 0 {
+				transaction.RelatedAccountAmount = int64(math.Round(float64(amount) * float64(template.RelatedAccountAmount) / float64(template.Amount)))
+			} else {
+				transaction.RelatedAccountAmount = amount
+			}
+		}
+	}
+
+	created, err := s.createTransaction(c, transaction, template.GetTagIds(), nil, func(sess *xorm.Session) (bool, error) {
+		return s.isScheduledTransactionCreated(sess, template, transaction, transactionUnixTime)
+	})
+
+	if err != nil {
+		return nil, err
+	} else if !created {
+		return nil, nil
+	}
+
+	return transaction, nil
+}
+
+// isScheduledTransactionCreated returns whether the transaction of the scheduled transaction template at the specified time has been created,
+// the deleted transactions are also included so that the transactions deleted by user will not be created again
+func (s *TransactionService) isScheduledTransactionCreated(sess *xorm.Session, template *models.TransactionTemplate, transaction *models.Transaction, transactionUnixTime int64) (bool, error) {
+	exists, err := sess.Cols("uid", "scheduled_template_id", "scheduled_time").Where("uid=? AND scheduled_template_id=? AND scheduled_time=?", template.Uid, template.TemplateId, transactionUnixTime).Limit(1).Exist(&models.Transaction{})
+
+	if err != nil || exists {
+		return exists, err
+	}
+
+	// the scheduled transactions created before the template id is recorded can only be matched by the transaction content
+	if template.AmountExpression == "" {
+		return sess.Cols("uid", "transaction_time").Where("uid=? AND scheduled_created=? AND scheduled_template_id=? AND type=? AND category_id=? AND account_id=? AND amount=? AND transaction_time>=? AND transaction_time<=?", template.Uid, true, 0, transaction.Type, transaction.CategoryId, transaction.AccountId, transaction.Amount, utils.GetMinTransactionTimeFromUnixTime(transactionUnixTime), utils.GetMaxTransactionTimeFromUnixTime(transactionUnixTime)).Limit(1).Exist(&models.Transaction{})
+	}
+
+	return sess.Cols("uid", "transaction_time").Where("uid=? AND scheduled_created=? AND scheduled_template_id=? AND type=? AND category_id=? AND account_id=? AND comment=? AND transaction_time>=? AND transaction_time<=?", template.Uid, true, 0, transaction.Type, transaction.CategoryId, transaction.AccountId, transaction.Comment, utils.GetMinTransactionTimeFromUnixTime(transactionUnixTime), utils.GetMaxTransactionTimeFromUnixTime(transactionUnixTime)).Limit(1).Exist(&models.Transaction{})
+}
+
+// evaluateScheduledTransactionAmount returns the amount (in the smallest unit of account currency) computed by the amount expression of the scheduled transaction template,
+// balance variables use the current account balances, period variables use the calendar months of the transaction time in template timezone
+func (s *TransactionService) evaluateScheduledTransactionAmount(c core.Context, template *models.TransactionTemplate, transactionUnixTime int64) (int64, error) {
+	variableNames, err := expressions.GetAmountExpressionVariableNames(c, template.AmountExpression)
+
+	if err != nil {
+		return 0, err
+	}
+
+	account, err := s.getScheduledTransactionAccount(c, template.Uid, template.AccountId)
+
+	if err != nil {
+		return 0, err
+	}
+
+	currencyPrecision := account.GetCurrencyPrecision()
+
+	variables := make(map[string]float64, len(variableNames))
+
+	for i := 0; i < len(variableNames); i++ {
+		variableName := variableNames[i]
+
+		if !template.IsAmountExpressionVariableSupported(variableName) {
+			log.Warnf(c, "[transactions.evaluateScheduledTransactionAmount] variable \"%s\" is not supported in amount expression of template \"id:%d\"", variableName, template.TemplateId)
+			return 0, errs.ErrScheduledTransactionAmountExpressionInvalid
+		}
+
+		var value int64
+		valuePrecision := currencyPrecision
+
+		switch variableName {
+		case models.TRANSACTION_TEMPLATE_AMOUNT_EXPRESSION_VARIABLE_AMOUNT:
+			value = template.Amount
+		case models.TRANSACTION_TEMPLATE_AMOUNT_EXPRESSION_VARIABLE_BALANCE:
+			value = account.Balance
+		case models.TRANSACTION_TEMPLATE_AMOUNT_EXPRESSION_VARIABLE_DESTINATION_BALANCE:
+			var relatedAccount *models.Account
+			relatedAccount, err = s.getScheduledTransactionAccount(c, template.Uid, template.RelatedAccountId)
+
+			if err == nil {
+				value = relatedAccount.Balance
+				valuePrecision = relatedAccount.GetCurrencyPrecision()
+			}
+		case models.TRANSACTION_TEMPLATE_AMOUNT_EXPRESSION_VARIABLE_THIS_MONTH_INCOME,
+			models.TRANSACTION_TEMPLATE_AMOUNT_EXPRESSION_VARIABLE_THIS_MONTH_EXPENSE,
+			models.TRANSACTION_TEMPLATE_AMOUNT_EXPRESSION_VARIABLE_LAST_MONTH_INCOME,
+			models.TRANSACTION_TEMPLATE_AMOUNT_EXPRESSION_VARIABLE_LAST_MONTH_EXPENSE:
+			value, err = s.getAccountMonthlyIncomeOrExpense(c, template, transactionUnixTime, variableName)
+		}
+
+		if err != nil {
+			return 0, err
+		}
+
+		variables[variableName] = float64(value) / math.Pow10(valuePrecision)
+	}
+
+	result, err := expressions.EvaluateAmountExpression(c, template.AmountExpression, variables)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return int64(math.Round(result * math.Pow10(currencyPrecision))), nil
+}
+
+func (s *TransactionService) getScheduledTransactionAccount(c core.Context, uid int64, accountId int64) (*models.Account, error) {
+	account := &models.Account{}
+	has, err := s.UserDataDB(uid).NewSession(c).ID(accountId).Where("uid=? AND deleted=?", uid, false).Get(account)
+
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, errs.ErrAccountNotFound
+	}
+
+	return account, nil
+}
+
+func (s *TransactionService) getAccountMonthlyIncomeOrExpense(c core.Context, template *models.TransactionTemplate, transactionUnixTime int64, variableName string) (int64, error) {
+	templateTimeZone := time.FixedZone("Template Timezone", int(template.ScheduledTimezoneUtcOffset)*60)
+	transactionTime := time.Unix(transactionUnixTime, 0).In(templateTimeZone)
+	thisMonthFirstTime := time.Date(transactionTime.Year(), transactionTime.Month(), 1, 0, 0, 0, 0, templateTimeZone)
+
+	var startUnixTime, endUnixTime int64
+
+	if variableName == models.TRANSACTION_TEMPLATE_AMOUNT_EXPRESSION_VARIABLE_THIS_MONTH_INCOME || variableName == models.TRANSACTION_TEMPLATE_AMOUNT_EXPRESSION_VARIABLE_THIS_MONTH_EXPENSE {
+		startUnixTime = thisMonthFirstTime.Unix()
+		endUnixTime = transactionUnixTime - 1
+	} else {
+		startUnixTime = thisMonthFirstTime.AddDate(0, -1, 0).Unix()
+		endUnixTime = thisMonthFirstTime.Unix() - 1
+	}
+
+	if endUnixTime < startUnixTime {
+		return 0, nil
+	}
+
+	incomeAmounts, expenseAmounts, err := s.GetAccountsTotalIncomeAndExpense(c, template.Uid, startUnixTime, endUnixTime, template.ScheduledTimezoneUtcOffset, false)
+
+	if err != nil {
+		return 0, err
+	}
+
+	if variableName == models.TRANSACTION_TEMPLATE_AMOUNT_EXPRESSION_VARIABLE_THIS_MONTH_INCOME || variableName == models.TRANSACTION_TEMPLATE_AMOUNT_EXPRESSION_VARIABLE_LAST_MONTH_INCOME {
+		return incomeAmounts[template.AccountId], nil
+	}
+
+	return expenseAmounts[template.AccountId], nil
+}
+
+func (s *TransactionService) updateTemplateScheduledRunTime(c core.Context, template *models.TransactionTemplate, lastRunTime int64, nextRunTime int64) error {
+	template.ScheduledLastRunTime = lastRunTime
+	template.ScheduledNextRunTime = nextRunTime
+
+	_, err := s.UserDataDB(template.Uid).NewSession(c).ID(template.TemplateId).Cols("scheduled_last_run_time", "scheduled_next_run_time").Where("uid=? AND deleted=? AND scheduled_last_run_time<=?", template.Uid, false, lastRunTime).Update(template)
+
+	return err
+}
+
+func (s *TransactionService) buildTransactionQueryCondition(uid int64, maxTransactionTime int64, minTransactionTime int64, transactionType models.TransactionDbType, categoryIds []int64, accountIds []int64, tagIds []int64, amountFilter string, keyword string, geoFilter *models.TransactionGeoRadiusFilter, noDuplicated bool) (string, []any) {
 	condition := "uid=? AND deleted=?"
 	conditionParams := make([]any, 0, 16)
 	conditionParams = append(conditionParams, uid)
@@ -2021,9 +2652,41 @@ func (s *TransactionService) buildTransactionQueryCondition(uid int64, maxTransa
 		conditionParams = append(conditionParams, "%%"+keyword+"%%")
 	}
 
+	if geoFilter != nil {
+		minLatitude, maxLatitude, longitudeRanges := geoFilter.GetBoundingBox()
+		latitudeScale, longitudeScale := geoFilter.GetDistanceScales()
+
+		// the bounding box condition can use the geographic location index, and the distance condition excludes the corners of bounding box
+		condition = condition + " AND geo_latitude>=? AND geo_latitude<=? AND NOT (geo_longitude=? AND geo_latitude=?)"
+		conditionParams = append(conditionParams, minLatitude, maxLatitude, 0, 0)
+
+		// the bounding box crossing the antimeridian has two longitude ranges, and each range has its own center longitude to calculate distance
+		longitudeConditions := make([]string, len(longitudeRanges))
+
+		for i := 0; i < len(longitudeRanges); i++ {
+			longitudeRange := longitudeRanges[i]
+			longitudeConditions[i] = "(geo_longitude>=? AND geo_longitude<=? AND ((geo_latitude-?)*(geo_latitude-?)*?+(geo_longitude-?)*(geo_longitude-?)*?)<=?)"
+			conditionParams = append(conditionParams, longitudeRange.MinLongitude, longitudeRange.MaxLongitude)
+			conditionParams = append(conditionParams, geoFilter.CenterLatitude, geoFilter.CenterLatitude, latitudeScale*latitudeScale)
+			conditionParams = append(conditionParams, longitudeRange.CenterLongitude, longitudeRange.CenterLongitude, longitudeScale*longitudeScale)
+			conditionParams = append(conditionParams, geoFilter.Radius*geoFilter.Radius)
+		}
+
+		condition = condition + " AND (" + strings.Join(longitudeConditions, " OR ") + ")"
+	}
+
 	return condition, conditionParams
 }
 
+func (s *TransactionService) appendFilterAnomalyConditionToQuery(sess *xorm.Session, uid int64, anomalyOnly bool) *xorm.Session {
+	if !anomalyOnly {
+		return sess
+	}
+
+	subQuery := builder.Select("transaction_id").From("transaction_anomaly").Where(builder.And(builder.Eq{"uid": uid}, builder.Eq{"deleted": false}))
+	return sess.And(builder.In("transaction_id", subQuery))
+}
+
 func (s *TransactionService) appendFilterTagIdsConditionToQuery(sess *xorm.Session, uid int64, maxTransactionTime int64, minTransactionTime int64, tagIds []int64, noTags bool, tagFilterType models.TransactionTagFilterType) *xorm.Session {
 	subQueryCondition := builder.And(builder.Eq{"uid": uid}, builder.Eq{"deleted": false})
 
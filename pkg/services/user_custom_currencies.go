@@ -0,0 +1,213 @@
+package services
+
+import (
+	"time"
+
+	"xorm.io/xorm"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/datastore"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/uuid"
+)
+
+// UserCustomCurrencyService represents user defined currency service
+type UserCustomCurrencyService struct {
+	ServiceUsingDB
+	ServiceUsingUuid
+}
+
+// Initialize a user defined currency service singleton instance
+var (
+	UserCustomCurrencies = &UserCustomCurrencyService{
+		ServiceUsingDB: ServiceUsingDB{
+			container: datastore.Container,
+		},
+		ServiceUsingUuid: ServiceUsingUuid{
+			container: uuid.Container,
+		},
+	}
+)
+
+// GetAllCurrenciesByUid returns all user defined currency models of user
+func (s *UserCustomCurrencyService) GetAllCurrenciesByUid(c core.Context, uid int64) (models.UserCustomCurrencies, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	var currencies models.UserCustomCurrencies
+	err := s.UserDataDB(uid).NewSession(c).Where("uid=? AND deleted=?", uid, false).OrderBy("code asc").Find(&currencies)
+
+	return currencies, err
+}
+
+// GetCurrencyByCurrencyId returns a user defined currency model according to currency id
+func (s *UserCustomCurrencyService) GetCurrencyByCurrencyId(c core.Context, uid int64, currencyId int64) (*models.UserCustomCurrency, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	if currencyId <= 0 {
+		return nil, errs.ErrUserCustomCurrencyIdInvalid
+	}
+
+	currency := &models.UserCustomCurrency{}
+	has, err := s.UserDataDB(uid).NewSession(c).ID(currencyId).Where("uid=? AND deleted=?", uid, false).Get(currency)
+
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, errs.ErrUserCustomCurrencyNotFound
+	}
+
+	return currency, nil
+}
+
+// GetCurrencyByCode returns a user defined currency model according to currency code
+func (s *UserCustomCurrencyService) GetCurrencyByCode(c core.Context, uid int64, code string) (*models.UserCustomCurrency, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	currency := &models.UserCustomCurrency{}
+	has, err := s.UserDataDB(uid).NewSession(c).Where("uid=? AND deleted=? AND code=?", uid, false, code).Get(currency)
+
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, errs.ErrUserCustomCurrencyNotFound
+	}
+
+	return currency, nil
+}
+
+// CreateCurrency saves a new user defined currency model to database
+func (s *UserCustomCurrencyService) CreateCurrency(c core.Context, currency *models.UserCustomCurrency) error {
+	if currency.Uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	if currency.Precision < 0 || currency.Precision > models.MAX_CURRENCY_PRECISION {
+		return errs.ErrUserCustomCurrencyPrecisionInvalid
+	}
+
+	currency.CurrencyId = s.GenerateUuid(uuid.UUID_TYPE_CURRENCY)
+
+	if currency.CurrencyId < 1 {
+		return errs.ErrSystemIsBusy
+	}
+
+	currency.Deleted = false
+	currency.CreatedUnixTime = time.Now().Unix()
+	currency.UpdatedUnixTime = time.Now().Unix()
+
+	return s.UserDataDB(currency.Uid).DoTransaction(c, func(sess *xorm.Session) error {
+		exists, err := sess.Cols("currency_id").Where("uid=? AND deleted=? AND code=?", currency.Uid, false, currency.Code).Exist(&models.UserCustomCurrency{})
+
+		if err != nil {
+			return err
+		} else if exists {
+			return errs.ErrUserCustomCurrencyCodeAlreadyExists
+		}
+
+		_, err = sess.Insert(currency)
+		return err
+	})
+}
+
+// ModifyCurrency saves an existed user defined currency model to database, the code and precision cannot be modified
+func (s *UserCustomCurrencyService) ModifyCurrency(c core.Context, currency *models.UserCustomCurrency) error {
+	if currency.Uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	currency.UpdatedUnixTime = time.Now().Unix()
+
+	return s.UserDataDB(currency.Uid).DoTransaction(c, func(sess *xorm.Session) error {
+		updatedRows, err := sess.ID(currency.CurrencyId).Cols("name", "symbol", "updated_unix_time").Where("uid=? AND deleted=?", currency.Uid, false).Update(currency)
+
+		if err != nil {
+			return err
+		} else if updatedRows < 1 {
+			return errs.ErrUserCustomCurrencyNotFound
+		}
+
+		return err
+	})
+}
+
+// DeleteCurrency deletes an existed user defined currency from database
+func (s *UserCustomCurrencyService) DeleteCurrency(c core.Context, uid int64, currencyId int64) error {
+	if uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	now := time.Now().Unix()
+
+	updateModel := &models.UserCustomCurrency{
+		Deleted:         true,
+		DeletedUnixTime: now,
+	}
+
+	return s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
+		currency := &models.UserCustomCurrency{}
+		has, err := sess.ID(currencyId).Where("uid=? AND deleted=?", uid, false).Get(currency)
+
+		if err != nil {
+			return err
+		} else if !has {
+			return errs.ErrUserCustomCurrencyNotFound
+		}
+
+		exists, err := sess.Cols("account_id").Where("uid=? AND deleted=? AND currency=?", uid, false, currency.Code).Exist(&models.Account{})
+
+		if err != nil {
+			return err
+		} else if exists {
+			return errs.ErrUserCustomCurrencyInUseCannotBeDeleted
+		}
+
+		exists, err = sess.Cols("exchange_rate_id").Where("uid=? AND deleted=? AND (base_currency=? OR target_currency=?)", uid, false, currency.Code, currency.Code).Exist(&models.UserExchangeRate{})
+
+		if err != nil {
+			return err
+		} else if exists {
+			return errs.ErrUserCustomCurrencyInUseCannotBeDeleted
+		}
+
+		deletedRows, err := sess.ID(currencyId).Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=?", uid, false).Update(updateModel)
+
+		if err != nil {
+			return err
+		} else if deletedRows < 1 {
+			return errs.ErrUserCustomCurrencyNotFound
+		}
+
+		return err
+	})
+}
+
+// DeleteAllCurrencies deletes all existed user defined currencies from database
+func (s *UserCustomCurrencyService) DeleteAllCurrencies(c core.Context, uid int64) error {
+	if uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	now := time.Now().Unix()
+
+	updateModel := &models.UserCustomCurrency{
+		Deleted:         true,
+		DeletedUnixTime: now,
+	}
+
+	return s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
+		_, err := sess.Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=?", uid, false).Update(updateModel)
+
+		if err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,170 @@
+package services
+
+import (
+	"time"
+
+	"xorm.io/xorm"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/datastore"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/uuid"
+)
+
+// UserExchangeRateService represents user defined exchange rate service
+type UserExchangeRateService struct {
+	ServiceUsingDB
+	ServiceUsingUuid
+}
+
+// Initialize a user defined exchange rate service singleton instance
+var (
+	UserExchangeRates = &UserExchangeRateService{
+		ServiceUsingDB: ServiceUsingDB{
+			container: datastore.Container,
+		},
+		ServiceUsingUuid: ServiceUsingUuid{
+			container: uuid.Container,
+		},
+	}
+)
+
+// GetAllExchangeRatesByUid returns all user defined exchange rate models of user
+func (s *UserExchangeRateService) GetAllExchangeRatesByUid(c core.Context, uid int64) (models.UserExchangeRates, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	var exchangeRates models.UserExchangeRates
+	err := s.UserDataDB(uid).NewSession(c).Where("uid=? AND deleted=?", uid, false).OrderBy("start_date asc, exchange_rate_id asc").Find(&exchangeRates)
+
+	return exchangeRates, err
+}
+
+// GetExchangeRatesInDateRange returns all user defined exchange rate models of user which are effective in the date range (YYYYMMDD)
+func (s *UserExchangeRateService) GetExchangeRatesInDateRange(c core.Context, uid int64, startDate int32, endDate int32) (models.UserExchangeRates, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	var exchangeRates models.UserExchangeRates
+	err := s.UserDataDB(uid).NewSession(c).Where("uid=? AND deleted=? AND start_date<=? AND (end_date<=? OR end_date>=?)", uid, false, endDate, 0, startDate).OrderBy("start_date asc, exchange_rate_id asc").Find(&exchangeRates)
+
+	return exchangeRates, err
+}
+
+// GetExchangeRateByExchangeRateId returns a user defined exchange rate model according to exchange rate id
+func (s *UserExchangeRateService) GetExchangeRateByExchangeRateId(c core.Context, uid int64, exchangeRateId int64) (*models.UserExchangeRate, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	if exchangeRateId <= 0 {
+		return nil, errs.ErrUserExchangeRateIdInvalid
+	}
+
+	exchangeRate := &models.UserExchangeRate{}
+	has, err := s.UserDataDB(uid).NewSession(c).ID(exchangeRateId).Where("uid=? AND deleted=?", uid, false).Get(exchangeRate)
+
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, errs.ErrUserExchangeRateNotFound
+	}
+
+	return exchangeRate, nil
+}
+
+// CreateExchangeRate saves a new user defined exchange rate model to database
+func (s *UserExchangeRateService) CreateExchangeRate(c core.Context, exchangeRate *models.UserExchangeRate) error {
+	if exchangeRate.Uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	exchangeRate.ExchangeRateId = s.GenerateUuid(uuid.UUID_TYPE_RATE)
+
+	if exchangeRate.ExchangeRateId < 1 {
+		return errs.ErrSystemIsBusy
+	}
+
+	exchangeRate.Deleted = false
+	exchangeRate.CreatedUnixTime = time.Now().Unix()
+	exchangeRate.UpdatedUnixTime = time.Now().Unix()
+
+	return s.UserDataDB(exchangeRate.Uid).DoTransaction(c, func(sess *xorm.Session) error {
+		_, err := sess.Insert(exchangeRate)
+		return err
+	})
+}
+
+// ModifyExchangeRate saves an existed user defined exchange rate model to database
+func (s *UserExchangeRateService) ModifyExchangeRate(c core.Context, exchangeRate *models.UserExchangeRate) error {
+	if exchangeRate.Uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	exchangeRate.UpdatedUnixTime = time.Now().Unix()
+
+	return s.UserDataDB(exchangeRate.Uid).DoTransaction(c, func(sess *xorm.Session) error {
+		updatedRows, err := sess.ID(exchangeRate.ExchangeRateId).Cols("base_currency", "target_currency", "rate", "start_date", "end_date", "comment", "updated_unix_time").Where("uid=? AND deleted=?", exchangeRate.Uid, false).Update(exchangeRate)
+
+		if err != nil {
+			return err
+		} else if updatedRows < 1 {
+			return errs.ErrUserExchangeRateNotFound
+		}
+
+		return err
+	})
+}
+
+// DeleteExchangeRate deletes an existed user defined exchange rate from database
+func (s *UserExchangeRateService) DeleteExchangeRate(c core.Context, uid int64, exchangeRateId int64) error {
+	if uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	now := time.Now().Unix()
+
+	updateModel := &models.UserExchangeRate{
+		Deleted:         true,
+		DeletedUnixTime: now,
+	}
+
+	return s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
+		deletedRows, err := sess.ID(exchangeRateId).Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=?", uid, false).Update(updateModel)
+
+		if err != nil {
+			return err
+		} else if deletedRows < 1 {
+			return errs.ErrUserExchangeRateNotFound
+		}
+
+		return err
+	})
+}
+
+// DeleteAllExchangeRates deletes all existed user defined exchange rates from database
+func (s *UserExchangeRateService) DeleteAllExchangeRates(c core.Context, uid int64) error {
+	if uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	now := time.Now().Unix()
+
+	updateModel := &models.UserExchangeRate{
+		Deleted:         true,
+		DeletedUnixTime: now,
+	}
+
+	return s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
+		_, err := sess.Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=?", uid, false).Update(updateModel)
+
+		if err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,562 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"xorm.io/xorm"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/datastore"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/settings"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+	"github.com/mayswind/ezbookkeeping/pkg/uuid"
+)
+
+const pageCountForDeliverWebhookEvents = 100
+const webhookDeliveryLastErrorMaxLength = 255
+
+// WebhookService represents webhook service
+type WebhookService struct {
+	ServiceUsingDB
+	ServiceUsingConfig
+	ServiceUsingUuid
+}
+
+// Initialize a webhook service singleton instance
+var (
+	Webhooks = &WebhookService{
+		ServiceUsingDB: ServiceUsingDB{
+			container: datastore.Container,
+		},
+		ServiceUsingConfig: ServiceUsingConfig{
+			container: settings.Container,
+		},
+		ServiceUsingUuid: ServiceUsingUuid{
+			container: uuid.Container,
+		},
+	}
+)
+
+// GetAllWebhooksByUid returns all webhook models of user
+func (s *WebhookService) GetAllWebhooksByUid(c core.Context, uid int64) ([]*models.Webhook, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	var webhooks []*models.Webhook
+	err := s.UserDataDB(uid).NewSession(c).Where("uid=? AND deleted=?", uid, false).OrderBy("webhook_id asc").Find(&webhooks)
+
+	return webhooks, err
+}
+
+// GetWebhookByWebhookId returns a webhook model according to webhook id
+func (s *WebhookService) GetWebhookByWebhookId(c core.Context, uid int64, webhookId int64) (*models.Webhook, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	if webhookId <= 0 {
+		return nil, errs.ErrWebhookIdInvalid
+	}
+
+	webhook := &models.Webhook{}
+	has, err := s.UserDataDB(uid).NewSession(c).ID(webhookId).Where("uid=? AND deleted=?", uid, false).Get(webhook)
+
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, errs.ErrWebhookNotFound
+	}
+
+	return webhook, nil
+}
+
+// GetDeliveriesByWebhookId returns the webhook delivery models of specified webhook in reverse chronological order
+func (s *WebhookService) GetDeliveriesByWebhookId(c core.Context, uid int64, webhookId int64, page int32, count int32) ([]*models.WebhookDelivery, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	if webhookId <= 0 {
+		return nil, errs.ErrWebhookIdInvalid
+	}
+
+	if page < 1 {
+		page = 1
+	}
+
+	var deliveries []*models.WebhookDelivery
+	err := s.UserDataDB(uid).NewSession(c).Where("uid=? AND webhook_id=?", uid, webhookId).OrderBy("created_unix_time desc, delivery_id desc").Limit(int(count), int(count*(page-1))).Find(&deliveries)
+
+	return deliveries, err
+}
+
+// CreateWebhook saves a new webhook model to database, the secret is encrypted before saving
+func (s *WebhookService) CreateWebhook(c core.Context, webhook *models.Webhook, secret string) error {
+	if webhook.Uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	encryptedSecret, err := utils.EncryptSecret(secret, s.CurrentConfig().SecretKey)
+
+	if err != nil {
+		return err
+	}
+
+	webhook.WebhookId = s.GenerateUuid(uuid.UUID_TYPE_WEBHOOK)
+
+	if webhook.WebhookId < 1 {
+		return errs.ErrSystemIsBusy
+	}
+
+	webhook.Secret = encryptedSecret
+	webhook.Deleted = false
+	webhook.CreatedUnixTime = time.Now().Unix()
+	webhook.UpdatedUnixTime = time.Now().Unix()
+
+	return s.UserDataDB(webhook.Uid).DoTransaction(c, func(sess *xorm.Session) error {
+		_, err := sess.Insert(webhook)
+		return err
+	})
+}
+
+// ModifyWebhook saves an existed webhook model to database, the secret is replaced only when the new secret is not empty
+func (s *WebhookService) ModifyWebhook(c core.Context, webhook *models.Webhook, newSecret string) error {
+	if webhook.Uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	updateCols := []string{"name", "url", "event_types", "disabled", "updated_unix_time"}
+
+	if newSecret != "" {
+		encryptedSecret, err := utils.EncryptSecret(newSecret, s.CurrentConfig().SecretKey)
+
+		if err != nil {
+			return err
+		}
+
+		webhook.Secret = encryptedSecret
+		updateCols = append(updateCols, "secret")
+	}
+
+	webhook.UpdatedUnixTime = time.Now().Unix()
+
+	return s.UserDataDB(webhook.Uid).DoTransaction(c, func(sess *xorm.Session) error {
+		updatedRows, err := sess.ID(webhook.WebhookId).Cols(updateCols...).Where("uid=? AND deleted=?", webhook.Uid, false).Update(webhook)
+
+		if err != nil {
+			return err
+		} else if updatedRows < 1 {
+			return errs.ErrWebhookNotFound
+		}
+
+		return err
+	})
+}
+
+// DeleteWebhook deletes an existed webhook from database, and cancels all its pending deliveries
+func (s *WebhookService) DeleteWebhook(c core.Context, uid int64, webhookId int64) error {
+	if uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	now := time.Now().Unix()
+
+	updateModel := &models.Webhook{
+		Deleted:         true,
+		DeletedUnixTime: now,
+	}
+
+	deliveryUpdateModel := &models.WebhookDelivery{
+		Status:          models.WEBHOOK_DELIVERY_STATUS_FAILED,
+		LastError:       "webhook has been deleted",
+		UpdatedUnixTime: now,
+	}
+
+	return s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
+		deletedRows, err := sess.ID(webhookId).Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=?", uid, false).Update(updateModel)
+
+		if err != nil {
+			return err
+		} else if deletedRows < 1 {
+			return errs.ErrWebhookNotFound
+		}
+
+		_, err = sess.Cols("status", "last_error", "updated_unix_time").Where("uid=? AND webhook_id=? AND status=?", uid, webhookId, models.WEBHOOK_DELIVERY_STATUS_PENDING).Update(deliveryUpdateModel)
+
+		return err
+	})
+}
+
+// DeleteAllWebhooks deletes all existed webhooks of user from database, and cancels all their pending deliveries
+func (s *WebhookService) DeleteAllWebhooks(c core.Context, uid int64) error {
+	if uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	now := time.Now().Unix()
+
+	updateModel := &models.Webhook{
+		Deleted:         true,
+		DeletedUnixTime: now,
+	}
+
+	deliveryUpdateModel := &models.WebhookDelivery{
+		Status:          models.WEBHOOK_DELIVERY_STATUS_FAILED,
+		LastError:       "webhook has been deleted",
+		UpdatedUnixTime: now,
+	}
+
+	return s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
+		_, err := sess.Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=?", uid, false).Update(updateModel)
+
+		if err != nil {
+			return err
+		}
+
+		_, err = sess.Cols("status", "last_error", "updated_unix_time").Where("uid=? AND status=?", uid, models.WEBHOOK_DELIVERY_STATUS_PENDING).Update(deliveryUpdateModel)
+
+		return err
+	})
+}
+
+// EnqueueTransactionEvent adds the transaction event of specified transaction to the delivery queue of all subscribed webhooks
+func (s *WebhookService) EnqueueTransactionEvent(c core.Context, eventType models.WebhookEventType, transaction *models.Transaction) {
+	if !s.CurrentConfig().EnableWebhook || transaction == nil {
+		return
+	}
+
+	var tagIndexes []*models.TransactionTagIndex
+	tagIds := make([]int64, 0)
+
+	if eventType != models.WEBHOOK_EVENT_TYPE_TRANSACTION_DELETED {
+		err := s.UserDataDB(transaction.Uid).NewSession(c).Where("uid=? AND deleted=? AND transaction_id=?", transaction.Uid, false, transaction.TransactionId).OrderBy("tag_index_id asc").Find(&tagIndexes)
+
+		if err != nil {
+			log.Errorf(c, "[webhooks.EnqueueTransactionEvent] failed to get tag ids of transaction \"id:%d\" for user \"uid:%d\", because %s", transaction.TransactionId, transaction.Uid, err.Error())
+			return
+		}
+
+		for i := 0; i < len(tagIndexes); i++ {
+			tagIds = append(tagIds, tagIndexes[i].TagId)
+		}
+	}
+
+	s.enqueueEvent(c, transaction.Uid, eventType, &models.WebhookTransactionEventData{
+		Transaction: transaction.ToTransactionInfoResponse(tagIds, false),
+	})
+}
+
+// EnqueueAccountBalanceChangedEvents adds the account balance changed events of specified accounts to the delivery queue of all subscribed webhooks
+func (s *WebhookService) EnqueueAccountBalanceChangedEvents(c core.Context, uid int64, accountIds []int64) {
+	if !s.CurrentConfig().EnableWebhook || len(accountIds) < 1 {
+		return
+	}
+
+	var accounts []*models.Account
+	err := s.UserDataDB(uid).NewSession(c).Where("uid=? AND deleted=?", uid, false).In("account_id", utils.ToUniqueInt64Slice(accountIds)).OrderBy("account_id asc").Find(&accounts)
+
+	if err != nil {
+		log.Errorf(c, "[webhooks.EnqueueAccountBalanceChangedEvents] failed to get accounts for user \"uid:%d\", because %s", uid, err.Error())
+		return
+	}
+
+	for i := 0; i < len(accounts); i++ {
+		account := accounts[i]
+
+		s.enqueueEvent(c, uid, models.WEBHOOK_EVENT_TYPE_ACCOUNT_BALANCE_CHANGED, &models.WebhookAccountBalanceChangedEventData{
+			AccountId: account.AccountId,
+			Currency:  account.Currency,
+			Balance:   account.Balance,
+		})
+	}
+}
+
+// EnqueueImportCompletedEvent adds the import completed event to the delivery queue of all subscribed webhooks
+func (s *WebhookService) EnqueueImportCompletedEvent(c core.Context, uid int64, transactionCount int) {
+	if !s.CurrentConfig().EnableWebhook {
+		return
+	}
+
+	s.enqueueEvent(c, uid, models.WEBHOOK_EVENT_TYPE_IMPORT_COMPLETED, &models.WebhookImportCompletedEventData{
+		TransactionCount: transactionCount,
+	})
+}
+
+// DeliverPendingWebhookEvents sends all the pending webhook deliveries which reach the next attempt time
+func (s *WebhookService) DeliverPendingWebhookEvents(c core.Context, currentUnixTime int64) error {
+	if !s.CurrentConfig().EnableWebhook {
+		return errs.ErrWebhookNotEnabled
+	}
+
+	client := s.getHttpClient()
+
+	for i := 0; i < s.UserDataDBCount(); i++ {
+		database := s.UserDataDBByIndex(i)
+
+		var deliveries []*models.WebhookDelivery
+		err := database.NewSession(c).Where("status=? AND next_attempt_unix_time<=?", models.WEBHOOK_DELIVERY_STATUS_PENDING, currentUnixTime).OrderBy("next_attempt_unix_time asc, delivery_id asc").Limit(pageCountForDeliverWebhookEvents).Find(&deliveries)
+
+		if err != nil {
+			return err
+		}
+
+		webhooks := make(map[int64]*models.Webhook)
+
+		for j := 0; j < len(deliveries); j++ {
+			delivery := deliveries[j]
+			webhook, exists := webhooks[delivery.WebhookId]
+
+			if !exists {
+				webhook = &models.Webhook{}
+				has, err := database.NewSession(c).ID(delivery.WebhookId).Where("uid=? AND deleted=?", delivery.Uid, false).Get(webhook)
+
+				if err != nil {
+					return err
+				} else if !has {
+					webhook = nil
+				}
+
+				webhooks[delivery.WebhookId] = webhook
+			}
+
+			if webhook == nil || webhook.Disabled {
+				delivery.Status = models.WEBHOOK_DELIVERY_STATUS_FAILED
+				delivery.LastError = "webhook has been deleted or disabled"
+			} else {
+				statusCode, err := s.deliver(c, client, webhook, delivery)
+
+				delivery.AttemptCount++
+				delivery.LastAttemptUnixTime = time.Now().Unix()
+				delivery.LastResponseStatusCode = int32(statusCode)
+
+				if err == nil {
+					delivery.Status = models.WEBHOOK_DELIVERY_STATUS_SUCCEEDED
+					delivery.LastError = ""
+				} else {
+					log.Warnf(c, "[webhooks.DeliverPendingWebhookEvents] failed to deliver webhook event \"id:%d\" to webhook \"id:%d\" for user \"uid:%d\", because %s", delivery.DeliveryId, delivery.WebhookId, delivery.Uid, err.Error())
+					delivery.LastError = utils.SubString(err.Error(), 0, webhookDeliveryLastErrorMaxLength)
+
+					if uint32(delivery.AttemptCount) > s.CurrentConfig().WebhookMaxRetryCount {
+						delivery.Status = models.WEBHOOK_DELIVERY_STATUS_FAILED
+					} else {
+						delivery.NextAttemptUnixTime = delivery.GetNextRetryUnixTime(delivery.LastAttemptUnixTime)
+					}
+				}
+			}
+
+			delivery.UpdatedUnixTime = time.Now().Unix()
+			_, err := database.NewSession(c).ID(delivery.DeliveryId).Cols("status", "attempt_count", "next_attempt_unix_time", "last_attempt_unix_time", "last_response_status_code", "last_error", "updated_unix_time").Where("uid=?", delivery.Uid).Update(delivery)
+
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *WebhookService) enqueueEvent(c core.Context, uid int64, eventType models.WebhookEventType, data any) {
+	var webhooks []*models.Webhook
+	err := s.UserDataDB(uid).NewSession(c).Where("uid=? AND deleted=? AND disabled=?", uid, false, false).Find(&webhooks)
+
+	if err != nil {
+		log.Errorf(c, "[webhooks.enqueueEvent] failed to get webhooks for user \"uid:%d\", because %s", uid, err.Error())
+		return
+	}
+
+	subscribedWebhooks := make([]*models.Webhook, 0, len(webhooks))
+
+	for i := 0; i < len(webhooks); i++ {
+		if webhooks[i].IsEventTypeSubscribed(eventType) {
+			subscribedWebhooks = append(subscribedWebhooks, webhooks[i])
+		}
+	}
+
+	if len(subscribedWebhooks) < 1 {
+		return
+	}
+
+	now := time.Now().Unix()
+	payload, err := json.Marshal(&models.WebhookEventPayload{
+		Event:     eventType.String(),
+		Uid:       uid,
+		Timestamp: now,
+		Data:      data,
+	})
+
+	if err != nil {
+		log.Errorf(c, "[webhooks.enqueueEvent] failed to serialize event \"%s\" for user \"uid:%d\", because %s", eventType, uid, err.Error())
+		return
+	}
+
+	deliveryIds := s.GenerateUuids(uuid.UUID_TYPE_DELIVERY, uint16(len(subscribedWebhooks)))
+
+	if len(deliveryIds) < len(subscribedWebhooks) {
+		log.Errorf(c, "[webhooks.enqueueEvent] failed to generate delivery ids for event \"%s\" for user \"uid:%d\"", eventType, uid)
+		return
+	}
+
+	deliveries := make([]*models.WebhookDelivery, len(subscribedWebhooks))
+
+	for i := 0; i < len(subscribedWebhooks); i++ {
+		deliveries[i] = &models.WebhookDelivery{
+			DeliveryId:          deliveryIds[i],
+			Uid:                 uid,
+			WebhookId:           subscribedWebhooks[i].WebhookId,
+			EventType:           eventType,
+			Payload:             string(payload),
+			Status:              models.WEBHOOK_DELIVERY_STATUS_PENDING,
+			NextAttemptUnixTime: now,
+			CreatedUnixTime:     now,
+			UpdatedUnixTime:     now,
+		}
+	}
+
+	err = s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
+		_, err := sess.Insert(deliveries)
+		return err
+	})
+
+	if err != nil {
+		log.Errorf(c, "[webhooks.enqueueEvent] failed to save deliveries of event \"%s\" for user \"uid:%d\", because %s", eventType, uid, err.Error())
+	}
+}
+
+func (s *WebhookService) deliver(c core.Context, client *http.Client, webhook *models.Webhook, delivery *models.WebhookDelivery) (int, error) {
+	secret, err := utils.DecryptSecret(webhook.Secret, s.CurrentConfig().SecretKey)
+
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := utils.Int64ToString(time.Now().Unix())
+	signature := utils.HmacSHA256EncodeToString([]byte(secret), []byte(timestamp+"."+delivery.Payload))
+
+	req, err := http.NewRequest("POST", webhook.Url, bytes.NewReader([]byte(delivery.Payload)))
+
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", fmt.Sprintf("ezBookkeeping/%s", settings.Version))
+	req.Header.Set("X-Ezbookkeeping-Event", delivery.EventType.String())
+	req.Header.Set("X-Ezbookkeeping-Delivery", utils.Int64ToString(delivery.DeliveryId))
+	req.Header.Set("X-Ezbookkeeping-Timestamp", timestamp)
+	req.Header.Set("X-Ezbookkeeping-Signature", "sha256="+signature)
+
+	resp, err := client.Do(req)
+
+	if err != nil {
+		return 0, err
+	}
+
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected response status \"%s\"", strings.TrimSpace(resp.Status))
+	}
+
+	return resp.StatusCode, nil
+}
+
+func (s *WebhookService) getHttpClient() *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	utils.SetProxyUrl(transport, s.CurrentConfig().WebhookProxy)
+
+	if s.CurrentConfig().WebhookSkipTLSVerify {
+		transport.TLSClientConfig = &tls.Config{
+			InsecureSkipVerify: true,
+		}
+	}
+
+	if !s.CurrentConfig().WebhookAllowPrivateNetwork {
+		s.disallowPrivateNetworkConnection(transport)
+	}
+
+	return &http.Client{
+		Transport: transport,
+		Timeout:   time.Duration(s.CurrentConfig().WebhookRequestTimeout) * time.Millisecond,
+	}
+}
+
+// disallowPrivateNetworkConnection makes the transport resolve the target host before connecting and refuse to connect to the private network address,
+// the resolved address is connected directly so that the host cannot be resolved to another address again, and the connection to proxy server is not restricted
+func (s *WebhookService) disallowPrivateNetworkConnection(transport *http.Transport) {
+	proxyAddresses := &sync.Map{}
+	proxy := transport.Proxy
+
+	if proxy != nil {
+		transport.Proxy = func(req *http.Request) (*url.URL, error) {
+			proxyUrl, err := proxy(req)
+
+			if err == nil && proxyUrl != nil {
+				proxyAddresses.Store(proxyUrl.Host, true)
+
+				if proxyUrl.Port() == "" {
+					if proxyUrl.Scheme == "https" {
+						proxyAddresses.Store(net.JoinHostPort(proxyUrl.Hostname(), "443"), true)
+					} else if proxyUrl.Scheme == "socks5" || proxyUrl.Scheme == "socks5h" {
+						proxyAddresses.Store(net.JoinHostPort(proxyUrl.Hostname(), "1080"), true)
+					} else {
+						proxyAddresses.Store(net.JoinHostPort(proxyUrl.Hostname(), "80"), true)
+					}
+				}
+			}
+
+			return proxyUrl, err
+		}
+	}
+
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}
+
+	transport.DialContext = func(ctx context.Context, network string, address string) (net.Conn, error) {
+		if _, exists := proxyAddresses.Load(address); exists {
+			return dialer.DialContext(ctx, network, address)
+		}
+
+		host, port, err := net.SplitHostPort(address)
+
+		if err != nil {
+			return nil, err
+		}
+
+		ipAddrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if len(ipAddrs) < 1 {
+			return nil, fmt.Errorf("no address found for host \"%s\"", host)
+		}
+
+		for i := 0; i < len(ipAddrs); i++ {
+			if utils.IsPrivateNetworkIP(ipAddrs[i].IP) {
+				return nil, fmt.Errorf("connecting to private network address \"%s\" of host \"%s\" is not allowed", ipAddrs[i].IP.String(), host)
+			}
+		}
+
+		return dialer.DialContext(ctx, network, net.JoinHostPort(ipAddrs[0].IP.String(), port))
+	}
+}
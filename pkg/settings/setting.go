@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -107,14 +108,20 @@ const (
 	EuroCentralBankDataSource           string = "euro_central_bank"
 	NationalBankOfGeorgiaDataSource     string = "national_bank_of_georgia"
 	CentralBankOfHungaryDataSource      string = "central_bank_of_hungary"
+	ReserveBankOfIndiaDataSource        string = "reserve_bank_of_india"
 	BankOfIsraelDataSource              string = "bank_of_israel"
+	BankOfJapanDataSource               string = "bank_of_japan"
 	CentralBankOfMyanmarDataSource      string = "central_bank_of_myanmar"
 	NorgesBankDataSource                string = "norges_bank"
 	NationalBankOfPolandDataSource      string = "national_bank_of_poland"
 	NationalBankOfRomaniaDataSource     string = "national_bank_of_romania"
 	BankOfRussiaDataSource              string = "bank_of_russia"
 	SwissNationalBankDataSource         string = "swiss_national_bank"
+	BankOfThailandDataSource            string = "bank_of_thailand"
+	CentralBankOfTurkeyDataSource       string = "central_bank_of_turkey"
 	NationalBankOfUkraineDataSource     string = "national_bank_of_ukraine"
+	BankOfEnglandDataSource             string = "bank_of_england"
+	FederalReserveDataSource            string = "federal_reserve"
 	CentralBankOfUzbekistanDataSource   string = "central_bank_of_uzbekistan"
 	InternationalMonetaryFundDataSource string = "international_monetary_fund"
 )
@@ -154,6 +161,12 @@ const (
 	defaultImportFileMaxSize uint32 = 10485760 // 10MB
 
 	defaultExchangeRatesDataRequestTimeout uint32 = 10000 // 10 seconds
+	defaultExchangeRatesCacheTTL           uint32 = 1800  // 30 minutes
+
+	defaultWebhookRequestTimeout uint32 = 10000 // 10 seconds
+	defaultWebhookMaxRetryCount  uint32 = 8
+
+	defaultAnomalyDuplicateTimeWindow uint32 = 120 // 2 hours
 )
 
 // DatabaseConfig represents the database setting config
@@ -275,6 +288,10 @@ type Config struct {
 	// Cron
 	EnableRemoveExpiredTokens        bool
 	EnableCreateScheduledTransaction bool
+	EnableSendBillReminder           bool
+	EnableSendAnomalyAlert           bool
+	EnableSendScheduledReport        bool
+	EnableSaveExchangeRatesHistory   bool
 
 	// Secret
 	SecretKeyNoSet                        bool
@@ -339,10 +356,30 @@ type Config struct {
 
 	// Exchange Rates
 	ExchangeRatesDataSource                       string
+	ExchangeRatesDataSources                      []string
 	ExchangeRatesRequestTimeout                   uint32
 	ExchangeRatesRequestTimeoutExceedDefaultValue bool
 	ExchangeRatesProxy                            string
 	ExchangeRatesSkipTLSVerify                    bool
+	ExchangeRatesCacheTTL                         uint32
+	ExchangeRatesCacheTTLDuration                 time.Duration
+
+	// Webhook
+	EnableWebhook              bool
+	WebhookRequestTimeout      uint32
+	WebhookProxy               string
+	WebhookSkipTLSVerify       bool
+	WebhookMaxRetryCount       uint32
+	WebhookAllowPrivateNetwork bool
+
+	// Anomaly Detection
+	EnableAnomalyDetection             bool
+	AnomalyDuplicateTimeWindow         uint32
+	AnomalyDuplicateTimeWindowDuration time.Duration
+
+	// Report
+	PdfFontPath     string
+	PdfBoldFontPath string
 }
 
 // LoadConfiguration loads setting config from given config file path
@@ -458,6 +495,24 @@ func LoadConfiguration(configFilePath string) (*Config, error) {
 		return nil, err
 	}
 
+	err = loadWebhookConfiguration(config, cfgFile, "webhook")
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = loadAnomalyDetectionConfiguration(config, cfgFile, "anomaly_detection")
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = loadReportConfiguration(config, cfgFile, "report")
+
+	if err != nil {
+		return nil, err
+	}
+
 	return config, nil
 }
 
@@ -726,6 +781,10 @@ func loadDuplicateCheckerConfiguration(config *Config, configFile *ini.File, sec
 func loadCronConfiguration(config *Config, configFile *ini.File, sectionName string) error {
 	config.EnableRemoveExpiredTokens = getConfigItemBoolValue(configFile, sectionName, "enable_remove_expired_tokens", false)
 	config.EnableCreateScheduledTransaction = getConfigItemBoolValue(configFile, sectionName, "enable_create_scheduled_transaction", false)
+	config.EnableSendBillReminder = getConfigItemBoolValue(configFile, sectionName, "enable_send_bill_reminder", false)
+	config.EnableSendAnomalyAlert = getConfigItemBoolValue(configFile, sectionName, "enable_send_anomaly_alert", false)
+	config.EnableSendScheduledReport = getConfigItemBoolValue(configFile, sectionName, "enable_send_scheduled_report", false)
+	config.EnableSaveExchangeRatesHistory = getConfigItemBoolValue(configFile, sectionName, "enable_save_exchange_rates_history", false)
 
 	return nil
 }
@@ -894,38 +953,107 @@ func loadMapConfiguration(config *Config, configFile *ini.File, sectionName stri
 	return nil
 }
 func loadExchangeRatesConfiguration(config *Config, configFile *ini.File, sectionName string) error {
-	dataSource := getConfigItemStringValue(configFile, sectionName, "data_source")
+	dataSources := strings.Split(getConfigItemStringValue(configFile, sectionName, "data_source"), ",")
+	config.ExchangeRatesDataSources = make([]string, 0, len(dataSources))
+
+	for i := 0; i < len(dataSources); i++ {
+		dataSource := strings.TrimSpace(dataSources[i])
+
+		if !isValidExchangeRatesDataSource(dataSource) {
+			return errs.ErrInvalidExchangeRatesDataSource
+		}
+
+		if slices.Contains(config.ExchangeRatesDataSources, dataSource) {
+			continue
+		}
+
+		config.ExchangeRatesDataSources = append(config.ExchangeRatesDataSources, dataSource)
+	}
+
+	config.ExchangeRatesDataSource = config.ExchangeRatesDataSources[0]
+	config.ExchangeRatesProxy = getConfigItemStringValue(configFile, sectionName, "proxy", "system")
+	config.ExchangeRatesRequestTimeout = getConfigItemUint32Value(configFile, sectionName, "request_timeout", defaultExchangeRatesDataRequestTimeout)
+
+	if config.ExchangeRatesRequestTimeout > defaultExchangeRatesDataRequestTimeout {
+		config.ExchangeRatesRequestTimeoutExceedDefaultValue = true
+	}
+
+	config.ExchangeRatesSkipTLSVerify = getConfigItemBoolValue(configFile, sectionName, "skip_tls_verify", false)
+	config.ExchangeRatesCacheTTL = getConfigItemUint32Value(configFile, sectionName, "cache_ttl", defaultExchangeRatesCacheTTL)
+	config.ExchangeRatesCacheTTLDuration = time.Duration(config.ExchangeRatesCacheTTL) * time.Second
+
+	return nil
+}
 
-	if dataSource == ReserveBankOfAustraliaDataSource ||
+func isValidExchangeRatesDataSource(dataSource string) bool {
+	return dataSource == ReserveBankOfAustraliaDataSource ||
 		dataSource == BankOfCanadaDataSource ||
 		dataSource == CzechNationalBankDataSource ||
 		dataSource == DanmarksNationalbankDataSource ||
 		dataSource == EuroCentralBankDataSource ||
 		dataSource == NationalBankOfGeorgiaDataSource ||
 		dataSource == CentralBankOfHungaryDataSource ||
+		dataSource == ReserveBankOfIndiaDataSource ||
 		dataSource == BankOfIsraelDataSource ||
+		dataSource == BankOfJapanDataSource ||
 		dataSource == CentralBankOfMyanmarDataSource ||
 		dataSource == NorgesBankDataSource ||
 		dataSource == NationalBankOfPolandDataSource ||
 		dataSource == NationalBankOfRomaniaDataSource ||
 		dataSource == BankOfRussiaDataSource ||
 		dataSource == SwissNationalBankDataSource ||
+		dataSource == BankOfThailandDataSource ||
+		dataSource == CentralBankOfTurkeyDataSource ||
 		dataSource == NationalBankOfUkraineDataSource ||
+		dataSource == BankOfEnglandDataSource ||
+		dataSource == FederalReserveDataSource ||
 		dataSource == CentralBankOfUzbekistanDataSource ||
-		dataSource == InternationalMonetaryFundDataSource {
-		config.ExchangeRatesDataSource = dataSource
-	} else {
-		return errs.ErrInvalidExchangeRatesDataSource
-	}
+		dataSource == InternationalMonetaryFundDataSource
+}
 
-	config.ExchangeRatesProxy = getConfigItemStringValue(configFile, sectionName, "proxy", "system")
-	config.ExchangeRatesRequestTimeout = getConfigItemUint32Value(configFile, sectionName, "request_timeout", defaultExchangeRatesDataRequestTimeout)
+func loadWebhookConfiguration(config *Config, configFile *ini.File, sectionName string) error {
+	config.EnableWebhook = getConfigItemBoolValue(configFile, sectionName, "enable_webhook", false)
+	config.WebhookRequestTimeout = getConfigItemUint32Value(configFile, sectionName, "request_timeout", defaultWebhookRequestTimeout)
+	config.WebhookProxy = getConfigItemStringValue(configFile, sectionName, "proxy", "system")
+	config.WebhookSkipTLSVerify = getConfigItemBoolValue(configFile, sectionName, "skip_tls_verify", false)
+	config.WebhookMaxRetryCount = getConfigItemUint32Value(configFile, sectionName, "max_retry_count", defaultWebhookMaxRetryCount)
+	config.WebhookAllowPrivateNetwork = getConfigItemBoolValue(configFile, sectionName, "allow_private_network", false)
 
-	if config.ExchangeRatesRequestTimeout > defaultExchangeRatesDataRequestTimeout {
-		config.ExchangeRatesRequestTimeoutExceedDefaultValue = true
+	return nil
+}
+
+func loadAnomalyDetectionConfiguration(config *Config, configFile *ini.File, sectionName string) error {
+	config.EnableAnomalyDetection = getConfigItemBoolValue(configFile, sectionName, "enable_anomaly_detection", true)
+	config.AnomalyDuplicateTimeWindow = getConfigItemUint32Value(configFile, sectionName, "duplicate_time_window", defaultAnomalyDuplicateTimeWindow)
+	config.AnomalyDuplicateTimeWindowDuration = time.Duration(config.AnomalyDuplicateTimeWindow) * time.Minute
+
+	return nil
+}
+
+func loadReportConfiguration(config *Config, configFile *ini.File, sectionName string) error {
+	pdfFontPath := getConfigItemStringValue(configFile, sectionName, "pdf_font_path")
+
+	if pdfFontPath != "" {
+		finalPdfFontPath, err := getFinalPath(config.WorkingPath, pdfFontPath)
+
+		if err != nil {
+			return errs.ErrInvalidPdfFontPath
+		}
+
+		config.PdfFontPath = finalPdfFontPath
 	}
 
-	config.ExchangeRatesSkipTLSVerify = getConfigItemBoolValue(configFile, sectionName, "skip_tls_verify", false)
+	pdfBoldFontPath := getConfigItemStringValue(configFile, sectionName, "pdf_bold_font_path")
+
+	if pdfBoldFontPath != "" {
+		finalPdfBoldFontPath, err := getFinalPath(config.WorkingPath, pdfBoldFontPath)
+
+		if err != nil {
+			return errs.ErrInvalidPdfFontPath
+		}
+
+		config.PdfBoldFontPath = finalPdfBoldFontPath
+	}
 
 	return nil
 }
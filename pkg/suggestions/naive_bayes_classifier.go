@@ -0,0 +1,112 @@
+package suggestions
+
+import (
+	"math"
+	"sort"
+)
+
+// ClassifierPrediction represents a predicted label and its probability
+type ClassifierPrediction struct {
+	Label       int64
+	Probability float64
+}
+
+// NaiveBayesClassifier represents a multinomial naive bayes classifier with laplace smoothing
+type NaiveBayesClassifier struct {
+	labelDocumentCounts map[int64]int
+	labelFeatureCounts  map[int64]map[string]int
+	labelTotalFeatures  map[int64]int
+	vocabulary          map[string]bool
+	totalDocumentCount  int
+}
+
+// NewNaiveBayesClassifier returns a new empty naive bayes classifier
+func NewNaiveBayesClassifier() *NaiveBayesClassifier {
+	return &NaiveBayesClassifier{
+		labelDocumentCounts: make(map[int64]int),
+		labelFeatureCounts:  make(map[int64]map[string]int),
+		labelTotalFeatures:  make(map[int64]int),
+		vocabulary:          make(map[string]bool),
+	}
+}
+
+// Train adds a sample with the specified features and label to the classifier
+func (c *NaiveBayesClassifier) Train(features []string, label int64) {
+	featureCounts, exists := c.labelFeatureCounts[label]
+
+	if !exists {
+		featureCounts = make(map[string]int)
+		c.labelFeatureCounts[label] = featureCounts
+	}
+
+	for i := 0; i < len(features); i++ {
+		featureCounts[features[i]]++
+		c.vocabulary[features[i]] = true
+	}
+
+	c.labelDocumentCounts[label]++
+	c.labelTotalFeatures[label] += len(features)
+	c.totalDocumentCount++
+}
+
+// SampleCount returns the count of samples which have been trained
+func (c *NaiveBayesClassifier) SampleCount() int {
+	return c.totalDocumentCount
+}
+
+// Predict returns all the labels ordered by probability in descending order for the specified features
+func (c *NaiveBayesClassifier) Predict(features []string) []*ClassifierPrediction {
+	if c.totalDocumentCount < 1 {
+		return nil
+	}
+
+	vocabularySize := float64(len(c.vocabulary))
+	logScores := make(map[int64]float64, len(c.labelDocumentCounts))
+	maxLogScore := math.Inf(-1)
+
+	for label, documentCount := range c.labelDocumentCounts {
+		featureCounts := c.labelFeatureCounts[label]
+		totalFeatures := float64(c.labelTotalFeatures[label])
+		logScore := math.Log(float64(documentCount) / float64(c.totalDocumentCount))
+
+		for i := 0; i < len(features); i++ {
+			if !c.vocabulary[features[i]] {
+				continue
+			}
+
+			logScore += math.Log((float64(featureCounts[features[i]]) + 1) / (totalFeatures + vocabularySize))
+		}
+
+		logScores[label] = logScore
+
+		if logScore > maxLogScore {
+			maxLogScore = logScore
+		}
+	}
+
+	totalScore := float64(0)
+	predictions := make([]*ClassifierPrediction, 0, len(logScores))
+
+	for label, logScore := range logScores {
+		score := math.Exp(logScore - maxLogScore)
+		totalScore += score
+		predictions = append(predictions, &ClassifierPrediction{
+			Label:       label,
+			Probability: score,
+		})
+	}
+
+	for i := 0; i < len(predictions); i++ {
+		predictions[i].Probability = predictions[i].Probability / totalScore
+	}
+
+	sort.Slice(predictions, func(i, j int) bool {
+		if predictions[i].Probability != predictions[j].Probability {
+			return predictions[i].Probability > predictions[j].Probability
+		}
+
+		return predictions[i].Label < predictions[j].Label
+	})
+
+	return predictions
+}
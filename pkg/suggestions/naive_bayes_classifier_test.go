@@ -0,0 +1,43 @@
+package suggestions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNaiveBayesClassifierPredict(t *testing.T) {
+	classifier := NewNaiveBayesClassifier()
+	classifier.Train([]string{"comment:netflix", "amount:4"}, 1)
+	classifier.Train([]string{"comment:netflix", "comment:monthly", "amount:4"}, 1)
+	classifier.Train([]string{"comment:supermarket", "amount:6"}, 2)
+	classifier.Train([]string{"comment:supermarket", "comment:weekly", "amount:5"}, 2)
+	classifier.Train([]string{"comment:bakery", "amount:3"}, 2)
+
+	assert.Equal(t, 5, classifier.SampleCount())
+
+	predictions := classifier.Predict([]string{"comment:netflix", "amount:4"})
+	assert.Equal(t, 2, len(predictions))
+	assert.Equal(t, int64(1), predictions[0].Label)
+	assert.Greater(t, predictions[0].Probability, 0.8)
+	assert.InDelta(t, 1.0, predictions[0].Probability+predictions[1].Probability, 1e-9)
+
+	predictions = classifier.Predict([]string{"comment:supermarket"})
+	assert.Equal(t, int64(2), predictions[0].Label)
+}
+
+func TestNaiveBayesClassifierPredict_UnknownFeatures(t *testing.T) {
+	classifier := NewNaiveBayesClassifier()
+	classifier.Train([]string{"comment:netflix"}, 1)
+	classifier.Train([]string{"comment:supermarket"}, 2)
+	classifier.Train([]string{"comment:bakery"}, 2)
+
+	predictions := classifier.Predict([]string{"comment:unknown"})
+	assert.Equal(t, int64(2), predictions[0].Label)
+	assert.InDelta(t, 2.0/3.0, predictions[0].Probability, 1e-9)
+}
+
+func TestNaiveBayesClassifierPredict_Empty(t *testing.T) {
+	classifier := NewNaiveBayesClassifier()
+	assert.Nil(t, classifier.Predict([]string{"comment:netflix"}))
+}
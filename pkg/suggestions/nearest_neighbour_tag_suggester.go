@@ -0,0 +1,127 @@
+package suggestions
+
+import (
+	"sort"
+)
+
+type tagSuggesterSample struct {
+	features map[string]bool
+	tagIds   []int64
+}
+
+type tagSuggesterNeighbour struct {
+	sample     *tagSuggesterSample
+	similarity float64
+}
+
+// NearestNeighbourTagSuggester represents a k-nearest neighbour tag suggester which uses jaccard similarity of features
+type NearestNeighbourTagSuggester struct {
+	samples []*tagSuggesterSample
+}
+
+// NewNearestNeighbourTagSuggester returns a new empty nearest neighbour tag suggester
+func NewNearestNeighbourTagSuggester() *NearestNeighbourTagSuggester {
+	return &NearestNeighbourTagSuggester{
+		samples: make([]*tagSuggesterSample, 0),
+	}
+}
+
+// Train adds a sample with the specified features and tag ids to the suggester
+func (s *NearestNeighbourTagSuggester) Train(features []string, tagIds []int64) {
+	featureSet := make(map[string]bool, len(features))
+
+	for i := 0; i < len(features); i++ {
+		featureSet[features[i]] = true
+	}
+
+	s.samples = append(s.samples, &tagSuggesterSample{
+		features: featureSet,
+		tagIds:   tagIds,
+	})
+}
+
+// Suggest returns the tag ids which are used by at least half of the k nearest neighbours of the specified features
+func (s *NearestNeighbourTagSuggester) Suggest(features []string, k int, minSimilarity float64) []int64 {
+	if len(s.samples) < 1 || len(features) < 1 || k < 1 {
+		return nil
+	}
+
+	featureSet := make(map[string]bool, len(features))
+
+	for i := 0; i < len(features); i++ {
+		featureSet[features[i]] = true
+	}
+
+	neighbours := make([]*tagSuggesterNeighbour, 0, k)
+
+	for i := 0; i < len(s.samples); i++ {
+		similarity := getJaccardSimilarity(featureSet, s.samples[i].features)
+
+		if similarity < minSimilarity || similarity <= 0 {
+			continue
+		}
+
+		neighbours = append(neighbours, &tagSuggesterNeighbour{
+			sample:     s.samples[i],
+			similarity: similarity,
+		})
+	}
+
+	if len(neighbours) < 1 {
+		return nil
+	}
+
+	sort.SliceStable(neighbours, func(i, j int) bool {
+		return neighbours[i].similarity > neighbours[j].similarity
+	})
+
+	if len(neighbours) > k {
+		neighbours = neighbours[:k]
+	}
+
+	tagCounts := make(map[int64]int)
+
+	for i := 0; i < len(neighbours); i++ {
+		tagIds := neighbours[i].sample.tagIds
+
+		for j := 0; j < len(tagIds); j++ {
+			tagCounts[tagIds[j]]++
+		}
+	}
+
+	suggestedTagIds := make([]int64, 0, len(tagCounts))
+
+	for tagId, count := range tagCounts {
+		if count*2 >= len(neighbours) {
+			suggestedTagIds = append(suggestedTagIds, tagId)
+		}
+	}
+
+	sort.Slice(suggestedTagIds, func(i, j int) bool {
+		if tagCounts[suggestedTagIds[i]] != tagCounts[suggestedTagIds[j]] {
+			return tagCounts[suggestedTagIds[i]] > tagCounts[suggestedTagIds[j]]
+		}
+
+		return suggestedTagIds[i] < suggestedTagIds[j]
+	})
+
+	return suggestedTagIds
+}
+
+func getJaccardSimilarity(features1 map[string]bool, features2 map[string]bool) float64 {
+	if len(features1) < 1 || len(features2) < 1 {
+		return 0
+	}
+
+	intersectionCount := 0
+
+	for feature := range features1 {
+		if features2[feature] {
+			intersectionCount++
+		}
+	}
+
+	unionCount := len(features1) + len(features2) - intersectionCount
+
+	return float64(intersectionCount) / float64(unionCount)
+}
@@ -0,0 +1,28 @@
+package suggestions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNearestNeighbourTagSuggesterSuggest(t *testing.T) {
+	suggester := NewNearestNeighbourTagSuggester()
+	suggester.Train([]string{"comment:netflix", "amount:4"}, []int64{1})
+	suggester.Train([]string{"comment:netflix", "amount:4"}, []int64{1, 2})
+	suggester.Train([]string{"comment:netflix", "amount:5"}, []int64{1})
+	suggester.Train([]string{"comment:supermarket", "amount:6"}, []int64{3})
+
+	assert.Equal(t, []int64{1}, suggester.Suggest([]string{"comment:netflix", "amount:4"}, 5, 0.2))
+	assert.Equal(t, []int64{3}, suggester.Suggest([]string{"comment:supermarket"}, 5, 0.2))
+	assert.Nil(t, suggester.Suggest([]string{"comment:bakery"}, 5, 0.2))
+}
+
+func TestNearestNeighbourTagSuggesterSuggest_UntaggedNeighbours(t *testing.T) {
+	suggester := NewNearestNeighbourTagSuggester()
+	suggester.Train([]string{"comment:coffee"}, nil)
+	suggester.Train([]string{"comment:coffee"}, nil)
+	suggester.Train([]string{"comment:coffee"}, []int64{1})
+
+	assert.Equal(t, 0, len(suggester.Suggest([]string{"comment:coffee"}, 5, 0.2)))
+}
@@ -0,0 +1,109 @@
+package suggestions
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+)
+
+const transactionTagSuggestionNeighbourCount = 5
+const transactionTagSuggestionMinSimilarity = 0.2
+
+// TransactionSuggestionModel represents the category and tag suggestion model trained by the transaction history of a user
+type TransactionSuggestionModel struct {
+	categoryClassifier *NaiveBayesClassifier
+	tagSuggester       *NearestNeighbourTagSuggester
+}
+
+// NewTransactionSuggestionModel returns a new empty transaction suggestion model
+func NewTransactionSuggestionModel() *TransactionSuggestionModel {
+	return &TransactionSuggestionModel{
+		categoryClassifier: NewNaiveBayesClassifier(),
+		tagSuggester:       NewNearestNeighbourTagSuggester(),
+	}
+}
+
+// Train adds a history transaction to the suggestion model
+func (m *TransactionSuggestionModel) Train(comment string, amount int64, accountId int64, categoryId int64, tagIds []int64) {
+	features := GetTransactionFeatures(comment, amount, accountId)
+
+	if categoryId > 0 {
+		m.categoryClassifier.Train(features, categoryId)
+	}
+
+	m.tagSuggester.Train(features, tagIds)
+}
+
+// SampleCount returns the count of history transactions which have been trained
+func (m *TransactionSuggestionModel) SampleCount() int {
+	return m.categoryClassifier.SampleCount()
+}
+
+// SuggestCategories returns all the category ids ordered by probability in descending order for the specified transaction
+func (m *TransactionSuggestionModel) SuggestCategories(comment string, amount int64, accountId int64) []*ClassifierPrediction {
+	return m.categoryClassifier.Predict(GetTransactionFeatures(comment, amount, accountId))
+}
+
+// SuggestTags returns the tag ids which are commonly used by the most similar history transactions of the specified transaction
+func (m *TransactionSuggestionModel) SuggestTags(comment string, amount int64, accountId int64) []int64 {
+	return m.tagSuggester.Suggest(GetTransactionFeatures(comment, amount, accountId), transactionTagSuggestionNeighbourCount, transactionTagSuggestionMinSimilarity)
+}
+
+// GetTransactionFeatures returns the features of the transaction, which contain the comment tokens, the amount bucket and the account
+func GetTransactionFeatures(comment string, amount int64, accountId int64) []string {
+	features := getCommentTokens(comment)
+
+	if amount < 0 {
+		amount = -amount
+	}
+
+	features = append(features, fmt.Sprintf("amount:%d", int(math.Log2(float64(amount)/100+1))))
+
+	if accountId > 0 {
+		features = append(features, fmt.Sprintf("account:%d", accountId))
+	}
+
+	return features
+}
+
+func getCommentTokens(comment string) []string {
+	tokens := make([]string, 0)
+	var currentToken strings.Builder
+
+	appendCurrentToken := func() {
+		token := currentToken.String()
+		currentToken.Reset()
+
+		if len([]rune(token)) < 2 || isAllDigits(token) {
+			return
+		}
+
+		tokens = append(tokens, "comment:"+token)
+	}
+
+	for _, ch := range strings.ToLower(comment) {
+		if unicode.Is(unicode.Han, ch) || unicode.Is(unicode.Hiragana, ch) || unicode.Is(unicode.Katakana, ch) || unicode.Is(unicode.Hangul, ch) {
+			appendCurrentToken()
+			tokens = append(tokens, "comment:"+string(ch))
+		} else if unicode.IsLetter(ch) || unicode.IsDigit(ch) {
+			currentToken.WriteRune(ch)
+		} else {
+			appendCurrentToken()
+		}
+	}
+
+	appendCurrentToken()
+
+	return tokens
+}
+
+func isAllDigits(str string) bool {
+	for _, ch := range str {
+		if !unicode.IsDigit(ch) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,36 @@
+package suggestions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetTransactionFeatures(t *testing.T) {
+	features := GetTransactionFeatures("NETFLIX.COM 866-579-7172 CA", 1599, 123)
+	assert.Equal(t, []string{"comment:netflix", "comment:com", "comment:ca", "amount:4", "account:123"}, features)
+
+	features = GetTransactionFeatures("星巴克咖啡", -3000, 0)
+	assert.Equal(t, []string{"comment:星", "comment:巴", "comment:克", "comment:咖", "comment:啡", "amount:4"}, features)
+
+	features = GetTransactionFeatures("", 0, 0)
+	assert.Equal(t, []string{"amount:0"}, features)
+}
+
+func TestTransactionSuggestionModel(t *testing.T) {
+	model := NewTransactionSuggestionModel()
+	model.Train("NETFLIX.COM", 1599, 1, 100, []int64{10})
+	model.Train("Netflix subscription", 1599, 1, 100, []int64{10})
+	model.Train("REWE Supermarket", 5320, 1, 200, nil)
+	model.Train("Lidl supermarket", 2380, 2, 200, nil)
+
+	assert.Equal(t, 4, model.SampleCount())
+
+	categoryPredictions := model.SuggestCategories("netflix.com", 1599, 1)
+	assert.Equal(t, int64(100), categoryPredictions[0].Label)
+
+	categoryPredictions = model.SuggestCategories("Aldi supermarket", 4100, 2)
+	assert.Equal(t, int64(200), categoryPredictions[0].Label)
+
+	assert.Equal(t, []int64{10}, model.SuggestTags("NETFLIX.COM", 1599, 1))
+}
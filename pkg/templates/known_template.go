@@ -4,6 +4,10 @@ type KnownTemplate string
 
 // Known templates
 const (
-	TEMPLATE_VERIFY_EMAIL   KnownTemplate = "email/verify_email"
-	TEMPLATE_PASSWORD_RESET KnownTemplate = "email/password_reset"
+	TEMPLATE_VERIFY_EMAIL              KnownTemplate = "email/verify_email"
+	TEMPLATE_PASSWORD_RESET            KnownTemplate = "email/password_reset"
+	TEMPLATE_BILL_REMINDER             KnownTemplate = "email/bill_reminder"
+	TEMPLATE_BILL_REMINDER_DIGEST      KnownTemplate = "email/bill_reminder_digest"
+	TEMPLATE_TRANSACTION_ANOMALY_ALERT KnownTemplate = "email/transaction_anomaly_alert"
+	TEMPLATE_SCHEDULED_REPORT          KnownTemplate = "email/scheduled_report"
 )
@@ -176,7 +176,7 @@ func getValidationErrorText(err validator.FieldError) string {
 		return errs.GetParameterInvalidUsernameMessage(fieldName)
 	case "validEmail":
 		return errs.GetParameterInvalidEmailMessage(fieldName)
-	case "validCurrency":
+	case "validCurrency", "validCurrencyCode", "validCustomCurrencyCode":
 		return errs.GetParameterInvalidCurrencyMessage(fieldName)
 	case "validHexRGBColor":
 		return errs.GetParameterInvalidHexRGBColorMessage(fieldName)
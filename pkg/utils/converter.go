@@ -102,25 +102,29 @@ func StringToFloat64(str string) (float64, error) {
 
 // FormatAmount returns a textual representation of amount
 func FormatAmount(value int64) string {
+	return FormatAmountWithPrecision(value, 2)
+}
+
+// FormatAmountWithPrecision returns a textual representation of amount which has the specified count of implied decimal places
+func FormatAmountWithPrecision(value int64, precision int) string {
 	displayAmount := Int64ToString(value)
+
+	if precision <= 0 {
+		return displayAmount
+	}
+
 	negative := displayAmount[0] == '-'
 
 	if negative {
 		displayAmount = displayAmount[1:]
 	}
 
-	integer := SubString(displayAmount, 0, len(displayAmount)-2)
-	decimals := SubString(displayAmount, -2, 2)
-
-	if integer == "" {
-		integer = "0"
+	if len(displayAmount) <= precision {
+		displayAmount = strings.Repeat("0", precision-len(displayAmount)+1) + displayAmount
 	}
 
-	if len(decimals) == 0 {
-		decimals = "00"
-	} else if len(decimals) == 1 {
-		decimals = "0" + decimals
-	}
+	integer := displayAmount[0 : len(displayAmount)-precision]
+	decimals := displayAmount[len(displayAmount)-precision:]
 
 	if negative {
 		return "-" + integer + "." + decimals
@@ -131,10 +135,19 @@ func FormatAmount(value int64) string {
 
 // ParseAmount parses a textual representation of amount
 func ParseAmount(amount string) (int64, error) {
+	return ParseAmountWithPrecision(amount, 2)
+}
+
+// ParseAmountWithPrecision parses a textual representation of amount to the amount which has the specified count of implied decimal places
+func ParseAmountWithPrecision(amount string, precision int) (int64, error) {
 	if len(amount) < 1 {
 		return 0, nil
 	}
 
+	if precision < 0 {
+		precision = 0
+	}
+
 	sign := int64(1)
 
 	if amount[0] == '-' {
@@ -172,7 +185,7 @@ func ParseAmount(amount string) (int64, error) {
 	}
 
 	if len(items) == 2 {
-		if len(items[1]) > 2 {
+		if len(items[1]) > precision {
 			return 0, errs.ErrNumberInvalid
 		}
 
@@ -186,10 +199,16 @@ func ParseAmount(amount string) (int64, error) {
 			return 0, errs.ErrNumberInvalid
 		}
 
-		if len(items[1]) == 1 {
+		for i := len(items[1]); i < precision; i++ {
 			decimals = decimals * 10
 		}
 	}
 
-	return sign*integer*100 + sign*decimals, nil
+	multiplier := int64(1)
+
+	for i := 0; i < precision; i++ {
+		multiplier = multiplier * 10
+	}
+
+	return sign*integer*multiplier + sign*decimals, nil
 }
@@ -173,6 +173,36 @@ func TestFormatAmount(t *testing.T) {
 	assert.Equal(t, expectedValue, actualValue)
 }
 
+func TestFormatAmountWithPrecision(t *testing.T) {
+	expectedValue := "0"
+	actualValue := FormatAmountWithPrecision(0, 0)
+	assert.Equal(t, expectedValue, actualValue)
+
+	expectedValue = "-123"
+	actualValue = FormatAmountWithPrecision(-123, 0)
+	assert.Equal(t, expectedValue, actualValue)
+
+	expectedValue = "0.00000000"
+	actualValue = FormatAmountWithPrecision(0, 8)
+	assert.Equal(t, expectedValue, actualValue)
+
+	expectedValue = "0.00000001"
+	actualValue = FormatAmountWithPrecision(1, 8)
+	assert.Equal(t, expectedValue, actualValue)
+
+	expectedValue = "-0.00012345"
+	actualValue = FormatAmountWithPrecision(-12345, 8)
+	assert.Equal(t, expectedValue, actualValue)
+
+	expectedValue = "1.23456789"
+	actualValue = FormatAmountWithPrecision(123456789, 8)
+	assert.Equal(t, expectedValue, actualValue)
+
+	expectedValue = "-12.345"
+	actualValue = FormatAmountWithPrecision(-12345, 3)
+	assert.Equal(t, expectedValue, actualValue)
+}
+
 func TestParseAmount(t *testing.T) {
 	expectedValue := int64(0)
 	actualValue, err := ParseAmount("")
@@ -295,6 +325,49 @@ func TestParseAmount(t *testing.T) {
 	assert.Equal(t, expectedValue, actualValue)
 }
 
+func TestParseAmountWithPrecision(t *testing.T) {
+	expectedValue := int64(123)
+	actualValue, err := ParseAmountWithPrecision("123", 0)
+	assert.Nil(t, err)
+	assert.Equal(t, expectedValue, actualValue)
+
+	expectedValue = int64(-123)
+	actualValue, err = ParseAmountWithPrecision("-123", 0)
+	assert.Nil(t, err)
+	assert.Equal(t, expectedValue, actualValue)
+
+	expectedValue = int64(1)
+	actualValue, err = ParseAmountWithPrecision("0.00000001", 8)
+	assert.Nil(t, err)
+	assert.Equal(t, expectedValue, actualValue)
+
+	expectedValue = int64(123456789)
+	actualValue, err = ParseAmountWithPrecision("1.23456789", 8)
+	assert.Nil(t, err)
+	assert.Equal(t, expectedValue, actualValue)
+
+	expectedValue = int64(-150000000)
+	actualValue, err = ParseAmountWithPrecision("-1.5", 8)
+	assert.Nil(t, err)
+	assert.Equal(t, expectedValue, actualValue)
+
+	expectedValue = int64(12000)
+	actualValue, err = ParseAmountWithPrecision("12", 3)
+	assert.Nil(t, err)
+	assert.Equal(t, expectedValue, actualValue)
+}
+
+func TestParseAmountWithPrecision_InvalidAmount(t *testing.T) {
+	_, err := ParseAmountWithPrecision("1.5", 0)
+	assert.NotNil(t, err)
+
+	_, err = ParseAmountWithPrecision("0.000000001", 8)
+	assert.NotNil(t, err)
+
+	_, err = ParseAmountWithPrecision("1.2345", 3)
+	assert.NotNil(t, err)
+}
+
 func TestParseAmount_InvalidAmount(t *testing.T) {
 	_, err := ParseAmount("-")
 	assert.NotNil(t, err)
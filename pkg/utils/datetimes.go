@@ -103,6 +103,16 @@ func FormatUnixTimeToNumericYearMonth(unixTime int64, timezone *time.Location) i
 	return int32(t.Year())*100 + int32(t.Month())
 }
 
+// FormatTimeToNumericDate returns numeric year, month and day of specified time in its location
+func FormatTimeToNumericDate(t time.Time) int32 {
+	return int32(t.Year())*10000 + int32(t.Month())*100 + int32(t.Day())
+}
+
+// FormatNumericDateToLongDate returns a textual representation (YYYY-MM-DD) of the numeric date (YYYYMMDD)
+func FormatNumericDateToLongDate(date int32) string {
+	return fmt.Sprintf("%04d-%02d-%02d", date/10000, date/100%100, date%100)
+}
+
 // FormatUnixTimeToNumericLocalDateTime returns numeric year, month, day, hour, minute and second of specified unix time
 func FormatUnixTimeToNumericLocalDateTime(unixTime int64, timezone *time.Location) int64 {
 	t := parseFromUnixTime(unixTime)
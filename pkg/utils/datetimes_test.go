@@ -88,6 +88,30 @@ func TestFormatUnixTimeToNumericYearMonth(t *testing.T) {
 	assert.Equal(t, expectedValue, actualValue)
 }
 
+func TestFormatTimeToNumericDate(t *testing.T) {
+	unixTime := int64(1617228083)
+	utcTimezone := time.FixedZone("Test Timezone", 0)      // UTC
+	utc8Timezone := time.FixedZone("Test Timezone", 28800) // UTC+8
+
+	expectedValue := int32(20210331)
+	actualValue := FormatTimeToNumericDate(time.Unix(unixTime, 0).In(utcTimezone))
+	assert.Equal(t, expectedValue, actualValue)
+
+	expectedValue = int32(20210401)
+	actualValue = FormatTimeToNumericDate(time.Unix(unixTime, 0).In(utc8Timezone))
+	assert.Equal(t, expectedValue, actualValue)
+}
+
+func TestFormatNumericDateToLongDate(t *testing.T) {
+	expectedValue := "2021-04-01"
+	actualValue := FormatNumericDateToLongDate(20210401)
+	assert.Equal(t, expectedValue, actualValue)
+
+	expectedValue = "0999-12-31"
+	actualValue = FormatNumericDateToLongDate(9991231)
+	assert.Equal(t, expectedValue, actualValue)
+}
+
 func TestFormatUnixTimeToNumericLocalDateTime(t *testing.T) {
 	unixTime := int64(1617228083)
 	utcTimezone := time.FixedZone("Test Timezone", 0)      // UTC
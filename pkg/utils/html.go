@@ -0,0 +1,91 @@
+package utils
+
+import (
+	"bytes"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// HtmlTable represents the caption and the text of all cells of a html table
+type HtmlTable struct {
+	Caption string
+	Rows    [][]string
+}
+
+// ParseHtmlTables returns all the tables in the html content, the text of each cell is trimmed and the whitespaces in it are collapsed
+func ParseHtmlTables(content []byte) ([]*HtmlTable, error) {
+	document, err := html.Parse(bytes.NewReader(content))
+
+	if err != nil {
+		return nil, err
+	}
+
+	tables := make([]*HtmlTable, 0)
+	findHtmlTables(document, &tables)
+
+	return tables, nil
+}
+
+func findHtmlTables(node *html.Node, tables *[]*HtmlTable) {
+	if node.Type == html.ElementNode && node.Data == "table" {
+		table := &HtmlTable{
+			Rows: make([][]string, 0),
+		}
+
+		*tables = append(*tables, table)
+		parseHtmlTable(node, table, tables)
+		return
+	}
+
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		findHtmlTables(child, tables)
+	}
+}
+
+func parseHtmlTable(node *html.Node, table *HtmlTable, tables *[]*HtmlTable) {
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type != html.ElementNode {
+			continue
+		}
+
+		if child.Data == "caption" {
+			table.Caption = getHtmlNodeText(child)
+		} else if child.Data == "tr" {
+			row := make([]string, 0)
+
+			for cell := child.FirstChild; cell != nil; cell = cell.NextSibling {
+				if cell.Type == html.ElementNode && (cell.Data == "td" || cell.Data == "th") {
+					row = append(row, getHtmlNodeText(cell))
+
+					for cellChild := cell.FirstChild; cellChild != nil; cellChild = cellChild.NextSibling {
+						findHtmlTables(cellChild, tables)
+					}
+				}
+			}
+
+			table.Rows = append(table.Rows, row)
+		} else if child.Data == "thead" || child.Data == "tbody" || child.Data == "tfoot" {
+			parseHtmlTable(child, table, tables)
+		}
+	}
+}
+
+func getHtmlNodeText(node *html.Node) string {
+	var builder strings.Builder
+	appendHtmlNodeText(node, &builder)
+
+	return strings.Join(strings.Fields(builder.String()), " ")
+}
+
+func appendHtmlNodeText(node *html.Node, builder *strings.Builder) {
+	if node.Type == html.TextNode {
+		builder.WriteString(node.Data)
+		builder.WriteString(" ")
+		return
+	}
+
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		appendHtmlNodeText(child, builder)
+	}
+}
@@ -0,0 +1,48 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseHtmlTables(t *testing.T) {
+	content := "<html><body>" +
+		"<p>Test</p>" +
+		"<table>\n" +
+		"  <caption> Test   Caption </caption>\n" +
+		"  <thead><tr><th>Name</th><th>Value</th></tr></thead>\n" +
+		"  <tbody>\n" +
+		"    <tr><td> <b>Foo</b> Bar </td><td>1.23</td></tr>\n" +
+		"    <tr><td>Baz</td><td></td></tr>\n" +
+		"  </tbody>\n" +
+		"</table>" +
+		"<div><table><tr><td>Other</td></tr></table></div>" +
+		"</body></html>"
+
+	actualTables, err := ParseHtmlTables([]byte(content))
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(actualTables))
+
+	assert.Equal(t, "Test Caption", actualTables[0].Caption)
+	assert.Equal(t, [][]string{{"Name", "Value"}, {"Foo Bar", "1.23"}, {"Baz", ""}}, actualTables[0].Rows)
+
+	assert.Equal(t, "", actualTables[1].Caption)
+	assert.Equal(t, [][]string{{"Other"}}, actualTables[1].Rows)
+}
+
+func TestParseHtmlTables_NestedTable(t *testing.T) {
+	content := "<table><tr><td>Outer<table><tr><td>Inner</td></tr></table></td></tr></table>"
+
+	actualTables, err := ParseHtmlTables([]byte(content))
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(actualTables))
+	assert.Equal(t, [][]string{{"Outer Inner"}}, actualTables[0].Rows)
+	assert.Equal(t, [][]string{{"Inner"}}, actualTables[1].Rows)
+}
+
+func TestParseHtmlTables_NoTable(t *testing.T) {
+	actualTables, err := ParseHtmlTables([]byte("<html><body><p>Test</p></body></html>"))
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(actualTables))
+}
@@ -3,6 +3,7 @@ package utils
 import (
 	"bytes"
 	"net"
+	"strings"
 
 	"github.com/mayswind/ezbookkeeping/pkg/errs"
 )
@@ -62,3 +63,36 @@ func GetLocalIPAddresses() ([]net.IP, error) {
 
 	return localAddrs, nil
 }
+
+// IsPrivateNetworkIP returns whether the ip address is a loopback, private, link-local or unspecified address
+func IsPrivateNetworkIP(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsUnspecified()
+}
+
+// IsPrivateNetworkHost returns whether the host name is localhost, or is (or is resolved to) a loopback, private, link-local or unspecified address,
+// the host name which cannot be resolved is not treated as private network host
+func IsPrivateNetworkHost(host string) bool {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return true
+	}
+
+	if ip := net.ParseIP(host); ip != nil {
+		return IsPrivateNetworkIP(ip)
+	}
+
+	ips, err := net.LookupIP(host)
+
+	if err != nil {
+		return false
+	}
+
+	for i := 0; i < len(ips); i++ {
+		if IsPrivateNetworkIP(ips[i]) {
+			return true
+		}
+	}
+
+	return false
+}
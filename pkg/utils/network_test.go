@@ -0,0 +1,36 @@
+package utils
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsPrivateNetworkIP(t *testing.T) {
+	assert.True(t, IsPrivateNetworkIP(net.ParseIP("127.0.0.1")))
+	assert.True(t, IsPrivateNetworkIP(net.ParseIP("10.1.2.3")))
+	assert.True(t, IsPrivateNetworkIP(net.ParseIP("172.16.0.1")))
+	assert.True(t, IsPrivateNetworkIP(net.ParseIP("192.168.1.1")))
+	assert.True(t, IsPrivateNetworkIP(net.ParseIP("169.254.169.254")))
+	assert.True(t, IsPrivateNetworkIP(net.ParseIP("0.0.0.0")))
+	assert.True(t, IsPrivateNetworkIP(net.ParseIP("::1")))
+	assert.True(t, IsPrivateNetworkIP(net.ParseIP("fd00::1")))
+	assert.True(t, IsPrivateNetworkIP(net.ParseIP("fe80::1")))
+	assert.True(t, IsPrivateNetworkIP(net.ParseIP("::ffff:127.0.0.1")))
+
+	assert.False(t, IsPrivateNetworkIP(net.ParseIP("8.8.8.8")))
+	assert.False(t, IsPrivateNetworkIP(net.ParseIP("172.32.0.1")))
+	assert.False(t, IsPrivateNetworkIP(net.ParseIP("2001:4860:4860::8888")))
+}
+
+func TestIsPrivateNetworkHost(t *testing.T) {
+	assert.True(t, IsPrivateNetworkHost("localhost"))
+	assert.True(t, IsPrivateNetworkHost("LOCALHOST."))
+	assert.True(t, IsPrivateNetworkHost("api.localhost"))
+	assert.True(t, IsPrivateNetworkHost("169.254.169.254"))
+	assert.True(t, IsPrivateNetworkHost("::1"))
+
+	assert.False(t, IsPrivateNetworkHost("8.8.8.8"))
+	assert.False(t, IsPrivateNetworkHost("notlocalhost.invalid"))
+}
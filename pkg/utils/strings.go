@@ -3,6 +3,7 @@ package utils
 import (
 	"crypto/aes"
 	"crypto/cipher"
+	"crypto/hmac"
 	"crypto/md5"
 	"crypto/rand"
 	"crypto/sha256"
@@ -91,6 +92,25 @@ func ContainsOnlyOneRune(s string, r rune) bool {
 	return true
 }
 
+// GetNormalizedPayeeName returns the lower case letters of the source string, other characters (e.g. digits and punctuations) are treated as word separators,
+// so the comments like "NETFLIX #2024-05" and "Netflix 2024/06" have the same payee name
+func GetNormalizedPayeeName(s string) string {
+	var builder strings.Builder
+	lastIsSpace := true
+
+	for _, ch := range strings.ToLower(s) {
+		if unicode.IsLetter(ch) {
+			builder.WriteRune(ch)
+			lastIsSpace = false
+		} else if !lastIsSpace {
+			builder.WriteRune(' ')
+			lastIsSpace = true
+		}
+	}
+
+	return strings.TrimSpace(builder.String())
+}
+
 // GetRandomString returns a random string of which length is n
 func GetRandomString(n int) (string, error) {
 	var result = make([]byte, n)
@@ -155,6 +175,13 @@ func MD5EncodeToString(data []byte) string {
 	return hex.EncodeToString(hash)
 }
 
+// HmacSHA256EncodeToString returns a hex encoded hmac-sha256 hash of the data with specified key
+func HmacSHA256EncodeToString(key []byte, data []byte) string {
+	m := hmac.New(sha256.New, key)
+	m.Write(data)
+	return hex.EncodeToString(m.Sum(nil))
+}
+
 // AESGCMEncrypt returns a encrypted string by aes-gcm
 func AESGCMEncrypt(key []byte, plainText []byte) ([]byte, error) {
 	block, err := aes.NewCipher(key)
@@ -90,6 +90,14 @@ func TestContainsOnlyOneRune(t *testing.T) {
 	assert.Equal(t, false, actualValue)
 }
 
+func TestGetNormalizedPayeeName(t *testing.T) {
+	assert.Equal(t, "netflix", GetNormalizedPayeeName("NETFLIX #2024-05"))
+	assert.Equal(t, "netflix", GetNormalizedPayeeName(" Netflix  2024/06 "))
+	assert.Equal(t, "acme corp salary", GetNormalizedPayeeName("ACME Corp. salary"))
+	assert.Equal(t, "", GetNormalizedPayeeName("12345"))
+	assert.Equal(t, "", GetNormalizedPayeeName(""))
+}
+
 func TestGetRandomString(t *testing.T) {
 	actualValue, err := GetRandomString(10)
 	assert.Equal(t, nil, err)
@@ -133,6 +141,16 @@ func TestMD5EncodeToString(t *testing.T) {
 	assert.Equal(t, expectedValue, actualValue)
 }
 
+func TestHmacSHA256EncodeToString(t *testing.T) {
+	expectedValue := "f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8"
+	actualValue := HmacSHA256EncodeToString([]byte("key"), []byte("The quick brown fox jumps over the lazy dog"))
+	assert.Equal(t, expectedValue, actualValue)
+
+	expectedValue = "b613679a0814d9ec772f95d778c35fc5ff1697c493715653c6c712144292c5ad"
+	actualValue = HmacSHA256EncodeToString([]byte(""), []byte(""))
+	assert.Equal(t, expectedValue, actualValue)
+}
+
 func TestEncodePassword(t *testing.T) {
 	password := "foobar"
 	salt := "salt"
@@ -14,4 +14,14 @@ const (
 	UUID_TYPE_TAG_INDEX   UuidType = 6
 	UUID_TYPE_TEMPLATE    UuidType = 7
 	UUID_TYPE_PICTURE     UuidType = 8
+	UUID_TYPE_ASSERTION   UuidType = 9
+	UUID_TYPE_RULE        UuidType = 10
+	UUID_TYPE_WEBHOOK     UuidType = 11
+	UUID_TYPE_DELIVERY    UuidType = 12
+	UUID_TYPE_ANOMALY     UuidType = 13
+	UUID_TYPE_REPORT      UuidType = 14
+	UUID_TYPE_RATE        UuidType = 15
+
+	// UUID_TYPE_CURRENCY shares the same type with exchange rates, for all the 16 uuid types have been used
+	UUID_TYPE_CURRENCY = UUID_TYPE_RATE
 )
@@ -184,3 +184,46 @@ func ValidCurrency(fl validator.FieldLevel) bool {
 
 	return false
 }
+
+// ValidCurrencyCode returns whether the given currency is a valid currency in ISO 4217 or a valid user defined currency code
+func ValidCurrencyCode(fl validator.FieldLevel) bool {
+	if value, ok := fl.Field().Interface().(string); ok {
+		if value == ParentAccountCurrencyPlaceholder {
+			return true
+		}
+
+		if _, ok := AllCurrencyNames[value]; ok {
+			return true
+		}
+
+		return IsValidCustomCurrencyCode(value)
+	}
+
+	return false
+}
+
+// ValidCustomCurrencyCode returns whether the given currency is a valid user defined currency code
+func ValidCustomCurrencyCode(fl validator.FieldLevel) bool {
+	if value, ok := fl.Field().Interface().(string); ok {
+		return IsValidCustomCurrencyCode(value)
+	}
+
+	return false
+}
+
+// IsValidCustomCurrencyCode returns whether the given code can be used as a user defined currency code,
+// which should be 3 uppercase letters or digits and not be any currency in ISO 4217
+func IsValidCustomCurrencyCode(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+
+	for i := 0; i < len(code); i++ {
+		if !(code[i] >= 'A' && code[i] <= 'Z') && !(code[i] >= '0' && code[i] <= '9') {
+			return false
+		}
+	}
+
+	_, exists := AllCurrencyNames[code]
+	return !exists
+}
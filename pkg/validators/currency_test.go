@@ -39,3 +39,72 @@ func TestInvalidCurrency(t *testing.T) {
 	err = validate.Var("-", "validCurrency")
 	assert.NotNil(t, err)
 }
+
+func TestValidCurrencyCode(t *testing.T) {
+	validate := validator.New()
+	err := validate.RegisterValidation("validCurrencyCode", ValidCurrencyCode)
+	assert.Nil(t, err)
+
+	err = validate.Var("USD", "validCurrencyCode")
+	assert.Nil(t, err)
+
+	err = validate.Var("---", "validCurrencyCode")
+	assert.Nil(t, err)
+
+	err = validate.Var("BTC", "validCurrencyCode")
+	assert.Nil(t, err)
+
+	err = validate.Var("PT1", "validCurrencyCode")
+	assert.Nil(t, err)
+}
+
+func TestInvalidCurrencyCode(t *testing.T) {
+	validate := validator.New()
+	err := validate.RegisterValidation("validCurrencyCode", ValidCurrencyCode)
+	assert.Nil(t, err)
+
+	err = validate.Var("btc", "validCurrencyCode")
+	assert.NotNil(t, err)
+
+	err = validate.Var("BTCX", "validCurrencyCode")
+	assert.NotNil(t, err)
+
+	err = validate.Var("B-C", "validCurrencyCode")
+	assert.NotNil(t, err)
+
+	err = validate.Var("", "validCurrencyCode")
+	assert.NotNil(t, err)
+}
+
+func TestValidCustomCurrencyCode(t *testing.T) {
+	validate := validator.New()
+	err := validate.RegisterValidation("validCustomCurrencyCode", ValidCustomCurrencyCode)
+	assert.Nil(t, err)
+
+	err = validate.Var("BTC", "validCustomCurrencyCode")
+	assert.Nil(t, err)
+
+	err = validate.Var("ETH", "validCustomCurrencyCode")
+	assert.Nil(t, err)
+
+	err = validate.Var("PT1", "validCustomCurrencyCode")
+	assert.Nil(t, err)
+}
+
+func TestInvalidCustomCurrencyCode(t *testing.T) {
+	validate := validator.New()
+	err := validate.RegisterValidation("validCustomCurrencyCode", ValidCustomCurrencyCode)
+	assert.Nil(t, err)
+
+	err = validate.Var("USD", "validCustomCurrencyCode")
+	assert.NotNil(t, err)
+
+	err = validate.Var("---", "validCustomCurrencyCode")
+	assert.NotNil(t, err)
+
+	err = validate.Var("eth", "validCustomCurrencyCode")
+	assert.NotNil(t, err)
+
+	err = validate.Var("BT", "validCustomCurrencyCode")
+	assert.NotNil(t, err)
+}
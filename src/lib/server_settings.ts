@@ -31,6 +31,10 @@ export function isDataImportingEnabled(): boolean {
     return getServerSetting('i') === 1;
 }
 
+export function isWebhookEnabled(): boolean {
+    return getServerSetting('w') === 1;
+}
+
 export function getLoginPageTips(): Record<string, string>{
     return getServerSetting('lpt') as Record<string, string>;
 }